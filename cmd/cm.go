@@ -3,12 +3,21 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
-	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
-	"github.com/open-cluster-management/cm-cli/pkg/cmd/verbs"
+	"github.com/open-cluster-management/cm-cli/pkg/audit"
+	cmdroot "github.com/open-cluster-management/cm-cli/pkg/cmd/root"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/telemetry"
+	"github.com/open-cluster-management/cm-cli/pkg/tracing"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -16,25 +25,33 @@ func main() {
 	flags := pflag.NewFlagSet("cm", pflag.ExitOnError)
 	pflag.CommandLine = flags
 
-	root := newCmdCMVerbs(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
-	if err := root.Execute(); err != nil {
+	streams := genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
+	root := cmdroot.NewCmdRoot(streams)
+	//The first Ctrl+C cancels the context to abort the waits, a second one kills the process
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	cmd, _, ferr := root.Find(os.Args[1:])
+	if ferr != nil {
+		cmd = root
+	}
+	audit.Command = strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), root.Name()))
+
+	tracing.Start(audit.Command)
+	start := time.Now()
+	err := root.ExecuteContext(ctx)
+	telemetry.Record(cmd, time.Since(start), err)
+	tracing.Finish(streams.ErrOut, err)
+	stop()
+	if err != nil {
+		err = cmderrors.Classify(err)
+		fmt.Fprintf(streams.ErrOut, "Error: %s\n", err.Error())
+		if cmderrors.ExplainErrors {
+			cmderrors.Print(streams.ErrOut, err)
+		}
 		os.Exit(1)
 	}
 }
-
-// NewCmdNamespace provides a cobra command wrapping NamespaceOptions
-func newCmdCMVerbs(streams genericclioptions.IOStreams) *cobra.Command {
-	cmd := &cobra.Command{Use: "cm"}
-	cmd.AddCommand(
-		verbs.NewVerb("create", streams),
-		// verbs.NewVerb("get", streams),
-		// verbs.NewVerb("update", streams),
-		verbs.NewVerb("delete", streams),
-		// verbs.NewVerb("list", streams),
-		verbs.NewVerb("applier", streams),
-		verbs.NewVerb("attach", streams),
-		verbs.NewVerb("detach", streams),
-	)
-
-	return cmd
-}
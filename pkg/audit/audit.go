@@ -0,0 +1,261 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/config"
+	"k8s.io/client-go/rest"
+)
+
+// Command is the command recorded in the entries, set once the command to execute is known
+var Command string
+
+// WarnOut receives the warning printed when an entry can not be written, the audit never fails a command.
+// It is set to the error stream of the root command.
+var WarnOut io.Writer = os.Stderr
+
+// sendTimeout bounds the time spent to post an entry to an http sink
+var sendTimeout = 2 * time.Second
+
+// verbs are the mutating http methods and the verb recorded for each of them
+var verbs = map[string]string{
+	http.MethodPost:   "create",
+	http.MethodPut:    "update",
+	http.MethodPatch:  "patch",
+	http.MethodDelete: "delete",
+}
+
+// Entry is a mutating request sent by the cli
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Command string    `json:"command,omitempty"`
+	Verb    string    `json:"verb"`
+	// Resource is the path of the resource, or of its collection completed by the name for a create
+	Resource string `json:"resource"`
+	Hub      string `json:"hub"`
+	// User is the user of the kubeconfig context, LocalUser the account running the cli
+	User      string `json:"user,omitempty"`
+	LocalUser string `json:"localUser,omitempty"`
+	DryRun    bool   `json:"dryRun,omitempty"`
+	// Result is the http status of the response or the error of the request
+	Result string `json:"result"`
+}
+
+// Sink receives the audit entries
+type Sink interface {
+	Write(e Entry) error
+}
+
+// FileSink appends the entries to a file, one json entry per line
+type FileSink struct {
+	Path string
+	lock sync.Mutex
+}
+
+// HTTPSink posts each entry as json to an endpoint
+type HTTPSink struct {
+	Endpoint string
+}
+
+// NewSink returns the sink of the configuration, nil if the audit is disabled
+func NewSink(c config.Audit) (Sink, error) {
+	if c.Disabled {
+		return nil, nil
+	}
+	if strings.HasPrefix(c.Sink, "http://") || strings.HasPrefix(c.Sink, "https://") {
+		return &HTTPSink{Endpoint: c.Sink}, nil
+	}
+	if c.Sink != "" {
+		return &FileSink{Path: c.Sink}, nil
+	}
+	path, err := config.DefaultAuditPath()
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{Path: path}, nil
+}
+
+// LoadSink returns the sink of the user configuration
+func LoadSink() (Sink, error) {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	c, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSink(c.Audit)
+}
+
+// Write appends the entry to the file, created with the permissions of the current user only
+func (s *FileSink) Write(e Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Clean(s.Path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Write posts the entry to the endpoint
+func (s *HTTPSink) Write(e Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: sendTimeout}
+	resp, err := client.Post(s.Endpoint, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("audit endpoint %s returned %s", s.Endpoint, resp.Status)
+	}
+	return nil
+}
+
+// ReadFile reads the entries of an audit log file
+func ReadFile(path string) ([]Entry, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	entries := make([]Entry, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		e := Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("invalid audit entry at line %d of %s: %s", line, path, err.Error())
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Enable records the mutating requests sent with the rest config in the sink of the user configuration,
+// the audit never fails a command so nothing is recorded if the configuration can not be read
+func Enable(restConfig *rest.Config, user string) {
+	sink, err := LoadSink()
+	if err != nil {
+		return
+	}
+	WrapConfig(restConfig, sink, user)
+}
+
+// WrapConfig records in the sink the mutating requests sent with the rest config,
+// user is the user of the kubeconfig context. Nothing is recorded if the sink is nil.
+func WrapConfig(restConfig *rest.Config, sink Sink, user string) {
+	if sink == nil {
+		return
+	}
+	rt := &roundTripper{sink: sink, hub: restConfig.Host, user: user, localUser: localUser()}
+	restConfig.Wrap(func(next http.RoundTripper) http.RoundTripper {
+		wrapped := *rt
+		wrapped.next = next
+		return &wrapped
+	})
+}
+
+type roundTripper struct {
+	next      http.RoundTripper
+	sink      Sink
+	hub       string
+	user      string
+	localUser string
+}
+
+func (r *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	verb, ok := verbs[req.Method]
+	//The reviews are posted to check the permissions, they do not change the hub
+	if !ok || strings.HasSuffix(req.URL.Path, "reviews") {
+		return r.next.RoundTrip(req)
+	}
+	e := Entry{
+		Command:   Command,
+		Verb:      verb,
+		Resource:  resourcePath(req),
+		Hub:       r.hub,
+		User:      r.user,
+		LocalUser: r.localUser,
+		DryRun:    req.URL.Query().Get("dryRun") != "",
+	}
+	resp, err := r.next.RoundTrip(req)
+	e.Time = time.Now().UTC()
+	if err != nil {
+		e.Result = err.Error()
+	} else {
+		e.Result = resp.Status
+	}
+	if werr := r.sink.Write(e); werr != nil {
+		fmt.Fprintf(WarnOut, "Warning: unable to write the audit entry: %s\n", werr.Error())
+	}
+	return resp, err
+}
+
+// resourcePath returns the path of the request, completed by the name of the resource for a create
+// as the name is only in the body
+func resourcePath(req *http.Request) string {
+	if req.Method != http.MethodPost || req.GetBody == nil {
+		return req.URL.Path
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return req.URL.Path
+	}
+	defer body.Close()
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return req.URL.Path
+	}
+	obj := struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(b, &obj); err != nil || obj.Metadata.Name == "" {
+		return req.URL.Path
+	}
+	return req.URL.Path + "/" + obj.Metadata.Name
+}
+
+// localUser returns the account running the cli
+func localUser() string {
+	u, err := user.Current()
+	if err != nil {
+		return ""
+	}
+	return u.Username
+}
@@ -0,0 +1,134 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/config"
+	"k8s.io/client-go/rest"
+)
+
+func TestNewSink(t *testing.T) {
+	tests := []struct {
+		name     string
+		audit    config.Audit
+		wantNil  bool
+		wantHTTP bool
+		wantPath string
+	}{
+		{name: "Disabled", audit: config.Audit{Disabled: true}, wantNil: true},
+		{name: "File", audit: config.Audit{Sink: "/var/log/cm/audit.log"}, wantPath: "/var/log/cm/audit.log"},
+		{name: "HTTP", audit: config.Audit{Sink: "https://audit.example.com"}, wantHTTP: true},
+		{name: "Default", audit: config.Audit{}, wantPath: filepath.Join(".cm", "audit.log")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink, err := NewSink(tt.audit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch s := sink.(type) {
+			case nil:
+				if !tt.wantNil {
+					t.Error("NewSink() expected a sink")
+				}
+			case *HTTPSink:
+				if !tt.wantHTTP {
+					t.Errorf("NewSink() got the http sink %s", s.Endpoint)
+				}
+			case *FileSink:
+				if !strings.HasSuffix(s.Path, tt.wantPath) {
+					t.Errorf("NewSink() got the file %s, want %s", s.Path, tt.wantPath)
+				}
+			}
+		})
+	}
+}
+
+func TestWrapConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "audit", "audit.log")
+	config := &rest.Config{Host: server.URL}
+	WrapConfig(config, &FileSink{Path: path}, "admin")
+	transport, err := rest.TransportFor(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: transport}
+	Command = "attach cluster"
+	defer func() { Command = "" }()
+
+	requests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{method: http.MethodGet, path: "/api/v1/namespaces/cluster1"},
+		{method: http.MethodPost, path: "/api/v1/namespaces", body: `{"metadata":{"name":"cluster1"}}`},
+		{method: http.MethodPost, path: "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews", body: `{}`},
+		{method: http.MethodPatch, path: "/apis/cluster.open-cluster-management.io/v1/managedclusters/cluster1?dryRun=All", body: `{}`},
+		{method: http.MethodDelete, path: "/api/v1/namespaces/cluster2"},
+	}
+	for _, r := range requests {
+		req, err := http.NewRequest(r.method, server.URL+r.path, bytes.NewReader([]byte(r.body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	entries, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		b, _ := json.Marshal(entries)
+		t.Fatalf("ReadFile() got %d entries, want 3: %s", len(entries), string(b))
+	}
+	want := []Entry{
+		{Verb: "create", Resource: "/api/v1/namespaces/cluster1", Result: "200 OK"},
+		{Verb: "patch", Resource: "/apis/cluster.open-cluster-management.io/v1/managedclusters/cluster1", DryRun: true, Result: "200 OK"},
+		{Verb: "delete", Resource: "/api/v1/namespaces/cluster2", Result: "404 Not Found"},
+	}
+	for i, e := range entries {
+		if e.Verb != want[i].Verb || e.Resource != want[i].Resource || e.DryRun != want[i].DryRun || e.Result != want[i].Result {
+			t.Errorf("entry %d = %+v, want %+v", i, e, want[i])
+		}
+		if e.Command != "attach cluster" || e.Hub != server.URL || e.User != "admin" || e.Time.IsZero() {
+			t.Errorf("entry %d = %+v, want the command, hub, user and time", i, e)
+		}
+	}
+}
+
+func TestHTTPSink_Write(t *testing.T) {
+	var got Entry
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	if err := (&HTTPSink{Endpoint: server.URL}).Write(Entry{Verb: "delete"}); err != nil {
+		t.Fatal(err)
+	}
+	if got.Verb != "delete" {
+		t.Errorf("got %+v", got)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clients
+
+import (
+	"sync"
+
+	"github.com/open-cluster-management/cm-cli/pkg/audit"
+	"github.com/open-cluster-management/cm-cli/pkg/tracing"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	//Register the oidc auth provider so the users authenticated through SSO can use the cli,
+	//the exec credential plugins are supported natively by client-go
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
+)
+
+var (
+	// QPS is the maximum queries per second to the hub
+	QPS float32 = 50
+	// Burst is the maximum burst of queries to the hub
+	Burst = 100
+)
+
+// AddFlags adds the rate limit flags to the flagset
+func AddFlags(flagSet *pflag.FlagSet) {
+	flagSet.Float32Var(&QPS, "qps", QPS, "Maximum queries per second to the hub")
+	flagSet.IntVar(&Burst, "burst", Burst, "Maximum burst of queries to the hub")
+	flagSet.StringToStringVar(&HubRateLimits, "hub-rate-limit", HubRateLimits, "Rate limits of the hubs as CONTEXT=QPS[:BURST], they override --qps and --burst for the hub of the kubeconfig context")
+}
+
+// Factory creates the hub clients once per invocation, they share the same rest config and RESTMapper
+type Factory struct {
+	configFlags *genericclioptions.ConfigFlags
+
+	lock          sync.Mutex
+	config        *rest.Config
+	mapper        meta.RESTMapper
+	client        crclient.Client
+	dynamicClient dynamic.Interface
+	kubeClient    kubernetes.Interface
+}
+
+var (
+	factoriesLock sync.Mutex
+	factories     = make(map[*genericclioptions.ConfigFlags]*Factory)
+)
+
+// ForFlags returns the factory of the config flags, it is created on the first call
+func ForFlags(configFlags *genericclioptions.ConfigFlags) *Factory {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	if f, ok := factories[configFlags]; ok {
+		return f
+	}
+	f := &Factory{configFlags: configFlags}
+	factories[configFlags] = f
+	return f
+}
+
+// ReleaseFactories forgets the factories created so far, a long-running process building
+// new commands for each operation releases the clients of the completed operations
+func ReleaseFactories() {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	factories = make(map[*genericclioptions.ConfigFlags]*Factory)
+}
+
+// ToRESTConfig returns the hub rest config built with the full client-go auth stack:
+// kubeconfig users with tokens, client certificates, exec credential plugins or oidc auth providers
+func (f *Factory) ToRESTConfig() (*rest.Config, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.toRESTConfig()
+}
+
+func (f *Factory) toRESTConfig() (*rest.Config, error) {
+	if f.config != nil {
+		return f.config, nil
+	}
+	config, err := f.configFlags.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := setRateLimiter(config, f.contextName()); err != nil {
+		return nil, err
+	}
+	audit.Enable(config, f.kubeconfigUser())
+	tracing.WrapConfig(config)
+	f.config = config
+	return f.config, nil
+}
+
+// kubeconfigUser returns the user of the kubeconfig context, "" if it can not be read
+func (f *Factory) kubeconfigUser() string {
+	if f.configFlags.AuthInfoName != nil && *f.configFlags.AuthInfoName != "" {
+		return *f.configFlags.AuthInfoName
+	}
+	raw, err := f.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return ""
+	}
+	if c, ok := raw.Contexts[f.contextName()]; ok {
+		return c.AuthInfo
+	}
+	return ""
+}
+
+// contextName returns the kubeconfig context of the config flags, the current context if not set
+func (f *Factory) contextName() string {
+	if f.configFlags.Context != nil && *f.configFlags.Context != "" {
+		return *f.configFlags.Context
+	}
+	raw, err := f.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return ""
+	}
+	return raw.CurrentContext
+}
+
+// ToRESTMapper returns the RESTMapper backed by the discovery cache of the config flags
+func (f *Factory) ToRESTMapper() (meta.RESTMapper, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.toRESTMapper()
+}
+
+func (f *Factory) toRESTMapper() (meta.RESTMapper, error) {
+	if f.mapper != nil {
+		return f.mapper, nil
+	}
+	mapper, err := f.configFlags.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	f.mapper = mapper
+	return f.mapper, nil
+}
+
+// Client returns the controller-runtime client of the hub
+func (f *Factory) Client() (crclient.Client, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.client != nil {
+		return f.client, nil
+	}
+	config, err := f.toRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := f.toRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	client, err := crclient.New(config, crclient.Options{Mapper: mapper})
+	if err != nil {
+		return nil, err
+	}
+	f.client = client
+	return f.client, nil
+}
+
+// DynamicClient returns the dynamic client of the hub
+func (f *Factory) DynamicClient() (dynamic.Interface, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.dynamicClient != nil {
+		return f.dynamicClient, nil
+	}
+	config, err := f.toRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	f.dynamicClient = dynamicClient
+	return f.dynamicClient, nil
+}
+
+// KubeClient returns the typed kubernetes client of the hub, used for the subresources such as the pod logs
+func (f *Factory) KubeClient() (kubernetes.Interface, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.kubeClient != nil {
+		return f.kubeClient, nil
+	}
+	config, err := f.toRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	f.kubeClient = kubeClient
+	return f.kubeClient, nil
+}
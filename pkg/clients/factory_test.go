@@ -0,0 +1,87 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clients
+
+import (
+	"path/filepath"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+)
+
+var testDir = filepath.Join("..", "..", "test", "unit", "resources", "clients")
+
+func newConfigFlags(kubeConfig string) *genericclioptions.ConfigFlags {
+	configFlags := genericclioptions.NewConfigFlags(false)
+	path := filepath.Join(testDir, kubeConfig)
+	configFlags.KubeConfig = &path
+	return configFlags
+}
+
+func TestFactory_ToRESTConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		kubeConfig string
+		check      func(*rest.Config) bool
+	}{
+		{
+			name:       "exec credential plugin",
+			kubeConfig: "kubeconfig-exec.yaml",
+			check: func(c *rest.Config) bool {
+				return c.ExecProvider != nil && c.ExecProvider.Command == "my-sso-login"
+			},
+		},
+		{
+			name:       "oidc auth provider",
+			kubeConfig: "kubeconfig-oidc.yaml",
+			check: func(c *rest.Config) bool {
+				return c.AuthProvider != nil && c.AuthProvider.Name == "oidc"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ForFlags(newConfigFlags(tt.kubeConfig)).ToRESTConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(config) {
+				t.Errorf("unexpected auth configuration %v", config)
+			}
+			if config.QPS != QPS || config.Burst != Burst {
+				t.Errorf("expected qps %v and burst %d got %v and %d", QPS, Burst, config.QPS, config.Burst)
+			}
+			//The auth provider must be registered to build the transport
+			if _, err := rest.TransportFor(config); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestForFlags(t *testing.T) {
+	configFlags := newConfigFlags("kubeconfig-exec.yaml")
+	f := ForFlags(configFlags)
+	if ForFlags(configFlags) != f {
+		t.Error("ForFlags() must return the same factory for the same config flags")
+	}
+	if ForFlags(newConfigFlags("kubeconfig-exec.yaml")) == f {
+		t.Error("ForFlags() must return a new factory for other config flags")
+	}
+
+	config, err := f.ToRESTConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other, _ := f.ToRESTConfig(); other != config {
+		t.Error("ToRESTConfig() must return the same config")
+	}
+	dynamicClient, err := f.DynamicClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other, _ := f.DynamicClient(); other != dynamicClient {
+		t.Error("DynamicClient() must return the same client")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clients
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// HubRateLimits are the rate limits of the hubs set with --hub-rate-limit, as QPS[:BURST] per kubeconfig context,
+// the hubs without a rate limit use --qps and --burst
+var HubRateLimits map[string]string
+
+var (
+	limitersLock sync.Mutex
+	// limiters are shared by all the clients of a hub, so the concurrent operations on a hub
+	// share its budget while each hub has its own
+	limiters = make(map[string]flowcontrol.RateLimiter)
+)
+
+// hubRateLimit returns the qps and burst of the hub of the kubeconfig context
+func hubRateLimit(contextName string) (float32, int, error) {
+	limit, ok := HubRateLimits[contextName]
+	if !ok || contextName == "" {
+		return QPS, Burst, nil
+	}
+	invalid := func(reason string) error {
+		return fmt.Errorf("invalid --hub-rate-limit %s=%s, expected QPS[:BURST]: %s", contextName, limit, reason)
+	}
+	qpsValue, burstValue := limit, ""
+	if i := strings.Index(limit, ":"); i != -1 {
+		qpsValue, burstValue = limit[:i], limit[i+1:]
+	}
+	qps, err := strconv.ParseFloat(qpsValue, 32)
+	if err != nil || qps <= 0 {
+		return 0, 0, invalid("the qps must be a number greater than 0")
+	}
+	//The burst defaults to twice the qps, like the --qps and --burst defaults
+	burst := int(2 * qps)
+	if burstValue != "" {
+		burst, err = strconv.Atoi(burstValue)
+		if err != nil || burst < 1 {
+			return 0, 0, invalid("the burst must be an integer greater than 0")
+		}
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return float32(qps), burst, nil
+}
+
+// setRateLimiter sets the rate limit of the hub of the context on the config, the rate limiter is
+// shared with the other clients of the same hub server
+func setRateLimiter(config *rest.Config, contextName string) error {
+	qps, burst, err := hubRateLimit(contextName)
+	if err != nil {
+		return err
+	}
+	config.QPS = qps
+	config.Burst = burst
+
+	key := fmt.Sprintf("%s|%v|%d", config.Host, qps, burst)
+	limitersLock.Lock()
+	defer limitersLock.Unlock()
+	limiter, ok := limiters[key]
+	if !ok {
+		limiter = flowcontrol.NewTokenBucketRateLimiter(qps, burst)
+		limiters[key] = limiter
+	}
+	config.RateLimiter = limiter
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clients
+
+import (
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func Test_hubRateLimit(t *testing.T) {
+	defer func(limits map[string]string) { HubRateLimits = limits }(HubRateLimits)
+	HubRateLimits = map[string]string{
+		"prod":    "20:30",
+		"staging": "5",
+		"invalid": "fast",
+		"burst":   "5:0",
+	}
+	tests := []struct {
+		context   string
+		wantQPS   float32
+		wantBurst int
+		wantErr   bool
+	}{
+		{context: "prod", wantQPS: 20, wantBurst: 30},
+		{context: "staging", wantQPS: 5, wantBurst: 10},
+		{context: "other", wantQPS: QPS, wantBurst: Burst},
+		{context: "", wantQPS: QPS, wantBurst: Burst},
+		{context: "invalid", wantErr: true},
+		{context: "burst", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.context, func(t *testing.T) {
+			qps, burst, err := hubRateLimit(tt.context)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("hubRateLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (qps != tt.wantQPS || burst != tt.wantBurst) {
+				t.Errorf("hubRateLimit() = %v, %d, want %v, %d", qps, burst, tt.wantQPS, tt.wantBurst)
+			}
+		})
+	}
+}
+
+func Test_setRateLimiter(t *testing.T) {
+	defer func(limits map[string]string) { HubRateLimits = limits }(HubRateLimits)
+	HubRateLimits = map[string]string{"hub2": "10"}
+	hub1a := &rest.Config{Host: "https://hub1:6443"}
+	hub1b := &rest.Config{Host: "https://hub1:6443"}
+	hub2 := &rest.Config{Host: "https://hub2:6443"}
+	for _, c := range []struct {
+		config  *rest.Config
+		context string
+	}{{hub1a, "hub1"}, {hub1b, "hub1-admin"}, {hub2, "hub2"}} {
+		if err := setRateLimiter(c.config, c.context); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hub1a.RateLimiter == nil || hub1a.RateLimiter != hub1b.RateLimiter {
+		t.Error("the clients of the same hub must share the rate limiter")
+	}
+	if hub2.RateLimiter == hub1a.RateLimiter {
+		t.Error("each hub must have its own rate limiter")
+	}
+	if hub2.QPS != 10 || hub2.Burst != 20 {
+		t.Errorf("unexpected rate limit of hub2 %v/%d", hub2.QPS, hub2.Burst)
+	}
+}
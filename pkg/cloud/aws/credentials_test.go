@@ -0,0 +1,97 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package aws
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cm-aws-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "credentials")
+	content := `[default]
+aws_access_key_id = default-id
+aws_secret_access_key = default-secret
+
+[dev]
+aws_access_key_id=dev-id
+aws_secret_access_key=dev-secret
+aws_session_token=dev-session
+
+[incomplete]
+aws_access_key_id = incomplete-id
+`
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_PROFILE", "AWS_SHARED_CREDENTIALS_FILE"} {
+		defer os.Setenv(e, os.Getenv(e))
+		os.Unsetenv(e)
+	}
+	os.Setenv("AWS_SHARED_CREDENTIALS_FILE", path)
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		profile string
+		want    Credentials
+		wantErr bool
+	}{
+		{
+			name: "Success, default profile",
+			want: Credentials{AccessKeyID: "default-id", SecretAccessKey: "default-secret"},
+		},
+		{
+			name:    "Success, profile",
+			profile: "dev",
+			want:    Credentials{AccessKeyID: "dev-id", SecretAccessKey: "dev-secret", SessionToken: "dev-session"},
+		},
+		{
+			name: "Success, AWS_PROFILE",
+			env:  map[string]string{"AWS_PROFILE": "dev"},
+			want: Credentials{AccessKeyID: "dev-id", SecretAccessKey: "dev-secret", SessionToken: "dev-session"},
+		},
+		{
+			name: "Success, environment",
+			env:  map[string]string{"AWS_ACCESS_KEY_ID": "env-id", "AWS_SECRET_ACCESS_KEY": "env-secret"},
+			want: Credentials{AccessKeyID: "env-id", SecretAccessKey: "env-secret"},
+		},
+		{
+			name:    "Failed, secret missing in environment",
+			env:     map[string]string{"AWS_ACCESS_KEY_ID": "env-id"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, unknown profile",
+			profile: "prod",
+			wantErr: true,
+		},
+		{
+			name:    "Failed, incomplete profile",
+			profile: "incomplete",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+			got, err := LoadCredentials(tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadCredentials() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("LoadCredentials() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
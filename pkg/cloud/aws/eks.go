@@ -0,0 +1,101 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package aws
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// TokenPrefix is the prefix of the tokens accepted by the aws-iam-authenticator of the EKS clusters
+	TokenPrefix = "k8s-aws-v1."
+	//clusterIDHeader binds the token to the cluster
+	clusterIDHeader = "x-k8s-aws-id"
+	//tokenExpiration is the validity of the presigned url, EKS refuses tokens older than 15 minutes anyway
+	tokenExpiration = 60 * time.Second
+)
+
+var (
+	//The endpoints are variables so they can be replaced in the tests
+	stsEndpoint = "https://sts.%s.amazonaws.com"
+	eksEndpoint = "https://eks.%s.amazonaws.com"
+	now         = time.Now
+	httpClient  = &http.Client{Timeout: 30 * time.Second}
+)
+
+// EKSCluster holds the connection information of an EKS cluster
+type EKSCluster struct {
+	Endpoint                 string
+	CertificateAuthorityData []byte
+}
+
+type describeClusterResponse struct {
+	Cluster struct {
+		Endpoint             string `json:"endpoint"`
+		Status               string `json:"status"`
+		CertificateAuthority struct {
+			Data string `json:"data"`
+		} `json:"certificateAuthority"`
+	} `json:"cluster"`
+}
+
+// DescribeCluster returns the api server endpoint and the certificate authority of an EKS cluster
+func DescribeCluster(creds *Credentials, region, clusterName string) (*EKSCluster, error) {
+	u := fmt.Sprintf(eksEndpoint, region) + "/clusters/" + url.PathEscape(clusterName)
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	s := &signer{credentials: creds, region: region, service: "eks"}
+	s.sign(req, now())
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to describe the EKS cluster %s in %s: %s %s", clusterName, region, resp.Status, string(b))
+	}
+
+	r := &describeClusterResponse{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, err
+	}
+	if r.Cluster.Endpoint == "" {
+		return nil, fmt.Errorf("the EKS cluster %s has no endpoint, its status is %s", clusterName, r.Cluster.Status)
+	}
+	ca, err := base64.StdEncoding.DecodeString(r.Cluster.CertificateAuthority.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate authority for the EKS cluster %s: %s", clusterName, err.Error())
+	}
+	return &EKSCluster{
+		Endpoint:                 r.Cluster.Endpoint,
+		CertificateAuthorityData: ca,
+	}, nil
+}
+
+// GetToken generates a bearer token for the EKS cluster the same way the aws-iam-authenticator does,
+// it is a presigned sts GetCallerIdentity url bound to the cluster name.
+// The token expires after 15 minutes.
+func GetToken(creds *Credentials, region, clusterName string) (string, error) {
+	u := fmt.Sprintf(stsEndpoint, region) + "/?Action=GetCallerIdentity&Version=2011-06-15"
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(clusterIDHeader, clusterName)
+	s := &signer{credentials: creds, region: region, service: "sts"}
+	s.presign(req, now(), tokenExpiration)
+	return TokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(req.URL.String())), nil
+}
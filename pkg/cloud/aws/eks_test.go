@@ -0,0 +1,89 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package aws
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestGetToken(t *testing.T) {
+	token, err := GetToken(testCredentials, "eu-west-1", "mycluster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(token, TokenPrefix) {
+		t.Fatalf("token %s must start with %s", token, TokenPrefix)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, TokenPrefix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(string(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Host != "sts.eu-west-1.amazonaws.com" {
+		t.Errorf("host = %s, want sts.eu-west-1.amazonaws.com", u.Host)
+	}
+	if got := u.Query().Get("Action"); got != "GetCallerIdentity" {
+		t.Errorf("Action = %s, want GetCallerIdentity", got)
+	}
+	if got := u.Query().Get("X-Amz-SignedHeaders"); got != "host;x-k8s-aws-id" {
+		t.Errorf("X-Amz-SignedHeaders = %s, the cluster id must be signed", got)
+	}
+}
+
+func TestDescribeCluster(t *testing.T) {
+	ca := base64.StdEncoding.EncodeToString([]byte("ca-data"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), signingAlgorithm) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/clusters/mycluster":
+			w.Write([]byte(`{"cluster":{"endpoint":"https://api.mycluster","status":"ACTIVE","certificateAuthority":{"data":"` + ca + `"}}}`))
+		case "/clusters/creating":
+			w.Write([]byte(`{"cluster":{"status":"CREATING"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"No cluster found"}`))
+		}
+	}))
+	defer server.Close()
+	defer func(e string) { eksEndpoint = e }(eksEndpoint)
+	eksEndpoint = server.URL + "%.0s"
+
+	tests := []struct {
+		name        string
+		clusterName string
+		want        string
+		wantErr     bool
+	}{
+		{name: "Success", clusterName: "mycluster", want: "https://api.mycluster"},
+		{name: "Failed, not ready", clusterName: "creating", wantErr: true},
+		{name: "Failed, not found", clusterName: "notfound", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DescribeCluster(testCredentials, "us-east-1", tt.clusterName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DescribeCluster() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Endpoint != tt.want {
+				t.Errorf("Endpoint = %s, want %s", got.Endpoint, tt.want)
+			}
+			if string(got.CertificateAuthorityData) != "ca-data" {
+				t.Errorf("CertificateAuthorityData = %s, want ca-data", string(got.CertificateAuthorityData))
+			}
+		})
+	}
+}
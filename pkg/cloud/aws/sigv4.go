@@ -0,0 +1,129 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package aws
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+	shortDateFormat  = "20060102"
+	//sha256 of an empty payload, the signed requests have no body
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// signer signs the requests with the AWS signature version 4
+type signer struct {
+	credentials *Credentials
+	region      string
+	service     string
+}
+
+// sign adds the Authorization header to the request
+func (s *signer) sign(req *http.Request, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if s.credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.credentials.SessionToken)
+	}
+	signedHeaders, canonicalHeaders := s.canonicalHeaders(req)
+	signature := s.signature(req, now, req.URL.Query(), signedHeaders, canonicalHeaders)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, s.credentials.AccessKeyID, s.scope(now), signedHeaders, signature))
+}
+
+// presign adds the signature to the query of the request, the request is valid until expires
+func (s *signer) presign(req *http.Request, now time.Time, expires time.Duration) {
+	signedHeaders, canonicalHeaders := s.canonicalHeaders(req)
+	query := req.URL.Query()
+	query.Set("X-Amz-Algorithm", signingAlgorithm)
+	query.Set("X-Amz-Credential", s.credentials.AccessKeyID+"/"+s.scope(now))
+	query.Set("X-Amz-Date", now.UTC().Format(amzDateFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires.Seconds())))
+	query.Set("X-Amz-SignedHeaders", signedHeaders)
+	if s.credentials.SessionToken != "" {
+		query.Set("X-Amz-Security-Token", s.credentials.SessionToken)
+	}
+	query.Set("X-Amz-Signature", s.signature(req, now, query, signedHeaders, canonicalHeaders))
+	req.URL.RawQuery = canonicalQuery(query)
+}
+
+func (s *signer) scope(now time.Time) string {
+	return strings.Join([]string{now.UTC().Format(shortDateFormat), s.region, s.service, "aws4_request"}, "/")
+}
+
+func (s *signer) signature(req *http.Request, now time.Time, query url.Values, signedHeaders, canonicalHeaders string) string {
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(query),
+		canonicalHeaders,
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		now.UTC().Format(amzDateFormat),
+		s.scope(now),
+		hashHex(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.credentials.SecretAccessKey), now.UTC().Format(shortDateFormat))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s.service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalHeaders returns the signed header names and the canonical headers, the host is always signed
+func (s *signer) canonicalHeaders(req *http.Request) (string, string) {
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		name := strings.ToLower(k)
+		if name == "authorization" {
+			continue
+		}
+		headers[name] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonical strings.Builder
+	for _, k := range names {
+		canonical.WriteString(k + ":" + headers[k] + "\n")
+	}
+	return strings.Join(names, ";"), canonical.String()
+}
+
+// canonicalQuery encodes the query as expected by the signature, spaces are encoded as %20
+func canonicalQuery(query url.Values) string {
+	return strings.Replace(query.Encode(), "+", "%20", -1)
+}
+
+func hashHex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,61 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package aws
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+var testCredentials = &Credentials{
+	AccessKeyID:     "AKIDEXAMPLE",
+	SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+}
+
+var testTime = time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+// Test_signer_sign uses the get-vanilla request of the AWS signature version 4 test suite
+func Test_signer_sign(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &signer{credentials: testCredentials, region: "us-east-1", service: "service"}
+	s.sign(req, testTime)
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization = %s, want %s", got, want)
+	}
+}
+
+func Test_signer_presign(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://sts.us-east-1.amazonaws.com/?Action=GetCallerIdentity&Version=2011-06-15", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(clusterIDHeader, "mycluster")
+	creds := *testCredentials
+	creds.SessionToken = "session"
+	s := &signer{credentials: &creds, region: "us-east-1", service: "sts"}
+	s.presign(req, testTime, time.Minute)
+
+	query := req.URL.Query()
+	for k, v := range map[string]string{
+		"X-Amz-Algorithm":      signingAlgorithm,
+		"X-Amz-Credential":     "AKIDEXAMPLE/20150830/us-east-1/sts/aws4_request",
+		"X-Amz-Date":           "20150830T123600Z",
+		"X-Amz-Expires":        "60",
+		"X-Amz-SignedHeaders":  "host;x-k8s-aws-id",
+		"X-Amz-Security-Token": "session",
+	} {
+		if got := query.Get(k); got != v {
+			t.Errorf("%s = %s, want %s", k, got, v)
+		}
+	}
+	if len(query.Get("X-Amz-Signature")) != 64 {
+		t.Errorf("X-Amz-Signature = %s, want a sha256 hex signature", query.Get("X-Amz-Signature"))
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package azure
+
+import (
+	"github.com/open-cluster-management/cm-cli/pkg/cloud"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// runCommand is a variable so the az CLI can be replaced in the tests
+var runCommand = cloud.Run
+
+// GetRESTConfig returns a rest config to access the AKS cluster with the credentials of the az account.
+// The admin credentials are requested when admin is set, otherwise the user credentials are used
+// and the clusters integrated with Azure AD rely on the kubelogin exec credential plugin.
+func GetRESTConfig(resourceGroup, name string, admin bool) (*rest.Config, error) {
+	args := []string{"aks", "get-credentials", "--resource-group", resourceGroup, "--name", name, "--file", "-"}
+	if admin {
+		args = append(args, "--admin")
+	}
+	kubeConfig, err := runCommand("az", args...)
+	if err != nil {
+		return nil, err
+	}
+	return clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+}
@@ -0,0 +1,48 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package azure
+
+import (
+	"strings"
+	"testing"
+)
+
+const kubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: mycluster
+  cluster:
+    server: https://mycluster.hcp.eastus.azmk8s.io:443
+contexts:
+- name: mycluster
+  context:
+    cluster: mycluster
+    user: clusterUser
+current-context: mycluster
+users:
+- name: clusterUser
+  user:
+    token: user-token
+`
+
+func TestGetRESTConfig(t *testing.T) {
+	defer func(f func(string, ...string) ([]byte, error)) { runCommand = f }(runCommand)
+
+	for _, admin := range []bool{false, true} {
+		var gotArgs []string
+		runCommand = func(name string, args ...string) ([]byte, error) {
+			gotArgs = args
+			return []byte(kubeConfig), nil
+		}
+		got, err := GetRESTConfig("group", "mycluster", admin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Host != "https://mycluster.hcp.eastus.azmk8s.io:443" {
+			t.Errorf("Host = %s", got.Host)
+		}
+		if strings.Contains(strings.Join(gotArgs, " "), "--admin") != admin {
+			t.Errorf("admin %v, got args %v", admin, gotArgs)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package cloud
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Run executes a cloud provider CLI and returns its standard output,
+// the error contains the standard error of the CLI
+func Run(name string, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return nil, fmt.Errorf("%s is required, install it and log in first: %s", name, err.Error())
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s %s failed: %s %s", name, strings.Join(args, " "), err.Error(), strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
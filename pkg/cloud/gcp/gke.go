@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package gcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cloud"
+	"k8s.io/client-go/rest"
+)
+
+// runCommand is a variable so the gcloud CLI can be replaced in the tests
+var runCommand = cloud.Run
+
+type describeClusterResponse struct {
+	Endpoint   string `json:"endpoint"`
+	Status     string `json:"status"`
+	MasterAuth struct {
+		ClusterCaCertificate string `json:"clusterCaCertificate"`
+	} `json:"masterAuth"`
+}
+
+// GetRESTConfig returns a rest config to access the GKE cluster with the access token of the gcloud account,
+// the access token expires after an hour.
+func GetRESTConfig(project, zone, name string) (*rest.Config, error) {
+	b, err := runCommand("gcloud", "container", "clusters", "describe", name,
+		"--project", project, "--zone", zone, "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+	cluster := &describeClusterResponse{}
+	if err := json.Unmarshal(b, cluster); err != nil {
+		return nil, err
+	}
+	if cluster.Endpoint == "" {
+		return nil, fmt.Errorf("the GKE cluster %s has no endpoint, its status is %s", name, cluster.Status)
+	}
+	ca, err := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClusterCaCertificate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate authority for the GKE cluster %s: %s", name, err.Error())
+	}
+
+	token, err := runCommand("gcloud", "auth", "print-access-token")
+	if err != nil {
+		return nil, err
+	}
+
+	return &rest.Config{
+		Host:        "https://" + cluster.Endpoint,
+		BearerToken: strings.TrimSpace(string(token)),
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: ca,
+		},
+	}, nil
+}
@@ -0,0 +1,65 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package gcp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGetRESTConfig(t *testing.T) {
+	defer func(f func(string, ...string) ([]byte, error)) { runCommand = f }(runCommand)
+
+	tests := []struct {
+		name     string
+		describe string
+		wantHost string
+		wantErr  bool
+	}{
+		{
+			name:     "Success",
+			describe: `{"endpoint":"1.2.3.4","status":"RUNNING","masterAuth":{"clusterCaCertificate":"Y2EtZGF0YQ=="}}`,
+			wantHost: "https://1.2.3.4",
+		},
+		{
+			name:     "Failed, no endpoint",
+			describe: `{"status":"PROVISIONING"}`,
+			wantErr:  true,
+		},
+		{
+			name:    "Failed, cluster not found",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCommand = func(name string, args ...string) ([]byte, error) {
+				switch args[0] {
+				case "container":
+					if tt.describe == "" {
+						return nil, fmt.Errorf("not found")
+					}
+					return []byte(tt.describe), nil
+				default:
+					return []byte("access-token\n"), nil
+				}
+			}
+			got, err := GetRESTConfig("project", "zone", "mycluster")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRESTConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Host != tt.wantHost {
+				t.Errorf("Host = %s, want %s", got.Host, tt.wantHost)
+			}
+			if got.BearerToken != "access-token" {
+				t.Errorf("BearerToken = %s, want access-token", got.BearerToken)
+			}
+			if string(got.CAData) != "ca-data" {
+				t.Errorf("CAData = %s, want ca-data", string(got.CAData))
+			}
+		})
+	}
+}
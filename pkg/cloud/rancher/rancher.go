@@ -0,0 +1,84 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package rancher
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// localClusterID is the id of the cluster running Rancher, it is not a downstream cluster
+const localClusterID = "local"
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Cluster is a downstream cluster managed by Rancher
+type Cluster struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	State    string `json:"state"`
+	Provider string `json:"provider"`
+}
+
+type clusterCollection struct {
+	Data       []Cluster `json:"data"`
+	Pagination struct {
+		Next string `json:"next"`
+	} `json:"pagination"`
+}
+
+// ListClusters returns the downstream clusters of a Rancher server, the token is a Rancher API bearer token.
+// With insecure the certificate of the Rancher server is not verified, for the servers with a self-signed certificate.
+func ListClusters(server, token string, insecure bool) ([]Cluster, error) {
+	client := httpClient
+	if insecure {
+		client = &http.Client{
+			Timeout: httpClient.Timeout,
+			//#nosec G402 explicitly requested with --rancher-insecure-skip-tls-verify
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		}
+	}
+	clusters := make([]Cluster, 0)
+	for u := strings.TrimSuffix(server, "/") + "/v3/clusters"; u != ""; {
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Accept", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		b, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unable to list the clusters of the Rancher server %s: %s %s", server, resp.Status, string(b))
+		}
+		c := &clusterCollection{}
+		if err := json.Unmarshal(b, c); err != nil {
+			return nil, fmt.Errorf("invalid cluster list from the Rancher server %s: %s", server, err.Error())
+		}
+		for _, cluster := range c.Data {
+			if cluster.ID != localClusterID {
+				clusters = append(clusters, cluster)
+			}
+		}
+		u = c.Pagination.Next
+	}
+	return clusters, nil
+}
+
+// ClusterServer returns the url of the Rancher proxy to the api server of a downstream cluster,
+// it accepts the Rancher API tokens
+func ClusterServer(server, clusterID string) string {
+	return strings.TrimSuffix(server, "/") + "/k8s/clusters/" + clusterID
+}
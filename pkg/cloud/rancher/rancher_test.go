@@ -0,0 +1,47 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package rancher
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestListClusters(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.RawQuery {
+		case "":
+			fmt.Fprintf(w, `{"data":[{"id":"local","name":"local","state":"active"},{"id":"c-1","name":"prod","state":"active","provider":"eks"}],
+"pagination":{"next":"%s/v3/clusters?marker=c-1"}}`, server.URL)
+		default:
+			fmt.Fprint(w, `{"data":[{"id":"c-2","name":"dev","state":"unavailable","provider":"rke"}]}`)
+		}
+	}))
+	defer server.Close()
+
+	clusters, err := ListClusters(server.URL+"/", "token-abc", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Cluster{
+		{ID: "c-1", Name: "prod", State: "active", Provider: "eks"},
+		{ID: "c-2", Name: "dev", State: "unavailable", Provider: "rke"},
+	}
+	if !reflect.DeepEqual(clusters, want) {
+		t.Errorf("ListClusters() = %v, want %v", clusters, want)
+	}
+	if _, err := ListClusters(server.URL, "wrong", false); err == nil {
+		t.Error("error expected for an invalid token")
+	}
+	if s := ClusterServer(server.URL+"/", "c-1"); s != server.URL+"/k8s/clusters/c-1" {
+		t.Errorf("ClusterServer() = %s", s)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+package disable
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Disable the search-collector addon on two clusters
+%[1]s addon disable search-collector --clusters cluster1,cluster2
+`
+
+// NewCmd provides a cobra command disabling an addon on managed clusters
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "disable <addon>",
+		Short: "Disable an addon on managed clusters",
+		Long: "Disable an addon on managed clusters, the addons " + strings.Join(helpers.KlusterletAddons(), ", ") +
+			" are toggled in the KlusterletAddonConfig of the cluster when it exists, the ManagedClusterAddOn is deleted",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&o.clusters, "clusters", nil, "Names of the managed clusters")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,70 @@
+// Copyright Contributors to the Open Cluster Management project
+package disable
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) != 1 {
+		return fmt.Errorf("the addon name is required")
+	}
+	o.addonName = args[0]
+	return nil
+}
+
+func (o *Options) validate() error {
+	if errs := validation.IsDNS1123Subdomain(o.addonName); len(errs) != 0 {
+		return fmt.Errorf("invalid addon name %s: %v", o.addonName, errs)
+	}
+	if len(o.clusters) == 0 {
+		return fmt.Errorf("the clusters must be provided with --clusters")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	failed := 0
+	for _, clusterName := range o.clusters {
+		if err := o.disableAddon(client, clusterName); err != nil {
+			failed++
+			fmt.Fprintf(o.ErrOut, "%s: %s\n", clusterName, err.Error())
+			continue
+		}
+		fmt.Fprintf(printers.Messages(o.ErrOut), "%s: addon %s disabled\n", clusterName, o.addonName)
+		printers.PrintIdentifier(o.Out, clusterName)
+	}
+	if failed != 0 {
+		return fmt.Errorf("the addon %s was not disabled on %d of %d clusters", o.addonName, failed, len(o.clusters))
+	}
+	return nil
+}
+
+func (o *Options) disableAddon(client crclient.Client, clusterName string) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(o.ctx, types.NamespacedName{Name: clusterName}, mc); err != nil {
+		return err
+	}
+	return helpers.DisableAddon(o.ctx, client, clusterName, o.addonName)
+}
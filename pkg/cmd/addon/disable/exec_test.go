@@ -0,0 +1,44 @@
+// Copyright Contributors to the Open Cluster Management project
+package disable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_runWithClient(t *testing.T) {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	mc.SetName("cluster1")
+	addon := &unstructured.Unstructured{}
+	addon.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK)
+	addon.SetName("my-addon")
+	addon.SetNamespace("cluster1")
+	client := fake.NewClient(mc, addon)
+
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	o := &Options{
+		addonName: "my-addon",
+		clusters:  []string{"cluster1"},
+		IOStreams: streams,
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	err := client.Get(context.TODO(), types.NamespacedName{Name: "my-addon", Namespace: "cluster1"}, addon.DeepCopy())
+	if !errors.IsNotFound(err) {
+		t.Errorf("the addon must be deleted, got %v", err)
+	}
+
+	o.clusters = []string{"cluster2"}
+	if err := o.runWithClient(client); err == nil {
+		t.Error("runWithClient() expected an error for a missing cluster")
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright Contributors to the Open Cluster Management project
+package enable
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Enable the search-collector addon on two clusters
+%[1]s addon enable search-collector --clusters cluster1,cluster2
+`
+
+// NewCmd provides a cobra command enabling an addon on managed clusters
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "enable <addon>",
+		Short: "Enable an addon on managed clusters",
+		Long: "Enable an addon on managed clusters, the addons " + strings.Join(helpers.KlusterletAddons(), ", ") +
+			" are toggled in the KlusterletAddonConfig of the cluster when it exists, the ManagedClusterAddOn of the other addons is created",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&o.clusters, "clusters", nil, "Names of the managed clusters")
+	cmd.Flags().StringVar(&o.installNamespace, "install-namespace", helpers.AddonInstallNamespace, "Namespace of the managed cluster in which the addon agent is installed, for the addons not managed by the KlusterletAddonConfig")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
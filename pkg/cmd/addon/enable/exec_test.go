@@ -0,0 +1,79 @@
+// Copyright Contributors to the Open Cluster Management project
+package enable
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		clusters []string
+		wantErr  bool
+		contains string
+	}{
+		{
+			name:     "Success",
+			clusters: []string{"cluster1", "cluster2"},
+			contains: "cluster2: addon my-addon enabled",
+		},
+		{
+			name:     "Failed, cluster not found",
+			clusters: []string{"cluster1", "cluster3"},
+			wantErr:  true,
+			contains: "cluster1: addon my-addon enabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(fake.NewManagedCluster("cluster1"), fake.NewManagedCluster("cluster2"))
+			streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				addonName:        "my-addon",
+				clusters:         tt.clusters,
+				installNamespace: helpers.AddonInstallNamespace,
+				IOStreams:        streams,
+			}
+			if err := o.runWithClient(client); (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(errOut.String(), tt.contains) {
+				t.Errorf("output must contain %q, got %s", tt.contains, errOut.String())
+			}
+			addon := &unstructured.Unstructured{}
+			addon.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK)
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "my-addon", Namespace: "cluster1"}, addon); err != nil {
+				t.Errorf("the addon must be created on cluster1, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		addon    string
+		clusters []string
+		wantErr  bool
+	}{
+		{name: "Success", addon: "search-collector", clusters: []string{"cluster1"}},
+		{name: "Failed, no cluster", addon: "search-collector", wantErr: true},
+		{name: "Failed, invalid addon name", addon: "Search_Collector", clusters: []string{"cluster1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{addonName: tt.addon, clusters: tt.clusters}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright Contributors to the Open Cluster Management project
+package enable
+
+import (
+	"context"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags      *genericclioptions.ConfigFlags
+	addonName        string
+	clusters         []string
+	installNamespace string
+	ctx              context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ctx:         context.Background(),
+
+		IOStreams: streams,
+	}
+}
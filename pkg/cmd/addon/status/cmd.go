@@ -0,0 +1,56 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Show the health of the addons of all clusters
+%[1]s addon status
+
+# Show the health of an addon on two clusters
+%[1]s addon status --addon search-collector --clusters cluster1,cluster2
+
+# Show the health of the addons of the clusters of a clusterset
+%[1]s addon status --clusterset myclusterset
+`
+
+// NewCmd provides a cobra command showing the health of the addons on each cluster
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "status",
+		Short:        "Show the health of the addons on each managed cluster",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&o.clusters, "clusters", nil, "Names of the managed clusters, all clusters if not set")
+	cmd.Flags().StringVar(&o.addonName, "addon", "", "Name of the addon, all addons if not set")
+	o.scope.AddClusterSetFlag(cmd.Flags())
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,103 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	return nil
+}
+
+func (o *Options) validate() error {
+	if len(o.clusters) != 0 && len(o.scope.ClusterSets) != 0 {
+		return fmt.Errorf("--clusters and --clusterset are mutually exclusive")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	if err := o.scope.Complete(o.configFlags); err != nil {
+		return err
+	}
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	listed := make([]unstructured.Unstructured, 0)
+	for _, ns := range o.clusters {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK.GroupVersion().WithKind(helpers.ManagedClusterAddOnGVK.Kind + "List"))
+		if err := client.List(o.ctx, list, crclient.InNamespace(ns)); err != nil {
+			return err
+		}
+		listed = append(listed, list.Items...)
+	}
+	//The addons of all clusters are listed in the scope of the clustersets
+	if len(o.clusters) == 0 {
+		var err error
+		listed, err = o.scope.ListInClusters(o.ctx, client, helpers.ManagedClusterAddOnGVK)
+		if err != nil {
+			return err
+		}
+		if message := o.scope.Describe(); message != "" {
+			fmt.Fprintln(printers.Messages(o.ErrOut), message)
+		}
+	}
+	addons := make([]unstructured.Unstructured, 0, len(listed))
+	for _, addon := range listed {
+		if o.addonName == "" || addon.GetName() == o.addonName {
+			addons = append(addons, addon)
+		}
+	}
+	sort.Slice(addons, func(i, j int) bool {
+		if addons[i].GetNamespace() != addons[j].GetNamespace() {
+			return addons[i].GetNamespace() < addons[j].GetNamespace()
+		}
+		return addons[i].GetName() < addons[j].GetName()
+	})
+
+	table := &printers.Table{
+		Headers: []string{"CLUSTER", "ADDON", "AVAILABLE", "DEGRADED", "PROGRESSING", "MESSAGE"},
+	}
+	items := make([]map[string]interface{}, 0, len(addons))
+	for i := range addons {
+		addon := &addons[i]
+		items = append(items, addon.Object)
+		conditions, _, _ := unstructured.NestedSlice(addon.Object, "status", "conditions")
+		available := helpers.GetConditionStatus(conditions, "Available")
+		message := ""
+		if available != "True" {
+			message = helpers.GetConditionMessage(conditions, "Available")
+		}
+		table.AddRow(addon.GetNamespace(),
+			addon.GetName(),
+			unknownIfEmpty(available),
+			unknownIfEmpty(helpers.GetConditionStatus(conditions, "Degraded")),
+			unknownIfEmpty(helpers.GetConditionStatus(conditions, "Progressing")),
+			message)
+	}
+	return o.printOptions.Print(o.Out, table, items)
+}
+
+func unknownIfEmpty(status string) string {
+	if status == "" {
+		return "Unknown"
+	}
+	return status
+}
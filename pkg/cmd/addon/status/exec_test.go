@@ -0,0 +1,96 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newAddon(clusterName, name string, conditions ...interface{}) *unstructured.Unstructured {
+	addon := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{"conditions": conditions},
+		},
+	}
+	addon.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK)
+	addon.SetName(name)
+	addon.SetNamespace(clusterName)
+	return addon
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := fake.NewClient(
+		fake.NewManagedCluster("cluster1", fake.WithClusterSet("prod")),
+		fake.NewManagedCluster("cluster2", fake.WithClusterSet("dev")),
+		newAddon("cluster1", "search-collector", fake.NewCondition("Available", "True")),
+		newAddon("cluster1", "work-manager", fake.NewCondition("Available", "False", fake.WithMessage("lease not updated")), fake.NewCondition("Degraded", "True")),
+		newAddon("cluster2", "search-collector"),
+	)
+	tests := []struct {
+		name        string
+		clusters    []string
+		clusterSets []string
+		addonName   string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "All clusters",
+			contains: []string{
+				"CLUSTER",
+				"lease not updated",
+				"cluster2",
+				"Unknown",
+			},
+		},
+		{
+			name:        "One cluster",
+			clusters:    []string{"cluster1"},
+			contains:    []string{"work-manager"},
+			notContains: []string{"cluster2"},
+		},
+		{
+			name:        "Clusterset",
+			clusterSets: []string{"dev"},
+			contains:    []string{"cluster2"},
+			notContains: []string{"cluster1"},
+		},
+		{
+			name:        "One addon",
+			addonName:   "search-collector",
+			contains:    []string{"cluster1", "cluster2"},
+			notContains: []string{"work-manager"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				printOptions: printers.NewPrintOptions(),
+				clusters:     tt.clusters,
+				addonName:    tt.addonName,
+				scope:        helpers.ClusterScope{ClusterSets: tt.clusterSets},
+				IOStreams:    streams,
+			}
+			if err := o.runWithClient(client); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got %s", c, out.String())
+				}
+			}
+			for _, c := range tt.notContains {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %q, got %s", c, out.String())
+				}
+			}
+		})
+	}
+}
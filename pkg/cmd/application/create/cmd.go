@@ -0,0 +1,61 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Create an application with its channel, subscription and placement rule
+%[1]s application create --values values.yaml
+
+# Create an application with overwritting the application name and namespace
+%[1]s application create --values values.yaml --name myapp --namespace mynamespace
+`
+
+const (
+	scenarioDirectory = "scenarios/application"
+)
+
+var valuesTemplatePath = filepath.Join(scenarioDirectory, "values-template.yaml")
+
+// NewCmd provides a cobra command creating an application, channel, subscription and placement rule
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "create",
+		Short:        "Create an application",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.SetUsageTemplate(applierscenarios.UsageTempate(cmd, valuesTemplatePath))
+	cmd.Flags().StringVar(&o.applicationName, "name", "", "Name of the application to create")
+
+	o.applierScenariosOptions.AddFlags(cmd.Flags())
+	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
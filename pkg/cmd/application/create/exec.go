@@ -0,0 +1,101 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"fmt"
+	"path/filepath"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	o.values, err = o.applierScenariosOptions.ReadValues()
+	if err != nil {
+		return err
+	}
+
+	if len(o.values) == 0 {
+		return fmt.Errorf("values are missing")
+	}
+
+	return nil
+}
+
+func (o *Options) validate() (err error) {
+	iapp, ok := o.values["application"]
+	if !ok || iapp == nil {
+		return fmt.Errorf("application is missing")
+	}
+	app := iapp.(map[string]interface{})
+
+	if o.applicationName == "" {
+		iname, ok := app["name"]
+		if !ok || iname == nil {
+			return fmt.Errorf("application name is missing")
+		}
+		o.applicationName = iname.(string)
+		if len(o.applicationName) == 0 {
+			return fmt.Errorf("application.name not specified")
+		}
+	}
+	app["name"] = o.applicationName
+
+	if o.applierScenariosOptions.ConfigFlags != nil &&
+		o.applierScenariosOptions.ConfigFlags.Namespace != nil &&
+		*o.applierScenariosOptions.ConfigFlags.Namespace != "" {
+		app["namespace"] = *o.applierScenariosOptions.ConfigFlags.Namespace
+	}
+	if ins, ok := app["namespace"]; !ok || ins == nil || ins.(string) == "" {
+		return fmt.Errorf("application.namespace not specified")
+	}
+
+	ichannel, ok := o.values["channel"]
+	if !ok || ichannel == nil {
+		return fmt.Errorf("channel is missing")
+	}
+	channel := ichannel.(map[string]interface{})
+	if ipathname, ok := channel["pathname"]; !ok || ipathname == nil || ipathname.(string) == "" {
+		return fmt.Errorf("channel.pathname not specified")
+	}
+
+	for _, k := range []string{"subscription", "placement"} {
+		if v, ok := o.values[k]; !ok || v == nil {
+			o.values[k] = map[string]interface{}{}
+		}
+	}
+
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.applierScenariosOptions.ConfigFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	reader := resources.NewResourcesReader()
+
+	applyOptions := &appliercmd.Options{
+		OutFile:     o.applierScenariosOptions.OutFile,
+		ConfigFlags: o.applierScenariosOptions.ConfigFlags,
+
+		Timeout:   o.applierScenariosOptions.ApplierTimeout(),
+		Force:     o.applierScenariosOptions.Force,
+		Silent:    o.applierScenariosOptions.Silent,
+		IOStreams: o.applierScenariosOptions.IOStreams,
+	}
+
+	return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader,
+		filepath.Join(scenarioDirectory, "hub"),
+		o.values)
+}
@@ -0,0 +1,167 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testDir = filepath.Join("..", "..", "..", "..", "test", "unit")
+var createApplicationTestDir = filepath.Join(testDir, "resources", "application", "create")
+
+func TestOptions_complete(t *testing.T) {
+	tests := []struct {
+		name       string
+		valuesPath string
+		wantErr    bool
+	}{
+		{
+			name:       "Failed, bad valuesPath",
+			valuesPath: "bad-values-path.yaml",
+			wantErr:    true,
+		},
+		{
+			name:       "Failed, empty values",
+			valuesPath: filepath.Join(createApplicationTestDir, "values-empty.yaml"),
+			wantErr:    true,
+		},
+		{
+			name:       "Success, with values",
+			valuesPath: filepath.Join(createApplicationTestDir, "values-fake.yaml"),
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+					ValuesPaths: []string{tt.valuesPath},
+				},
+			}
+			if err := o.complete(nil, nil); (err != nil) != tt.wantErr {
+				t.Errorf("Options.complete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		applicationName string
+		values          map[string]interface{}
+		wantErr         bool
+	}{
+		{
+			name: "Success, all info in values",
+			values: map[string]interface{}{
+				"application": map[string]interface{}{
+					"name":      "test",
+					"namespace": "test-ns",
+				},
+				"channel": map[string]interface{}{
+					"pathname": "https://github.com/test/test.git",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:            "Success, overwrite name",
+			applicationName: "other",
+			values: map[string]interface{}{
+				"application": map[string]interface{}{
+					"namespace": "test-ns",
+				},
+				"channel": map[string]interface{}{
+					"pathname": "https://github.com/test/test.git",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Failed, application missing",
+			values:  map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name: "Failed, namespace missing",
+			values: map[string]interface{}{
+				"application": map[string]interface{}{
+					"name": "test",
+				},
+				"channel": map[string]interface{}{
+					"pathname": "https://github.com/test/test.git",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed, channel pathname missing",
+			values: map[string]interface{}{
+				"application": map[string]interface{}{
+					"name":      "test",
+					"namespace": "test-ns",
+				},
+				"channel": map[string]interface{}{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				applicationName:         tt.applicationName,
+				values:                  tt.values,
+			}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Options.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	values, err := appliercmd.ConvertValuesFileToValuesMap(filepath.Join(createApplicationTestDir, "values-fake.yaml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := crclientfake.NewFakeClient()
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			Timeout: time.Second,
+		},
+		values: values,
+	}
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	subscription := &unstructured.Unstructured{}
+	subscription.SetGroupVersionKind(helpers.SubscriptionGVK)
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "test-app-subscription", Namespace: "test-ns"}, subscription)
+	if err != nil {
+		t.Error(err)
+	}
+	channel, _, err := unstructured.NestedString(subscription.Object, "spec", "channel")
+	if err != nil {
+		t.Error(err)
+	}
+	if channel != "test-ns/test-app-channel" {
+		t.Errorf("expected channel test-ns/test-app-channel got %s", channel)
+	}
+	if subscription.GetAnnotations()["apps.open-cluster-management.io/git-path"] != "helloworld" {
+		t.Errorf("expected git-path annotation helloworld got %v", subscription.GetAnnotations())
+	}
+}
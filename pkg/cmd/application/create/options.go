@@ -0,0 +1,24 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"context"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	applierScenariosOptions *applierscenarios.ApplierScenariosOptions
+	applicationName         string
+	values                  map[string]interface{}
+	ctx                     context.Context
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(streams),
+		ctx:                     context.Background(),
+	}
+}
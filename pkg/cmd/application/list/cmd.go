@@ -0,0 +1,51 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# List the applications of the current namespace
+%[1]s application list
+
+# List the applications of all namespaces
+%[1]s application list -A
+`
+
+// NewCmd provides a cobra command listing the applications and their subscriptions
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List the applications with their subscriptions",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "If set, list the applications across all namespaces")
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,65 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if o.allNamespaces {
+		o.namespace = ""
+		return nil
+	}
+	o.namespace, err = helpers.GetNamespaceFromFlags(o.configFlags)
+	return err
+}
+
+func (o *Options) validate() error {
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	apps := &unstructured.UnstructuredList{}
+	apps.SetGroupVersionKind(helpers.ApplicationListGVK)
+	err := client.List(o.ctx, apps, crclient.InNamespace(o.namespace))
+	if err != nil {
+		return err
+	}
+
+	table := &printers.Table{
+		Headers: []string{"NAMESPACE", "NAME", "SUBSCRIPTIONS", "PHASE"},
+	}
+	for i := range apps.Items {
+		app := &apps.Items[i]
+		subscriptions, err := helpers.GetApplicationSubscriptions(o.ctx, client, app)
+		if err != nil {
+			return err
+		}
+		names := make([]string, len(subscriptions))
+		phases := make([]string, len(subscriptions))
+		for j := range subscriptions {
+			names[j] = subscriptions[j].GetName()
+			phases[j], _, _ = unstructured.NestedString(subscriptions[j].Object, "status", "phase")
+		}
+		table.AddRow(app.GetNamespace(), app.GetName(), strings.Join(names, ","), strings.Join(phases, ","))
+	}
+	return o.printOptions.Print(o.Out, table, apps.Items)
+}
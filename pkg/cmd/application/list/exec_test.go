@@ -0,0 +1,81 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newObject(gvk schema.GroupVersionKind, namespace, name string, labels map[string]string, obj map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: obj}
+	u.SetGroupVersionKind(gvk)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetLabels(labels)
+	return u
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := fake.NewClient(
+		newObject(helpers.ApplicationGVK, "default", "app1", nil, map[string]interface{}{
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"app": "app1"},
+				},
+			},
+		}),
+		newObject(helpers.SubscriptionGVK, "default", "app1-subscription", map[string]string{"app": "app1"}, map[string]interface{}{
+			"status": map[string]interface{}{"phase": "Propagated"},
+		}),
+		newObject(helpers.SubscriptionGVK, "default", "other-subscription", map[string]string{"app": "other"}, map[string]interface{}{}),
+		newObject(helpers.ApplicationGVK, "other", "app2", nil, map[string]interface{}{}),
+	)
+	tests := []struct {
+		name        string
+		namespace   string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "Namespace",
+			namespace:   "default",
+			contains:    []string{"app1", "app1-subscription", "Propagated"},
+			notContains: []string{"app2", "other-subscription"},
+		},
+		{
+			name:      "All namespaces",
+			namespace: "",
+			contains:  []string{"app1", "app2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				printOptions: printers.NewPrintOptions(),
+				namespace:    tt.namespace,
+				IOStreams:    streams,
+			}
+			if err := o.runWithClient(client); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %s, got:\n%s", c, out.String())
+				}
+			}
+			for _, c := range tt.notContains {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %s, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
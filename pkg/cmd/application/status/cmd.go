@@ -0,0 +1,47 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Show the deployment phase of an application on each cluster
+%[1]s application status myapp --namespace mynamespace
+`
+
+// NewCmd provides a cobra command showing the deployment phase of an application per cluster
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "status <application>",
+		Short:        "Show the deployment phase of an application on each cluster",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
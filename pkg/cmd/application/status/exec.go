@@ -0,0 +1,75 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 0 {
+		o.applicationName = args[0]
+	}
+	o.namespace, err = helpers.GetNamespaceFromFlags(o.configFlags)
+	return err
+}
+
+func (o *Options) validate() error {
+	if o.applicationName == "" {
+		return fmt.Errorf("application name is missing")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	app := &unstructured.Unstructured{}
+	app.SetGroupVersionKind(helpers.ApplicationGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.applicationName, Namespace: o.namespace}, app)
+	if err != nil {
+		return err
+	}
+
+	subscriptions, err := helpers.GetApplicationSubscriptions(o.ctx, client, app)
+	if err != nil {
+		return err
+	}
+
+	statuses := make([]helpers.SubscriptionClusterStatus, 0)
+	for i := range subscriptions {
+		s, err := helpers.GetSubscriptionClusterStatuses(o.ctx, client, &subscriptions[i])
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, s...)
+	}
+
+	table := &printers.Table{
+		Headers: []string{"SUBSCRIPTION", "CLUSTER", "PACKAGE", "PHASE"},
+	}
+	for _, s := range statuses {
+		phase := s.Phase
+		if phase == "" {
+			phase = "Pending"
+		}
+		table.AddRow(s.Subscription, s.Cluster, s.Package, phase)
+	}
+	return o.printOptions.Print(o.Out, table, statuses)
+}
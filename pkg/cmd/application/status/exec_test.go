@@ -0,0 +1,96 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newObject(gvk schema.GroupVersionKind, namespace, name string, labels map[string]string, obj map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: obj}
+	u.SetGroupVersionKind(gvk)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetLabels(labels)
+	return u
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := fake.NewClient(
+		newObject(helpers.ApplicationGVK, "default", "app1", nil, map[string]interface{}{
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"app": "app1"},
+				},
+			},
+		}),
+		newObject(helpers.SubscriptionGVK, "default", "app1-subscription", map[string]string{"app": "app1"}, map[string]interface{}{
+			"spec": map[string]interface{}{
+				"placement": map[string]interface{}{
+					"placementRef": map[string]interface{}{"kind": "PlacementRule", "name": "app1-placement"},
+				},
+			},
+			"status": map[string]interface{}{
+				"statuses": map[string]interface{}{
+					"cluster1": map[string]interface{}{
+						"packages": map[string]interface{}{
+							"nginx": map[string]interface{}{"phase": "Subscribed"},
+						},
+					},
+				},
+			},
+		}),
+		newObject(helpers.PlacementRuleGVK, "default", "app1-placement", nil, map[string]interface{}{
+			"status": map[string]interface{}{
+				"decisions": []interface{}{
+					map[string]interface{}{"clusterName": "cluster1", "clusterNamespace": "cluster1"},
+					map[string]interface{}{"clusterName": "cluster2", "clusterNamespace": "cluster2"},
+				},
+			},
+		}),
+	)
+	tests := []struct {
+		name            string
+		applicationName string
+		wantErr         bool
+		contains        []string
+	}{
+		{
+			name:            "Success",
+			applicationName: "app1",
+			contains:        []string{"cluster1", "nginx", "Subscribed", "cluster2", "Pending"},
+		},
+		{
+			name:            "Failed, application not found",
+			applicationName: "app2",
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				printOptions:    printers.NewPrintOptions(),
+				applicationName: tt.applicationName,
+				namespace:       "default",
+				IOStreams:       streams,
+			}
+			err := o.runWithClient(client)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Options.runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %s, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
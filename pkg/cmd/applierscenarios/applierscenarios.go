@@ -3,7 +3,17 @@ package applierscenarios
 
 import (
 	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/cm-cli/pkg/credentials"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/progress"
 	"github.com/open-cluster-management/cm-cli/pkg/resources"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -18,11 +28,26 @@ var ApplierScenariosExample = `
 type ApplierScenariosOptions struct {
 	ConfigFlags *genericclioptions.ConfigFlags
 
-	OutFile    string
-	ValuesPath string
-	Timeout    int
-	Force      bool
-	Silent     bool
+	OutFile        string
+	ValuesPaths    []string
+	SetValues      []string
+	SetFileValues  []string
+	Timeout        time.Duration
+	Force          bool
+	Silent         bool
+	ServerSide     bool
+	ForceConflicts bool
+	Validate       Validation
+	ProgressFormat progress.Format
+
+	//TimeoutFlag is the name of the flag of Timeout, timeout if not set
+	TimeoutFlag string
+
+	//EnvSubstitution replaces the ${NAME} references of the values files by the environment variables
+	EnvSubstitution bool
+	//SkipCredentialResolution keeps the vault:// and keychain:// references of the credential values,
+	//set by serve as the values of its requests must not read the credentials of the server host
+	SkipCredentialResolution bool
 
 	genericclioptions.IOStreams
 }
@@ -39,10 +64,167 @@ func (o *ApplierScenariosOptions) AddFlags(flagSet *pflag.FlagSet) {
 	flagSet.StringVarP(&o.OutFile, "outFile", "o", "",
 		"Output file. If set nothing will be applied but a file will be generate "+
 			"which you can apply later with 'kubectl <create|apply|delete> -f")
-	flagSet.StringVar(&o.ValuesPath, "values", "", "The files containing the values")
-	flagSet.IntVar(&o.Timeout, "t", 5, "Timeout in second to apply one resource, default 5 sec")
+	o.AddValuesFlags(flagSet)
+	timeoutFlag := o.TimeoutFlag
+	if timeoutFlag == "" {
+		timeoutFlag = "timeout"
+	}
+	helpers.DurationVar(flagSet, &o.Timeout, timeoutFlag, 5*time.Second, "Timeout to apply one resource, e.g. 30s or 2m30s")
+	//--t was the timeout in seconds before it was a duration
+	flagSet.Var(flagSet.Lookup(timeoutFlag).Value, "t", "Timeout to apply one resource")
+	_ = flagSet.MarkDeprecated("t", "use --"+timeoutFlag+" instead")
 	flagSet.BoolVar(&o.Force, "force", false, "If set, the finalizers will be removed before delete")
+	flagSet.BoolVar(&o.ServerSide, "server-side", true, fmt.Sprintf("If set, the resources are applied server-side with the %s field manager, only the fields of the templates are owned by the CLI", helpers.FieldManager))
+	flagSet.BoolVar(&o.ForceConflicts, "force-conflicts", false, "If set, the server-side apply takes the ownership of the fields managed by another field manager")
+	flagSet.Var(&o.Validate, "validate", fmt.Sprintf("Validation of the resources before they are applied, %s or %s to submit them first with a server-side dry-run of their apply so the admission and schema errors of all of them are reported at once", ValidationNone, ValidationServer))
 	flagSet.BoolVar(&o.Silent, "s", false, "If set the applier will run silently")
+	flagSet.Var(&o.ProgressFormat, "progress-format", fmt.Sprintf("Format of the progress, %s or %s to emit one json event per line", progress.FormatText, progress.FormatJSON))
+}
+
+// AddValuesFlags adds only the flags providing the values, for the commands which do not apply the scenario
+func (o *ApplierScenariosOptions) AddValuesFlags(flagSet *pflag.FlagSet) {
+	flagSet.StringSliceVar(&o.ValuesPaths, "values", nil, "The files containing the values, deep merged in order so the last file wins (can specify multiple or separate files with commas: base.yaml,prod.yaml)")
+	flagSet.StringArrayVar(&o.SetValues, "set", nil, "Set values on the command line, merged after the values file (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	flagSet.StringArrayVar(&o.SetFileValues, "set-file", nil, "Set values from files on the command line, merged after the values file (can specify multiple: key1=path1)")
+	flagSet.BoolVar(&o.EnvSubstitution, "env-substitution", false, "If set, the ${NAME} references of the values files are replaced by the environment variables, $${NAME} is kept as ${NAME}")
+	flagSet.BoolVar(&o.SkipCredentialResolution, "skip-credential-resolution", false, "If set, the vault:// and keychain:// references of the credential values are not resolved")
+	_ = flagSet.MarkHidden("skip-credential-resolution")
+}
+
+// ApplierTimeout returns the timeout in seconds expected by the applier, at least one second
+func (o *ApplierScenariosOptions) ApplierTimeout() int {
+	seconds := int(math.Ceil(o.Timeout.Seconds()))
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// ReadValues reads the values files, each one deep merged over the previous ones, substitutes the environment
+// variables, merges the --set and --set-file values and resolves the credential references,
+// the global --quiet flag also silences the applier
+func (o *ApplierScenariosOptions) ReadValues() (map[string]interface{}, error) {
+	if printers.Quiet {
+		o.Silent = true
+	}
+	//The first file is completed by the values piped on the input
+	var b []byte
+	var err error
+	if len(o.ValuesPaths) != 0 && o.ValuesPaths[0] != "" {
+		b, err = ioutil.ReadFile(filepath.Clean(o.ValuesPaths[0]))
+		if err != nil {
+			return nil, err
+		}
+	}
+	piped, err := o.readPipedValues()
+	if err != nil {
+		return nil, err
+	}
+	if len(piped) != 0 {
+		b = append(append(b, '\n'), piped...)
+	}
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(b, &values); err != nil {
+		return nil, err
+	}
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+	for i := 1; i < len(o.ValuesPaths); i++ {
+		overrides, err := readValuesFile(o.ValuesPaths[i])
+		if err != nil {
+			return nil, err
+		}
+		MergeValues(values, overrides)
+	}
+	if o.EnvSubstitution {
+		if err := SubstituteEnv(values, os.LookupEnv); err != nil {
+			return nil, err
+		}
+	}
+	if err := MergeSetValues(values, o.SetValues); err != nil {
+		return nil, err
+	}
+	if err := MergeSetFileValues(values, o.SetFileValues); err != nil {
+		return nil, err
+	}
+	//The vault:// and keychain:// references are resolved last so the --set values can be references as well
+	if err := o.ResolveCredentialValues(values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// ResolveCredentialValues resolves the vault:// and keychain:// references of the CredentialPaths values,
+// unless the resolution is disabled
+func (o *ApplierScenariosOptions) ResolveCredentialValues(values map[string]interface{}) error {
+	if o.SkipCredentialResolution {
+		return nil
+	}
+	return credentials.ResolveValues(values, CredentialPaths...)
+}
+
+// readPipedValues reads the values piped on the input stream, the input is only read when it is a pipe
+// so the interactive input stays available for the prompts
+func (o *ApplierScenariosOptions) readPipedValues() ([]byte, error) {
+	f, ok := o.In.(*os.File)
+	if !ok {
+		return nil, nil
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.Mode()&os.ModeNamedPipe == 0 {
+		return nil, nil
+	}
+	return ioutil.ReadAll(f)
+}
+
+// readValuesFile reads a values file which is not the first one
+func readValuesFile(path string) (map[string]interface{}, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("invalid values file %s: %s", path, err.Error())
+	}
+	return values, nil
+}
+
+// ReadValuesWithDefaults reads the values like ReadValues, the values which are not set
+// are taken from the values template so the values file is optional
+func (o *ApplierScenariosOptions) ReadValuesWithDefaults(valuesTemplatePath string) (map[string]interface{}, error) {
+	values, err := o.ReadValues()
+	if err != nil {
+		return nil, err
+	}
+	b, err := resources.NewResourcesReader().Asset(valuesTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+	defaults := make(map[string]interface{})
+	if err := yaml.Unmarshal(b, &defaults); err != nil {
+		return nil, err
+	}
+	MergeDefaults(values, defaults)
+	return values, nil
+}
+
+// NewProgressReporter returns the reporter of the progress events,
+// the json format silences the human readable output so only the events are written
+func (o *ApplierScenariosOptions) NewProgressReporter() *progress.Reporter {
+	//The events are not emitted in quiet mode
+	if printers.Quiet {
+		return progress.NewReporter(progress.FormatText, o.Out)
+	}
+	r := progress.NewReporter(o.ProgressFormat, o.Out)
+	if r.Enabled() {
+		o.Silent = true
+	}
+	return r
 }
 
 func UsageTempate(cmd *cobra.Command, valuesTemplatePath string) string {
@@ -0,0 +1,35 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/progress"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestApplierScenariosOptions_NewProgressReporter(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     progress.Format
+		wantSilent bool
+	}{
+		{name: "default", format: "", wantSilent: false},
+		{name: "text", format: progress.FormatText, wantSilent: false},
+		{name: "json", format: progress.FormatJSON, wantSilent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewApplierScenariosOptions(genericclioptions.IOStreams{Out: &bytes.Buffer{}})
+			o.ProgressFormat = tt.format
+			r := o.NewProgressReporter()
+			if r.Enabled() != tt.wantSilent {
+				t.Errorf("Enabled() = %v, want %v", r.Enabled(), tt.wantSilent)
+			}
+			if o.Silent != tt.wantSilent {
+				t.Errorf("Silent = %v, want %v", o.Silent, tt.wantSilent)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/applier/pkg/applier"
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/applier/pkg/templateprocessor"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ValidationNone applies the resources without validating them first
+	ValidationNone = "none"
+	// ValidationServer submits the resources with a server-side dry-run before applying them
+	ValidationServer = "server"
+)
+
+// Validation is the --validate flag value, it is validated when the flag is parsed
+type Validation string
+
+func (v *Validation) String() string {
+	if *v == "" {
+		return ValidationNone
+	}
+	return string(*v)
+}
+
+func (v *Validation) Set(s string) error {
+	switch s {
+	case ValidationNone, ValidationServer:
+		*v = Validation(s)
+		return nil
+	}
+	return fmt.Errorf("unsupported validation %s, supported validations are %s and %s", s, ValidationNone, ValidationServer)
+}
+
+func (v *Validation) Type() string {
+	return "string"
+}
+
+// Apply creates or updates the resources rendered from the templates of the path,
+// with a server-side apply unless --server-side=false. With --validate=server, all the resources
+// are first submitted with a server-side dry-run so the rejected ones are reported before any resource of the path is applied.
+// The generation of the output file and the deletions are done by the applier.
+func (o *ApplierScenariosOptions) Apply(ctx context.Context, applyOptions *appliercmd.Options, client crclient.Client,
+	reader templateprocessor.TemplateReader, path string, values map[string]interface{}) error {
+	applying := applyOptions.OutFile == "" && !applyOptions.Delete && !applyOptions.DryRun
+	if !applying || (!o.ServerSide && o.Validate != ValidationServer) {
+		return applyOptions.ApplyWithValues(client, reader, path, values)
+	}
+	tp, err := templateprocessor.NewTemplateProcessor(reader, &templateprocessor.Options{})
+	if err != nil {
+		return err
+	}
+	manifests, err := tp.TemplateResourcesInPathUnstructured(path, []string{}, true, values)
+	if err != nil {
+		return err
+	}
+	if o.Validate == ValidationServer {
+		if err := o.validateOnServer(ctx, client, manifests); err != nil {
+			return err
+		}
+	}
+	if !o.ServerSide {
+		return applyOptions.ApplyWithValues(client, reader, path, values)
+	}
+	//The same backoff as the applier, a resource can be rejected until the CRD created before it is established
+	backoff := wait.Backoff{
+		Steps:    4,
+		Duration: 500 * time.Millisecond,
+		Factor:   5.0,
+		Jitter:   0.1,
+		Cap:      time.Duration(applyOptions.Timeout) * time.Second,
+	}
+	for _, m := range manifests {
+		var applyErr error
+		err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+			applyErr = helpers.ServerSideApply(ctx, client, m, o.ForceConflicts)
+			//The apply is not retried once the command is interrupted
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			//A conflict is not transient, it requires --force-conflicts
+			if errors.IsConflict(applyErr) {
+				return false, fmt.Errorf("unable to apply %s/%s, its fields are managed by another field manager, use --force-conflicts to take their ownership: %s",
+					m.GetKind(), m.GetName(), applyErr.Error())
+			}
+			return applyErr == nil, nil
+		})
+		if err == wait.ErrWaitTimeout {
+			return applyErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOnServer submits the manifests with a server-side dry-run, the admission webhooks and the schema
+// validation of the hub reject the invalid ones. The manifests in a namespace or of a kind created by
+// the manifests themselves can not be validated before they are applied, they are skipped.
+func (o *ApplierScenariosOptions) validateOnServer(ctx context.Context, client crclient.Client, manifests []*unstructured.Unstructured) error {
+	namespaces := make(map[string]bool)
+	kinds := make(map[schema.GroupKind]bool)
+	for _, m := range manifests {
+		switch m.GetKind() {
+		case "Namespace":
+			namespaces[m.GetName()] = true
+		case "CustomResourceDefinition":
+			group, _, _ := unstructured.NestedString(m.Object, "spec", "group")
+			kind, _, _ := unstructured.NestedString(m.Object, "spec", "names", "kind")
+			kinds[schema.GroupKind{Group: group, Kind: kind}] = true
+		}
+	}
+	failures := make([]string, 0)
+	for _, m := range manifests {
+		if namespaces[m.GetNamespace()] || kinds[m.GroupVersionKind().GroupKind()] {
+			continue
+		}
+		if err := o.dryRun(ctx, client, m); err != nil {
+			name := m.GetName()
+			if m.GetNamespace() != "" {
+				name = m.GetNamespace() + "/" + name
+			}
+			failures = append(failures, fmt.Sprintf("%s %s: %s", m.GetKind(), name, err.Error()))
+		}
+	}
+	if len(failures) != 0 {
+		return fmt.Errorf("the hub rejected %d of the %d resources with a server-side dry-run:\n  %s",
+			len(failures), len(manifests), strings.Join(failures, "\n  "))
+	}
+	return nil
+}
+
+// dryRun submits the manifest with a server-side dry-run of the request applying it: the server-side apply,
+// or with --server-side=false the create or the update of the applier, merged with the existing resource as the applier does.
+// The dry-run returns the object as it would be applied, the manifest is kept as rendered.
+func (o *ApplierScenariosOptions) dryRun(ctx context.Context, client crclient.Client, m *unstructured.Unstructured) error {
+	if o.ServerSide {
+		opts := []crclient.PatchOption{crclient.FieldOwner(helpers.FieldManager), crclient.DryRunAll}
+		if o.ForceConflicts {
+			opts = append(opts, crclient.ForceOwnership)
+		}
+		return client.Patch(ctx, m.DeepCopy(), crclient.Apply, opts...)
+	}
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(m.GroupVersionKind())
+	err := client.Get(ctx, types.NamespacedName{Namespace: m.GetNamespace(), Name: m.GetName()}, current)
+	switch {
+	case errors.IsNotFound(err):
+		return client.Create(ctx, m.DeepCopy(), crclient.DryRunAll)
+	case err != nil:
+		return err
+	}
+	future, update := applier.DefaultKubernetesMerger(current, m.DeepCopy())
+	if !update {
+		return nil
+	}
+	return client.Update(ctx, future, crclient.DryRunAll)
+}
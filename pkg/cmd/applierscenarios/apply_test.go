@@ -0,0 +1,236 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/applier/pkg/templateprocessor"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// applyClient records the server-side applies which are not supported by the fake client
+type applyClient struct {
+	crclient.Client
+	options  []crclient.PatchOptions
+	conflict bool
+	// invalid are the names of the resources rejected by the admission
+	invalid map[string]bool
+	// dryRuns are the verbs of the dry-run creates and updates, which the fake client does not support either
+	dryRuns []string
+}
+
+func (c *applyClient) Create(ctx context.Context, obj runtime.Object, opts ...crclient.CreateOption) error {
+	co := &crclient.CreateOptions{}
+	co.ApplyOptions(opts)
+	if len(co.DryRun) == 0 {
+		return c.Client.Create(ctx, obj, opts...)
+	}
+	return c.dryRun("create", obj)
+}
+
+func (c *applyClient) Update(ctx context.Context, obj runtime.Object, opts ...crclient.UpdateOption) error {
+	uo := &crclient.UpdateOptions{}
+	uo.ApplyOptions(opts)
+	if len(uo.DryRun) == 0 {
+		return c.Client.Update(ctx, obj, opts...)
+	}
+	return c.dryRun("update", obj)
+}
+
+func (c *applyClient) dryRun(verb string, obj runtime.Object) error {
+	c.dryRuns = append(c.dryRuns, verb)
+	if u, ok := obj.(*unstructured.Unstructured); ok && c.invalid[u.GetName()] {
+		return errors.NewBadRequest(fmt.Sprintf("admission webhook denied %s", u.GetName()))
+	}
+	return nil
+}
+
+func (c *applyClient) Patch(ctx context.Context, obj runtime.Object, patch crclient.Patch, opts ...crclient.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	po := crclient.PatchOptions{}
+	po.ApplyOptions(opts)
+	c.options = append(c.options, po)
+	if u, ok := obj.(*unstructured.Unstructured); ok && c.invalid[u.GetName()] {
+		return errors.NewBadRequest(fmt.Sprintf("admission webhook denied %s", u.GetName()))
+	}
+	if c.conflict {
+		return errors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "test", nil)
+	}
+	return nil
+}
+
+func TestApplierScenariosOptions_Apply(t *testing.T) {
+	reader := templateprocessor.NewTestReader(map[string]string{
+		"test/configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .name }}\n  namespace: default\ndata:\n  key: value\n",
+	})
+	values := map[string]interface{}{"name": "test"}
+	tests := []struct {
+		name           string
+		serverSide     bool
+		forceConflicts bool
+		conflict       bool
+		wantPatches    int
+		wantErr        string
+	}{
+		{
+			name:        "Success, server-side apply",
+			serverSide:  true,
+			wantPatches: 1,
+		},
+		{
+			name:           "Success, force conflicts",
+			serverSide:     true,
+			forceConflicts: true,
+			wantPatches:    1,
+		},
+		{
+			name:        "Success, applier",
+			wantPatches: 0,
+		},
+		{
+			name:        "Failed, conflict is not retried",
+			serverSide:  true,
+			conflict:    true,
+			wantPatches: 1,
+			wantErr:     "--force-conflicts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &applyClient{Client: crclientfake.NewFakeClient(), conflict: tt.conflict}
+			o := &ApplierScenariosOptions{ServerSide: tt.serverSide, ForceConflicts: tt.forceConflicts}
+			err := o.Apply(context.TODO(), &appliercmd.Options{Timeout: 1}, client, reader, "test", values)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Apply() error = %v, want %s", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if len(client.options) != tt.wantPatches {
+				t.Fatalf("Apply() got %d server-side applies, want %d", len(client.options), tt.wantPatches)
+			}
+			for _, po := range client.options {
+				if po.FieldManager != helpers.FieldManager {
+					t.Errorf("field manager = %s, want %s", po.FieldManager, helpers.FieldManager)
+				}
+				if (po.Force != nil && *po.Force) != tt.forceConflicts {
+					t.Errorf("force = %v, want %v", po.Force, tt.forceConflicts)
+				}
+			}
+			if !tt.serverSide {
+				cm := &corev1.ConfigMap{}
+				if err := client.Get(context.TODO(), types.NamespacedName{Name: "test", Namespace: "default"}, cm); err != nil {
+					t.Errorf("the applier must create the resource: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestApplierScenariosOptions_Apply_validate(t *testing.T) {
+	reader := templateprocessor.NewTestReader(map[string]string{
+		"test/namespace.yaml":  "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: new\n",
+		"test/secret.yaml":     "apiVersion: v1\nkind: Secret\nmetadata:\n  name: secret\n  namespace: new\n",
+		"test/configmap.yaml":  "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .name }}\n  namespace: default\ndata:\n  key: value\n",
+		"test/configmap2.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: other\n  namespace: default\n",
+		"test/role.yaml":       "apiVersion: rbac.authorization.k8s.io/v1\nkind: Role\nmetadata:\n  name: reader\n  namespace: default\nrules:\n- apiGroups: [\"\"]\n  resources: [\"configmaps\"]\n  verbs: [\"get\"]\n",
+	})
+	tests := []struct {
+		name        string
+		serverSide  bool
+		existing    []runtime.Object
+		invalid     map[string]bool
+		wantDryRuns int
+		wantApplies int
+		//wantApplierDryRuns are the dry-runs of the applier requests with --server-side=false
+		wantApplierDryRuns []string
+		wantErr            string
+	}{
+		{
+			name:        "Success",
+			serverSide:  true,
+			wantDryRuns: 4,
+			wantApplies: 5,
+		},
+		{
+			//The applier does not update the data of a ConfigMap, only the rules of the Role
+			name: "Success, applier",
+			existing: []runtime.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}},
+				&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "reader"}},
+			},
+			wantApplierDryRuns: []string{"create", "create", "update"},
+		},
+		{
+			name:        "Failed, rejected",
+			serverSide:  true,
+			invalid:     map[string]bool{"test": true, "other": true},
+			wantDryRuns: 4,
+			wantErr:     "the hub rejected 2 of the 5 resources",
+		},
+		{
+			name:               "Failed, rejected by the applier dry-run",
+			invalid:            map[string]bool{"test": true, "other": true},
+			wantApplierDryRuns: []string{"create", "create", "create", "create"},
+			wantErr:            "the hub rejected 2 of the 5 resources",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &applyClient{Client: crclientfake.NewFakeClient(tt.existing...), invalid: tt.invalid}
+			o := &ApplierScenariosOptions{ServerSide: tt.serverSide, Validate: ValidationServer}
+			err := o.Apply(context.TODO(), &appliercmd.Options{Timeout: 1}, client, reader, "test", map[string]interface{}{"name": "test"})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), "ConfigMap default/other") {
+					t.Fatalf("Apply() error = %v, want %s", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			dryRuns, applies := 0, 0
+			for _, po := range client.options {
+				if len(po.DryRun) != 0 {
+					dryRuns++
+				} else {
+					applies++
+				}
+			}
+			if dryRuns != tt.wantDryRuns || applies != tt.wantApplies {
+				t.Errorf("Apply() got %d dry-runs and %d applies, want %d and %d", dryRuns, applies, tt.wantDryRuns, tt.wantApplies)
+			}
+			if strings.Join(client.dryRuns, ",") != strings.Join(tt.wantApplierDryRuns, ",") {
+				t.Errorf("Apply() got the applier dry-runs %v, want %v", client.dryRuns, tt.wantApplierDryRuns)
+			}
+		})
+	}
+}
+
+func TestValidation_Set(t *testing.T) {
+	v := Validation("")
+	if v.String() != ValidationNone {
+		t.Errorf("String() = %s, want %s", v.String(), ValidationNone)
+	}
+	if err := v.Set(ValidationServer); err != nil || v != ValidationServer {
+		t.Errorf("Set(%s) = %v, got %s", ValidationServer, err, v)
+	}
+	if err := v.Set("client"); err == nil {
+		t.Error("Set(client) must fail")
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// envReference matches the ${NAME} references to the environment variables, $${NAME} is the escaped ${NAME}
+var envReference = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// SubstituteEnv replaces the ${NAME} references of the string values by the environment variables,
+// the keys are not substituted and an error lists the variables which are not set
+func SubstituteEnv(values map[string]interface{}, lookupEnv func(string) (string, bool)) error {
+	missing := make(map[string]bool)
+	substituteEnv(values, lookupEnv, missing)
+	if len(missing) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(missing))
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("the environment variables %s referenced by the values are not set", strings.Join(names, ", "))
+}
+
+func substituteEnv(value interface{}, lookupEnv func(string) (string, bool), missing map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = substituteEnv(e, lookupEnv, missing)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = substituteEnv(e, lookupEnv, missing)
+		}
+	case string:
+		return envReference.ReplaceAllStringFunc(v, func(ref string) string {
+			if strings.HasPrefix(ref, "$$") {
+				return ref[1:]
+			}
+			name := envReference.FindStringSubmatch(ref)[1]
+			env, ok := lookupEnv(name)
+			if !ok {
+				missing[name] = true
+			}
+			return env
+		})
+	}
+	return value
+}
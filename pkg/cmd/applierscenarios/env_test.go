@@ -0,0 +1,59 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSubstituteEnv(t *testing.T) {
+	env := map[string]string{"CLUSTER_TOKEN": "sha256~abc", "CLUSTER_SERVER": "https://api.cluster1:6443", "EMPTY": ""}
+	lookupEnv := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	tests := []struct {
+		name    string
+		values  map[string]interface{}
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			values: map[string]interface{}{
+				"server": "${CLUSTER_SERVER}",
+				"token":  "Bearer ${CLUSTER_TOKEN}",
+				"empty":  "${EMPTY}",
+				"price":  "$${CLUSTER_TOKEN} and $5",
+				"port":   int64(6443),
+				"labels": map[string]interface{}{"${CLUSTER_TOKEN}": "${CLUSTER_TOKEN}"},
+				"hosts":  []interface{}{"${CLUSTER_SERVER}"},
+			},
+			want: map[string]interface{}{
+				"server": "https://api.cluster1:6443",
+				"token":  "Bearer sha256~abc",
+				"empty":  "",
+				"price":  "${CLUSTER_TOKEN} and $5",
+				"port":   int64(6443),
+				"labels": map[string]interface{}{"${CLUSTER_TOKEN}": "sha256~abc"},
+				"hosts":  []interface{}{"https://api.cluster1:6443"},
+			},
+		},
+		{
+			name:    "Failed, variable not set",
+			values:  map[string]interface{}{"token": "${CLUSTER_TOKEN}", "kubeConfig": "${KUBECONFIG_CONTENT}"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SubstituteEnv(tt.values, lookupEnv)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SubstituteEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(tt.values, tt.want) {
+				t.Errorf("SubstituteEnv() = %v, want %v", tt.values, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"strings"
+
+	"github.com/open-cluster-management/applier/pkg/templateprocessor"
+)
+
+const (
+	// FunctionSourceApplier are the functions added by the applier
+	FunctionSourceApplier = "applier"
+	// FunctionSourceSprig are the sprig functions the applier adds to the templates
+	FunctionSourceSprig = "sprig"
+)
+
+// TemplateFunction is a function available in the templates of the scenarios
+type TemplateFunction struct {
+	Name        string `json:"name"`
+	Source      string `json:"source"`
+	Usage       string `json:"usage"`
+	Description string `json:"description"`
+	Example     string `json:"example"`
+}
+
+// FunctionExampleValues are the values the examples of the functions are rendered with
+var FunctionExampleValues = map[string]interface{}{
+	"name":   "mycluster",
+	"labels": map[string]interface{}{"env": "dev", "region": "eu"},
+	"token":  "my-token",
+}
+
+// TemplateFunctions are the most useful functions of the scenario templates, the applier renders
+// the templates with its own functions and the text functions of sprig (http://masterminds.github.io/sprig/)
+var TemplateFunctions = []TemplateFunction{
+	{Name: "toYaml", Source: FunctionSourceApplier, Usage: "toYaml VALUE",
+		Description: "Marshal a value in yaml, used with nindent to embed a values section in a manifest",
+		Example:     `{{ .labels | toYaml }}`},
+	{Name: "encodeBase64", Source: FunctionSourceApplier, Usage: "encodeBase64 STRING",
+		Description: "Encode a string in base64, same as b64enc",
+		Example:     `{{ .token | encodeBase64 }}`},
+	{Name: "include", Source: FunctionSourceApplier, Usage: "include TEMPLATE VALUES",
+		Description: "Render a template defined in the _helpers.tpl of the directory and return it as a string",
+		Example:     `{{ define "greeting" }}hello {{ . }}{{ end }}{{ include "greeting" .name | upper }}`},
+	{Name: "b64enc", Source: FunctionSourceSprig, Usage: "b64enc STRING",
+		Description: "Encode a string in base64, e.g. the data of a secret",
+		Example:     `{{ .token | b64enc }}`},
+	{Name: "b64dec", Source: FunctionSourceSprig, Usage: "b64dec STRING",
+		Description: "Decode a base64 string",
+		Example:     `{{ "bXktdG9rZW4=" | b64dec }}`},
+	{Name: "indent", Source: FunctionSourceSprig, Usage: "indent N STRING",
+		Description: "Indent each line of a string with N spaces",
+		Example:     `{{ "a: 1" | indent 4 }}`},
+	{Name: "nindent", Source: FunctionSourceSprig, Usage: "nindent N STRING",
+		Description: "Indent each line of a string with N spaces, after a new line",
+		Example:     `spec:{{ "a: 1\nb: 2" | nindent 2 }}`},
+	{Name: "default", Source: FunctionSourceSprig, Usage: "default DEFAULT VALUE",
+		Description: "Return the default if the value is empty or not set",
+		Example:     `{{ .namespace | default "open-cluster-management" }}`},
+	{Name: "empty", Source: FunctionSourceSprig, Usage: "empty VALUE",
+		Description: "Return true if the value is empty or not set",
+		Example:     `{{ empty .namespace }}`},
+	{Name: "coalesce", Source: FunctionSourceSprig, Usage: "coalesce VALUE...",
+		Description: "Return the first value which is not empty",
+		Example:     `{{ coalesce .namespace .name }}`},
+	{Name: "ternary", Source: FunctionSourceSprig, Usage: "ternary TRUE FALSE CONDITION",
+		Description: "Return the first value if the condition is true, the second otherwise",
+		Example:     `{{ ternary "Hosted" "Default" (hasKey . "hostingCluster") }}`},
+	{Name: "quote", Source: FunctionSourceSprig, Usage: "quote VALUE",
+		Description: "Wrap a value in double quotes, to keep a yaml string such as \"true\" a string",
+		Example:     `{{ "true" | quote }}`},
+	{Name: "toJson", Source: FunctionSourceSprig, Usage: "toJson VALUE",
+		Description: "Marshal a value in json",
+		Example:     `{{ .labels | toJson }}`},
+	{Name: "hasKey", Source: FunctionSourceSprig, Usage: "hasKey MAP KEY",
+		Description: "Return true if the map has the key",
+		Example:     `{{ hasKey .labels "env" }}`},
+	{Name: "get", Source: FunctionSourceSprig, Usage: "get MAP KEY",
+		Description: "Return the value of a key of a map, empty if it is not set",
+		Example:     `{{ get .labels "region" }}`},
+	{Name: "dict", Source: FunctionSourceSprig, Usage: "dict KEY VALUE...",
+		Description: "Create a map from key and value pairs",
+		Example:     `{{ dict "cluster" .name | toJson }}`},
+	{Name: "list", Source: FunctionSourceSprig, Usage: "list VALUE...",
+		Description: "Create a list of values",
+		Example:     `{{ list "a" "b" | join "," }}`},
+	{Name: "join", Source: FunctionSourceSprig, Usage: "join SEPARATOR LIST",
+		Description: "Join the values of a list with a separator",
+		Example:     `{{ splitList "-" "a-b-c" | join "," }}`},
+	{Name: "splitList", Source: FunctionSourceSprig, Usage: "splitList SEPARATOR STRING",
+		Description: "Split a string in a list",
+		Example:     `{{ index (splitList "." "api.example.com") 1 }}`},
+	{Name: "replace", Source: FunctionSourceSprig, Usage: "replace OLD NEW STRING",
+		Description: "Replace all the occurrences of a string",
+		Example:     `{{ .name | replace "my" "your" }}`},
+	{Name: "trunc", Source: FunctionSourceSprig, Usage: "trunc N STRING",
+		Description: "Truncate a string to N characters, e.g. a name to the 63 characters of a label value",
+		Example:     `{{ .name | trunc 2 }}`},
+	{Name: "lower", Source: FunctionSourceSprig, Usage: "lower STRING",
+		Description: "Convert a string to lower case",
+		Example:     `{{ "MyCluster" | lower }}`},
+	{Name: "sha256sum", Source: FunctionSourceSprig, Usage: "sha256sum STRING",
+		Description: "Return the sha256 hash of a string, e.g. to roll out a deployment when its configuration changes",
+		Example:     `{{ .token | sha256sum | trunc 8 }}`},
+	{Name: "randAlphaNum", Source: FunctionSourceSprig, Usage: "randAlphaNum N",
+		Description: "Generate a random string of N alphanumeric characters",
+		Example:     `{{ randAlphaNum 8 | len }}`},
+	{Name: "fail", Source: FunctionSourceSprig, Usage: "fail MESSAGE",
+		Description: "Fail the rendering with a message, to reject invalid values",
+		Example:     `{{ if not .name }}{{ fail "name is required" }}{{ end }}ok`},
+}
+
+// GetTemplateFunction returns the function of the name
+func GetTemplateFunction(name string) (TemplateFunction, bool) {
+	for _, f := range TemplateFunctions {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return TemplateFunction{}, false
+}
+
+// RenderExample renders the example of the function with the FunctionExampleValues,
+// the same way as the templates of the scenarios
+func (f TemplateFunction) RenderExample() (string, error) {
+	reader := &templateprocessor.YamlStringReader{Yamls: []string{f.Example}}
+	tp, err := templateprocessor.NewTemplateProcessor(reader, &templateprocessor.Options{})
+	if err != nil {
+		return "", err
+	}
+	b, err := tp.TemplateResource("0", FunctionExampleValues)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(b), "\n"), nil
+}
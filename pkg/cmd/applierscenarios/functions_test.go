@@ -0,0 +1,59 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"testing"
+)
+
+func TestTemplateFunction_RenderExample(t *testing.T) {
+	want := map[string]string{
+		"toYaml":       "env: dev\nregion: eu",
+		"encodeBase64": "bXktdG9rZW4=",
+		"include":      "HELLO MYCLUSTER",
+		"b64enc":       "bXktdG9rZW4=",
+		"b64dec":       "my-token",
+		"indent":       "    a: 1",
+		"nindent":      "spec:\n  a: 1\n  b: 2",
+		"default":      "open-cluster-management",
+		"empty":        "true",
+		"coalesce":     "mycluster",
+		"ternary":      "Default",
+		"quote":        `"true"`,
+		"toJson":       `{"env":"dev","region":"eu"}`,
+		"hasKey":       "true",
+		"get":          "eu",
+		"dict":         `{"cluster":"mycluster"}`,
+		"list":         "a,b",
+		"join":         "a,b,c",
+		"splitList":    "example",
+		"replace":      "yourcluster",
+		"trunc":        "my",
+		"lower":        "mycluster",
+		"sha256sum":    "fece50d2",
+		"randAlphaNum": "8",
+		"fail":         "ok",
+	}
+	for _, f := range TemplateFunctions {
+		t.Run(f.Name, func(t *testing.T) {
+			got, err := f.RenderExample()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if w, ok := want[f.Name]; !ok || got != w {
+				t.Errorf("RenderExample() = %q, want %q", got, w)
+			}
+		})
+	}
+	if len(want) != len(TemplateFunctions) {
+		t.Errorf("%d functions expected, got %d", len(want), len(TemplateFunctions))
+	}
+}
+
+func TestGetTemplateFunction(t *testing.T) {
+	if f, ok := GetTemplateFunction("b64enc"); !ok || f.Source != FunctionSourceSprig {
+		t.Errorf("GetTemplateFunction(b64enc) = %v, %v", f, ok)
+	}
+	if _, ok := GetTemplateFunction("lookup"); ok {
+		t.Error("GetTemplateFunction(lookup) must not be found")
+	}
+}
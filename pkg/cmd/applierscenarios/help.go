@@ -0,0 +1,90 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/spf13/cobra"
+)
+
+// GetScenarioByCommand returns the scenario applied by the command, for example attach cluster
+func GetScenarioByCommand(command string) (Scenario, bool) {
+	command = strings.Join(strings.Fields(command), " ")
+	for _, s := range Scenarios {
+		if s.Command == command {
+			return s, true
+		}
+	}
+	return Scenario{}, false
+}
+
+// ScaffoldValues returns the example values file of the scenario with a header
+// giving the command reading it, commandHeader is the name of the cli in the examples
+func (s Scenario) ScaffoldValues(commandHeader string) ([]byte, error) {
+	b, err := s.ValuesTemplate()
+	if err != nil {
+		return nil, err
+	}
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "# Values of %[1]s %[2]s, set the required values then run:\n# %[1]s %[2]s --values values.yaml\n",
+		commandHeader, s.Command)
+	//The copyright of the template is not the one of the values file of the user
+	for _, line := range strings.SplitAfter(string(ExampleValues(b)), "\n") {
+		if !strings.HasPrefix(line, "# Copyright") {
+			out.WriteString(line)
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// ValuesHelp returns the values of the scenario with their default and description, for the long help of its command
+func (s Scenario) ValuesHelp() (string, error) {
+	b, err := s.ValuesTemplate()
+	if err != nil {
+		return "", err
+	}
+	table := &printers.Table{Headers: []string{"VALUE", "DEFAULT", "DESCRIPTION"}}
+	for _, v := range ParseValuesTemplate(b) {
+		value := v.Default
+		if v.Required {
+			value = "(required)"
+		}
+		table.AddRow(v.Path, value, v.Description)
+	}
+	out := &bytes.Buffer{}
+	if err := printers.PrintTable(out, table); err != nil {
+		return "", err
+	}
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	return "Values of the values file:\n\n  " + strings.Join(lines, "\n  "), nil
+}
+
+// AddValuesHelp appends the values of the scenarios to the long help of the commands applying them,
+// and the scaffolding of their values file to their examples
+func AddValuesHelp(root *cobra.Command, commandHeader string) {
+	for _, c := range root.Commands() {
+		AddValuesHelp(c, commandHeader)
+	}
+	command := strings.TrimSpace(strings.TrimPrefix(root.CommandPath(), root.Root().Name()))
+	s, ok := GetScenarioByCommand(command)
+	if !ok || root.Root() == root {
+		return
+	}
+	help, err := s.ValuesHelp()
+	if err != nil {
+		return
+	}
+	long := root.Long
+	if long == "" {
+		long = root.Short
+	}
+	root.Long = long + "\n\n" + help
+	root.Example = strings.TrimRight(root.Example, "\n") + fmt.Sprintf("\n\n# Write an example values file with the description of the values\n%s example %s > values.yaml\n",
+		commandHeader, s.Command)
+}
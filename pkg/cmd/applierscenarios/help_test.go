@@ -0,0 +1,52 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestGetScenarioByCommand(t *testing.T) {
+	if s, ok := GetScenarioByCommand(" attach  cluster"); !ok || s.Name != "attach" {
+		t.Errorf("GetScenarioByCommand() = %v, %v, want the attach scenario", s, ok)
+	}
+	if _, ok := GetScenarioByCommand("get clusters"); ok {
+		t.Error("get clusters has no scenario")
+	}
+}
+
+func TestScenario_ScaffoldValues(t *testing.T) {
+	s, _ := GetScenario("attach")
+	b, err := s.ScaffoldValues("cm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(b), "# Values of cm attach cluster") || strings.Contains(string(b), "# Copyright") {
+		t.Errorf("unexpected header:\n%s", string(b)[:200])
+	}
+}
+
+func TestAddValuesHelp(t *testing.T) {
+	root := &cobra.Command{Use: "cm"}
+	attach := &cobra.Command{Use: "attach"}
+	cluster := &cobra.Command{Use: "cluster", Short: "Import a cluster", Example: "cm attach cluster --values values.yaml\n"}
+	get := &cobra.Command{Use: "get"}
+	clusters := &cobra.Command{Use: "clusters", Short: "List the clusters"}
+	attach.AddCommand(cluster)
+	get.AddCommand(clusters)
+	root.AddCommand(attach, get)
+
+	AddValuesHelp(root, "cm")
+	if !strings.HasPrefix(cluster.Long, "Import a cluster\n\nValues of the values file:") ||
+		!strings.Contains(cluster.Long, "managedClusterName") {
+		t.Errorf("the values of the attach scenario expected in the long help, got:\n%s", cluster.Long)
+	}
+	if !strings.HasSuffix(cluster.Example, "cm example attach cluster > values.yaml\n") {
+		t.Errorf("the scaffolding expected in the examples, got:\n%s", cluster.Example)
+	}
+	if clusters.Long != "" || attach.Long != "" || root.Long != "" {
+		t.Error("only the commands of a scenario have the values in their help")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+)
+
+const valuesTemplateFile = "values-template.yaml"
+
+// Scenario is a bundled applier scenario, its templates and its values template are in scenarios/<name>
+type Scenario struct {
+	Name        string `json:"name"`
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+// Scenarios are the bundled scenarios sorted by name
+var Scenarios = []Scenario{
+	{Name: "application", Command: "application create", Description: "Deploy an application from a Git, Helm or object storage channel on the clusters of a placement"},
+	{Name: "attach", Command: "attach cluster", Description: "Attach an existing cluster to the hub, with its import secret, addons and optional Hive adoption"},
+	{Name: "clusterpool", Command: "clusterpool create", Description: "Create a Hive clusterpool keeping clusters ready to be claimed on a cloud provider"},
+	{Name: "create", Command: "create cluster", Description: "Provision a new OpenShift cluster with Hive on a cloud provider and attach it"},
+	{Name: "delete", Command: "delete cluster", Description: "Delete a cluster provisioned by the hub and its cloud infrastructure"},
+	{Name: "detach", Command: "detach cluster", Description: "Detach a cluster from the hub, the cluster itself is kept"},
+	{Name: "init", Command: "init hub", Description: "Install a hub, the cluster-manager or Red Hat Advanced Cluster Management"},
+	{Name: "join", Command: "join hub", Description: "Install the klusterlet on a cluster to request its registration on a hub"},
+	{Name: "observability", Command: "observability enable", Description: "Enable the multicluster observability with a thanos object storage"},
+	{Name: "policy", Command: "policy create", Description: "Create a policy and the placement of the clusters it applies to"},
+	{Name: "submariner", Command: "submariner join", Description: "Connect the networks of the clusters of a clusterset with submariner"},
+}
+
+// GetScenario returns the scenario of the name
+func GetScenario(name string) (Scenario, bool) {
+	for _, s := range Scenarios {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Scenario{}, false
+}
+
+// ScenarioNames returns the names of the scenarios
+func ScenarioNames() []string {
+	names := make([]string, len(Scenarios))
+	for i, s := range Scenarios {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// Directory returns the directory of the scenario in the bundled resources
+func (s Scenario) Directory() string {
+	return path.Join("scenarios", s.Name)
+}
+
+// ValuesTemplate returns the values template of the scenario
+func (s Scenario) ValuesTemplate() ([]byte, error) {
+	return resources.NewResourcesReader().Asset(path.Join(s.Directory(), valuesTemplateFile))
+}
+
+// Templates returns the sorted templates of the scenario, relative to its directory
+func (s Scenario) Templates() ([]string, error) {
+	names, err := resources.NewResourcesReader().AssetNames()
+	if err != nil {
+		return nil, err
+	}
+	prefix := s.Directory() + "/"
+	templates := make([]string, 0)
+	for _, name := range names {
+		name = filepath.ToSlash(name)
+		if !strings.HasPrefix(name, prefix) || name == prefix+valuesTemplateFile {
+			continue
+		}
+		templates = append(templates, strings.TrimPrefix(name, prefix))
+	}
+	sort.Strings(templates)
+	return templates, nil
+}
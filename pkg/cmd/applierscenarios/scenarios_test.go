@@ -0,0 +1,39 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+)
+
+func TestScenarios(t *testing.T) {
+	for _, s := range Scenarios {
+		if _, err := s.ValuesTemplate(); err != nil {
+			t.Errorf("scenario %s: %v", s.Name, err)
+		}
+		templates, err := s.Templates()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(templates) == 0 {
+			t.Errorf("scenario %s has no template", s.Name)
+		}
+	}
+	//Each bundled scenario is listed
+	names, err := resources.NewResourcesReader().AssetNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		parts := strings.Split(filepath.ToSlash(name), "/")
+		if len(parts) < 2 || parts[0] != "scenarios" {
+			continue
+		}
+		if _, ok := GetScenario(parts[1]); !ok {
+			t.Errorf("the bundled scenario %s is not listed", parts[1])
+		}
+	}
+}
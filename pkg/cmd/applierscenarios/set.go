@@ -0,0 +1,102 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// MergeSetValues merges the --set key=value pairs in the values, a pair can hold several
+// comma separated assignments, a comma is escaped with a backslash.
+// true and false are set as booleans, integers as int64 and null removes the value.
+func MergeSetValues(values map[string]interface{}, sets []string) error {
+	for _, set := range sets {
+		for _, assignment := range splitAssignments(set) {
+			path, value, err := parseAssignment(assignment)
+			if err != nil {
+				return err
+			}
+			if err := setValue(values, path, typedValue(value)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MergeSetFileValues merges the --set-file key=path pairs in the values, the value is the content of the file
+func MergeSetFileValues(values map[string]interface{}, setFiles []string) error {
+	for _, setFile := range setFiles {
+		path, file, err := parseAssignment(setFile)
+		if err != nil {
+			return err
+		}
+		b, err := ioutil.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return fmt.Errorf("unable to read the file of %s: %s", path, err.Error())
+		}
+		if err := setValue(values, path, string(b)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseAssignment(assignment string) (string, string, error) {
+	kv := strings.SplitN(assignment, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return "", "", fmt.Errorf("invalid value %q, the format is key=value", assignment)
+	}
+	return kv[0], kv[1], nil
+}
+
+// splitAssignments splits on the commas which are not escaped
+func splitAssignments(s string) []string {
+	assignments := make([]string, 0)
+	var current strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == ',':
+			current.WriteByte(',')
+			i++
+		case s[i] == ',':
+			assignments = append(assignments, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(s[i])
+		}
+	}
+	return append(assignments, current.String())
+}
+
+func typedValue(value string) interface{} {
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	return value
+}
+
+func setValue(values map[string]interface{}, path string, value interface{}) error {
+	fields := strings.Split(path, ".")
+	if value == nil {
+		unstructured.RemoveNestedField(values, fields...)
+		return nil
+	}
+	if err := unstructured.SetNestedField(values, value, fields...); err != nil {
+		return fmt.Errorf("unable to set %s: %s", path, err.Error())
+	}
+	return nil
+}
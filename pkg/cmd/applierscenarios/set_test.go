@@ -0,0 +1,156 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/credentials"
+)
+
+var applierScenariosTestDir = filepath.Join("..", "..", "..", "test", "unit", "resources", "applierscenarios")
+var setFileTestPath = filepath.Join(applierScenariosTestDir, "set-file.txt")
+
+func TestMergeSetValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		sets    []string
+		values  map[string]interface{}
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "Typed values",
+			sets:   []string{"name=mycluster", "retry=3", "addons.search.enabled=false"},
+			values: map[string]interface{}{"name": "from-values"},
+			want: map[string]interface{}{
+				"name":   "mycluster",
+				"retry":  int64(3),
+				"addons": map[string]interface{}{"search": map[string]interface{}{"enabled": false}},
+			},
+		},
+		{
+			name:   "Comma separated and escaped",
+			sets:   []string{`a=1,b=x\,y`},
+			values: map[string]interface{}{},
+			want:   map[string]interface{}{"a": int64(1), "b": "x,y"},
+		},
+		{
+			name:   "Null removes the value",
+			sets:   []string{"a.b=null"},
+			values: map[string]interface{}{"a": map[string]interface{}{"b": "c", "d": "e"}},
+			want:   map[string]interface{}{"a": map[string]interface{}{"d": "e"}},
+		},
+		{
+			name:    "Failed, no equal",
+			sets:    []string{"a"},
+			values:  map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, not a map",
+			sets:    []string{"a.b=c"},
+			values:  map[string]interface{}{"a": "string"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MergeSetValues(tt.values, tt.sets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MergeSetValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(tt.values, tt.want) {
+				t.Errorf("MergeSetValues() = %v, want %v", tt.values, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeSetFileValues(t *testing.T) {
+	values := map[string]interface{}{}
+	if err := MergeSetFileValues(values, []string{"config.content=" + setFileTestPath}); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"config": map[string]interface{}{"content": "line1\nline2\n"}}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("MergeSetFileValues() = %v, want %v", values, want)
+	}
+	if err := MergeSetFileValues(values, []string{"a=missing-file"}); err == nil {
+		t.Error("MergeSetFileValues() expected an error for a missing file")
+	}
+}
+
+func TestApplierScenariosOptions_ReadValues(t *testing.T) {
+	o := &ApplierScenariosOptions{
+		SetValues:     []string{"name=mycluster"},
+		SetFileValues: []string{"kubeConfig=" + setFileTestPath},
+	}
+	values, err := o.ReadValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"name": "mycluster", "kubeConfig": "line1\nline2\n"}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("ReadValues() = %v, want %v", values, want)
+	}
+}
+
+func TestApplierScenariosOptions_ReadValues_multipleFiles(t *testing.T) {
+	o := &ApplierScenariosOptions{
+		ValuesPaths: []string{
+			filepath.Join(applierScenariosTestDir, "values-base.yaml"),
+			filepath.Join(applierScenariosTestDir, "values-overrides.yaml"),
+		},
+		SetValues: []string{"managedClusterName=prod"},
+	}
+	values, err := o.ReadValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"managedClusterName": "prod",
+		"autoImportRetry":    float64(2),
+		"addons": map[string]interface{}{
+			"searchCollector":  map[string]interface{}{"enabled": false},
+			"policyController": map[string]interface{}{"enabled": true},
+		},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("ReadValues() = %v, want %v", values, want)
+	}
+
+	o.ValuesPaths = append(o.ValuesPaths, "missing.yaml")
+	if _, err := o.ReadValues(); err == nil {
+		t.Error("ReadValues() expected an error for a missing file")
+	}
+}
+
+func TestApplierScenariosOptions_ReadValues_credentials(t *testing.T) {
+	credentials.Register(&credentials.KeychainProvider{
+		GOOS: "linux",
+		Run: func(name string, args ...string) ([]byte, error) {
+			return []byte("my-token\n"), nil
+		},
+	})
+	o := &ApplierScenariosOptions{
+		SetValues: []string{"token=keychain://cm-cli#prod"},
+	}
+	values, err := o.ReadValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["token"] != "my-token" {
+		t.Errorf("the token reference must be resolved, got %v", values["token"])
+	}
+
+	o.SkipCredentialResolution = true
+	values, err = o.ReadValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["token"] != "keychain://cm-cli#prod" {
+		t.Errorf("the token reference must be kept, got %v", values["token"])
+	}
+}
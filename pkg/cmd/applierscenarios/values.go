@@ -0,0 +1,157 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ValueType is the type of a value of a values file
+type ValueType string
+
+const (
+	StringValue ValueType = "string"
+	IntValue    ValueType = "int"
+	BoolValue   ValueType = "bool"
+	// FileValue is a string value whose flag is the path of a file, the value is the content of the file
+	FileValue ValueType = "file"
+)
+
+// CredentialPaths are the paths of the values holding credentials, the only values whose vault:// and keychain://
+// references are resolved: the kubeconfig and token importing a cluster and the token joining a hub
+var CredentialPaths = []string{"kubeConfig", "token", "hub.token"}
+
+// ValueSchema describes a value of the values file and the flag overwriting it
+type ValueSchema struct {
+	// Path is the dot separated path of the value in the values file
+	Path  string
+	Flag  string
+	Type  ValueType
+	Usage string
+	// DeprecatedFlag is a former name of the flag, kept as a deprecated alias
+	DeprecatedFlag string
+}
+
+// ValuesSchema is the single definition of the values of a scenario from which the flags are generated
+type ValuesSchema []ValueSchema
+
+// AddFlags adds a flag for each value of the schema
+func (s ValuesSchema) AddFlags(flagSet *pflag.FlagSet) {
+	for _, v := range s {
+		usage := fmt.Sprintf("%s, overwrites %s of the values file", v.Usage, v.Path)
+		switch v.Type {
+		case IntValue:
+			flagSet.Int(v.Flag, 0, usage)
+		case BoolValue:
+			flagSet.Bool(v.Flag, false, usage)
+		default:
+			flagSet.String(v.Flag, "", usage)
+		}
+		if v.DeprecatedFlag != "" {
+			flagSet.Var(flagSet.Lookup(v.Flag).Value, v.DeprecatedFlag, usage)
+			_ = flagSet.MarkDeprecated(v.DeprecatedFlag, "use --"+v.Flag+" instead")
+		}
+	}
+}
+
+// MergeFlags sets in the values the flags set by the user, the flags always win over the values file
+func (s ValuesSchema) MergeFlags(flagSet *pflag.FlagSet, values map[string]interface{}) (err error) {
+	if flagSet == nil {
+		return nil
+	}
+	for _, v := range s {
+		if !flagSet.Changed(v.Flag) && (v.DeprecatedFlag == "" || !flagSet.Changed(v.DeprecatedFlag)) {
+			continue
+		}
+		var value interface{}
+		switch v.Type {
+		case IntValue:
+			var i int
+			i, err = flagSet.GetInt(v.Flag)
+			value = int64(i)
+		case BoolValue:
+			value, err = flagSet.GetBool(v.Flag)
+		case FileValue:
+			//An empty path clears the value of the values file
+			var path string
+			var b []byte
+			path, err = flagSet.GetString(v.Flag)
+			if err == nil && path != "" {
+				b, err = ioutil.ReadFile(filepath.Clean(path))
+			}
+			value = string(b)
+		default:
+			value, err = flagSet.GetString(v.Flag)
+		}
+		if err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedField(values, value, strings.Split(v.Path, ".")...); err != nil {
+			return fmt.Errorf("unable to set %s from flag --%s: %s", v.Path, v.Flag, err.Error())
+		}
+	}
+	return nil
+}
+
+// FlagNames returns the names of the flags of the schema, including the deprecated ones
+func (s ValuesSchema) FlagNames() []string {
+	names := make([]string, 0, len(s))
+	for _, v := range s {
+		names = append(names, v.Flag)
+		if v.DeprecatedFlag != "" {
+			names = append(names, v.DeprecatedFlag)
+		}
+	}
+	return names
+}
+
+// GetString returns the string value at the dot separated path or an empty string if not set
+func GetString(values map[string]interface{}, path string) string {
+	v, found, err := unstructured.NestedFieldNoCopy(values, strings.Split(path, ".")...)
+	if !found || err != nil || v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// MergeDefaults sets in the values the defaults which are not set
+func MergeDefaults(values, defaults map[string]interface{}) {
+	for k, d := range defaults {
+		v, ok := values[k]
+		if !ok || v == nil {
+			values[k] = d
+			continue
+		}
+		vm, vok := v.(map[string]interface{})
+		dm, dok := d.(map[string]interface{})
+		if vok && dok {
+			MergeDefaults(vm, dm)
+		}
+	}
+}
+
+// MergeValues deep merges the overrides in the values like helm merges its values files:
+// the maps are merged, the other values are replaced and a null override removes the value
+func MergeValues(values, overrides map[string]interface{}) {
+	for k, o := range overrides {
+		if o == nil {
+			delete(values, k)
+			continue
+		}
+		vm, vok := values[k].(map[string]interface{})
+		om, ook := o.(map[string]interface{})
+		if vok && ook {
+			MergeValues(vm, om)
+			continue
+		}
+		values[k] = o
+	}
+}
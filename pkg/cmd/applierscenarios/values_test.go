@@ -0,0 +1,205 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+var testSchema = ValuesSchema{
+	{Path: "name", Flag: "name", Type: StringValue, Usage: "Name", DeprecatedFlag: "nmae"},
+	{Path: "retry", Flag: "retry", Type: IntValue, Usage: "Retry"},
+	{Path: "addons.search.enabled", Flag: "search", Type: BoolValue, Usage: "Search"},
+}
+
+func TestValuesSchema_MergeFlags(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		values map[string]interface{}
+		want   map[string]interface{}
+	}{
+		{
+			name: "No flags, values kept",
+			values: map[string]interface{}{
+				"name":  "from-values",
+				"retry": int64(5),
+			},
+			want: map[string]interface{}{
+				"name":  "from-values",
+				"retry": int64(5),
+			},
+		},
+		{
+			name: "Flags win",
+			args: []string{"--name", "from-flag", "--retry", "2", "--search=false"},
+			values: map[string]interface{}{
+				"name":  "from-values",
+				"retry": int64(5),
+				"addons": map[string]interface{}{
+					"search": map[string]interface{}{"enabled": true},
+				},
+			},
+			want: map[string]interface{}{
+				"name":  "from-flag",
+				"retry": int64(2),
+				"addons": map[string]interface{}{
+					"search": map[string]interface{}{"enabled": false},
+				},
+			},
+		},
+		{
+			name:   "Deprecated flag",
+			args:   []string{"--nmae", "from-flag"},
+			values: map[string]interface{}{"name": "from-values"},
+			want:   map[string]interface{}{"name": "from-flag"},
+		},
+		{
+			name:   "Flags create missing values",
+			args:   []string{"--search"},
+			values: map[string]interface{}{},
+			want: map[string]interface{}{
+				"addons": map[string]interface{}{
+					"search": map[string]interface{}{"enabled": true},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			testSchema.AddFlags(flagSet)
+			if err := flagSet.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := testSchema.MergeFlags(flagSet, tt.values); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tt.values, tt.want) {
+				t.Errorf("MergeFlags() got %v, want %v", tt.values, tt.want)
+			}
+		})
+	}
+}
+
+func TestValuesSchema_MergeFlags_file(t *testing.T) {
+	dir, err := ioutil.TempDir("", "values")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "kubeconfig")
+	if err := ioutil.WriteFile(path, []byte("apiVersion: v1\nkind: Config\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	schema := ValuesSchema{{Path: "kubeConfig", Flag: "kubeconfig", Type: FileValue, Usage: "Kubeconfig"}}
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	schema.AddFlags(flagSet)
+	if err := flagSet.Parse([]string{"--kubeconfig", path}); err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]interface{}{"kubeConfig": "from-values"}
+	if err := schema.MergeFlags(flagSet, values); err != nil {
+		t.Fatal(err)
+	}
+	if values["kubeConfig"] != "apiVersion: v1\nkind: Config\n" {
+		t.Errorf("the value must be the content of the file, got %v", values["kubeConfig"])
+	}
+
+	flagSet = pflag.NewFlagSet("test", pflag.ContinueOnError)
+	schema.AddFlags(flagSet)
+	if err := flagSet.Parse([]string{"--kubeconfig", filepath.Join(dir, "missing")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.MergeFlags(flagSet, map[string]interface{}{}); err == nil {
+		t.Errorf("a missing file must be rejected")
+	}
+}
+
+func TestGetString(t *testing.T) {
+	values := map[string]interface{}{
+		"name":  "test",
+		"empty": nil,
+		"nested": map[string]interface{}{
+			"retry": 5,
+		},
+	}
+	for path, want := range map[string]string{
+		"name":         "test",
+		"empty":        "",
+		"missing":      "",
+		"nested.retry": "5",
+	} {
+		if got := GetString(values, path); got != want {
+			t.Errorf("GetString(%s) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestMergeDefaults(t *testing.T) {
+	values := map[string]interface{}{
+		"name":   "from-values",
+		"empty":  nil,
+		"addons": map[string]interface{}{"search": map[string]interface{}{"enabled": false}},
+	}
+	defaults := map[string]interface{}{
+		"name":  "default",
+		"empty": "default",
+		"retry": int64(5),
+		"addons": map[string]interface{}{
+			"search": map[string]interface{}{"enabled": true},
+			"policy": map[string]interface{}{"enabled": true},
+		},
+	}
+	MergeDefaults(values, defaults)
+	want := map[string]interface{}{
+		"name":  "from-values",
+		"empty": "default",
+		"retry": int64(5),
+		"addons": map[string]interface{}{
+			"search": map[string]interface{}{"enabled": false},
+			"policy": map[string]interface{}{"enabled": true},
+		},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("MergeDefaults() = %v, want %v", values, want)
+	}
+}
+
+func TestMergeValues(t *testing.T) {
+	values := map[string]interface{}{
+		"name":    "base",
+		"removed": "base",
+		"list":    []interface{}{"a", "b"},
+		"addons": map[string]interface{}{
+			"search": map[string]interface{}{"enabled": true},
+			"policy": map[string]interface{}{"enabled": true},
+		},
+	}
+	overrides := map[string]interface{}{
+		"name":    "override",
+		"removed": nil,
+		"list":    []interface{}{"c"},
+		"addons": map[string]interface{}{
+			"search": map[string]interface{}{"enabled": false},
+		},
+	}
+	MergeValues(values, overrides)
+	want := map[string]interface{}{
+		"name": "override",
+		"list": []interface{}{"c"},
+		"addons": map[string]interface{}{
+			"search": map[string]interface{}{"enabled": false},
+			"policy": map[string]interface{}{"enabled": true},
+		},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("MergeValues() = %v, want %v", values, want)
+	}
+}
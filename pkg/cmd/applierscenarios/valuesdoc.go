@@ -0,0 +1,139 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// RequiredAnnotation is the comment marking in a values template a value which must be set
+const RequiredAnnotation = "+required"
+
+// valueLineRegexp matches a key of a values template and its value
+var valueLineRegexp = regexp.MustCompile(`^([A-Za-z0-9_.-]+):(\s+(.*))?$`)
+
+// ValueDoc documents a value of a values template
+type ValueDoc struct {
+	// Path is the dot separated path of the value
+	Path        string `json:"path"`
+	Required    bool   `json:"required"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ParseValuesTemplate returns the documentation of the values of a values template, the description
+// of a value is the comment preceding it or following it on the same line and the values preceded
+// by a +required comment must be set. The maps are only listed when they are documented.
+// The templates are simple yaml files, the sequences and the block scalars are not described.
+func ParseValuesTemplate(b []byte) []ValueDoc {
+	type key struct {
+		indent int
+		name   string
+	}
+	type entry struct {
+		doc    ValueDoc
+		indent int
+	}
+	entries := make([]entry, 0)
+	parents := make([]key, 0)
+	comments := make([]string, 0)
+	required := false
+	//The lines of a block scalar or of a sequence more indented than skipIndent are not values
+	skipIndent := -1
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if skipIndent >= 0 {
+			if trimmed == "" || indent > skipIndent || (indent == skipIndent && strings.HasPrefix(trimmed, "-")) {
+				continue
+			}
+			skipIndent = -1
+		}
+		switch {
+		case trimmed == "":
+			comments = comments[:0]
+			required = false
+			continue
+		case strings.HasPrefix(trimmed, "#"):
+			c := strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+			//The commented yaml lines are examples, not descriptions
+			switch {
+			case c == RequiredAnnotation:
+				required = true
+			case !valueLineRegexp.MatchString(c):
+				comments = append(comments, c)
+			}
+			continue
+		case strings.HasPrefix(trimmed, "-"):
+			//A sequence at the indentation of its key
+			skipIndent = indent
+			comments = comments[:0]
+			required = false
+			continue
+		}
+		m := valueLineRegexp.FindStringSubmatch(trimmed)
+		if m == nil {
+			continue
+		}
+		for len(parents) != 0 && parents[len(parents)-1].indent >= indent {
+			parents = parents[:len(parents)-1]
+		}
+		names := make([]string, 0, len(parents)+1)
+		for _, p := range parents {
+			names = append(names, p.name)
+		}
+		names = append(names, m[1])
+
+		value, inlineComment := m[3], ""
+		if i := strings.Index(" "+value, " #"); i >= 0 {
+			value, inlineComment = strings.TrimSpace(value[:i]), strings.TrimSpace(strings.TrimPrefix(value[i:], "#"))
+		}
+		if inlineComment != "" {
+			comments = append(comments, inlineComment)
+		}
+		if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+			value = ""
+			skipIndent = indent
+		}
+		entries = append(entries, entry{
+			doc: ValueDoc{
+				Path:        strings.Join(names, "."),
+				Required:    required,
+				Default:     value,
+				Description: strings.Join(comments, " "),
+			},
+			indent: indent,
+		})
+		parents = append(parents, key{indent: indent, name: m[1]})
+		comments = comments[:0]
+		required = false
+	}
+
+	docs := make([]ValueDoc, 0, len(entries))
+	for i, e := range entries {
+		isMap := i+1 < len(entries) && entries[i+1].indent > e.indent && e.doc.Default == ""
+		if isMap && e.doc.Description == "" && !e.doc.Required {
+			continue
+		}
+		docs = append(docs, e.doc)
+	}
+	return docs
+}
+
+// ExampleValues returns the values template without its annotations, as an example values file
+func ExampleValues(b []byte) []byte {
+	out := &bytes.Buffer{}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "# "+RequiredAnnotation {
+			continue
+		}
+		out.WriteString(scanner.Text())
+		out.WriteString("\n")
+	}
+	return out.Bytes()
+}
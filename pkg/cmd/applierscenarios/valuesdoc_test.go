@@ -0,0 +1,61 @@
+// Copyright Contributors to the Open Cluster Management project
+package applierscenarios
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testValuesTemplate = `# Copyright Contributors to the Open Cluster Management project
+
+# The name of the cluster
+# +required
+name: # <cluster_name>
+cloud: aws # aws, azure or gcp
+addons:
+  search:
+    enabled: true
+# The proxy of the cluster
+proxy:
+  # +required
+  httpProxy:
+# The storage configuration
+# type: s3
+storage:
+templates:
+- kind: Namespace
+  name: test
+kubeConfig: |-
+  <Kubeconfig>
+  key: value
+token: <token>
+`
+
+func TestParseValuesTemplate(t *testing.T) {
+	want := []ValueDoc{
+		{Path: "name", Required: true, Description: "The name of the cluster <cluster_name>"},
+		{Path: "cloud", Default: "aws", Description: "aws, azure or gcp"},
+		{Path: "addons.search.enabled", Default: "true"},
+		{Path: "proxy", Description: "The proxy of the cluster"},
+		{Path: "proxy.httpProxy", Required: true},
+		{Path: "storage", Description: "The storage configuration"},
+		{Path: "templates"},
+		{Path: "kubeConfig"},
+		{Path: "token", Default: "<token>"},
+	}
+	got := ParseValuesTemplate([]byte(testValuesTemplate))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseValuesTemplate() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestExampleValues(t *testing.T) {
+	got := string(ExampleValues([]byte(testValuesTemplate)))
+	if strings.Contains(got, RequiredAnnotation) {
+		t.Errorf("ExampleValues() must remove the annotations, got:\n%s", got)
+	}
+	if !strings.Contains(got, "# The name of the cluster\nname: # <cluster_name>\n") {
+		t.Errorf("ExampleValues() must keep the values and their comments, got:\n%s", got)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright Contributors to the Open Cluster Management project
+package apply
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Attach a cluster and save the spec of the attach
+%[1]s attach cluster --values values.yaml --save-spec mycluster-spec.yaml
+
+# Replay the attach on another hub
+%[1]s apply -f mycluster-spec.yaml --context otherhub
+`
+
+// NewCmd provides a cobra command replaying a command saved with --save-spec
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "apply",
+		Short:        "Replay a command saved with --save-spec",
+		Long:         "Replay the attach or create command saved in the spec file, with the same values and flags on the hub of the current kubeconfig",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.specPath, "filename", "f", "", "The spec file saved with --save-spec")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,90 @@
+// Copyright Contributors to the Open Cluster Management project
+package apply
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	o.root = cmd.Root()
+	o.configArgs = nil
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Name == "filename" {
+			return
+		}
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			for _, v := range s.GetSlice() {
+				o.configArgs = append(o.configArgs, fmt.Sprintf("--%s=%s", f.Name, v))
+			}
+			return
+		}
+		o.configArgs = append(o.configArgs, fmt.Sprintf("--%s=%s", f.Name, f.Value.String()))
+	})
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.specPath == "" {
+		return fmt.Errorf("the spec file is required, use -f")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	spec, err := helpers.ReadOperationSpec(o.specPath)
+	if err != nil {
+		return err
+	}
+	if spec.CLIVersion != helpers.Version {
+		fmt.Fprintf(o.ErrOut, "Warning: the spec was saved by the version %s and is replayed by the version %s\n", spec.CLIVersion, helpers.Version)
+	}
+
+	command := strings.Fields(spec.Command)
+	target, _, err := o.root.Find(command)
+	if err != nil || target.CommandPath() != strings.Join(append([]string{o.root.Name()}, command...), " ") {
+		return fmt.Errorf("unknown command %s in the spec %s", spec.Command, o.specPath)
+	}
+	if target.Flags().Lookup(helpers.SaveSpecFlag) == nil {
+		return fmt.Errorf("the command %s can not be replayed", spec.Command)
+	}
+
+	valuesFile, err := helpers.WriteValuesFile(spec.Values)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(valuesFile)
+
+	args := append([]string{}, command...)
+	args = append(args, spec.Args...)
+	args = append(args, "--values="+valuesFile)
+	names := make([]string, 0, len(spec.Flags))
+	for name := range spec.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if target.Flags().Lookup(name) == nil {
+			return fmt.Errorf("unknown flag --%s of the command %s in the spec %s", name, spec.Command, o.specPath)
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", name, spec.Flags[name]))
+	}
+	args = append(args, o.configArgs...)
+
+	//The error of the replayed command is printed once, as the error of the apply
+	silenceErrors := o.root.SilenceErrors
+	o.root.SilenceErrors = true
+	defer func() {
+		o.root.SilenceErrors = silenceErrors
+	}()
+	o.root.SetArgs(args)
+	return o.root.ExecuteContext(o.ctx)
+}
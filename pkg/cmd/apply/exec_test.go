@@ -0,0 +1,131 @@
+// Copyright Contributors to the Open Cluster Management project
+package apply
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// replayed is what the fake attach cluster command received
+type replayed struct {
+	values  map[string]interface{}
+	name    string
+	async   bool
+	context string
+	args    []string
+}
+
+// newRoot returns a root command with the apply command and a fake attach cluster command
+func newRoot(got *replayed) *cobra.Command {
+	var valuesPath, saveSpec string
+	cluster := &cobra.Command{
+		Use: "cluster",
+		RunE: func(c *cobra.Command, args []string) error {
+			b, err := ioutil.ReadFile(valuesPath)
+			if err != nil {
+				return err
+			}
+			got.args = args
+			got.context, _ = c.Flags().GetString("context")
+			return yaml.Unmarshal(b, &got.values)
+		},
+	}
+	cluster.Flags().StringVar(&valuesPath, "values", "", "")
+	cluster.Flags().StringVar(&saveSpec, helpers.SaveSpecFlag, "", "")
+	cluster.Flags().StringVar(&got.name, "name", "", "")
+	cluster.Flags().BoolVar(&got.async, "async", false, "")
+	genericclioptions.NewConfigFlags(true).AddFlags(cluster.Flags())
+	attach := &cobra.Command{Use: "attach"}
+	attach.AddCommand(cluster)
+	detach := &cobra.Command{Use: "detach", RunE: func(c *cobra.Command, args []string) error { return nil }}
+
+	root := &cobra.Command{Use: "cm", SilenceErrors: true}
+	root.AddCommand(attach, detach, NewCmd(genericclioptions.NewTestIOStreamsDiscard()))
+	return root
+}
+
+func writeSpec(t *testing.T, dir string, spec *helpers.OperationSpec) string {
+	path := filepath.Join(dir, "spec.yaml")
+	if err := helpers.WriteOperationSpec(path, spec); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOptions_run(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cm-apply-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		spec    *helpers.OperationSpec
+		want    replayed
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			spec: &helpers.OperationSpec{
+				Command: "attach cluster",
+				Args:    []string{"extra"},
+				Flags:   map[string]string{"name": "mycluster", "async": "true"},
+				Values:  map[string]interface{}{"managedClusterName": "mycluster"},
+			},
+			want: replayed{
+				values:  map[string]interface{}{"managedClusterName": "mycluster"},
+				name:    "mycluster",
+				async:   true,
+				context: "otherhub",
+				args:    []string{"extra"},
+			},
+		},
+		{
+			name:    "Failed, unknown command",
+			spec:    &helpers.OperationSpec{Command: "attach policy"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, command which can not be replayed",
+			spec:    &helpers.OperationSpec{Command: "detach"},
+			wantErr: true,
+		},
+		{
+			name: "Failed, unknown flag",
+			spec: &helpers.OperationSpec{
+				Command: "attach cluster",
+				Flags:   map[string]string{"unknown": "true"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := replayed{}
+			root := newRoot(&got)
+			spec := *tt.spec
+			spec.APIVersion = helpers.OperationSpecAPIVersion
+			spec.Kind = helpers.OperationSpecKind
+			spec.CLIVersion = helpers.Version
+			path := writeSpec(t, dir, &spec)
+
+			root.SetArgs([]string{"apply", "-f", path, "--context", "otherhub"})
+			err := root.Execute()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("apply error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("replayed %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
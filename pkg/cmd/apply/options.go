@@ -0,0 +1,31 @@
+// Copyright Contributors to the Open Cluster Management project
+package apply
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags *genericclioptions.ConfigFlags
+	specPath    string
+	//root is the root command in which the saved command is found
+	root *cobra.Command
+	//configArgs are the kubeconfig flags set by the user, passed to the replayed command
+	configArgs []string
+	//ctx is canceled on Ctrl+C to abort the waits of the replayed command
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ctx:         context.Background(),
+
+		IOStreams: streams,
+	}
+}
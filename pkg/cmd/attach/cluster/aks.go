@@ -0,0 +1,88 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cloud/azure"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var aksExample = `
+# Attach an AKS cluster, az must be installed and logged in
+%[1]s attach cluster aks --resource-group mygroup --name mycluster
+
+# Attach an AKS cluster with the cluster admin credentials and another managed cluster name
+%[1]s attach cluster aks --resource-group mygroup --name mycluster --admin --managed-cluster-name aks-dev
+`
+
+var aksValuesSchema = newProviderValuesSchema("managed-cluster-name")
+
+type aksOptions struct {
+	*Options
+	resourceGroup  string
+	aksClusterName string
+	admin          bool
+}
+
+// newCmdAKS attaches an AKS cluster with a service account bootstrapped with the az credentials
+func newCmdAKS(streams genericclioptions.IOStreams) *cobra.Command {
+	o := &aksOptions{
+		Options: newOptions(streams),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "aks",
+		Short: "Import an AKS cluster",
+		Long: "Import an AKS cluster, the az credentials are used to create a service account on the cluster, " +
+			"the import uses the non-expiring token of that service account",
+		Example:      fmt.Sprintf(aksExample, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.resourceGroup, "resource-group", "", "Azure resource group of the AKS cluster")
+	cmd.Flags().StringVar(&o.aksClusterName, "name", "", "Name of the AKS cluster")
+	cmd.Flags().BoolVar(&o.admin, "admin", false, "If set, the cluster admin credentials are used instead of the user credentials")
+	o.addProviderFlags(cmd, aksValuesSchema)
+
+	return cmd
+}
+
+func (o *aksOptions) complete(cmd *cobra.Command, args []string) error {
+	return o.completeProvider(cmd, aksValuesSchema, o.aksClusterName)
+}
+
+func (o *aksOptions) validate() error {
+	if o.resourceGroup == "" {
+		return fmt.Errorf("resource-group is missing")
+	}
+	if o.aksClusterName == "" {
+		return fmt.Errorf("name is missing")
+	}
+	return nil
+}
+
+func (o *aksOptions) run() error {
+	config, err := azure.GetRESTConfig(o.resourceGroup, o.aksClusterName, o.admin)
+	if err != nil {
+		return err
+	}
+	return o.attachWithServiceAccount(config)
+}
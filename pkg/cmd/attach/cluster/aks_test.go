@@ -0,0 +1,47 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+)
+
+func TestAKSOptions_complete(t *testing.T) {
+	o := &aksOptions{
+		Options: &Options{
+			applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+		},
+		aksClusterName: "aks-cluster",
+	}
+	if err := o.complete(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if o.clusterName != "aks-cluster" {
+		t.Errorf("clusterName = %s, want aks-cluster", o.clusterName)
+	}
+}
+
+func TestAKSOptions_validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		resourceGroup  string
+		aksClusterName string
+		wantErr        bool
+	}{
+		{name: "Success", resourceGroup: "group", aksClusterName: "aks-cluster"},
+		{name: "Failed, resource-group missing", aksClusterName: "aks-cluster", wantErr: true},
+		{name: "Failed, name missing", resourceGroup: "group", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &aksOptions{
+				resourceGroup:  tt.resourceGroup,
+				aksClusterName: tt.aksClusterName,
+			}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
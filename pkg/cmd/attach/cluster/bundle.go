@@ -0,0 +1,123 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	bundleCRDsFile      = "crds.yaml"
+	bundleImportFile    = "import.yaml"
+	bundleReadmeFile    = "README.md"
+	bundleChecksumsFile = "SHA256SUMS"
+)
+
+var bundleReadme = `# Offline import of the managed cluster %[1]s
+
+This bundle contains the manifests needed to import the cluster %[1]s
+in a hub without any connection between the hub and the managed cluster.
+
+1. Verify the integrity of the manifests:
+
+       sha256sum -c %[2]s
+
+2. Apply the CRDs on the managed cluster and wait for them to be established:
+
+       kubectl apply -f %[3]s
+       kubectl wait --for=condition=established --timeout=%[5]s -f %[3]s
+
+3. Apply the import manifests on the managed cluster:
+
+       kubectl apply -f %[4]s
+`
+
+// writeBundle generates a tar.gz archive containing the crds.yaml and import.yaml
+// of the import secret with their provenance annotations, a README with the apply order and the checksums of the manifests.
+// crdWaitTimeout is the time the README waits for the CRDs on the managed cluster.
+func writeBundle(bundlePath, clusterName string, crdWaitTimeout time.Duration, importSecret *corev1.Secret, caBundle []byte) error {
+	crds, imports, err := importManifests(importSecret, caBundle)
+	if err != nil {
+		return err
+	}
+
+	checksums := &bytes.Buffer{}
+	fmt.Fprintf(checksums, "%x  %s\n", sha256.Sum256(crds), bundleCRDsFile)
+	fmt.Fprintf(checksums, "%x  %s\n", sha256.Sum256(imports), bundleImportFile)
+
+	readme := fmt.Sprintf(bundleReadme, clusterName, bundleChecksumsFile, bundleCRDsFile, bundleImportFile, crdWaitTimeout)
+
+	f, err := os.OpenFile(filepath.Clean(bundlePath), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dir := fmt.Sprintf("%s-import", clusterName)
+	return helpers.WriteTarGz(f, []helpers.ArchiveEntry{
+		{Name: path.Join(dir, bundleReadmeFile), Data: []byte(readme)},
+		{Name: path.Join(dir, bundleChecksumsFile), Data: checksums.Bytes()},
+		{Name: path.Join(dir, bundleCRDsFile), Data: crds},
+		{Name: path.Join(dir, bundleImportFile), Data: imports},
+	})
+}
+
+// writeImportManifests writes the crds.yaml and import.yaml of the import secret in the directory,
+// the CRDs must be established on the managed cluster before the import manifests are applied
+func writeImportManifests(dir string, importSecret *corev1.Secret, caBundle []byte) error {
+	crds, imports, err := importManifests(importSecret, caBundle)
+	if err != nil {
+		return err
+	}
+	for name, data := range map[string][]byte{bundleCRDsFile: crds, bundleImportFile: imports} {
+		path := filepath.Join(dir, name)
+		tmp, err := helpers.TempFile(path)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+		if err := helpers.ReplaceFile(tmp, path); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+	}
+	return nil
+}
+
+// annotateImportFile rewrites the import file with the manifests of the import secret and their provenance
+// annotations, the crds.yaml and import.yaml are separated so each manifest of the file can be verified
+func annotateImportFile(path string, importSecret *corev1.Secret, caBundle []byte) error {
+	crds, imports, err := importManifests(importSecret, caBundle)
+	if err != nil {
+		return err
+	}
+	b := append(append(crds, []byte("---\n")...), imports...)
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// importManifests returns the crds.yaml and import.yaml of the import secret with their provenance annotations,
+// the CA bundle is appended to the bootstrap kubeconfig of the import.yaml
+func importManifests(importSecret *corev1.Secret, caBundle []byte) (crds []byte, imports []byte, err error) {
+	crds, imports, err = helpers.GetAnnotatedImportManifests(importSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+	imports, err = helpers.AddBootstrapCA(imports, caBundle)
+	if err != nil {
+		return nil, nil, err
+	}
+	return crds, imports, nil
+}
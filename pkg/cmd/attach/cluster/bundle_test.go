@@ -0,0 +1,176 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+)
+
+const (
+	testCRDs   = "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: klusterlets.operator.open-cluster-management.io\n"
+	testImport = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: open-cluster-management-agent\n"
+)
+
+func Test_writeBundle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	type args struct {
+		clusterName  string
+		importSecret *corev1.Secret
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			args: args{
+				clusterName: "test",
+				importSecret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-import",
+						Namespace: "test",
+					},
+					Data: map[string][]byte{
+						"crds.yaml":   []byte(testCRDs),
+						"import.yaml": []byte(testImport),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed, import.yaml missing",
+			args: args{
+				clusterName: "test",
+				importSecret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-import",
+						Namespace: "test",
+					},
+					Data: map[string][]byte{
+						"crds.yaml": []byte(testCRDs),
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "bundle.tar.gz")
+			err := writeBundle(path, tt.args.clusterName, time.Minute, tt.args.importSecret, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("writeBundle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := readBundle(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, n := range []string{bundleCRDsFile, bundleImportFile, bundleReadmeFile, bundleChecksumsFile} {
+				if _, ok := got[filepath.Join("test-import", n)]; !ok {
+					t.Errorf("%s missing in bundle", n)
+				}
+			}
+			if readme := string(got[filepath.Join("test-import", bundleReadmeFile)]); !strings.Contains(readme, "--timeout=1m0s") {
+				t.Errorf("the README must wait for the CRDs for 1m0s, got %s", readme)
+			}
+			sums := string(got[filepath.Join("test-import", bundleChecksumsFile)])
+			for _, n := range []string{bundleCRDsFile, bundleImportFile} {
+				want := fmt.Sprintf("%x  %s", sha256.Sum256(got[filepath.Join("test-import", n)]), n)
+				if !strings.Contains(sums, want) {
+					t.Errorf("checksums %s doesn't contain %s", sums, want)
+				}
+			}
+		})
+	}
+}
+
+func readBundle(path string) (map[string][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gr)
+	files := make(map[string][]byte)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[h.Name] = b
+	}
+}
+
+func Test_writeImportManifests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	importSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-import",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			"crds.yaml":   []byte(testCRDs),
+			"import.yaml": []byte(testImport),
+		},
+	}
+	outputDir := filepath.Join(dir, "test-import")
+	if err := writeImportManifests(outputDir, importSecret, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []string{bundleCRDsFile, bundleImportFile} {
+		b, err := ioutil.ReadFile(filepath.Join(outputDir, n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifests, err := helpers.DecodeManifests(b, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(manifests) != 1 || helpers.GetImportProvenance(manifests) == nil {
+			t.Errorf("%s = %s, want the manifest of %s with its provenance annotations", n, string(b), string(importSecret.Data[n]))
+		}
+	}
+
+	delete(importSecret.Data, "import.yaml")
+	if err := writeImportManifests(outputDir, importSecret, nil); err == nil {
+		t.Error("writeImportManifests() expected an error when import.yaml is missing")
+	}
+}
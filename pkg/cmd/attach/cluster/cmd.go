@@ -4,8 +4,11 @@ package cluster
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
 	"github.com/open-cluster-management/cm-cli/pkg/helpers"
 
 	"github.com/spf13/cobra"
@@ -18,6 +21,82 @@ var example = `
 
 # Attach a cluster with overwritting the cluster name
 %[1]s attach cluster --values values.yaml --name mycluster
+
+# Attach a cluster without values file, the values which are not set are the default values
+%[1]s attach cluster --name mycluster --cluster-server https://api.mycluster.example.com:6443 --cluster-token mytoken
+
+# Attach the cluster mycluster with the values of clusters/mycluster/values.yaml if it exists, completed by the default values
+%[1]s attach cluster mycluster --cluster-kubeconfig mycluster.kubeconfig
+
+# Attach the cluster mycluster with the values of fleet/mycluster/values.yaml
+%[1]s attach cluster mycluster --values-root fleet
+
+# Attach a cluster and remove what was created if the attach fails
+%[1]s attach cluster --values values.yaml --import-file import.yaml --rollback-on-failure
+
+# Attach an OpenShift cluster and adopt it in Hive to enable hibernation and machine pools
+%[1]s attach cluster --values values.yaml --hive-adopt --hive-credentials-secret aws-creds
+
+# Attach a cluster and wait until its addons are available before deploying on it
+%[1]s attach cluster --values values.yaml --wait-for addons-ready --wait-for-timeout 15m
+
+# Attach a cluster without waiting, then follow the import with the status command
+%[1]s attach cluster --values values.yaml --async
+%[1]s status mycluster
+
+# Attach a cluster behind a corporate proxy
+%[1]s attach cluster --values values.yaml --https-proxy http://proxy.example.com:3128 --no-proxy .cluster.local,10.0.0.0/8
+
+# Attach a single-node OpenShift cluster with the edge profile, the application and policy addons
+# and a longer lease, or a MicroShift cluster with the minimal profile, the klusterlet only
+%[1]s attach cluster --values values.yaml --profile edge
+%[1]s attach cluster --values values.yaml --profile minimal
+
+# Generate the import bundle of a k3s cluster, the distribution can not be detected without its kubeconfig
+%[1]s attach cluster --values values.yaml --distribution k3s --bundle mycluster-import.tar.gz
+
+# Attach a small edge cluster with lower klusterlet requests, on its edge nodes
+%[1]s attach cluster --values values.yaml --klusterlet-cpu-request 10m --klusterlet-memory-request 32Mi --klusterlet-node-selector node-role.kubernetes.io/edge= --klusterlet-toleration edge:NoSchedule
+
+# Attach a cluster with its klusterlet agents in the agents namespace
+%[1]s attach cluster --values values.yaml --klusterlet-namespace agents
+
+# Attach a cluster with its klusterlet agents running on the hosting managed cluster
+%[1]s attach cluster --values values.yaml --cluster-kubeconfig mycluster.kubeconfig --klusterlet-mode Hosted --hosting-cluster hosting
+
+# Attach a cluster in a namespace pre-created by an administrator
+%[1]s attach cluster --values values.yaml --create-namespace=false
+
+# Attach a cluster with the token of a service account created with the client certificate of its kubeconfig
+%[1]s attach cluster --values values.yaml --mint-token
+
+# Attach a cluster and apply the import manifests on the managed cluster
+%[1]s attach cluster --values values.yaml --import-file - | kubectl --kubeconfig spoke.kubeconfig apply -f -
+
+# Attach a cluster and write the crds.yaml and import.yaml to apply in two steps
+%[1]s attach cluster --values values.yaml --import-output-dir mycluster-import
+
+# Attach a cluster and generate an offline import bundle
+%[1]s attach cluster --values values.yaml --bundle mycluster-import.tar.gz
+
+# Attach the clusters of a manifest, each to the hub of its kubeconfig context
+%[1]s attach cluster --manifest clusters.yaml --values common-values.yaml
+# Write the resources of the cluster in a Kustomize layout to onboard it through Git with Argo CD
+%[1]s attach cluster mycluster --export gitops --git-dir fleet-repo
+
+# Attach a cluster with Ansible pre and post import hooks and wait for the curation
+%[1]s attach cluster --values values.yaml --curator-file curator.yaml --wait
+
+# Attach a cluster and launch an onboarding Ansible job template once attached
+%[1]s attach cluster --values values.yaml --post-attach-job onboard-cluster --tower-secret toweraccess --wait-post-attach-job
+
+# Attach the clusters of a CMDB export, 10 at a time
+%[1]s attach cluster --inventory clusters.csv --max-concurrency 10
+
+# Attach an EKS, GKE or AKS cluster
+%[1]s attach cluster eks --cluster-name mycluster --region us-east-1
+%[1]s attach cluster gke --project myproject --zone us-east1-b --name mycluster
+%[1]s attach cluster aks --resource-group mygroup --name mycluster
 `
 
 const (
@@ -26,39 +105,113 @@ const (
 
 var valuesTemplatePath = filepath.Join(scenarioDirectory, "values-template.yaml")
 
+// valuesSchema defines the flag of each value of the values-template.yaml
+var valuesSchema = applierscenarios.ValuesSchema{
+	{Path: "managedClusterName", Flag: "name", Type: applierscenarios.StringValue, Usage: "Name of the cluster to import"},
+	{Path: "server", Flag: "cluster-server", Type: applierscenarios.StringValue, Usage: "cluster server url of the cluster to import"},
+	{Path: "token", Flag: "cluster-token", Type: applierscenarios.StringValue, Usage: "token to access the cluster to import"},
+	{Path: "kubeConfig", Flag: "cluster-kubeconfig", Type: applierscenarios.FileValue, Usage: "The kubeconfig file of the cluster to import", DeprecatedFlag: "cluster-kubeconfigr"},
+	{Path: "proxy.httpProxy", Flag: "http-proxy", Type: applierscenarios.StringValue, Usage: "The HTTP proxy used by the klusterlet and the addons to reach the hub"},
+	{Path: "proxy.httpsProxy", Flag: "https-proxy", Type: applierscenarios.StringValue, Usage: "The HTTPS proxy used by the klusterlet and the addons to reach the hub"},
+	{Path: "proxy.noProxy", Flag: "no-proxy", Type: applierscenarios.StringValue, Usage: "The comma separated hosts, domains and CIDRs reached by the addons without proxy"},
+	{Path: "clusterSet", Flag: "clusterset", Type: applierscenarios.StringValue, Usage: "The ManagedClusterSet the cluster joins, it must exist unless --create-clusterset is set"},
+	{Path: "lifecycle.owner", Flag: "owner", Type: applierscenarios.StringValue, Usage: "The owner of the cluster, such as a team or an email, set as an annotation of the ManagedCluster"},
+	{Path: "lifecycle.ticket", Flag: "ticket", Type: applierscenarios.StringValue, Usage: "The ticket tracking the cluster, set as an annotation of the ManagedCluster"},
+	{Path: "lifecycle.expires", Flag: "expires", Type: applierscenarios.StringValue, Usage: "The expiry of the cluster, a duration such as 72h or 7d, a date such as 2026-12-31 or an RFC 3339 time, the expired clusters are deleted by gc --expired"},
+	{Path: "distribution", Flag: "distribution", Type: applierscenarios.StringValue, Usage: "The distribution of the cluster: kubernetes, openshift, k3s or microshift, detected from the kubeConfig if not set"},
+	{Path: "createNamespace", Flag: "create-namespace", Type: applierscenarios.BoolValue, Usage: "Create the namespace of the cluster on the hub, set it to false when the namespace is pre-created by an administrator"},
+	{Path: "hive.adopt", Flag: "hive-adopt", Type: applierscenarios.BoolValue, Usage: "Adopt the OpenShift cluster in Hive with a ClusterDeployment to enable the day-2 Hive features, requires the kubeConfig of the cluster"},
+	{Path: "hive.baseDomain", Flag: "hive-base-domain", Type: applierscenarios.StringValue, Usage: "The base domain of the adopted cluster, read from the cluster if not set"},
+	{Path: "hive.platform", Flag: "hive-platform", Type: applierscenarios.StringValue, Usage: "The platform of the adopted cluster: aws, gcp, azure or none, read from the cluster if not set"},
+	{Path: "hive.region", Flag: "hive-region", Type: applierscenarios.StringValue, Usage: "The region of the adopted cluster, read from the cluster if not set"},
+	{Path: "hive.credentialsSecretName", Flag: "hive-credentials-secret", Type: applierscenarios.StringValue, Usage: "The secret of the cluster namespace containing the cloud credentials of the adopted cluster"},
+	{Path: "klusterlet.resources.requests.cpu", Flag: "klusterlet-cpu-request", Type: applierscenarios.StringValue, Usage: "The CPU requested by each klusterlet agent, e.g. 50m"},
+	{Path: "klusterlet.resources.requests.memory", Flag: "klusterlet-memory-request", Type: applierscenarios.StringValue, Usage: "The memory requested by each klusterlet agent, e.g. 64Mi"},
+	{Path: "klusterlet.resources.limits.cpu", Flag: "klusterlet-cpu-limit", Type: applierscenarios.StringValue, Usage: "The CPU limit of each klusterlet agent"},
+	{Path: "klusterlet.resources.limits.memory", Flag: "klusterlet-memory-limit", Type: applierscenarios.StringValue, Usage: "The memory limit of each klusterlet agent"},
+	{Path: "klusterlet.namespace", Flag: "klusterlet-namespace", Type: applierscenarios.StringValue, Usage: "The namespace of the klusterlet agents on the cluster, open-cluster-management-agent if not set"},
+	{Path: "klusterlet.mode", Flag: "klusterlet-mode", Type: applierscenarios.StringValue, Usage: "The deploy mode of the klusterlet: Default runs the agents on the cluster, Hosted runs them on the hosting cluster"},
+	{Path: "klusterlet.hostingClusterName", Flag: "hosting-cluster", Type: applierscenarios.StringValue, Usage: "The managed cluster running the klusterlet agents of the Hosted mode"},
+	{Path: "leaseDurationSeconds", Flag: "lease-duration-seconds", Type: applierscenarios.IntValue, Usage: "The interval in seconds at which the klusterlet renews the lease of the cluster on the hub"},
+	{Path: "autoImportRetry", Flag: "auto-import-retry", Type: applierscenarios.IntValue, Usage: "Number of times the import is retried"},
+	{Path: "addons.applicationManager.enabled", Flag: "addon-application-manager", Type: applierscenarios.BoolValue, Usage: "Enable the application manager addon"},
+	{Path: "addons.applicationManager.argocdCluster", Flag: "addon-application-manager-argocd", Type: applierscenarios.BoolValue, Usage: "Register the cluster in ArgoCD"},
+	{Path: "addons.policyController.enabled", Flag: "addon-policy-controller", Type: applierscenarios.BoolValue, Usage: "Enable the policy controller addon"},
+	{Path: "addons.searchCollector.enabled", Flag: "addon-search-collector", Type: applierscenarios.BoolValue, Usage: "Enable the search collector addon"},
+	{Path: "addons.certPolicyController.enabled", Flag: "addon-cert-policy-controller", Type: applierscenarios.BoolValue, Usage: "Enable the cert policy controller addon"},
+	{Path: "addons.iamPolicyController.enabled", Flag: "addon-iam-policy-controller", Type: applierscenarios.BoolValue, Usage: "Enable the iam policy controller addon"},
+}
+
 // NewCmd provides a cobra command wrapping NewCmdImportCluster
 func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 	o := newOptions(streams)
 
 	cmd := &cobra.Command{
-		Use:          "cluster",
+		Use:          "cluster [name]",
 		Short:        "Import a cluster",
 		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
 			if err := o.complete(c, args); err != nil {
-				return err
+				return cmderrors.NewValidationError(err)
 			}
 			if err := o.validate(); err != nil {
-				return err
+				return cmderrors.NewValidationError(err)
 			}
 			if err := o.run(); err != nil {
 				return err
 			}
+			if err := o.saveSpec(c, args); err != nil {
+				return err
+			}
 
 			return nil
 		},
 	}
 
 	cmd.SetUsageTemplate(applierscenarios.UsageTempate(cmd, valuesTemplatePath))
-	cmd.Flags().StringVar(&o.clusterName, "name", "", "Name of the cluster to import")
-	cmd.Flags().StringVar(&o.clusterServer, "cluster-server", "", "cluster server url of the cluster to import")
-	cmd.Flags().StringVar(&o.clusterToken, "cluster-token", "", "token to access the cluster to import")
-	cmd.Flags().StringVar(&o.clusterKubeConfig, "cluster-kubeconfigr", "", "path to the kubeconfig the cluster to import")
-	cmd.Flags().StringVar(&o.importFile, "import-file", "", "the file which will contain the import secret for manual import")
+	valuesSchema.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.importFile, "import-file", "", "the file which will contain the import secret for manual import, - to write it on the standard output")
+	cmd.Flags().StringVar(&o.importOutputDir, "import-output-dir", "", "the directory which will contain the crds.yaml and import.yaml to apply in two steps for manual import")
+	cmd.Flags().BoolVar(&o.skipPreflight, "skip-preflight", false, "If set, the preflight checks on the hub and the cluster to import will be skipped")
+	cmd.Flags().BoolVar(&o.createClusterSet, "create-clusterset", false, "If set, the clusterset of --clusterset is created if it does not exist")
+	cmd.Flags().BoolVar(&o.allowSimilarName, "allow-similar-name", false, "If set, the cluster is attached even if its name only differs by its case or one typo from an existing cluster")
+	cmd.Flags().BoolVar(&o.mintToken, "mint-token", false, "If set, a cluster-admin service account is created on the cluster with the kubeConfig and its non-expiring token is used for the import instead of the kubeConfig, so the import does not depend on the expiry of the user credentials")
+	cmd.Flags().BoolVar(&o.rollbackOnFailure, "rollback-on-failure", false, "If set, the resources and files created by the attach are removed if it fails")
+	cmd.Flags().BoolVar(&o.async, "async", false, "If set, the attach returns immediately with an operation ID to follow with the status command")
+	helpers.DurationVar(cmd.Flags(), &o.importSecretTimeout, "import-secret-timeout", 2*time.Minute, "Timeout to wait for the import secret generated for the import-file and bundle, e.g. 2m")
+	cmd.Flags().StringVar(&o.curatorFile, "curator-file", "", "The file containing the towerAuthSecret and the prehook and posthook Ansible job templates of the import curation")
+	cmd.Flags().BoolVar(&o.wait, "wait", false, "Wait until the curation completes, requires --curator-file")
+	helpers.DurationVar(cmd.Flags(), &o.curationTimeout, "curation-timeout", time.Hour, "Timeout to wait for the curation, e.g. 1h")
+	cmd.Flags().StringVar(&o.waitFor, "wait-for", "", fmt.Sprintf("Wait until the cluster is in this state before returning, one of %s", strings.Join(helpers.WaitForConditions(), ", ")))
+	helpers.DurationVar(cmd.Flags(), &o.waitForTimeout, "wait-for-timeout", 10*time.Minute, "Timeout to wait for the --wait-for state, e.g. 10m")
+	cmd.Flags().StringVar(&o.postAttachJob, "post-attach-job", "", "The Ansible Tower or AWX job template launched by an AnsibleJob once the hub resources of the cluster are applied, its cluster_name extra var is the name of the cluster")
+	cmd.Flags().StringVar(&o.towerSecret, "tower-secret", "", "The secret of the namespace of the cluster holding the host and token of the Ansible Tower or AWX, required by --post-attach-job")
+	cmd.Flags().BoolVar(&o.waitPostAttachJob, "wait-post-attach-job", false, "Wait until the post-attach job completes, requires --post-attach-job")
+	helpers.DurationVar(cmd.Flags(), &o.postAttachJobTimeout, "post-attach-job-timeout", 30*time.Minute, "Timeout to wait for the post-attach job, e.g. 30m")
+	cmd.Flags().StringVar(&o.additionalCABundleFile, helpers.AdditionalCABundleFlag, "", "The PEM file of the CA of a hub fronted by a custom PKI, appended to the CA data or the certificate-authority file of the bootstrap kubeconfig of the import-file, import-output-dir and bundle manifests. "+
+		"A bootstrap kubeconfig skipping the TLS verification or trusting the system roots then only trusts this CA")
+	cmd.Flags().StringVar(&o.bundleFile, "bundle", "", "the tar.gz archive which will contain the crds.yaml, import.yaml, apply-order README and checksums for an offline import")
+	cmd.Flags().StringVar(&o.manifestFile, "manifest", "", "A yaml or json file listing the clusters to attach with their name, the kubeconfig context of their hub and their values, the values files and flags apply to all of them")
+	cmd.Flags().StringVar(&o.valuesRoot, "values-root", defaultValuesRoot, "The directory in which the values of the cluster given as argument without --values are looked up, as <values-root>/<name>/values.yaml")
+	cmd.Flags().StringVar(&o.export, "export", "", fmt.Sprintf("Write the hub resources instead of applying them, one of %s", strings.Join(exportFormats, ", ")))
+	cmd.Flags().StringVar(&o.gitDir, "git-dir", "", "The git directory in which --export gitops writes the base and the overlay of the cluster")
+	cmd.Flags().StringVar(&o.inventoryFile, "inventory", "", "A .csv or .json file listing the clusters to attach with their name, server, token, kubeconfig path, labels and hub context, the values and flags apply to all of them")
+	cmd.Flags().StringVar(&o.profile, "profile", "", fmt.Sprintf("A preset of the addons, the lease duration and the klusterlet resources for the small clusters, one of %s, it overwrites the values file and is overwritten by the flags", strings.Join(profileNames(), ", ")))
+	cmd.Flags().StringToStringVar(&o.klusterletNodeSelector, "klusterlet-node-selector", nil, "The labels of the nodes running the klusterlet agents, as key=value, added to klusterlet.nodeSelector of the values file")
+	cmd.Flags().StringSliceVar(&o.klusterletTolerations, "klusterlet-toleration", nil, "The KEY[=VALUE][:EFFECT] taints of the nodes tolerated by the klusterlet agents, overwrites klusterlet.tolerations of the values file")
+	helpers.AddMaxConcurrencyFlag(cmd.Flags(), &o.maxConcurrency, "clusters of the inventory or of the manifest attached")
+	cmd.Flags().StringVar(&o.saveSpecPath, helpers.SaveSpecFlag, "", "Once succeeded, save the command with its resolved values in this file to replay it with the apply command")
 
 	o.applierScenariosOptions.AddFlags(cmd.Flags())
 	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
 
+	cmd.AddCommand(
+		newCmdEKS(streams),
+		newCmdGKE(streams),
+		newCmdAKS(streams),
+		newCmdMigrate(streams),
+	)
+
 	return cmd
 }
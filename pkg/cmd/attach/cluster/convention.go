@@ -0,0 +1,75 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+)
+
+// defaultValuesRoot is the directory containing the values of the clusters, one directory per cluster
+const defaultValuesRoot = "clusters"
+
+// conventionValuesPath returns the values file of the cluster, <root>/<name>/values.yaml,
+// or "" if the cluster has no values file
+func conventionValuesPath(root, clusterName string) (string, error) {
+	path := filepath.Join(root, clusterName, "values.yaml")
+	fi, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if fi.IsDir() {
+		return "", fmt.Errorf("%s is a directory, expected the values file of the cluster %s", path, clusterName)
+	}
+	return path, nil
+}
+
+// readConventionValues reads the values of the cluster given as argument without --values,
+// from its values file in the values root if it exists, the values which are not set are taken
+// from the values template. The import credentials of the template are placeholders, they are
+// provided by the flags, --set or the values file.
+func (o *Options) readConventionValues(clusterName string) (map[string]interface{}, error) {
+	path, err := conventionValuesPath(o.valuesRoot, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "Reading the values of cluster %s from %s\n", clusterName, path)
+		o.applierScenariosOptions.ValuesPaths = []string{path}
+	} else {
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "No values file found in %s, using the default values for cluster %s\n",
+			filepath.Join(o.valuesRoot, clusterName), clusterName)
+	}
+	return o.readDefaultedValues()
+}
+
+// readDefaultedValues reads the values, the values which are not set are taken from the values template
+// without its placeholders for the import credentials
+func (o *Options) readDefaultedValues() (map[string]interface{}, error) {
+	values, err := o.applierScenariosOptions.ReadValues()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := resources.NewResourcesReader().Asset(valuesTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+	defaults := make(map[string]interface{})
+	if err := yaml.Unmarshal(b, &defaults); err != nil {
+		return nil, err
+	}
+	defaults["kubeConfig"] = ""
+	defaults["server"] = ""
+	defaults["token"] = ""
+	applierscenarios.MergeDefaults(values, defaults)
+	return values, nil
+}
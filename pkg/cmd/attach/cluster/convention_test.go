@@ -0,0 +1,111 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_complete_convention(t *testing.T) {
+	root, err := ioutil.TempDir("", "clusters")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	if err := os.MkdirAll(filepath.Join(root, "cluster1"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	values := "managedClusterName: other\ntoken: myToken\nserver: myServer\naddons:\n  searchCollector:\n    enabled: false\n"
+	if err := ioutil.WriteFile(filepath.Join(root, "cluster1", "values.yaml"), []byte(values), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "cluster3", "values.yaml"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		args            []string
+		valuesPaths     []string
+		setValues       []string
+		wantErr         bool
+		wantToken       string
+		wantSearch      bool
+		wantKubeConfig  string
+		wantClusterName string
+	}{
+		{
+			name:            "Success, values file of the cluster",
+			args:            []string{"cluster1"},
+			wantToken:       "myToken",
+			wantClusterName: "cluster1",
+		},
+		{
+			name:            "Success, default values",
+			args:            []string{"cluster2"},
+			setValues:       []string{"token=setToken"},
+			wantToken:       "setToken",
+			wantSearch:      true,
+			wantClusterName: "cluster2",
+		},
+		{
+			name:            "Success, --values wins over the values root",
+			args:            []string{"cluster1"},
+			valuesPaths:     []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")},
+			wantToken:       "myToken",
+			wantSearch:      true,
+			wantKubeConfig:  "myKubeConfig",
+			wantClusterName: "cluster1",
+		},
+		{
+			name:    "Failed, values file is a directory",
+			args:    []string{"cluster3"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, several cluster names",
+			args:    []string{"cluster1", "cluster2"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, _, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.valuesRoot = root
+			o.applierScenariosOptions = &applierscenarios.ApplierScenariosOptions{
+				ValuesPaths: tt.valuesPaths,
+				SetValues:   tt.setValues,
+				IOStreams:   streams,
+			}
+			err := o.complete(nil, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Options.complete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if o.clusterName != tt.wantClusterName {
+				t.Errorf("clusterName = %s, want %s", o.clusterName, tt.wantClusterName)
+			}
+			if o.clusterToken != tt.wantToken {
+				t.Errorf("token = %s, want %s", o.clusterToken, tt.wantToken)
+			}
+			if o.clusterKubeConfig != tt.wantKubeConfig {
+				t.Errorf("kubeConfig = %s, want %s", o.clusterKubeConfig, tt.wantKubeConfig)
+			}
+			if enabled, _, _ := unstructured.NestedBool(o.values, "addons", "searchCollector", "enabled"); enabled != tt.wantSearch {
+				t.Errorf("searchCollector enabled = %v, want %v", enabled, tt.wantSearch)
+			}
+			if o.values["autoImportRetry"] == nil {
+				t.Error("the default values must be merged")
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/preflight"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	importControllerName = "managedcluster-import-controller"
+	//maxReportedEvents is the number of warning events reported per namespace
+	maxReportedEvents = 3
+)
+
+// importControllerNamespaces are the namespaces in which the import controller is deployed
+// by the open-cluster-management and the multicluster engine installers
+var importControllerNamespaces = []string{"open-cluster-management", "multicluster-engine"}
+
+// waitForImportSecret waits for the import secret generated by the import controller,
+// on timeout the hub is inspected to report the likely root causes
+func (o *Options) waitForImportSecret(client crclient.Client) (*corev1.Secret, error) {
+	var importSecret *corev1.Secret
+	err := helpers.PollImmediate(o.ctx, o.pollInterval, o.importSecretTimeout, func() (bool, error) {
+		var err error
+		importSecret, err = helpers.GetImportSecret(o.ctx, client, o.clusterName)
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err == wait.ErrWaitTimeout {
+		return nil, diagnoseImportSecret(o.ctx, client, o.clusterName, o.importSecretTimeout)
+	}
+	return importSecret, err
+}
+
+func diagnoseImportSecret(ctx context.Context, client crclient.Client, clusterName string, timeout time.Duration) error {
+	checks := []preflight.Check{
+		preflight.NewCheck("Import controller", func() error {
+			return checkImportController(ctx, client)
+		}),
+		preflight.NewCheck("Cluster namespace", func() error {
+			return checkClusterNamespace(ctx, client, clusterName)
+		}),
+		preflight.NewCheck("Events", func() error {
+			return checkWarningEvents(ctx, client, append([]string{clusterName}, importControllerNamespaces...))
+		}),
+	}
+	failed := preflight.Failed(preflight.Run(checks))
+	causes := make([]string, len(failed))
+	for i, r := range failed {
+		causes[i] = fmt.Sprintf("%s: %s", r.Name, r.Err.Error())
+	}
+	return &cmderrors.ImportTimeoutError{ClusterName: clusterName, Timeout: timeout, Causes: causes}
+}
+
+func checkImportController(ctx context.Context, client crclient.Client) error {
+	found := false
+	problems := make([]string, 0)
+	for _, ns := range importControllerNamespaces {
+		pods := &corev1.PodList{}
+		if err := client.List(ctx, pods, crclient.InNamespace(ns)); err != nil {
+			return err
+		}
+		for _, pod := range pods.Items {
+			if !strings.HasPrefix(pod.Name, importControllerName) {
+				continue
+			}
+			found = true
+			if problem := helpers.PodProblem(&pod); problem != "" {
+				problems = append(problems, fmt.Sprintf("pod %s/%s is %s", pod.Namespace, pod.Name, problem))
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("no %s pod found in %s, the controller is not installed on the hub",
+			importControllerName, strings.Join(importControllerNamespaces, " or "))
+	}
+	if len(problems) != 0 {
+		return fmt.Errorf("%s", strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+func checkClusterNamespace(ctx context.Context, client crclient.Client, clusterName string) error {
+	ns := &corev1.Namespace{}
+	err := client.Get(ctx, types.NamespacedName{Name: clusterName}, ns)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("the namespace %s does not exist, the ManagedCluster was not created", clusterName)
+	}
+	if err != nil {
+		return err
+	}
+	if ns.DeletionTimestamp != nil {
+		return fmt.Errorf("the namespace %s is terminating, wait for the previous cluster %s to be fully detached", clusterName, clusterName)
+	}
+	return nil
+}
+
+func checkWarningEvents(ctx context.Context, client crclient.Client, namespaces []string) error {
+	messages := make([]string, 0)
+	for _, ns := range namespaces {
+		events := &corev1.EventList{}
+		if err := client.List(ctx, events, crclient.InNamespace(ns)); err != nil {
+			return err
+		}
+		warnings := make([]corev1.Event, 0)
+		for _, e := range events.Items {
+			if e.Type == corev1.EventTypeWarning {
+				warnings = append(warnings, e)
+			}
+		}
+		//Most recent first
+		sort.Slice(warnings, func(i, j int) bool {
+			return warnings[j].LastTimestamp.Before(&warnings[i].LastTimestamp)
+		})
+		for i, e := range warnings {
+			if i == maxReportedEvents {
+				break
+			}
+			msg := fmt.Sprintf("%s %s/%s: %s", e.Reason, e.InvolvedObject.Kind, e.InvolvedObject.Name, e.Message)
+			if strings.Contains(strings.ToLower(e.Message), "webhook") {
+				msg = "webhook failure, " + msg
+			}
+			messages = append(messages, msg)
+		}
+	}
+	if len(messages) != 0 {
+		return fmt.Errorf("%s", strings.Join(messages, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newImportControllerPod(phase corev1.PodPhase, waitingReason string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      importControllerName + "-abcde",
+			Namespace: "open-cluster-management",
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Ready: true},
+			},
+		},
+	}
+	if waitingReason != "" {
+		pod.Status.ContainerStatuses[0].Ready = false
+		pod.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: waitingReason}
+	}
+	return pod
+}
+
+func TestOptions_waitForImportSecret(t *testing.T) {
+	now := metav1.Now()
+	tests := []struct {
+		name      string
+		objs      []runtime.Object
+		wantCause []string
+	}{
+		{
+			name:      "Controller not installed",
+			objs:      []runtime.Object{&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}},
+			wantCause: []string{"not installed"},
+		},
+		{
+			name: "Controller crashing",
+			objs: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+				newImportControllerPod(corev1.PodRunning, "CrashLoopBackOff"),
+			},
+			wantCause: []string{"CrashLoopBackOff"},
+		},
+		{
+			name: "Namespace terminating",
+			objs: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", DeletionTimestamp: &now}},
+				newImportControllerPod(corev1.PodRunning, ""),
+			},
+			wantCause: []string{"terminating"},
+		},
+		{
+			name: "Webhook failure",
+			objs: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+				newImportControllerPod(corev1.PodRunning, ""),
+				&corev1.Event{
+					ObjectMeta:     metav1.ObjectMeta{Name: "event", Namespace: "test"},
+					InvolvedObject: corev1.ObjectReference{Kind: "Secret", Name: "test-import"},
+					Type:           corev1.EventTypeWarning,
+					Reason:         "FailedCreate",
+					Message:        `Internal error occurred: failed calling webhook "ocm.webhook.io"`,
+				},
+			},
+			wantCause: []string{"webhook failure"},
+		},
+		{
+			name: "No root cause",
+			objs: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+				newImportControllerPod(corev1.PodRunning, ""),
+			},
+			wantCause: []string{"no root cause found"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				clusterName:         "test",
+				importSecretTimeout: time.Second,
+				pollInterval:        100 * time.Millisecond,
+				ctx:                 context.Background(),
+			}
+			_, err := o.waitForImportSecret(fake.NewClient(tt.objs...))
+			if err == nil {
+				t.Fatal("waitForImportSecret() expected an error")
+			}
+			for _, want := range tt.wantCause {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("waitForImportSecret() error must contain %q, got %s", want, err.Error())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// crdWaitTimeouts are the timeouts of the wait for the CRDs of the manual import, the single node
+// distributions run on small devices on which the CRDs take longer to be established
+var crdWaitTimeouts = map[string]time.Duration{
+	helpers.DistributionK3s:        5 * time.Minute,
+	helpers.DistributionMicroShift: 5 * time.Minute,
+}
+
+const defaultCRDWaitTimeout = time.Minute
+
+// crdWaitTimeout returns the timeout of the wait for the CRDs on a cluster of the distribution
+func crdWaitTimeout(distribution string) time.Duration {
+	if timeout, ok := crdWaitTimeouts[distribution]; ok {
+		return timeout
+	}
+	return defaultCRDWaitTimeout
+}
+
+// distribution returns the distribution of the cluster, "" if it is not known
+func (o *Options) distribution() string {
+	return applierscenarios.GetString(o.values, "distribution")
+}
+
+// detectDistribution sets the distribution of the cluster from its kubeconfig when it is not set,
+// the distribution is left unset if the cluster can not be queried, the preflight checks report it
+func (o *Options) detectDistribution() error {
+	if o.distribution() != "" || o.clusterKubeConfig == "" || o.clusterName == localClusterName {
+		return nil
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig([]byte(o.clusterKubeConfig))
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig: %s", err.Error())
+	}
+	config.Timeout = 10 * time.Second
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	distribution, err := helpers.DetectDistribution(o.ctx, client)
+	if err != nil {
+		return nil
+	}
+	o.values["distribution"] = distribution
+	if !o.applierScenariosOptions.Silent {
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "The cluster %s is a %s cluster\n", o.clusterName, distribution)
+	}
+	return nil
+}
+
+// validateDistribution checks the distribution and that the OpenShift features are only used on OpenShift
+func validateDistribution(values map[string]interface{}, hiveAdopt bool) error {
+	distribution := applierscenarios.GetString(values, "distribution")
+	if err := helpers.ValidateDistribution(distribution); err != nil {
+		return err
+	}
+	if hiveAdopt && distribution != "" && distribution != helpers.DistributionOpenShift {
+		return fmt.Errorf("only the OpenShift clusters can be adopted in Hive, the cluster is a %s cluster", distribution)
+	}
+	return nil
+}
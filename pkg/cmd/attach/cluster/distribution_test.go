@@ -0,0 +1,76 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+)
+
+func Test_validateDistribution(t *testing.T) {
+	tests := []struct {
+		name         string
+		distribution string
+		hiveAdopt    bool
+		wantErr      bool
+	}{
+		{name: "Success, detected", hiveAdopt: true},
+		{name: "Success, k3s", distribution: helpers.DistributionK3s},
+		{name: "Success, adopt OpenShift", distribution: helpers.DistributionOpenShift, hiveAdopt: true},
+		{name: "Failed, unsupported", distribution: "rancher", wantErr: true},
+		{name: "Failed, adopt MicroShift", distribution: helpers.DistributionMicroShift, hiveAdopt: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]interface{}{"distribution": tt.distribution}
+			if err := validateDistribution(values, tt.hiveAdopt); (err != nil) != tt.wantErr {
+				t.Errorf("validateDistribution() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_crdWaitTimeout(t *testing.T) {
+	if got := crdWaitTimeout(""); got != time.Minute {
+		t.Errorf("crdWaitTimeout() = %s, want 1m0s", got)
+	}
+	if got := crdWaitTimeout(helpers.DistributionMicroShift); got != 5*time.Minute {
+		t.Errorf("crdWaitTimeout(microshift) = %s, want 5m0s", got)
+	}
+}
+
+func TestHubManifests_distribution(t *testing.T) {
+	tests := []struct {
+		distribution string
+		want         string
+	}{
+		{distribution: "", want: "auto-detect"},
+		{distribution: helpers.DistributionOpenShift, want: "auto-detect"},
+		{distribution: helpers.DistributionK3s, want: "K3s"},
+		{distribution: helpers.DistributionMicroShift, want: "MicroShift"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.distribution, func(t *testing.T) {
+			values := newTemplateValues(t, map[string]interface{}{"managedClusterName": "edge1", "distribution": tt.distribution})
+			manifests, err := HubManifests(resources.NewResourcesReader(), values)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range manifests {
+				switch m.GetKind() {
+				case "ManagedCluster":
+					if got := m.GetLabels()["vendor"]; got != tt.want {
+						t.Errorf("ManagedCluster vendor = %s, want %s", got, tt.want)
+					}
+				case "KlusterletAddonConfig":
+					got, _ := m.Object["spec"].(map[string]interface{})["clusterLabels"].(map[string]interface{})["vendor"].(string)
+					if got != tt.want {
+						t.Errorf("KlusterletAddonConfig vendor = %s, want %s", got, tt.want)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cloud/aws"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+)
+
+var eksExample = `
+# Attach an EKS cluster, the AWS credentials are read from the environment or the shared credentials file
+%[1]s attach cluster eks --cluster-name mycluster --region us-east-1
+
+# Attach an EKS cluster using an AWS profile and another managed cluster name
+%[1]s attach cluster eks --cluster-name mycluster --region us-east-1 --profile dev --name eks-dev
+`
+
+var eksValuesSchema = newProviderValuesSchema("name")
+
+type eksOptions struct {
+	*Options
+	eksClusterName string
+	region         string
+	profile        string
+}
+
+// newCmdEKS attaches an EKS cluster with a service account bootstrapped with the AWS credentials
+func newCmdEKS(streams genericclioptions.IOStreams) *cobra.Command {
+	o := &eksOptions{
+		Options: newOptions(streams),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "eks",
+		Short: "Import an EKS cluster",
+		Long: "Import an EKS cluster, the AWS credentials are used to generate a short-lived token " +
+			"with which a service account is created on the cluster, the import uses the non-expiring token of that service account",
+		Example:      fmt.Sprintf(eksExample, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.eksClusterName, "cluster-name", "", "Name of the EKS cluster")
+	cmd.Flags().StringVar(&o.region, "region", "", "AWS region of the EKS cluster")
+	cmd.Flags().StringVar(&o.profile, "profile", "", "AWS profile of the shared credentials file, defaults to AWS_PROFILE or default")
+	o.addProviderFlags(cmd, eksValuesSchema)
+
+	return cmd
+}
+
+func (o *eksOptions) complete(cmd *cobra.Command, args []string) error {
+	return o.completeProvider(cmd, eksValuesSchema, o.eksClusterName)
+}
+
+func (o *eksOptions) validate() error {
+	if o.eksClusterName == "" {
+		return fmt.Errorf("cluster-name is missing")
+	}
+	if o.region == "" {
+		return fmt.Errorf("region is missing")
+	}
+	return nil
+}
+
+func (o *eksOptions) run() error {
+	creds, err := aws.LoadCredentials(o.profile)
+	if err != nil {
+		return err
+	}
+	eksCluster, err := aws.DescribeCluster(creds, o.region, o.eksClusterName)
+	if err != nil {
+		return err
+	}
+	token, err := aws.GetToken(creds, o.region, o.eksClusterName)
+	if err != nil {
+		return err
+	}
+	return o.attachWithServiceAccount(&rest.Config{
+		Host:        eksCluster.Endpoint,
+		BearerToken: token,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: eksCluster.CertificateAuthorityData,
+		},
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newProviderValuesCmd(t *testing.T, schema applierscenarios.ValuesSchema, args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	schema.AddFlags(cmd.Flags())
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestEKSOptions_complete(t *testing.T) {
+	tests := []struct {
+		name            string
+		valuesPath      string
+		setValues       []string
+		cmd             *cobra.Command
+		wantClusterName string
+	}{
+		{
+			name:            "Success, default values",
+			wantClusterName: "eks-cluster",
+		},
+		{
+			name:            "Success, name flag",
+			cmd:             newProviderValuesCmd(t, eksValuesSchema, "--name", "mycluster"),
+			wantClusterName: "mycluster",
+		},
+		{
+			name:            "Success, set values merged with the defaults",
+			setValues:       []string{"autoImportRetry=3"},
+			wantClusterName: "eks-cluster",
+		},
+		{
+			name:            "Success, values file",
+			valuesPath:      filepath.Join(attachClusterTestDir, "values-with-data.yaml"),
+			wantClusterName: "test",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &eksOptions{
+				Options: &Options{
+					applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+						ValuesPaths: []string{tt.valuesPath},
+						SetValues:   tt.setValues,
+					},
+				},
+				eksClusterName: "eks-cluster",
+				region:         "us-east-1",
+			}
+			if err := o.complete(tt.cmd, nil); err != nil {
+				t.Fatal(err)
+			}
+			if o.clusterName != tt.wantClusterName {
+				t.Errorf("clusterName = %s, want %s", o.clusterName, tt.wantClusterName)
+			}
+			for _, k := range []string{"kubeConfig", "server", "token"} {
+				if o.values[k] != "" {
+					t.Errorf("%s must be reset, got %v", k, o.values[k])
+				}
+			}
+			if _, found, _ := unstructured.NestedBool(o.values, "addons", "policyController", "enabled"); !found {
+				t.Error("the addons must be set")
+			}
+		})
+	}
+}
+
+func TestEKSOptions_validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		eksClusterName string
+		region         string
+		wantErr        bool
+	}{
+		{name: "Success", eksClusterName: "eks-cluster", region: "us-east-1"},
+		{name: "Failed, cluster-name missing", region: "us-east-1", wantErr: true},
+		{name: "Failed, region missing", eksClusterName: "eks-cluster", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &eksOptions{
+				eksClusterName: tt.eksClusterName,
+				region:         tt.region,
+			}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,58 +4,155 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/url"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ghodss/yaml"
 
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
-
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/applier/pkg/templateprocessor"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
 	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/progress"
 	"github.com/open-cluster-management/cm-cli/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+// importFileStdout is the --import-file value writing the import manifests on the standard output
+const importFileStdout = "-"
+
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
-	o.values, err = appliercmd.ConvertValuesFileToValuesMap(o.applierScenariosOptions.ValuesPath, "")
+	o.ctx = helpers.CommandContext(cmd)
+	if o.inventoryFile != "" {
+		return o.completeInventory(cmd, args)
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("only one cluster name can be given, got %s", strings.Join(args, " "))
+	}
+	if o.manifestFile != "" {
+		if len(args) != 0 {
+			return fmt.Errorf("a cluster name can not be given with --manifest, got %s", strings.Join(args, " "))
+		}
+		return o.completeManifest(cmd)
+	}
+	switch {
+	//Without --values, the values of the cluster given as argument are looked up in the values root
+	case len(args) == 1 && len(o.applierScenariosOptions.ValuesPaths) == 0:
+		o.values, err = o.readConventionValues(args[0])
+	//Without --values, --name and the credentials flags are enough, the other values are the defaults of the template
+	case len(o.applierScenariosOptions.ValuesPaths) == 0 && cmd != nil && cmd.Flags().Changed("name"):
+		o.values, err = o.readDefaultedValues()
+	default:
+		o.values, err = o.applierScenariosOptions.ReadValues()
+	}
 	if err != nil {
 		return err
 	}
 
 	if len(o.values) == 0 {
-		return fmt.Errorf("values are missing")
+		return fmt.Errorf("values are missing, set --values or --name with --cluster-server and --cluster-token or --cluster-kubeconfig")
+	}
+	//The cluster name given as argument is overwritten by --name like the one of the values file
+	if len(args) == 1 {
+		o.values["managedClusterName"] = args[0]
 	}
 
-	if o.clusterKubeConfig == "" {
-		if ikubeConfig, ok := o.values["kubeConfig"]; ok {
-			o.clusterKubeConfig = ikubeConfig.(string)
-		}
+	//Nothing else than the import manifests is written on the standard output
+	if o.importFile == importFileStdout {
+		o.applierScenariosOptions.Silent = true
 	}
-	o.values["kubeConfig"] = o.clusterKubeConfig
 
-	if o.clusterServer == "" {
-		if iclusterServer, ok := o.values["server"]; ok {
-			o.clusterServer = iclusterServer.(string)
+	if o.curatorFile != "" {
+		o.values["curator"], err = helpers.ReadCuratorFile(o.curatorFile)
+		if err != nil {
+			return err
 		}
 	}
-	o.values["server"] = o.clusterServer
 
-	if o.clusterToken == "" {
-		if iclusterToken, ok := o.values["token"]; ok {
-			o.clusterToken = iclusterToken.(string)
+	if o.additionalCABundleFile != "" {
+		o.additionalCABundle, err = helpers.ReadCABundle(o.additionalCABundleFile)
+		if err != nil {
+			return err
 		}
 	}
+
+	if err := applyProfile(o.values, o.profile); err != nil {
+		return err
+	}
+
+	return o.completeValues(cmd, valuesSchema)
+}
+
+// completeValues merges the flags of the schema in the values and reads the import parameters
+func (o *Options) completeValues(cmd *cobra.Command, schema applierscenarios.ValuesSchema) error {
+	var flagSet *pflag.FlagSet
+	if cmd != nil {
+		flagSet = cmd.Flags()
+	}
+	if err := schema.MergeFlags(flagSet, o.values); err != nil {
+		return err
+	}
+	//The credentials given by the flags can be references as well
+	if err := o.applierScenariosOptions.ResolveCredentialValues(o.values); err != nil {
+		return err
+	}
+
+	o.clusterName = applierscenarios.GetString(o.values, "managedClusterName")
+	o.clusterKubeConfig = applierscenarios.GetString(o.values, "kubeConfig")
+	o.clusterServer = applierscenarios.GetString(o.values, "server")
+	o.clusterToken = applierscenarios.GetString(o.values, "token")
+	//The templates expect strings for the import credentials
+	o.values["kubeConfig"] = o.clusterKubeConfig
+	o.values["server"] = o.clusterServer
 	o.values["token"] = o.clusterToken
+	//The namespace is created unless explicitly disabled, the values files written before the option still create it
+	o.existingNamespace = applierscenarios.GetString(o.values, "createNamespace") == "false"
+	o.values["createNamespace"] = !o.existingNamespace
+	//The templates expect strings for the proxy as well
+	o.values["proxy"] = map[string]interface{}{
+		"httpProxy":  applierscenarios.GetString(o.values, "proxy.httpProxy"),
+		"httpsProxy": applierscenarios.GetString(o.values, "proxy.httpsProxy"),
+		"noProxy":    applierscenarios.GetString(o.values, "proxy.noProxy"),
+	}
+	o.values["clusterSet"] = applierscenarios.GetString(o.values, "clusterSet")
+	//The expiry is set as an RFC 3339 time so it does not depend on when the values are applied
+	expires, err := helpers.NormalizeExpiry(applierscenarios.GetString(o.values, "lifecycle.expires"), time.Now())
+	if err != nil {
+		return err
+	}
+	o.values["lifecycle"] = map[string]interface{}{
+		"owner":   applierscenarios.GetString(o.values, "lifecycle.owner"),
+		"ticket":  applierscenarios.GetString(o.values, "lifecycle.ticket"),
+		"expires": expires,
+	}
+	o.hiveAdopt = completeHiveValues(o.values)
+	o.values["distribution"] = applierscenarios.GetString(o.values, "distribution")
 
-	return nil
+	return completeKlusterletValues(o.values, o.klusterletNodeSelector, o.klusterletTolerations)
 }
 
 func (o *Options) validate() error {
+	if o.manifestFile != "" {
+		return o.validateManifest()
+	}
+	if o.inventoryFile != "" {
+		return o.validateInventory()
+	}
 	if o.clusterName == "" {
 		iname, ok := o.values["managedClusterName"]
 		if !ok || iname == nil {
@@ -68,8 +165,11 @@ func (o *Options) validate() error {
 	}
 
 	o.values["managedClusterName"] = o.clusterName
+	if err := helpers.ValidateClusterName(o.clusterName); err != nil {
+		return err
+	}
 
-	if o.clusterName != "local-cluster" {
+	if o.clusterName != localClusterName {
 		if o.clusterKubeConfig != "" && (o.clusterToken != "" || o.clusterServer != "") {
 			return fmt.Errorf("server/token and kubeConfig are mutually exclusif")
 		}
@@ -80,79 +180,553 @@ func (o *Options) validate() error {
 		}
 
 		if o.applierScenariosOptions.OutFile == "" &&
+			o.export == "" &&
 			o.clusterKubeConfig == "" &&
 			o.clusterToken == "" &&
 			o.clusterServer == "" &&
-			o.importFile == "" {
-			return fmt.Errorf("either kubeConfig or token/server or import-file must be provided")
+			o.importFile == "" &&
+			o.importOutputDir == "" &&
+			o.bundleFile == "" {
+			return fmt.Errorf("either kubeConfig or token/server or import-file or import-output-dir or bundle must be provided")
+		}
+	}
+
+	//The import controller applies the manifests of the auto-import as they are generated on the hub
+	if len(o.additionalCABundle) != 0 && !o.manualImport() {
+		return fmt.Errorf("--%s requires import-file, import-output-dir or bundle", helpers.AdditionalCABundleFlag)
+	}
+
+	if err := validateProxy(o.values); err != nil {
+		return err
+	}
+
+	if err := validateKlusterlet(o.values); err != nil {
+		return err
+	}
+
+	//The agents of the Hosted mode run on the hosting cluster, deployed by the hub, and reach the cluster with its kubeConfig
+	if hostingCluster(o.values) != "" {
+		if o.clusterKubeConfig == "" {
+			return fmt.Errorf("the %s klusterlet mode requires the kubeConfig of the cluster", klusterletModeHosted)
+		}
+		if o.manualImport() {
+			return fmt.Errorf("the %s klusterlet mode can not be used with import-file, import-output-dir or bundle", klusterletModeHosted)
+		}
+	}
+
+	if lease := applierscenarios.GetString(o.values, "leaseDurationSeconds"); lease != "" {
+		if i, err := strconv.Atoi(lease); err != nil || i <= 0 {
+			return fmt.Errorf("invalid leaseDurationSeconds %s, expected a positive number of seconds", lease)
+		}
+	}
+
+	if err := o.validateMintToken(); err != nil {
+		return err
+	}
+
+	//The ClusterDeployment references the admin kubeconfig of the cluster
+	if o.hiveAdopt && o.clusterKubeConfig == "" {
+		return fmt.Errorf("hive-adopt requires the kubeConfig of the cluster")
+	}
+
+	if err := validateDistribution(o.values, o.hiveAdopt); err != nil {
+		return err
+	}
+
+	if o.async && o.manualImport() {
+		return fmt.Errorf("async can not be used with import-file, import-output-dir or bundle")
+	}
+
+	//The standard output only contains the import manifests so they can be piped
+	if o.importFile == importFileStdout && o.applierScenariosOptions.ProgressFormat == progress.FormatJSON {
+		return fmt.Errorf("--import-file - can not be used with --progress-format %s", progress.FormatJSON)
+	}
+
+	if o.rollbackOnFailure && o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("rollback-on-failure can not be used with outFile")
+	}
+
+	if err := o.validateExport(); err != nil {
+		return err
+	}
+
+	if o.wait && o.curatorFile == "" {
+		return fmt.Errorf("wait requires curator-file")
+	}
+	//The curation completes once the cluster is imported, which never happens before the import-file or bundle is applied
+	if o.wait && (o.async || o.manualImport() || o.applierScenariosOptions.OutFile != "") {
+		return fmt.Errorf("wait can not be used with async, import-file, import-output-dir, bundle or outFile")
+	}
+
+	if err := helpers.ValidateWaitFor(o.waitFor); err != nil {
+		return err
+	}
+	//The cluster only joins once the import manifests are applied on it
+	if o.waitFor != "" && (o.async || o.manualImport() || o.export != "" || o.applierScenariosOptions.OutFile != "") {
+		return fmt.Errorf("wait-for can not be used with async, import-file, import-output-dir, bundle, export or outFile")
+	}
+
+	if err := o.validatePostAttachJob(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateMintToken checks the service account of --mint-token can be created with the kubeConfig
+// and that nothing else needs the kubeConfig once it is replaced by the token
+func (o *Options) validateMintToken() error {
+	if !o.mintToken {
+		return nil
+	}
+	if o.clusterKubeConfig == "" {
+		return fmt.Errorf("mint-token requires the kubeConfig of the cluster")
+	}
+	if o.manualImport() || o.export != "" || o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("mint-token can not be used with import-file, import-output-dir, bundle, export or outFile")
+	}
+	if o.hiveAdopt || hostingCluster(o.values) != "" {
+		return fmt.Errorf("mint-token can not be used with hive-adopt or the %s klusterlet mode, they require the kubeConfig", klusterletModeHosted)
+	}
+	return nil
+}
+
+// validateExport checks --export is only combined with the options writing the resources in the git directory
+func (o *Options) validateExport() error {
+	if o.export == "" {
+		if o.gitDir != "" {
+			return fmt.Errorf("git-dir requires --export %s", exportGitOps)
+		}
+		return nil
+	}
+	if o.export != exportGitOps {
+		return fmt.Errorf("unsupported export %s, supported exports are %s", o.export, strings.Join(exportFormats, ", "))
+	}
+	if o.gitDir == "" {
+		return fmt.Errorf("--export %s requires git-dir", exportGitOps)
+	}
+	if o.manualImport() || o.async || o.wait || o.rollbackOnFailure || o.hiveAdopt || o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("--export can not be used with import-file, import-output-dir, bundle, async, wait, rollback-on-failure, hive-adopt or outFile")
+	}
+	return nil
+}
+
+// manualImport returns true if the import manifests are generated to be applied manually on the managed cluster
+func (o *Options) manualImport() bool {
+	return o.importFile != "" || o.importOutputDir != "" || o.bundleFile != ""
+}
+
+// validateProxy checks the proxies are http or https urls, the noProxy only applies with a proxy
+func validateProxy(values map[string]interface{}) error {
+	httpProxy := applierscenarios.GetString(values, "proxy.httpProxy")
+	httpsProxy := applierscenarios.GetString(values, "proxy.httpsProxy")
+	for _, proxy := range []string{httpProxy, httpsProxy} {
+		if proxy == "" {
+			continue
 		}
+		u, err := url.Parse(proxy)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid proxy %s, expected http://host:port or https://host:port", proxy)
+		}
+	}
+	if httpProxy == "" && httpsProxy == "" && applierscenarios.GetString(values, "proxy.noProxy") != "" {
+		return fmt.Errorf("no-proxy requires http-proxy or https-proxy")
+	}
+	return nil
+}
+
+// HubManifests returns the resources created on the hub by the attach of a cluster with the values,
+// the values are completed as the attach does
+func HubManifests(reader templateprocessor.TemplateReader, values map[string]interface{}) ([]*unstructured.Unstructured, error) {
+	if err := CompleteValues(values); err != nil {
+		return nil, err
+	}
+	return renderHub(reader, values)
+}
+
+// CompleteValues completes the values as the attach does before rendering the templates
+func CompleteValues(values map[string]interface{}) error {
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+		values:                  values,
+	}
+	return o.completeValues(nil, valuesSchema)
+}
+
+// renderHub renders the templates of the hub resources in apply order
+func renderHub(reader templateprocessor.TemplateReader, values map[string]interface{}) ([]*unstructured.Unstructured, error) {
+	tp, err := templateprocessor.NewTemplateProcessor(reader, &templateprocessor.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return tp.TemplateResourcesInPathUnstructured(filepath.Join(scenarioDirectory, "hub"), []string{}, true, values)
+}
+
+// trackRollback records the hub resources and the files which do not exist yet and are about to be created by the attach
+func (o *Options) trackRollback(client crclient.Client, reader templateprocessor.TemplateReader) (*helpers.Rollback, error) {
+	manifests, err := renderHub(reader, o.values)
+	if err != nil {
+		return nil, err
+	}
+	rollback := helpers.NewRollback(client)
+	if err := rollback.TrackResources(o.ctx, manifests); err != nil {
+		return nil, err
+	}
+	if o.bundleFile != "" {
+		rollback.TrackFile(o.bundleFile)
+	}
+	if o.importOutputDir != "" {
+		rollback.TrackFile(filepath.Join(o.importOutputDir, bundleCRDsFile))
+		rollback.TrackFile(filepath.Join(o.importOutputDir, bundleImportFile))
 	}
+	if o.importFile != "" && o.importFile != importFileStdout {
+		rollback.TrackFile(o.importFile)
+	}
+	return rollback, nil
+}
 
+// rollback removes what the failed attach created, it returns the error of the attach
+// completed by the one of the rollback so the user knows what is left on the hub
+func (o *Options) rollback(rollback *helpers.Rollback, attachErr error) error {
+	//The rollback also cleans up an attach interrupted by Ctrl+C, so it does not run with the context of the command
+	err := o.progressReporter().Step("rollback", "ManagedCluster/"+o.clusterName, func() error {
+		return rollback.Run(context.Background())
+	})
+	if err != nil {
+		return fmt.Errorf("%s, the rollback failed: %s", attachErr.Error(), err.Error())
+	}
+	if !o.applierScenariosOptions.Silent && len(rollback.Resources()) != 0 {
+		fmt.Fprintf(o.applierScenariosOptions.ErrOut, "The attach failed, %s removed\n", strings.Join(rollback.Resources(), ", "))
+	}
+	return attachErr
+}
+
+// checkNamespace checks the namespace of the cluster exists when its creation is disabled
+func checkNamespace(ctx context.Context, client crclient.Client, clusterName string) error {
+	ns := &corev1.Namespace{}
+	err := client.Get(ctx, types.NamespacedName{Name: clusterName}, ns)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("the namespace %s of the cluster does not exist and --create-namespace is false, ask an administrator to create it or set --create-namespace=true", clusterName)
+	}
+	return err
+}
+
+// saveSpec saves the command with its resolved values to replay it, if --save-spec is set
+func (o *Options) saveSpec(cmd *cobra.Command, args []string) error {
+	if o.saveSpecPath == "" {
+		return nil
+	}
+	if err := helpers.WriteOperationSpec(o.saveSpecPath, helpers.NewOperationSpec(cmd, args, o.values, valuesSchema.FlagNames())); err != nil {
+		return err
+	}
+	if !o.applierScenariosOptions.Silent {
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "Spec saved in %s, replay it with \"%s apply -f %s\"\n", o.saveSpecPath, helpers.GetExampleHeader(), o.saveSpecPath)
+	}
 	return nil
 }
 
 func (o *Options) run() (err error) {
-	client, err := helpers.GetClientFromFlags(o.applierScenariosOptions.ConfigFlags)
+	if o.manifestFile != "" {
+		return o.runManifest(func(c *Options) error {
+			return c.run()
+		})
+	}
+	//The exported resources are applied by the GitOps tool, the hub is not accessed
+	if o.export == exportGitOps {
+		return o.writeGitOps()
+	}
+	if o.inventoryFile != "" {
+		return o.runInventory(func(c *Options) error {
+			return c.run()
+		})
+	}
+	client, err := clients.ForFlags(o.applierScenariosOptions.ConfigFlags).Client()
 	if err != nil {
 		return err
 	}
-	return o.runWithClient(client)
+	if o.applierScenariosOptions.OutFile == "" {
+		if err := o.detectLocalCluster(); err != nil {
+			return err
+		}
+		if err := o.detectDistribution(); err != nil {
+			return err
+		}
+	}
+	if o.hiveAdopt {
+		err := o.progressReporter().Step("hive", "ClusterDeployment/"+o.clusterName, o.completeHive)
+		if err != nil {
+			return err
+		}
+	}
+	if !o.skipPreflight && o.applierScenariosOptions.OutFile == "" {
+		err := o.progressReporter().Step("preflight", "", func() error {
+			return o.preflight(client)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if o.mintToken {
+		if err := o.mintTokenFromKubeConfig(); err != nil {
+			return err
+		}
+	}
+	if err := o.runWithClient(client); err != nil {
+		return err
+	}
+	//The asynchronous attach prints the operation ID, the standard output only contains the manifests with --import-file -
+	if !o.async && o.importFile != importFileStdout {
+		printers.PrintIdentifier(o.applierScenariosOptions.Out, o.clusterName)
+	}
+	return nil
+}
+
+// progressReporter returns the reporter of the progress events, it is created on first use
+func (o *Options) progressReporter() *progress.Reporter {
+	if o.progress == nil {
+		o.progress = o.applierScenariosOptions.NewProgressReporter()
+	}
+	return o.progress
 }
 
 func (o *Options) runWithClient(client crclient.Client) (err error) {
+	reporter := o.progressReporter()
 	reader := resources.NewResourcesReader()
 
 	applyOptions := &appliercmd.Options{
 		OutFile:     o.applierScenariosOptions.OutFile,
 		ConfigFlags: o.applierScenariosOptions.ConfigFlags,
 
-		Timeout:   o.applierScenariosOptions.Timeout,
+		Timeout:   o.applierScenariosOptions.ApplierTimeout(),
 		Force:     o.applierScenariosOptions.Force,
 		Silent:    o.applierScenariosOptions.Silent,
 		IOStreams: o.applierScenariosOptions.IOStreams,
 	}
 
-	err = applyOptions.ApplyWithValues(client, reader,
-		filepath.Join(scenarioDirectory, "hub"),
-		o.values)
+	if o.rollbackOnFailure && o.applierScenariosOptions.OutFile == "" {
+		var rollback *helpers.Rollback
+		rollback, err = o.trackRollback(client, reader)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				err = o.rollback(rollback, err)
+			}
+		}()
+	}
+
+	//An interrupted attach is resumed after its last step, the step is cleared once the attach completes
+	step := ""
+	if o.applierScenariosOptions.OutFile == "" {
+		step, err = getAttachStep(o.ctx, client, o.clusterName)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err == nil {
+				err = setAttachStep(o.ctx, client, o.clusterName, "")
+			}
+		}()
+	}
+
+	if o.existingNamespace && o.applierScenariosOptions.OutFile == "" {
+		err = reporter.Step("namespace", "Namespace/"+o.clusterName, func() error {
+			return checkNamespace(o.ctx, client, o.clusterName)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if clusterSet := applierscenarios.GetString(o.values, "clusterSet"); clusterSet != "" && o.applierScenariosOptions.OutFile == "" {
+		err = reporter.Step("clusterset", "ManagedClusterSet/"+clusterSet, func() error {
+			created, err := helpers.EnsureClusterSet(o.ctx, client, clusterSet, o.createClusterSet)
+			if created && !o.applierScenariosOptions.Silent {
+				fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "ManagedClusterSet %s created\n", clusterSet)
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if step != "" && !o.applierScenariosOptions.Silent {
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "Resuming the interrupted attach of cluster %s, its hub resources are applied again\n", o.clusterName)
+	}
+	err = reporter.Step("apply", "ManagedCluster/"+o.clusterName, func() error {
+		err := o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader,
+			filepath.Join(scenarioDirectory, "hub"),
+			o.values)
+		if err != nil || o.applierScenariosOptions.OutFile != "" {
+			return err
+		}
+		return setAttachStep(o.ctx, client, o.clusterName, laterAttachStep(step, attachStepApplied))
+	})
 	if err != nil {
 		return err
 	}
 
-	if o.importFile != "" &&
+	if o.wait {
+		err = reporter.Step("curation", "ClusterCurator/"+o.clusterName, func() error {
+			return helpers.WaitForCuration(o.ctx, client, o.clusterName, o.pollInterval, o.curationTimeout)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.postAttachJob != "" && attachStepDone(step, attachStepPostAttachJob) {
+		reporter.Report("post-attach-job", "AnsibleJob/"+o.postAttachJob, progress.StatusSucceeded, "created by the interrupted attach")
+	} else if o.postAttachJob != "" {
+		err = reporter.Step("post-attach-job", "AnsibleJob/"+o.postAttachJob, func() error {
+			return o.runPostAttachJob(client)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.waitFor != "" {
+		err = reporter.Step("wait-for", "ManagedCluster/"+o.clusterName, func() error {
+			return helpers.WaitForCluster(o.ctx, client, o.clusterName, o.waitFor, o.pollInterval, o.waitForTimeout)
+		})
+		if err != nil {
+			return err
+		}
+		if !o.applierScenariosOptions.Silent {
+			fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "Cluster %s is %s\n", o.clusterName, o.waitFor)
+		}
+	}
+
+	if o.async && o.applierScenariosOptions.OutFile == "" {
+		op, err := helpers.NewOperation(o.ctx, client, "attach", o.clusterName)
+		if err != nil {
+			reporter.Report("operation", "", progress.StatusFailed, err.Error())
+			return err
+		}
+		reporter.Report("operation", op.ID, progress.StatusSucceeded, "")
+		if !o.applierScenariosOptions.Silent {
+			fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "Attach of cluster %s started with operation ID %s\nFollow the import with\n%s status %s\n",
+				o.clusterName, op.ID, helpers.GetExampleHeader(), op.ID)
+		}
+		printers.PrintIdentifier(o.applierScenariosOptions.Out, op.ID)
+		return nil
+	}
+
+	if o.manualImport() &&
 		o.applierScenariosOptions.OutFile == "" &&
-		o.clusterName != "local-cluster" {
-		time.Sleep(10 * time.Second)
-		importSecret := &corev1.Secret{}
-		err = client.Get(context.TODO(),
-			types.NamespacedName{Name: fmt.Sprintf("%s-import", o.clusterName),
-				Namespace: o.clusterName}, importSecret)
+		o.clusterName != localClusterName {
+		if err := o.writeImport(client, reader, applyOptions, reporter, step); err != nil {
+			return err
+		}
+	}
+
+	if o.applierScenariosOptions.OutFile == "" {
+		summary, err := o.newAttachSummary(reader)
 		if err != nil {
 			return err
 		}
+		reporter.ReportSummary("summary", "ManagedCluster/"+o.clusterName, summary)
+		if !o.applierScenariosOptions.Silent {
+			summary.print(o.applierScenariosOptions.ErrOut)
+		}
+	}
+	return nil
+}
 
-		ys, err := yaml.Marshal(importSecret)
+// writeImport waits for the import secret and writes the import bundle, manifests and file of the manual import.
+// The import secret generated for an interrupted attach is read without waiting, step is the step it recorded.
+func (o *Options) writeImport(client crclient.Client, reader templateprocessor.TemplateReader, applyOptions *appliercmd.Options, reporter *progress.Reporter, step string) error {
+	var importSecret *corev1.Secret
+	err := reporter.Step("import-secret", fmt.Sprintf("Secret/%s/%s-import", o.clusterName, o.clusterName), func() (err error) {
+		if attachStepDone(step, attachStepImportSecret) {
+			importSecret, err = helpers.GetImportSecret(o.ctx, client, o.clusterName)
+			if err == nil || !errors.IsNotFound(err) {
+				return err
+			}
+		}
+		importSecret, err = o.waitForImportSecret(client)
 		if err != nil {
 			return err
 		}
+		return setAttachStep(o.ctx, client, o.clusterName, attachStepImportSecret)
+	})
+	if err != nil {
+		return err
+	}
+
+	if o.bundleFile != "" {
+		err = reporter.Step("bundle", o.bundleFile, func() error {
+			return writeBundle(o.bundleFile, o.clusterName, crdWaitTimeout(o.distribution()), importSecret, o.additionalCABundle)
+		})
+		if err != nil {
+			return err
+		}
+	}
 
-		valueys := make(map[string]interface{})
-		err = yaml.Unmarshal(ys, &valueys)
+	if o.importOutputDir != "" {
+		err = reporter.Step("import-output-dir", o.importOutputDir, func() error {
+			return writeImportManifests(o.importOutputDir, importSecret, o.additionalCABundle)
+		})
 		if err != nil {
 			return err
 		}
+	}
+
+	if o.importFile == "" {
+		return nil
+	}
+
+	ys, err := yaml.Marshal(importSecret)
+	if err != nil {
+		return err
+	}
+
+	valueys := make(map[string]interface{})
+	err = yaml.Unmarshal(ys, &valueys)
+	if err != nil {
+		return err
+	}
+
+	//Generate in a temporary file so a failure never leaves a partial import file
+	importFile := o.importFile
+	if importFile == importFileStdout {
+		importFile = filepath.Join(os.TempDir(), "import.yaml")
+	}
+	tmpImportFile, err := helpers.TempFile(importFile)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpImportFile)
 
-		applyOptions.Silent = true
-		applyOptions.OutFile = o.importFile
-		err = applyOptions.ApplyWithValues(client, reader,
+	applyOptions.Silent = true
+	applyOptions.OutFile = tmpImportFile
+	return reporter.Step("import-file", o.importFile, func() error {
+		err := applyOptions.ApplyWithValues(client, reader,
 			filepath.Join(scenarioDirectory, "managedcluster"),
 			valueys)
 		if err != nil {
 			return err
 		}
-		if !o.applierScenariosOptions.Silent {
-			fmt.Printf("Execute this command on the managed cluster\n%s applier -d %s\n", helpers.GetExampleHeader(), o.importFile)
+		if err := annotateImportFile(tmpImportFile, importSecret, o.additionalCABundle); err != nil {
+			return err
 		}
+		if o.importFile == importFileStdout {
+			return copyFile(o.applierScenariosOptions.Out, tmpImportFile)
+		}
+		return helpers.ReplaceFile(tmpImportFile, o.importFile)
+	})
+}
+
+// copyFile writes the content of the file in w
+func copyFile(w io.Writer, path string) error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
 	}
-	return nil
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
 }
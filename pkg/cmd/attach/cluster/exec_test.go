@@ -7,13 +7,24 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/progress"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -45,7 +56,7 @@ func TestOptions_complete(t *testing.T) {
 			name: "Failed, bad valuesPath",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: "bad-values-path.yaml",
+					ValuesPaths: []string{"bad-values-path.yaml"},
 				},
 			},
 			wantErr: true,
@@ -54,7 +65,7 @@ func TestOptions_complete(t *testing.T) {
 			name: "Failed, empty values",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: filepath.Join(attachClusterTestDir, "values-empty.yaml"),
+					ValuesPaths: []string{filepath.Join(attachClusterTestDir, "values-empty.yaml")},
 				},
 			},
 			wantErr: true,
@@ -63,7 +74,7 @@ func TestOptions_complete(t *testing.T) {
 			name: "Sucess, not replacing values",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: filepath.Join(attachClusterTestDir, "values-with-data.yaml"),
+					ValuesPaths: []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")},
 				},
 			},
 			wantErr: false,
@@ -72,11 +83,16 @@ func TestOptions_complete(t *testing.T) {
 			name: "Sucess, replacing values",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: filepath.Join(attachClusterTestDir, "values-with-data.yaml"),
+					ValuesPaths: []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")},
 				},
-				clusterServer:     "overwriteServer",
-				clusterToken:      "overwriteToken",
-				clusterKubeConfig: "overwriteKubeConfig",
+			},
+			args: args{
+				cmd: newValuesCmd(t,
+					"--cluster-server", "overwriteServer",
+					"--cluster-token", "overwriteToken",
+					"--cluster-kubeconfig", filepath.Join(attachClusterTestDir, "kubeconfig.yaml"),
+					"--auto-import-retry", "2",
+					"--addon-search-collector=false"),
 			},
 			wantErr: false,
 		},
@@ -105,6 +121,19 @@ func TestOptions_complete(t *testing.T) {
 				if o.values["token"] != o.clusterToken {
 					t.Errorf("Expect %s got %s", o.clusterToken, o.values["token"])
 				}
+				if !strings.HasPrefix(o.clusterKubeConfig, "apiVersion: v1\nkind: Config") {
+					t.Errorf("Expect the content of the kubeconfig file got %s", o.clusterKubeConfig)
+				}
+				if o.clusterToken != "overwriteToken" {
+					t.Errorf("Expect %s got %s", "overwriteToken", o.clusterToken)
+				}
+				if o.values["autoImportRetry"] != int64(2) {
+					t.Errorf("Expect %d got %v", 2, o.values["autoImportRetry"])
+				}
+				enabled, _, _ := unstructured.NestedBool(o.values, "addons", "searchCollector", "enabled")
+				if enabled {
+					t.Error("Expect searchCollector to be disabled")
+				}
 			}
 			if tt.name == "Sucess, not replacing values" {
 				if o.values["kubeConfig"] != "myKubeConfig" {
@@ -121,6 +150,38 @@ func TestOptions_complete(t *testing.T) {
 	}
 }
 
+func TestOptions_complete_flagsOnly(t *testing.T) {
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	if err := o.complete(newValuesCmd(t, "--name", "mycluster", "--cluster-server", "https://mycluster:6443", "--cluster-token", "myToken"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if o.clusterName != "mycluster" || o.clusterServer != "https://mycluster:6443" || o.clusterToken != "myToken" || o.clusterKubeConfig != "" {
+		t.Errorf("unexpected cluster %s, server %s, token %s, kubeconfig %s", o.clusterName, o.clusterServer, o.clusterToken, o.clusterKubeConfig)
+	}
+	//The other values are the defaults of the template
+	if applierscenarios.GetString(o.values, "addons.applicationManager.enabled") != "true" {
+		t.Errorf("the values of the template are expected, got %v", o.values["addons"])
+	}
+
+	o = newOptions(streams)
+	if err := o.complete(newValuesCmd(t, "--cluster-token", "myToken"), nil); err == nil {
+		t.Error("error expected without --values nor --name")
+	}
+}
+
+func newValuesCmd(t *testing.T, args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	valuesSchema.AddFlags(cmd.Flags())
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
 func TestAttachClusterOptions_Validate(t *testing.T) {
 	type fields struct {
 		applierScenariosOptions *applierscenarios.ApplierScenariosOptions
@@ -130,6 +191,13 @@ func TestAttachClusterOptions_Validate(t *testing.T) {
 		clusterToken            string
 		clusterKubeConfig       string
 		importFile              string
+		importOutputDir         string
+		async                   bool
+		curatorFile             string
+		wait                    bool
+		hiveAdopt               bool
+		waitFor                 string
+		additionalCABundle      []byte
 	}
 	tests := []struct {
 		name    string
@@ -243,6 +311,251 @@ func TestAttachClusterOptions_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Success non-local-cluster, async with kubeconfig",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				clusterKubeConfig: "fake-config",
+				async:             true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed non-local-cluster, async with import-file",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				importFile: "import.yaml",
+				async:      true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success non-local-cluster, wait with curator-file",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				clusterKubeConfig: "fake-config",
+				curatorFile:       "curator.yaml",
+				wait:              true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed non-local-cluster, wait without curator-file",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				clusterKubeConfig: "fake-config",
+				wait:              true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed non-local-cluster, wait with async",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				clusterKubeConfig: "fake-config",
+				curatorFile:       "curator.yaml",
+				wait:              true,
+				async:             true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success non-local-cluster, with import-output-dir",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				importOutputDir: "cluster-test-import",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed non-local-cluster, import-output-dir with async",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				importOutputDir: "cluster-test-import",
+				async:           true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed non-local-cluster, import-file on stdout with json progress",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{ProgressFormat: progress.FormatJSON},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				importFile: "-",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success non-local-cluster, with proxy",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+					"proxy":              map[string]interface{}{"httpsProxy": "http://proxy.example.com:3128", "noProxy": ".cluster.local"},
+				},
+				clusterKubeConfig: "fake-config",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed non-local-cluster, invalid proxy",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+					"proxy":              map[string]interface{}{"httpProxy": "proxy.example.com:3128"},
+				},
+				clusterKubeConfig: "fake-config",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed non-local-cluster, no-proxy without proxy",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+					"proxy":              map[string]interface{}{"noProxy": ".cluster.local"},
+				},
+				clusterKubeConfig: "fake-config",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed non-local-cluster, hive-adopt without kubeconfig",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				clusterServer: "fake-server",
+				clusterToken:  "fake-token",
+				hiveAdopt:     true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed non-local-cluster, wait-for with import-file",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				importFile: "import.yaml",
+				waitFor:    helpers.WaitForJoined,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed non-local-cluster, invalid wait-for",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				clusterKubeConfig: "fake-config",
+				waitFor:           "ready",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success non-local-cluster, hosted klusterlet",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+					"klusterlet":         map[string]interface{}{"mode": "Hosted", "hostingClusterName": "hosting"},
+				},
+				clusterKubeConfig: "fake-config",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed non-local-cluster, hosted klusterlet without kubeconfig",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+					"klusterlet":         map[string]interface{}{"mode": "Hosted", "hostingClusterName": "hosting"},
+				},
+				clusterServer: "fake-server",
+				clusterToken:  "fake-token",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed non-local-cluster, hosted klusterlet with import-file",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+					"klusterlet":         map[string]interface{}{"mode": "Hosted", "hostingClusterName": "hosting"},
+				},
+				clusterKubeConfig: "fake-config",
+				importFile:        "import.yaml",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success, additional CA bundle with import-file",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				importFile:         "import.yaml",
+				additionalCABundle: []byte("ca"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed, additional CA bundle with auto-import",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "cluster-test",
+				},
+				clusterServer:      "fake-server",
+				clusterToken:       "fake-token",
+				additionalCABundle: []byte("ca"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed, reserved cluster name",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedClusterName": "kube-system",
+				},
+				clusterServer: "fake-server",
+				clusterToken:  "fake-token",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -254,6 +567,13 @@ func TestAttachClusterOptions_Validate(t *testing.T) {
 				clusterToken:            tt.fields.clusterToken,
 				clusterKubeConfig:       tt.fields.clusterKubeConfig,
 				importFile:              tt.fields.importFile,
+				importOutputDir:         tt.fields.importOutputDir,
+				async:                   tt.fields.async,
+				curatorFile:             tt.fields.curatorFile,
+				wait:                    tt.fields.wait,
+				hiveAdopt:               tt.fields.hiveAdopt,
+				waitFor:                 tt.fields.waitFor,
+				additionalCABundle:      tt.fields.additionalCABundle,
 			}
 			if err := o.validate(); (err != nil) != tt.wantErr {
 				t.Errorf("AttachClusterOptions.Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -263,17 +583,16 @@ func TestAttachClusterOptions_Validate(t *testing.T) {
 }
 
 func TestOptions_runWithClient(t *testing.T) {
-	generatedImportFileName := filepath.Join(testDir, "tmp", "import.yaml")
+	generatedImportFileName := filepath.Join(t.TempDir(), "import.yaml")
 	resultImportFileName := filepath.Join(attachClusterTestDir, "import_result.yaml")
-	os.Remove(generatedImportFileName)
 	importSecret := corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test-import",
 			Namespace: "test",
 		},
 		Data: map[string][]byte{
-			"crds.yaml":   []byte("crds: mycrds"),
-			"import.yaml": []byte("import: myimport"),
+			"crds.yaml":   []byte(testCRDs),
+			"import.yaml": []byte(testImport),
 		},
 	}
 	client := crclientfake.NewFakeClient(&importSecret)
@@ -304,7 +623,7 @@ func TestOptions_runWithClient(t *testing.T) {
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
 					//Had to set to 1 sec otherwise test timeout is reached (30s)
-					Timeout: 1,
+					Timeout: time.Second,
 				},
 				values:      values,
 				importFile:  generatedImportFileName,
@@ -317,6 +636,7 @@ func TestOptions_runWithClient(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			tt.fields.applierScenariosOptions.IOStreams, _, _, _ = genericclioptions.NewTestIOStreams()
 			o := &Options{
 				applierScenariosOptions: tt.fields.applierScenariosOptions,
 				values:                  tt.fields.values,
@@ -325,6 +645,7 @@ func TestOptions_runWithClient(t *testing.T) {
 				clusterToken:            tt.fields.clusterToken,
 				clusterKubeConfig:       tt.fields.clusterKubeConfig,
 				importFile:              tt.fields.importFile,
+				ctx:                     context.Background(),
 			}
 			if err := o.runWithClient(tt.args.client); (err != nil) != tt.wantErr {
 				t.Errorf("Options.runWithClient() error = %v, wantErr %v", err, tt.wantErr)
@@ -356,3 +677,412 @@ func TestOptions_runWithClient(t *testing.T) {
 		})
 	}
 }
+
+func TestOptions_runWithClient_async(t *testing.T) {
+	client := crclientfake.NewFakeClient()
+	values, err := appliercmd.ConvertValuesFileToValuesMap(filepath.Join(attachClusterTestDir, "values-with-data.yaml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			Timeout: time.Second,
+			Silent:  true,
+		},
+		values:      values,
+		clusterName: "test",
+		async:       true,
+		ctx:         context.Background(),
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	op, err := helpers.GetLastClusterOperation(context.TODO(), client, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op == nil || op.Type != "attach" {
+		t.Errorf("expected an attach operation, got %v", op)
+	}
+}
+
+func TestOptions_runWithClient_curator(t *testing.T) {
+	client := fake.NewClient()
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			ValuesPaths: []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")},
+			Timeout:     time.Second,
+			Silent:      true,
+		},
+		curatorFile:     filepath.Join(attachClusterTestDir, "curator.yaml"),
+		wait:            true,
+		curationTimeout: 10 * time.Second,
+		pollInterval:    50 * time.Millisecond,
+	}
+	if err := o.complete(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	//Simulate the cluster-curator controller
+	go func() {
+		curator := &unstructured.Unstructured{}
+		curator.SetGroupVersionKind(helpers.ClusterCuratorGVK)
+		key := types.NamespacedName{Name: o.clusterName, Namespace: o.clusterName}
+		for i := 0; client.Get(context.TODO(), key, curator) != nil; i++ {
+			if i == 200 {
+				return
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		unstructured.SetNestedSlice(curator.Object, []interface{}{
+			map[string]interface{}{"type": "clustercurator-job", "status": "True", "reason": "Job_has_finished"},
+		}, "status", "conditions")
+		client.Update(context.TODO(), curator)
+	}()
+
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	curator := &unstructured.Unstructured{}
+	curator.SetGroupVersionKind(helpers.ClusterCuratorGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: o.clusterName, Namespace: o.clusterName}, curator); err != nil {
+		t.Fatal(err)
+	}
+	if secret, _, _ := unstructured.NestedString(curator.Object, "spec", "import", "towerAuthSecret"); secret != "toweraccess" {
+		t.Errorf("towerAuthSecret = %s, want toweraccess", secret)
+	}
+}
+
+func TestOptions_runWithClient_waitFor(t *testing.T) {
+	client := fake.NewClient()
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			ValuesPaths: []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")},
+			Timeout:     time.Second,
+			Silent:      true,
+		},
+		waitFor:        helpers.WaitForJoined,
+		waitForTimeout: 10 * time.Second,
+		pollInterval:   50 * time.Millisecond,
+	}
+	if err := o.complete(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	//Simulate the klusterlet joining the hub
+	go func() {
+		mc := &unstructured.Unstructured{}
+		mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+		key := types.NamespacedName{Name: o.clusterName}
+		for i := 0; client.Get(context.TODO(), key, mc) != nil; i++ {
+			if i == 200 {
+				return
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		unstructured.SetNestedSlice(mc.Object, []interface{}{
+			map[string]interface{}{"type": "HubAcceptedManagedCluster", "status": "True"},
+			map[string]interface{}{"type": "ManagedClusterJoined", "status": "True"},
+		}, "status", "conditions")
+		client.Update(context.TODO(), mc)
+	}()
+
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+
+	//The cluster never becomes available
+	o.waitFor = helpers.WaitForAvailable
+	o.waitForTimeout = 200 * time.Millisecond
+	err := o.runWithClient(client)
+	if err == nil || !strings.Contains(err.Error(), "ManagedClusterConditionAvailable") {
+		t.Errorf("error reporting the availability expected, got %v", err)
+	}
+}
+
+func TestOptions_runWithClient_proxy(t *testing.T) {
+	client := fake.NewClient()
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			ValuesPaths: []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")},
+			Timeout:     time.Second,
+			Silent:      true,
+		},
+		async: true,
+		ctx:   context.Background(),
+	}
+	cmd := newValuesCmd(t, "--https-proxy", "http://proxy.example.com:3128", "--no-proxy", ".cluster.local")
+	if err := o.complete(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+
+	kc := &unstructured.Unstructured{}
+	kc.SetGroupVersionKind(helpers.KlusterletConfigGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "test"}, kc); err != nil {
+		t.Fatal(err)
+	}
+	if proxy, _, _ := unstructured.NestedString(kc.Object, "spec", "hubKubeAPIServerProxyConfig", "httpsProxy"); proxy != "http://proxy.example.com:3128" {
+		t.Errorf("httpsProxy = %s, want http://proxy.example.com:3128", proxy)
+	}
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "test"}, mc); err != nil {
+		t.Fatal(err)
+	}
+	if mc.GetAnnotations()["agent.open-cluster-management.io/klusterlet-config"] != "test" {
+		t.Errorf("the ManagedCluster must reference the KlusterletConfig, got %v", mc.GetAnnotations())
+	}
+	kac := &unstructured.Unstructured{}
+	kac.SetGroupVersionKind(helpers.KlusterletAddonConfigGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "test", Namespace: "test"}, kac); err != nil {
+		t.Fatal(err)
+	}
+	if noProxy, _, _ := unstructured.NestedString(kac.Object, "spec", "proxyConfig", "noProxy"); noProxy != ".cluster.local" {
+		t.Errorf("noProxy = %s, want .cluster.local", noProxy)
+	}
+}
+
+func TestOptions_runWithClient_createNamespace(t *testing.T) {
+	preCreated := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: map[string]string{"quota": "small"}},
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		objs    []runtime.Object
+		wantErr bool
+	}{
+		{
+			name: "Success, namespace created",
+		},
+		{
+			name: "Success, pre-created namespace",
+			args: []string{"--create-namespace=false"},
+			objs: []runtime.Object{preCreated},
+		},
+		{
+			name:    "Failed, namespace missing and creation disabled",
+			args:    []string{"--create-namespace=false"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(tt.objs...)
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+					ValuesPaths: []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")},
+					Timeout:     time.Second,
+					Silent:      true,
+				},
+				async: true,
+				ctx:   context.Background(),
+			}
+			if err := o.complete(newValuesCmd(t, tt.args...), nil); err != nil {
+				t.Fatal(err)
+			}
+			err := o.runWithClient(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			mc := &unstructured.Unstructured{}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "test"}, mc); tt.wantErr != (err != nil) {
+				t.Errorf("the ManagedCluster must be created only on success, got %v", err)
+			}
+			ns := &corev1.Namespace{}
+			err = client.Get(context.TODO(), types.NamespacedName{Name: "test"}, ns)
+			if tt.wantErr {
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tt.objs) != 0 && ns.Labels["quota"] != "small" {
+				t.Errorf("the pre-created namespace must not be changed, got labels %v", ns.Labels)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient_clusterSet(t *testing.T) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(helpers.ManagedClusterSetGVK)
+	existing.SetName("prod")
+	tests := []struct {
+		name             string
+		clusterSet       string
+		createClusterSet bool
+		wantErr          bool
+	}{
+		{name: "Success, existing clusterset", clusterSet: "prod"},
+		{name: "Success, created clusterset", clusterSet: "dev", createClusterSet: true},
+		{name: "Failed, missing clusterset", clusterSet: "dev", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(existing.DeepCopy())
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+					ValuesPaths: []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")},
+					Timeout:     time.Second,
+					Silent:      true,
+				},
+				createClusterSet: tt.createClusterSet,
+				async:            true,
+				ctx:              context.Background(),
+			}
+			if err := o.complete(newValuesCmd(t, "--clusterset", tt.clusterSet), nil); err != nil {
+				t.Fatal(err)
+			}
+			err := o.runWithClient(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			mc := &unstructured.Unstructured{}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			err = client.Get(context.TODO(), types.NamespacedName{Name: "test"}, mc)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("the ManagedCluster must not be created when the clusterset does not exist")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mc.GetLabels()[helpers.ClusterSetLabel] != tt.clusterSet {
+				t.Errorf("the ManagedCluster must have the clusterset label, got %v", mc.GetLabels())
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient_lifecycle(t *testing.T) {
+	client := fake.NewClient()
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			ValuesPaths: []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")},
+			Timeout:     time.Second,
+			Silent:      true,
+		},
+		async: true,
+		ctx:   context.Background(),
+	}
+	if err := o.complete(newValuesCmd(t, "--owner", "team-a", "--ticket", "DEV-42", "--expires", "2099-12-31"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "test"}, mc); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		helpers.OwnerAnnotation:   "team-a",
+		helpers.TicketAnnotation:  "DEV-42",
+		helpers.ExpiresAnnotation: "2099-12-31T23:59:59Z",
+	}
+	for k, v := range want {
+		if mc.GetAnnotations()[k] != v {
+			t.Errorf("annotation %s = %s, want %s", k, mc.GetAnnotations()[k], v)
+		}
+	}
+
+	o.values = nil
+	if err := o.complete(newValuesCmd(t, "--expires", "2020-01-01"), nil); err == nil {
+		t.Error("an expiry in the past must be rejected")
+	}
+}
+
+func TestOptions_runWithClient_importStdout(t *testing.T) {
+	importSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-import",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			"crds.yaml":   []byte(testCRDs),
+			"import.yaml": []byte(testImport),
+		},
+	}
+	client := crclientfake.NewFakeClient(&importSecret)
+	values, err := appliercmd.ConvertValuesFileToValuesMap(filepath.Join(attachClusterTestDir, "values-with-data.yaml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &bytes.Buffer{}
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			Timeout: time.Second,
+			Silent:  true,
+		},
+		values:      values,
+		clusterName: "test",
+		importFile:  "-",
+		ctx:         context.Background(),
+	}
+	o.applierScenariosOptions.Out = out
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	want, err := ioutil.ReadFile(filepath.Join(attachClusterTestDir, "import_result.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("the standard output must only contain the import manifests, got:\n%s\nexpected:\n%s", out.String(), string(want))
+	}
+	if _, err := os.Stat("-"); !os.IsNotExist(err) {
+		t.Errorf("no file named - must be written, got %v", err)
+	}
+}
+
+func TestOptions_runWithClient_rollbackOnFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rollback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	//The namespace existed before the attach, it must be kept by the rollback
+	client := fake.NewClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}})
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			ValuesPaths: []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")},
+			Timeout:     time.Second,
+			Silent:      true,
+		},
+		importOutputDir:     filepath.Join(dir, "test-import"),
+		rollbackOnFailure:   true,
+		importSecretTimeout: 10 * time.Millisecond,
+		pollInterval:        time.Millisecond,
+		ctx:                 context.Background(),
+	}
+	if err := o.complete(newValuesCmd(t), nil); err != nil {
+		t.Fatal(err)
+	}
+	//The import secret is never generated so the attach fails once the ManagedCluster is created
+	if err := o.runWithClient(client); err == nil {
+		t.Fatal("runWithClient() expected an error")
+	}
+	for _, gvk := range []schema.GroupVersionKind{helpers.ManagedClusterGVK, helpers.KlusterletConfigGVK} {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		if err := client.Get(context.TODO(), types.NamespacedName{Name: "test"}, u); !errors.IsNotFound(err) {
+			t.Errorf("the %s must be removed by the rollback, got %v", gvk.Kind, err)
+		}
+	}
+	kac := &unstructured.Unstructured{}
+	kac.SetGroupVersionKind(helpers.KlusterletAddonConfigGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "test", Namespace: "test"}, kac); !errors.IsNotFound(err) {
+		t.Errorf("the KlusterletAddonConfig must be removed by the rollback, got %v", err)
+	}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "test"}, &corev1.Namespace{}); err != nil {
+		t.Errorf("the pre-existing namespace must be kept, got %v", err)
+	}
+}
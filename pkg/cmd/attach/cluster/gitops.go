@@ -0,0 +1,125 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// exportGitOps writes the hub resources in a Kustomize layout instead of applying them
+	exportGitOps = "gitops"
+	// gitOpsBaseDir holds the kustomization shared by all the clusters of the git directory
+	gitOpsBaseDir = "base"
+	// gitOpsClustersDir holds one overlay per cluster
+	gitOpsClustersDir = "clusters"
+	// kustomizationFile is the file read by kustomize and Argo CD in each directory
+	kustomizationFile = "kustomization.yaml"
+)
+
+// exportFormats are the supported values of --export
+var exportFormats = []string{exportGitOps}
+
+// baseKustomization is written once, the settings added by the user to the base apply to all the clusters
+const baseKustomization = `# Settings shared by all the clusters, e.g. commonLabels or commonAnnotations
+apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources: []
+`
+
+// gitOpsOverlayDir returns the overlay directory of the cluster in the git directory
+func gitOpsOverlayDir(gitDir, clusterName string) string {
+	return filepath.Join(gitDir, gitOpsClustersDir, clusterName)
+}
+
+// writeGitOps writes the hub resources of the cluster in the git directory, in an overlay of the base,
+// for a GitOps tool such as Argo CD to apply them. The secrets hold the credentials of the cluster,
+// they are not written in the git directory and must be created on the hub by other means.
+func (o *Options) writeGitOps() error {
+	manifests, err := renderHub(resources.NewResourcesReader(), o.values)
+	if err != nil {
+		return err
+	}
+
+	basePath := filepath.Join(o.gitDir, gitOpsBaseDir, kustomizationFile)
+	if _, err := os.Stat(basePath); os.IsNotExist(err) {
+		if err := writeGitOpsFile(basePath, []byte(baseKustomization)); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	overlayDir := gitOpsOverlayDir(o.gitDir, o.clusterName)
+	files := []string{filepath.ToSlash(filepath.Join("..", "..", gitOpsBaseDir))}
+	skipped := make([]string, 0)
+	for _, m := range manifests {
+		if m.GetKind() == "Secret" {
+			skipped = append(skipped, fmt.Sprintf("Secret/%s/%s", m.GetNamespace(), m.GetName()))
+			continue
+		}
+		name := gitOpsFileName(m)
+		b, err := yaml.Marshal(m.Object)
+		if err != nil {
+			return err
+		}
+		if err := writeGitOpsFile(filepath.Join(overlayDir, name), b); err != nil {
+			return err
+		}
+		files = append(files, name)
+	}
+
+	b, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  files,
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeGitOpsFile(filepath.Join(overlayDir, kustomizationFile), b); err != nil {
+		return err
+	}
+
+	if !o.applierScenariosOptions.Silent {
+		msgs := printers.Messages(o.applierScenariosOptions.ErrOut)
+		fmt.Fprintf(msgs, "The resources of cluster %s have been written in %s, commit them to onboard the cluster\n", o.clusterName, overlayDir)
+		if len(skipped) != 0 {
+			fmt.Fprintf(msgs, "The secrets holding the credentials are not written in git, create them on the hub with a secret manager: %s\n",
+				strings.Join(skipped, ", "))
+		}
+	}
+	printers.PrintIdentifier(o.applierScenariosOptions.Out, overlayDir)
+	return nil
+}
+
+// gitOpsFileName returns the file of the resource in the overlay, e.g. managedcluster-mycluster.yaml
+func gitOpsFileName(u *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s-%s.yaml", strings.ToLower(u.GetKind()), u.GetName())
+}
+
+// writeGitOpsFile writes the file through a temporary file so a failure never leaves a partial manifest
+func writeGitOpsFile(path string, data []byte) error {
+	tmp, err := helpers.TempFile(path)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := helpers.ReplaceFile(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
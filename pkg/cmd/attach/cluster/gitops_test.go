@@ -0,0 +1,131 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_writeGitOps(t *testing.T) {
+	gitDir := t.TempDir()
+	values, err := appliercmd.ConvertValuesFileToValuesMap(filepath.Join(attachClusterTestDir, "values-with-data.yaml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{IOStreams: streams},
+		values:                  values,
+		export:                  exportGitOps,
+		gitDir:                  gitDir,
+	}
+	if err := o.completeValues(nil, valuesSchema); err != nil {
+		t.Fatal(err)
+	}
+
+	//The settings added by the user to the base are kept
+	basePath := filepath.Join(gitDir, gitOpsBaseDir, kustomizationFile)
+	if err := os.MkdirAll(filepath.Dir(basePath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	userBase := baseKustomization + "commonLabels:\n  env: prod\n"
+	if err := ioutil.WriteFile(basePath, []byte(userBase), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := o.writeGitOps(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(basePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != userBase {
+		t.Errorf("the base must not be overwritten, got:\n%s", string(b))
+	}
+
+	overlayDir := gitOpsOverlayDir(gitDir, o.clusterName)
+	b, err = ioutil.ReadFile(filepath.Join(overlayDir, kustomizationFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	kustomization := struct {
+		Resources []string `json:"resources"`
+	}{}
+	if err := yaml.Unmarshal(b, &kustomization); err != nil {
+		t.Fatal(err)
+	}
+	if len(kustomization.Resources) < 2 || kustomization.Resources[0] != "../../base" {
+		t.Fatalf("the overlay must reference the base and the resources, got %v", kustomization.Resources)
+	}
+	for _, r := range kustomization.Resources[1:] {
+		if _, err := os.Stat(filepath.Join(overlayDir, r)); err != nil {
+			t.Errorf("the resource %s of the overlay is missing: %v", r, err)
+		}
+		if strings.HasPrefix(r, "secret-") {
+			t.Errorf("the secrets must not be written in git, got %s", r)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(overlayDir, "managedcluster-"+o.clusterName+".yaml")); err != nil {
+		t.Errorf("the ManagedCluster must be written in the overlay: %v", err)
+	}
+	if !strings.Contains(errOut.String(), "Secret/") {
+		t.Errorf("the secrets which are not written must be reported, got %s", errOut.String())
+	}
+	if out.Len() != 0 {
+		t.Errorf("nothing must be written on the standard output, got %s", out.String())
+	}
+}
+
+func TestOptions_validateExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       *Options
+		wantErr bool
+	}{
+		{
+			name: "Success, no export",
+			o:    &Options{},
+		},
+		{
+			name: "Success, gitops",
+			o:    &Options{export: exportGitOps, gitDir: "repo"},
+		},
+		{
+			name:    "Failed, unsupported export",
+			o:       &Options{export: "terraform", gitDir: "repo"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, git-dir missing",
+			o:       &Options{export: exportGitOps},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, git-dir without export",
+			o:       &Options{gitDir: "repo"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, with import-file",
+			o:       &Options{export: exportGitOps, gitDir: "repo", importFile: "import.yaml"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.o.applierScenariosOptions = &applierscenarios.ApplierScenariosOptions{}
+			if err := tt.o.validateExport(); (err != nil) != tt.wantErr {
+				t.Errorf("Options.validateExport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
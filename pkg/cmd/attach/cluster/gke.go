@@ -0,0 +1,91 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cloud/gcp"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var gkeExample = `
+# Attach a GKE cluster, gcloud must be installed and logged in
+%[1]s attach cluster gke --project myproject --zone us-east1-b --name mycluster
+
+# Attach a GKE cluster with another managed cluster name
+%[1]s attach cluster gke --project myproject --zone us-east1-b --name mycluster --managed-cluster-name gke-dev
+`
+
+var gkeValuesSchema = newProviderValuesSchema("managed-cluster-name")
+
+type gkeOptions struct {
+	*Options
+	project        string
+	zone           string
+	gkeClusterName string
+}
+
+// newCmdGKE attaches a GKE cluster with a service account bootstrapped with the gcloud credentials
+func newCmdGKE(streams genericclioptions.IOStreams) *cobra.Command {
+	o := &gkeOptions{
+		Options: newOptions(streams),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "gke",
+		Short: "Import a GKE cluster",
+		Long: "Import a GKE cluster, the gcloud access token is used to create a service account on the cluster, " +
+			"the import uses the non-expiring token of that service account",
+		Example:      fmt.Sprintf(gkeExample, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.project, "project", "", "Google Cloud project of the GKE cluster")
+	cmd.Flags().StringVar(&o.zone, "zone", "", "Zone or region of the GKE cluster")
+	cmd.Flags().StringVar(&o.gkeClusterName, "name", "", "Name of the GKE cluster")
+	o.addProviderFlags(cmd, gkeValuesSchema)
+
+	return cmd
+}
+
+func (o *gkeOptions) complete(cmd *cobra.Command, args []string) error {
+	return o.completeProvider(cmd, gkeValuesSchema, o.gkeClusterName)
+}
+
+func (o *gkeOptions) validate() error {
+	if o.project == "" {
+		return fmt.Errorf("project is missing")
+	}
+	if o.zone == "" {
+		return fmt.Errorf("zone is missing")
+	}
+	if o.gkeClusterName == "" {
+		return fmt.Errorf("name is missing")
+	}
+	return nil
+}
+
+func (o *gkeOptions) run() error {
+	config, err := gcp.GetRESTConfig(o.project, o.zone, o.gkeClusterName)
+	if err != nil {
+		return err
+	}
+	return o.attachWithServiceAccount(config)
+}
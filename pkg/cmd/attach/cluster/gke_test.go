@@ -0,0 +1,51 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+)
+
+func TestGKEOptions_complete(t *testing.T) {
+	o := &gkeOptions{
+		Options: &Options{
+			applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+		},
+		gkeClusterName: "gke-cluster",
+	}
+	cmd := newProviderValuesCmd(t, gkeValuesSchema, "--managed-cluster-name", "mycluster")
+	if err := o.complete(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if o.clusterName != "mycluster" {
+		t.Errorf("clusterName = %s, want mycluster", o.clusterName)
+	}
+}
+
+func TestGKEOptions_validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		project        string
+		zone           string
+		gkeClusterName string
+		wantErr        bool
+	}{
+		{name: "Success", project: "project", zone: "zone", gkeClusterName: "gke-cluster"},
+		{name: "Failed, project missing", zone: "zone", gkeClusterName: "gke-cluster", wantErr: true},
+		{name: "Failed, zone missing", project: "project", gkeClusterName: "gke-cluster", wantErr: true},
+		{name: "Failed, name missing", project: "project", zone: "zone", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &gkeOptions{
+				project:        tt.project,
+				zone:           tt.zone,
+				gkeClusterName: tt.gkeClusterName,
+			}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// hivePlatformNone is the platform of the ClusterDeployments adopted without cloud credentials
+	hivePlatformNone = "none"
+	// regionLabel is set by the cloud providers on the nodes
+	regionLabel = "topology.kubernetes.io/region"
+)
+
+// hivePlatforms maps the platforms of the OpenShift Infrastructure to the platforms of the ClusterDeployment
+// supporting the day-2 operations, the clusters of the other platforms are adopted with the none platform
+var hivePlatforms = map[string]string{
+	"AWS":   "aws",
+	"GCP":   "gcp",
+	"Azure": "azure",
+}
+
+// hiveValuePaths are the values of the ClusterDeployment, the templates expect strings
+var hiveValuePaths = []string{
+	"clusterName",
+	"baseDomain",
+	"platform",
+	"region",
+	"credentialsSecretName",
+	"clusterID",
+	"infraID",
+	"baseDomainResourceGroupName",
+}
+
+// completeHiveValues normalizes the hive values so the templates can evaluate them
+// even for the values files written before the adoption, it returns true if the cluster is adopted
+func completeHiveValues(values map[string]interface{}) bool {
+	hive := map[string]interface{}{
+		"adopt": applierscenarios.GetString(values, "hive.adopt") == "true",
+	}
+	for _, path := range hiveValuePaths {
+		hive[path] = applierscenarios.GetString(values, "hive."+path)
+	}
+	values["hive"] = hive
+	return hive["adopt"].(bool)
+}
+
+// completeHive reads the values of the ClusterDeployment which are not set from the cluster
+// and checks the ClusterDeployment can be created
+func (o *Options) completeHive() error {
+	if o.clusterName == localClusterName {
+		return fmt.Errorf("the hub can not be adopted in Hive, remove --hive-adopt")
+	}
+	hive := o.values["hive"].(map[string]interface{})
+	if o.applierScenariosOptions.OutFile == "" {
+		config, err := clientcmd.RESTConfigFromKubeConfig([]byte(o.clusterKubeConfig))
+		if err != nil {
+			return fmt.Errorf("invalid kubeconfig: %s", err.Error())
+		}
+		config.Timeout = 10 * time.Second
+		spokeClient, err := crclient.New(config, crclient.Options{})
+		if err != nil {
+			return err
+		}
+		if err := discoverHiveValues(o.ctx, spokeClient, hive); err != nil {
+			return err
+		}
+	}
+	return validateHiveValues(hive)
+}
+
+// discoverHiveValues sets the hive values which are not set from the ClusterVersion,
+// Infrastructure and DNS of the OpenShift cluster
+func discoverHiveValues(ctx context.Context, spokeClient crclient.Client, hive map[string]interface{}) error {
+	get := func(gvk schema.GroupVersionKind, name string) (*unstructured.Unstructured, error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		if err := spokeClient.Get(ctx, types.NamespacedName{Name: name}, obj); err != nil {
+			return nil, fmt.Errorf("unable to read the %s %s of the cluster, is it an OpenShift cluster? %s", gvk.Kind, name, err.Error())
+		}
+		return obj, nil
+	}
+	setDefault := func(key, value string) {
+		if hive[key] == "" {
+			hive[key] = value
+		}
+	}
+
+	cv, err := get(helpers.ClusterVersionGVK, "version")
+	if err != nil {
+		return err
+	}
+	clusterID, _, _ := unstructured.NestedString(cv.Object, "spec", "clusterID")
+	setDefault("clusterID", clusterID)
+
+	infra, err := get(helpers.InfrastructureGVK, "cluster")
+	if err != nil {
+		return err
+	}
+	infraID, _, _ := unstructured.NestedString(infra.Object, "status", "infrastructureName")
+	setDefault("infraID", infraID)
+	platformType, _, _ := unstructured.NestedString(infra.Object, "status", "platformStatus", "type")
+	platform, ok := hivePlatforms[platformType]
+	if !ok {
+		platform = hivePlatformNone
+	}
+	setDefault("platform", platform)
+	region, _, _ := unstructured.NestedString(infra.Object, "status", "platformStatus", strings.ToLower(platformType), "region")
+	if region == "" && hive["platform"] != hivePlatformNone {
+		region, err = nodesRegion(ctx, spokeClient)
+		if err != nil {
+			return err
+		}
+	}
+	setDefault("region", region)
+
+	dns, err := get(helpers.DNSGVK, "cluster")
+	if err != nil {
+		return err
+	}
+	//The base domain of the cluster DNS is prefixed by the name of the cluster given at install
+	clusterDomain, _, _ := unstructured.NestedString(dns.Object, "spec", "baseDomain")
+	if parts := strings.SplitN(clusterDomain, ".", 2); len(parts) == 2 {
+		setDefault("clusterName", parts[0])
+		setDefault("baseDomain", parts[1])
+	}
+	return nil
+}
+
+// nodesRegion returns the region set by the cloud provider on the nodes
+func nodesRegion(ctx context.Context, spokeClient crclient.Client) (string, error) {
+	nodes := &corev1.NodeList{}
+	if err := spokeClient.List(ctx, nodes); err != nil {
+		return "", err
+	}
+	for _, node := range nodes.Items {
+		if region := node.Labels[regionLabel]; region != "" {
+			return region, nil
+		}
+	}
+	return "", nil
+}
+
+// validateHiveValues checks the values required by the ClusterDeployment of the platform are set
+func validateHiveValues(hive map[string]interface{}) error {
+	required := []string{"clusterName", "baseDomain", "clusterID", "infraID"}
+	switch hive["platform"] {
+	case hivePlatformNone:
+	case "aws", "gcp":
+		required = append(required, "region", "credentialsSecretName")
+	case "azure":
+		required = append(required, "region", "credentialsSecretName", "baseDomainResourceGroupName")
+	default:
+		return fmt.Errorf("unsupported hive platform %v, expected aws, gcp, azure or none", hive["platform"])
+	}
+	missing := make([]string, 0)
+	for _, key := range required {
+		if hive[key] == "" {
+			missing = append(missing, "hive."+key)
+		}
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("the values %s are required to adopt the cluster in Hive", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newOpenShiftConfig(gvk schema.GroupVersionKind, name string, fields map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: fields}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	return obj
+}
+
+func newOpenShiftCluster(platformStatus map[string]interface{}) []runtime.Object {
+	return []runtime.Object{
+		newOpenShiftConfig(helpers.ClusterVersionGVK, "version", map[string]interface{}{
+			"spec": map[string]interface{}{"clusterID": "6a2e3b5c-0d4f-4a8e-9c1b-3f5e7d9a1b2c"},
+		}),
+		newOpenShiftConfig(helpers.InfrastructureGVK, "cluster", map[string]interface{}{
+			"status": map[string]interface{}{"infrastructureName": "mycluster-x7k2p", "platformStatus": platformStatus},
+		}),
+		newOpenShiftConfig(helpers.DNSGVK, "cluster", map[string]interface{}{
+			"spec": map[string]interface{}{"baseDomain": "mycluster.example.com"},
+		}),
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{regionLabel: "westeurope"}}},
+	}
+}
+
+func Test_discoverHiveValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		objs    []runtime.Object
+		hive    map[string]interface{}
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "AWS",
+			objs: newOpenShiftCluster(map[string]interface{}{"type": "AWS", "aws": map[string]interface{}{"region": "us-east-1"}}),
+			hive: map[string]interface{}{"clusterID": "", "infraID": "", "platform": "", "region": "", "clusterName": "", "baseDomain": ""},
+			want: map[string]interface{}{
+				"clusterID":   "6a2e3b5c-0d4f-4a8e-9c1b-3f5e7d9a1b2c",
+				"infraID":     "mycluster-x7k2p",
+				"platform":    "aws",
+				"region":      "us-east-1",
+				"clusterName": "mycluster",
+				"baseDomain":  "example.com",
+			},
+		},
+		{
+			name: "Azure, region of the nodes and values kept",
+			objs: newOpenShiftCluster(map[string]interface{}{"type": "Azure"}),
+			hive: map[string]interface{}{"clusterID": "", "infraID": "", "platform": "", "region": "", "clusterName": "", "baseDomain": "other.com"},
+			want: map[string]interface{}{
+				"clusterID":   "6a2e3b5c-0d4f-4a8e-9c1b-3f5e7d9a1b2c",
+				"infraID":     "mycluster-x7k2p",
+				"platform":    "azure",
+				"region":      "westeurope",
+				"clusterName": "mycluster",
+				"baseDomain":  "other.com",
+			},
+		},
+		{
+			name: "Bare metal adopted with the none platform",
+			objs: newOpenShiftCluster(map[string]interface{}{"type": "BareMetal"}),
+			hive: map[string]interface{}{"clusterID": "", "infraID": "", "platform": "", "region": "", "clusterName": "", "baseDomain": ""},
+			want: map[string]interface{}{
+				"clusterID":   "6a2e3b5c-0d4f-4a8e-9c1b-3f5e7d9a1b2c",
+				"infraID":     "mycluster-x7k2p",
+				"platform":    "none",
+				"region":      "",
+				"clusterName": "mycluster",
+				"baseDomain":  "example.com",
+			},
+		},
+		{
+			name:    "Failed, not an OpenShift cluster",
+			hive:    map[string]interface{}{"clusterID": ""},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := discoverHiveValues(context.TODO(), fake.NewClient(tt.objs...), tt.hive)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("discoverHiveValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(tt.hive, tt.want) {
+				t.Errorf("discoverHiveValues() = %v, want %v", tt.hive, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateHiveValues(t *testing.T) {
+	hive := func(platform string, extra ...string) map[string]interface{} {
+		values := map[string]interface{}{
+			"platform": platform, "clusterName": "mycluster", "baseDomain": "example.com", "clusterID": "id", "infraID": "infra",
+			"region": "", "credentialsSecretName": "", "baseDomainResourceGroupName": "",
+		}
+		for i := 0; i < len(extra); i += 2 {
+			values[extra[i]] = extra[i+1]
+		}
+		return values
+	}
+	tests := []struct {
+		name    string
+		hive    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "Success, none",
+			hive: hive("none"),
+		},
+		{
+			name: "Success, aws",
+			hive: hive("aws", "region", "us-east-1", "credentialsSecretName", "aws-creds"),
+		},
+		{
+			name:    "Failed, aws without credentials",
+			hive:    hive("aws", "region", "us-east-1"),
+			wantErr: true,
+		},
+		{
+			name:    "Failed, azure without base domain resource group",
+			hive:    hive("azure", "region", "westeurope", "credentialsSecretName", "azure-creds"),
+			wantErr: true,
+		},
+		{
+			name:    "Failed, unsupported platform",
+			hive:    hive("vsphere"),
+			wantErr: true,
+		},
+		{
+			name:    "Failed, cluster ID missing",
+			hive:    hive("none", "clusterID", ""),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateHiveValues(tt.hive); (err != nil) != tt.wantErr {
+				t.Errorf("validateHiveValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient_hiveAdopt(t *testing.T) {
+	client := fake.NewClient()
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			ValuesPaths: []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")},
+			Timeout:     time.Second,
+			Silent:      true,
+		},
+		async: true,
+		ctx:   context.Background(),
+	}
+	args := []string{"--hive-adopt", "--hive-platform", "aws", "--hive-region", "us-east-1", "--hive-credentials-secret", "aws-creds", "--hive-base-domain", "example.com"}
+	if err := o.complete(newValuesCmd(t, args...), nil); err != nil {
+		t.Fatal(err)
+	}
+	if !o.hiveAdopt {
+		t.Fatal("--hive-adopt must be set")
+	}
+	hive := o.values["hive"].(map[string]interface{})
+	hive["clusterName"] = "mycluster"
+	hive["clusterID"] = "id"
+	hive["infraID"] = "infra"
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+
+	cd := &unstructured.Unstructured{}
+	cd.SetGroupVersionKind(helpers.ClusterDeploymentGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "test", Namespace: "test"}, cd); err != nil {
+		t.Fatal(err)
+	}
+	if preserve, _, _ := unstructured.NestedBool(cd.Object, "spec", "preserveOnDelete"); !preserve {
+		t.Error("the adopted ClusterDeployment must preserve the cluster on delete")
+	}
+	if installed, _, _ := unstructured.NestedBool(cd.Object, "spec", "installed"); !installed {
+		t.Error("the adopted ClusterDeployment must be installed")
+	}
+	if region, _, _ := unstructured.NestedString(cd.Object, "spec", "platform", "aws", "region"); region != "us-east-1" {
+		t.Errorf("the region must be us-east-1, got %s", region)
+	}
+	secretName, _, _ := unstructured.NestedString(cd.Object, "spec", "clusterMetadata", "adminKubeconfigSecretRef", "name")
+	secret := &corev1.Secret{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: "test"}, secret); err != nil {
+		t.Fatalf("the admin kubeconfig secret %s must be created, got %v", secretName, err)
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/progress"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// inventoryColumns are the columns of a csv inventory, named by its header row
+var inventoryColumns = []string{"name", "server", "token", "kubeconfig", "labels", "hub"}
+
+// inventoryRow is a cluster to attach listed in an inventory, kubeconfig is the path of its kubeconfig
+// and hub the kubeconfig context of the hub it is attached to, the hub of the command if not set
+type inventoryRow struct {
+	Name       string            `json:"name"`
+	Server     string            `json:"server,omitempty"`
+	Token      string            `json:"token,omitempty"`
+	KubeConfig string            `json:"kubeconfig,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Hub        string            `json:"hub,omitempty"`
+	//kubeConfigData is the content of the kubeconfig of the clusters which are not read from an inventory file
+	kubeConfigData []byte
+}
+
+// readInventory reads the clusters of a csv or json inventory, the format is given by the extension
+func readInventory(path string) ([]inventoryRow, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var rows []inventoryRow
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		rows, err = readCSVInventory(f)
+	case ".json":
+		err = json.NewDecoder(f).Decode(&rows)
+	default:
+		return nil, fmt.Errorf("unsupported inventory %s, expected a .csv or .json file", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid inventory %s: %s", path, err.Error())
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("the inventory %s has no cluster", path)
+	}
+	return rows, nil
+}
+
+// readCSVInventory reads a csv inventory, the first row names the columns and the labels
+// are KEY=VALUE pairs separated by semicolons or commas
+func readCSVInventory(r io.Reader) ([]inventoryRow, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	columns := make(map[string]int)
+	for i, h := range records[0] {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if !contains(inventoryColumns, h) {
+			return nil, fmt.Errorf("unknown column %s, the columns are %s", h, strings.Join(inventoryColumns, ", "))
+		}
+		columns[h] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("the name column is missing")
+	}
+	get := func(record []string, column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	rows := make([]inventoryRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		row := inventoryRow{
+			Name:       get(record, "name"),
+			Server:     get(record, "server"),
+			Token:      get(record, "token"),
+			KubeConfig: get(record, "kubeconfig"),
+			Hub:        get(record, "hub"),
+		}
+		if labels := get(record, "labels"); labels != "" {
+			row.Labels = make(map[string]string)
+			for _, l := range strings.FieldsFunc(labels, func(r rune) bool { return r == ';' || r == ',' }) {
+				kv := strings.SplitN(strings.TrimSpace(l), "=", 2)
+				if len(kv) != 2 {
+					return nil, fmt.Errorf("row %d: invalid label %s, expected KEY=VALUE", i+1, l)
+				}
+				row.Labels[kv[0]] = kv[1]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// completeInventory prepares the attach of each cluster of the inventory, the values files,
+// --set and the flags of the values apply to all clusters
+func (o *Options) completeInventory(cmd *cobra.Command, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("the cluster name can not be given with --inventory, the clusters are the rows of the inventory")
+	}
+	if cmd != nil {
+		for _, f := range []string{"name", "cluster-server", "cluster-token", "cluster-kubeconfig", "cluster-kubeconfigr"} {
+			if cmd.Flags().Changed(f) {
+				return fmt.Errorf("--%s can not be used with --inventory, it is a column of the inventory", f)
+			}
+		}
+	}
+	rows, err := readInventory(o.inventoryFile)
+	if err != nil {
+		return err
+	}
+	values, err := o.inventoryValues(cmd, valuesSchema)
+	if err != nil {
+		return err
+	}
+	return o.setInventory(rows, values)
+}
+
+// inventoryValues returns the values common to all the clusters of an inventory
+func (o *Options) inventoryValues(cmd *cobra.Command, schema applierscenarios.ValuesSchema) (map[string]interface{}, error) {
+	values, err := o.applierScenariosOptions.ReadValuesWithDefaults(valuesTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyProfile(values, o.profile); err != nil {
+		return nil, err
+	}
+	if cmd != nil {
+		if err := schema.MergeFlags(cmd.Flags(), values); err != nil {
+			return nil, err
+		}
+	}
+	if o.curatorFile != "" {
+		values["curator"], err = helpers.ReadCuratorFile(o.curatorFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// setInventory sets the options attaching each cluster of the rows with the common values,
+// the clusters of a same hub share its config flags and so its clients
+func (o *Options) setInventory(rows []inventoryRow, values map[string]interface{}) error {
+	o.inventory = make([]*Options, 0, len(rows))
+	hubFlags := make(map[string]*genericclioptions.ConfigFlags)
+	for i, row := range rows {
+		if _, ok := hubFlags[row.Hub]; !ok {
+			hubFlags[row.Hub] = o.hubConfigFlags(row.Hub)
+		}
+		c, err := o.newInventoryOptions(row, values, hubFlags[row.Hub])
+		if err != nil {
+			return fmt.Errorf("row %d (%s): %s", i+1, row.Name, err.Error())
+		}
+		o.inventory = append(o.inventory, c)
+	}
+	return nil
+}
+
+// newInventoryOptions returns the options attaching the cluster of the row with the common values to the hub
+// of the config flags, the attaches run concurrently so their applier output is silenced
+func (o *Options) newInventoryOptions(row inventoryRow, common map[string]interface{}, hubFlags *genericclioptions.ConfigFlags) (*Options, error) {
+	values, err := copyValues(common)
+	if err != nil {
+		return nil, err
+	}
+	values["managedClusterName"] = row.Name
+	values["server"] = row.Server
+	values["token"] = row.Token
+	values["kubeConfig"] = string(row.kubeConfigData)
+	if row.KubeConfig != "" {
+		b, err := ioutil.ReadFile(filepath.Clean(row.KubeConfig))
+		if err != nil {
+			return nil, err
+		}
+		values["kubeConfig"] = string(b)
+	}
+	//The labels of the row are added to the common labels
+	if len(row.Labels) != 0 {
+		labels, ok := values["labels"].(map[string]interface{})
+		if !ok {
+			labels = make(map[string]interface{}, len(row.Labels))
+		}
+		for k, v := range row.Labels {
+			labels[k] = v
+		}
+		values["labels"] = labels
+	}
+
+	applierScenariosOptions := *o.applierScenariosOptions
+	applierScenariosOptions.Silent = true
+	applierScenariosOptions.ConfigFlags = hubFlags
+	c := *o
+	c.applierScenariosOptions = &applierScenariosOptions
+	c.values = values
+	c.clusterName = ""
+	c.hub = row.Hub
+	c.inventoryFile = ""
+	c.inventory = nil
+	c.progress = progress.NewReporter(progress.FormatText, nil)
+	if err := c.completeValues(nil, valuesSchema); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// validateInventory validates all clusters of the inventory before attaching any of them
+func (o *Options) validateInventory() error {
+	if o.manualImport() || o.export != "" || o.saveSpecPath != "" || o.wait || o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("--inventory can not be used with import-file, import-output-dir, bundle, export, save-spec, wait or outFile")
+	}
+	if err := helpers.ValidateMaxConcurrency(o.maxConcurrency); err != nil {
+		return err
+	}
+	contexts, err := o.kubeconfigContexts(o.inventory)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]int)
+	problems := make([]string, 0)
+	for i, c := range o.inventory {
+		row := i + 1
+		if c.hub != "" && !contexts[c.hub] {
+			problems = append(problems, fmt.Sprintf(" - row %d (%s): the hub %s is not a context of the kubeconfig", row, c.clusterName, c.hub))
+			continue
+		}
+		if c.clusterName == "" {
+			problems = append(problems, fmt.Sprintf(" - row %d: the name of the cluster is missing", row))
+			continue
+		}
+		if first, ok := names[c.clusterName]; ok {
+			problems = append(problems, fmt.Sprintf(" - row %d: cluster %s is already listed at row %d", row, c.clusterName, first))
+			continue
+		}
+		names[c.clusterName] = row
+		if err := c.validate(); err != nil {
+			problems = append(problems, fmt.Sprintf(" - row %d (%s): %s", row, c.clusterName, err.Error()))
+			continue
+		}
+		if err := validateLabels(c.values); err != nil {
+			problems = append(problems, fmt.Sprintf(" - row %d (%s): %s", row, c.clusterName, err.Error()))
+		}
+	}
+	if len(problems) != 0 {
+		return fmt.Errorf("%d invalid row(s) in the inventory %s, no cluster was attached:\n%s",
+			len(problems), o.inventoryFile, strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// validateLabels checks the labels of the ManagedCluster
+func validateLabels(values map[string]interface{}) error {
+	labels, _ := values["labels"].(map[string]interface{})
+	for k, v := range labels {
+		if errs := validation.IsQualifiedName(k); len(errs) != 0 {
+			return fmt.Errorf("invalid label key %s: %s", k, strings.Join(errs, ", "))
+		}
+		value := fmt.Sprintf("%v", v)
+		if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+			return fmt.Errorf("invalid label value %s: %s", value, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// runInventory attaches the clusters of the inventory concurrently and reports the status of each of them,
+// a failed attach does not stop the others. The clusters of several hubs are spread fairly across the hubs.
+func (o *Options) runInventory(attach func(c *Options) error) error {
+	reporter := o.progressReporter()
+	results := make([]attachResult, len(o.inventory))
+	var lock sync.Mutex
+	hubs := make([]string, len(o.inventory))
+	multiHub := false
+	for i, c := range o.inventory {
+		hubs[i] = c.hub
+		multiHub = multiHub || c.hub != ""
+	}
+	errs := helpers.RunBatch(o.ctx, hubs, o.maxConcurrency, func(i int) error {
+		c := o.inventory[i]
+		resource := "ManagedCluster/" + c.clusterName
+		lock.Lock()
+		reporter.Report("attach", resource, progress.StatusStarted, "")
+		lock.Unlock()
+
+		result := attachResult{Name: c.clusterName, Hub: c.hub, Status: attachStatusAttached}
+		if err := attach(c); err != nil {
+			result.Status = attachStatusFailed
+			result.Message = err.Error()
+		}
+		results[i] = result
+
+		lock.Lock()
+		defer lock.Unlock()
+		if result.Status == attachStatusFailed {
+			reporter.Report("attach", resource, progress.StatusFailed, result.Message)
+			fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "Cluster %s failed to attach: %s\n", result.Name, result.Message)
+			return nil
+		}
+		reporter.Report("attach", resource, progress.StatusSucceeded, "")
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "Cluster %s attached\n", result.Name)
+		return nil
+	})
+	//The clusters not started on Ctrl+C are reported as failed
+	for i, err := range errs {
+		if err != nil {
+			results[i] = attachResult{Name: o.inventory[i].clusterName, Hub: o.inventory[i].hub, Status: attachStatusFailed, Message: err.Error()}
+		}
+	}
+
+	failed := 0
+	table := &printers.Table{
+		Headers: []string{"NAME", "STATUS", "MESSAGE"},
+	}
+	if multiHub {
+		table.Headers = []string{"HUB", "NAME", "STATUS", "MESSAGE"}
+	}
+	for _, r := range results {
+		if r.Status == attachStatusFailed {
+			failed++
+		}
+		if multiHub {
+			table.AddRow(hubName(r.Hub), r.Name, r.Status, r.Message)
+			continue
+		}
+		table.AddRow(r.Name, r.Status, r.Message)
+	}
+	//The standard output only contains the events with --progress-format json and the names in quiet mode
+	if !reporter.Enabled() && !printers.Quiet {
+		if err := printers.PrintTable(o.applierScenariosOptions.Out, table); err != nil {
+			return err
+		}
+	}
+	if failed != 0 {
+		if multiHub {
+			return fmt.Errorf("%d of %d clusters failed to attach: %s", failed, len(results), hubSummary(results))
+		}
+		return fmt.Errorf("%d of %d clusters failed to attach", failed, len(results))
+	}
+	if multiHub {
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "%d clusters attached: %s\n", len(results), hubSummary(results))
+	}
+	return nil
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
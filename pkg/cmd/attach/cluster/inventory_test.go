@@ -0,0 +1,308 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func writeInventory(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func Test_readInventory(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		wantRows []inventoryRow
+		wantErr  string
+	}{
+		{
+			name:    "Success, csv",
+			file:    "clusters.csv",
+			content: "Name,Server,Token,Labels\ncluster1,https://cluster1:6443,token1,\"env=prod,region=eu\"\ncluster2,https://cluster2:6443,token2,env=dev;region=us\n",
+			wantRows: []inventoryRow{
+				{Name: "cluster1", Server: "https://cluster1:6443", Token: "token1", Labels: map[string]string{"env": "prod", "region": "eu"}},
+				{Name: "cluster2", Server: "https://cluster2:6443", Token: "token2", Labels: map[string]string{"env": "dev", "region": "us"}},
+			},
+		},
+		{
+			name:    "Success, json",
+			file:    "clusters.json",
+			content: `[{"name": "cluster1", "kubeconfig": "cluster1.kubeconfig", "labels": {"env": "prod"}}]`,
+			wantRows: []inventoryRow{
+				{Name: "cluster1", KubeConfig: "cluster1.kubeconfig", Labels: map[string]string{"env": "prod"}},
+			},
+		},
+		{
+			name:    "Success, hubs",
+			file:    "clusters.csv",
+			content: "name,server,token,hub\ncluster1,https://cluster1:6443,token1,hub-eu\ncluster2,https://cluster2:6443,token2,\n",
+			wantRows: []inventoryRow{
+				{Name: "cluster1", Server: "https://cluster1:6443", Token: "token1", Hub: "hub-eu"},
+				{Name: "cluster2", Server: "https://cluster2:6443", Token: "token2"},
+			},
+		},
+		{
+			name:    "Failed, unknown column",
+			file:    "clusters.csv",
+			content: "name,owner\ncluster1,me\n",
+			wantErr: "unknown column owner",
+		},
+		{
+			name:    "Failed, missing name column",
+			file:    "clusters.csv",
+			content: "server,token\nhttps://cluster1:6443,token1\n",
+			wantErr: "the name column is missing",
+		},
+		{
+			name:    "Failed, invalid label",
+			file:    "clusters.csv",
+			content: "name,labels\ncluster1,prod\n",
+			wantErr: "row 1: invalid label prod",
+		},
+		{
+			name:    "Failed, no cluster",
+			file:    "clusters.csv",
+			content: "name,server,token\n",
+			wantErr: "has no cluster",
+		},
+		{
+			name:    "Failed, unsupported format",
+			file:    "clusters.yaml",
+			content: "- name: cluster1\n",
+			wantErr: "expected a .csv or .json file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := readInventory(writeInventory(t, tt.file, tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readInventory() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprintf("%v", rows) != fmt.Sprintf("%v", tt.wantRows) {
+				t.Errorf("readInventory() = %v, want %v", rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func newInventoryTestOptions(t *testing.T, content string) *Options {
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			Timeout:   time.Second,
+			IOStreams: streams,
+		},
+		inventoryFile:  writeInventory(t, "clusters.csv", content),
+		maxConcurrency: 2,
+		ctx:            context.Background(),
+	}
+	if err := o.complete(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func TestOptions_validate_inventory(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		contains []string
+	}{
+		{
+			name:    "Success",
+			content: "name,server,token\ncluster1,https://cluster1:6443,token1\ncluster2,https://cluster2:6443,token2\n",
+		},
+		{
+			name:    "Failed, all invalid rows are reported",
+			content: "name,server,token,labels\ncluster1,https://cluster1:6443,,\ncluster2,https://cluster2:6443,token2,env=prod!\ncluster2,https://cluster2:6443,token2,\n,https://cluster3:6443,token3,\n",
+			contains: []string{
+				"4 invalid row(s)",
+				"row 1 (cluster1): server or token is missing",
+				"row 2 (cluster2): invalid label value prod!",
+				"row 3: cluster cluster2 is already listed at row 2",
+				"row 4: the name of the cluster is missing",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newInventoryTestOptions(t, tt.content).validate()
+			if len(tt.contains) == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("validate() expected an error")
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(err.Error(), c) {
+					t.Errorf("validate() = %s, must contain %s", err.Error(), c)
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_runInventory(t *testing.T) {
+	o := newInventoryTestOptions(t, "name,server,token,labels\ncluster1,https://cluster1:6443,token1,env=prod\ncluster2,https://cluster2:6443,token2,\ncluster3,https://cluster3:6443,token3,\n")
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	client := crclientfake.NewFakeClient()
+	err := o.runInventory(func(c *Options) error {
+		if c.clusterName == "cluster3" {
+			return fmt.Errorf("unreachable")
+		}
+		return c.runWithClient(client)
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 clusters failed to attach") {
+		t.Fatalf("runInventory() error = %v", err)
+	}
+	for _, name := range []string{"cluster1", "cluster2"} {
+		mc := &unstructured.Unstructured{}
+		mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+		if err := client.Get(context.TODO(), crclient.ObjectKey{Name: name}, mc); err != nil {
+			t.Fatalf("the ManagedCluster %s must be created: %v", name, err)
+		}
+		if name == "cluster1" && mc.GetLabels()["env"] != "prod" {
+			t.Errorf("the ManagedCluster %s must have the labels of the inventory, got %v", name, mc.GetLabels())
+		}
+	}
+	out := o.applierScenariosOptions.Out.(interface{ String() string }).String()
+	for _, c := range []string{"cluster1   attached", "cluster3   failed     unreachable"} {
+		if !strings.Contains(out, c) {
+			t.Errorf("the status of each cluster must be reported, got:\n%s", out)
+		}
+	}
+}
+
+const testHubsKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: eu
+  cluster:
+    server: https://hub-eu:6443
+- name: us
+  cluster:
+    server: https://hub-us:6443
+contexts:
+- name: hub-eu
+  context:
+    cluster: eu
+    user: admin
+- name: hub-us
+  context:
+    cluster: us
+    user: admin
+current-context: hub-eu
+users:
+- name: admin
+  user:
+    token: admin
+`
+
+func newHubsInventoryTestOptions(t *testing.T, content string) *Options {
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	configFlags := genericclioptions.NewConfigFlags(true)
+	kubeConfig := writeInventory(t, "kubeconfig", testHubsKubeConfig)
+	configFlags.KubeConfig = &kubeConfig
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			ConfigFlags: configFlags,
+			Timeout:     time.Second,
+			IOStreams:   streams,
+		},
+		inventoryFile:  writeInventory(t, "clusters.csv", content),
+		maxConcurrency: 2,
+		ctx:            context.Background(),
+	}
+	if err := o.complete(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func TestOptions_validate_inventoryHubs(t *testing.T) {
+	o := newHubsInventoryTestOptions(t, "name,server,token,hub\ncluster1,https://cluster1:6443,token1,hub-us\ncluster2,https://cluster2:6443,token2,hub-ap\n")
+	err := o.validate()
+	if err == nil || !strings.Contains(err.Error(), "row 2 (cluster2): the hub hub-ap is not a context of the kubeconfig") {
+		t.Fatalf("validate() error = %v", err)
+	}
+}
+
+func TestOptions_runInventoryHubs(t *testing.T) {
+	o := newHubsInventoryTestOptions(t, "name,server,token,hub\n"+
+		"cluster1,https://cluster1:6443,token1,hub-us\n"+
+		"cluster2,https://cluster2:6443,token2,\n"+
+		"cluster3,https://cluster3:6443,token3,hub-us\n")
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if o.inventory[0].applierScenariosOptions.ConfigFlags != o.inventory[2].applierScenariosOptions.ConfigFlags {
+		t.Error("the clusters of a same hub must share its config flags")
+	}
+	if o.inventory[1].applierScenariosOptions.ConfigFlags != o.applierScenariosOptions.ConfigFlags {
+		t.Error("the clusters without hub must use the config flags of the command")
+	}
+	clients := map[*genericclioptions.ConfigFlags]crclient.Client{}
+	var lock sync.Mutex
+	err := o.runInventory(func(c *Options) error {
+		if c.clusterName == "cluster3" {
+			return fmt.Errorf("unreachable")
+		}
+		lock.Lock()
+		client, ok := clients[c.applierScenariosOptions.ConfigFlags]
+		if !ok {
+			client = crclientfake.NewFakeClient()
+			clients[c.applierScenariosOptions.ConfigFlags] = client
+		}
+		lock.Unlock()
+		return c.runWithClient(client)
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 clusters failed to attach: hub-us 1 attached, 1 failed; <current> 1 attached, 0 failed") {
+		t.Fatalf("runInventory() error = %v", err)
+	}
+	hubUS := clients[o.inventory[0].applierScenariosOptions.ConfigFlags]
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := hubUS.Get(context.TODO(), crclient.ObjectKey{Name: "cluster1"}, mc); err != nil {
+		t.Fatalf("cluster1 must be attached to hub-us: %v", err)
+	}
+	if err := hubUS.Get(context.TODO(), crclient.ObjectKey{Name: "cluster2"}, mc); err == nil {
+		t.Error("cluster2 must not be attached to hub-us")
+	}
+	out := o.applierScenariosOptions.Out.(interface{ String() string }).String()
+	for _, c := range []string{"HUB", "hub-us      cluster1   attached", "<current>   cluster2   attached"} {
+		if !strings.Contains(out, c) {
+			t.Errorf("the hub of each cluster must be reported, got:\n%s", out)
+		}
+	}
+}
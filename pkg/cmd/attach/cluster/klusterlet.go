@@ -0,0 +1,239 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// klusterletModeDefault runs the klusterlet agents on the cluster
+	klusterletModeDefault = "Default"
+	// klusterletModeHosted runs the klusterlet agents on the hosting cluster, they reach the cluster with its kubeConfig
+	klusterletModeHosted = "Hosted"
+	// klusterletModeDetached is the former name of the Hosted mode
+	klusterletModeDetached = "Detached"
+)
+
+// klusterletModes are the deploy modes of the klusterlet
+var klusterletModes = []string{klusterletModeDefault, klusterletModeHosted}
+
+// tolerationEffects are the effects of the tolerations of the pods
+var tolerationEffects = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+
+// klusterletResources are the resources of the requests and limits of the klusterlet agents
+var klusterletResources = []string{"cpu", "memory"}
+
+// parseToleration parses a KEY[=VALUE][:EFFECT] toleration, a toleration without value
+// tolerates all the values of the key and a toleration without effect tolerates all effects
+func parseToleration(s string) (map[string]interface{}, error) {
+	key, effect := s, ""
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		key, effect = s[:i], s[i+1:]
+	}
+	t := map[string]interface{}{
+		"key":      key,
+		"operator": "Exists",
+	}
+	if kv := strings.SplitN(key, "=", 2); len(kv) == 2 {
+		t["key"], t["operator"], t["value"] = kv[0], "Equal", kv[1]
+	}
+	if effect != "" {
+		t["effect"] = effect
+	}
+	if err := validateToleration(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// completeKlusterletValues normalizes the klusterlet values so the templates only see the values which are set,
+// the node selector and the tolerations of the flags overwrite the ones of the values file
+func completeKlusterletValues(values map[string]interface{}, nodeSelector map[string]string, tolerations []string) error {
+	resources := make(map[string]interface{})
+	for _, kind := range []string{"requests", "limits"} {
+		quantities := make(map[string]interface{})
+		for _, r := range klusterletResources {
+			if q := applierscenarios.GetString(values, fmt.Sprintf("klusterlet.resources.%s.%s", kind, r)); q != "" {
+				quantities[r] = q
+			}
+		}
+		if len(quantities) != 0 {
+			resources[kind] = quantities
+		}
+	}
+
+	selector, _, err := unstructured.NestedMap(values, "klusterlet", "nodeSelector")
+	if err != nil {
+		return fmt.Errorf("invalid klusterlet.nodeSelector: %s", err.Error())
+	}
+	if selector == nil {
+		selector = make(map[string]interface{})
+	}
+	for k, v := range nodeSelector {
+		selector[k] = v
+	}
+
+	tolerationValues, _, err := unstructured.NestedSlice(values, "klusterlet", "tolerations")
+	if err != nil {
+		return fmt.Errorf("invalid klusterlet.tolerations: %s", err.Error())
+	}
+	if len(tolerations) != 0 {
+		tolerationValues = make([]interface{}, 0, len(tolerations))
+		for _, s := range tolerations {
+			t, err := parseToleration(s)
+			if err != nil {
+				return err
+			}
+			tolerationValues = append(tolerationValues, t)
+		}
+	}
+	if tolerationValues == nil {
+		tolerationValues = make([]interface{}, 0)
+	}
+
+	klusterlet := map[string]interface{}{
+		"resources":    resources,
+		"nodeSelector": selector,
+		"tolerations":  tolerationValues,
+	}
+	mode := applierscenarios.GetString(values, "klusterlet.mode")
+	if mode == klusterletModeDetached {
+		mode = klusterletModeHosted
+	}
+	for k, v := range map[string]string{
+		"namespace":          applierscenarios.GetString(values, "klusterlet.namespace"),
+		"mode":               mode,
+		"hostingClusterName": applierscenarios.GetString(values, "klusterlet.hostingClusterName"),
+	} {
+		if v != "" {
+			klusterlet[k] = v
+		}
+	}
+	values["klusterlet"] = klusterlet
+	return nil
+}
+
+// hostingCluster returns the cluster running the klusterlet agents in the Hosted mode, empty in the Default mode
+func hostingCluster(values map[string]interface{}) string {
+	if applierscenarios.GetString(values, "klusterlet.mode") != klusterletModeHosted {
+		return ""
+	}
+	return applierscenarios.GetString(values, "klusterlet.hostingClusterName")
+}
+
+// validateKlusterlet checks the quantities of the resources, a limit can not be lower than its request,
+// the node selector, the tolerations, the namespace and the deploy mode
+func validateKlusterlet(values map[string]interface{}) error {
+	if err := validateKlusterletMode(values); err != nil {
+		return err
+	}
+	for _, r := range klusterletResources {
+		quantities := make(map[string]resource.Quantity)
+		for _, kind := range []string{"requests", "limits"} {
+			path := fmt.Sprintf("klusterlet.resources.%s.%s", kind, r)
+			s := applierscenarios.GetString(values, path)
+			if s == "" {
+				continue
+			}
+			q, err := resource.ParseQuantity(s)
+			if err != nil {
+				return fmt.Errorf("invalid %s %s: %s", path, s, err.Error())
+			}
+			quantities[kind] = q
+		}
+		request, hasRequest := quantities["requests"]
+		limit, hasLimit := quantities["limits"]
+		if hasRequest && hasLimit && limit.Cmp(request) < 0 {
+			return fmt.Errorf("the %s limit %s of the klusterlet is lower than its request %s", r, limit.String(), request.String())
+		}
+	}
+
+	selector, _, _ := unstructured.NestedMap(values, "klusterlet", "nodeSelector")
+	for k, v := range selector {
+		if errs := validation.IsQualifiedName(k); len(errs) != 0 {
+			return fmt.Errorf("invalid node selector key %s: %s", k, strings.Join(errs, ", "))
+		}
+		value := fmt.Sprintf("%v", v)
+		if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+			return fmt.Errorf("invalid node selector value %s: %s", value, strings.Join(errs, ", "))
+		}
+	}
+
+	tolerations, _, _ := unstructured.NestedSlice(values, "klusterlet", "tolerations")
+	for _, t := range tolerations {
+		m, ok := t.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid toleration %v, expected a map with key, operator, value and effect", t)
+		}
+		if err := validateToleration(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateKlusterletMode checks the namespace of the agents and the hosting cluster of the Hosted mode
+func validateKlusterletMode(values map[string]interface{}) error {
+	if namespace := applierscenarios.GetString(values, "klusterlet.namespace"); namespace != "" {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) != 0 {
+			return fmt.Errorf("invalid klusterlet namespace %s: %s", namespace, strings.Join(errs, ", "))
+		}
+	}
+	mode := applierscenarios.GetString(values, "klusterlet.mode")
+	hosting := applierscenarios.GetString(values, "klusterlet.hostingClusterName")
+	switch mode {
+	case "", klusterletModeDefault:
+		if hosting != "" {
+			return fmt.Errorf("the hosting cluster %s requires the %s klusterlet mode", hosting, klusterletModeHosted)
+		}
+	case klusterletModeHosted, klusterletModeDetached:
+		if hosting == "" {
+			return fmt.Errorf("the %s klusterlet mode requires the cluster running the agents, set it with --hosting-cluster", klusterletModeHosted)
+		}
+		if hosting == applierscenarios.GetString(values, "managedClusterName") {
+			return fmt.Errorf("the cluster %s can not host its own klusterlet, use the %s mode", hosting, klusterletModeDefault)
+		}
+	default:
+		return fmt.Errorf("invalid klusterlet mode %s, supported modes are %s", mode, strings.Join(klusterletModes, ", "))
+	}
+	return nil
+}
+
+// validateToleration checks the key, the operator and the effect of a toleration
+func validateToleration(t map[string]interface{}) error {
+	key := applierscenarios.GetString(t, "key")
+	operator := applierscenarios.GetString(t, "operator")
+	value := applierscenarios.GetString(t, "value")
+	effect := applierscenarios.GetString(t, "effect")
+	if key != "" {
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return fmt.Errorf("invalid toleration key %s: %s", key, strings.Join(errs, ", "))
+		}
+	}
+	switch operator {
+	case "", "Equal":
+		if key == "" {
+			return fmt.Errorf("the toleration of value %s has no key, only the Exists operator tolerates all keys", value)
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+			return fmt.Errorf("invalid toleration value %s: %s", value, strings.Join(errs, ", "))
+		}
+	case "Exists":
+		if value != "" {
+			return fmt.Errorf("the toleration %s has the value %s, the Exists operator tolerates all values", key, value)
+		}
+	default:
+		return fmt.Errorf("invalid toleration operator %s, supported operators are Equal, Exists", operator)
+	}
+	if effect != "" && !contains(tolerationEffects, effect) {
+		return fmt.Errorf("invalid toleration effect %s, supported effects are %s", effect, strings.Join(tolerationEffects, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_parseToleration(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "Key and effect",
+			s:    "edge:NoSchedule",
+			want: map[string]interface{}{"key": "edge", "operator": "Exists", "effect": "NoSchedule"},
+		},
+		{
+			name: "Key, value and effect",
+			s:    "node-role.kubernetes.io/edge=true:NoExecute",
+			want: map[string]interface{}{"key": "node-role.kubernetes.io/edge", "operator": "Equal", "value": "true", "effect": "NoExecute"},
+		},
+		{
+			name: "Key only",
+			s:    "edge",
+			want: map[string]interface{}{"key": "edge", "operator": "Exists"},
+		},
+		{
+			name:    "Failed, invalid effect",
+			s:       "edge:NoSelect",
+			wantErr: true,
+		},
+		{
+			name:    "Failed, invalid key",
+			s:       "-edge:NoSchedule",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseToleration(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseToleration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseToleration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_completeKlusterletValues(t *testing.T) {
+	values := map[string]interface{}{
+		"klusterlet": map[string]interface{}{
+			"resources": map[string]interface{}{
+				"requests": map[string]interface{}{"cpu": "10m", "memory": nil},
+				"limits":   map[string]interface{}{"cpu": nil, "memory": nil},
+			},
+			"nodeSelector": map[string]interface{}{"zone": "a"},
+			"tolerations": []interface{}{
+				map[string]interface{}{"key": "foo", "operator": "Exists"},
+			},
+		},
+	}
+	if err := completeKlusterletValues(values, map[string]string{"edge": "true"}, []string{"edge:NoSchedule"}); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{"cpu": "10m"},
+		},
+		"nodeSelector": map[string]interface{}{"zone": "a", "edge": "true"},
+		"tolerations": []interface{}{
+			map[string]interface{}{"key": "edge", "operator": "Exists", "effect": "NoSchedule"},
+		},
+	}
+	if !reflect.DeepEqual(values["klusterlet"], want) {
+		t.Errorf("klusterlet = %v, want %v", values["klusterlet"], want)
+	}
+
+	//The values files written before the klusterlet values render no KlusterletConfig
+	values = map[string]interface{}{}
+	if err := completeKlusterletValues(values, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateKlusterlet(values); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_validateKlusterlet(t *testing.T) {
+	tests := []struct {
+		name       string
+		klusterlet map[string]interface{}
+		wantErr    bool
+	}{
+		{
+			name: "Success",
+			klusterlet: map[string]interface{}{
+				"resources": map[string]interface{}{
+					"requests": map[string]interface{}{"cpu": "10m", "memory": "32Mi"},
+					"limits":   map[string]interface{}{"cpu": "100m", "memory": "128Mi"},
+				},
+				"nodeSelector": map[string]interface{}{"node-role.kubernetes.io/edge": ""},
+				"tolerations": []interface{}{
+					map[string]interface{}{"key": "edge", "operator": "Equal", "value": "true", "effect": "NoSchedule"},
+					map[string]interface{}{"operator": "Exists"},
+				},
+			},
+		},
+		{
+			name: "Failed, invalid quantity",
+			klusterlet: map[string]interface{}{
+				"resources": map[string]interface{}{"requests": map[string]interface{}{"memory": "32MB"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed, limit lower than the request",
+			klusterlet: map[string]interface{}{
+				"resources": map[string]interface{}{
+					"requests": map[string]interface{}{"memory": "256Mi"},
+					"limits":   map[string]interface{}{"memory": "128Mi"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "Failed, invalid node selector",
+			klusterlet: map[string]interface{}{"nodeSelector": map[string]interface{}{"edge": "not a label value"}},
+			wantErr:    true,
+		},
+		{
+			name: "Failed, value with the Exists operator",
+			klusterlet: map[string]interface{}{
+				"tolerations": []interface{}{map[string]interface{}{"key": "edge", "operator": "Exists", "value": "true"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "Success, hosted",
+			klusterlet: map[string]interface{}{"namespace": "agents", "mode": "Hosted", "hostingClusterName": "hosting"},
+		},
+		{
+			name:       "Failed, invalid namespace",
+			klusterlet: map[string]interface{}{"namespace": "Agents"},
+			wantErr:    true,
+		},
+		{
+			name:       "Failed, invalid mode",
+			klusterlet: map[string]interface{}{"mode": "Remote"},
+			wantErr:    true,
+		},
+		{
+			name:       "Failed, hosted without hosting cluster",
+			klusterlet: map[string]interface{}{"mode": "Detached"},
+			wantErr:    true,
+		},
+		{
+			name:       "Failed, hosting cluster in the default mode",
+			klusterlet: map[string]interface{}{"hostingClusterName": "hosting"},
+			wantErr:    true,
+		},
+		{
+			name:       "Failed, cluster hosting itself",
+			klusterlet: map[string]interface{}{"mode": "Hosted", "hostingClusterName": "edge1"},
+			wantErr:    true,
+		},
+		{
+			name:       "Failed, toleration is not a map",
+			klusterlet: map[string]interface{}{"tolerations": []interface{}{"edge:NoSchedule"}},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]interface{}{"managedClusterName": "edge1", "klusterlet": tt.klusterlet}
+			if err := validateKlusterlet(values); (err != nil) != tt.wantErr {
+				t.Errorf("validateKlusterlet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// newTemplateValues returns the defaults of the values template with the overrides
+func newTemplateValues(t *testing.T, overrides map[string]interface{}) map[string]interface{} {
+	b, err := resources.NewResourcesReader().Asset(valuesTemplatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(b, &values); err != nil {
+		t.Fatal(err)
+	}
+	applierscenarios.MergeValues(values, overrides)
+	return values
+}
+
+func TestHubManifests_klusterletConfig(t *testing.T) {
+	find := func(manifests []*unstructured.Unstructured, kind string) *unstructured.Unstructured {
+		for _, m := range manifests {
+			if m.GetKind() == kind {
+				return m
+			}
+		}
+		return nil
+	}
+
+	manifests, err := HubManifests(resources.NewResourcesReader(), newTemplateValues(t, map[string]interface{}{"managedClusterName": "edge1"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if find(manifests, helpers.KlusterletConfigGVK.Kind) != nil {
+		t.Error("no KlusterletConfig expected without proxy nor klusterlet values")
+	}
+	if _, ok := find(manifests, "ManagedCluster").GetAnnotations()["agent.open-cluster-management.io/klusterlet-config"]; ok {
+		t.Error("no klusterlet-config annotation expected without proxy nor klusterlet values")
+	}
+
+	manifests, err = HubManifests(resources.NewResourcesReader(), newTemplateValues(t, map[string]interface{}{
+		"managedClusterName": "edge1",
+		"klusterlet": map[string]interface{}{
+			"resources":    map[string]interface{}{"requests": map[string]interface{}{"cpu": "10m", "memory": "32Mi"}},
+			"nodeSelector": map[string]interface{}{"edge": "true"},
+			"tolerations":  []interface{}{map[string]interface{}{"key": "edge", "operator": "Exists", "effect": "NoSchedule"}},
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := find(manifests, helpers.KlusterletConfigGVK.Kind)
+	if config == nil {
+		t.Fatal("KlusterletConfig expected")
+	}
+	if _, ok, _ := unstructured.NestedFieldNoCopy(config.Object, "spec", "hubKubeAPIServerProxyConfig"); ok {
+		t.Error("no proxy expected")
+	}
+	selector, _, _ := unstructured.NestedStringMap(config.Object, "spec", "nodePlacement", "nodeSelector")
+	if !reflect.DeepEqual(selector, map[string]string{"edge": "true"}) {
+		t.Errorf("nodeSelector = %v", selector)
+	}
+	tolerations, _, _ := unstructured.NestedSlice(config.Object, "spec", "nodePlacement", "tolerations")
+	if len(tolerations) != 1 {
+		t.Errorf("tolerations = %v", tolerations)
+	}
+	memory, _, _ := unstructured.NestedString(config.Object, "spec", "resourceRequirement", "resourceRequirements", "requests", "memory")
+	if memory != "32Mi" {
+		t.Errorf("memory request = %s, want 32Mi", memory)
+	}
+	if name := find(manifests, "ManagedCluster").GetAnnotations()["agent.open-cluster-management.io/klusterlet-config"]; name != "edge1" {
+		t.Errorf("klusterlet-config annotation = %s, want edge1", name)
+	}
+}
+
+func TestHubManifests_hosted(t *testing.T) {
+	manifests, err := HubManifests(resources.NewResourcesReader(), newTemplateValues(t, map[string]interface{}{
+		"managedClusterName": "edge1",
+		"kubeConfig":         "apiVersion: v1\nkind: Config",
+		"klusterlet": map[string]interface{}{
+			"namespace":          "edge-agents",
+			"mode":               klusterletModeDetached,
+			"hostingClusterName": "hosting",
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mc, work *unstructured.Unstructured
+	for _, m := range manifests {
+		switch m.GetKind() {
+		case "ManagedCluster":
+			mc = m
+		case "ManifestWork":
+			work = m
+		}
+	}
+	annotations := mc.GetAnnotations()
+	if annotations["import.open-cluster-management.io/klusterlet-deploy-mode"] != klusterletModeHosted ||
+		annotations["import.open-cluster-management.io/hosting-cluster-name"] != "hosting" ||
+		annotations["import.open-cluster-management.io/klusterlet-namespace"] != "edge-agents" {
+		t.Errorf("unexpected ManagedCluster annotations %v", annotations)
+	}
+	if work == nil || work.GetNamespace() != "hosting" {
+		t.Fatalf("the external-managed-kubeconfig ManifestWork is expected in the namespace of the hosting cluster, got %v", work)
+	}
+	manifestList, _, _ := unstructured.NestedSlice(work.Object, "spec", "workload", "manifests")
+	secret := &unstructured.Unstructured{Object: manifestList[len(manifestList)-1].(map[string]interface{})}
+	if secret.GetName() != "external-managed-kubeconfig" || secret.GetNamespace() != "klusterlet-edge1" {
+		t.Errorf("unexpected secret %s/%s", secret.GetNamespace(), secret.GetName())
+	}
+	if kubeConfig, _, _ := unstructured.NestedString(secret.Object, "stringData", "kubeconfig"); kubeConfig != "apiVersion: v1\nkind: Config" {
+		t.Errorf("kubeconfig = %q", kubeConfig)
+	}
+
+	//The default mode deploys the agents on the cluster
+	manifests, err = HubManifests(resources.NewResourcesReader(), newTemplateValues(t, map[string]interface{}{
+		"managedClusterName": "edge1",
+		"kubeConfig":         "apiVersion: v1\nkind: Config",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range manifests {
+		if m.GetKind() == "ManifestWork" {
+			t.Error("no ManifestWork expected in the Default mode")
+		}
+		if _, ok := m.GetAnnotations()["import.open-cluster-management.io/klusterlet-deploy-mode"]; ok {
+			t.Error("no deploy mode annotation expected in the Default mode")
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const localClusterName = "local-cluster"
+
+// detectLocalCluster switches to the local-cluster scenario when the spoke is the hub itself
+func (o *Options) detectLocalCluster() error {
+	if o.clusterName == localClusterName || (o.clusterKubeConfig == "" && o.clusterServer == "") {
+		return nil
+	}
+	factory := clients.ForFlags(o.applierScenariosOptions.ConfigFlags)
+	hubConfig, err := factory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	hubClient, err := factory.KubeClient()
+	if err != nil {
+		return err
+	}
+
+	spokeHost := o.clusterServer
+	var spokeClient kubernetes.Interface
+	//The spoke is only queried with a kubeconfig, a bare token is not sent to an unverified server
+	if o.clusterKubeConfig != "" {
+		spokeConfig, err := clientcmd.RESTConfigFromKubeConfig([]byte(o.clusterKubeConfig))
+		if err != nil {
+			return fmt.Errorf("invalid kubeconfig: %s", err.Error())
+		}
+		spokeHost = spokeConfig.Host
+		spokeConfig.Timeout = spokeTimeout
+		spokeClient, err = kubernetes.NewForConfig(spokeConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !isLocalCluster(o.ctx, hubConfig.Host, hubClient, spokeHost, spokeClient) {
+		return nil
+	}
+	if !o.applierScenariosOptions.Silent {
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "The cluster %s is the hub itself, it is attached as %s\n", o.clusterName, localClusterName)
+	}
+	o.useLocalCluster()
+	return nil
+}
+
+// useLocalCluster attaches the hub as local-cluster, it does not need the import credentials
+func (o *Options) useLocalCluster() {
+	o.clusterName = localClusterName
+	o.clusterKubeConfig = ""
+	o.clusterServer = ""
+	o.clusterToken = ""
+	o.values["managedClusterName"] = o.clusterName
+	o.values["kubeConfig"] = ""
+	o.values["server"] = ""
+	o.values["token"] = ""
+}
+
+// isLocalCluster tells if the spoke is the hub, either the api server urls are the same
+// or the kube-system namespaces have the same uid. The spoke client is nil when it can not be queried.
+func isLocalCluster(ctx context.Context, hubHost string, hubClient kubernetes.Interface, spokeHost string, spokeClient kubernetes.Interface) bool {
+	if spokeHost != "" && normalizeHost(hubHost) == normalizeHost(spokeHost) {
+		return true
+	}
+	if spokeClient == nil {
+		return false
+	}
+	hubNS, err := hubClient.CoreV1().Namespaces().Get(ctx, "kube-system", metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	//An unreachable spoke is not the hub, the preflight checks which run next report it
+	spokeNS, err := spokeClient.CoreV1().Namespaces().Get(ctx, "kube-system", metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	return hubNS.UID != "" && hubNS.UID == spokeNS.UID
+}
+
+// normalizeHost returns the host:port of an api server url, the default scheme is https
+func normalizeHost(host string) string {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return host
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(strings.ToLower(u.Hostname()), port)
+}
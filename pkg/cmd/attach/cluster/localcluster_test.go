@@ -0,0 +1,88 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newKubeSystem(uid types.UID) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system", UID: uid}}
+}
+
+func Test_isLocalCluster(t *testing.T) {
+	tests := []struct {
+		name        string
+		spokeHost   string
+		spokeClient kubernetes.Interface
+		want        bool
+	}{
+		{
+			name:      "Same server",
+			spokeHost: "https://API.hub.example.com:443",
+			want:      true,
+		},
+		{
+			name:      "Same server without scheme and default port",
+			spokeHost: "api.hub.example.com",
+			want:      true,
+		},
+		{
+			name:      "Other server, no spoke client",
+			spokeHost: "https://api.spoke.example.com:6443",
+		},
+		{
+			name:        "Other server, same kube-system uid",
+			spokeHost:   "https://api-int.hub.example.com:6443",
+			spokeClient: kubefake.NewSimpleClientset(newKubeSystem("hub-uid")),
+			want:        true,
+		},
+		{
+			name:        "Other server, other kube-system uid",
+			spokeHost:   "https://api.spoke.example.com:6443",
+			spokeClient: kubefake.NewSimpleClientset(newKubeSystem("spoke-uid")),
+		},
+		{
+			name:        "Other server, spoke unreachable",
+			spokeHost:   "https://api.spoke.example.com:6443",
+			spokeClient: kubefake.NewSimpleClientset(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hubClient := kubefake.NewSimpleClientset(newKubeSystem("hub-uid"))
+			if got := isLocalCluster(context.TODO(), "https://api.hub.example.com", hubClient, tt.spokeHost, tt.spokeClient); got != tt.want {
+				t.Errorf("isLocalCluster() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptions_useLocalCluster(t *testing.T) {
+	o := &Options{
+		clusterName:   "mycluster",
+		clusterServer: "https://api.hub.example.com",
+		clusterToken:  "token",
+		values: map[string]interface{}{
+			"managedClusterName": "mycluster",
+			"server":             "https://api.hub.example.com",
+			"token":              "token",
+		},
+	}
+	o.useLocalCluster()
+	if o.clusterName != localClusterName || o.values["managedClusterName"] != localClusterName {
+		t.Errorf("the cluster must be attached as %s, got %s", localClusterName, o.clusterName)
+	}
+	if o.clusterServer != "" || o.clusterToken != "" || o.values["server"] != "" || o.values["token"] != "" {
+		t.Errorf("the import credentials must be removed, got %v", o.values)
+	}
+	if err := o.validate(); err != nil {
+		t.Errorf("the local-cluster must be valid, got %v", err)
+	}
+}
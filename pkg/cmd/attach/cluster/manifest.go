@@ -0,0 +1,322 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/progress"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const (
+	attachStatusAttached = "attached"
+	attachStatusFailed   = "failed"
+)
+
+// manifest lists the clusters of a batch attach
+type manifest struct {
+	Clusters []manifestCluster `json:"clusters"`
+}
+
+// manifestCluster is a cluster of a manifest, hub is the kubeconfig context of the hub it is attached to,
+// the hub of the command if not set, and values are merged over the values of the command
+type manifestCluster struct {
+	Name   string                 `json:"name"`
+	Hub    string                 `json:"hub,omitempty"`
+	Values map[string]interface{} `json:"values,omitempty"`
+}
+
+// attachResult is the outcome of the attach of a cluster of the manifest or of the inventory
+type attachResult struct {
+	Name    string `json:"name"`
+	Hub     string `json:"hub,omitempty"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// readManifest reads the clusters of a yaml or json manifest
+func readManifest(path string) ([]manifestCluster, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	m := &manifest{}
+	if err := yaml.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %s", path, err.Error())
+	}
+	if len(m.Clusters) == 0 {
+		return nil, fmt.Errorf("the manifest %s lists no cluster", path)
+	}
+	return m.Clusters, nil
+}
+
+// completeManifest reads the manifest and sets the options attaching each of its clusters,
+// the values files and the flags apply to all of them
+func (o *Options) completeManifest(cmd *cobra.Command) (err error) {
+	if cmd != nil && cmd.Flags().Changed("name") {
+		return fmt.Errorf("--name can not be used with --manifest, the names are given by the manifest")
+	}
+	clusters, err := readManifest(o.manifestFile)
+	if err != nil {
+		return err
+	}
+	common, err := o.applierScenariosOptions.ReadValues()
+	if err != nil {
+		return err
+	}
+	if o.curatorFile != "" {
+		common["curator"], err = helpers.ReadCuratorFile(o.curatorFile)
+		if err != nil {
+			return err
+		}
+	}
+	//The clusters of a same hub share its config flags and so its clients
+	hubFlags := make(map[string]*genericclioptions.ConfigFlags)
+	o.manifest = make([]*Options, 0, len(clusters))
+	for i, cluster := range clusters {
+		if _, ok := hubFlags[cluster.Hub]; !ok {
+			hubFlags[cluster.Hub] = o.hubConfigFlags(cluster.Hub)
+		}
+		c, err := o.newManifestOptions(cmd, cluster, common, hubFlags[cluster.Hub])
+		if err != nil {
+			return fmt.Errorf("cluster %d (%s): %s", i+1, cluster.Name, err.Error())
+		}
+		o.manifest = append(o.manifest, c)
+	}
+	return nil
+}
+
+// newManifestOptions returns the options attaching the cluster of the manifest to the hub of the config flags,
+// the hubs are attached concurrently so the applier output is silenced
+func (o *Options) newManifestOptions(cmd *cobra.Command, cluster manifestCluster, common map[string]interface{},
+	hubFlags *genericclioptions.ConfigFlags) (*Options, error) {
+	values, err := copyValues(common)
+	if err != nil {
+		return nil, err
+	}
+	mergeValues(values, cluster.Values)
+	if cluster.Name != "" {
+		values["managedClusterName"] = cluster.Name
+	}
+
+	applierScenariosOptions := *o.applierScenariosOptions
+	applierScenariosOptions.Silent = true
+	applierScenariosOptions.ConfigFlags = hubFlags
+	c := *o
+	c.applierScenariosOptions = &applierScenariosOptions
+	c.values = values
+	c.clusterName = ""
+	c.hub = cluster.Hub
+	c.manifestFile = ""
+	c.manifest = nil
+	c.progress = progress.NewReporter(progress.FormatText, nil)
+	if err := c.completeValues(cmd, valuesSchema); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// hubConfigFlags returns the config flags of the hub of a cluster, the flags of the command if no hub is set.
+// The other hubs are the contexts of the kubeconfig of the command.
+func (o *Options) hubConfigFlags(hub string) *genericclioptions.ConfigFlags {
+	flags := o.applierScenariosOptions.ConfigFlags
+	if hub == "" {
+		return flags
+	}
+	hubFlags := genericclioptions.NewConfigFlags(true)
+	hubFlags.KubeConfig = flags.KubeConfig
+	hubFlags.CacheDir = flags.CacheDir
+	hubFlags.Timeout = flags.Timeout
+	hubFlags.Context = &hub
+	return hubFlags
+}
+
+// kubeconfigContexts returns the contexts of the kubeconfig, it is only read if a cluster sets its hub
+func (o *Options) kubeconfigContexts(clusters []*Options) (map[string]bool, error) {
+	contexts := make(map[string]bool)
+	for _, c := range clusters {
+		if c.hub == "" {
+			continue
+		}
+		raw, err := o.applierScenariosOptions.ConfigFlags.ToRawKubeConfigLoader().RawConfig()
+		if err != nil {
+			return nil, err
+		}
+		for name := range raw.Contexts {
+			contexts[name] = true
+		}
+		break
+	}
+	return contexts, nil
+}
+
+// copyValues returns a deep copy of the values
+func copyValues(values map[string]interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	c := make(map[string]interface{})
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// mergeValues merges the values of src in dst, the maps are merged and the other values of src replace the ones of dst
+func mergeValues(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcMap, ok := v.(map[string]interface{})
+		dstMap, dstOk := dst[k].(map[string]interface{})
+		if ok && dstOk {
+			mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// validateManifest validates all clusters of the manifest before attaching any of them
+func (o *Options) validateManifest() error {
+	if o.inventoryFile != "" || o.manualImport() || o.export != "" || o.saveSpecPath != "" || o.async || o.wait || o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("--manifest can not be used with inventory, import-file, import-output-dir, bundle, export, save-spec, async, wait or outFile")
+	}
+	if err := helpers.ValidateMaxConcurrency(o.maxConcurrency); err != nil {
+		return err
+	}
+	contexts, err := o.kubeconfigContexts(o.manifest)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]int)
+	problems := make([]string, 0)
+	for i, c := range o.manifest {
+		n := i + 1
+		if c.hub != "" && !contexts[c.hub] {
+			problems = append(problems, fmt.Sprintf(" - cluster %d (%s): the hub %s is not a context of the kubeconfig", n, c.clusterName, c.hub))
+			continue
+		}
+		if c.clusterName == "" {
+			problems = append(problems, fmt.Sprintf(" - cluster %d: the name of the cluster is missing", n))
+			continue
+		}
+		//A same cluster name can be attached to several hubs
+		key := c.hub + "/" + c.clusterName
+		if first, ok := names[key]; ok {
+			problems = append(problems, fmt.Sprintf(" - cluster %d: cluster %s is already listed for hub %s at %d", n, c.clusterName, hubName(c.hub), first))
+			continue
+		}
+		names[key] = n
+		if err := c.validate(); err != nil {
+			problems = append(problems, fmt.Sprintf(" - cluster %d (%s): %s", n, c.clusterName, err.Error()))
+		}
+	}
+	if len(problems) != 0 {
+		return fmt.Errorf("%d invalid cluster(s) in the manifest %s, no cluster was attached:\n%s",
+			len(problems), o.manifestFile, strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// runManifest attaches the clusters of the manifest concurrently and reports the status of each of them.
+// The slots of --max-concurrency are shared fairly across the hubs, the clusters of a hub share its config flags
+// and so its clients and its --hub-rate-limit. A failed attach does not stop the others.
+func (o *Options) runManifest(attach func(c *Options) error) error {
+	reporter := o.progressReporter()
+	results := make([]attachResult, len(o.manifest))
+	hubs := make([]string, len(o.manifest))
+	for i, c := range o.manifest {
+		hubs[i] = c.hub
+	}
+	var lock sync.Mutex
+	errs := helpers.RunBatch(o.ctx, hubs, o.maxConcurrency, func(i int) error {
+		c := o.manifest[i]
+		resource := "ManagedCluster/" + c.clusterName
+		lock.Lock()
+		reporter.Report("attach", resource, progress.StatusStarted, "")
+		lock.Unlock()
+
+		result := attachResult{Name: c.clusterName, Hub: c.hub, Status: attachStatusAttached}
+		if err := attach(c); err != nil {
+			result.Status = attachStatusFailed
+			result.Message = err.Error()
+		}
+		results[i] = result
+
+		lock.Lock()
+		defer lock.Unlock()
+		if result.Status == attachStatusFailed {
+			reporter.Report("attach", resource, progress.StatusFailed, result.Message)
+			return nil
+		}
+		reporter.Report("attach", resource, progress.StatusSucceeded, "")
+		return nil
+	})
+	//The clusters not started on Ctrl+C are reported as failed
+	for i, err := range errs {
+		if err != nil {
+			results[i] = attachResult{Name: o.manifest[i].clusterName, Hub: o.manifest[i].hub, Status: attachStatusFailed, Message: err.Error()}
+		}
+	}
+
+	failed := 0
+	table := &printers.Table{
+		Headers: []string{"HUB", "NAME", "STATUS", "MESSAGE"},
+	}
+	for _, r := range results {
+		if r.Status == attachStatusFailed {
+			failed++
+		}
+		table.AddRow(hubName(r.Hub), r.Name, r.Status, r.Message)
+	}
+	//The output only contains the events with --progress-format json
+	if !reporter.Enabled() {
+		if err := printers.PrintTable(o.applierScenariosOptions.Out, table); err != nil {
+			return err
+		}
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d of %d clusters failed to attach: %s", failed, len(results), hubSummary(results))
+	}
+	return nil
+}
+
+// hubSummary returns the number of clusters attached and failed on each hub, in the order of the first cluster of each hub
+func hubSummary(results []attachResult) string {
+	order := make([]string, 0)
+	attached := make(map[string]int)
+	failed := make(map[string]int)
+	for _, r := range results {
+		if _, ok := attached[r.Hub]; !ok {
+			order = append(order, r.Hub)
+			attached[r.Hub] = 0
+		}
+		if r.Status == attachStatusFailed {
+			failed[r.Hub]++
+			continue
+		}
+		attached[r.Hub]++
+	}
+	hubs := make([]string, 0, len(order))
+	for _, hub := range order {
+		hubs = append(hubs, fmt.Sprintf("%s %d attached, %d failed", hubName(hub), attached[hub], failed[hub]))
+	}
+	return strings.Join(hubs, "; ")
+}
+
+// hubName returns the name of the hub of a cluster in the reports, the hub of the command is the current context
+func hubName(hub string) string {
+	if hub == "" {
+		return "<current>"
+	}
+	return hub
+}
@@ -0,0 +1,246 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const testManifest = `clusters:
+- name: cluster1
+  hub: hub-eu
+  values:
+    server: https://api.cluster1.example.com:6443
+    token: token1
+    labels:
+      region: eu
+- name: cluster2
+  hub: hub-us
+  values:
+    server: https://api.cluster2.example.com:6443
+    token: token2
+- name: cluster3
+  values:
+    server: https://api.cluster3.example.com:6443
+    token: token3
+`
+
+const testHubsKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: hub
+  cluster:
+    server: https://hub.example.com:6443
+users:
+- name: admin
+  user:
+    token: admin
+contexts:
+- name: hub-eu
+  context:
+    cluster: hub
+    user: admin
+- name: hub-us
+  context:
+    cluster: hub
+    user: admin
+current-context: hub-eu
+`
+
+// writeManifestFiles writes the manifest and the kubeconfig of the hubs in a temporary directory
+func writeManifestFiles(t *testing.T, manifest string) (string, string) {
+	dir, err := ioutil.TempDir("", "manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	manifestFile := filepath.Join(dir, "clusters.yaml")
+	if err := ioutil.WriteFile(manifestFile, []byte(manifest), 0600); err != nil {
+		t.Fatal(err)
+	}
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	if err := ioutil.WriteFile(kubeconfig, []byte(testHubsKubeconfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return manifestFile, kubeconfig
+}
+
+func newManifestOptions(t *testing.T, manifest string, args ...string) (*Options, *cobra.Command) {
+	manifestFile, kubeconfig := writeManifestFiles(t, manifest)
+	o := newOptions(genericclioptions.IOStreams{In: os.Stdin, Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}})
+	o.applierScenariosOptions.ConfigFlags.KubeConfig = &kubeconfig
+	o.applierScenariosOptions.ValuesPaths = []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")}
+	o.manifestFile = manifestFile
+	o.skipPreflight = true
+	o.maxConcurrency = helpers.DefaultMaxConcurrency
+	cmd := newValuesCmd(t, args...)
+	return o, cmd
+}
+
+func Test_readManifest(t *testing.T) {
+	manifestFile, _ := writeManifestFiles(t, testManifest)
+	clusters, err := readManifest(manifestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clusters) != 3 || clusters[0].Hub != "hub-eu" || clusters[2].Hub != "" ||
+		applierscenarios.GetString(clusters[1].Values, "token") != "token2" {
+		t.Errorf("unexpected clusters %+v", clusters)
+	}
+
+	emptyFile, _ := writeManifestFiles(t, "clusters: []\n")
+	if _, err := readManifest(emptyFile); err == nil || !strings.Contains(err.Error(), "lists no cluster") {
+		t.Errorf("an empty manifest must be rejected, got %v", err)
+	}
+	invalidFile, _ := writeManifestFiles(t, "clusters: {\n")
+	if _, err := readManifest(invalidFile); err == nil || !strings.Contains(err.Error(), "invalid manifest") {
+		t.Errorf("an invalid manifest must be rejected, got %v", err)
+	}
+}
+
+func TestOptions_completeManifest(t *testing.T) {
+	o, cmd := newManifestOptions(t, testManifest, "--auto-import-retry", "7")
+	if err := o.completeManifest(cmd); err != nil {
+		t.Fatal(err)
+	}
+	if len(o.manifest) != 3 {
+		t.Fatalf("expected 3 clusters, got %d", len(o.manifest))
+	}
+	c1 := o.manifest[0]
+	if c1.clusterName != "cluster1" || c1.hub != "hub-eu" || c1.clusterServer != "https://api.cluster1.example.com:6443" ||
+		*c1.applierScenariosOptions.ConfigFlags.Context != "hub-eu" || !c1.applierScenariosOptions.Silent {
+		t.Errorf("unexpected options of cluster1 %+v", c1)
+	}
+	if labels, _ := c1.values["labels"].(map[string]interface{}); labels["region"] != "eu" {
+		t.Errorf("the values of the manifest must be merged over the values file, got %v", c1.values["labels"])
+	}
+	if fmt.Sprint(c1.values["autoImportRetry"]) != "7" {
+		t.Errorf("the flags must apply to all clusters, got %v", c1.values["autoImportRetry"])
+	}
+	if labels, _ := o.manifest[1].values["labels"].(map[string]interface{}); labels["region"] != nil {
+		t.Errorf("the values of a cluster must not leak to the others, got %v", o.manifest[1].values["labels"])
+	}
+	if o.manifest[2].applierScenariosOptions.ConfigFlags != o.applierScenariosOptions.ConfigFlags {
+		t.Errorf("a cluster without hub must be attached to the hub of the command")
+	}
+
+	o, cmd = newManifestOptions(t, testManifest, "--name", "mycluster")
+	if err := o.completeManifest(cmd); err == nil || !strings.Contains(err.Error(), "--name") {
+		t.Errorf("--name must be rejected with --manifest, got %v", err)
+	}
+}
+
+func TestOptions_validateManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		async    bool
+		wantErr  []string
+	}{
+		{
+			name:     "Success",
+			manifest: testManifest,
+		},
+		{
+			name:     "Failed, async",
+			manifest: testManifest,
+			async:    true,
+			wantErr:  []string{"--manifest can not be used with"},
+		},
+		{
+			name: "Failed, all problems reported",
+			manifest: `clusters:
+- name: cluster1
+  hub: hub-ap
+  values: {server: https://api.example.com:6443, token: t}
+- hub: hub-eu
+  values: {server: https://api.example.com:6443, token: t}
+- name: cluster2
+  hub: hub-eu
+  values: {server: https://api.example.com:6443, token: t}
+- name: cluster2
+  hub: hub-eu
+  values: {server: https://api.example.com:6443, token: t}
+- name: cluster2
+  hub: hub-us
+  values: {server: https://api.example.com:6443}
+`,
+			wantErr: []string{
+				"4 invalid cluster(s)",
+				"cluster 1 (cluster1): the hub hub-ap is not a context of the kubeconfig",
+				"cluster 2: the name of the cluster is missing",
+				"cluster 4: cluster cluster2 is already listed for hub hub-eu at 3",
+				"cluster 5 (cluster2): server or token is missing",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, _ := newManifestOptions(t, tt.manifest)
+			o.applierScenariosOptions.ValuesPaths = nil
+			if err := o.completeManifest(nil); err != nil {
+				t.Fatal(err)
+			}
+			o.async = tt.async
+			err := o.validateManifest()
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("validateManifest() must fail with %v", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("validateManifest() error = %s, must contain %s", err.Error(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_runManifest(t *testing.T) {
+	o, _ := newManifestOptions(t, testManifest)
+	if err := o.completeManifest(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var lock sync.Mutex
+	hubFlags := make(map[string]*genericclioptions.ConfigFlags)
+	attach := func(c *Options) error {
+		lock.Lock()
+		defer lock.Unlock()
+		hubFlags[c.clusterName] = c.applierScenariosOptions.ConfigFlags
+		if c.clusterName == "cluster2" {
+			return fmt.Errorf("import failed")
+		}
+		return nil
+	}
+
+	err := o.runManifest(attach)
+	if err == nil || err.Error() != "1 of 3 clusters failed to attach: hub-eu 1 attached, 0 failed; hub-us 0 attached, 1 failed; <current> 1 attached, 0 failed" {
+		t.Errorf("unexpected error %v", err)
+	}
+	if *hubFlags["cluster1"].Context != "hub-eu" || *hubFlags["cluster2"].Context != "hub-us" || hubFlags["cluster3"] != o.applierScenariosOptions.ConfigFlags {
+		t.Errorf("each cluster must be attached with the config flags of its hub")
+	}
+	out := o.applierScenariosOptions.Out.(*bytes.Buffer).String()
+	for _, want := range []string{"HUB", "hub-eu", "cluster2", "import failed", "<current>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("the output must contain %s, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cloud/rancher"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+var migrateExample = `
+# Select the clusters of a Rancher server to attach
+%[1]s attach cluster migrate --rancher-url https://rancher.example.com --rancher-token token-abcde:secret
+
+# Attach all the clusters of the kubeconfigs exported by another fleet manager
+%[1]s attach cluster migrate --kubeconfigs exported-kubeconfigs/ --select all --values values.yaml
+`
+
+// migrateValuesSchema is the values schema of the migration, the name and the credentials of each cluster are read from its source
+var migrateValuesSchema = newMigrateValuesSchema()
+
+type migrateOptions struct {
+	*Options
+	rancherURL      string
+	rancherToken    string
+	rancherInsecure bool
+	kubeConfigsPath string
+	//selection are the clusters to attach without prompt, as numbers, ranges, names or all
+	selection []string
+}
+
+// migrationCandidate is a cluster of another fleet manager which can be attached
+type migrationCandidate struct {
+	row    inventoryRow
+	state  string
+	source string
+}
+
+// newCmdMigrate attaches the clusters selected among the ones of a Rancher server or of exported kubeconfigs
+func newCmdMigrate(streams genericclioptions.IOStreams) *cobra.Command {
+	o := &migrateOptions{
+		Options: newOptions(streams),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Attach clusters of another fleet manager",
+		Long: "List the clusters of a Rancher server or of the kubeconfigs exported by another fleet manager and attach the selected ones. " +
+			"The clusters are selected in an interactive prompt or with --select, then they are attached like the clusters of an --inventory",
+		Example:      fmt.Sprintf(migrateExample, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.SetUsageTemplate(applierscenarios.UsageTempate(cmd, valuesTemplatePath))
+	migrateValuesSchema.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.rancherURL, "rancher-url", "", "The url of the Rancher server whose clusters are listed")
+	cmd.Flags().StringVar(&o.rancherToken, "rancher-token", "", "The Rancher API token, defaults to the RANCHER_TOKEN environment variable, it is also the token of the import")
+	cmd.Flags().BoolVar(&o.rancherInsecure, "rancher-insecure-skip-tls-verify", false, "If set, the certificate of the Rancher server is not verified")
+	cmd.Flags().StringVar(&o.kubeConfigsPath, "kubeconfigs", "", "A kubeconfig file or a directory of kubeconfig files, each context is a cluster")
+	cmd.Flags().StringSliceVar(&o.selection, "select", nil, "The clusters to attach without prompt, as numbers, ranges like 2-4, names or all")
+	helpers.AddMaxConcurrencyFlag(cmd.Flags(), &o.maxConcurrency, "clusters attached")
+	cmd.Flags().StringVar(&o.profile, "profile", "", fmt.Sprintf("A preset of the addons, the lease duration and the klusterlet resources for the small clusters, one of %s", strings.Join(profileNames(), ", ")))
+	cmd.Flags().BoolVar(&o.skipPreflight, "skip-preflight", false, "If set, the preflight checks on the hub will be skipped")
+
+	o.applierScenariosOptions.AddFlags(cmd.Flags())
+	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// newMigrateValuesSchema returns the values schema of the cloud provider shortcuts without the managed cluster name
+func newMigrateValuesSchema() applierscenarios.ValuesSchema {
+	schema := applierscenarios.ValuesSchema{}
+	for _, v := range newProviderValuesSchema("name") {
+		if v.Path != "managedClusterName" {
+			schema = append(schema, v)
+		}
+	}
+	return schema
+}
+
+func (o *migrateOptions) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) != 0 {
+		return fmt.Errorf("the cluster names can not be given as arguments, they are selected among the listed clusters")
+	}
+	if o.rancherToken == "" {
+		o.rancherToken = os.Getenv("RANCHER_TOKEN")
+	}
+	var candidates []migrationCandidate
+	var err error
+	switch {
+	case o.rancherURL != "" && o.kubeConfigsPath != "":
+		return fmt.Errorf("--rancher-url and --kubeconfigs can not be used together")
+	case o.rancherURL != "":
+		if o.rancherToken == "" {
+			return fmt.Errorf("the Rancher API token is missing, set it with --rancher-token or RANCHER_TOKEN")
+		}
+		candidates, err = rancherCandidates(o.rancherURL, o.rancherToken, o.rancherInsecure)
+		//The source is reported as the inventory of the clusters
+		o.inventoryFile = o.rancherURL
+	case o.kubeConfigsPath != "":
+		candidates, err = kubeConfigCandidates(o.kubeConfigsPath)
+		o.inventoryFile = o.kubeConfigsPath
+	default:
+		return fmt.Errorf("the clusters to migrate are missing, set --rancher-url or --kubeconfigs")
+	}
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf("no cluster found in %s", o.inventoryFile)
+	}
+
+	rows, err := o.selectCandidates(candidates)
+	if err != nil {
+		return err
+	}
+	values, err := o.inventoryValues(cmd, migrateValuesSchema)
+	if err != nil {
+		return err
+	}
+	return o.setInventory(rows, values)
+}
+
+func (o *migrateOptions) validate() error {
+	return o.validateInventory()
+}
+
+func (o *migrateOptions) run() error {
+	return o.runInventory(func(c *Options) error {
+		return c.run()
+	})
+}
+
+// rancherCandidates returns the downstream clusters of a Rancher server, they are imported
+// through the Rancher proxy of their api server with the Rancher token
+func rancherCandidates(server, token string, insecure bool) ([]migrationCandidate, error) {
+	clusters, err := rancher.ListClusters(server, token, insecure)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]migrationCandidate, 0, len(clusters))
+	for _, c := range clusters {
+		candidates = append(candidates, migrationCandidate{
+			row: inventoryRow{
+				Name:   managedClusterName(c.Name),
+				Server: rancher.ClusterServer(server, c.ID),
+				Token:  token,
+			},
+			state:  c.State,
+			source: c.ID,
+		})
+	}
+	return candidates, nil
+}
+
+// kubeConfigCandidates returns a cluster per context of the kubeconfig file or of the files of the directory,
+// the kubeconfig of each cluster only holds its context with the certificates inlined
+func kubeConfigCandidates(path string) ([]migrationCandidate, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if fi.IsDir() {
+		files = make([]string, 0)
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			//The kubeconfigs often have no extension
+			if !e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+				files = append(files, filepath.Join(path, e.Name()))
+			}
+		}
+	}
+	candidates := make([]migrationCandidate, 0)
+	for _, f := range files {
+		config, err := clientcmd.LoadFromFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig %s: %s", f, err.Error())
+		}
+		contexts := make([]string, 0, len(config.Contexts))
+		for name := range config.Contexts {
+			contexts = append(contexts, name)
+		}
+		sort.Strings(contexts)
+		for _, name := range contexts {
+			c := config.DeepCopy()
+			c.CurrentContext = name
+			if err := clientcmdapi.MinifyConfig(c); err != nil {
+				return nil, fmt.Errorf("invalid context %s of the kubeconfig %s: %s", name, f, err.Error())
+			}
+			if err := clientcmdapi.FlattenConfig(c); err != nil {
+				return nil, fmt.Errorf("invalid context %s of the kubeconfig %s: %s", name, f, err.Error())
+			}
+			b, err := helpers.MarshalKubeConfig(c)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, migrationCandidate{
+				row: inventoryRow{
+					Name:           managedClusterName(name),
+					Server:         c.Clusters[c.Contexts[name].Cluster].Server,
+					kubeConfigData: b,
+				},
+				source: filepath.Base(f) + ":" + name,
+			})
+		}
+	}
+	return candidates, nil
+}
+
+// managedClusterName turns the name of a cluster in another fleet manager in a valid managed cluster name
+func managedClusterName(name string) string {
+	b := strings.Builder{}
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteRune('-')
+	}
+	s := b.String()
+	if len(s) > validation.DNS1123LabelMaxLength {
+		s = s[:validation.DNS1123LabelMaxLength]
+	}
+	return strings.Trim(s, "-")
+}
+
+// selectCandidates returns the rows of the clusters given by --select, or chosen in an interactive prompt
+func (o *migrateOptions) selectCandidates(candidates []migrationCandidate) ([]inventoryRow, error) {
+	if len(o.selection) != 0 {
+		return parseSelection(strings.Join(o.selection, ","), candidates)
+	}
+	w := o.applierScenariosOptions.ErrOut
+	table := &printers.Table{
+		Headers: []string{"#", "NAME", "SERVER", "STATE", "SOURCE"},
+	}
+	for i, c := range candidates {
+		table.AddRow(strconv.Itoa(i+1), c.row.Name, c.row.Server, c.state, c.source)
+	}
+	if err := printers.PrintTable(w, table); err != nil {
+		return nil, err
+	}
+	fmt.Fprint(w, "Select the clusters to attach, as numbers, ranges like 2-4 or names separated by commas, or all: ")
+	if o.applierScenariosOptions.In == nil {
+		fmt.Fprintln(w)
+		return nil, fmt.Errorf("aborted, no input to select the clusters, use --select")
+	}
+	answer, err := bufio.NewReader(o.applierScenariosOptions.In).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return parseSelection(answer, candidates)
+}
+
+// parseSelection returns the rows of the selected clusters in the order of the candidates,
+// a cluster is selected by its number, a range of numbers, its name or all
+func parseSelection(selection string, candidates []migrationCandidate) ([]inventoryRow, error) {
+	selected := make(map[int]bool)
+	for _, s := range strings.FieldsFunc(selection, func(r rune) bool { return r == ',' || r == ' ' || r == '\n' || r == '\r' }) {
+		if s == "all" {
+			for i := range candidates {
+				selected[i] = true
+			}
+			continue
+		}
+		first, last, err := parseRange(s)
+		if err != nil {
+			return nil, err
+		}
+		if first == 0 {
+			found := false
+			for i, c := range candidates {
+				if c.row.Name == s {
+					selected[i], found = true, true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unknown cluster %s", s)
+			}
+			continue
+		}
+		if first > last || last > len(candidates) {
+			return nil, fmt.Errorf("invalid selection %s, the clusters are numbered from 1 to %d", s, len(candidates))
+		}
+		for i := first; i <= last; i++ {
+			selected[i-1] = true
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no cluster selected")
+	}
+	rows := make([]inventoryRow, 0, len(selected))
+	for i, c := range candidates {
+		if selected[i] {
+			rows = append(rows, c.row)
+		}
+	}
+	return rows, nil
+}
+
+// parseRange parses a number or a N-M range, 0 is returned when s is not a number nor a range
+func parseRange(s string) (first, last int, err error) {
+	parts := strings.SplitN(s, "-", 2)
+	first, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, nil
+	}
+	last = first
+	if len(parts) == 2 {
+		last, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return 0, 0, nil
+		}
+	}
+	if first < 1 {
+		return 0, 0, fmt.Errorf("invalid selection %s, the clusters are numbered from 1", s)
+	}
+	return first, last, nil
+}
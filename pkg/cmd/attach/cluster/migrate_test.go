@@ -0,0 +1,150 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func newCandidates(names ...string) []migrationCandidate {
+	candidates := make([]migrationCandidate, 0, len(names))
+	for _, n := range names {
+		candidates = append(candidates, migrationCandidate{row: inventoryRow{Name: n}})
+	}
+	return candidates
+}
+
+func Test_parseSelection(t *testing.T) {
+	candidates := newCandidates("prod", "dev", "1-edge", "staging")
+	tests := []struct {
+		name      string
+		selection string
+		want      []string
+		wantErr   bool
+	}{
+		{name: "Numbers and range", selection: "4, 1-2", want: []string{"prod", "dev", "staging"}},
+		{name: "Names", selection: "staging,1-edge\n", want: []string{"1-edge", "staging"}},
+		{name: "All", selection: "all", want: []string{"prod", "dev", "1-edge", "staging"}},
+		{name: "Failed, empty", selection: "\n", wantErr: true},
+		{name: "Failed, out of range", selection: "3-5", wantErr: true},
+		{name: "Failed, zero", selection: "0", wantErr: true},
+		{name: "Failed, unknown name", selection: "qa", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseSelection(tt.selection, candidates)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSelection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := make([]string, 0, len(rows))
+			for _, r := range rows {
+				got = append(got, r.Name)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSelection() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_managedClusterName(t *testing.T) {
+	for name, want := range map[string]string{
+		"Prod_EU.1":             "prod-eu-1",
+		"-edge-":                "edge",
+		strings.Repeat("a", 70): strings.Repeat("a", 63),
+	} {
+		if got := managedClusterName(name); got != want {
+			t.Errorf("managedClusterName(%s) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func Test_kubeConfigCandidates(t *testing.T) {
+	dir := t.TempDir()
+	config := clientcmdapi.NewConfig()
+	for _, name := range []string{"prod", "dev"} {
+		config.Clusters[name] = &clientcmdapi.Cluster{Server: "https://" + name + ":6443"}
+		config.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: name + "-token"}
+		config.Contexts[name+"_admin"] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	}
+	b, err := helpers.MarshalKubeConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "exported"), b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	candidates, err := kubeConfigCandidates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 2 {
+		t.Fatalf("expected 2 clusters got %d", len(candidates))
+	}
+	c := candidates[0]
+	if c.row.Name != "dev-admin" || c.row.Server != "https://dev:6443" || c.source != "exported:dev_admin" {
+		t.Errorf("unexpected cluster %+v", c)
+	}
+	kubeConfig, err := clientcmd.Load(c.row.kubeConfigData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kubeConfig.Contexts) != 1 || kubeConfig.CurrentContext != "dev_admin" || kubeConfig.AuthInfos["dev"].Token != "dev-token" {
+		t.Errorf("the kubeconfig of the cluster must only hold its context, got %+v", kubeConfig)
+	}
+}
+
+func TestMigrateOptions_complete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":"c-1","name":"Prod","state":"active"},{"id":"c-2","name":"dev","state":"active"}]}`)
+	}))
+	defer server.Close()
+
+	streams, in, _, errOut := genericclioptions.NewTestIOStreams()
+	o := &migrateOptions{
+		Options:      newOptions(streams),
+		rancherURL:   server.URL,
+		rancherToken: "token-abc",
+	}
+	o.applierScenariosOptions.ValuesPaths = []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")}
+	o.maxConcurrency = 5
+	in.WriteString("1\n")
+	if err := o.complete(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(errOut.String(), "c-2") {
+		t.Errorf("the clusters must be listed before the prompt, got %s", errOut.String())
+	}
+	if len(o.inventory) != 1 {
+		t.Fatalf("expected 1 selected cluster got %d", len(o.inventory))
+	}
+	c := o.inventory[0]
+	if c.clusterName != "prod" || applierscenarios.GetString(c.values, "server") != server.URL+"/k8s/clusters/c-1" ||
+		applierscenarios.GetString(c.values, "token") != "token-abc" {
+		t.Errorf("unexpected cluster %s with values %v", c.clusterName, c.values)
+	}
+	if err := o.validate(); err != nil {
+		t.Error(err)
+	}
+
+	o = &migrateOptions{Options: newOptions(streams)}
+	if err := o.complete(nil, nil); err == nil {
+		t.Error("error expected without source")
+	}
+	o = &migrateOptions{Options: newOptions(streams), rancherURL: server.URL, rancherToken: "token-abc", kubeConfigsPath: "dir"}
+	if err := o.complete(nil, nil); err == nil {
+		t.Error("error expected with two sources")
+	}
+}
@@ -2,7 +2,11 @@
 package cluster
 
 import (
+	"context"
+	"time"
+
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/progress"
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
@@ -14,11 +18,58 @@ type Options struct {
 	clusterServer           string
 	clusterToken            string
 	clusterKubeConfig       string
-	importFile              string
+	//mintToken replaces the kubeConfig by the token of a service account created on the cluster
+	mintToken              bool
+	importFile             string
+	bundleFile             string
+	manifestFile           string
+	importOutputDir        string
+	additionalCABundleFile string
+	additionalCABundle     []byte
+	saveSpecPath           string
+	valuesRoot             string
+	export                 string
+	gitDir                 string
+	inventoryFile          string
+	maxConcurrency         int
+	profile                string
+	klusterletNodeSelector map[string]string
+	klusterletTolerations  []string
+	skipPreflight          bool
+	existingNamespace      bool
+	allowSimilarName       bool
+	createClusterSet       bool
+	rollbackOnFailure      bool
+	hiveAdopt              bool
+	async                  bool
+	importSecretTimeout    time.Duration
+	curatorFile            string
+	wait                   bool
+	curationTimeout        time.Duration
+	//waitFor is the state of the cluster the attach waits for before returning
+	waitFor        string
+	waitForTimeout time.Duration
+	//postAttachJob is the Ansible job template launched once the hub resources are applied
+	postAttachJob        string
+	towerSecret          string
+	waitPostAttachJob    bool
+	postAttachJobTimeout time.Duration
+	pollInterval         time.Duration
+	//ctx is canceled on Ctrl+C to abort the waits
+	ctx      context.Context
+	progress *progress.Reporter
+	//manifest holds the options of each cluster of the manifest
+	manifest []*Options
+	//hub is the kubeconfig context of the hub of a cluster of the manifest or of the inventory, "" for the hub of the command
+	hub string
+	//inventory holds the options of each cluster of the inventory
+	inventory []*Options
 }
 
 func newOptions(streams genericclioptions.IOStreams) *Options {
 	return &Options{
 		applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(streams),
+		pollInterval:            2 * time.Second,
+		ctx:                     context.Background(),
 	}
 }
@@ -0,0 +1,54 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// validatePostAttachJob checks the post-attach job has a tower secret and is only launched when the attach applies the hub resources
+func (o *Options) validatePostAttachJob() error {
+	if o.postAttachJob == "" {
+		if o.towerSecret != "" || o.waitPostAttachJob {
+			return fmt.Errorf("tower-secret and wait-post-attach-job require post-attach-job")
+		}
+		return nil
+	}
+	if o.towerSecret == "" {
+		return fmt.Errorf("post-attach-job requires tower-secret")
+	}
+	if o.manualImport() || o.export != "" || o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("post-attach-job can not be used with import-file, import-output-dir, bundle, export or outFile")
+	}
+	if o.waitPostAttachJob && o.async {
+		return fmt.Errorf("wait-post-attach-job can not be used with async")
+	}
+	return nil
+}
+
+// runPostAttachJob creates the AnsibleJob launching the post-attach job template in the namespace of the cluster,
+// the tower secret must be in this namespace, and waits for it with --wait-post-attach-job
+func (o *Options) runPostAttachJob(client crclient.Client) error {
+	name := fmt.Sprintf("%s-post-attach-%s", o.clusterName, time.Now().Format("20060102150405"))
+	job := helpers.NewAnsibleJob(o.clusterName, name, o.postAttachJob, o.towerSecret, map[string]interface{}{
+		"cluster_name": o.clusterName,
+	})
+	if err := client.Create(o.ctx, job); err != nil {
+		return err
+	}
+	if err := setAttachStep(o.ctx, client, o.clusterName, attachStepPostAttachJob); err != nil {
+		return err
+	}
+	if !o.applierScenariosOptions.Silent {
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "AnsibleJob %s/%s created for the job template %s\n", o.clusterName, name, o.postAttachJob)
+	}
+	if !o.waitPostAttachJob {
+		return nil
+	}
+	return helpers.WaitForAnsibleJob(o.ctx, client, o.clusterName, name, o.pollInterval, o.postAttachJobTimeout)
+}
@@ -0,0 +1,79 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_validatePostAttachJob(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       Options
+		wantErr bool
+	}{
+		{name: "Success, no job"},
+		{name: "Success", o: Options{postAttachJob: "onboard", towerSecret: "tower", waitPostAttachJob: true}},
+		{name: "Success, async", o: Options{postAttachJob: "onboard", towerSecret: "tower", async: true}},
+		{name: "Failed, no tower secret", o: Options{postAttachJob: "onboard"}, wantErr: true},
+		{name: "Failed, tower secret without job", o: Options{towerSecret: "tower"}, wantErr: true},
+		{name: "Failed, wait without job", o: Options{waitPostAttachJob: true}, wantErr: true},
+		{name: "Failed, manual import", o: Options{postAttachJob: "onboard", towerSecret: "tower", importFile: "import.yaml"}, wantErr: true},
+		{name: "Failed, wait and async", o: Options{postAttachJob: "onboard", towerSecret: "tower", waitPostAttachJob: true, async: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.o.applierScenariosOptions = &applierscenarios.ApplierScenariosOptions{}
+			if err := tt.o.validatePostAttachJob(); (err != nil) != tt.wantErr {
+				t.Errorf("validatePostAttachJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runPostAttachJob(t *testing.T) {
+	client := fake.NewClient(fake.NewManagedCluster("cluster1"), fake.NewManagedCluster("cluster2"))
+	o := newOptions(genericclioptions.NewTestIOStreamsDiscard())
+	o.clusterName = "cluster1"
+	o.postAttachJob = "onboard"
+	o.towerSecret = "tower"
+	if err := o.runPostAttachJob(client); err != nil {
+		t.Fatal(err)
+	}
+	jobs := &unstructured.UnstructuredList{}
+	jobs.SetGroupVersionKind(helpers.AnsibleJobGVK.GroupVersion().WithKind("AnsibleJobList"))
+	if err := client.List(context.TODO(), jobs); err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs.Items) != 1 {
+		t.Fatalf("1 AnsibleJob expected, got %d", len(jobs.Items))
+	}
+	job := jobs.Items[0]
+	if job.GetNamespace() != "cluster1" || !strings.HasPrefix(job.GetName(), "cluster1-post-attach-") {
+		t.Errorf("AnsibleJob %s/%s, expected in the namespace of the cluster", job.GetNamespace(), job.GetName())
+	}
+	if name, _, _ := unstructured.NestedString(job.Object, "spec", "extra_vars", "cluster_name"); name != "cluster1" {
+		t.Errorf("cluster_name = %s, want cluster1", name)
+	}
+	if step, _ := getAttachStep(context.TODO(), client, "cluster1"); step != attachStepPostAttachJob {
+		t.Errorf("getAttachStep() = %s, want %s so a resumed attach does not launch the job again", step, attachStepPostAttachJob)
+	}
+
+	//No tower runs the job
+	o.waitPostAttachJob = true
+	o.pollInterval = 10 * time.Millisecond
+	o.postAttachJobTimeout = 50 * time.Millisecond
+	o.clusterName = "cluster2"
+	if err := o.runPostAttachJob(client); err == nil || !strings.Contains(err.Error(), "did not complete") {
+		t.Errorf("runPostAttachJob() must time out, got %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/preflight"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/clientcmd"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// spokeTimeout bounds the queries to the cluster to import, so an unreachable cluster does not hang the attach
+const spokeTimeout = 10 * time.Second
+
+func (o *Options) preflightChecks(client crclient.Client) []preflight.Check {
+	checks := []preflight.Check{
+		preflight.NewCheck("ManagedCluster CRD", func() error {
+			return checkManagedClusterCRD(o.ctx, client)
+		}),
+		preflight.NewCheck("RBAC", func() error {
+			return checkRBAC(o.ctx, client, !o.existingNamespace)
+		}),
+		preflight.NewCheck("Cluster name", func() error {
+			//The similar names are checked first to suggest the existing cluster of a name in upper case
+			if !o.allowSimilarName {
+				if err := checkSimilarClusterName(o.ctx, client, o.clusterName); err != nil {
+					return err
+				}
+			}
+			return checkClusterName(o.ctx, client, o.clusterName)
+		}),
+	}
+	if hosting := hostingCluster(o.values); hosting != "" {
+		checks = append(checks, preflight.NewCheck("Hosting cluster", func() error {
+			return checkHostingCluster(o.ctx, client, hosting)
+		}))
+	}
+	if o.clusterKubeConfig != "" {
+		checks = append(checks, preflight.NewCheck("Spoke connectivity", func() error {
+			return checkSpoke(o.clusterKubeConfig)
+		}))
+	}
+	return checks
+}
+
+func (o *Options) preflight(client crclient.Client) error {
+	return preflight.ToError(preflight.Run(o.preflightChecks(client)))
+}
+
+func checkManagedClusterCRD(ctx context.Context, client crclient.Client) error {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(helpers.CustomResourceDefinitionGVK)
+	err := client.Get(ctx, types.NamespacedName{Name: helpers.ManagedClusterCRDName}, crd)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("CRD %s not found on the hub, is the hub installed?", helpers.ManagedClusterCRDName)
+	}
+	return err
+}
+
+func checkRBAC(ctx context.Context, client crclient.Client, createNamespace bool) error {
+	attributes := []authorizationv1.ResourceAttributes{
+		{
+			Verb:     "create",
+			Group:    helpers.ManagedClusterGVK.Group,
+			Resource: "managedclusters",
+		},
+	}
+	//The user attaching in a pre-created namespace is not required to create namespaces
+	if createNamespace {
+		attributes = append(attributes, authorizationv1.ResourceAttributes{
+			Verb:     "create",
+			Resource: "namespaces",
+		})
+	}
+	denied := make([]string, 0)
+	for i := range attributes {
+		ssar := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &attributes[i],
+			},
+		}
+		if err := client.Create(ctx, ssar); err != nil {
+			return err
+		}
+		if !ssar.Status.Allowed {
+			denied = append(denied, fmt.Sprintf("%s %s", attributes[i].Verb, attributes[i].Resource))
+		}
+	}
+	if len(denied) != 0 {
+		return fmt.Errorf("the user is not allowed to %s", strings.Join(denied, ", "))
+	}
+	return nil
+}
+
+func checkClusterName(ctx context.Context, client crclient.Client, clusterName string) error {
+	if errs := validation.IsDNS1123Label(clusterName); len(errs) != 0 {
+		return fmt.Errorf("%s is not a valid DNS-1123 label: %s", clusterName, strings.Join(errs, ", "))
+	}
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	err := client.Get(ctx, types.NamespacedName{Name: clusterName}, mc)
+	switch {
+	case err == nil && mc.GetAnnotations()[attachStepAnnotation] != "":
+		//The attach of the cluster was interrupted, it is resumed
+		return nil
+	case err == nil:
+		return fmt.Errorf("managed cluster %s already exists", clusterName)
+	case errors.IsNotFound(err):
+		return nil
+	default:
+		return err
+	}
+}
+
+// checkSimilarClusterName rejects a cluster name only differing by its case or one typo from an existing cluster,
+// which is most likely the existing cluster attached a second time under a mistyped name
+func checkSimilarClusterName(ctx context.Context, client crclient.Client, clusterName string) error {
+	mcs := &unstructured.UnstructuredList{}
+	mcs.SetGroupVersionKind(helpers.ManagedClusterGVK.GroupVersion().WithKind(helpers.ManagedClusterGVK.Kind + "List"))
+	if err := client.List(ctx, mcs); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(mcs.Items))
+	for _, mc := range mcs.Items {
+		names = append(names, mc.GetName())
+	}
+	if similar := helpers.SimilarClusterName(clusterName, names); similar != "" {
+		return fmt.Errorf("cluster name %s is similar to the existing cluster %s, did you mean %s? Set --allow-similar-name to attach a new cluster with this name",
+			clusterName, similar, similar)
+	}
+	return nil
+}
+
+// checkHostingCluster checks the cluster running the klusterlet agents of the Hosted mode is managed by the hub
+func checkHostingCluster(ctx context.Context, client crclient.Client, hosting string) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	err := client.Get(ctx, types.NamespacedName{Name: hosting}, mc)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("the hosting cluster %s is not a managed cluster of the hub, attach it first", hosting)
+	}
+	return err
+}
+
+func checkSpoke(kubeConfig string) error {
+	config, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeConfig))
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig: %s", err.Error())
+	}
+	config.Timeout = spokeTimeout
+	dc, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return err
+	}
+	info, err := dc.ServerVersion()
+	if err != nil {
+		return &cmderrors.SpokeUnreachableError{Host: config.Host, Err: err}
+	}
+	return helpers.CheckSpokeVersion(info.GitVersion)
+}
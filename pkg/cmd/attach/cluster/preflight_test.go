@@ -0,0 +1,147 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newUnstructured(gvkName string, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	switch gvkName {
+	case "crd":
+		u.SetGroupVersionKind(helpers.CustomResourceDefinitionGVK)
+	case "managedcluster":
+		u.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	}
+	u.SetName(name)
+	return u
+}
+
+func Test_checkManagedClusterCRD(t *testing.T) {
+	tests := []struct {
+		name    string
+		objs    []runtime.Object
+		wantErr bool
+	}{
+		{
+			name:    "Success, CRD exists",
+			objs:    []runtime.Object{newUnstructured("crd", helpers.ManagedClusterCRDName)},
+			wantErr: false,
+		},
+		{
+			name:    "Failed, CRD missing",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := crclientfake.NewFakeClient(tt.objs...)
+			if err := checkManagedClusterCRD(context.TODO(), client); (err != nil) != tt.wantErr {
+				t.Errorf("checkManagedClusterCRD() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_checkClusterName(t *testing.T) {
+	client := crclientfake.NewFakeClient(newUnstructured("managedcluster", "existing"))
+	tests := []struct {
+		name        string
+		clusterName string
+		wantErr     bool
+	}{
+		{
+			name:        "Success",
+			clusterName: "new-cluster",
+			wantErr:     false,
+		},
+		{
+			name:        "Failed, not a DNS-1123 label",
+			clusterName: "My_Cluster",
+			wantErr:     true,
+		},
+		{
+			name:        "Failed, already exists",
+			clusterName: "existing",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkClusterName(context.TODO(), client, tt.clusterName); (err != nil) != tt.wantErr {
+				t.Errorf("checkClusterName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_checkSimilarClusterName(t *testing.T) {
+	client := fake.NewClient(newUnstructured("managedcluster", "prod-eu1"))
+	tests := []struct {
+		name        string
+		clusterName string
+		wantErr     string
+	}{
+		{name: "Success, new cluster", clusterName: "prod-us2"},
+		{name: "Success, same series", clusterName: "prod-eu2"},
+		{name: "Success, existing cluster", clusterName: "prod-eu1"},
+		{name: "Failed, case", clusterName: "Prod-EU1", wantErr: "did you mean prod-eu1?"},
+		{name: "Failed, typo", clusterName: "prod-ue1", wantErr: "did you mean prod-eu1?"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSimilarClusterName(context.TODO(), client, tt.clusterName)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkSimilarClusterName() unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkSimilarClusterName() error containing %q expected, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func Test_checkHostingCluster(t *testing.T) {
+	client := crclientfake.NewFakeClient(newUnstructured("managedcluster", "hosting"))
+	if err := checkHostingCluster(context.TODO(), client, "hosting"); err != nil {
+		t.Error(err)
+	}
+	if err := checkHostingCluster(context.TODO(), client, "unknown"); err == nil {
+		t.Error("checkHostingCluster() expected an error for a cluster which is not managed")
+	}
+}
+
+func Test_checkSpoke(t *testing.T) {
+	if err := checkSpoke("not a kubeconfig"); err == nil {
+		t.Error("checkSpoke() expected an error for an invalid kubeconfig")
+	}
+}
+
+func TestOptions_preflight(t *testing.T) {
+	o := &Options{
+		clusterName:       "My_Cluster",
+		clusterKubeConfig: "not a kubeconfig",
+	}
+	var client crclient.Client = crclientfake.NewFakeClient()
+	err := o.preflight(client)
+	if err == nil {
+		t.Fatal("preflight() expected an error")
+	}
+	for _, want := range []string{"ManagedCluster CRD", "Cluster name", "Spoke connectivity"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("preflight() error must report %s, got %s", want, err.Error())
+		}
+	}
+}
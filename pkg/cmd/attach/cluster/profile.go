@@ -0,0 +1,80 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+)
+
+const (
+	// profileEdge keeps the application and policy addons with a small footprint,
+	// for the single-node OpenShift clusters
+	profileEdge = "edge"
+	// profileMinimal only installs the klusterlet, for the MicroShift clusters and the constrained devices
+	profileMinimal = "minimal"
+)
+
+// profiles are the values of the attach presets, they overwrite the values files and are overwritten by the flags
+var profiles = map[string]map[string]interface{}{
+	profileEdge: {
+		"addons": map[string]interface{}{
+			"applicationManager":   map[string]interface{}{"enabled": true},
+			"policyController":     map[string]interface{}{"enabled": true},
+			"searchCollector":      map[string]interface{}{"enabled": false},
+			"certPolicyController": map[string]interface{}{"enabled": false},
+			"iamPolicyController":  map[string]interface{}{"enabled": false},
+		},
+		"leaseDurationSeconds": int64(180),
+		"klusterlet": map[string]interface{}{
+			"resources": map[string]interface{}{
+				"requests": map[string]interface{}{"cpu": "10m", "memory": "64Mi"},
+			},
+		},
+	},
+	profileMinimal: {
+		"addons": map[string]interface{}{
+			"applicationManager":   map[string]interface{}{"enabled": false},
+			"policyController":     map[string]interface{}{"enabled": false},
+			"searchCollector":      map[string]interface{}{"enabled": false},
+			"certPolicyController": map[string]interface{}{"enabled": false},
+			"iamPolicyController":  map[string]interface{}{"enabled": false},
+		},
+		"leaseDurationSeconds": int64(300),
+		"klusterlet": map[string]interface{}{
+			"resources": map[string]interface{}{
+				"requests": map[string]interface{}{"cpu": "5m", "memory": "32Mi"},
+			},
+		},
+	},
+}
+
+// profileNames returns the sorted names of the profiles
+func profileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyProfile merges the values of the profile in the values, nothing is done without profile
+func applyProfile(values map[string]interface{}, profile string) error {
+	if profile == "" {
+		return nil
+	}
+	p, ok := profiles[profile]
+	if !ok {
+		return fmt.Errorf("unsupported profile %s, supported profiles are %s", profile, strings.Join(profileNames(), ", "))
+	}
+	//The profile is copied as the merge shares its maps with the values
+	overrides, err := copyValues(p)
+	if err != nil {
+		return err
+	}
+	applierscenarios.MergeValues(values, overrides)
+	return nil
+}
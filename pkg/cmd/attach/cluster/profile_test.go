@@ -0,0 +1,66 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func Test_applyProfile(t *testing.T) {
+	values := map[string]interface{}{
+		"addons": map[string]interface{}{
+			"searchCollector": map[string]interface{}{"enabled": true},
+		},
+		"leaseDurationSeconds": int64(60),
+	}
+	if err := applyProfile(values, profileMinimal); err != nil {
+		t.Fatal(err)
+	}
+	for _, addon := range []string{"applicationManager", "policyController", "searchCollector"} {
+		if applierscenarios.GetString(values, "addons."+addon+".enabled") != "false" {
+			t.Errorf("%s must be disabled by the minimal profile", addon)
+		}
+	}
+	if lease := applierscenarios.GetString(values, "leaseDurationSeconds"); lease != "300" {
+		t.Errorf("leaseDurationSeconds = %s, want 300", lease)
+	}
+
+	//The profile is not modified by the values
+	values["klusterlet"].(map[string]interface{})["resources"] = nil
+	if _, ok := profiles[profileMinimal]["klusterlet"].(map[string]interface{})["resources"].(map[string]interface{}); !ok {
+		t.Error("the profile must not share its maps with the values")
+	}
+
+	if err := applyProfile(map[string]interface{}{}, "tiny"); err == nil {
+		t.Error("error expected for an unknown profile")
+	}
+}
+
+func TestOptions_complete_profile(t *testing.T) {
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	o.applierScenariosOptions.ValuesPaths = []string{filepath.Join(attachClusterTestDir, "values-with-data.yaml")}
+	o.profile = profileEdge
+	//The flags win over the profile
+	if err := o.complete(newValuesCmd(t, "--klusterlet-memory-request", "128Mi", "--cluster-kubeconfig", ""), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"addons.policyController.enabled":      "true",
+		"addons.searchCollector.enabled":       "false",
+		"leaseDurationSeconds":                 "180",
+		"klusterlet.resources.requests.cpu":    "10m",
+		"klusterlet.resources.requests.memory": "128Mi",
+	}
+	for path, value := range want {
+		if got := applierscenarios.GetString(o.values, path); got != value {
+			t.Errorf("%s = %s, want %s", path, got, value)
+		}
+	}
+}
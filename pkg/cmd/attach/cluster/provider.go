@@ -0,0 +1,130 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/audit"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// newProviderValuesSchema returns the values schema of the cloud provider shortcuts,
+// the import credentials are generated and so can not be set by the user.
+// nameFlag is the flag of the managed cluster name as some shortcuts use --name for the provider cluster name.
+func newProviderValuesSchema(nameFlag string) applierscenarios.ValuesSchema {
+	schema := applierscenarios.ValuesSchema{}
+	for _, v := range valuesSchema {
+		switch v.Path {
+		case "server", "token", "kubeConfig":
+			continue
+		case "managedClusterName":
+			v.Flag = nameFlag
+		}
+		schema = append(schema, v)
+	}
+	return schema
+}
+
+// addProviderFlags adds the flags common to the cloud provider shortcuts
+func (o *Options) addProviderFlags(cmd *cobra.Command, schema applierscenarios.ValuesSchema) {
+	cmd.SetUsageTemplate(applierscenarios.UsageTempate(cmd, valuesTemplatePath))
+	schema.AddFlags(cmd.Flags())
+	cmd.Flags().BoolVar(&o.skipPreflight, "skip-preflight", false, "If set, the preflight checks on the hub will be skipped")
+	cmd.Flags().BoolVar(&o.async, "async", false, "If set, the attach returns immediately with an operation ID to follow with the status command")
+
+	o.applierScenariosOptions.AddFlags(cmd.Flags())
+	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
+}
+
+// completeProvider loads the values for a cloud provider shortcut, the values file is optional
+// and the default values are used for the values not provided.
+// The managed cluster name defaults to the cloud provider cluster name.
+func (o *Options) completeProvider(cmd *cobra.Command, schema applierscenarios.ValuesSchema, providerClusterName string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	o.values, err = o.applierScenariosOptions.ReadValuesWithDefaults(valuesTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	if applierscenarios.GetString(o.values, "managedClusterName") == "" {
+		o.values["managedClusterName"] = providerClusterName
+	}
+
+	//The import credentials are generated during the run
+	o.values["kubeConfig"] = ""
+	o.values["server"] = ""
+	o.values["token"] = ""
+
+	return o.completeValues(cmd, schema)
+}
+
+// attachWithServiceAccount creates a long-lived service account on the cluster to import
+// with the short-lived provider credentials of the config and attaches the cluster with its token
+func (o *Options) attachWithServiceAccount(config *rest.Config) error {
+	if err := o.useImportServiceAccount(config); err != nil {
+		return err
+	}
+	if err := o.validate(); err != nil {
+		return cmderrors.NewValidationError(err)
+	}
+	return o.run()
+}
+
+// useImportServiceAccount creates the import service account on the cluster of the config
+// and sets its server and token as the import credentials instead of the kubeConfig
+func (o *Options) useImportServiceAccount(config *rest.Config) error {
+	audit.Enable(config, "")
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	return o.mintImportToken(kubeClient, config.Host)
+}
+
+// mintTokenFromKubeConfig replaces the kubeConfig, whose client certificate or token may expire before
+// the import completes, by the token of the import service account created with it
+func (o *Options) mintTokenFromKubeConfig() error {
+	config, err := clientcmd.RESTConfigFromKubeConfig([]byte(o.clusterKubeConfig))
+	if err != nil {
+		return fmt.Errorf("invalid kubeConfig of cluster %s: %s", o.clusterName, err.Error())
+	}
+	return o.useImportServiceAccount(config)
+}
+
+func (o *Options) mintImportToken(kubeClient kubernetes.Interface, host string) error {
+	var token string
+	err := o.progressReporter().Step("service-account",
+		fmt.Sprintf("ServiceAccount/%s/%s", helpers.ImportServiceAccountNamespace, helpers.ImportServiceAccountName),
+		func() (err error) {
+			token, err = helpers.BootstrapImportServiceAccount(o.ctx, kubeClient)
+			if cmderrors.IsUnreachable(err) {
+				return &cmderrors.SpokeUnreachableError{Host: host, Err: err}
+			}
+			if err != nil {
+				return fmt.Errorf("unable to create the import service account on the cluster %s: %s", host, err.Error())
+			}
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	if !o.applierScenariosOptions.Silent {
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "Service account %s/%s created on %s for the import\n",
+			helpers.ImportServiceAccountNamespace, helpers.ImportServiceAccountName, host)
+	}
+
+	o.clusterServer = host
+	o.clusterToken = token
+	o.clusterKubeConfig = ""
+	o.values["server"] = o.clusterServer
+	o.values["token"] = o.clusterToken
+	o.values["kubeConfig"] = o.clusterKubeConfig
+	return nil
+}
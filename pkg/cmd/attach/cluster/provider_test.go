@@ -0,0 +1,64 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestOptions_mintImportToken(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	//Simulate the token controller
+	kubeClient.PrependReactor("create", "secrets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		s := action.(clienttesting.CreateAction).GetObject().(*corev1.Secret)
+		s.Data = map[string][]byte{corev1.ServiceAccountTokenKey: []byte("sa-token")}
+		return false, nil, nil
+	})
+	o := newOptions(genericclioptions.NewTestIOStreamsDiscard())
+	o.clusterKubeConfig = "kubeconfig-with-client-certificate"
+	o.values = map[string]interface{}{"kubeConfig": o.clusterKubeConfig, "server": "", "token": ""}
+	if err := o.mintImportToken(kubeClient, "https://spoke:6443"); err != nil {
+		t.Fatal(err)
+	}
+	if o.clusterKubeConfig != "" || o.values["kubeConfig"] != "" {
+		t.Error("the kubeConfig must be replaced by the token")
+	}
+	if o.values["server"] != "https://spoke:6443" || o.values["token"] != "sa-token" {
+		t.Errorf("unexpected import credentials %v", o.values)
+	}
+}
+
+func TestOptions_validateMintToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       Options
+		wantErr bool
+	}{
+		{name: "Success, not set", o: Options{}},
+		{name: "Success", o: Options{mintToken: true, clusterKubeConfig: "kubeconfig"}},
+		{name: "Failed, no kubeConfig", o: Options{mintToken: true, clusterToken: "token", clusterServer: "server"}, wantErr: true},
+		{name: "Failed, import file", o: Options{mintToken: true, clusterKubeConfig: "kubeconfig", importFile: "import.yaml"}, wantErr: true},
+		{name: "Failed, hive adopt", o: Options{mintToken: true, clusterKubeConfig: "kubeconfig", hiveAdopt: true}, wantErr: true},
+		{
+			name: "Failed, hosted",
+			o: Options{mintToken: true, clusterKubeConfig: "kubeconfig", values: map[string]interface{}{
+				"klusterlet": map[string]interface{}{"mode": klusterletModeHosted, "hostingClusterName": "hosting"},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.o.applierScenariosOptions = &applierscenarios.ApplierScenariosOptions{}
+			if err := tt.o.validateMintToken(); (err != nil) != tt.wantErr {
+				t.Errorf("validateMintToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
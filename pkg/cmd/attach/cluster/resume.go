@@ -0,0 +1,94 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// attachStepAnnotation records on the ManagedCluster the last step done by an attach in progress,
+	// it is removed once the attach completes so a ManagedCluster with the annotation is a partial attach to resume
+	attachStepAnnotation = "cm-cli.open-cluster-management.io/attach-step"
+	// attachStepApplied is recorded once the hub resources are applied. A resumed attach applies them again,
+	// the apply is idempotent and takes the corrected values, then continues with the curation and the import.
+	attachStepApplied = "applied"
+	// attachStepPostAttachJob is recorded once the AnsibleJob of the post-attach job is created,
+	// a resumed attach does not launch the job a second time
+	attachStepPostAttachJob = "post-attach-job"
+	// attachStepImportSecret is recorded once the import controller generated the import secret,
+	// a resumed attach reads it without waiting for it and writes the import files
+	attachStepImportSecret = "import-secret"
+)
+
+// attachSteps are the steps in their order, the post-attach job and the manual import are exclusive
+var attachSteps = []string{"", attachStepApplied, attachStepPostAttachJob, attachStepImportSecret}
+
+// attachStepDone tells if the step was done by the interrupted attach which recorded the last step
+func attachStepDone(last, step string) bool {
+	return attachStepIndex(last) >= attachStepIndex(step)
+}
+
+// laterAttachStep returns the later of the two steps, the recorded step never goes back
+// when a resumed attach redoes a step
+func laterAttachStep(last, step string) string {
+	if attachStepDone(last, step) {
+		return last
+	}
+	return step
+}
+
+func attachStepIndex(step string) int {
+	for i, s := range attachSteps {
+		if s == step {
+			return i
+		}
+	}
+	return 0
+}
+
+// getAttachStep returns the step recorded on the ManagedCluster by an interrupted attach,
+// "" if the cluster does not exist or its attach completed
+func getAttachStep(ctx context.Context, client crclient.Client, clusterName string) (string, error) {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	err := client.Get(ctx, types.NamespacedName{Name: clusterName}, mc)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return mc.GetAnnotations()[attachStepAnnotation], nil
+}
+
+// setAttachStep records the step on the ManagedCluster, "" removes it once the attach completes.
+// The ManagedCluster is patched as the hub controllers update it at the same time.
+func setAttachStep(ctx context.Context, client crclient.Client, clusterName, step string) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(ctx, types.NamespacedName{Name: clusterName}, mc); err != nil {
+		return err
+	}
+	annotations := mc.GetAnnotations()
+	if annotations[attachStepAnnotation] == step {
+		return nil
+	}
+	patch := crclient.MergeFrom(mc.DeepCopy())
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if step == "" {
+		delete(annotations, attachStepAnnotation)
+	} else {
+		annotations[attachStepAnnotation] = step
+	}
+	mc.SetAnnotations(annotations)
+	return client.Patch(ctx, mc, patch)
+}
@@ -0,0 +1,123 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newInterruptedManagedCluster(name string) runtime.Object {
+	mc := newUnstructured("managedcluster", name)
+	mc.SetAnnotations(map[string]string{attachStepAnnotation: attachStepApplied})
+	return mc
+}
+
+func Test_attachStep(t *testing.T) {
+	client := crclientfake.NewFakeClient(newUnstructured("managedcluster", "cluster1"))
+	step, err := getAttachStep(context.TODO(), client, "cluster1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if step != "" {
+		t.Errorf("getAttachStep() = %s, want no step", step)
+	}
+	if err := setAttachStep(context.TODO(), client, "cluster1", attachStepApplied); err != nil {
+		t.Fatal(err)
+	}
+	if step, _ := getAttachStep(context.TODO(), client, "cluster1"); step != attachStepApplied {
+		t.Errorf("getAttachStep() = %s, want %s", step, attachStepApplied)
+	}
+	if err := checkClusterName(context.TODO(), client, "cluster1"); err != nil {
+		t.Errorf("checkClusterName() must accept an interrupted attach, got %v", err)
+	}
+	if err := setAttachStep(context.TODO(), client, "cluster1", ""); err != nil {
+		t.Fatal(err)
+	}
+	if step, _ := getAttachStep(context.TODO(), client, "cluster1"); step != "" {
+		t.Errorf("getAttachStep() = %s, want the step removed", step)
+	}
+	if err := checkClusterName(context.TODO(), client, "cluster1"); err == nil {
+		t.Error("checkClusterName() must fail once the attach completed")
+	}
+	if step, err := getAttachStep(context.TODO(), client, "missing"); err != nil || step != "" {
+		t.Errorf("getAttachStep() = %s, %v, want no step for a missing cluster", step, err)
+	}
+	if err := setAttachStep(context.TODO(), client, "missing", attachStepApplied); err == nil {
+		t.Error("setAttachStep() expected an error for a missing cluster")
+	}
+}
+
+func Test_laterAttachStep(t *testing.T) {
+	tests := []struct {
+		last string
+		step string
+		want string
+	}{
+		{last: "", step: attachStepApplied, want: attachStepApplied},
+		{last: attachStepApplied, step: attachStepImportSecret, want: attachStepImportSecret},
+		{last: attachStepImportSecret, step: attachStepApplied, want: attachStepImportSecret},
+		{last: attachStepPostAttachJob, step: attachStepApplied, want: attachStepPostAttachJob},
+	}
+	for _, tt := range tests {
+		if got := laterAttachStep(tt.last, tt.step); got != tt.want {
+			t.Errorf("laterAttachStep(%q, %q) = %s, want %s", tt.last, tt.step, got, tt.want)
+		}
+	}
+	if attachStepDone(attachStepApplied, attachStepImportSecret) {
+		t.Error("the import secret step is not done when the last step is the apply")
+	}
+}
+
+func TestOptions_runWithClient_resume(t *testing.T) {
+	importSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-import",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			"crds.yaml":   []byte(testCRDs),
+			"import.yaml": []byte(testImport),
+		},
+	}
+	client := crclientfake.NewFakeClient(&importSecret, newInterruptedManagedCluster("test"))
+	values, err := appliercmd.ConvertValuesFileToValuesMap(filepath.Join(attachClusterTestDir, "values-with-data.yaml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			Timeout:   time.Second,
+			IOStreams: streams,
+		},
+		values:      values,
+		clusterName: "test",
+		importFile:  filepath.Join(t.TempDir(), "import.yaml"),
+		ctx:         context.Background(),
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	//The hub resources are applied again so the corrected values are taken
+	ns := &corev1.Namespace{}
+	if err := client.Get(context.TODO(), crclient.ObjectKey{Name: "test"}, ns); err != nil {
+		t.Errorf("the hub resources must be applied again, got %v", err)
+	}
+	if step, _ := getAttachStep(context.TODO(), client, "test"); step != "" {
+		t.Errorf("the step must be removed once the attach completes, got %s", step)
+	}
+	if errOut.Len() == 0 {
+		t.Error("the resumed attach must be reported")
+	}
+}
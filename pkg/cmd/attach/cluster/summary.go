@@ -0,0 +1,111 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"github.com/open-cluster-management/applier/pkg/templateprocessor"
+)
+
+// attachSummary is the outcome of a successful attach, printed once the attach completes
+// and emitted as the summary event with --progress-format json
+type attachSummary struct {
+	Cluster string `json:"cluster"`
+	//Resources are the resources applied on the hub
+	Resources       []string `json:"resources"`
+	ImportFile      string   `json:"importFile,omitempty"`
+	ImportOutputDir string   `json:"importOutputDir,omitempty"`
+	Bundle          string   `json:"bundle,omitempty"`
+	//Commands are the commands to run on the managed cluster for a manual import
+	Commands []string `json:"commands,omitempty"`
+	//NextSteps are the commands following the import of the cluster from the hub
+	NextSteps []string `json:"nextSteps"`
+}
+
+// newAttachSummary returns the summary of the attach, with the hub resources rendered from the values
+func (o *Options) newAttachSummary(reader templateprocessor.TemplateReader) (*attachSummary, error) {
+	manifests, err := renderHub(reader, o.values)
+	if err != nil {
+		return nil, err
+	}
+	s := &attachSummary{
+		Cluster:   o.clusterName,
+		Resources: make([]string, 0, len(manifests)),
+	}
+	for _, m := range manifests {
+		resource := m.GetKind() + "/" + m.GetName()
+		if m.GetNamespace() != "" {
+			resource = m.GetKind() + "/" + m.GetNamespace() + "/" + m.GetName()
+		}
+		s.Resources = append(s.Resources, resource)
+	}
+	if !o.manualImport() || o.clusterName == localClusterName {
+		s.NextSteps = o.nextSteps()
+		return s, nil
+	}
+
+	s.Bundle = o.bundleFile
+	s.ImportOutputDir = o.importOutputDir
+	s.Commands = make([]string, 0)
+	if o.bundleFile != "" {
+		s.Commands = append(s.Commands, fmt.Sprintf("tar -xzf %s", filepath.Base(o.bundleFile)))
+	}
+	if o.importOutputDir != "" {
+		crds := filepath.Join(o.importOutputDir, bundleCRDsFile)
+		s.Commands = append(s.Commands,
+			fmt.Sprintf("kubectl apply -f %s", crds),
+			fmt.Sprintf("kubectl wait --for=condition=established --timeout=%s -f %s", crdWaitTimeout(o.distribution()), crds),
+			fmt.Sprintf("kubectl apply -f %s", filepath.Join(o.importOutputDir, bundleImportFile)))
+	}
+	//The import manifests written on the standard output are applied by the caller
+	if o.importFile != "" && o.importFile != importFileStdout {
+		s.ImportFile = o.importFile
+		s.Commands = append(s.Commands, fmt.Sprintf("%s applier -d %s", helpers.GetExampleHeader(), o.importFile))
+	}
+	s.NextSteps = o.nextSteps()
+	return s, nil
+}
+
+// nextSteps returns the commands to follow the import and inspect the cluster once it joined
+func (o *Options) nextSteps() []string {
+	return []string{
+		fmt.Sprintf("%s describe cluster %s", helpers.GetExampleHeader(), o.clusterName),
+		fmt.Sprintf("%s get nodes --cluster %s", helpers.GetExampleHeader(), o.clusterName),
+	}
+}
+
+// print writes the summary in a human readable form
+func (s *attachSummary) print(w io.Writer) {
+	w = printers.Messages(w)
+	fmt.Fprintf(w, "Cluster %s attached\n", s.Cluster)
+	if len(s.Resources) != 0 {
+		fmt.Fprintln(w, "Resources applied on the hub:")
+		for _, r := range s.Resources {
+			fmt.Fprintf(w, "  %s\n", r)
+		}
+	}
+	if s.Bundle != "" {
+		fmt.Fprintf(w, "Import bundle: %s, extract it on the managed cluster network and follow the README\n", s.Bundle)
+	}
+	if s.ImportOutputDir != "" {
+		fmt.Fprintf(w, "Import manifests: %s\n", s.ImportOutputDir)
+	}
+	if s.ImportFile != "" {
+		fmt.Fprintf(w, "Import file: %s\n", s.ImportFile)
+	}
+	if len(s.Commands) != 0 {
+		fmt.Fprintln(w, "Execute these commands on the managed cluster:")
+		for _, c := range s.Commands {
+			fmt.Fprintf(w, "  %s\n", c)
+		}
+	}
+	fmt.Fprintln(w, "Next steps:")
+	for _, c := range s.NextSteps {
+		fmt.Fprintf(w, "  %s\n", c)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/progress"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newSummaryOptions(t *testing.T, importFile string) (*Options, *bytes.Buffer) {
+	values, err := appliercmd.ConvertValuesFileToValuesMap(filepath.Join(attachClusterTestDir, "values-with-data.yaml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			Timeout:   time.Second,
+			IOStreams: streams,
+		},
+		values:      values,
+		clusterName: "test",
+		importFile:  importFile,
+		ctx:         context.Background(),
+	}
+	return o, errOut
+}
+
+func newSummaryImportSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-import", Namespace: "test"},
+		Data: map[string][]byte{
+			"crds.yaml":   []byte(testCRDs),
+			"import.yaml": []byte(testImport),
+		},
+	}
+}
+
+func TestOptions_runWithClient_summary(t *testing.T) {
+	tests := []struct {
+		name       string
+		importFile bool
+		contains   []string
+		excludes   []string
+	}{
+		{
+			name:     "Success",
+			contains: []string{"Cluster test attached", "Resources applied on the hub:", "ManagedCluster/test", "Next steps:", "describe cluster test", "get nodes --cluster test"},
+			excludes: []string{"Execute these commands"},
+		},
+		{
+			name:       "Success, manual import",
+			importFile: true,
+			contains:   []string{"Import file: ", "Execute these commands on the managed cluster:", "applier -d ", "describe cluster test"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importFile := ""
+			if tt.importFile {
+				importFile = filepath.Join(t.TempDir(), "import.yaml")
+			}
+			o, errOut := newSummaryOptions(t, importFile)
+			if err := o.runWithClient(crclientfake.NewFakeClient(newSummaryImportSecret())); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range append(tt.contains, importFile) {
+				if !strings.Contains(errOut.String(), c) {
+					t.Errorf("summary must contain %q, got:\n%s", c, errOut.String())
+				}
+			}
+			for _, c := range tt.excludes {
+				if strings.Contains(errOut.String(), c) {
+					t.Errorf("summary must not contain %q, got:\n%s", c, errOut.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient_summaryJSON(t *testing.T) {
+	importFile := filepath.Join(t.TempDir(), "import.yaml")
+	o, errOut := newSummaryOptions(t, importFile)
+	o.applierScenariosOptions.Silent = true
+	events := &bytes.Buffer{}
+	o.progress = progress.NewReporter(progress.FormatJSON, events)
+	if err := o.runWithClient(crclientfake.NewFakeClient(newSummaryImportSecret())); err != nil {
+		t.Fatal(err)
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("the summary must only be emitted as an event, got:\n%s", errOut.String())
+	}
+	var summary *attachSummary
+	scanner := bufio.NewScanner(events)
+	for scanner.Scan() {
+		e := struct {
+			Phase   string         `json:"phase"`
+			Summary *attachSummary `json:"summary"`
+		}{}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+		if e.Phase == "summary" {
+			summary = e.Summary
+		}
+	}
+	if summary == nil {
+		t.Fatalf("no summary event, got:\n%s", events.String())
+	}
+	if summary.Cluster != "test" || summary.ImportFile != importFile || len(summary.Commands) != 1 || len(summary.NextSteps) != 2 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	found := false
+	for _, r := range summary.Resources {
+		found = found || r == "ManagedCluster/test"
+	}
+	if !found {
+		t.Errorf("the ManagedCluster must be in the resources, got %v", summary.Resources)
+	}
+}
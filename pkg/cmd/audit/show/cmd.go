@@ -0,0 +1,52 @@
+// Copyright Contributors to the Open Cluster Management project
+package show
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Show the mutating actions of the last 24 hours
+%[1]s audit show --since 24h
+
+# Show the last 20 mutating actions as json
+%[1]s audit show --tail 20 -o json
+`
+
+// NewCmd provides a cobra command showing the audit log of the mutating actions
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "show",
+		Short:        "Show the mutating actions recorded in the audit log",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.file, "file", "", "The audit log to read, the sink of the configuration by default")
+	helpers.DurationVar(cmd.Flags(), &o.since, "since", 0, "Only show the actions more recent than this duration, e.g. 24h")
+	cmd.Flags().IntVar(&o.tail, "tail", 0, "Only show the last actions, all of them if 0")
+	o.printOptions.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,85 @@
+// Copyright Contributors to the Open Cluster Management project
+package show
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/audit"
+	"github.com/open-cluster-management/cm-cli/pkg/config"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	if o.file != "" {
+		return nil
+	}
+	path, err := config.DefaultPath()
+	if err != nil {
+		return err
+	}
+	c, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	//The log written before the audit was disabled can still be shown
+	c.Audit.Disabled = false
+	sink, err := audit.NewSink(c.Audit)
+	if err != nil {
+		return err
+	}
+	fileSink, ok := sink.(*audit.FileSink)
+	if !ok {
+		return fmt.Errorf("the audit entries are posted to %s, only a file sink can be shown", c.Audit.Sink)
+	}
+	o.file = fileSink.Path
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.since < 0 {
+		return fmt.Errorf("since must be positive")
+	}
+	if o.tail < 0 {
+		return fmt.Errorf("tail must be positive")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	entries, err := audit.ReadFile(o.file)
+	if os.IsNotExist(err) {
+		entries = []audit.Entry{}
+	} else if err != nil {
+		return err
+	}
+
+	if o.since > 0 {
+		after := o.now().Add(-o.since)
+		kept := make([]audit.Entry, 0, len(entries))
+		for _, e := range entries {
+			if e.Time.After(after) {
+				kept = append(kept, e)
+			}
+		}
+		entries = kept
+	}
+	if o.tail > 0 && len(entries) > o.tail {
+		entries = entries[len(entries)-o.tail:]
+	}
+
+	table := &printers.Table{
+		Headers: []string{"TIME", "COMMAND", "VERB", "RESOURCE", "HUB", "USER", "RESULT"},
+	}
+	for _, e := range entries {
+		verb := e.Verb
+		if e.DryRun {
+			verb += " (dry run)"
+		}
+		table.AddRow(e.Time.Local().Format(time.RFC3339), e.Command, verb, e.Resource, e.Hub, e.User, e.Result)
+	}
+	return o.printOptions.Print(o.Out, table, entries)
+}
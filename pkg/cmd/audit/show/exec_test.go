@@ -0,0 +1,87 @@
+// Copyright Contributors to the Open Cluster Management project
+package show
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/audit"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_run(t *testing.T) {
+	now := time.Date(2021, 4, 1, 10, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink := &audit.FileSink{Path: path}
+	for i, resource := range []string{"managedclusters/old", "managedclusters/recent", "managedclusters/last"} {
+		e := audit.Entry{
+			Time:     now.Add(time.Duration(i-2) * time.Hour),
+			Command:  "attach cluster",
+			Verb:     "create",
+			Resource: resource,
+			Result:   "201 Created",
+		}
+		if err := sink.Write(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		file        string
+		since       time.Duration
+		tail        int
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "All",
+			file:     path,
+			contains: []string{"managedclusters/old", "managedclusters/recent", "managedclusters/last", "201 Created"},
+		},
+		{
+			name:        "Since",
+			file:        path,
+			since:       90 * time.Minute,
+			contains:    []string{"managedclusters/recent", "managedclusters/last"},
+			notContains: []string{"managedclusters/old"},
+		},
+		{
+			name:        "Tail",
+			file:        path,
+			tail:        1,
+			contains:    []string{"managedclusters/last"},
+			notContains: []string{"managedclusters/old", "managedclusters/recent"},
+		},
+		{
+			name:     "No audit log yet",
+			file:     filepath.Join(t.TempDir(), "missing.log"),
+			contains: []string{"RESOURCE"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.file = tt.file
+			o.since = tt.since
+			o.tail = tt.tail
+			o.now = func() time.Time { return now }
+			if err := o.run(); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %s, got:\n%s", c, out.String())
+				}
+			}
+			for _, c := range tt.notContains {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %s, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright Contributors to the Open Cluster Management project
+package show
+
+import (
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	printOptions *printers.PrintOptions
+	file         string
+	since        time.Duration
+	tail         int
+	now          func() time.Time
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		printOptions: printers.NewPrintOptions(),
+		now:          time.Now,
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright Contributors to the Open Cluster Management project
+package hub
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// hubNamespaces are the namespaces of the hub controllers deployed by the cluster-manager,
+// the open-cluster-management and the multicluster engine installers
+var hubNamespaces = []string{"open-cluster-management-hub", "open-cluster-management", "multicluster-engine"}
+
+// hubComponents are the hub controllers and the name of their deployment,
+// the deployments named after it with a suffix such as -v2 are also matched
+var hubComponents = []struct {
+	name       string
+	deployment string
+}{
+	{name: "Registration controller", deployment: "cluster-manager-registration-controller"},
+	{name: "Import controller", deployment: "managedcluster-import-controller"},
+	{name: "Work webhook", deployment: "cluster-manager-work-webhook"},
+	{name: "Placement controller", deployment: "cluster-manager-placement-controller"},
+	{name: "Addon manager", deployment: "cluster-manager-addon-manager-controller"},
+}
+
+// hubCRDs are the CRDs of the hub and the version cm-cli expects them to serve
+var hubCRDs = []struct {
+	name    string
+	version string
+}{
+	{name: helpers.ManagedClusterCRDName, version: helpers.ManagedClusterGVK.Version},
+	{name: "managedclustersets.cluster.open-cluster-management.io", version: helpers.ManagedClusterSetGVK.Version},
+	{name: "placementdecisions.cluster.open-cluster-management.io", version: helpers.PlacementDecisionGVK.Version},
+	{name: "manifestworks.work.open-cluster-management.io", version: helpers.ManifestWorkGVK.Version},
+	{name: "managedclusteraddons.addon.open-cluster-management.io", version: helpers.ManagedClusterAddOnGVK.Version},
+}
+
+// checkComponent checks the deployment of a hub controller exists and all its replicas are available
+func checkComponent(ctx context.Context, client crclient.Client, deployment string) error {
+	found := make([]string, 0)
+	problems := make([]string, 0)
+	for _, ns := range hubNamespaces {
+		deployments := &appsv1.DeploymentList{}
+		if err := client.List(ctx, deployments, crclient.InNamespace(ns)); err != nil {
+			return err
+		}
+		for _, d := range deployments.Items {
+			if d.Name != deployment && !strings.HasPrefix(d.Name, deployment+"-") {
+				continue
+			}
+			found = append(found, d.Namespace+"/"+d.Name)
+			replicas := int32(1)
+			if d.Spec.Replicas != nil {
+				replicas = *d.Spec.Replicas
+			}
+			if d.Status.AvailableReplicas < replicas {
+				problems = append(problems, fmt.Sprintf("deployment %s/%s has %d of %d replicas available",
+					d.Namespace, d.Name, d.Status.AvailableReplicas, replicas))
+			}
+		}
+	}
+	if len(found) == 0 {
+		return fmt.Errorf("deployment %s not found in %s", deployment, strings.Join(hubNamespaces, ", "))
+	}
+	if len(problems) != 0 {
+		return fmt.Errorf("%s", strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// checkCRDs checks the hub CRDs are established and serve the expected versions
+func checkCRDs(ctx context.Context, client crclient.Client) error {
+	problems := make([]string, 0)
+	for _, c := range hubCRDs {
+		crd := &unstructured.Unstructured{}
+		crd.SetGroupVersionKind(helpers.CustomResourceDefinitionGVK)
+		err := client.Get(ctx, types.NamespacedName{Name: c.name}, crd)
+		if errors.IsNotFound(err) {
+			problems = append(problems, fmt.Sprintf("CRD %s not found", c.name))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+		if helpers.GetConditionStatus(conditions, "Established") != "True" {
+			problems = append(problems, fmt.Sprintf("CRD %s is not established", c.name))
+			continue
+		}
+		if !servesVersion(crd, c.version) {
+			problems = append(problems, fmt.Sprintf("CRD %s does not serve %s", c.name, c.version))
+		}
+	}
+	if len(problems) != 0 {
+		return fmt.Errorf("%s", strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+func servesVersion(crd *unstructured.Unstructured, version string) bool {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(m, "name")
+		served, _, _ := unstructured.NestedBool(m, "served")
+		if name == version && served {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCertificates checks the certificates of the secrets of the hub namespaces,
+// the webhook serving certificates and the signer of the registration, do not expire within the threshold
+func checkCertificates(ctx context.Context, client crclient.Client, now time.Time, threshold time.Duration) error {
+	problems := make([]string, 0)
+	for _, ns := range hubNamespaces {
+		secrets := &corev1.SecretList{}
+		if err := client.List(ctx, secrets, crclient.InNamespace(ns)); err != nil {
+			return err
+		}
+		for _, s := range secrets.Items {
+			data, ok := s.Data[corev1.TLSCertKey]
+			if !ok {
+				continue
+			}
+			notAfter, err := firstExpiry(data)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("secret %s/%s: %s", s.Namespace, s.Name, err.Error()))
+				continue
+			}
+			switch {
+			case !now.Before(notAfter):
+				problems = append(problems, fmt.Sprintf("secret %s/%s expired on %s", s.Namespace, s.Name, notAfter.UTC().Format(time.RFC3339)))
+			case notAfter.Sub(now) < threshold:
+				problems = append(problems, fmt.Sprintf("secret %s/%s expires on %s", s.Namespace, s.Name, notAfter.UTC().Format(time.RFC3339)))
+			}
+		}
+	}
+	if len(problems) != 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%s", strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// firstExpiry returns the earliest expiry of the PEM encoded certificates
+func firstExpiry(data []byte) (time.Time, error) {
+	var notAfter time.Time
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return notAfter, fmt.Errorf("invalid certificate: %s", err.Error())
+		}
+		if notAfter.IsZero() || cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+	if notAfter.IsZero() {
+		return notAfter, fmt.Errorf("no certificate found")
+	}
+	return notAfter, nil
+}
@@ -0,0 +1,54 @@
+// Copyright Contributors to the Open Cluster Management project
+package hub
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Check the health of the hub of the current kubeconfig
+%[1]s check hub
+
+# Check the hub from a monitoring job, the certificates expiring within 60 days are reported
+%[1]s check hub --cert-expiry-threshold 1440h -o json
+`
+
+// NewCmd provides a cobra command checking the health of the hub
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "hub",
+		Short: "Check the health of the hub",
+		Long: "Check the hub controllers are available, the hub CRDs serve the expected versions " +
+			"and the certificates of the hub are not about to expire, the command fails if a check fails.",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	helpers.DurationVar(cmd.Flags(), &o.certExpiryThreshold, "cert-expiry-threshold", 30*24*time.Hour, "The certificates expiring within this duration fail the check, e.g. 720h")
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,86 @@
+// Copyright Contributors to the Open Cluster Management project
+package hub
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/preflight"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+// checkResult is the outcome of a check as printed in json and yaml
+type checkResult struct {
+	Check   string `json:"check"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.certExpiryThreshold < 0 {
+		return fmt.Errorf("the certificate expiry threshold must not be negative")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	results := preflight.Run(o.checks(client))
+	table := &printers.Table{
+		Headers: []string{"CHECK", "RESULT", "MESSAGE"},
+	}
+	checkResults := make([]checkResult, 0, len(results))
+	for _, r := range results {
+		result := checkResult{Check: r.Name, Passed: r.Err == nil}
+		status := "Passed"
+		if r.Err != nil {
+			result.Message = r.Err.Error()
+			status = "Failed"
+		}
+		checkResults = append(checkResults, result)
+		table.AddRow(result.Check, status, result.Message)
+	}
+	if err := o.printOptions.Print(o.Out, table, checkResults); err != nil {
+		return err
+	}
+	if failed := preflight.Failed(results); len(failed) != 0 {
+		return fmt.Errorf("%d of %d checks failed", len(failed), len(results))
+	}
+	return nil
+}
+
+// checks returns the health checks of the hub, one per controller then the CRDs and the certificates
+func (o *Options) checks(client crclient.Client) []preflight.Check {
+	checks := make([]preflight.Check, 0, len(hubComponents)+2)
+	for _, c := range hubComponents {
+		deployment := c.deployment
+		checks = append(checks, preflight.NewCheck(c.name, func() error {
+			return checkComponent(o.ctx, client, deployment)
+		}))
+	}
+	return append(checks,
+		preflight.NewCheck("CRDs", func() error {
+			return checkCRDs(o.ctx, client)
+		}),
+		preflight.NewCheck("Certificates", func() error {
+			return checkCertificates(o.ctx, client, o.now(), o.certExpiryThreshold)
+		}),
+	)
+}
@@ -0,0 +1,175 @@
+// Copyright Contributors to the Open Cluster Management project
+package hub
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var now = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newDeployment(namespace, name string, available int32) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{AvailableReplicas: available},
+	}
+}
+
+func newCRD(name, version string) *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(helpers.CustomResourceDefinitionGVK)
+	crd.SetName(name)
+	unstructured.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"name": version, "served": true},
+	}, "spec", "versions")
+	unstructured.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"type": "Established", "status": "True"},
+	}, "status", "conditions")
+	return crd
+}
+
+func newCertSecret(t *testing.T, namespace, name string, notAfter time.Time) *corev1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    now.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		},
+	}
+}
+
+// healthyHub returns the objects of a hub passing all the checks, but the excluded ones
+func healthyHub(t *testing.T, excluded ...string) []runtime.Object {
+	objs := make([]runtime.Object, 0)
+	for _, c := range hubComponents {
+		objs = append(objs, newDeployment("open-cluster-management-hub", c.deployment, 1))
+	}
+	for _, c := range hubCRDs {
+		objs = append(objs, newCRD(c.name, c.version))
+	}
+	objs = append(objs, newCertSecret(t, "open-cluster-management-hub", "registration-webhook-serving-cert", now.Add(365*24*time.Hour)))
+	kept := make([]runtime.Object, 0, len(objs))
+	for _, o := range objs {
+		name := o.(metav1.Object).GetName()
+		exclude := false
+		for _, e := range excluded {
+			exclude = exclude || name == e
+		}
+		if !exclude {
+			kept = append(kept, o)
+		}
+	}
+	return kept
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		objs       []runtime.Object
+		wantErr    bool
+		wantFailed map[string]string
+	}{
+		{
+			name: "Success",
+			objs: healthyHub(t),
+		},
+		{
+			name: "Success, import controller v2 of the multicluster engine",
+			objs: append(healthyHub(t, "managedcluster-import-controller"),
+				newDeployment("multicluster-engine", "managedcluster-import-controller-v2", 1)),
+		},
+		{
+			name: "Failed, unavailable controller, missing webhook and CRD",
+			objs: append(healthyHub(t, "cluster-manager-registration-controller", "cluster-manager-work-webhook", helpers.ManagedClusterCRDName),
+				newDeployment("open-cluster-management-hub", "cluster-manager-registration-controller", 0)),
+			wantErr: true,
+			wantFailed: map[string]string{
+				"Registration controller": "has 0 of 1 replicas available",
+				"Work webhook":            "deployment cluster-manager-work-webhook not found",
+				"CRDs":                    "CRD managedclusters.cluster.open-cluster-management.io not found",
+			},
+		},
+		{
+			name:    "Failed, CRD version not served",
+			objs:    append(healthyHub(t, helpers.ManagedClusterCRDName), newCRD(helpers.ManagedClusterCRDName, "v1beta1")),
+			wantErr: true,
+			wantFailed: map[string]string{
+				"CRDs": "does not serve v1",
+			},
+		},
+		{
+			name: "Failed, certificates near expiry",
+			objs: append(healthyHub(t),
+				newCertSecret(t, "open-cluster-management-hub", "work-webhook-serving-cert", now.Add(24*time.Hour)),
+				newCertSecret(t, "open-cluster-management", "signer-secret", now.Add(-time.Hour))),
+			wantErr: true,
+			wantFailed: map[string]string{
+				"Certificates": "secret open-cluster-management-hub/work-webhook-serving-cert expires on 2021-06-02T12:00:00Z",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(tt.objs...)
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.certExpiryThreshold = 30 * 24 * time.Hour
+			o.now = func() time.Time { return now }
+			o.printOptions.OutputFormat = "json"
+			if err := o.runWithClient(client); (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v\n%s", err, tt.wantErr, out.String())
+			}
+			results := []checkResult{}
+			if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != len(hubComponents)+2 {
+				t.Errorf("got %d results, want %d", len(results), len(hubComponents)+2)
+			}
+			for _, r := range results {
+				want, failed := tt.wantFailed[r.Check]
+				if failed == r.Passed {
+					t.Errorf("check %s passed %v: %s", r.Check, r.Passed, r.Message)
+				}
+				if failed && !strings.Contains(r.Message, want) {
+					t.Errorf("check %s message must contain %q, got %s", r.Check, want, r.Message)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright Contributors to the Open Cluster Management project
+package hub
+
+import (
+	"context"
+
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags         *genericclioptions.ConfigFlags
+	printOptions        *printers.PrintOptions
+	certExpiryThreshold time.Duration
+	//now is the time the certificate expiries are compared to
+	now func() time.Time
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		printOptions: printers.NewPrintOptions(),
+		now:          time.Now,
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
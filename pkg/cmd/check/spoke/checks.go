@@ -0,0 +1,219 @@
+// Copyright Contributors to the Open Cluster Management project
+package spoke
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	//defaultProbeImage contains the curl used to reach the hub from the cluster
+	defaultProbeImage = "registry.access.redhat.com/ubi8/ubi-minimal:latest"
+	//klusterletGroupVersion serves the Klusterlet CRD once a klusterlet is installed
+	klusterletGroupVersion = "operator.open-cluster-management.io/v1"
+)
+
+var (
+	//klusterletCPU and klusterletMemory are the resources requested by the klusterlet agents and the default addons
+	klusterletCPU    = resource.MustParse("200m")
+	klusterletMemory = resource.MustParse("512Mi")
+	//agentNamespaces are created by the klusterlet and removed when the cluster is detached
+	agentNamespaces = []string{"open-cluster-management-agent", helpers.AddonInstallNamespace}
+)
+
+// klusterletPermissions are the permissions required to apply the import manifests on the cluster
+var klusterletPermissions = []authorizationv1.ResourceAttributes{
+	{Verb: "create", Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"},
+	{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+	{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+	{Verb: "create", Resource: "namespaces"},
+	{Verb: "create", Resource: "serviceaccounts"},
+	{Verb: "create", Resource: "secrets"},
+	{Verb: "create", Group: "apps", Resource: "deployments"},
+}
+
+func checkVersion(client kubernetes.Interface) error {
+	info, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return fmt.Errorf("the cluster is not reachable: %s", err.Error())
+	}
+	return helpers.CheckSpokeVersion(info.GitVersion)
+}
+
+func checkRBAC(ctx context.Context, client kubernetes.Interface) error {
+	denied := make([]string, 0)
+	for i := range klusterletPermissions {
+		ssar := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &klusterletPermissions[i],
+			},
+		}
+		ssar, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, ssar, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		if !ssar.Status.Allowed {
+			denied = append(denied, fmt.Sprintf("%s %s", klusterletPermissions[i].Verb, klusterletPermissions[i].Resource))
+		}
+	}
+	if len(denied) != 0 {
+		return fmt.Errorf("the user of the kubeconfig is not allowed to %s", strings.Join(denied, ", "))
+	}
+	return nil
+}
+
+// checkHubConnectivity runs a pod on the cluster reaching the hub API server,
+// the klusterlet connects from the cluster network which may not be the one of the CLI
+func (o *Options) checkHubConnectivity(client kubernetes.Interface) error {
+	if o.hubServer == "" {
+		return fmt.Errorf("the hub server is unknown, set --hub-server")
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cm-check-hub-connectivity-" + utilrand.String(5),
+			Namespace: o.probeNamespace,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:    "probe",
+					Image:   o.probeImage,
+					Command: []string{"curl", "-sSk", "-o", "/dev/null", "--connect-timeout", "10", strings.TrimSuffix(o.hubServer, "/") + "/healthz"},
+				},
+			},
+		},
+	}
+	pods := client.CoreV1().Pods(o.probeNamespace)
+	if _, err := pods.Create(o.ctx, pod, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("unable to run the probe pod: %s", err.Error())
+	}
+	defer pods.Delete(o.ctx, pod.Name, metav1.DeleteOptions{})
+
+	var phase corev1.PodPhase
+	err := helpers.PollImmediate(o.ctx, o.pollInterval, o.probeTimeout, func() (bool, error) {
+		p, err := pods.Get(o.ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		phase = p.Status.Phase
+		return phase == corev1.PodSucceeded || phase == corev1.PodFailed, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("the probe pod %s/%s did not complete after %s, check the image %s can be pulled", o.probeNamespace, pod.Name, o.probeTimeout, o.probeImage)
+	}
+	if err != nil {
+		return err
+	}
+	if phase == corev1.PodFailed {
+		logs, _ := pods.GetLogs(pod.Name, &corev1.PodLogOptions{}).Do(o.ctx).Raw()
+		return fmt.Errorf("the cluster can not reach the hub %s: %s", o.hubServer, strings.TrimSpace(string(logs)))
+	}
+	return nil
+}
+
+// checkResources checks a schedulable node has the resources requested by the klusterlet available
+func checkResources(ctx context.Context, client kubernetes.Interface) error {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	requested := map[string]corev1.ResourceList{}
+	for _, p := range pods.Items {
+		if p.Spec.NodeName == "" || p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if _, ok := requested[p.Spec.NodeName]; !ok {
+			requested[p.Spec.NodeName] = corev1.ResourceList{}
+		}
+		for _, c := range p.Spec.Containers {
+			for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				if q, ok := c.Resources.Requests[name]; ok {
+					total := requested[p.Spec.NodeName][name]
+					total.Add(q)
+					requested[p.Spec.NodeName][name] = total
+				}
+			}
+		}
+	}
+	for _, n := range nodes.Items {
+		if !schedulable(&n) {
+			continue
+		}
+		cpu := n.Status.Allocatable[corev1.ResourceCPU]
+		cpu.Sub(requested[n.Name][corev1.ResourceCPU])
+		memory := n.Status.Allocatable[corev1.ResourceMemory]
+		memory.Sub(requested[n.Name][corev1.ResourceMemory])
+		if cpu.Cmp(klusterletCPU) >= 0 && memory.Cmp(klusterletMemory) >= 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("no schedulable node has %s CPU and %s memory available for the klusterlet", klusterletCPU.String(), klusterletMemory.String())
+}
+
+// schedulable returns true if the node is ready and accepts the pods without toleration
+func schedulable(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, t := range node.Spec.Taints {
+		if t.Effect == corev1.TaintEffectNoSchedule || t.Effect == corev1.TaintEffectNoExecute {
+			return false
+		}
+	}
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// checkKlusterletRemnants checks no klusterlet is installed or left over by a previous attach
+func checkKlusterletRemnants(ctx context.Context, client kubernetes.Interface) error {
+	remnants := make([]string, 0)
+	for _, name := range agentNamespaces {
+		ns, err := client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+			continue
+		case err != nil:
+			return err
+		case ns.Status.Phase == corev1.NamespaceTerminating:
+			remnants = append(remnants, fmt.Sprintf("namespace %s (terminating)", name))
+		default:
+			remnants = append(remnants, "namespace "+name)
+		}
+	}
+	groups, err := client.Discovery().ServerGroups()
+	if err != nil {
+		return err
+	}
+	for _, g := range groups.Groups {
+		for _, v := range g.Versions {
+			if v.GroupVersion == klusterletGroupVersion {
+				remnants = append(remnants, "Klusterlet CRD")
+			}
+		}
+	}
+	if len(remnants) != 0 {
+		return fmt.Errorf("%s found, a klusterlet is installed or was not cleaned up, detach the cluster from its hub first", strings.Join(remnants, ", "))
+	}
+	return nil
+}
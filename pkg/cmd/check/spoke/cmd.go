@@ -0,0 +1,56 @@
+// Copyright Contributors to the Open Cluster Management project
+package spoke
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Check a cluster can be attached to the hub of the current kubeconfig
+%[1]s check spoke --cluster-kubeconfig mycluster.kubeconfig
+
+# Check the connectivity to a given hub API server with a mirrored probe image
+%[1]s check spoke --cluster-kubeconfig mycluster.kubeconfig --hub-server https://api.hub.example.com:6443 --probe-image registry.example.com/ubi8/ubi-minimal:latest
+`
+
+// NewCmd provides a cobra command checking the prerequisites of a cluster to attach
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "spoke",
+		Short:        "Check the prerequisites of a cluster to attach",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.kubeConfigPath, "cluster-kubeconfig", "", "Kubeconfig of the cluster to check")
+	cmd.Flags().StringVar(&o.hubServer, "hub-server", "", "The hub API server the klusterlet will connect to, default to the server of the hub kubeconfig")
+	cmd.Flags().StringVar(&o.probeImage, "probe-image", defaultProbeImage, "The image containing curl run on the cluster to check the connectivity to the hub")
+	cmd.Flags().StringVar(&o.probeNamespace, "probe-namespace", "default", "The namespace of the cluster in which the connectivity probe runs")
+	helpers.DurationVar(cmd.Flags(), &o.probeTimeout, "probe-timeout", 2*time.Minute, "Timeout to wait for the connectivity probe, e.g. 2m")
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,120 @@
+// Copyright Contributors to the Open Cluster Management project
+package spoke
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/preflight"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/spf13/cobra"
+)
+
+// checkResult is the outcome of a check as printed in json and yaml
+type checkResult struct {
+	Check   string `json:"check"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	if o.kubeConfigPath == "" {
+		return nil
+	}
+	b, err := ioutil.ReadFile(filepath.Clean(o.kubeConfigPath))
+	if err != nil {
+		return err
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(b)
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig %s: %s", o.kubeConfigPath, err.Error())
+	}
+	config.Timeout = 10 * time.Second
+	o.spokeClient, err = kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	//The hub is the one of the current kubeconfig unless given, the connectivity check reports it if unknown
+	if o.hubServer == "" {
+		if hubConfig, err := clients.ForFlags(o.configFlags).ToRESTConfig(); err == nil {
+			o.hubServer = hubConfig.Host
+		}
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.kubeConfigPath == "" {
+		return fmt.Errorf("the kubeconfig of the cluster to check is missing, set --cluster-kubeconfig")
+	}
+	if o.hubServer != "" {
+		u, err := url.Parse(o.hubServer)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("invalid hub server %s, expected https://host:port", o.hubServer)
+		}
+	}
+	if o.probeImage == "" {
+		return fmt.Errorf("the probe image is missing")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	return o.runWithClient(o.spokeClient)
+}
+
+func (o *Options) runWithClient(spokeClient kubernetes.Interface) error {
+	results := preflight.Run(o.checks(spokeClient))
+	table := &printers.Table{
+		Headers: []string{"CHECK", "RESULT", "MESSAGE"},
+	}
+	checkResults := make([]checkResult, 0, len(results))
+	for _, r := range results {
+		result := checkResult{Check: r.Name, Passed: r.Err == nil}
+		status := "Passed"
+		if r.Err != nil {
+			result.Message = r.Err.Error()
+			status = "Failed"
+		}
+		checkResults = append(checkResults, result)
+		table.AddRow(result.Check, status, result.Message)
+	}
+	if err := o.printOptions.Print(o.Out, table, checkResults); err != nil {
+		return err
+	}
+	if failed := preflight.Failed(results); len(failed) != 0 {
+		return fmt.Errorf("%d of %d checks failed", len(failed), len(results))
+	}
+	return nil
+}
+
+// checks returns the prerequisites of the klusterlet on the cluster
+func (o *Options) checks(spokeClient kubernetes.Interface) []preflight.Check {
+	return []preflight.Check{
+		preflight.NewCheck("Kubernetes version", func() error {
+			return checkVersion(spokeClient)
+		}),
+		preflight.NewCheck("RBAC", func() error {
+			return checkRBAC(o.ctx, spokeClient)
+		}),
+		preflight.NewCheck("Hub connectivity", func() error {
+			return o.checkHubConnectivity(spokeClient)
+		}),
+		preflight.NewCheck("Resources", func() error {
+			return checkResources(o.ctx, spokeClient)
+		}),
+		preflight.NewCheck("Klusterlet remnants", func() error {
+			return checkKlusterletRemnants(o.ctx, spokeClient)
+		}),
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright Contributors to the Open Cluster Management project
+package spoke
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func newNode(name, cpu, memory string, ready bool) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func newPod(name, nodeName, cpu, memory string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []corev1.Container{{
+				Name: "c",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse(cpu),
+						corev1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+// newSpokeClient returns a spoke allowing every access review whose probe pods complete with the phase
+func newSpokeClient(gitVersion string, allowed bool, probePhase corev1.PodPhase, objs ...runtime.Object) *kubefake.Clientset {
+	client := kubefake.NewSimpleClientset(objs...)
+	client.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: gitVersion}
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		ssar := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		ssar.Status.Allowed = allowed
+		return true, ssar, nil
+	})
+	client.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		pod := action.(clienttesting.CreateAction).GetObject().(*corev1.Pod)
+		pod.Status.Phase = probePhase
+		return false, nil, nil
+	})
+	return client
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		hubServer  string
+		client     *kubefake.Clientset
+		wantErr    bool
+		wantFailed []string
+	}{
+		{
+			name:      "Success",
+			hubServer: "https://api.hub.example.com:6443",
+			client:    newSpokeClient("v1.20.0", true, corev1.PodSucceeded, newNode("node1", "4", "16Gi", true)),
+		},
+		{
+			name:      "Failed, all checks",
+			hubServer: "https://api.hub.example.com:6443",
+			client: newSpokeClient("v1.15.3", false, corev1.PodFailed,
+				newNode("node1", "4", "16Gi", false),
+				newNode("node2", "1", "2Gi", true),
+				newPod("pod1", "node2", "900m", "1Gi"),
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "open-cluster-management-agent"}},
+			),
+			wantErr:    true,
+			wantFailed: []string{"Kubernetes version", "RBAC", "Hub connectivity", "Resources", "Klusterlet remnants"},
+		},
+		{
+			name:       "Failed, unknown hub",
+			client:     newSpokeClient("v1.20.0", true, corev1.PodSucceeded, newNode("node1", "4", "16Gi", true)),
+			wantErr:    true,
+			wantFailed: []string{"Hub connectivity"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				printOptions:   &printers.PrintOptions{OutputFormat: printers.OutputTable},
+				hubServer:      tt.hubServer,
+				probeImage:     defaultProbeImage,
+				probeNamespace: "default",
+				probeTimeout:   time.Second,
+				pollInterval:   10 * time.Millisecond,
+				ctx:            context.Background(),
+				IOStreams:      streams,
+			}
+			err := o.runWithClient(tt.client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, line := range strings.Split(out.String(), "\n")[1:] {
+				if line == "" {
+					continue
+				}
+				failed := strings.Contains(line, "Failed")
+				wantFailed := false
+				for _, c := range tt.wantFailed {
+					if strings.HasPrefix(line, c+" ") {
+						wantFailed = true
+					}
+				}
+				if failed != wantFailed {
+					t.Errorf("unexpected result %s", line)
+				}
+			}
+			pods, err := tt.client.CoreV1().Pods("default").List(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range pods.Items {
+				if strings.HasPrefix(p.Name, "cm-check-hub-connectivity-") {
+					t.Errorf("the probe pod %s must be deleted", p.Name)
+				}
+			}
+		})
+	}
+}
+
+func Test_checkKlusterletRemnants(t *testing.T) {
+	client := kubefake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: klusterletGroupVersion, APIResources: []metav1.APIResource{{Name: "klusterlets"}}},
+	}
+	err := checkKlusterletRemnants(context.TODO(), client)
+	if err == nil || !strings.Contains(err.Error(), "Klusterlet CRD") {
+		t.Errorf("checkKlusterletRemnants() must report the Klusterlet CRD, got %v", err)
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr bool
+	}{
+		{
+			name:    "Success",
+			options: Options{kubeConfigPath: "spoke.kubeconfig", hubServer: "https://api.hub.example.com:6443", probeImage: defaultProbeImage},
+		},
+		{
+			name:    "Failed, no kubeconfig",
+			options: Options{probeImage: defaultProbeImage},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, invalid hub server",
+			options: Options{kubeConfigPath: "spoke.kubeconfig", hubServer: "api.hub.example.com:6443", probeImage: defaultProbeImage},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.printOptions = printers.NewPrintOptions()
+			if err := tt.options.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright Contributors to the Open Cluster Management project
+package spoke
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Options struct {
+	configFlags    *genericclioptions.ConfigFlags
+	printOptions   *printers.PrintOptions
+	kubeConfigPath string
+	hubServer      string
+	probeImage     string
+	probeNamespace string
+	probeTimeout   time.Duration
+	pollInterval   time.Duration
+	spokeClient    kubernetes.Interface
+	//ctx is canceled on Ctrl+C to abort the probe
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		printOptions: printers.NewPrintOptions(),
+		pollInterval: 2 * time.Second,
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
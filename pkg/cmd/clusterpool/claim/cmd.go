@@ -0,0 +1,53 @@
+// Copyright Contributors to the Open Cluster Management project
+package claim
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Claim a cluster from a clusterpool
+%[1]s clusterpool claim mypool myclaim --namespace mypool-ns
+
+# Claim a cluster, wait until it is ready and save its kubeconfig
+%[1]s clusterpool claim mypool myclaim --namespace mypool-ns --wait --kubeconfig-file myclaim.kubeconfig
+`
+
+// NewCmd provides a cobra command claiming a cluster from a clusterpool
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "claim <clusterpool> <clusterclaim>",
+		Short:        "Claim a cluster from a clusterpool",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.wait, "wait", false, "Wait until the claimed cluster is ready and print its credentials")
+	helpers.DurationVar(cmd.Flags(), &o.timeout, "timeout", 30*time.Minute, "Timeout to wait for the claimed cluster, e.g. 30m")
+	cmd.Flags().StringVar(&o.kubeConfigFile, "kubeconfig-file", "", "The file where the kubeconfig of the claimed cluster is written, if not set it is printed")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,161 @@
+// Copyright Contributors to the Open Cluster Management project
+package claim
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) != 2 {
+		return fmt.Errorf("the clusterpool and clusterclaim names are required")
+	}
+	o.clusterPoolName = args[0]
+	o.clusterClaimName = args[1]
+	o.namespace, err = helpers.GetNamespaceFromFlags(o.configFlags)
+	return err
+}
+
+func (o *Options) validate() error {
+	if o.kubeConfigFile != "" && !o.wait {
+		return fmt.Errorf("--kubeconfig-file requires --wait")
+	}
+	if o.timeout <= 0 {
+		return fmt.Errorf("timeout must be greater than 0")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	if err := o.createClusterClaim(client); err != nil {
+		return err
+	}
+	if !o.wait {
+		fmt.Fprintf(printers.Messages(o.ErrOut), "clusterclaim %s created in clusterpool %s\n", o.clusterClaimName, o.clusterPoolName)
+		printers.PrintIdentifier(o.Out, o.clusterClaimName)
+		return nil
+	}
+
+	var cd *unstructured.Unstructured
+	err := helpers.PollImmediate(o.ctx, o.pollInterval, o.timeout, func() (bool, error) {
+		var err error
+		cd, err = o.getClaimedClusterDeployment(client)
+		return cd != nil, err
+	})
+	if err != nil {
+		return fmt.Errorf("clusterclaim %s is not ready: %s", o.clusterClaimName, err.Error())
+	}
+	return o.printCredentials(client, cd)
+}
+
+// createClusterClaim creates the claim, an existing claim on the same pool is reused
+func (o *Options) createClusterClaim(client crclient.Client) error {
+	cc := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"clusterPoolName": o.clusterPoolName,
+			},
+		},
+	}
+	cc.SetGroupVersionKind(helpers.ClusterClaimGVK)
+	cc.SetName(o.clusterClaimName)
+	cc.SetNamespace(o.namespace)
+	err := client.Create(o.ctx, cc)
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+	err = client.Get(o.ctx, types.NamespacedName{Name: o.clusterClaimName, Namespace: o.namespace}, cc)
+	if err != nil {
+		return err
+	}
+	pool, _, _ := unstructured.NestedString(cc.Object, "spec", "clusterPoolName")
+	if pool != o.clusterPoolName {
+		return fmt.Errorf("clusterclaim %s already exists for clusterpool %s", o.clusterClaimName, pool)
+	}
+	return nil
+}
+
+// getClaimedClusterDeployment returns the installed clusterdeployment assigned to the claim
+// or nil if the claim is not yet fulfilled
+func (o *Options) getClaimedClusterDeployment(client crclient.Client) (*unstructured.Unstructured, error) {
+	cc := &unstructured.Unstructured{}
+	cc.SetGroupVersionKind(helpers.ClusterClaimGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterClaimName, Namespace: o.namespace}, cc)
+	if err != nil {
+		return nil, err
+	}
+	//The clusterdeployment of a pool has the same name than its namespace
+	cdName, _, _ := unstructured.NestedString(cc.Object, "spec", "namespace")
+	if cdName == "" {
+		return nil, nil
+	}
+	cd := &unstructured.Unstructured{}
+	cd.SetGroupVersionKind(helpers.ClusterDeploymentGVK)
+	err = client.Get(o.ctx, types.NamespacedName{Name: cdName, Namespace: cdName}, cd)
+	switch {
+	case errors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	installed, _, _ := unstructured.NestedBool(cd.Object, "spec", "installed")
+	if !installed {
+		return nil, nil
+	}
+	return cd, nil
+}
+
+func (o *Options) printCredentials(client crclient.Client, cd *unstructured.Unstructured) error {
+	kubeConfigSecretName, _, _ := unstructured.NestedString(cd.Object, "spec", "clusterMetadata", "adminKubeconfigSecretRef", "name")
+	kubeConfigSecret := &corev1.Secret{}
+	err := client.Get(o.ctx, types.NamespacedName{Name: kubeConfigSecretName, Namespace: cd.GetNamespace()}, kubeConfigSecret)
+	if err != nil {
+		return err
+	}
+
+	passwordSecretName, _, _ := unstructured.NestedString(cd.Object, "spec", "clusterMetadata", "adminPasswordSecretRef", "name")
+	passwordSecret := &corev1.Secret{}
+	err = client.Get(o.ctx, types.NamespacedName{Name: passwordSecretName, Namespace: cd.GetNamespace()}, passwordSecret)
+	if err != nil {
+		return err
+	}
+
+	apiURL, _, _ := unstructured.NestedString(cd.Object, "status", "apiURL")
+	consoleURL, _, _ := unstructured.NestedString(cd.Object, "status", "webConsoleURL")
+	fmt.Fprintf(printers.Messages(o.ErrOut), "clusterclaim %s is ready, cluster: %s\n", o.clusterClaimName, cd.GetName())
+	fmt.Fprintf(o.Out, "api url: %s\n", apiURL)
+	fmt.Fprintf(o.Out, "console url: %s\n", consoleURL)
+	fmt.Fprintf(o.Out, "username: %s\n", string(passwordSecret.Data["username"]))
+	fmt.Fprintf(o.Out, "password: %s\n", string(passwordSecret.Data["password"]))
+
+	if o.kubeConfigFile != "" {
+		if err := ioutil.WriteFile(o.kubeConfigFile, kubeConfigSecret.Data["kubeconfig"], 0600); err != nil {
+			return err
+		}
+		fmt.Fprintf(printers.Messages(o.ErrOut), "kubeconfig written to %s\n", o.kubeConfigFile)
+		return nil
+	}
+	fmt.Fprintf(o.Out, "kubeconfig:\n%s\n", string(kubeConfigSecret.Data["kubeconfig"]))
+	return nil
+}
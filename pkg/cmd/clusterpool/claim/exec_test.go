@@ -0,0 +1,190 @@
+// Copyright Contributors to the Open Cluster Management project
+package claim
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newClusterClaim(pool, clusterNamespace string) *unstructured.Unstructured {
+	cc := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"clusterPoolName": pool,
+				"namespace":       clusterNamespace,
+			},
+		},
+	}
+	cc.SetGroupVersionKind(helpers.ClusterClaimGVK)
+	cc.SetNamespace("pools")
+	cc.SetName("claim1")
+	return cc
+}
+
+func newClusterDeployment(name string, installed bool) *unstructured.Unstructured {
+	cd := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"installed": installed,
+				"clusterMetadata": map[string]interface{}{
+					"adminKubeconfigSecretRef": map[string]interface{}{"name": name + "-admin-kubeconfig"},
+					"adminPasswordSecretRef":   map[string]interface{}{"name": name + "-admin-password"},
+				},
+			},
+			"status": map[string]interface{}{
+				"apiURL":        "https://api.pool1-abcde.example.com:6443",
+				"webConsoleURL": "https://console.pool1-abcde.example.com",
+			},
+		},
+	}
+	cd.SetGroupVersionKind(helpers.ClusterDeploymentGVK)
+	cd.SetNamespace(name)
+	cd.SetName(name)
+	return cd
+}
+
+func newSecret(namespace, name string, data map[string]string) *corev1.Secret {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{},
+	}
+	for k, v := range data {
+		s.Data[k] = []byte(v)
+	}
+	return s
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	readyObjs := []runtime.Object{
+		newClusterClaim("pool1", "pool1-abcde"),
+		newClusterDeployment("pool1-abcde", true),
+		newSecret("pool1-abcde", "pool1-abcde-admin-kubeconfig", map[string]string{"kubeconfig": "my-kubeconfig"}),
+		newSecret("pool1-abcde", "pool1-abcde-admin-password", map[string]string{"username": "kubeadmin", "password": "my-password"}),
+	}
+	tests := []struct {
+		name         string
+		objs         []runtime.Object
+		pool         string
+		wait         bool
+		contains     []string
+		wantMessages []string
+		wantErr      bool
+	}{
+		{
+			name:         "Success, no wait",
+			pool:         "pool1",
+			wantMessages: []string{"clusterclaim claim1 created in clusterpool pool1"},
+		},
+		{
+			name:     "Success, wait",
+			objs:     readyObjs,
+			pool:     "pool1",
+			wait:     true,
+			contains: []string{"api.pool1-abcde", "kubeadmin", "my-password", "my-kubeconfig"},
+		},
+		{
+			name:    "Failed, claim exists on another pool",
+			objs:    readyObjs,
+			pool:    "pool2",
+			wantErr: true,
+		},
+		{
+			name:    "Failed, cluster not installed",
+			objs:    []runtime.Object{newClusterClaim("pool1", "pool1-abcde"), newClusterDeployment("pool1-abcde", false)},
+			pool:    "pool1",
+			wait:    true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(tt.objs...)
+			streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				clusterPoolName:  tt.pool,
+				clusterClaimName: "claim1",
+				namespace:        "pools",
+				wait:             tt.wait,
+				timeout:          time.Second,
+				ctx:              context.Background(),
+				pollInterval:     100 * time.Millisecond,
+				IOStreams:        streams,
+			}
+			err := o.runWithClient(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %s, got:\n%s", c, out.String())
+				}
+			}
+			for _, c := range tt.wantMessages {
+				if !strings.Contains(errOut.String(), c) {
+					t.Errorf("messages must contain %s, got:\n%s", c, errOut.String())
+				}
+			}
+			if tt.wantErr {
+				return
+			}
+			cc := &unstructured.Unstructured{}
+			cc.SetGroupVersionKind(helpers.ClusterClaimGVK)
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "claim1", Namespace: "pools"}, cc); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient_kubeConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "claim")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	client := fake.NewClient(
+		newClusterClaim("pool1", "pool1-abcde"),
+		newClusterDeployment("pool1-abcde", true),
+		newSecret("pool1-abcde", "pool1-abcde-admin-kubeconfig", map[string]string{"kubeconfig": "my-kubeconfig"}),
+		newSecret("pool1-abcde", "pool1-abcde-admin-password", map[string]string{"username": "kubeadmin", "password": "my-password"}),
+	)
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	o := &Options{
+		clusterPoolName:  "pool1",
+		clusterClaimName: "claim1",
+		namespace:        "pools",
+		wait:             true,
+		timeout:          time.Second,
+		ctx:              context.Background(),
+		kubeConfigFile:   filepath.Join(dir, "kubeconfig"),
+		pollInterval:     100 * time.Millisecond,
+		IOStreams:        streams,
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(o.kubeConfigFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "my-kubeconfig" {
+		t.Errorf("expected my-kubeconfig got %s", string(b))
+	}
+}
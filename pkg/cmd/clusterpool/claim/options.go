@@ -0,0 +1,34 @@
+// Copyright Contributors to the Open Cluster Management project
+package claim
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags      *genericclioptions.ConfigFlags
+	clusterPoolName  string
+	clusterClaimName string
+	namespace        string
+	wait             bool
+	timeout          time.Duration
+	kubeConfigFile   string
+	pollInterval     time.Duration
+	//ctx is canceled on Ctrl+C to abort the waits
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		pollInterval: 10 * time.Second,
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
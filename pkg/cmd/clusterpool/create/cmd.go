@@ -0,0 +1,61 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const (
+	scenarioDirectory = "scenarios/clusterpool"
+)
+
+var valuesTemplatePath = filepath.Join(scenarioDirectory, "values-template.yaml")
+
+var example = `
+# Create a clusterpool
+%[1]s clusterpool create --values values.yaml
+
+# Create a clusterpool overwriting the name and namespace of the values file
+%[1]s clusterpool create --values values.yaml --name mypool --namespace mypool-ns
+`
+
+// NewCmd provides a cobra command creating a Hive ClusterPool from a values file
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "create",
+		Short:        "Create a clusterpool",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.SetUsageTemplate(applierscenarios.UsageTempate(cmd, valuesTemplatePath))
+	cmd.Flags().StringVar(&o.clusterPoolName, "name", "", "Name of the clusterpool")
+
+	o.applierScenariosOptions.AddFlags(cmd.Flags())
+	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,111 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"fmt"
+	"path/filepath"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	AWS   = "aws"
+	AZURE = "azure"
+	GCP   = "gcp"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	o.values, err = o.applierScenariosOptions.ReadValues()
+	if err != nil {
+		return err
+	}
+
+	if len(o.values) == 0 {
+		return fmt.Errorf("values are missing")
+	}
+
+	return nil
+}
+
+func (o *Options) validate() (err error) {
+	icp, ok := o.values["clusterPool"]
+	if !ok || icp == nil {
+		return fmt.Errorf("clusterPool is missing")
+	}
+	cp := icp.(map[string]interface{})
+	icloud, ok := cp["cloud"]
+	if !ok || icloud == nil {
+		return fmt.Errorf("cloud type is missing")
+	}
+	cloud := icloud.(string)
+	if cloud != AWS && cloud != AZURE && cloud != GCP {
+		return fmt.Errorf("supported cloud type are (%s, %s, %s) and got %s", AWS, AZURE, GCP, cloud)
+	}
+
+	if o.clusterPoolName == "" {
+		iname, ok := cp["name"]
+		if !ok || iname == nil {
+			return fmt.Errorf("clusterpool name is missing")
+		}
+		o.clusterPoolName = iname.(string)
+		if len(o.clusterPoolName) == 0 {
+			return fmt.Errorf("clusterPool.name not specified")
+		}
+	}
+	cp["name"] = o.clusterPoolName
+
+	if o.applierScenariosOptions.ConfigFlags != nil &&
+		o.applierScenariosOptions.ConfigFlags.Namespace != nil &&
+		*o.applierScenariosOptions.ConfigFlags.Namespace != "" {
+		cp["namespace"] = *o.applierScenariosOptions.ConfigFlags.Namespace
+	}
+	if ins, ok := cp["namespace"]; !ok || ins == nil || ins.(string) == "" {
+		return fmt.Errorf("clusterPool.namespace not specified")
+	}
+
+	if iocpImage, ok := cp["ocpImage"]; !ok || iocpImage == nil || iocpImage.(string) == "" {
+		return fmt.Errorf("clusterPool.ocpImage not specified")
+	}
+
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.applierScenariosOptions.ConfigFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	valueps, err := helpers.GetPullSecretValues(o.ctx, client)
+	if err != nil {
+		return err
+	}
+
+	o.values["pullSecret"] = valueps
+
+	reader := resources.NewResourcesReader()
+
+	applyOptions := &appliercmd.Options{
+		OutFile:     o.applierScenariosOptions.OutFile,
+		ConfigFlags: o.applierScenariosOptions.ConfigFlags,
+
+		Timeout:   o.applierScenariosOptions.ApplierTimeout(),
+		Force:     o.applierScenariosOptions.Force,
+		Silent:    o.applierScenariosOptions.Silent,
+		IOStreams: o.applierScenariosOptions.IOStreams,
+	}
+
+	return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader,
+		filepath.Join(scenarioDirectory, "hub", "common"),
+		o.values)
+}
@@ -0,0 +1,190 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testDir = filepath.Join("..", "..", "..", "..", "test", "unit")
+var createClusterPoolTestDir = filepath.Join(testDir, "resources", "clusterpool", "create")
+
+func TestOptions_complete(t *testing.T) {
+	tests := []struct {
+		name       string
+		valuesPath string
+		wantErr    bool
+	}{
+		{
+			name:       "Failed, bad valuesPath",
+			valuesPath: "bad-values-path.yaml",
+			wantErr:    true,
+		},
+		{
+			name:       "Failed, empty values",
+			valuesPath: filepath.Join(createClusterPoolTestDir, "values-empty.yaml"),
+			wantErr:    true,
+		},
+		{
+			name:       "Success, with values",
+			valuesPath: filepath.Join(createClusterPoolTestDir, "values-fake-aws.yaml"),
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+					ValuesPaths: []string{tt.valuesPath},
+				},
+			}
+			if err := o.complete(nil, nil); (err != nil) != tt.wantErr {
+				t.Errorf("Options.complete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		clusterPoolName string
+		values          map[string]interface{}
+		wantErr         bool
+	}{
+		{
+			name: "Success, all info in values",
+			values: map[string]interface{}{
+				"clusterPool": map[string]interface{}{
+					"name":      "test",
+					"namespace": "test-ns",
+					"cloud":     "aws",
+					"ocpImage":  "quay.io/openshift-release-dev/ocp-release:4.6.17-x86_64",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:            "Success, overwrite name",
+			clusterPoolName: "other",
+			values: map[string]interface{}{
+				"clusterPool": map[string]interface{}{
+					"namespace": "test-ns",
+					"cloud":     "gcp",
+					"ocpImage":  "quay.io/openshift-release-dev/ocp-release:4.6.17-x86_64",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Failed, clusterPool missing",
+			values:  map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name: "Failed, unsupported cloud",
+			values: map[string]interface{}{
+				"clusterPool": map[string]interface{}{
+					"name":      "test",
+					"namespace": "test-ns",
+					"cloud":     "vsphere",
+					"ocpImage":  "quay.io/openshift-release-dev/ocp-release:4.6.17-x86_64",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed, namespace missing",
+			values: map[string]interface{}{
+				"clusterPool": map[string]interface{}{
+					"name":     "test",
+					"cloud":    "aws",
+					"ocpImage": "quay.io/openshift-release-dev/ocp-release:4.6.17-x86_64",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed, ocpImage missing",
+			values: map[string]interface{}{
+				"clusterPool": map[string]interface{}{
+					"name":      "test",
+					"namespace": "test-ns",
+					"cloud":     "aws",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				clusterPoolName:         tt.clusterPoolName,
+				values:                  tt.values,
+			}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Options.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	pullSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pull-secret",
+			Namespace: "openshift-config",
+		},
+		Data: map[string][]byte{
+			".dockerconfigjson": []byte("crds: mycrds"),
+		},
+	}
+	values, err := appliercmd.ConvertValuesFileToValuesMap(filepath.Join(createClusterPoolTestDir, "values-fake-aws.yaml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := crclientfake.NewFakeClient(&pullSecret)
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			Timeout: time.Second,
+		},
+		values: values,
+	}
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	cp := &unstructured.Unstructured{}
+	cp.SetGroupVersionKind(helpers.ClusterPoolGVK)
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "test-pool", Namespace: "test-pool-ns"}, cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	size, _, _ := unstructured.NestedInt64(cp.Object, "spec", "size")
+	if size != 2 {
+		t.Errorf("expected size 2 got %d", size)
+	}
+	region, _, _ := unstructured.NestedString(cp.Object, "spec", "platform", "aws", "region")
+	if region != "myRegion" {
+		t.Errorf("expected region myRegion got %s", region)
+	}
+	secret := &corev1.Secret{}
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "test-pool-pull-secret", Namespace: "test-pool-ns"}, secret)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"strconv"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if o.allNamespaces {
+		o.namespace = ""
+		return nil
+	}
+	o.namespace, err = helpers.GetNamespaceFromFlags(o.configFlags)
+	return err
+}
+
+func (o *Options) validate() error {
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	clusterPools := &unstructured.UnstructuredList{}
+	clusterPools.SetGroupVersionKind(helpers.ClusterPoolListGVK)
+	err := client.List(o.ctx, clusterPools, crclient.InNamespace(o.namespace))
+	if err != nil {
+		return err
+	}
+
+	clusterClaims := &unstructured.UnstructuredList{}
+	clusterClaims.SetGroupVersionKind(helpers.ClusterClaimListGVK)
+	err = client.List(o.ctx, clusterClaims, crclient.InNamespace(o.namespace))
+	if err != nil {
+		return err
+	}
+	//The claims are in the namespace of their pool
+	claims := make(map[string]int)
+	for _, cc := range clusterClaims.Items {
+		pool, _, _ := unstructured.NestedString(cc.Object, "spec", "clusterPoolName")
+		claims[cc.GetNamespace()+"/"+pool]++
+	}
+
+	table := &printers.Table{
+		Headers: []string{"NAMESPACE", "NAME", "SIZE", "READY", "CLAIMS"},
+	}
+	items := make([]map[string]interface{}, 0)
+	for i := range clusterPools.Items {
+		cp := &clusterPools.Items[i]
+		items = append(items, cp.Object)
+		size, _, _ := unstructured.NestedInt64(cp.Object, "spec", "size")
+		ready, _, _ := unstructured.NestedInt64(cp.Object, "status", "ready")
+		table.AddRow(cp.GetNamespace(),
+			cp.GetName(),
+			strconv.FormatInt(size, 10),
+			strconv.FormatInt(ready, 10),
+			strconv.Itoa(claims[cp.GetNamespace()+"/"+cp.GetName()]))
+	}
+	return o.printOptions.Print(o.Out, table, items)
+}
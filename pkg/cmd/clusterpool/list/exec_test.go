@@ -0,0 +1,93 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newClusterPool(namespace, name string, size, ready int64) *unstructured.Unstructured {
+	cp := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"size": size,
+			},
+			"status": map[string]interface{}{
+				"ready": ready,
+			},
+		},
+	}
+	cp.SetGroupVersionKind(helpers.ClusterPoolGVK)
+	cp.SetNamespace(namespace)
+	cp.SetName(name)
+	return cp
+}
+
+func newClusterClaim(namespace, name, pool string) *unstructured.Unstructured {
+	cc := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"clusterPoolName": pool,
+			},
+		},
+	}
+	cc.SetGroupVersionKind(helpers.ClusterClaimGVK)
+	cc.SetNamespace(namespace)
+	cc.SetName(name)
+	return cc
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := fake.NewClient(
+		newClusterPool("pools", "pool1", 3, 2),
+		newClusterPool("other", "pool2", 1, 0),
+		newClusterClaim("pools", "claim1", "pool1"),
+	)
+	tests := []struct {
+		name        string
+		namespace   string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "Namespace",
+			namespace:   "pools",
+			contains:    []string{"pools       pool1   3      2       1"},
+			notContains: []string{"pool2"},
+		},
+		{
+			name:      "All namespaces",
+			namespace: "",
+			contains:  []string{"pool1", "other       pool2   1      0       0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				printOptions: printers.NewPrintOptions(),
+				namespace:    tt.namespace,
+				IOStreams:    streams,
+			}
+			if err := o.runWithClient(client); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+			for _, c := range tt.notContains {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %q, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright Contributors to the Open Cluster Management project
+package release
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Release a claimed cluster, the cluster is destroyed and the clusterpool provisions a new one
+%[1]s clusterpool release myclaim --namespace mypool-ns
+`
+
+// NewCmd provides a cobra command releasing a clusterclaim
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "release <clusterclaim>",
+		Short:        "Release a cluster claimed from a clusterpool",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	helpers.AddYesFlag(cmd.Flags(), &o.yes)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
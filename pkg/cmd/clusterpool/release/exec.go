@@ -0,0 +1,67 @@
+// Copyright Contributors to the Open Cluster Management project
+package release
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) != 1 {
+		return fmt.Errorf("the clusterclaim name is required")
+	}
+	o.clusterClaimName = args[0]
+	o.namespace, err = helpers.GetNamespaceFromFlags(o.configFlags)
+	return err
+}
+
+func (o *Options) validate() error {
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	cc := &unstructured.Unstructured{}
+	cc.SetGroupVersionKind(helpers.ClusterClaimGVK)
+	cc.SetName(o.clusterClaimName)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterClaimName, Namespace: o.namespace}, cc)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("clusterclaim %s not found in namespace %s", o.clusterClaimName, o.namespace)
+	}
+	if err != nil {
+		return err
+	}
+	if !o.yes {
+		summary := []string{fmt.Sprintf("The clusterclaim %s will be released.", o.clusterClaimName)}
+		if clusterName, _, _ := unstructured.NestedString(cc.Object, "spec", "namespace"); clusterName != "" {
+			summary = append(summary, fmt.Sprintf("The claimed cluster %s and its cloud infrastructure will be DESTROYED.", clusterName))
+		}
+		if err := helpers.Confirm(o.In, o.ErrOut, summary, o.clusterClaimName); err != nil {
+			return err
+		}
+	}
+	if err := client.Delete(o.ctx, cc); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "clusterclaim %s released\n", o.clusterClaimName)
+	printers.PrintIdentifier(o.Out, o.clusterClaimName)
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright Contributors to the Open Cluster Management project
+package release
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_runWithClient(t *testing.T) {
+	cc := &unstructured.Unstructured{}
+	cc.SetGroupVersionKind(helpers.ClusterClaimGVK)
+	cc.SetNamespace("pools")
+	cc.SetName("claim1")
+	tests := []struct {
+		name      string
+		claimName string
+		yes       bool
+		input     string
+		wantErr   bool
+	}{
+		{
+			name:      "Success",
+			claimName: "claim1",
+			yes:       true,
+		},
+		{
+			name:      "Success, confirmed",
+			claimName: "claim1",
+			input:     "claim1\n",
+		},
+		{
+			name:      "Failed, not confirmed",
+			claimName: "claim1",
+			input:     "claim2\n",
+			wantErr:   true,
+		},
+		{
+			name:      "Failed, claim not found",
+			claimName: "claim2",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(cc.DeepCopy())
+			streams, in, _, _ := genericclioptions.NewTestIOStreams()
+			in.WriteString(tt.input)
+			o := &Options{
+				clusterClaimName: tt.claimName,
+				namespace:        "pools",
+				yes:              tt.yes,
+				IOStreams:        streams,
+			}
+			if err := o.runWithClient(client); (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := &unstructured.Unstructured{}
+			got.SetGroupVersionKind(helpers.ClusterClaimGVK)
+			err := client.Get(context.TODO(), types.NamespacedName{Name: "claim1", Namespace: "pools"}, got)
+			if !errors.IsNotFound(err) {
+				t.Errorf("clusterclaim must be deleted, got %v", err)
+			}
+		})
+	}
+}
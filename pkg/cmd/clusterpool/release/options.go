@@ -0,0 +1,28 @@
+// Copyright Contributors to the Open Cluster Management project
+package release
+
+import (
+	"context"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags      *genericclioptions.ConfigFlags
+	clusterClaimName string
+	namespace        string
+	//yes skips the confirmation prompt
+	yes bool
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ctx:         context.Background(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright Contributors to the Open Cluster Management project
+package collect
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Collect a support bundle for all managed clusters
+%[1]s collect
+
+# Collect a support bundle for two clusters including the agent logs of cluster1
+%[1]s collect --clusters cluster1,cluster2 --cluster-kubeconfig cluster1=cluster1.kubeconfig --output bundle.tar.gz
+`
+
+// NewCmd provides a cobra command collecting a support bundle
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "collect",
+		Short:        "Collect the hub resources and the agent logs in a support bundle",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&o.clusters, "clusters", o.clusters, "Names of the managed clusters to collect, all clusters if not set")
+	cmd.Flags().StringVarP(&o.outputFile, "output", "o", o.outputFile, "The tar.gz file in which the bundle is written")
+	cmd.Flags().StringToStringVar(&o.kubeConfigPaths, "cluster-kubeconfig", o.kubeConfigPaths,
+		"Kubeconfigs of managed clusters as cluster=path, the logs of the agents of these clusters are collected")
+	cmd.Flags().Int64Var(&o.tailLines, "tail", o.tailLines, "Number of lines collected from the end of each container log")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,193 @@
+// Copyright Contributors to the Open Cluster Management project
+package collect
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	//errorsFile lists what could not be collected, the collection continues on errors
+	errorsFile = "errors.txt"
+)
+
+// hubNamespaces are the namespaces of the hub controllers deployed by the cluster-manager,
+// the open-cluster-management and the multicluster engine installers
+var hubNamespaces = []string{"open-cluster-management-hub", "open-cluster-management", "multicluster-engine"}
+
+// agentNamespaces are the namespaces of the klusterlet and addon agents on the managed clusters
+var agentNamespaces = []string{"open-cluster-management-agent", "open-cluster-management-agent-addon"}
+
+// clusterNamespaceGVKs are the resources collected in the namespace of each cluster,
+// the secrets are never collected
+var clusterNamespaceGVKs = []schema.GroupVersionKind{
+	helpers.ManifestWorkGVK,
+	helpers.ManagedClusterAddOnGVK,
+	helpers.KlusterletAddonConfigGVK,
+}
+
+// collector gathers the files of the bundle
+type collector struct {
+	client     crclient.Client
+	kubeClient kubernetes.Interface
+	tailLines  int64
+	entries    []helpers.ArchiveEntry
+	errors     []string
+}
+
+func (c *collector) addFile(name string, data []byte) {
+	c.entries = append(c.entries, helpers.ArchiveEntry{Name: path.Join("bundle", name), Data: data})
+}
+
+func (c *collector) addError(format string, args ...interface{}) {
+	c.errors = append(c.errors, fmt.Sprintf(format, args...))
+}
+
+func (c *collector) addYAML(name string, obj interface{}) {
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		c.addError("%s: %s", name, err.Error())
+		return
+	}
+	c.addFile(name, b)
+}
+
+// archiveEntries returns the collected files and the errors file if some items could not be collected
+func (c *collector) archiveEntries() []helpers.ArchiveEntry {
+	if len(c.errors) == 0 {
+		return c.entries
+	}
+	return append(c.entries, helpers.ArchiveEntry{
+		Name: path.Join("bundle", errorsFile),
+		Data: []byte(strings.Join(c.errors, "\n") + "\n"),
+	})
+}
+
+// collectHub collects the resources of the clusters and the logs of the hub controllers,
+// all managed clusters are collected if no cluster is given. It returns the collected clusters.
+func (c *collector) collectHub(ctx context.Context, clusterNames []string) ([]string, error) {
+	clusters := make([]unstructured.Unstructured, 0)
+	if len(clusterNames) == 0 {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(helpers.ManagedClusterGVK.GroupVersion().WithKind(helpers.ManagedClusterGVK.Kind + "List"))
+		if err := c.client.List(ctx, list); err != nil {
+			return nil, err
+		}
+		clusters = list.Items
+	}
+	for _, name := range clusterNames {
+		mc := &unstructured.Unstructured{}
+		mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+		err := c.client.Get(ctx, types.NamespacedName{Name: name}, mc)
+		switch {
+		case errors.IsNotFound(err):
+			c.addError("ManagedCluster %s: not found", name)
+			continue
+		case err != nil:
+			return nil, err
+		}
+		clusters = append(clusters, *mc)
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].GetName() < clusters[j].GetName() })
+
+	collected := make([]string, 0, len(clusters))
+	for i := range clusters {
+		mc := &clusters[i]
+		name := mc.GetName()
+		dir := path.Join("hub", "clusters", name)
+		c.addYAML(path.Join(dir, "managedcluster.yaml"), cleanObject(mc).Object)
+		for _, gvk := range clusterNamespaceGVKs {
+			c.collectList(ctx, path.Join(dir, strings.ToLower(gvk.Kind)+"s.yaml"), gvk, name)
+		}
+		c.collectEvents(ctx, path.Join(dir, "events.yaml"), name)
+		collected = append(collected, name)
+	}
+
+	for _, ns := range hubNamespaces {
+		c.collectLogs(ctx, c.kubeClient, path.Join("hub", "logs"), ns)
+	}
+	return collected, nil
+}
+
+// collectSpoke collects the logs of the agents of a managed cluster
+func (c *collector) collectSpoke(ctx context.Context, clusterName string, spokeClient kubernetes.Interface) {
+	for _, ns := range agentNamespaces {
+		c.collectLogs(ctx, spokeClient, path.Join("spokes", clusterName, "logs"), ns)
+	}
+}
+
+func (c *collector) collectList(ctx context.Context, name string, gvk schema.GroupVersionKind, namespace string) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := c.client.List(ctx, list, crclient.InNamespace(namespace)); err != nil {
+		c.addError("%s in %s: %s", gvk.Kind, namespace, err.Error())
+		return
+	}
+	if len(list.Items) == 0 {
+		return
+	}
+	items := make([]interface{}, len(list.Items))
+	for i := range list.Items {
+		items[i] = cleanObject(&list.Items[i]).Object
+	}
+	c.addYAML(name, map[string]interface{}{"apiVersion": "v1", "kind": "List", "items": items})
+}
+
+func (c *collector) collectEvents(ctx context.Context, name, namespace string) {
+	events, err := c.kubeClient.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		c.addError("events in %s: %s", namespace, err.Error())
+		return
+	}
+	if len(events.Items) == 0 {
+		return
+	}
+	for i := range events.Items {
+		events.Items[i].ManagedFields = nil
+	}
+	c.addYAML(name, events)
+}
+
+// collectLogs collects the logs of all containers of the pods of a namespace in dir/namespace/pod/container.log
+func (c *collector) collectLogs(ctx context.Context, kubeClient kubernetes.Interface, dir, namespace string) {
+	pods, err := kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		c.addError("pods in %s: %s", namespace, err.Error())
+		return
+	}
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			b, err := kubeClient.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: container.Name,
+				TailLines: &c.tailLines,
+			}).DoRaw(ctx)
+			if err != nil {
+				c.addError("logs of %s/%s/%s: %s", namespace, pod.Name, container.Name, err.Error())
+				continue
+			}
+			c.addFile(path.Join(dir, namespace, pod.Name, container.Name+".log"), b)
+		}
+	}
+}
+
+// cleanObject removes the managed fields which are only noise in a bundle
+func cleanObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+	obj.SetManagedFields(nil)
+	return obj
+}
@@ -0,0 +1,120 @@
+// Copyright Contributors to the Open Cluster Management project
+package collect
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	for clusterName, path := range o.kubeConfigPaths {
+		b, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		config, err := clientcmd.RESTConfigFromKubeConfig(b)
+		if err != nil {
+			return fmt.Errorf("invalid kubeconfig %s for cluster %s: %s", path, clusterName, err.Error())
+		}
+		config.Timeout = 30 * time.Second
+		o.spokeClients[clusterName], err = kubernetes.NewForConfig(config)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.outputFile == "" {
+		return fmt.Errorf("the output file is missing")
+	}
+	if o.tailLines <= 0 {
+		return fmt.Errorf("tail must be greater than 0")
+	}
+	if len(o.clusters) == 0 {
+		return nil
+	}
+	clusters := map[string]bool{}
+	for _, c := range o.clusters {
+		clusters[c] = true
+	}
+	for c := range o.kubeConfigPaths {
+		if !clusters[c] {
+			return fmt.Errorf("a kubeconfig is provided for the cluster %s which is not collected", c)
+		}
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	factory := clients.ForFlags(o.configFlags)
+	client, err := factory.Client()
+	if err != nil {
+		return err
+	}
+	kubeClient, err := factory.KubeClient()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client, kubeClient)
+}
+
+func (o *Options) runWithClient(client crclient.Client, kubeClient kubernetes.Interface) error {
+	c := &collector{
+		client:     client,
+		kubeClient: kubeClient,
+		tailLines:  o.tailLines,
+	}
+	clusters, err := c.collectHub(o.ctx, o.clusters)
+	if err != nil {
+		return err
+	}
+	for _, clusterName := range clusters {
+		if spokeClient, ok := o.spokeClients[clusterName]; ok {
+			c.collectSpoke(o.ctx, clusterName, spokeClient)
+		}
+	}
+
+	//Write in a temporary file so a failure never leaves a partial bundle
+	tmpFile, err := helpers.TempFile(o.outputFile)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile)
+	f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if err := helpers.WriteTarGz(f, c.archiveEntries()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := helpers.ReplaceFile(tmpFile, o.outputFile); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(printers.Messages(o.ErrOut), "Support bundle of %d clusters written in %s\n", len(clusters), o.outputFile)
+	printers.PrintIdentifier(o.Out, o.outputFile)
+	if len(c.errors) != 0 {
+		fmt.Fprintf(o.ErrOut, "%d items could not be collected, see %s in the bundle\n", len(c.errors), errorsFile)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright Contributors to the Open Cluster Management project
+package collect
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newManifestWork(namespace, name string) *unstructured.Unstructured {
+	mw := &unstructured.Unstructured{}
+	mw.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	mw.SetNamespace(namespace)
+	mw.SetName(name)
+	return mw
+}
+
+func newPod(namespace, name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "controller"}},
+		},
+	}
+}
+
+// readBundle returns the content of the files of the bundle by name
+func readBundle(t *testing.T, path string) map[string]string {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	tr := tar.NewReader(gr)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[h.Name] = string(b)
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	objs := []runtime.Object{
+		fake.NewManagedCluster("cluster1"),
+		fake.NewManagedCluster("cluster2"),
+		newManifestWork("cluster1", "work1"),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cluster1-import", Namespace: "cluster1"}},
+	}
+	tests := []struct {
+		name         string
+		clusters     []string
+		spokeClients map[string]kubernetes.Interface
+		wantFiles    []string
+		notWantFiles []string
+		contains     map[string]string
+	}{
+		{
+			name:     "Success, all clusters",
+			clusters: nil,
+			wantFiles: []string{
+				"bundle/hub/clusters/cluster1/managedcluster.yaml",
+				"bundle/hub/clusters/cluster1/manifestworks.yaml",
+				"bundle/hub/clusters/cluster2/managedcluster.yaml",
+				"bundle/hub/logs/open-cluster-management-hub/cluster-manager-registration-controller/controller.log",
+			},
+			notWantFiles: []string{"bundle/" + errorsFile},
+			contains: map[string]string{
+				"bundle/hub/clusters/cluster1/manifestworks.yaml": "name: work1",
+			},
+		},
+		{
+			name:     "Success, selected clusters with spoke logs",
+			clusters: []string{"cluster1", "cluster3"},
+			spokeClients: map[string]kubernetes.Interface{
+				"cluster1": kubefake.NewSimpleClientset(newPod("open-cluster-management-agent", "klusterlet-registration-agent")),
+			},
+			wantFiles: []string{
+				"bundle/hub/clusters/cluster1/managedcluster.yaml",
+				"bundle/spokes/cluster1/logs/open-cluster-management-agent/klusterlet-registration-agent/controller.log",
+				"bundle/" + errorsFile,
+			},
+			notWantFiles: []string{"bundle/hub/clusters/cluster2/managedcluster.yaml"},
+			contains: map[string]string{
+				"bundle/" + errorsFile: "ManagedCluster cluster3: not found",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "collect")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			streams, _, _, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				clusters:     tt.clusters,
+				outputFile:   filepath.Join(dir, "bundle.tar.gz"),
+				tailLines:    10,
+				spokeClients: tt.spokeClients,
+				ctx:          context.Background(),
+				IOStreams:    streams,
+			}
+			kubeClient := kubefake.NewSimpleClientset(newPod("open-cluster-management-hub", "cluster-manager-registration-controller"))
+			if err := o.runWithClient(fake.NewClient(objs...), kubeClient); err != nil {
+				t.Fatalf("Options.runWithClient() error = %v", err)
+			}
+			files := readBundle(t, o.outputFile)
+			for _, f := range tt.wantFiles {
+				if _, ok := files[f]; !ok {
+					t.Errorf("the bundle must contain %s", f)
+				}
+			}
+			for _, f := range tt.notWantFiles {
+				if _, ok := files[f]; ok {
+					t.Errorf("the bundle must not contain %s", f)
+				}
+			}
+			for f, c := range tt.contains {
+				if !strings.Contains(files[f], c) {
+					t.Errorf("%s must contain %s, got:\n%s", f, c, files[f])
+				}
+			}
+			for f := range files {
+				if strings.Contains(f, "secret") {
+					t.Errorf("the bundle must not contain secrets, got %s", f)
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		clusters        []string
+		kubeConfigPaths map[string]string
+		wantErr         bool
+	}{
+		{
+			name: "Success, all clusters",
+		},
+		{
+			name:            "Success, kubeconfig of a collected cluster",
+			clusters:        []string{"cluster1"},
+			kubeConfigPaths: map[string]string{"cluster1": "kubeconfig"},
+		},
+		{
+			name:            "Failed, kubeconfig of a cluster not collected",
+			clusters:        []string{"cluster1"},
+			kubeConfigPaths: map[string]string{"cluster2": "kubeconfig"},
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOptions(genericclioptions.IOStreams{})
+			o.clusters = tt.clusters
+			o.kubeConfigPaths = tt.kubeConfigPaths
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Options.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
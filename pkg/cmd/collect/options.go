@@ -0,0 +1,35 @@
+// Copyright Contributors to the Open Cluster Management project
+package collect
+
+import (
+	"context"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Options struct {
+	configFlags     *genericclioptions.ConfigFlags
+	clusters        []string
+	outputFile      string
+	kubeConfigPaths map[string]string
+	tailLines       int64
+	//spokeClients are the clients of the managed clusters for which a kubeconfig is provided
+	spokeClients map[string]kubernetes.Interface
+	ctx          context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:     genericclioptions.NewConfigFlags(true),
+		outputFile:      "bundle.tar.gz",
+		kubeConfigPaths: map[string]string{},
+		tailLines:       1000,
+		spokeClients:    map[string]kubernetes.Interface{},
+		ctx:             context.Background(),
+
+		IOStreams: streams,
+	}
+}
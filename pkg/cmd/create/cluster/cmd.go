@@ -4,7 +4,9 @@ package create
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
 	"github.com/open-cluster-management/cm-cli/pkg/helpers"
 
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
@@ -23,8 +25,8 @@ var example = `
 # Create a cluster
 %[1]s create cluster --values values.yaml
 
-# Create a cluster
-%[1]s create cluster --values values.yaml
+# Create a cluster with Ansible pre and post install hooks and wait for the curation
+%[1]s create cluster --values values.yaml --curator-file curator.yaml --wait
 `
 
 // NewCmd ...
@@ -38,14 +40,17 @@ func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
 			if err := o.complete(c, args); err != nil {
-				return err
+				return cmderrors.NewValidationError(err)
 			}
 			if err := o.validate(); err != nil {
-				return err
+				return cmderrors.NewValidationError(err)
 			}
 			if err := o.run(); err != nil {
 				return err
 			}
+			if err := o.saveSpec(c, args); err != nil {
+				return err
+			}
 
 			return nil
 		},
@@ -53,6 +58,15 @@ func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 
 	cmd.SetUsageTemplate(applierscenarios.UsageTempate(cmd, valuesTemplatePath))
 	cmd.Flags().StringVar(&o.clusterName, "name", "", "Name of the cluster to import")
+	cmd.Flags().StringVar(&o.clusterSet, "clusterset", "", "The ManagedClusterSet the cluster joins, it must exist unless --create-clusterset is set")
+	cmd.Flags().BoolVar(&o.createClusterSet, "create-clusterset", false, "If set, the clusterset of --clusterset is created if it does not exist")
+	cmd.Flags().StringVar(&o.owner, "owner", "", "The owner of the cluster, such as a team or an email, set as an annotation of the ManagedCluster")
+	cmd.Flags().StringVar(&o.ticket, "ticket", "", "The ticket tracking the cluster, set as an annotation of the ManagedCluster")
+	cmd.Flags().StringVar(&o.expires, "expires", "", "The expiry of the cluster, a duration such as 72h or 7d, a date such as 2026-12-31 or an RFC 3339 time, the expired clusters are deleted by gc --expired")
+	cmd.Flags().StringVar(&o.curatorFile, "curator-file", "", "The file containing the towerAuthSecret and the prehook and posthook Ansible job templates of the install curation")
+	cmd.Flags().BoolVar(&o.wait, "wait", false, "Wait until the curation completes, requires --curator-file")
+	helpers.DurationVar(cmd.Flags(), &o.curationTimeout, "curation-timeout", time.Hour, "Timeout to wait for the curation, e.g. 1h")
+	cmd.Flags().StringVar(&o.saveSpecPath, helpers.SaveSpecFlag, "", "Once succeeded, save the command with its resolved values in this file to replay it with the apply command")
 
 	o.applierScenariosOptions.AddFlags(cmd.Flags())
 	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
@@ -2,19 +2,19 @@
 package create
 
 import (
-	"context"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
 
 	"github.com/ghodss/yaml"
 	"github.com/open-cluster-management/applier/pkg/templateprocessor"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
 	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
 	"github.com/open-cluster-management/cm-cli/pkg/resources"
 
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spf13/cobra"
@@ -28,7 +28,8 @@ const (
 )
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
-	o.values, err = appliercmd.ConvertValuesFileToValuesMap(o.applierScenariosOptions.ValuesPath, "")
+	o.ctx = helpers.CommandContext(cmd)
+	o.values, err = o.applierScenariosOptions.ReadValues()
 	if err != nil {
 		return err
 	}
@@ -37,6 +38,13 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 		return fmt.Errorf("values are missing")
 	}
 
+	if o.curatorFile != "" {
+		o.values["curator"], err = helpers.ReadCuratorFile(o.curatorFile)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -68,38 +76,76 @@ func (o *Options) validate() (err error) {
 	}
 
 	mc["name"] = o.clusterName
+	if o.clusterSet == "" {
+		if iset, ok := mc["clusterSet"].(string); ok {
+			o.clusterSet = iset
+		}
+	}
+	mc["clusterSet"] = o.clusterSet
+	for key, flag := range map[string]*string{"owner": &o.owner, "ticket": &o.ticket, "expires": &o.expires} {
+		if *flag == "" {
+			if v, ok := mc[key].(string); ok {
+				*flag = v
+			}
+		}
+	}
+	//The expiry is set as an RFC 3339 time so it does not depend on when the values are applied
+	if o.expires, err = helpers.NormalizeExpiry(o.expires, time.Now()); err != nil {
+		return err
+	}
+	mc["owner"] = o.owner
+	mc["ticket"] = o.ticket
+	mc["expires"] = o.expires
+	if err := helpers.ValidateClusterName(o.clusterName); err != nil {
+		return err
+	}
+
+	if o.wait && o.curatorFile == "" {
+		return fmt.Errorf("wait requires curator-file")
+	}
+	if o.wait && o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("wait can not be used with outFile")
+	}
 
 	return nil
 }
 
-func (o *Options) run() error {
-	client, err := helpers.GetClientFromFlags(o.applierScenariosOptions.ConfigFlags)
-	if err != nil {
+// saveSpec saves the command with its resolved values to replay it, if --save-spec is set
+func (o *Options) saveSpec(cmd *cobra.Command, args []string) error {
+	if o.saveSpecPath == "" {
+		return nil
+	}
+	//The pull secret of the hub and the install config are generated again on replay
+	values := make(map[string]interface{}, len(o.values))
+	for k, v := range o.values {
+		if k != "pullSecret" && k != "installConfig" {
+			values[k] = v
+		}
+	}
+	if err := helpers.WriteOperationSpec(o.saveSpecPath, helpers.NewOperationSpec(cmd, args, values, nil)); err != nil {
 		return err
 	}
-	return o.runWithClient(client)
+	if !o.applierScenariosOptions.Silent {
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "Spec saved in %s, replay it with \"%s apply -f %s\"\n", o.saveSpecPath, helpers.GetExampleHeader(), o.saveSpecPath)
+	}
+	return nil
 }
 
-func (o *Options) runWithClient(client crclient.Client) error {
-	pullSecret := &corev1.Secret{}
-	err := client.Get(
-		context.TODO(),
-		types.NamespacedName{
-			Name:      "pull-secret",
-			Namespace: "openshift-config",
-		},
-		pullSecret)
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.applierScenariosOptions.ConfigFlags).Client()
 	if err != nil {
 		return err
 	}
-
-	ps, err := yaml.Marshal(pullSecret)
-	if err != nil {
+	if err := o.runWithClient(client); err != nil {
 		return err
 	}
+	printers.PrintIdentifier(o.applierScenariosOptions.Out, o.clusterName)
+	return nil
+}
 
-	valueps := make(map[string]interface{})
-	err = yaml.Unmarshal(ps, &valueps)
+func (o *Options) runWithClient(client crclient.Client) error {
+	reporter := o.applierScenariosOptions.NewProgressReporter()
+	valueps, err := helpers.GetPullSecretValues(o.ctx, client)
 	if err != nil {
 		return err
 	}
@@ -107,41 +153,63 @@ func (o *Options) runWithClient(client crclient.Client) error {
 	o.values["pullSecret"] = valueps
 
 	reader := resources.NewResourcesReader()
-	tp, err := templateprocessor.NewTemplateProcessor(
-		reader,
-		&templateprocessor.Options{},
-	)
+	o.values["installConfig"], err = InstallConfigValues(reader, o.cloud, o.values)
 	if err != nil {
 		return err
 	}
 
-	installConfig, err := tp.TemplateResource(
-		filepath.Join(scenarioDirectory, "hub", o.cloud, "install_config.yaml"),
-		o.values)
-	if err != nil {
-		return err
-	}
-
-	valueic := make(map[string]interface{})
-	err = yaml.Unmarshal(installConfig, &valueic)
-	if err != nil {
-		return err
-	}
-
-	o.values["installConfig"] = valueic
-
 	applyOptions := &appliercmd.Options{
 		OutFile:     o.applierScenariosOptions.OutFile,
 		ConfigFlags: o.applierScenariosOptions.ConfigFlags,
 
 		Delete:    false,
-		Timeout:   o.applierScenariosOptions.Timeout,
+		Timeout:   o.applierScenariosOptions.ApplierTimeout(),
 		Force:     o.applierScenariosOptions.Force,
 		Silent:    o.applierScenariosOptions.Silent,
 		IOStreams: o.applierScenariosOptions.IOStreams,
 	}
 
-	return applyOptions.ApplyWithValues(client, reader,
-		filepath.Join(scenarioDirectory, "hub", "common"),
-		o.values)
+	if o.clusterSet != "" && o.applierScenariosOptions.OutFile == "" {
+		err = reporter.Step("clusterset", "ManagedClusterSet/"+o.clusterSet, func() error {
+			created, err := helpers.EnsureClusterSet(o.ctx, client, o.clusterSet, o.createClusterSet)
+			if created && !o.applierScenariosOptions.Silent {
+				fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "ManagedClusterSet %s created\n", o.clusterSet)
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = reporter.Step("apply", "ClusterDeployment/"+o.clusterName, func() error {
+		return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader,
+			filepath.Join(scenarioDirectory, "hub", "common"),
+			o.values)
+	})
+	if err != nil || !o.wait {
+		return err
+	}
+
+	return reporter.Step("curation", "ClusterCurator/"+o.clusterName, func() error {
+		return helpers.WaitForCuration(o.ctx, client, o.clusterName, o.pollInterval, o.curationTimeout)
+	})
+}
+
+// InstallConfigValues renders the install-config of the cloud with the values,
+// it is provided to the cluster deployment templates as the installConfig value
+func InstallConfigValues(reader templateprocessor.TemplateReader, cloud string, values map[string]interface{}) (map[string]interface{}, error) {
+	tp, err := templateprocessor.NewTemplateProcessor(reader, &templateprocessor.Options{})
+	if err != nil {
+		return nil, err
+	}
+	installConfig, err := tp.TemplateResource(filepath.Join(scenarioDirectory, "hub", cloud, "install_config.yaml"), values)
+	if err != nil {
+		return nil, err
+	}
+	valueic := make(map[string]interface{})
+	if err := yaml.Unmarshal(installConfig, &valueic); err != nil {
+		return nil, err
+	}
+	return valueic, nil
 }
@@ -2,14 +2,23 @@
 package create
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -38,7 +47,7 @@ func TestOptions_complete(t *testing.T) {
 			name: "Failed, empty values",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: filepath.Join(createClusterTestDir, "values-empty.yaml"),
+					ValuesPaths: []string{filepath.Join(createClusterTestDir, "values-empty.yaml")},
 				},
 			},
 			wantErr: true,
@@ -47,7 +56,7 @@ func TestOptions_complete(t *testing.T) {
 			name: "Sucess, with values",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: filepath.Join(createClusterTestDir, "values-fake-aws.yaml"),
+					ValuesPaths: []string{filepath.Join(createClusterTestDir, "values-fake-aws.yaml")},
 				},
 			},
 			wantErr: false,
@@ -74,6 +83,8 @@ func TestOptions_validate(t *testing.T) {
 		clusterName             string
 		cloud                   string
 		values                  map[string]interface{}
+		curatorFile             string
+		wait                    bool
 	}
 	tests := []struct {
 		name    string
@@ -136,7 +147,7 @@ func TestOptions_validate(t *testing.T) {
 			name: "Failed, bad valuesPath",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: "bad-values-path.yaml",
+					ValuesPaths: []string{"bad-values-path.yaml"},
 				},
 			},
 			wantErr: true,
@@ -199,6 +210,35 @@ func TestOptions_validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Failed wait without curator-file",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedCluster": map[string]interface{}{
+						"name":  "test",
+						"cloud": "aws",
+					},
+				},
+				wait: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success wait with curator-file",
+			fields: fields{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				values: map[string]interface{}{
+					"managedCluster": map[string]interface{}{
+						"name":  "test",
+						"cloud": "aws",
+					},
+				},
+				curatorFile: "curator.yaml",
+				wait:        true,
+			},
+			wantErr: false,
+		},
 		{
 			name: "Success replace clusterName",
 			fields: fields{
@@ -221,6 +261,8 @@ func TestOptions_validate(t *testing.T) {
 				clusterName:             tt.fields.clusterName,
 				cloud:                   tt.fields.cloud,
 				values:                  tt.fields.values,
+				curatorFile:             tt.fields.curatorFile,
+				wait:                    tt.fields.wait,
 			}
 			if err := o.validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Options.validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -279,7 +321,7 @@ func TestOptions_runWithClient(t *testing.T) {
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
 					//Had to set to 1 sec otherwise test timeout is reached (30s)
-					Timeout: 1,
+					Timeout: time.Second,
 				},
 				values: valuesAWS,
 				cloud:  "aws",
@@ -294,7 +336,7 @@ func TestOptions_runWithClient(t *testing.T) {
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
 					//Had to set to 1 sec otherwise test timeout is reached (30s)
-					Timeout: 1,
+					Timeout: time.Second,
 				},
 				values: valuesAWS,
 				cloud:  "aws",
@@ -319,3 +361,202 @@ func TestOptions_runWithClient(t *testing.T) {
 		})
 	}
 }
+
+func TestOptions_runWithClient_curator(t *testing.T) {
+	pullSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pull-secret",
+			Namespace: "openshift-config",
+		},
+		Data: map[string][]byte{
+			".dockerconfigjson": []byte("crds: mycrds"),
+		},
+	}
+	client := fake.NewClient(&pullSecret)
+	values, err := appliercmd.ConvertValuesFileToValuesMap(filepath.Join(createClusterTestDir, "values-fake-aws.yaml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	values["curator"] = map[string]interface{}{
+		"towerAuthSecret": "toweraccess",
+		"prehook":         []interface{}{map[string]interface{}{"name": "Demo Job Template"}},
+	}
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			Timeout: time.Second,
+		},
+		values: values,
+		cloud:  "aws",
+	}
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+
+	curator := &unstructured.Unstructured{}
+	curator.SetGroupVersionKind(helpers.ClusterCuratorGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: o.clusterName, Namespace: o.clusterName}, curator); err != nil {
+		t.Fatal(err)
+	}
+	if curation, _, _ := unstructured.NestedString(curator.Object, "spec", "desiredCuration"); curation != "install" {
+		t.Errorf("desiredCuration = %s, want install", curation)
+	}
+	cd := &unstructured.Unstructured{}
+	cd.SetGroupVersionKind(helpers.ClusterDeploymentGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: o.clusterName, Namespace: o.clusterName}, cd); err != nil {
+		t.Fatal(err)
+	}
+	if limit, found, _ := unstructured.NestedInt64(cd.Object, "spec", "installAttemptsLimit"); !found || limit != 0 {
+		t.Errorf("installAttemptsLimit = %d, the install must wait for the curation", limit)
+	}
+}
+
+func TestOptions_runWithClient_lifecycle(t *testing.T) {
+	pullSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pull-secret",
+			Namespace: "openshift-config",
+		},
+		Data: map[string][]byte{
+			".dockerconfigjson": []byte("crds: mycrds"),
+		},
+	}
+	client := fake.NewClient(&pullSecret)
+	values, err := appliercmd.ConvertValuesFileToValuesMap(filepath.Join(createClusterTestDir, "values-fake-aws.yaml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	values["managedCluster"].(map[string]interface{})["owner"] = "team-a"
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			Timeout: time.Second,
+			Silent:  true,
+		},
+		values:  values,
+		ticket:  "DEV-42",
+		expires: "2099-12-31T18:00:00Z",
+	}
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: o.clusterName}, mc); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		helpers.OwnerAnnotation:   "team-a",
+		helpers.TicketAnnotation:  "DEV-42",
+		helpers.ExpiresAnnotation: "2099-12-31T18:00:00Z",
+	}
+	for k, v := range want {
+		if mc.GetAnnotations()[k] != v {
+			t.Errorf("annotation %s = %s, want %s", k, mc.GetAnnotations()[k], v)
+		}
+	}
+
+	o.expires = "-1h"
+	if err := o.validate(); err == nil {
+		t.Error("an invalid expiry must be rejected")
+	}
+}
+
+func TestOptions_runWithClient_clusterSet(t *testing.T) {
+	pullSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pull-secret",
+			Namespace: "openshift-config",
+		},
+		Data: map[string][]byte{
+			".dockerconfigjson": []byte("crds: mycrds"),
+		},
+	}
+	for _, create := range []bool{false, true} {
+		client := fake.NewClient(&pullSecret)
+		values, err := appliercmd.ConvertValuesFileToValuesMap(filepath.Join(createClusterTestDir, "values-fake-aws.yaml"), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		o := &Options{
+			applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+				Timeout: time.Second,
+				Silent:  true,
+			},
+			values:           values,
+			clusterSet:       "prod",
+			createClusterSet: create,
+		}
+		if err := o.validate(); err != nil {
+			t.Fatal(err)
+		}
+		err = o.runWithClient(client)
+		if !create {
+			if err == nil {
+				t.Error("runWithClient() expected an error for a missing clusterset")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, gvk := range []schema.GroupVersionKind{helpers.ManagedClusterGVK, helpers.ClusterDeploymentGVK} {
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(gvk)
+			namespace := o.clusterName
+			if gvk == helpers.ManagedClusterGVK {
+				namespace = ""
+			}
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: o.clusterName, Namespace: namespace}, u); err != nil {
+				t.Fatal(err)
+			}
+			if u.GetLabels()[helpers.ClusterSetLabel] != "prod" {
+				t.Errorf("the %s must have the clusterset label, got %v", gvk.Kind, u.GetLabels())
+			}
+		}
+	}
+}
+
+func TestOptions_saveSpec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cm-create-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cmd := &cobra.Command{Use: "cluster"}
+	(&cobra.Command{Use: "create"}).AddCommand(cmd)
+	cmd.Flags().Bool("wait", false, "")
+	if err := cmd.Flags().Parse([]string{"--wait"}); err != nil {
+		t.Fatal(err)
+	}
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{Silent: true},
+		saveSpecPath:            filepath.Join(dir, "spec.yaml"),
+		values: map[string]interface{}{
+			"managedCluster": map[string]interface{}{"name": "mycluster", "cloud": "aws"},
+			"pullSecret":     map[string]interface{}{"data": "secret"},
+			"installConfig":  "generated",
+		},
+	}
+	if err := o.saveSpec(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := helpers.ReadOperationSpec(o.saveSpecPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := spec.Values["managedCluster"]; !ok {
+		t.Error("the values must be saved")
+	}
+	if _, ok := spec.Values["pullSecret"]; ok {
+		t.Error("the pull secret of the hub must not be saved")
+	}
+	if spec.Flags["wait"] != "true" {
+		t.Errorf("the flags must be saved, got %v", spec.Flags)
+	}
+}
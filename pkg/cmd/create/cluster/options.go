@@ -2,6 +2,9 @@
 package create
 
 import (
+	"context"
+	"time"
+
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -10,12 +13,27 @@ import (
 type Options struct {
 	applierScenariosOptions *applierscenarios.ApplierScenariosOptions
 	clusterName             string
-	cloud                   string
-	values                  map[string]interface{}
+	clusterSet              string
+	createClusterSet        bool
+	//owner, ticket and expires are the lifecycle annotations of the ManagedCluster
+	owner           string
+	ticket          string
+	expires         string
+	cloud           string
+	values          map[string]interface{}
+	curatorFile     string
+	wait            bool
+	curationTimeout time.Duration
+	saveSpecPath    string
+	pollInterval    time.Duration
+	//ctx is canceled on Ctrl+C to abort the waits
+	ctx context.Context
 }
 
 func newOptions(streams genericclioptions.IOStreams) *Options {
 	return &Options{
 		applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(streams),
+		pollInterval:            10 * time.Second,
+		ctx:                     context.Background(),
 	}
 }
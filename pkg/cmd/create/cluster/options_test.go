@@ -2,8 +2,10 @@
 package create
 
 import (
+	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -25,6 +27,8 @@ func Test_newOptions(t *testing.T) {
 			},
 			want: &Options{
 				applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(genericclioptions.IOStreams{}),
+				pollInterval:            10 * time.Second,
+				ctx:                     context.Background(),
 			},
 		},
 	}
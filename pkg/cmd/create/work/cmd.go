@@ -0,0 +1,55 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Deploy the manifests of a directory on two clusters
+%[1]s create work mywork --clusters cluster1,cluster2 -f manifests/
+
+# Deploy the manifests on the clusters selected by a placement and wait until they are available
+%[1]s create work mywork --placement mynamespace/myplacement -f manifests.yaml --wait
+`
+
+// NewCmd provides a cobra command wrapping local manifests in manifestworks
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "work <name>",
+		Short:        "Create manifestworks from local manifests on a set of clusters",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&o.clusters, "clusters", nil, "Comma separated list of the clusters on which the manifests are deployed")
+	cmd.Flags().StringVar(&o.placement, "placement", "", "The <namespace>/<name> of the placement selecting the clusters on which the manifests are deployed")
+	cmd.Flags().StringVarP(&o.manifestsPath, "filename", "f", "", "The file or directory containing the manifests")
+	cmd.Flags().BoolVar(&o.wait, "wait", false, "Wait until the manifests are applied and available on all clusters")
+	helpers.DurationVar(cmd.Flags(), &o.timeout, "timeout", 5*time.Minute, "Timeout to wait for the manifestworks, e.g. 5m")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
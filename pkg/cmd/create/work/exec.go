@@ -0,0 +1,156 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 0 {
+		o.workName = args[0]
+	}
+	if o.manifestsPath == "" {
+		return fmt.Errorf("the manifests file or directory must be provided with -f")
+	}
+	o.manifests, err = helpers.ReadManifests(o.manifestsPath)
+	return err
+}
+
+func (o *Options) validate() error {
+	if o.workName == "" {
+		return fmt.Errorf("the manifestwork name is missing")
+	}
+	if len(o.manifests) == 0 {
+		return fmt.Errorf("no manifests found in %s", o.manifestsPath)
+	}
+	if len(o.clusters) == 0 && o.placement == "" {
+		return fmt.Errorf("either --clusters or --placement must be provided")
+	}
+	if len(o.clusters) != 0 && o.placement != "" {
+		return fmt.Errorf("--clusters and --placement are mutually exclusive")
+	}
+	if o.placement != "" && len(strings.Split(o.placement, "/")) != 2 {
+		return fmt.Errorf("the placement must be <namespace>/<name>, got %s", o.placement)
+	}
+	if o.wait && o.timeout <= 0 {
+		return fmt.Errorf("timeout must be greater than 0")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	clusters := o.clusters
+	if o.placement != "" {
+		placement := strings.Split(o.placement, "/")
+		var err error
+		clusters, err = helpers.GetPlacementClusters(o.ctx, client, placement[0], placement[1])
+		if err != nil {
+			return err
+		}
+		if len(clusters) == 0 {
+			return fmt.Errorf("placement %s doesn't select any cluster", o.placement)
+		}
+	}
+
+	for _, cluster := range clusters {
+		if err := o.applyManifestWork(client, cluster); err != nil {
+			return err
+		}
+		fmt.Fprintf(printers.Messages(o.ErrOut), "manifestwork %s applied on cluster %s\n", o.workName, cluster)
+	}
+
+	if !o.wait {
+		return nil
+	}
+	return o.waitManifestWorks(client, clusters)
+}
+
+func (o *Options) newManifestWork(cluster string) *unstructured.Unstructured {
+	manifests := make([]interface{}, len(o.manifests))
+	for i, m := range o.manifests {
+		manifests[i] = m.DeepCopy().Object
+	}
+	work := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"workload": map[string]interface{}{
+					"manifests": manifests,
+				},
+			},
+		},
+	}
+	work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	work.SetName(o.workName)
+	//The manifestworks of a cluster are in the cluster namespace
+	work.SetNamespace(cluster)
+	return work
+}
+
+// applyManifestWork creates the manifestwork or updates its manifests if it already exists
+func (o *Options) applyManifestWork(client crclient.Client, cluster string) error {
+	work := o.newManifestWork(cluster)
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.workName, Namespace: cluster}, existing)
+	switch {
+	case errors.IsNotFound(err):
+		return client.Create(o.ctx, work)
+	case err != nil:
+		return err
+	}
+	existing.Object["spec"] = work.Object["spec"]
+	return client.Update(o.ctx, existing)
+}
+
+func (o *Options) waitManifestWorks(client crclient.Client, clusters []string) error {
+	pending := make(map[string]bool)
+	for _, c := range clusters {
+		pending[c] = true
+	}
+	err := helpers.PollImmediate(o.ctx, o.pollInterval, o.timeout, func() (bool, error) {
+		for cluster := range pending {
+			work := &unstructured.Unstructured{}
+			work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+			err := client.Get(o.ctx, types.NamespacedName{Name: o.workName, Namespace: cluster}, work)
+			if err != nil {
+				return false, err
+			}
+			conditions, _, _ := unstructured.NestedSlice(work.Object, "status", "conditions")
+			if helpers.GetConditionStatus(conditions, helpers.WorkAppliedCondition) == "True" &&
+				helpers.GetConditionStatus(conditions, helpers.WorkAvailableCondition) == "True" {
+				fmt.Fprintf(printers.Messages(o.ErrOut), "manifestwork %s available on cluster %s\n", o.workName, cluster)
+				delete(pending, cluster)
+			}
+		}
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		notReady := make([]string, 0)
+		for c := range pending {
+			notReady = append(notReady, c)
+		}
+		return fmt.Errorf("manifestwork %s is not available on %s: %s", o.workName, strings.Join(notReady, ","), err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var testDir = filepath.Join("..", "..", "..", "..", "test", "unit")
+var createWorkTestDir = filepath.Join(testDir, "resources", "create", "work")
+
+func TestOptions_complete(t *testing.T) {
+	tests := []struct {
+		name          string
+		manifestsPath string
+		wantManifests int
+		wantErr       bool
+	}{
+		{
+			name:    "Failed, no manifests path",
+			wantErr: true,
+		},
+		{
+			name:          "Failed, invalid manifest",
+			manifestsPath: filepath.Join(createWorkTestDir, "invalid.yaml"),
+			wantErr:       true,
+		},
+		{
+			name:          "Success, directory",
+			manifestsPath: filepath.Join(createWorkTestDir, "manifests"),
+			wantManifests: 3,
+		},
+		{
+			name:          "Success, file",
+			manifestsPath: filepath.Join(createWorkTestDir, "manifests", "configmaps.yaml"),
+			wantManifests: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				manifestsPath: tt.manifestsPath,
+			}
+			err := o.complete(nil, []string{"work1"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("complete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(o.manifests) != tt.wantManifests {
+				t.Errorf("expected %d manifests got %d", tt.wantManifests, len(o.manifests))
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	manifests := []*unstructured.Unstructured{{}}
+	tests := []struct {
+		name      string
+		clusters  []string
+		placement string
+		wantErr   bool
+	}{
+		{name: "Clusters", clusters: []string{"cluster1"}},
+		{name: "Placement", placement: "ns/placement1"},
+		{name: "Failed, no target", wantErr: true},
+		{name: "Failed, both targets", clusters: []string{"cluster1"}, placement: "ns/placement1", wantErr: true},
+		{name: "Failed, placement without namespace", placement: "placement1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				workName:  "work1",
+				manifests: manifests,
+				clusters:  tt.clusters,
+				placement: tt.placement,
+			}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func newPlacementDecision(clusters ...string) *unstructured.Unstructured {
+	decisions := make([]interface{}, 0)
+	for _, c := range clusters {
+		decisions = append(decisions, map[string]interface{}{"clusterName": c})
+	}
+	pd := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"decisions": decisions,
+			},
+		},
+	}
+	pd.SetGroupVersionKind(helpers.PlacementDecisionGVK)
+	pd.SetNamespace("ns")
+	pd.SetName("placement1-decision-1")
+	pd.SetLabels(map[string]string{helpers.PlacementLabel: "placement1"})
+	return pd
+}
+
+func getManifests(t *testing.T, client crclient.Client, cluster string) []interface{} {
+	work := &unstructured.Unstructured{}
+	work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	err := client.Get(context.TODO(), types.NamespacedName{Name: "work1", Namespace: cluster}, work)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifests, _, _ := unstructured.NestedSlice(work.Object, "spec", "workload", "manifests")
+	return manifests
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	manifests, err := helpers.ReadManifests(filepath.Join(createWorkTestDir, "manifests"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		objs         []runtime.Object
+		clusters     []string
+		placement    string
+		wantClusters []string
+		wantErr      bool
+	}{
+		{
+			name:         "Clusters",
+			clusters:     []string{"cluster1", "cluster2"},
+			wantClusters: []string{"cluster1", "cluster2"},
+		},
+		{
+			name:         "Placement",
+			objs:         []runtime.Object{newPlacementDecision("cluster3", "cluster1")},
+			placement:    "ns/placement1",
+			wantClusters: []string{"cluster1", "cluster3"},
+		},
+		{
+			name:      "Failed, placement without decisions",
+			placement: "ns/placement1",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(tt.objs...)
+			streams, _, _, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				workName:  "work1",
+				manifests: manifests,
+				clusters:  tt.clusters,
+				placement: tt.placement,
+				IOStreams: streams,
+			}
+			err := o.runWithClient(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.wantClusters {
+				if got := getManifests(t, client, c); len(got) != 3 {
+					t.Errorf("expected 3 manifests on %s got %d", c, len(got))
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient_update(t *testing.T) {
+	client := fake.NewClient()
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	manifests, err := helpers.ReadManifests(filepath.Join(createWorkTestDir, "manifests"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := &Options{
+		workName:  "work1",
+		manifests: manifests,
+		clusters:  []string{"cluster1"},
+		IOStreams: streams,
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	o.manifests = manifests[:1]
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	if got := getManifests(t, client, "cluster1"); len(got) != 1 {
+		t.Errorf("expected 1 manifest after update got %d", len(got))
+	}
+}
+
+func TestOptions_runWithClient_wait(t *testing.T) {
+	work := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Applied", "status": "True"},
+					map[string]interface{}{"type": "Available", "status": "True"},
+				},
+			},
+		},
+	}
+	work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	work.SetNamespace("cluster1")
+	work.SetName("work1")
+	client := fake.NewClient(work)
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	manifests, err := helpers.ReadManifests(filepath.Join(createWorkTestDir, "manifests"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := &Options{
+		workName:     "work1",
+		manifests:    manifests,
+		clusters:     []string{"cluster1"},
+		wait:         true,
+		timeout:      time.Second,
+		ctx:          context.Background(),
+		pollInterval: 100 * time.Millisecond,
+		IOStreams:    streams,
+	}
+	//The fake client keeps the status on update, cluster1 is available
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	o.clusters = []string{"cluster1", "cluster2"}
+	if err := o.runWithClient(client); err == nil {
+		t.Error("expected a timeout as cluster2 is never available")
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags   *genericclioptions.ConfigFlags
+	workName      string
+	clusters      []string
+	placement     string
+	manifestsPath string
+	manifests     []*unstructured.Unstructured
+	wait          bool
+	timeout       time.Duration
+	pollInterval  time.Duration
+	//ctx is canceled on Ctrl+C to abort the waits
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		pollInterval: 5 * time.Second,
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
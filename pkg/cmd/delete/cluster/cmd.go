@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
 	"github.com/open-cluster-management/cm-cli/pkg/helpers"
 
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
@@ -19,6 +20,12 @@ var example = `
 
 # Delete a cluster with overwritting the cluster name
 %[1]s delete cluster --values values.yaml --name mycluster
+
+# Delete a cluster without confirmation, for scripts
+%[1]s delete cluster --values values.yaml --yes
+
+# Delete a cluster protected by the protect command
+%[1]s delete cluster --values values.yaml --override-protection
 `
 
 const (
@@ -38,10 +45,10 @@ func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
 			if err := o.complete(c, args); err != nil {
-				return err
+				return cmderrors.NewValidationError(err)
 			}
 			if err := o.validate(); err != nil {
-				return err
+				return cmderrors.NewValidationError(err)
 			}
 			if err := o.run(); err != nil {
 				return err
@@ -53,6 +60,8 @@ func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 
 	cmd.SetUsageTemplate(applierscenarios.UsageTempate(cmd, valuesTemplatePath))
 	cmd.Flags().StringVar(&o.clusterName, "name", "", "Name of the cluster to import")
+	helpers.AddYesFlag(cmd.Flags(), &o.yes)
+	cmd.Flags().BoolVar(&o.overrideProtection, helpers.OverrideProtectionFlag, false, "If set, the cluster is deleted even if it is protected")
 
 	o.applierScenariosOptions.AddFlags(cmd.Flags())
 	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
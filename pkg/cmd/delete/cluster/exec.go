@@ -6,10 +6,15 @@ import (
 	"path/filepath"
 
 	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
 	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
 
 	"github.com/open-cluster-management/cm-cli/pkg/resources"
 
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spf13/cobra"
@@ -19,7 +24,8 @@ var testDir = filepath.Join("..", "..", "..", "..", "test", "unit")
 var deleteClusterTestDir = filepath.Join(testDir, "resources", "delete", "cluster")
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
-	o.values, err = appliercmd.ConvertValuesFileToValuesMap(o.applierScenariosOptions.ValuesPath, "")
+	o.ctx = helpers.CommandContext(cmd)
+	o.values, err = o.applierScenariosOptions.ReadValues()
 	if err != nil {
 		return err
 	}
@@ -55,15 +61,19 @@ func (o *Options) validate() (err error) {
 }
 
 func (o *Options) run() error {
-	client, err := helpers.GetClientFromFlags(o.applierScenariosOptions.ConfigFlags)
+	client, err := clients.ForFlags(o.applierScenariosOptions.ConfigFlags).Client()
 	if err != nil {
 		return err
 	}
-	return o.runWithClient(client)
+	if err := o.runWithClient(client); err != nil {
+		return err
+	}
+	printers.PrintIdentifier(o.applierScenariosOptions.Out, o.clusterName)
+	return nil
 }
 
 func (o *Options) runWithClient(client crclient.Client) error {
-
+	reporter := o.applierScenariosOptions.NewProgressReporter()
 	reader := resources.NewResourcesReader()
 
 	applyOptions := &appliercmd.Options{
@@ -71,21 +81,58 @@ func (o *Options) runWithClient(client crclient.Client) error {
 		ConfigFlags: o.applierScenariosOptions.ConfigFlags,
 
 		Delete:    true,
-		Timeout:   o.applierScenariosOptions.Timeout,
+		Timeout:   o.applierScenariosOptions.ApplierTimeout(),
 		Force:     o.applierScenariosOptions.Force,
 		Silent:    o.applierScenariosOptions.Silent,
 		IOStreams: o.applierScenariosOptions.IOStreams,
 	}
 
-	err := applyOptions.ApplyWithValues(client, reader,
-		filepath.Join(deleteClusterTestDir, "managed_cluster_cr.yaml"),
-		o.values)
+	if o.applierScenariosOptions.OutFile == "" {
+		if err := helpers.CheckProtection(o.ctx, client, o.clusterName, o.overrideProtection); err != nil {
+			return err
+		}
+	}
+
+	if o.applierScenariosOptions.OutFile == "" && !o.yes {
+		if err := o.confirm(client); err != nil {
+			return err
+		}
+	}
+
+	err := reporter.Step("delete", "ManagedCluster/"+o.clusterName, func() error {
+		return applyOptions.ApplyWithValues(client, reader,
+			filepath.Join(deleteClusterTestDir, "managed_cluster_cr.yaml"),
+			o.values)
+	})
 	if err != nil {
 		return err
 	}
 
-	return applyOptions.ApplyWithValues(client, reader,
-		filepath.Join(deleteClusterTestDir, "cluster_deployment_cr.yaml"),
-		o.values)
+	return reporter.Step("delete", "ClusterDeployment/"+o.clusterName, func() error {
+		return applyOptions.ApplyWithValues(client, reader,
+			filepath.Join(deleteClusterTestDir, "cluster_deployment_cr.yaml"),
+			o.values)
+	})
+}
 
+// confirm asks to type the cluster name, the summary tells if the cloud infrastructure will be destroyed
+func (o *Options) confirm(client crclient.Client) error {
+	summary := []string{fmt.Sprintf("The ManagedCluster %s will be deleted.", o.clusterName)}
+	cd := &unstructured.Unstructured{}
+	cd.SetGroupVersionKind(helpers.ClusterDeploymentGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName, Namespace: o.clusterName}, cd)
+	switch {
+	case errors.IsNotFound(err):
+		summary = append(summary, fmt.Sprintf("The cluster %s has no ClusterDeployment, no cloud infrastructure will be destroyed.", o.clusterName))
+	case err != nil:
+		return err
+	default:
+		//The clusters adopted in Hive are preserved on delete
+		if preserve, _, _ := unstructured.NestedBool(cd.Object, "spec", "preserveOnDelete"); preserve {
+			summary = append(summary, fmt.Sprintf("The ClusterDeployment %s will be deleted, it preserves the cloud infrastructure of the cluster.", o.clusterName))
+			break
+		}
+		summary = append(summary, fmt.Sprintf("The ClusterDeployment %s will be deleted and the cloud infrastructure of the cluster DESTROYED.", o.clusterName))
+	}
+	return helpers.Confirm(o.applierScenariosOptions.In, o.applierScenariosOptions.ErrOut, summary, o.clusterName)
 }
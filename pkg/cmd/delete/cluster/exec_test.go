@@ -3,10 +3,16 @@ package cluster
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -30,7 +36,7 @@ func TestOptions_complete(t *testing.T) {
 			name: "Failed, bad valuesPath",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: "bad-values-path.yaml",
+					ValuesPaths: []string{"bad-values-path.yaml"},
 				},
 			},
 			wantErr: true,
@@ -39,7 +45,7 @@ func TestOptions_complete(t *testing.T) {
 			name: "Failed, empty values",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: filepath.Join(deleteClusterTestDir, "values-empty.yaml"),
+					ValuesPaths: []string{filepath.Join(deleteClusterTestDir, "values-empty.yaml")},
 				},
 			},
 			wantErr: true,
@@ -48,7 +54,7 @@ func TestOptions_complete(t *testing.T) {
 			name: "Sucess, with values",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: filepath.Join(deleteClusterTestDir, "values-fake.yaml"),
+					ValuesPaths: []string{filepath.Join(deleteClusterTestDir, "values-fake.yaml")},
 				},
 			},
 			wantErr: false,
@@ -180,3 +186,77 @@ func TestOptions_runWithClient(t *testing.T) {
 		})
 	}
 }
+
+func TestOptions_confirm(t *testing.T) {
+	cd := &unstructured.Unstructured{}
+	cd.SetGroupVersionKind(helpers.ClusterDeploymentGVK)
+	cd.SetName("mycluster")
+	cd.SetNamespace("mycluster")
+	adopted := cd.DeepCopy()
+	unstructured.SetNestedField(adopted.Object, true, "spec", "preserveOnDelete")
+	tests := []struct {
+		name        string
+		objs        []runtime.Object
+		input       string
+		wantErr     bool
+		wantSummary string
+	}{
+		{
+			name:        "Confirmed, infrastructure destroyed",
+			objs:        []runtime.Object{cd},
+			input:       "mycluster\n",
+			wantSummary: "DESTROYED",
+		},
+		{
+			name:        "Confirmed, adopted cluster preserved",
+			objs:        []runtime.Object{adopted},
+			input:       "mycluster\n",
+			wantSummary: "it preserves the cloud infrastructure",
+		},
+		{
+			name:        "Confirmed, no infrastructure",
+			input:       "mycluster\n",
+			wantSummary: "no cloud infrastructure will be destroyed",
+		},
+		{
+			name:        "Failed, not confirmed",
+			objs:        []runtime.Object{cd},
+			input:       "\n",
+			wantErr:     true,
+			wantSummary: "DESTROYED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, in, _, errOut := genericclioptions.NewTestIOStreams()
+			in.WriteString(tt.input)
+			o := &Options{
+				applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(streams),
+				clusterName:             "mycluster",
+			}
+			if err := o.confirm(fake.NewClient(tt.objs...)); (err != nil) != tt.wantErr {
+				t.Errorf("Options.confirm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(errOut.String(), tt.wantSummary) {
+				t.Errorf("Options.confirm() summary must contain %q, got %s", tt.wantSummary, errOut.String())
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient_protected(t *testing.T) {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	mc.SetName("cluster1")
+	mc.SetAnnotations(map[string]string{helpers.ProtectionAnnotation: "production"})
+	o := &Options{
+		applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(genericclioptions.NewTestIOStreamsDiscard()),
+		clusterName:             "cluster1",
+		values:                  map[string]interface{}{},
+		yes:                     true,
+	}
+	err := o.runWithClient(fake.NewClient(mc))
+	if err == nil || !strings.Contains(err.Error(), "--"+helpers.OverrideProtectionFlag) {
+		t.Errorf("runWithClient() must refuse the protected cluster, got %v", err)
+	}
+}
@@ -2,6 +2,8 @@
 package cluster
 
 import (
+	"context"
+
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -11,10 +13,16 @@ type Options struct {
 	applierScenariosOptions *applierscenarios.ApplierScenariosOptions
 	clusterName             string
 	values                  map[string]interface{}
+	//yes skips the confirmation prompt
+	yes bool
+	//overrideProtection acts on a protected cluster
+	overrideProtection bool
+	ctx                context.Context
 }
 
 func newOptions(streams genericclioptions.IOStreams) *Options {
 	return &Options{
 		applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(streams),
+		ctx:                     context.Background(),
 	}
 }
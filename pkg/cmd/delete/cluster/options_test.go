@@ -2,6 +2,7 @@
 package cluster
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -25,6 +26,7 @@ func Test_newOptions(t *testing.T) {
 			},
 			want: &Options{
 				applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(genericclioptions.IOStreams{}),
+				ctx:                     context.Background(),
 			},
 		},
 	}
@@ -0,0 +1,54 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Describe a managed cluster with the conditions of the cluster and of its addons
+%[1]s describe cluster mycluster
+
+# Show when and why the cluster and its addons changed
+%[1]s describe cluster mycluster --events
+`
+
+// NewCmd provides a cobra command describing a managed cluster
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "cluster <name>",
+		Short: "Describe a managed cluster and its addons",
+		Long: "Describe the labels, taints and conditions of a managed cluster and of its addons. With --events the hub events " +
+			"of the cluster, the transitions of the ManagedCluster conditions and the changes of the addon conditions are " +
+			"merged in a chronological timeline",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.events, "events", false, "Show the chronological timeline of the events and the condition changes of the cluster")
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,260 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// clusterEventsNamespace is the namespace of the events of the cluster scoped resources like the ManagedCluster
+	clusterEventsNamespace = "default"
+	// sourceCondition is the source of the timeline entries of the condition transitions
+	sourceCondition = "Condition"
+	unknownTime     = "<unknown>"
+)
+
+// condition is a condition of the ManagedCluster or of one of its addons
+type condition struct {
+	Resource       string `json:"resource"`
+	Type           string `json:"type"`
+	Status         string `json:"status"`
+	Reason         string `json:"reason,omitempty"`
+	LastTransition string `json:"lastTransitionTime,omitempty"`
+	Message        string `json:"message,omitempty"`
+}
+
+// description is the description of a cluster
+type description struct {
+	Name       string            `json:"name"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Taints     []string          `json:"taints,omitempty"`
+	Conditions []condition       `json:"conditions"`
+}
+
+// timelineEntry is a hub event or a condition transition of the cluster or of one of its addons
+type timelineEntry struct {
+	Time    time.Time `json:"time"`
+	Source  string    `json:"source"`
+	Object  string    `json:"object"`
+	Reason  string    `json:"reason"`
+	Message string    `json:"message,omitempty"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 0 {
+		o.clusterName = args[0]
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.clusterName == "" {
+		return fmt.Errorf("the name of the cluster to describe is missing")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName}, mc)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("the cluster %s does not exist", o.clusterName)
+	}
+	if err != nil {
+		return err
+	}
+	addons := &unstructured.UnstructuredList{}
+	addons.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK.GroupVersion().WithKind(helpers.ManagedClusterAddOnGVK.Kind + "List"))
+	if err := client.List(o.ctx, addons, crclient.InNamespace(o.clusterName)); err != nil {
+		return err
+	}
+	resources := append([]unstructured.Unstructured{*mc}, addons.Items...)
+
+	if o.events {
+		timeline, err := o.timeline(client, resources)
+		if err != nil {
+			return err
+		}
+		table := &printers.Table{
+			Headers: []string{"TIME", "SOURCE", "OBJECT", "REASON", "MESSAGE"},
+		}
+		for _, e := range timeline {
+			table.AddRow(formatTime(e.Time), e.Source, e.Object, e.Reason, e.Message)
+		}
+		return o.printOptions.Print(o.Out, table, timeline)
+	}
+
+	d := describe(mc, resources)
+	table := &printers.Table{
+		Headers: []string{"RESOURCE", "CONDITION", "STATUS", "REASON", "LAST TRANSITION", "MESSAGE"},
+	}
+	for _, c := range d.Conditions {
+		table.AddRow(c.Resource, c.Type, c.Status, c.Reason, c.LastTransition, c.Message)
+	}
+	if o.printOptions.IsTable() {
+		fmt.Fprintf(o.Out, "Name:    %s\nLabels:  %s\nTaints:  %s\n\n", d.Name, formatLabels(d.Labels), strings.Join(d.Taints, ", "))
+	}
+	return o.printOptions.Print(o.Out, table, d)
+}
+
+// describe returns the labels, the taints and the conditions of the cluster and of its addons
+func describe(mc *unstructured.Unstructured, resources []unstructured.Unstructured) *description {
+	d := &description{
+		Name:       mc.GetName(),
+		Labels:     mc.GetLabels(),
+		Conditions: make([]condition, 0),
+	}
+	taints, _, _ := unstructured.NestedSlice(mc.Object, "spec", "taints")
+	for _, it := range taints {
+		t, ok := it.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		taint := helpers.Taint{}
+		taint.Key, _, _ = unstructured.NestedString(t, "key")
+		taint.Value, _, _ = unstructured.NestedString(t, "value")
+		taint.Effect, _, _ = unstructured.NestedString(t, "effect")
+		d.Taints = append(d.Taints, taint.String())
+	}
+	for i := range resources {
+		r := &resources[i]
+		conditions, _, _ := unstructured.NestedSlice(r.Object, "status", "conditions")
+		for _, ic := range conditions {
+			c, ok := ic.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			d.Conditions = append(d.Conditions, condition{
+				Resource:       objectName(r.GetKind(), r.GetName()),
+				Type:           nestedString(c, "type"),
+				Status:         nestedString(c, "status"),
+				Reason:         nestedString(c, "reason"),
+				LastTransition: nestedString(c, "lastTransitionTime"),
+				Message:        nestedString(c, "message"),
+			})
+		}
+	}
+	return d
+}
+
+// timeline merges the hub events of the cluster and of its namespace with the last transitions of the conditions
+// of the cluster and of its addons, the entries are sorted from the oldest to the most recent
+func (o *Options) timeline(client crclient.Client, resources []unstructured.Unstructured) ([]timelineEntry, error) {
+	entries := make([]timelineEntry, 0)
+	for _, ns := range []string{o.clusterName, clusterEventsNamespace} {
+		events := &corev1.EventList{}
+		if err := client.List(o.ctx, events, crclient.InNamespace(ns)); err != nil {
+			return nil, err
+		}
+		for _, e := range events.Items {
+			//The default namespace holds the events of all the cluster scoped resources
+			if ns == clusterEventsNamespace &&
+				(e.InvolvedObject.Kind != helpers.ManagedClusterGVK.Kind || e.InvolvedObject.Name != o.clusterName) {
+				continue
+			}
+			reason := e.Reason
+			if e.Count > 1 {
+				reason = fmt.Sprintf("%s (x%d)", e.Reason, e.Count)
+			}
+			entries = append(entries, timelineEntry{
+				Time:    eventTime(&e),
+				Source:  e.Type,
+				Object:  objectName(e.InvolvedObject.Kind, e.InvolvedObject.Name),
+				Reason:  reason,
+				Message: e.Message,
+			})
+		}
+	}
+	for i := range resources {
+		r := &resources[i]
+		conditions, _, _ := unstructured.NestedSlice(r.Object, "status", "conditions")
+		for _, ic := range conditions {
+			c, ok := ic.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			//An unparsable transition time is reported as unknown
+			t, _ := time.Parse(time.RFC3339, nestedString(c, "lastTransitionTime"))
+			message := nestedString(c, "reason")
+			if m := nestedString(c, "message"); m != "" && message != "" {
+				message += ": " + m
+			} else if m != "" {
+				message = m
+			}
+			entries = append(entries, timelineEntry{
+				Time:    t,
+				Source:  sourceCondition,
+				Object:  objectName(r.GetKind(), r.GetName()),
+				Reason:  nestedString(c, "type") + "=" + nestedString(c, "status"),
+				Message: message,
+			})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries, nil
+}
+
+// eventTime returns the last time the event occurred
+func eventTime(e *corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	}
+	return e.CreationTimestamp.Time
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return unknownTime
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+func objectName(kind, name string) string {
+	return kind + "/" + name
+}
+
+func nestedString(m map[string]interface{}, field string) string {
+	s, _, _ := unstructured.NestedString(m, field)
+	return s
+}
@@ -0,0 +1,155 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var now = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newConditionResource(kind, namespace, name string, conditions ...interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"conditions": conditions},
+	}}
+	switch kind {
+	case helpers.ManagedClusterGVK.Kind:
+		u.SetGroupVersionKind(helpers.ManagedClusterGVK)
+		u.SetLabels(map[string]string{"cloud": "Amazon", "env": "dev"})
+		u.Object["spec"] = map[string]interface{}{
+			"taints": []interface{}{map[string]interface{}{"key": "maintenance", "effect": "NoSelect"}},
+		}
+	default:
+		u.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK)
+	}
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func newEvent(namespace, name, kind, object, reason string, at time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: namespace, Name: name},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: object},
+		Reason:         reason,
+		Type:           corev1.EventTypeWarning,
+		Message:        reason + " message",
+		LastTimestamp:  metav1.NewTime(at),
+		Count:          1,
+	}
+}
+
+func newObjects() []runtime.Object {
+	return []runtime.Object{
+		newConditionResource(helpers.ManagedClusterGVK.Kind, "", "cluster1",
+			fake.NewCondition("ManagedClusterJoined", "True", fake.WithReason("Joined"), fake.WithLastTransitionTime(now.Add(-3*time.Hour))),
+			fake.NewCondition("ManagedClusterConditionAvailable", "Unknown", fake.WithReason("ClusterStatusUnknown"), fake.WithMessage("lease not updated"), fake.WithLastTransitionTime(now.Add(-time.Hour)))),
+		newConditionResource(helpers.ManagedClusterAddOnGVK.Kind, "cluster1", "search-collector",
+			fake.NewCondition("Available", "Unknown", fake.WithReason("AddonLeaseUnknown"), fake.WithLastTransitionTime(now.Add(-50*time.Minute)))),
+		newEvent("cluster1", "e1", "Secret", "cluster1-import", "ImportSecretRotated", now.Add(-2*time.Hour)),
+		newEvent(clusterEventsNamespace, "e2", helpers.ManagedClusterGVK.Kind, "cluster1", "LeaseExpired", now.Add(-70*time.Minute)),
+		newEvent(clusterEventsNamespace, "e3", helpers.ManagedClusterGVK.Kind, "cluster2", "OtherCluster", now.Add(-30*time.Minute)),
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		events   bool
+		format   string
+		contains []string
+		absent   []string
+		wantErr  bool
+	}{
+		{
+			name:     "Success, description",
+			contains: []string{"cluster1", "cloud=Amazon, env=dev", "maintenance:NoSelect", "ManagedClusterConditionAvailable", "lease not updated", "ManagedClusterAddOn/search-collector"},
+		},
+		{
+			name:   "Success, timeline",
+			events: true,
+			contains: []string{
+				"ManagedClusterJoined=True",
+				"ImportSecretRotated",
+				"LeaseExpired",
+				"ManagedClusterConditionAvailable=Unknown",
+				"ClusterStatusUnknown: lease not updated",
+				"Available=Unknown",
+			},
+			absent: []string{"OtherCluster"},
+		},
+		{
+			name:     "Success, timeline in json",
+			events:   true,
+			format:   printers.OutputJSON,
+			contains: []string{`"source": "Condition"`, `"reason": "LeaseExpired"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.clusterName = "cluster1"
+			o.events = tt.events
+			o.printOptions.OutputFormat = tt.format
+			err := o.runWithClient(fake.NewClient(newObjects()...))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+			for _, c := range tt.absent {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %q, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	o.clusterName = "missing"
+	if err := o.runWithClient(fake.NewClient()); err == nil {
+		t.Error("error expected for a missing cluster")
+	}
+}
+
+func TestOptions_timeline(t *testing.T) {
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	o.clusterName = "cluster1"
+	objs := newObjects()
+	resources := []unstructured.Unstructured{*objs[0].(*unstructured.Unstructured), *objs[1].(*unstructured.Unstructured)}
+	entries, err := o.timeline(fake.NewClient(objs...), resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"ManagedClusterJoined=True",
+		"ImportSecretRotated",
+		"LeaseExpired",
+		"ManagedClusterConditionAvailable=Unknown",
+		"Available=Unknown",
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries got %v", len(want), entries)
+	}
+	for i, w := range want {
+		if entries[i].Reason != w {
+			t.Errorf("entry %d = %s, want %s", i, entries[i].Reason, w)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/progress"
+
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	batchStatusDetached = "detached"
+	batchStatusFailed   = "failed"
+)
+
+// validateBatch validates the detach of the --clusters
+func (o *Options) validateBatch() error {
+	if o.clusterName != "" {
+		return fmt.Errorf("--clusters can not be used with --name or the managedClusterName of the values")
+	}
+	if o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("--clusters can not be used with --outFile")
+	}
+	for _, clusterName := range o.clusterNames {
+		if clusterName == "" {
+			return fmt.Errorf("--clusters contains an empty cluster name")
+		}
+	}
+	return helpers.ValidateMaxConcurrency(o.maxConcurrency)
+}
+
+// runBatch confirms the detach of all the --clusters at once, then detaches them concurrently and reports
+// the status of each of them, a failed detach does not stop the others
+func (o *Options) runBatch(client crclient.Client, detach func(c *Options) error) error {
+	if !o.yes {
+		summary := []string{
+			fmt.Sprintf("The ManagedClusters %s will be deleted and the klusterlet removed from the clusters.", strings.Join(o.clusterNames, ", ")),
+			"The clusters themselves and their cloud infrastructure are not destroyed.",
+		}
+		if o.evacuate {
+			summary = append(summary, "Their ManifestWorks will be deleted and the PlacementRules of the subscriptions will exclude them before.")
+		}
+		expected := fmt.Sprintf("%d-clusters", len(o.clusterNames))
+		if err := helpers.Confirm(o.applierScenariosOptions.In, o.applierScenariosOptions.ErrOut, summary, expected); err != nil {
+			return err
+		}
+	}
+
+	w := printers.Messages(o.applierScenariosOptions.ErrOut)
+	var lock sync.Mutex
+	//All the clusters are detached from the hub of the kubeconfig
+	errs := helpers.RunSingleHubBatch(o.ctx, len(o.clusterNames), o.maxConcurrency, func(i int) error {
+		err := detach(o.batchOptions(o.clusterNames[i]))
+		lock.Lock()
+		defer lock.Unlock()
+		if err != nil {
+			fmt.Fprintf(w, "Cluster %s failed to detach: %s\n", o.clusterNames[i], err.Error())
+			return err
+		}
+		fmt.Fprintf(w, "Cluster %s detached\n", o.clusterNames[i])
+		return nil
+	})
+
+	failed := 0
+	table := &printers.Table{Headers: []string{"NAME", "STATUS", "MESSAGE"}}
+	for i, err := range errs {
+		if err != nil {
+			failed++
+			table.AddRow(o.clusterNames[i], batchStatusFailed, err.Error())
+			continue
+		}
+		table.AddRow(o.clusterNames[i], batchStatusDetached, "")
+	}
+	if !printers.Quiet {
+		if err := printers.PrintTable(o.applierScenariosOptions.Out, table); err != nil {
+			return err
+		}
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d of %d clusters failed to detach", failed, len(errs))
+	}
+	return nil
+}
+
+// batchOptions returns the options detaching one cluster of the batch, the detaches run concurrently
+// so their applier output and progress are silenced and the batch was already confirmed
+func (o *Options) batchOptions(clusterName string) *Options {
+	applierScenariosOptions := *o.applierScenariosOptions
+	applierScenariosOptions.Silent = true
+	applierScenariosOptions.ProgressFormat = progress.FormatText
+	c := *o
+	c.applierScenariosOptions = &applierScenariosOptions
+	c.clusterName = clusterName
+	c.clusterNames = nil
+	c.yes = true
+	c.values = make(map[string]interface{}, len(o.values)+1)
+	for k, v := range o.values {
+		c.values[k] = v
+	}
+	c.values["managedClusterName"] = clusterName
+	return &c
+}
@@ -0,0 +1,120 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_runBatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		clusters []string
+		failing  string
+		yes      bool
+		in       string
+		contains []string
+		wantErr  string
+	}{
+		{
+			name:     "Success",
+			clusters: []string{"c1", "c2", "c3"},
+			yes:      true,
+			contains: []string{"c1", "c3", batchStatusDetached},
+		},
+		{
+			name:     "Success, confirmed",
+			clusters: []string{"c1", "c2"},
+			in:       "2-clusters\n",
+			contains: []string{"c2", batchStatusDetached},
+		},
+		{
+			name:     "Failed, not confirmed",
+			clusters: []string{"c1", "c2"},
+			in:       "c1\n",
+			wantErr:  "aborted",
+		},
+		{
+			name:     "Failed, one cluster",
+			clusters: []string{"c1", "c2", "c3"},
+			failing:  "c2",
+			yes:      true,
+			contains: []string{"c2", batchStatusFailed, "cluster is protected"},
+			wantErr:  "1 of 3 clusters failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, in, out, _ := genericclioptions.NewTestIOStreams()
+			in.WriteString(tt.in)
+			o := newOptions(streams)
+			o.clusterNames = tt.clusters
+			o.maxConcurrency = 2
+			o.yes = tt.yes
+			o.values = map[string]interface{}{"common": "value"}
+			var lock sync.Mutex
+			detached := make(map[string]bool)
+			err := o.runBatch(fake.NewClient(), func(c *Options) error {
+				if !c.yes || !c.applierScenariosOptions.Silent || c.values["managedClusterName"] != c.clusterName || c.values["common"] != "value" {
+					return fmt.Errorf("unexpected options of cluster %s", c.clusterName)
+				}
+				if c.clusterName == tt.failing {
+					return fmt.Errorf("cluster is protected")
+				}
+				lock.Lock()
+				defer lock.Unlock()
+				detached[c.clusterName] = true
+				return nil
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error containing %q expected, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+			for _, clusterName := range tt.clusters {
+				if want := tt.in != "c1\n" && clusterName != tt.failing; detached[clusterName] != want {
+					t.Errorf("cluster %s detached = %v, want %v", clusterName, detached[clusterName], want)
+				}
+			}
+			if _, ok := o.values["managedClusterName"]; ok {
+				t.Error("the values of the batch must not be changed")
+			}
+		})
+	}
+}
+
+func TestOptions_validateBatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       Options
+		wantErr bool
+	}{
+		{name: "Success", o: Options{clusterNames: []string{"c1", "c2"}, maxConcurrency: 5}},
+		{name: "Failed, name", o: Options{clusterNames: []string{"c1"}, clusterName: "c2", maxConcurrency: 5}, wantErr: true},
+		{name: "Failed, empty name", o: Options{clusterNames: []string{"c1", ""}, maxConcurrency: 5}, wantErr: true},
+		{name: "Failed, no concurrency", o: Options{clusterNames: []string{"c1"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.o.applierScenariosOptions = applierscenarios.NewApplierScenariosOptions(genericclioptions.NewTestIOStreamsDiscard())
+			tt.o.ctx = context.Background()
+			if err := tt.o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
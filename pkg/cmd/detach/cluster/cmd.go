@@ -4,8 +4,10 @@ package cluster
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
 	"github.com/open-cluster-management/cm-cli/pkg/helpers"
 
 	"github.com/spf13/cobra"
@@ -18,6 +20,18 @@ var example = `
 
 # Detach a cluster with overwritting the cluster name
 %[1]s detach cluster --values values.yaml --name mycluster
+
+# Detach a cluster without confirmation, for scripts
+%[1]s detach cluster --values values.yaml --yes
+
+# Detach a cluster protected by the protect command
+%[1]s detach cluster --values values.yaml --override-protection
+
+# Detach several clusters named on the command line, 10 at a time
+%[1]s detach cluster --clusters c1,c2,c3 --max-concurrency 10
+
+# Detach a cluster after removing its ManifestWorks and the applications placed on it
+%[1]s detach cluster --values values.yaml --evacuate --evacuate-timeout 15m
 `
 
 const (
@@ -37,10 +51,10 @@ func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
 			if err := o.complete(c, args); err != nil {
-				return err
+				return cmderrors.NewValidationError(err)
 			}
 			if err := o.validate(); err != nil {
-				return err
+				return cmderrors.NewValidationError(err)
 			}
 			if err := o.run(); err != nil {
 				return err
@@ -52,6 +66,12 @@ func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 
 	cmd.SetUsageTemplate(applierscenarios.UsageTempate(cmd, valuesTemplatePath))
 	cmd.Flags().StringVar(&o.clusterName, "name", "", "Name of the cluster to import")
+	cmd.Flags().StringSliceVar(&o.clusterNames, "clusters", nil, "Names of the clusters to detach in a batch, separated by commas, the values file is then optional")
+	helpers.AddMaxConcurrencyFlag(cmd.Flags(), &o.maxConcurrency, "clusters of --clusters detached")
+	helpers.AddYesFlag(cmd.Flags(), &o.yes)
+	cmd.Flags().BoolVar(&o.overrideProtection, helpers.OverrideProtectionFlag, false, "If set, the cluster is detached even if it is protected")
+	cmd.Flags().BoolVar(&o.evacuate, "evacuate", false, "If set, the ManifestWorks of the cluster are deleted and the PlacementRules of the subscriptions exclude it, then the workloads removal is awaited before detaching it")
+	helpers.DurationVar(cmd.Flags(), &o.evacuateTimeout, "evacuate-timeout", 10*time.Minute, "Timeout to wait for the workloads removal of the evacuation, e.g. 10m")
 
 	o.applierScenariosOptions.AddFlags(cmd.Flags())
 	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
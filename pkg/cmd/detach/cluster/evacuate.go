@@ -0,0 +1,265 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// evacuatedTaintKey is the taint preventing the Placements from selecting the evacuated cluster
+	evacuatedTaintKey = "cm-cli.open-cluster-management.io/evacuated"
+	// clusterNameLabel is the label of the ManagedCluster holding its name, used to exclude it from the PlacementRules
+	clusterNameLabel = "name"
+)
+
+// evacuation is what the evacuation of a cluster changed on the hub
+type evacuation struct {
+	// placementRules are the namespace/name of the PlacementRules which do not select the cluster anymore
+	placementRules []string
+	// manifestWorks are the names of the ManifestWorks deleted from the namespace of the cluster
+	manifestWorks []string
+}
+
+// runEvacuation removes the workloads of the hub from the cluster before it is detached: the Placements stop
+// selecting it, the PlacementRules of the Subscriptions exclude it and the ManifestWorks are deleted,
+// then it waits for the workloads to be removed from the cluster
+func (o *Options) runEvacuation(client crclient.Client) error {
+	reporter := o.applierScenariosOptions.NewProgressReporter()
+	e := &evacuation{}
+	err := reporter.Step("evacuate", "ManagedCluster/"+o.clusterName, func() (err error) {
+		if err := taintEvacuated(o.ctx, client, o.clusterName); err != nil {
+			return err
+		}
+		e.placementRules, err = excludeFromPlacementRules(o.ctx, client, o.clusterName)
+		if err != nil {
+			return err
+		}
+		e.manifestWorks, err = deleteManifestWorks(o.ctx, client, o.clusterName)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !o.applierScenariosOptions.Silent {
+		w := printers.Messages(o.applierScenariosOptions.ErrOut)
+		for _, pr := range e.placementRules {
+			fmt.Fprintf(w, "PlacementRule %s does not select cluster %s anymore\n", pr, o.clusterName)
+		}
+		for _, mw := range e.manifestWorks {
+			fmt.Fprintf(w, "ManifestWork %s/%s deleted\n", o.clusterName, mw)
+		}
+	}
+	return reporter.Step("evacuate-wait", "ManagedCluster/"+o.clusterName, func() error {
+		return o.waitForEvacuation(client, e)
+	})
+}
+
+// taintEvacuated adds the NoSelect taint of the evacuation so the Placements stop selecting the cluster
+func taintEvacuated(ctx context.Context, client crclient.Client, clusterName string) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(ctx, types.NamespacedName{Name: clusterName}, mc); err != nil {
+		return err
+	}
+	taint := helpers.Taint{Key: evacuatedTaintKey, Effect: helpers.TaintNoSelect}
+	changes, err := helpers.AddTaints(mc, []helpers.Taint{taint}, true, time.Now())
+	if err != nil || len(changes) == 0 {
+		return err
+	}
+	return client.Update(ctx, mc)
+}
+
+// excludeFromPlacementRules removes the cluster from the Subscriptions listing it and excludes it from the
+// PlacementRules of the Subscriptions selecting it, it returns the namespace/name of the changed PlacementRules
+func excludeFromPlacementRules(ctx context.Context, client crclient.Client, clusterName string) ([]string, error) {
+	subscriptions := &unstructured.UnstructuredList{}
+	subscriptions.SetGroupVersionKind(helpers.SubscriptionListGVK)
+	if err := client.List(ctx, subscriptions); err != nil {
+		return nil, err
+	}
+	changed := make([]string, 0)
+	seen := make(map[string]bool)
+	for i := range subscriptions.Items {
+		subscription := &subscriptions.Items[i]
+		clusters, found, _ := unstructured.NestedSlice(subscription.Object, "spec", "placement", "clusters")
+		if kept := removeCluster(clusters, clusterName); found && len(kept) != len(clusters) {
+			if err := unstructured.SetNestedSlice(subscription.Object, kept, "spec", "placement", "clusters"); err != nil {
+				return nil, err
+			}
+			if err := client.Update(ctx, subscription); err != nil {
+				return nil, err
+			}
+		}
+
+		name, _, _ := unstructured.NestedString(subscription.Object, "spec", "placement", "placementRef", "name")
+		kind, _, _ := unstructured.NestedString(subscription.Object, "spec", "placement", "placementRef", "kind")
+		id := subscription.GetNamespace() + "/" + name
+		if name == "" || (kind != "" && kind != helpers.PlacementRuleGVK.Kind) || seen[id] {
+			continue
+		}
+		seen[id] = true
+		placementRule := &unstructured.Unstructured{}
+		placementRule.SetGroupVersionKind(helpers.PlacementRuleGVK)
+		err := client.Get(ctx, types.NamespacedName{Namespace: subscription.GetNamespace(), Name: name}, placementRule)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !placementRuleSelects(placementRule, clusterName) {
+			continue
+		}
+		if err := excludeCluster(placementRule, clusterName); err != nil {
+			return nil, err
+		}
+		if err := client.Update(ctx, placementRule); err != nil {
+			return nil, err
+		}
+		changed = append(changed, id)
+	}
+	return changed, nil
+}
+
+// placementRuleSelects returns true if the PlacementRule lists the cluster or decided to place on it
+func placementRuleSelects(placementRule *unstructured.Unstructured, clusterName string) bool {
+	clusters, _, _ := unstructured.NestedSlice(placementRule.Object, "spec", "clusters")
+	decisions, _, _ := unstructured.NestedSlice(placementRule.Object, "status", "decisions")
+	return len(removeCluster(clusters, clusterName)) != len(clusters) || hasDecision(decisions, clusterName)
+}
+
+// excludeCluster removes the cluster from the clusters of the PlacementRule and excludes it from its cluster selector
+func excludeCluster(placementRule *unstructured.Unstructured, clusterName string) error {
+	clusters, found, _ := unstructured.NestedSlice(placementRule.Object, "spec", "clusters")
+	if found {
+		if err := unstructured.SetNestedSlice(placementRule.Object, removeCluster(clusters, clusterName), "spec", "clusters"); err != nil {
+			return err
+		}
+	}
+	//A PlacementRule listing its clusters without selector only selects the listed clusters
+	_, hasSelector, _ := unstructured.NestedMap(placementRule.Object, "spec", "clusterSelector")
+	if len(clusters) != 0 && !hasSelector {
+		return nil
+	}
+	expressions, _, _ := unstructured.NestedSlice(placementRule.Object, "spec", "clusterSelector", "matchExpressions")
+	for _, ie := range expressions {
+		e, ok := ie.(map[string]interface{})
+		if !ok || e["key"] != clusterNameLabel || e["operator"] != "NotIn" {
+			continue
+		}
+		values, _, _ := unstructured.NestedStringSlice(e, "values")
+		for _, v := range values {
+			if v == clusterName {
+				return nil
+			}
+		}
+		if err := unstructured.SetNestedStringSlice(e, append(values, clusterName), "values"); err != nil {
+			return err
+		}
+		return unstructured.SetNestedSlice(placementRule.Object, expressions, "spec", "clusterSelector", "matchExpressions")
+	}
+	expressions = append(expressions, map[string]interface{}{
+		"key":      clusterNameLabel,
+		"operator": "NotIn",
+		"values":   []interface{}{clusterName},
+	})
+	return unstructured.SetNestedSlice(placementRule.Object, expressions, "spec", "clusterSelector", "matchExpressions")
+}
+
+// removeCluster returns the {name: <cluster>} items which are not the cluster
+func removeCluster(clusters []interface{}, clusterName string) []interface{} {
+	kept := make([]interface{}, 0, len(clusters))
+	for _, ic := range clusters {
+		if c, ok := ic.(map[string]interface{}); ok && c["name"] == clusterName {
+			continue
+		}
+		kept = append(kept, ic)
+	}
+	return kept
+}
+
+func hasDecision(decisions []interface{}, clusterName string) bool {
+	for _, id := range decisions {
+		if d, ok := id.(map[string]interface{}); ok && d["clusterName"] == clusterName {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteManifestWorks deletes the ManifestWorks of the namespace of the cluster, the ones owned
+// by an addon or another controller are removed by their owner when the cluster is detached
+func deleteManifestWorks(ctx context.Context, client crclient.Client, clusterName string) ([]string, error) {
+	works := &unstructured.UnstructuredList{}
+	works.SetGroupVersionKind(helpers.ManifestWorkListGVK)
+	if err := client.List(ctx, works, crclient.InNamespace(clusterName)); err != nil {
+		return nil, err
+	}
+	deleted := make([]string, 0)
+	for i := range works.Items {
+		work := &works.Items[i]
+		if len(work.GetOwnerReferences()) != 0 {
+			continue
+		}
+		if err := client.Delete(ctx, work); err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+		deleted = append(deleted, work.GetName())
+	}
+	return deleted, nil
+}
+
+// waitForEvacuation waits until the deleted ManifestWorks are gone, their finalizer is removed once the
+// work agent removed their resources from the cluster, and the PlacementRules do not place on the cluster anymore
+func (o *Options) waitForEvacuation(client crclient.Client, e *evacuation) error {
+	remaining := make([]string, 0)
+	err := helpers.PollImmediate(o.ctx, o.pollInterval, o.evacuateTimeout, func() (bool, error) {
+		remaining = remaining[:0]
+		for _, name := range e.manifestWorks {
+			work := &unstructured.Unstructured{}
+			work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+			err := client.Get(o.ctx, types.NamespacedName{Namespace: o.clusterName, Name: name}, work)
+			switch {
+			case errors.IsNotFound(err):
+			case err != nil:
+				return false, err
+			default:
+				remaining = append(remaining, "ManifestWork "+name)
+			}
+		}
+		for _, id := range e.placementRules {
+			parts := strings.SplitN(id, "/", 2)
+			placementRule := &unstructured.Unstructured{}
+			placementRule.SetGroupVersionKind(helpers.PlacementRuleGVK)
+			err := client.Get(o.ctx, types.NamespacedName{Namespace: parts[0], Name: parts[1]}, placementRule)
+			switch {
+			case errors.IsNotFound(err):
+			case err != nil:
+				return false, err
+			default:
+				decisions, _, _ := unstructured.NestedSlice(placementRule.Object, "status", "decisions")
+				if hasDecision(decisions, o.clusterName) {
+					remaining = append(remaining, "PlacementRule "+id)
+				}
+			}
+		}
+		return len(remaining) == 0, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("the workloads of cluster %s were not removed after %s, still placed by: %s, detach with --evacuate again or without it to orphan them",
+			o.clusterName, o.evacuateTimeout, strings.Join(remaining, ", "))
+	}
+	return err
+}
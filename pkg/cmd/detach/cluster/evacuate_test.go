@@ -0,0 +1,172 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newEvacuationObject(gvk metav1.GroupVersionKind, namespace, name string, spec, status map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	u.SetAPIVersion(gvk.Group + "/" + gvk.Version)
+	u.SetKind(gvk.Kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	if spec != nil {
+		u.Object["spec"] = spec
+	}
+	if status != nil {
+		u.Object["status"] = status
+	}
+	return u
+}
+
+func Test_excludeCluster(t *testing.T) {
+	notIn := func(values ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"key": clusterNameLabel, "operator": "NotIn", "values": values}
+	}
+	tests := []struct {
+		name string
+		spec map[string]interface{}
+		want map[string]interface{}
+	}{
+		{
+			name: "Listed clusters",
+			spec: map[string]interface{}{
+				"clusters": []interface{}{map[string]interface{}{"name": "cluster1"}, map[string]interface{}{"name": "cluster2"}},
+			},
+			want: map[string]interface{}{
+				"clusters": []interface{}{map[string]interface{}{"name": "cluster2"}},
+			},
+		},
+		{
+			name: "Cluster selector",
+			spec: map[string]interface{}{
+				"clusterSelector": map[string]interface{}{"matchLabels": map[string]interface{}{"env": "prod"}},
+			},
+			want: map[string]interface{}{
+				"clusterSelector": map[string]interface{}{
+					"matchLabels":      map[string]interface{}{"env": "prod"},
+					"matchExpressions": []interface{}{notIn("cluster1")},
+				},
+			},
+		},
+		{
+			name: "Existing exclusion",
+			spec: map[string]interface{}{
+				"clusterSelector": map[string]interface{}{"matchExpressions": []interface{}{notIn("cluster2")}},
+			},
+			want: map[string]interface{}{
+				"clusterSelector": map[string]interface{}{"matchExpressions": []interface{}{notIn("cluster2", "cluster1")}},
+			},
+		},
+		{
+			name: "All clusters",
+			spec: map[string]interface{}{},
+			want: map[string]interface{}{
+				"clusterSelector": map[string]interface{}{"matchExpressions": []interface{}{notIn("cluster1")}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &unstructured.Unstructured{Object: map[string]interface{}{"spec": tt.spec}}
+			if err := excludeCluster(pr, "cluster1"); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(pr.Object["spec"], tt.want) {
+				t.Errorf("spec = %v, want %v", pr.Object["spec"], tt.want)
+			}
+		})
+	}
+}
+
+func TestOptions_runEvacuation(t *testing.T) {
+	workGVK := metav1.GroupVersionKind(helpers.ManifestWorkGVK)
+	subscriptionGVK := metav1.GroupVersionKind(helpers.SubscriptionGVK)
+	placementRuleGVK := metav1.GroupVersionKind(helpers.PlacementRuleGVK)
+
+	mc := newEvacuationObject(metav1.GroupVersionKind(helpers.ManagedClusterGVK), "", "cluster1", nil, nil)
+	work := newEvacuationObject(workGVK, "cluster1", "app", map[string]interface{}{}, nil)
+	ownedWork := newEvacuationObject(workGVK, "cluster1", "addon", map[string]interface{}{}, nil)
+	ownedWork.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "owner", UID: "1"}})
+	listed := newEvacuationObject(subscriptionGVK, "apps", "listed", map[string]interface{}{
+		"placement": map[string]interface{}{
+			"clusters": []interface{}{map[string]interface{}{"name": "cluster1"}},
+		},
+	}, nil)
+	placed := newEvacuationObject(subscriptionGVK, "apps", "placed", map[string]interface{}{
+		"placement": map[string]interface{}{
+			"placementRef": map[string]interface{}{"name": "rule1", "kind": "PlacementRule"},
+		},
+	}, nil)
+	rule := newEvacuationObject(placementRuleGVK, "apps", "rule1",
+		map[string]interface{}{"clusterSelector": map[string]interface{}{}},
+		map[string]interface{}{"decisions": []interface{}{map[string]interface{}{"clusterName": "cluster1"}}})
+	client := fake.NewClient(mc, work, ownedWork, listed, placed, rule)
+
+	o := &Options{
+		applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(genericclioptions.NewTestIOStreamsDiscard()),
+		clusterName:             "cluster1",
+		evacuateTimeout:         50 * time.Millisecond,
+		pollInterval:            10 * time.Millisecond,
+		ctx:                     context.Background(),
+	}
+	//No placement controller runs so the decision of the PlacementRule is kept
+	err := o.runEvacuation(client)
+	if err == nil || !strings.Contains(err.Error(), "PlacementRule apps/rule1") {
+		t.Fatalf("runEvacuation() must time out on the PlacementRule decision, got %v", err)
+	}
+
+	got := &unstructured.Unstructured{}
+	got.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if taints, _, _ := unstructured.NestedSlice(got.Object, "spec", "taints"); len(taints) != 1 {
+		t.Errorf("taints = %v, want the evacuated taint", taints)
+	}
+	got.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "cluster1", Name: "app"}, got); !errors.IsNotFound(err) {
+		t.Errorf("the ManifestWork app must be deleted, got %v", err)
+	}
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "cluster1", Name: "addon"}, got); err != nil {
+		t.Errorf("the owned ManifestWork addon must be kept, got %v", err)
+	}
+	got.SetGroupVersionKind(helpers.SubscriptionGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "apps", Name: "listed"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if clusters, _, _ := unstructured.NestedSlice(got.Object, "spec", "placement", "clusters"); len(clusters) != 0 {
+		t.Errorf("clusters = %v, want none", clusters)
+	}
+	got.SetGroupVersionKind(helpers.PlacementRuleGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "apps", Name: "rule1"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if expressions, _, _ := unstructured.NestedSlice(got.Object, "spec", "clusterSelector", "matchExpressions"); len(expressions) != 1 {
+		t.Errorf("matchExpressions = %v, want the exclusion of cluster1", expressions)
+	}
+
+	//The placement controller removed the decision
+	unstructured.RemoveNestedField(got.Object, "status")
+	if err := client.Update(context.TODO(), got); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.runEvacuation(client); err != nil {
+		t.Errorf("runEvacuation() error = %v", err)
+	}
+}
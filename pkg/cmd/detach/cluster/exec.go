@@ -6,7 +6,9 @@ import (
 	"path/filepath"
 
 	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
 	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
 	"github.com/open-cluster-management/cm-cli/pkg/resources"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -17,19 +19,27 @@ var testDir = filepath.Join("..", "..", "..", "..", "test", "unit")
 var detachClusterTestDir = filepath.Join(testDir, "resources", "detach", "cluster")
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
-	o.values, err = appliercmd.ConvertValuesFileToValuesMap(o.applierScenariosOptions.ValuesPath, "")
+	o.ctx = helpers.CommandContext(cmd)
+	o.values, err = o.applierScenariosOptions.ReadValues()
 	if err != nil {
 		return err
 	}
 
-	if len(o.values) == 0 {
+	//The values file is optional for the batch, the clusters are named by --clusters
+	if len(o.values) == 0 && len(o.clusterNames) == 0 {
 		return fmt.Errorf("values are missing")
 	}
+	if o.values == nil {
+		o.values = make(map[string]interface{})
+	}
 
 	return nil
 }
 
 func (o *Options) validate() error {
+	if len(o.clusterNames) != 0 {
+		return o.validateBatch()
+	}
 	if o.clusterName == "" {
 		iname, ok := o.values["managedClusterName"]
 		if !ok || iname == nil {
@@ -43,18 +53,32 @@ func (o *Options) validate() error {
 
 	o.values["managedClusterName"] = o.clusterName
 
+	if o.evacuate && o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("--evacuate can not be used with --outFile, the evacuation changes the hub")
+	}
+
 	return nil
 }
 
 func (o *Options) run() error {
-	client, err := helpers.GetClientFromFlags(o.applierScenariosOptions.ConfigFlags)
+	client, err := clients.ForFlags(o.applierScenariosOptions.ConfigFlags).Client()
 	if err != nil {
 		return err
 	}
-	return o.runWithClient(client)
+	if len(o.clusterNames) != 0 {
+		return o.runBatch(client, func(c *Options) error {
+			return c.runWithClient(client)
+		})
+	}
+	if err := o.runWithClient(client); err != nil {
+		return err
+	}
+	printers.PrintIdentifier(o.applierScenariosOptions.Out, o.clusterName)
+	return nil
 }
 
 func (o *Options) runWithClient(client crclient.Client) error {
+	reporter := o.applierScenariosOptions.NewProgressReporter()
 	reader := resources.NewResourcesReader()
 
 	applyOptions := &appliercmd.Options{
@@ -62,14 +86,41 @@ func (o *Options) runWithClient(client crclient.Client) error {
 		ConfigFlags: o.applierScenariosOptions.ConfigFlags,
 
 		Delete:    true,
-		Timeout:   o.applierScenariosOptions.Timeout,
+		Timeout:   o.applierScenariosOptions.ApplierTimeout(),
 		Force:     o.applierScenariosOptions.Force,
 		Silent:    o.applierScenariosOptions.Silent,
 		IOStreams: o.applierScenariosOptions.IOStreams,
 	}
 
-	return applyOptions.ApplyWithValues(client, reader,
-		filepath.Join(detachClusterTestDir, "managed_cluster_cr.yaml"),
-		o.values)
+	if o.applierScenariosOptions.OutFile == "" {
+		if err := helpers.CheckProtection(o.ctx, client, o.clusterName, o.overrideProtection); err != nil {
+			return err
+		}
+	}
+
+	if o.applierScenariosOptions.OutFile == "" && !o.yes {
+		summary := []string{
+			fmt.Sprintf("The ManagedCluster %s will be deleted and the klusterlet removed from the cluster.", o.clusterName),
+			"The cluster itself and its cloud infrastructure are not destroyed.",
+		}
+		if o.evacuate {
+			summary = append(summary, "Its ManifestWorks will be deleted and the PlacementRules of the subscriptions will exclude it before.")
+		}
+		if err := helpers.Confirm(o.applierScenariosOptions.In, o.applierScenariosOptions.ErrOut, summary, o.clusterName); err != nil {
+			return err
+		}
+	}
+
+	if o.evacuate {
+		if err := o.runEvacuation(client); err != nil {
+			return err
+		}
+	}
+
+	return reporter.Step("delete", "ManagedCluster/"+o.clusterName, func() error {
+		return applyOptions.ApplyWithValues(client, reader,
+			filepath.Join(detachClusterTestDir, "managed_cluster_cr.yaml"),
+			o.values)
+	})
 
 }
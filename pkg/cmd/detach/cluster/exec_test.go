@@ -3,10 +3,15 @@ package cluster
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -30,7 +35,7 @@ func TestOptions_complete(t *testing.T) {
 			name: "Failed, bad valuesPath",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: "bad-values-path.yaml",
+					ValuesPaths: []string{"bad-values-path.yaml"},
 				},
 			},
 			wantErr: true,
@@ -39,7 +44,7 @@ func TestOptions_complete(t *testing.T) {
 			name: "Failed, empty values",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: filepath.Join(detachClusterTestDir, "values-empty.yaml"),
+					ValuesPaths: []string{filepath.Join(detachClusterTestDir, "values-empty.yaml")},
 				},
 			},
 			wantErr: true,
@@ -48,7 +53,7 @@ func TestOptions_complete(t *testing.T) {
 			name: "Sucess, with values",
 			fields: fields{
 				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
-					ValuesPath: filepath.Join(detachClusterTestDir, "values-fake.yaml"),
+					ValuesPaths: []string{filepath.Join(detachClusterTestDir, "values-fake.yaml")},
 				},
 			},
 			wantErr: false,
@@ -152,3 +157,20 @@ func TestOptions_runWithClient(t *testing.T) {
 		})
 	}
 }
+
+func TestOptions_runWithClient_protected(t *testing.T) {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	mc.SetName("cluster1")
+	mc.SetAnnotations(map[string]string{helpers.ProtectionAnnotation: "production"})
+	o := &Options{
+		applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(genericclioptions.NewTestIOStreamsDiscard()),
+		clusterName:             "cluster1",
+		values:                  map[string]interface{}{},
+		yes:                     true,
+	}
+	err := o.runWithClient(fake.NewClient(mc))
+	if err == nil || !strings.Contains(err.Error(), "--"+helpers.OverrideProtectionFlag) {
+		t.Errorf("runWithClient() must refuse the protected cluster, got %v", err)
+	}
+}
@@ -2,6 +2,9 @@
 package cluster
 
 import (
+	"context"
+	"time"
+
 	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -10,11 +13,26 @@ import (
 type Options struct {
 	applierScenariosOptions *applierscenarios.ApplierScenariosOptions
 	clusterName             string
-	values                  map[string]interface{}
+	//clusterNames are the clusters detached in a batch with --clusters
+	clusterNames   []string
+	maxConcurrency int
+	values         map[string]interface{}
+	//yes skips the confirmation prompt
+	yes bool
+	//overrideProtection acts on a protected cluster
+	overrideProtection bool
+	//evacuate removes the workloads of the hub from the cluster before detaching it
+	evacuate        bool
+	evacuateTimeout time.Duration
+	pollInterval    time.Duration
+	//ctx is canceled on Ctrl+C to abort the wait of the evacuation
+	ctx context.Context
 }
 
 func newOptions(streams genericclioptions.IOStreams) *Options {
 	return &Options{
 		applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(streams),
+		pollInterval:            5 * time.Second,
+		ctx:                     context.Background(),
 	}
 }
@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Compare a cluster with the resources an attach with the values would create
+%[1]s diff cluster mycluster --values values.yaml
+
+# Compare a cluster with the spec saved by its attach
+%[1]s diff cluster mycluster --spec mycluster-spec.yaml
+
+# Compare also the manifestworks of the cluster with the manifests they were created from
+%[1]s diff cluster mycluster --values values.yaml --work nginx=nginx.yaml
+`
+
+// NewCmd provides a cobra command comparing the resources of a cluster on the hub with its scenario
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "cluster <name>",
+		Short: "Detect the drift of the hub resources of a cluster",
+		Long: "Compare the ManagedCluster, KlusterletAddonConfig and ManifestWorks of a cluster on the hub with the resources " +
+			"the attach templates generate for the values, the fields changed by manual edits are reported and the command fails",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.specPath, "spec", "", "The spec saved by the attach with --save-spec, instead of the values")
+	cmd.Flags().StringToStringVar(&o.workPaths, "work", nil, "The manifests file or directory of a manifestwork of the cluster as NAME=PATH (can specify multiple)")
+	o.applierScenariosOptions.AddValuesFlags(cmd.Flags())
+	o.printOptions.AddFlags(cmd.Flags())
+	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
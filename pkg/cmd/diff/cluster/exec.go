@@ -0,0 +1,285 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	attachcluster "github.com/open-cluster-management/cm-cli/pkg/cmd/attach/cluster"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	attachCommand      = "attach cluster"
+	valuesTemplatePath = "scenarios/attach/values-template.yaml"
+	// autoDetect is replaced on the hub by the detected value
+	autoDetect = "auto-detect"
+	// missingValue is displayed for the fields and resources which are not on the hub
+	missingValue = "<missing>"
+)
+
+// comparedKinds are the kinds of the attach templates which are compared, the namespace
+// and the auto-import secret consumed by the import are not
+var comparedKinds = map[string]bool{
+	"ManagedCluster":        true,
+	"KlusterletAddonConfig": true,
+	"KlusterletConfig":      true,
+	"ClusterDeployment":     true,
+}
+
+// drift is a field of a hub resource which differs from the one generated by the templates
+type drift struct {
+	Resource string `json:"resource"`
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Found    string `json:"found"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 0 {
+		o.clusterName = args[0]
+	}
+	if o.specPath != "" {
+		spec, err := helpers.ReadOperationSpec(o.specPath)
+		if err != nil {
+			return err
+		}
+		if spec.Command != attachCommand {
+			return fmt.Errorf("the spec %s is the one of %s, expected %s", o.specPath, spec.Command, attachCommand)
+		}
+		o.values = spec.Values
+	} else {
+		o.values, err = o.applierScenariosOptions.ReadValuesWithDefaults(valuesTemplatePath)
+		if err != nil {
+			return err
+		}
+	}
+	if o.values == nil {
+		o.values = map[string]interface{}{}
+	}
+	o.values["managedClusterName"] = o.clusterName
+
+	o.works = map[string][]*unstructured.Unstructured{}
+	for name, path := range o.workPaths {
+		o.works[name], err = helpers.ReadManifests(path)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.clusterName == "" {
+		return fmt.Errorf("the cluster name is missing")
+	}
+	if o.specPath != "" && (len(o.applierScenariosOptions.ValuesPaths) != 0 ||
+		len(o.applierScenariosOptions.SetValues) != 0 ||
+		len(o.applierScenariosOptions.SetFileValues) != 0) {
+		return fmt.Errorf("--spec can not be used with --values, --set or --set-file")
+	}
+	for name, manifests := range o.works {
+		if len(manifests) == 0 {
+			return fmt.Errorf("no manifests found in %s for the manifestwork %s", o.workPaths[name], name)
+		}
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.applierScenariosOptions.ConfigFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	desired, err := o.desiredResources()
+	if err != nil {
+		return err
+	}
+	drifts := make([]drift, 0)
+	for _, d := range desired {
+		found, err := compareResource(o.ctx, client, d)
+		if err != nil {
+			return err
+		}
+		drifts = append(drifts, found...)
+	}
+
+	if len(drifts) == 0 && o.printOptions.IsTable() {
+		fmt.Fprintf(o.applierScenariosOptions.Out, "No drift found for cluster %s\n", o.clusterName)
+		return nil
+	}
+	table := &printers.Table{
+		Headers: []string{"RESOURCE", "FIELD", "EXPECTED", "FOUND"},
+	}
+	for _, d := range drifts {
+		table.AddRow(d.Resource, d.Field, d.Expected, d.Found)
+	}
+	if err := o.printOptions.Print(o.applierScenariosOptions.Out, table, drifts); err != nil {
+		return err
+	}
+	if len(drifts) != 0 {
+		return fmt.Errorf("%d drifts found on cluster %s", len(drifts), o.clusterName)
+	}
+	return nil
+}
+
+// desiredResources returns the compared resources generated by the attach templates and the manifestworks
+func (o *Options) desiredResources() ([]*unstructured.Unstructured, error) {
+	manifests, err := attachcluster.HubManifests(resources.NewResourcesReader(), o.values)
+	if err != nil {
+		return nil, err
+	}
+	desired := make([]*unstructured.Unstructured, 0, len(manifests)+len(o.works))
+	for _, m := range manifests {
+		if comparedKinds[m.GetKind()] {
+			desired = append(desired, m)
+		}
+	}
+
+	names := make([]string, 0, len(o.works))
+	for name := range o.works {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		manifests := make([]interface{}, len(o.works[name]))
+		for i, m := range o.works[name] {
+			manifests[i] = m.Object
+		}
+		work := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"workload": map[string]interface{}{
+						"manifests": manifests,
+					},
+				},
+			},
+		}
+		work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+		work.SetName(name)
+		work.SetNamespace(o.clusterName)
+		desired = append(desired, work)
+	}
+	return desired, nil
+}
+
+// compareResource returns the drifts of the resource on the hub, only the labels, annotations
+// and the fields set by the templates are compared
+func compareResource(ctx context.Context, client crclient.Client, desired *unstructured.Unstructured) ([]drift, error) {
+	resource := desired.GetKind() + "/" + desired.GetName()
+	if desired.GetNamespace() != "" {
+		resource = desired.GetKind() + "/" + desired.GetNamespace() + "/" + desired.GetName()
+	}
+	found := &unstructured.Unstructured{}
+	found.SetGroupVersionKind(desired.GroupVersionKind())
+	err := client.Get(ctx, types.NamespacedName{Name: desired.GetName(), Namespace: desired.GetNamespace()}, found)
+	if errors.IsNotFound(err) {
+		return []drift{{Resource: resource, Expected: "present", Found: missingValue}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := make([]drift, 0)
+	for _, field := range []string{"labels", "annotations"} {
+		expected, _, _ := unstructured.NestedFieldNoCopy(desired.Object, "metadata", field)
+		current, _, _ := unstructured.NestedFieldNoCopy(found.Object, "metadata", field)
+		drifts = append(drifts, compareFields(resource, "metadata."+field, expected, current)...)
+	}
+	for _, field := range sortedKeys(desired.Object) {
+		switch field {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+		drifts = append(drifts, compareFields(resource, field, desired.Object[field], found.Object[field])...)
+	}
+	return drifts, nil
+}
+
+// compareFields returns the fields of expected which have a different value in found,
+// the fields which are only in found are set by the controllers and not reported
+func compareFields(resource, path string, expected, found interface{}) []drift {
+	if e, ok := expected.(map[string]interface{}); ok {
+		f, ok := found.(map[string]interface{})
+		if !ok {
+			if found == nil && len(e) == 0 {
+				return nil
+			}
+			return []drift{newDrift(resource, path, expected, found)}
+		}
+		drifts := make([]drift, 0)
+		for _, k := range sortedKeys(e) {
+			drifts = append(drifts, compareFields(resource, path+"."+k, e[k], f[k])...)
+		}
+		return drifts
+	}
+	if expected == autoDetect {
+		return nil
+	}
+	if reflect.DeepEqual(normalize(expected), normalize(found)) {
+		return nil
+	}
+	return []drift{newDrift(resource, path, expected, found)}
+}
+
+func newDrift(resource, path string, expected, found interface{}) drift {
+	return drift{Resource: resource, Field: path, Expected: formatValue(expected), Found: formatValue(found)}
+}
+
+// normalize converts the value to its json representation so the numbers have the same type
+// whether they come from the templates or from the hub
+func normalize(v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var n interface{}
+	if err := json.Unmarshal(b, &n); err != nil {
+		return v
+	}
+	return n
+}
+
+// formatValue returns the value as displayed in the table, the lists are summarized
+func formatValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return missingValue
+	case string:
+		return t
+	case []interface{}:
+		return fmt.Sprintf("%d items", len(t))
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
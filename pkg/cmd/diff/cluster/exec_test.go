@@ -0,0 +1,214 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var testDir = filepath.Join("..", "..", "..", "..", "test", "unit")
+
+func newTestOptions(t *testing.T, streams genericclioptions.IOStreams) *Options {
+	o := &Options{
+		applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(streams),
+		printOptions:            printers.NewPrintOptions(),
+	}
+	o.applierScenariosOptions.ValuesPaths = []string{filepath.Join(testDir, "resources", "attach", "cluster", "values-with-data.yaml")}
+	if err := o.complete(nil, []string{"test"}); err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func newNginxDeployment(image string) *unstructured.Unstructured {
+	deploy := &unstructured.Unstructured{}
+	deploy.SetAPIVersion("apps/v1")
+	deploy.SetKind("Deployment")
+	deploy.SetName("nginx")
+	deploy.SetNamespace("default")
+	unstructured.SetNestedField(deploy.Object, image, "spec", "template", "spec", "containers", "image")
+	return deploy
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		mutate     func(objs []*unstructured.Unstructured) []*unstructured.Unstructured
+		wantErr    bool
+		wantOutput []string
+	}{
+		{
+			name:       "No drift",
+			wantOutput: []string{"No drift found for cluster test"},
+		},
+		{
+			name: "No drift, labels detected and set by the controllers",
+			mutate: func(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
+				for _, o := range objs {
+					if o.GetKind() == "ManagedCluster" {
+						o.SetLabels(map[string]string{"cloud": "Amazon", "vendor": "OpenShift", "name": "test"})
+						unstructured.SetNestedField(o.Object, []interface{}{map[string]interface{}{"url": "https://test:6443"}}, "spec", "managedClusterClientConfigs")
+					}
+				}
+				return objs
+			},
+			wantOutput: []string{"No drift found for cluster test"},
+		},
+		{
+			name: "Drift, addon disabled and client not accepted",
+			mutate: func(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
+				for _, o := range objs {
+					switch o.GetKind() {
+					case "KlusterletAddonConfig":
+						unstructured.SetNestedField(o.Object, false, "spec", "searchCollector", "enabled")
+					case "ManagedCluster":
+						unstructured.SetNestedField(o.Object, false, "spec", "hubAcceptsClient")
+					}
+				}
+				return objs
+			},
+			wantErr: true,
+			wantOutput: []string{
+				"KlusterletAddonConfig/test/test   spec.searchCollector.enabled   true       false",
+				"ManagedCluster/test",
+				"spec.hubAcceptsClient",
+			},
+		},
+		{
+			name: "Drift, resource deleted",
+			mutate: func(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
+				kept := make([]*unstructured.Unstructured, 0)
+				for _, o := range objs {
+					if o.GetKind() != "KlusterletAddonConfig" {
+						kept = append(kept, o)
+					}
+				}
+				return kept
+			},
+			wantErr:    true,
+			wantOutput: []string{"KlusterletAddonConfig/test/test", "present", missingValue},
+		},
+		{
+			name: "Drift, manifestwork edited",
+			mutate: func(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
+				for _, o := range objs {
+					if o.GetKind() == "ManifestWork" {
+						unstructured.SetNestedSlice(o.Object, []interface{}{newNginxDeployment("nginx:latest").Object}, "spec", "workload", "manifests")
+					}
+				}
+				return objs
+			},
+			wantErr:    true,
+			wantOutput: []string{"ManifestWork/test/nginx", "spec.workload.manifests"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newTestOptions(t, streams)
+			o.works = map[string][]*unstructured.Unstructured{
+				"nginx": {newNginxDeployment("nginx:1.21")},
+			}
+			desired, err := o.desiredResources()
+			if err != nil {
+				t.Fatal(err)
+			}
+			hub := make([]*unstructured.Unstructured, 0, len(desired))
+			for _, d := range desired {
+				hub = append(hub, d.DeepCopy())
+			}
+			if tt.mutate != nil {
+				hub = tt.mutate(hub)
+			}
+			objs := make([]runtime.Object, 0, len(hub))
+			for _, h := range hub {
+				objs = append(objs, h)
+			}
+			if err := o.runWithClient(fake.NewClient(objs...)); (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v, output %s", err, tt.wantErr, out.String())
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output must contain %q, got\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func Test_compareFields(t *testing.T) {
+	expected := map[string]interface{}{
+		"leaseDurationSeconds": int64(60),
+		"labels":               map[string]interface{}{"cloud": autoDetect, "env": "dev"},
+		"none":                 map[string]interface{}{},
+	}
+	found := map[string]interface{}{
+		"leaseDurationSeconds": float64(60),
+		"labels":               map[string]interface{}{"cloud": "Amazon", "env": "prod", "name": "test"},
+	}
+	drifts := compareFields("ManagedCluster/test", "spec", expected, found)
+	if len(drifts) != 1 || drifts[0].Field != "spec.labels.env" || drifts[0].Expected != "dev" || drifts[0].Found != "prod" {
+		t.Errorf("compareFields() = %v, want the env label only", drifts)
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			options: Options{
+				clusterName:             "test",
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				printOptions:            printers.NewPrintOptions(),
+			},
+		},
+		{
+			name: "Failed, cluster name missing",
+			options: Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				printOptions:            printers.NewPrintOptions(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed, spec and values",
+			options: Options{
+				clusterName:             "test",
+				specPath:                "spec.yaml",
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{ValuesPaths: []string{"values.yaml"}},
+				printOptions:            printers.NewPrintOptions(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed, empty manifestwork",
+			options: Options{
+				clusterName:             "test",
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				printOptions:            printers.NewPrintOptions(),
+				workPaths:               map[string]string{"nginx": "empty"},
+				works:                   map[string][]*unstructured.Unstructured{"nginx": {}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
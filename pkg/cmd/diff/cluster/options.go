@@ -0,0 +1,31 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	applierScenariosOptions *applierscenarios.ApplierScenariosOptions
+	printOptions            *printers.PrintOptions
+	clusterName             string
+	specPath                string
+	workPaths               map[string]string
+	values                  map[string]interface{}
+	works                   map[string][]*unstructured.Unstructured
+	ctx                     context.Context
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(streams),
+		printOptions:            printers.NewPrintOptions(),
+		ctx:                     context.Background(),
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright Contributors to the Open Cluster Management project
+package example
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Write a starter values file for the attach of a cluster
+%[1]s example attach cluster > values.yaml
+
+# The scenario name can be used instead of the command
+%[1]s example clusterpool > clusterpool.yaml
+`
+
+// NewCmd provides a cobra command scaffolding the values file of a command
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "example <command>",
+		Short: "Print a starter values file of a command with the description of the values",
+		Long: fmt.Sprintf("Print a starter values file of a command, with the default values and the description of each value "+
+			"from the bundled values template. The commands are %s", strings.Join(commands(), ", ")),
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// commands returns the commands reading a values file
+func commands() []string {
+	commands := make([]string, len(applierscenarios.Scenarios))
+	for i, s := range applierscenarios.Scenarios {
+		commands[i] = s.Command
+	}
+	return commands
+}
@@ -0,0 +1,45 @@
+// Copyright Contributors to the Open Cluster Management project
+package example
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.command = strings.Join(args, " ")
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.command == "" {
+		return fmt.Errorf("the command is missing, one of %s", strings.Join(commands(), ", "))
+	}
+	if _, ok := o.scenario(); !ok {
+		return fmt.Errorf("%s has no values file, the commands are %s", o.command, strings.Join(commands(), ", "))
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	s, _ := o.scenario()
+	b, err := s.ScaffoldValues(helpers.GetExampleHeader())
+	if err != nil {
+		return err
+	}
+	_, err = o.Out.Write(b)
+	return err
+}
+
+// scenario returns the scenario of the command, or of the scenario name
+func (o *Options) scenario() (applierscenarios.Scenario, bool) {
+	if s, ok := applierscenarios.GetScenarioByCommand(o.command); ok {
+		return s, true
+	}
+	return applierscenarios.GetScenario(o.command)
+}
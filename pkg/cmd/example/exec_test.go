@@ -0,0 +1,49 @@
+// Copyright Contributors to the Open Cluster Management project
+package example
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_run(t *testing.T) {
+	for _, args := range [][]string{{"attach", "cluster"}, {"attach"}} {
+		streams, _, out, _ := genericclioptions.NewTestIOStreams()
+		o := newOptions(streams)
+		if err := o.complete(nil, args); err != nil {
+			t.Fatal(err)
+		}
+		if err := o.validate(); err != nil {
+			t.Fatal(err)
+		}
+		if err := o.run(); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out.String(), "attach cluster --values values.yaml") {
+			t.Errorf("the header must give the command reading the values, got:\n%s", out.String())
+		}
+		if strings.Contains(out.String(), applierscenarios.RequiredAnnotation) {
+			t.Error("the annotations of the values template must be removed")
+		}
+		values := make(map[string]interface{})
+		if err := yaml.Unmarshal(out.Bytes(), &values); err != nil {
+			t.Fatalf("the values file must be valid yaml: %v", err)
+		}
+		if _, ok := values["managedClusterName"]; !ok {
+			t.Errorf("managedClusterName expected in the values, got %v", values)
+		}
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	for _, command := range []string{"", "get clusters"} {
+		o := &Options{command: command}
+		if err := o.validate(); err == nil {
+			t.Errorf("error expected for %q", command)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright Contributors to the Open Cluster Management project
+package example
+
+import (
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	command string
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright Contributors to the Open Cluster Management project
+package inventory
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Print the inventory of the hub
+%[1]s export inventory
+
+# Write the inventory of the hub in a file to reconcile another hub with import inventory
+%[1]s export inventory --output inventory.yaml
+`
+
+// NewCmd provides a cobra command exporting the clusters, clustersets and addon configurations of the hub
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "Export the inventory of the hub",
+		Long: "Export a portable description of the managed clusters of the hub, with their labels, annotations, " +
+			"clusterset and addon configuration, and of the clustersets",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.outputFile, "output", "", "The file where the inventory is written, if not set it is printed")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
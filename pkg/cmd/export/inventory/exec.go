@@ -0,0 +1,67 @@
+// Copyright Contributors to the Open Cluster Management project
+package inventory
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	return nil
+}
+
+func (o *Options) validate() error {
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	inventory, err := helpers.ExportInventory(o.ctx, client)
+	if err != nil {
+		return err
+	}
+	b, err := yaml.Marshal(inventory)
+	if err != nil {
+		return err
+	}
+
+	if o.outputFile == "" {
+		_, err = o.Out.Write(b)
+		return err
+	}
+
+	//Write in a temporary file so a failure never leaves a partial inventory
+	tmpFile, err := helpers.TempFile(o.outputFile)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile)
+	if err := ioutil.WriteFile(tmpFile, b, 0600); err != nil {
+		return err
+	}
+	if err := helpers.ReplaceFile(tmpFile, o.outputFile); err != nil {
+		return err
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "Inventory of %d clusters and %d clustersets written in %s\n",
+		len(inventory.Clusters), len(inventory.ClusterSets), o.outputFile)
+	printers.PrintIdentifier(o.Out, o.outputFile)
+	return nil
+}
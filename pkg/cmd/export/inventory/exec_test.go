@@ -0,0 +1,55 @@
+// Copyright Contributors to the Open Cluster Management project
+package inventory
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_runWithClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cm-export-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name       string
+		outputFile string
+	}{
+		{name: "Success, printed"},
+		{name: "Success, written in a file", outputFile: filepath.Join(dir, "inventory.yaml")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				outputFile: tt.outputFile,
+				IOStreams:  streams,
+			}
+			if err := o.runWithClient(fake.NewClient(fake.NewManagedCluster("cluster1", fake.WithLabels(map[string]string{"env": "dev"})))); err != nil {
+				t.Fatal(err)
+			}
+			inventory := out.String()
+			if tt.outputFile != "" {
+				b, err := ioutil.ReadFile(tt.outputFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+				inventory = string(b)
+			}
+			for _, want := range []string{"kind: " + helpers.InventoryKind, "name: cluster1", "env: dev"} {
+				if !strings.Contains(inventory, want) {
+					t.Errorf("the inventory must contain %s, got %s", want, inventory)
+				}
+			}
+		})
+	}
+}
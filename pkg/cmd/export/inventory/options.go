@@ -0,0 +1,25 @@
+// Copyright Contributors to the Open Cluster Management project
+package inventory
+
+import (
+	"context"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags *genericclioptions.ConfigFlags
+	outputFile  string
+	ctx         context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ctx:         context.Background(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright Contributors to the Open Cluster Management project
+package gc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# List the orphaned artifacts of the hub without deleting them
+%[1]s gc --dry-run
+
+# Delete the namespaces and the ManifestWorks of the clusters which no longer exist
+%[1]s gc --kinds namespaces,manifestworks
+
+# Delete the clusters past the expiry set by the --expires flag of attach and create
+%[1]s gc --expired --kinds manifestworks
+
+# Delete the import secrets of the joined clusters older than 30 days, without confirmation
+%[1]s gc --kinds import-secrets --older-than 720h --yes
+`
+
+// NewCmd provides a cobra command deleting the artifacts left on the hub by the clusters
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "gc",
+		Short:        "Delete the orphaned artifacts of the clusters on the hub",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&o.kinds, "kinds", gcKinds, fmt.Sprintf("The kinds of orphaned artifacts to delete, among %s", strings.Join(gcKinds, ", ")))
+	helpers.DurationVar(cmd.Flags(), &o.olderThan, "older-than", 7*24*time.Hour, "The minimum age of the import secrets of the joined clusters to delete, e.g. 168h")
+	cmd.Flags().BoolVar(&o.expired, "expired", false, "If set, the clusters past their expiry are deleted as well, with their ClusterDeployment which destroys the clusters created by create cluster, the protected clusters are kept")
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, "If set, the orphaned artifacts are printed but not deleted")
+	helpers.AddYesFlag(cmd.Flags(), &o.yes)
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
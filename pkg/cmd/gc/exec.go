@@ -0,0 +1,390 @@
+// Copyright Contributors to the Open Cluster Management project
+package gc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	kindNamespaces        = "namespaces"
+	kindAutoImportSecrets = "auto-import-secrets"
+	kindImportSecrets     = "import-secrets"
+	kindManifestWorks     = "manifestworks"
+	//kindClusters and kindClusterDeployments are the expired clusters collected with --expired
+	kindClusters           = "clusters"
+	kindClusterDeployments = "clusterdeployments"
+
+	//clusterNamespaceLabel is set by the registration on the namespace of a managed cluster
+	clusterNamespaceLabel = "cluster.open-cluster-management.io/managedCluster"
+	autoImportSecretName  = "auto-import-secret"
+	joinedCondition       = "ManagedClusterJoined"
+)
+
+// gcKinds are the kinds of orphaned artifacts, in their deletion order so the namespaces are deleted last
+var gcKinds = []string{kindManifestWorks, kindAutoImportSecrets, kindImportSecrets, kindNamespaces}
+
+// Orphan is an artifact left on the hub by a cluster which no longer needs it
+type Orphan struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+	object    runtime.Object
+	//missingCluster is the cluster which did not exist when the artifact was found, the artifact is kept if it is created since
+	missingCluster string
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	return nil
+}
+
+func (o *Options) validate() error {
+	if len(o.kinds) == 0 {
+		return fmt.Errorf("kinds is empty, supported kinds are %s", strings.Join(gcKinds, ", "))
+	}
+	for _, k := range o.kinds {
+		if !contains(gcKinds, k) {
+			return fmt.Errorf("unsupported kind %s, supported kinds are %s", k, strings.Join(gcKinds, ", "))
+		}
+	}
+	if o.olderThan < 0 {
+		return fmt.Errorf("older-than can not be negative")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	orphans, err := o.findOrphans(client, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(orphans) == 0 {
+		fmt.Fprintln(printers.Messages(o.ErrOut), "No orphaned artifact found")
+		return nil
+	}
+
+	table := &printers.Table{Headers: []string{"KIND", "NAMESPACE", "NAME", "REASON"}}
+	for _, orphan := range orphans {
+		table.AddRow(orphan.Kind, orphan.Namespace, orphan.Name, orphan.Reason)
+	}
+	if err := o.printOptions.Print(o.Out, table, orphans); err != nil {
+		return err
+	}
+	if o.dryRun {
+		return nil
+	}
+
+	if !o.yes {
+		summary := []string{fmt.Sprintf("The %d orphaned artifacts above will be deleted.", len(orphans))}
+		if err := helpers.Confirm(o.In, o.ErrOut, summary, "gc"); err != nil {
+			return err
+		}
+	}
+	deleted := 0
+	for _, orphan := range orphans {
+		ok, err := o.deleteOrphan(client, orphan)
+		if err != nil {
+			return fmt.Errorf("%d orphaned artifacts deleted, unable to delete %s %s: %s", deleted, orphan.Kind, orphan.id(), err.Error())
+		}
+		if !ok {
+			continue
+		}
+		deleted++
+		fmt.Fprintf(printers.Messages(o.ErrOut), "%s %s deleted\n", orphan.Kind, orphan.id())
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "%d orphaned artifacts deleted\n", deleted)
+	return nil
+}
+
+// deleteOrphan deletes the artifact unless it is not orphaned anymore: its cluster was created since it was found,
+// or the artifact was changed or recreated, which the preconditions of the deletion reject.
+// It returns false when the artifact is kept.
+func (o *Options) deleteOrphan(client crclient.Client, orphan Orphan) (bool, error) {
+	if orphan.missingCluster != "" {
+		mc := &unstructured.Unstructured{}
+		mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+		err := client.Get(o.ctx, types.NamespacedName{Name: orphan.missingCluster}, mc)
+		switch {
+		case err == nil:
+			fmt.Fprintf(printers.Messages(o.ErrOut), "%s %s kept, cluster %s was created\n", orphan.Kind, orphan.id(), orphan.missingCluster)
+			return false, nil
+		case !errors.IsNotFound(err):
+			return false, err
+		}
+	}
+	accessor, err := meta.Accessor(orphan.object)
+	if err != nil {
+		return false, err
+	}
+	uid, resourceVersion := accessor.GetUID(), accessor.GetResourceVersion()
+	err = client.Delete(o.ctx, orphan.object, crclient.Preconditions{UID: &uid, ResourceVersion: &resourceVersion})
+	switch {
+	case errors.IsNotFound(err):
+		return true, nil
+	case errors.IsConflict(err):
+		fmt.Fprintf(printers.Messages(o.ErrOut), "%s %s kept, it changed since it was found\n", orphan.Kind, orphan.id())
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (orphan Orphan) id() string {
+	if orphan.Namespace == "" {
+		return orphan.Name
+	}
+	return orphan.Namespace + "/" + orphan.Name
+}
+
+// findOrphans returns the orphaned artifacts of the selected kinds, in their deletion order
+func (o *Options) findOrphans(client crclient.Client, now time.Time) ([]Orphan, error) {
+	//joined holds the existing clusters and whether they joined the hub
+	joined := make(map[string]bool)
+	mcs := &unstructured.UnstructuredList{}
+	mcs.SetGroupVersionKind(helpers.ManagedClusterGVK.GroupVersion().WithKind(helpers.ManagedClusterGVK.Kind + "List"))
+	if err := client.List(o.ctx, mcs); err != nil {
+		return nil, err
+	}
+	for _, mc := range mcs.Items {
+		conditions, _, _ := unstructured.NestedSlice(mc.Object, "status", "conditions")
+		joined[mc.GetName()] = helpers.GetConditionStatus(conditions, joinedCondition) == "True"
+	}
+	clusterNames := make([]string, 0, len(joined))
+	for name := range joined {
+		clusterNames = append(clusterNames, name)
+	}
+	sort.Strings(clusterNames)
+
+	orphans := make([]Orphan, 0)
+	if o.expired {
+		found, err := o.findExpiredClusters(client, mcs.Items, now)
+		if err != nil {
+			return nil, err
+		}
+		orphans = append(orphans, found...)
+	}
+	for _, kind := range gcKinds {
+		if !contains(o.kinds, kind) {
+			continue
+		}
+		var found []Orphan
+		var err error
+		switch kind {
+		case kindManifestWorks:
+			found, err = findManifestWorks(o.ctx, client, joined)
+		case kindAutoImportSecrets:
+			found, err = findAutoImportSecrets(o.ctx, client, clusterNames, joined)
+		case kindImportSecrets:
+			found, err = findImportSecrets(o.ctx, client, clusterNames, joined, now.Add(-o.olderThan))
+		case kindNamespaces:
+			found, err = findNamespaces(o.ctx, client, joined)
+		}
+		if err != nil {
+			return nil, err
+		}
+		orphans = append(orphans, found...)
+	}
+	return orphans, nil
+}
+
+// findExpiredClusters returns the clusters past their expiry followed by their ClusterDeployment, if any.
+// The protected clusters are kept.
+func (o *Options) findExpiredClusters(client crclient.Client, mcs []unstructured.Unstructured, now time.Time) ([]Orphan, error) {
+	orphans := make([]Orphan, 0)
+	for i := range mcs {
+		mc := &mcs[i]
+		if !helpers.IsExpired(mc, now) || mc.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if _, protected := helpers.ProtectionReason(mc); protected {
+			fmt.Fprintf(printers.Messages(o.ErrOut), "Cluster %s expired but is protected, it is kept\n", mc.GetName())
+			continue
+		}
+		expires, _ := helpers.ClusterExpiry(mc)
+		reason := fmt.Sprintf("expired %s ago", duration.HumanDuration(now.Sub(expires)))
+		for _, a := range []struct{ name, annotation string }{{"owner", helpers.OwnerAnnotation}, {"ticket", helpers.TicketAnnotation}} {
+			if v := mc.GetAnnotations()[a.annotation]; v != "" {
+				reason += fmt.Sprintf(", %s %s", a.name, v)
+			}
+		}
+		orphans = append(orphans, Orphan{
+			Kind:   kindClusters,
+			Name:   mc.GetName(),
+			Reason: reason,
+			object: mc,
+		})
+		cd := &unstructured.Unstructured{}
+		cd.SetGroupVersionKind(helpers.ClusterDeploymentGVK)
+		err := client.Get(o.ctx, types.NamespacedName{Namespace: mc.GetName(), Name: mc.GetName()}, cd)
+		switch {
+		//Hive is not installed on the hubs without cluster provisioning
+		case errors.IsNotFound(err) || meta.IsNoMatchError(err):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		orphans = append(orphans, Orphan{
+			Kind:      kindClusterDeployments,
+			Namespace: cd.GetNamespace(),
+			Name:      cd.GetName(),
+			Reason:    fmt.Sprintf("cluster %s expired", mc.GetName()),
+			object:    cd,
+		})
+	}
+	return orphans, nil
+}
+
+// findManifestWorks returns the ManifestWorks of the namespaces which are not the namespace of a cluster
+func findManifestWorks(ctx context.Context, client crclient.Client, joined map[string]bool) ([]Orphan, error) {
+	works := &unstructured.UnstructuredList{}
+	works.SetGroupVersionKind(helpers.ManifestWorkListGVK)
+	if err := client.List(ctx, works); err != nil {
+		return nil, err
+	}
+	orphans := make([]Orphan, 0)
+	for i := range works.Items {
+		work := &works.Items[i]
+		if _, ok := joined[work.GetNamespace()]; ok || work.GetDeletionTimestamp() != nil {
+			continue
+		}
+		orphans = append(orphans, Orphan{
+			Kind:      kindManifestWorks,
+			Namespace: work.GetNamespace(),
+			Name:      work.GetName(),
+			Reason:    fmt.Sprintf("cluster %s does not exist", work.GetNamespace()),
+			object:    work,
+
+			missingCluster: work.GetNamespace(),
+		})
+	}
+	return orphans, nil
+}
+
+// findAutoImportSecrets returns the auto-import secrets of the joined clusters, the import controller
+// deletes them once the cluster is imported so the remaining ones hold credentials which are not used anymore
+func findAutoImportSecrets(ctx context.Context, client crclient.Client, clusterNames []string, joined map[string]bool) ([]Orphan, error) {
+	orphans := make([]Orphan, 0)
+	for _, name := range clusterNames {
+		if !joined[name] {
+			continue
+		}
+		secret, err := getSecret(ctx, client, name, autoImportSecretName)
+		if err != nil {
+			return nil, err
+		}
+		if secret == nil {
+			continue
+		}
+		orphans = append(orphans, Orphan{
+			Kind:      kindAutoImportSecrets,
+			Namespace: name,
+			Name:      autoImportSecretName,
+			Reason:    fmt.Sprintf("cluster %s joined", name),
+			object:    secret,
+		})
+	}
+	return orphans, nil
+}
+
+// findImportSecrets returns the import secrets of the joined clusters created before the given time
+func findImportSecrets(ctx context.Context, client crclient.Client, clusterNames []string, joined map[string]bool, before time.Time) ([]Orphan, error) {
+	orphans := make([]Orphan, 0)
+	for _, name := range clusterNames {
+		if !joined[name] {
+			continue
+		}
+		secret, err := getSecret(ctx, client, name, name+"-import")
+		if err != nil {
+			return nil, err
+		}
+		if secret == nil {
+			continue
+		}
+		created := secret.CreationTimestamp.Time
+		if !created.Before(before) {
+			continue
+		}
+		orphans = append(orphans, Orphan{
+			Kind:      kindImportSecrets,
+			Namespace: name,
+			Name:      secret.Name,
+			Reason:    fmt.Sprintf("cluster %s joined, created %s ago", name, duration.HumanDuration(time.Since(created))),
+			object:    secret,
+		})
+	}
+	return orphans, nil
+}
+
+// findNamespaces returns the namespaces labeled for a cluster which does not exist, the ones being deleted are skipped
+func findNamespaces(ctx context.Context, client crclient.Client, joined map[string]bool) ([]Orphan, error) {
+	namespaces := &corev1.NamespaceList{}
+	if err := client.List(ctx, namespaces, crclient.HasLabels{clusterNamespaceLabel}); err != nil {
+		return nil, err
+	}
+	orphans := make([]Orphan, 0)
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		cluster := ns.Labels[clusterNamespaceLabel]
+		if _, ok := joined[cluster]; ok || ns.DeletionTimestamp != nil || ns.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		orphans = append(orphans, Orphan{
+			Kind:   kindNamespaces,
+			Name:   ns.Name,
+			Reason: fmt.Sprintf("cluster %s does not exist", cluster),
+			object: ns,
+
+			missingCluster: cluster,
+		})
+	}
+	return orphans, nil
+}
+
+// getSecret returns the secret or nil if it does not exist
+func getSecret(ctx context.Context, client crclient.Client, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
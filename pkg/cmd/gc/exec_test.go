@@ -0,0 +1,243 @@
+// Copyright Contributors to the Open Cluster Management project
+package gc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// newCluster returns a cluster which joined the hub or not
+func newCluster(name string, joined bool) *unstructured.Unstructured {
+	status := "False"
+	if joined {
+		status = "True"
+	}
+	return fake.NewManagedCluster(name, fake.WithConditions(fake.NewCondition(joinedCondition, status)))
+}
+
+func newClusterNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{clusterNamespaceLabel: name}}}
+}
+
+func newSecret(namespace, name string, created time.Time) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, CreationTimestamp: metav1.NewTime(created)}}
+}
+
+func newManifestWork(namespace, name string) *unstructured.Unstructured {
+	work := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+	work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	work.SetNamespace(namespace)
+	work.SetName(name)
+	return work
+}
+
+func newObjects() []runtime.Object {
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	return []runtime.Object{
+		newCluster("joined", true),
+		newCluster("pending", false),
+		newClusterNamespace("joined"),
+		newClusterNamespace("pending"),
+		newClusterNamespace("gone"),
+		newSecret("joined", autoImportSecretName, old),
+		newSecret("joined", "joined-import", old),
+		newSecret("pending", autoImportSecretName, old),
+		newSecret("pending", "pending-import", old),
+		newManifestWork("joined", "app"),
+		newManifestWork("gone", "app"),
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		kinds   []string
+		wantErr bool
+	}{
+		{name: "Success", kinds: gcKinds},
+		{name: "Failed, empty kinds", kinds: []string{}, wantErr: true},
+		{name: "Failed, unsupported kind", kinds: []string{"secrets"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.kinds = tt.kinds
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Options.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_findOrphans(t *testing.T) {
+	o := newOptions(genericclioptions.NewTestIOStreamsDiscard())
+	o.kinds = gcKinds
+	o.olderThan = 7 * 24 * time.Hour
+	orphans, err := o.findOrphans(fake.NewClient(newObjects()...), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, 0, len(orphans))
+	for _, orphan := range orphans {
+		got = append(got, orphan.Kind+" "+orphan.id())
+	}
+	want := []string{
+		"manifestworks gone/app",
+		"auto-import-secrets joined/auto-import-secret",
+		"import-secrets joined/joined-import",
+		"namespaces gone",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("orphans = %v, want %v", got, want)
+	}
+
+	//The recent import secrets are kept
+	o.kinds = []string{kindImportSecrets}
+	o.olderThan = 60 * 24 * time.Hour
+	orphans, err = o.findOrphans(fake.NewClient(newObjects()...), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orphans) != 0 {
+		t.Errorf("no orphan expected, got %v", orphans)
+	}
+}
+
+func TestOptions_findOrphans_expired(t *testing.T) {
+	now := time.Now()
+	newExpiringCluster := func(name string, expires time.Time, annotations map[string]string) *unstructured.Unstructured {
+		mc := newCluster(name, true)
+		annotations[helpers.ExpiresAnnotation] = expires.UTC().Format(time.RFC3339)
+		mc.SetAnnotations(annotations)
+		return mc
+	}
+	cd := &unstructured.Unstructured{}
+	cd.SetGroupVersionKind(helpers.ClusterDeploymentGVK)
+	cd.SetNamespace("expired")
+	cd.SetName("expired")
+	client := fake.NewClient(
+		newExpiringCluster("expired", now.Add(-48*time.Hour), map[string]string{helpers.OwnerAnnotation: "team-a", helpers.TicketAnnotation: "DEV-42"}),
+		newExpiringCluster("attached", now.Add(-time.Hour), map[string]string{}),
+		newExpiringCluster("protected", now.Add(-time.Hour), map[string]string{helpers.ProtectionAnnotation: "demo"}),
+		newExpiringCluster("active", now.Add(time.Hour), map[string]string{}),
+		newCluster("permanent", true),
+		cd,
+	)
+	o := newOptions(genericclioptions.NewTestIOStreamsDiscard())
+	o.kinds = []string{kindManifestWorks}
+	o.expired = true
+	orphans, err := o.findOrphans(client, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, 0, len(orphans))
+	for _, orphan := range orphans {
+		got = append(got, orphan.Kind+" "+orphan.id()+" "+orphan.Reason)
+	}
+	want := []string{
+		"clusters attached expired 60m ago",
+		"clusters expired expired 2d ago, owner team-a, ticket DEV-42",
+		"clusterdeployments expired/expired cluster expired expired",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("orphans = %v, want %v", got, want)
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	exists := func(client crclient.Client, obj runtime.Object, namespace, name string) bool {
+		err := client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, obj)
+		if err != nil && !errors.IsNotFound(err) {
+			t.Fatal(err)
+		}
+		return err == nil
+	}
+
+	//The dry run deletes nothing
+	client := fake.NewClient(newObjects()...)
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	o.kinds = gcKinds
+	o.dryRun = true
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "gone") {
+		t.Errorf("the orphaned namespace must be printed, got %s", out.String())
+	}
+	if !exists(client, &corev1.Namespace{}, "", "gone") {
+		t.Error("the dry run must not delete the namespace")
+	}
+
+	//Without --yes nor input the deletion is aborted
+	o = newOptions(genericclioptions.NewTestIOStreamsDiscard())
+	o.kinds = gcKinds
+	o.In = nil
+	if err := o.runWithClient(client); err == nil {
+		t.Error("the deletion must be confirmed")
+	}
+
+	//The selected kinds are deleted
+	o.kinds = []string{kindNamespaces, kindAutoImportSecrets}
+	o.yes = true
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	if exists(client, &corev1.Namespace{}, "", "gone") {
+		t.Error("the orphaned namespace must be deleted")
+	}
+	if exists(client, &corev1.Secret{}, "joined", autoImportSecretName) {
+		t.Error("the auto-import secret of the joined cluster must be deleted")
+	}
+	if !exists(client, &corev1.Secret{}, "pending", autoImportSecretName) {
+		t.Error("the auto-import secret of the pending cluster must be kept")
+	}
+	if !exists(client, &corev1.Secret{}, "joined", "joined-import") {
+		t.Error("the import secret must be kept, its kind is not selected")
+	}
+}
+
+func TestOptions_deleteOrphan(t *testing.T) {
+	client := fake.NewClient(newObjects()...)
+	o := newOptions(genericclioptions.NewTestIOStreamsDiscard())
+	o.kinds = []string{kindNamespaces, kindManifestWorks}
+	o.ctx = context.TODO()
+	orphans, err := o.findOrphans(client, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orphans) != 2 {
+		t.Fatalf("findOrphans() = %d orphans, want the namespace and the ManifestWork of gone", len(orphans))
+	}
+
+	//The cluster is created after the orphans were found
+	if err := client.Create(context.TODO(), newCluster("gone", false)); err != nil {
+		t.Fatal(err)
+	}
+	for _, orphan := range orphans {
+		deleted, err := o.deleteOrphan(client, orphan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleted {
+			t.Errorf("%s %s must be kept, its cluster was created", orphan.Kind, orphan.id())
+		}
+	}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "gone"}, &corev1.Namespace{}); err != nil {
+		t.Errorf("the namespace must be kept, got %v", err)
+	}
+}
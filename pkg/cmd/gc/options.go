@@ -0,0 +1,37 @@
+// Copyright Contributors to the Open Cluster Management project
+package gc
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags  *genericclioptions.ConfigFlags
+	printOptions *printers.PrintOptions
+	//kinds are the kinds of orphaned artifacts to collect
+	kinds     []string
+	olderThan time.Duration
+	dryRun    bool
+	//expired also deletes the clusters past their expiry
+	expired bool
+	//yes skips the confirmation prompt
+	yes bool
+	//ctx is canceled on Ctrl+C to abort the calls to the hub
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		printOptions: printers.NewPrintOptions(),
+
+		IOStreams: streams,
+	}
+}
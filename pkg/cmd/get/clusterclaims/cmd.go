@@ -0,0 +1,51 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Show the claims of a cluster
+%[1]s get clusterclaims mycluster
+
+# Show the claims of all clusters, one column per claim
+%[1]s get clusterclaims --all-clusters
+`
+
+// NewCmd provides a cobra command showing the claims exposed by the managed clusters
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "clusterclaims [cluster]",
+		Short:        "Show the claims exposed by a managed cluster, such as its platform, product, version and region",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&o.allClusters, "all-clusters", "A", false, "If set, show the claims of all clusters")
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,158 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 0 {
+		o.clusterName = args[0]
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.clusterName == "" && !o.allClusters {
+		return fmt.Errorf("either the cluster name or --all-clusters must be provided")
+	}
+	if o.clusterName != "" && o.allClusters {
+		return fmt.Errorf("the cluster name and --all-clusters are mutually exclusive")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	if o.allClusters {
+		return o.printClusterClaims(client)
+	}
+
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName}, mc); err != nil {
+		return err
+	}
+	claims := getClusterClaims(mc)
+	names := sortedKeys(claims)
+
+	table := &printers.Table{
+		Headers: []string{"NAME", "VALUE"},
+	}
+	for _, name := range names {
+		table.AddRow(name, claims[name])
+	}
+	return o.printOptions.Print(o.Out, table, claims)
+}
+
+// printClusterClaims prints the claims of all clusters, one row per cluster and one column per claim
+func (o *Options) printClusterClaims(client crclient.Client) error {
+	mcs := &unstructured.UnstructuredList{}
+	mcs.SetGroupVersionKind(helpers.ManagedClusterGVK.GroupVersion().WithKind(helpers.ManagedClusterGVK.Kind + "List"))
+	if err := client.List(o.ctx, mcs); err != nil {
+		return err
+	}
+	sort.Slice(mcs.Items, func(i, j int) bool {
+		return mcs.Items[i].GetName() < mcs.Items[j].GetName()
+	})
+
+	clusterClaims := make(map[string]map[string]string, len(mcs.Items))
+	names := make([]string, 0)
+	for i := range mcs.Items {
+		claims := getClusterClaims(&mcs.Items[i])
+		clusterClaims[mcs.Items[i].GetName()] = claims
+		for name := range claims {
+			if !contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	table := &printers.Table{
+		Headers: append([]string{"CLUSTER"}, claimHeaders(names)...),
+	}
+	for i := range mcs.Items {
+		clusterName := mcs.Items[i].GetName()
+		row := []string{clusterName}
+		for _, name := range names {
+			row = append(row, clusterClaims[clusterName][name])
+		}
+		table.AddRow(row...)
+	}
+	return o.printOptions.Print(o.Out, table, clusterClaims)
+}
+
+// getClusterClaims returns the claims reported in the status of the managed cluster
+func getClusterClaims(mc *unstructured.Unstructured) map[string]string {
+	claims := map[string]string{}
+	statusClaims, _, _ := unstructured.NestedSlice(mc.Object, "status", "clusterClaims")
+	for _, c := range statusClaims {
+		claim, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(claim, "name")
+		value, _, _ := unstructured.NestedString(claim, "value")
+		if name != "" {
+			claims[name] = value
+		}
+	}
+	return claims
+}
+
+// claimHeaders returns the column of each claim, the first segment of the claim name
+// (platform for platform.open-cluster-management.io) unless two claims share it
+func claimHeaders(names []string) []string {
+	shortNames := map[string]int{}
+	for _, name := range names {
+		shortNames[strings.SplitN(name, ".", 2)[0]]++
+	}
+	headers := make([]string, 0, len(names))
+	for _, name := range names {
+		header := strings.SplitN(name, ".", 2)[0]
+		if shortNames[header] > 1 {
+			header = name
+		}
+		headers = append(headers, strings.ToUpper(header))
+	}
+	return headers
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
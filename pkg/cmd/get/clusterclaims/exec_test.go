@@ -0,0 +1,111 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// newCluster returns a cluster reporting the claims
+func newCluster(name string, claims map[string]string) *unstructured.Unstructured {
+	statusClaims := make([]interface{}, 0, len(claims))
+	for k, v := range claims {
+		statusClaims = append(statusClaims, map[string]interface{}{"name": k, "value": v})
+	}
+	return fake.NewManagedCluster(name, fake.WithField(statusClaims, "status", "clusterClaims"))
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := fake.NewClient(
+		newCluster("cluster1", map[string]string{
+			"platform.open-cluster-management.io": "AWS",
+			"region.open-cluster-management.io":   "us-east-1",
+			"version.openshift.io":                "4.7.13",
+		}),
+		newCluster("cluster2", map[string]string{
+			"platform.open-cluster-management.io": "GCP",
+			"product.open-cluster-management.io":  "GKE",
+		}),
+	)
+	tests := []struct {
+		name        string
+		options     Options
+		contains    []string
+		notContains []string
+		wantErr     bool
+	}{
+		{
+			name:        "One cluster",
+			options:     Options{clusterName: "cluster1"},
+			contains:    []string{"NAME", "version.openshift.io", "4.7.13"},
+			notContains: []string{"GCP"},
+		},
+		{
+			name:     "All clusters",
+			options:  Options{allClusters: true},
+			contains: []string{"CLUSTER", "PLATFORM", "PRODUCT", "REGION", "VERSION", "us-east-1", "GKE"},
+		},
+		{
+			name:    "Failed, cluster not found",
+			options: Options{clusterName: "cluster3"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := tt.options
+			o.printOptions = printers.NewPrintOptions()
+			o.IOStreams = streams
+			if err := o.runWithClient(client); (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got %s", c, out.String())
+				}
+			}
+			for _, c := range tt.notContains {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %q, got %s", c, out.String())
+				}
+			}
+		})
+	}
+}
+
+func Test_claimHeaders(t *testing.T) {
+	names := []string{"platform.open-cluster-management.io", "version.example.com", "version.openshift.io"}
+	want := []string{"PLATFORM", "VERSION.EXAMPLE.COM", "VERSION.OPENSHIFT.IO"}
+	if got := claimHeaders(names); !reflect.DeepEqual(got, want) {
+		t.Errorf("claimHeaders() = %v, want %v", got, want)
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr bool
+	}{
+		{name: "Success, cluster", options: Options{clusterName: "cluster1"}},
+		{name: "Success, all clusters", options: Options{allClusters: true}},
+		{name: "Failed, no cluster", wantErr: true},
+		{name: "Failed, cluster and all clusters", options: Options{clusterName: "cluster1", allClusters: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := tt.options
+			o.printOptions = printers.NewPrintOptions()
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
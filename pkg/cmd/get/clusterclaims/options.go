@@ -0,0 +1,30 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"context"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags  *genericclioptions.ConfigFlags
+	printOptions *printers.PrintOptions
+	clusterName  string
+	allClusters  bool
+	ctx          context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		printOptions: printers.NewPrintOptions(),
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# List the managed clusters with their availability
+%[1]s get clusters
+
+# Show the availability of the clusters per value of their env label
+%[1]s get clusters --group-by label:env
+
+# Show the availability per region of the production clusters
+%[1]s get clusters -l env=prod --group-by label:region
+
+# List the clusters of a clusterset
+%[1]s get clusters --clusterset prod
+`
+
+// NewCmd provides a cobra command listing the managed clusters, optionally grouped by a label
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "clusters",
+		Short:        "List the managed clusters with their availability, or their availability per group",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.selector, "selector", "l", "", "Label selector of the managed clusters to list, e.g. env=dev")
+	o.scope.AddClusterSetFlag(cmd.Flags())
+	cmd.Flags().BoolVar(&o.expired, "expired", false, "Only list the clusters past the expiry set by the --expires flag of attach and create, with their owner and ticket")
+	cmd.Flags().StringVar(&o.groupBy, "group-by", "", "Aggregate the clusters per value of a label, as label:<key>, with the availability of each group")
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,199 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/validation"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// groupByLabelPrefix is the prefix of the --group-by label keys
+	groupByLabelPrefix = "label:"
+	// noGroup is the group of the clusters without the label
+	noGroup = "<none>"
+)
+
+// cluster is a managed cluster with the status of its conditions
+type cluster struct {
+	Name      string            `json:"name"`
+	Accepted  string            `json:"hubAccepted"`
+	Joined    string            `json:"joined"`
+	Available string            `json:"available"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Owner     string            `json:"owner,omitempty"`
+	Ticket    string            `json:"ticket,omitempty"`
+	Expires   string            `json:"expires,omitempty"`
+}
+
+// group is the availability of the clusters having the same value of the --group-by label
+type group struct {
+	Label       string   `json:"label"`
+	Value       string   `json:"value"`
+	Clusters    int      `json:"clusters"`
+	Available   int      `json:"available"`
+	Unavailable int      `json:"unavailable"`
+	Unknown     int      `json:"unknown"`
+	Names       []string `json:"names"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) != 0 {
+		return fmt.Errorf("the clusters are selected with --selector, got %s", strings.Join(args, " "))
+	}
+	if o.groupBy != "" {
+		o.groupByLabel = strings.TrimPrefix(o.groupBy, groupByLabelPrefix)
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.selector != "" {
+		if _, err := labels.Parse(o.selector); err != nil {
+			return fmt.Errorf("invalid selector %s: %s", o.selector, err.Error())
+		}
+	}
+	if o.groupBy != "" {
+		if !strings.HasPrefix(o.groupBy, groupByLabelPrefix) {
+			return fmt.Errorf("invalid --group-by %s, expected %s<key>", o.groupBy, groupByLabelPrefix)
+		}
+		if errs := validation.IsQualifiedName(o.groupByLabel); len(errs) != 0 {
+			return fmt.Errorf("invalid label key %s: %s", o.groupByLabel, strings.Join(errs, ", "))
+		}
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	if err := o.scope.Complete(o.configFlags); err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	selector, err := labels.Parse(o.selector)
+	if err != nil {
+		return err
+	}
+	mcs, err := o.scope.ListClusters(o.ctx, client, selector)
+	if err != nil {
+		return err
+	}
+	if message := o.scope.Describe(); message != "" {
+		fmt.Fprintln(printers.Messages(o.ErrOut), message)
+	}
+	clusters := make([]cluster, 0, len(mcs))
+	ages := make([]string, 0, len(mcs))
+	now := time.Now()
+	for i := range mcs {
+		mc := &mcs[i]
+		if o.expired && !helpers.IsExpired(mc, now) {
+			continue
+		}
+		conditions, _, _ := unstructured.NestedSlice(mc.Object, "status", "conditions")
+		clusters = append(clusters, cluster{
+			Name:      mc.GetName(),
+			Accepted:  conditionStatus(conditions, "HubAcceptedManagedCluster"),
+			Joined:    conditionStatus(conditions, "ManagedClusterJoined"),
+			Available: conditionStatus(conditions, "ManagedClusterConditionAvailable"),
+			Labels:    mc.GetLabels(),
+			Owner:     mc.GetAnnotations()[helpers.OwnerAnnotation],
+			Ticket:    mc.GetAnnotations()[helpers.TicketAnnotation],
+			Expires:   mc.GetAnnotations()[helpers.ExpiresAnnotation],
+		})
+		ages = append(ages, duration.HumanDuration(time.Since(mc.GetCreationTimestamp().Time)))
+	}
+
+	if o.groupByLabel != "" {
+		groups := groupClusters(clusters, o.groupByLabel)
+		table := &printers.Table{
+			Headers: []string{"GROUP", "CLUSTERS", "AVAILABLE", "UNAVAILABLE", "UNKNOWN", "AVAILABILITY"},
+		}
+		for _, g := range groups {
+			table.AddRow(g.Value, strconv.Itoa(g.Clusters), strconv.Itoa(g.Available), strconv.Itoa(g.Unavailable),
+				strconv.Itoa(g.Unknown), fmt.Sprintf("%d%%", g.Available*100/g.Clusters))
+		}
+		return o.printOptions.Print(o.Out, table, groups)
+	}
+
+	table := &printers.Table{
+		Headers: []string{"NAME", "HUB ACCEPTED", "JOINED", "AVAILABLE", "AGE"},
+	}
+	if o.expired {
+		table.Headers = append(table.Headers, "OWNER", "TICKET", "EXPIRES")
+	}
+	for i, c := range clusters {
+		if o.expired {
+			table.AddRow(c.Name, c.Accepted, c.Joined, c.Available, ages[i], c.Owner, c.Ticket, c.Expires)
+			continue
+		}
+		table.AddRow(c.Name, c.Accepted, c.Joined, c.Available, ages[i])
+	}
+	return o.printOptions.Print(o.Out, table, clusters)
+}
+
+// groupClusters aggregates the clusters per value of the label, the groups are sorted by value
+// and the clusters without the label are in the last group
+func groupClusters(clusters []cluster, label string) []group {
+	byValue := make(map[string]*group)
+	for _, c := range clusters {
+		value, ok := c.Labels[label]
+		if !ok {
+			value = noGroup
+		}
+		g, ok := byValue[value]
+		if !ok {
+			g = &group{Label: label, Value: value, Names: make([]string, 0)}
+			byValue[value] = g
+		}
+		g.Clusters++
+		g.Names = append(g.Names, c.Name)
+		switch c.Available {
+		case "True":
+			g.Available++
+		case "False":
+			g.Unavailable++
+		default:
+			g.Unknown++
+		}
+	}
+	groups := make([]group, 0, len(byValue))
+	for _, g := range byValue {
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Value == noGroup || groups[j].Value == noGroup {
+			return groups[j].Value == noGroup && groups[i].Value != noGroup
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups
+}
+
+// conditionStatus returns the status of the condition, Unknown if the cluster does not report it
+func conditionStatus(conditions []interface{}, conditionType string) string {
+	if status := helpers.GetConditionStatus(conditions, conditionType); status != "" {
+		return status
+	}
+	return "Unknown"
+}
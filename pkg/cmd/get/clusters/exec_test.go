@@ -0,0 +1,154 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// newCluster returns an accepted cluster with the availability and the labels
+func newCluster(name, available string, labels map[string]string) *unstructured.Unstructured {
+	return fake.NewManagedCluster(name, fake.WithLabels(labels), fake.WithConditions(
+		fake.NewCondition("HubAcceptedManagedCluster", "True"),
+		fake.NewCondition("ManagedClusterConditionAvailable", available)))
+}
+
+func newClusters() []runtime.Object {
+	dev := newCluster("dev", "False", map[string]string{"env": "dev"})
+	dev.SetAnnotations(map[string]string{
+		helpers.OwnerAnnotation:   "team-a",
+		helpers.TicketAnnotation:  "DEV-42",
+		helpers.ExpiresAnnotation: "2020-01-01T00:00:00Z",
+	})
+	lab := newCluster("lab", "True", nil)
+	lab.SetAnnotations(map[string]string{helpers.ExpiresAnnotation: "2099-01-01T00:00:00Z"})
+	return []runtime.Object{
+		newCluster("prod-eu", "True", map[string]string{"env": "prod", "region": "eu", helpers.ClusterSetLabel: "prod"}),
+		newCluster("prod-us", "Unknown", map[string]string{"env": "prod", "region": "us", helpers.ClusterSetLabel: "prod"}),
+		dev,
+		lab,
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name        string
+		selector    string
+		clusterSets []string
+		expired     bool
+		groupBy     string
+		format      string
+		contains    []string
+		absent      []string
+	}{
+		{
+			name:     "Success, list",
+			contains: []string{"NAME", "prod-eu", "lab", "HUB ACCEPTED"},
+		},
+		{
+			name:     "Success, selector",
+			selector: "env=prod",
+			contains: []string{"prod-eu", "prod-us"},
+			absent:   []string{"dev", "lab"},
+		},
+		{
+			name:        "Success, clusterset",
+			clusterSets: []string{"prod"},
+			contains:    []string{"prod-eu", "prod-us"},
+			absent:      []string{"dev", "lab"},
+		},
+		{
+			name:     "Success, expired",
+			expired:  true,
+			contains: []string{"OWNER", "team-a", "DEV-42", "2020-01-01T00:00:00Z"},
+			absent:   []string{"prod", "lab"},
+		},
+		{
+			name:     "Success, grouped",
+			groupBy:  "label:env",
+			contains: []string{"GROUP", "prod", "50%", "dev", "0%", "<none>", "100%"},
+		},
+		{
+			name:     "Success, grouped in json",
+			groupBy:  "label:region",
+			format:   printers.OutputJSON,
+			contains: []string{`"label": "region"`, `"value": "eu"`, `"prod-us"`},
+		},
+		{
+			name:     "Success, jsonpath",
+			selector: "env=prod",
+			format:   `jsonpath={range [*]}{.name}{"\n"}{end}`,
+			contains: []string{"prod-eu\n", "prod-us\n"},
+			absent:   []string{"NAME"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.selector = tt.selector
+			o.groupBy = tt.groupBy
+			o.scope.ClusterSets = tt.clusterSets
+			o.expired = tt.expired
+			o.printOptions.OutputFormat = tt.format
+			if err := o.complete(nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := o.validate(); err != nil {
+				t.Fatal(err)
+			}
+			if err := o.runWithClient(fake.NewClient(newClusters()...)); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+			for _, c := range tt.absent {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %q, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	for _, groupBy := range []string{"env", "label:", "label:-env"} {
+		streams, _, _, _ := genericclioptions.NewTestIOStreams()
+		o := newOptions(streams)
+		o.groupBy = groupBy
+		if err := o.complete(nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := o.validate(); err == nil {
+			t.Errorf("validate() expected an error for --group-by %s", groupBy)
+		}
+	}
+}
+
+func Test_groupClusters(t *testing.T) {
+	groups := groupClusters([]cluster{
+		{Name: "a", Available: "True"},
+		{Name: "b", Available: "True", Labels: map[string]string{"tier": "gold"}},
+		{Name: "c", Available: "False", Labels: map[string]string{"tier": "bronze"}},
+		{Name: "d", Available: "Unknown", Labels: map[string]string{"tier": "gold"}},
+	}, "tier")
+	want := []group{
+		{Label: "tier", Value: "bronze", Clusters: 1, Unavailable: 1, Names: []string{"c"}},
+		{Label: "tier", Value: "gold", Clusters: 2, Available: 1, Unknown: 1, Names: []string{"b", "d"}},
+		{Label: "tier", Value: noGroup, Clusters: 1, Available: 1, Names: []string{"a"}},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("groupClusters() = %+v, want %+v", groups, want)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"context"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags  *genericclioptions.ConfigFlags
+	printOptions *printers.PrintOptions
+	selector     string
+	groupBy      string
+	//groupByLabel is the label key of --group-by
+	groupByLabel string
+	//scope restricts the clusters to the clustersets of --clusterset or the ones bound to the namespace
+	scope helpers.ClusterScope
+	//expired only lists the clusters past their expiry
+	expired bool
+	ctx     context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		printOptions: printers.NewPrintOptions(),
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
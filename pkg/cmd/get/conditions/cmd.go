@@ -0,0 +1,52 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# List the conditions of a managed cluster and of its addons, the failing ones first
+%[1]s get conditions mycluster
+
+# Show the messages of the conditions
+%[1]s get conditions mycluster -o yaml
+`
+
+// NewCmd provides a cobra command listing the conditions of a managed cluster and of its addons
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "conditions <cluster>",
+		Short: "List the conditions of a managed cluster and of its addons",
+		Long: "List the conditions of a managed cluster and of its addons with their status, reason and age in a compact table. " +
+			"The conditions are sorted by severity, the failing conditions first, then the unknown and progressing ones",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
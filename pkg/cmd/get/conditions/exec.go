@@ -0,0 +1,167 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	severityError   = "Error"
+	severityWarning = "Warning"
+	severityOK      = "OK"
+
+	unknownTime = "<unknown>"
+)
+
+// severityOrder sorts the conditions, the most severe first
+var severityOrder = map[string]int{
+	severityError:   0,
+	severityWarning: 1,
+	severityOK:      2,
+}
+
+// negativeSuffixes are the suffixes of the condition types for which True is a failure, such as Degraded
+var negativeSuffixes = []string{"Degraded", "Denied", "Failed", "Pressure"}
+
+// condition is a condition of the ManagedCluster or of one of its addons
+type condition struct {
+	Resource       string `json:"resource"`
+	Type           string `json:"type"`
+	Status         string `json:"status"`
+	Severity       string `json:"severity"`
+	Reason         string `json:"reason,omitempty"`
+	Age            string `json:"age"`
+	LastTransition string `json:"lastTransitionTime,omitempty"`
+	Message        string `json:"message,omitempty"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 1 {
+		return fmt.Errorf("only one cluster can be given, got %s", strings.Join(args, " "))
+	}
+	if len(args) == 1 {
+		o.clusterName = args[0]
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.clusterName == "" {
+		return fmt.Errorf("the name of the cluster is missing")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName}, mc)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("the cluster %s does not exist", o.clusterName)
+	}
+	if err != nil {
+		return err
+	}
+	addons := &unstructured.UnstructuredList{}
+	addons.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK.GroupVersion().WithKind(helpers.ManagedClusterAddOnGVK.Kind + "List"))
+	if err := client.List(o.ctx, addons, crclient.InNamespace(o.clusterName)); err != nil {
+		return err
+	}
+
+	conditions := getConditions(append([]unstructured.Unstructured{*mc}, addons.Items...), time.Now())
+	table := &printers.Table{
+		Headers: []string{"RESOURCE", "CONDITION", "STATUS", "SEVERITY", "REASON", "AGE", "LAST TRANSITION"},
+	}
+	for _, c := range conditions {
+		table.AddRow(c.Resource, c.Type, c.Status, c.Severity, c.Reason, c.Age, c.LastTransition)
+	}
+	return o.printOptions.Print(o.Out, table, conditions)
+}
+
+// getConditions returns the conditions of the cluster and of its addons, sorted by severity.
+// The conditions of a same severity keep the order of the resources, the cluster first.
+func getConditions(resources []unstructured.Unstructured, now time.Time) []condition {
+	conditions := make([]condition, 0)
+	for i := range resources {
+		r := &resources[i]
+		resource := "cluster/" + r.GetName()
+		if r.GetKind() != helpers.ManagedClusterGVK.Kind {
+			resource = "addon/" + r.GetName()
+		}
+		rconditions, _, _ := unstructured.NestedSlice(r.Object, "status", "conditions")
+		for _, ic := range rconditions {
+			c, ok := ic.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			cond := condition{
+				Resource:       resource,
+				Type:           nestedString(c, "type"),
+				Status:         nestedString(c, "status"),
+				Reason:         nestedString(c, "reason"),
+				Age:            unknownTime,
+				LastTransition: nestedString(c, "lastTransitionTime"),
+				Message:        nestedString(c, "message"),
+			}
+			cond.Severity = severity(cond.Type, cond.Status)
+			if t, err := time.Parse(time.RFC3339, cond.LastTransition); err == nil {
+				cond.Age = duration.HumanDuration(now.Sub(t))
+			}
+			conditions = append(conditions, cond)
+		}
+	}
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return severityOrder[conditions[i].Severity] < severityOrder[conditions[j].Severity]
+	})
+	return conditions
+}
+
+// severity returns Error for a failing condition, Warning for an unknown or progressing condition and OK otherwise.
+// A condition fails when it is False, or True for the negative conditions such as Degraded.
+func severity(conditionType, status string) string {
+	negative := false
+	for _, s := range negativeSuffixes {
+		negative = negative || strings.HasSuffix(conditionType, s)
+	}
+	switch {
+	case status != "True" && status != "False":
+		return severityWarning
+	case conditionType == "Progressing":
+		if status == "True" {
+			return severityWarning
+		}
+		return severityOK
+	case negative == (status == "True"):
+		return severityError
+	}
+	return severityOK
+}
+
+func nestedString(m map[string]interface{}, field string) string {
+	s, _, _ := unstructured.NestedString(m, field)
+	return s
+}
@@ -0,0 +1,155 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var now = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+// newAgedCondition returns a condition which transitioned age ago
+func newAgedCondition(conditionType, status, reason string, age time.Duration) map[string]interface{} {
+	return fake.NewCondition(conditionType, status, fake.WithReason(reason), fake.WithMessage(reason+" message"), fake.WithLastTransitionTime(now.Add(-age)))
+}
+
+func newAddon(cluster, name string, conditions ...interface{}) *unstructured.Unstructured {
+	addon := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"conditions": conditions},
+	}}
+	addon.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK)
+	addon.SetNamespace(cluster)
+	addon.SetName(name)
+	return addon
+}
+
+func Test_severity(t *testing.T) {
+	tests := []struct {
+		conditionType string
+		status        string
+		want          string
+	}{
+		{conditionType: "ManagedClusterConditionAvailable", status: "True", want: severityOK},
+		{conditionType: "ManagedClusterConditionAvailable", status: "False", want: severityError},
+		{conditionType: "ManagedClusterConditionAvailable", status: "Unknown", want: severityWarning},
+		{conditionType: "Degraded", status: "True", want: severityError},
+		{conditionType: "Degraded", status: "False", want: severityOK},
+		{conditionType: "HubDenied", status: "True", want: severityError},
+		{conditionType: "Progressing", status: "True", want: severityWarning},
+		{conditionType: "Progressing", status: "False", want: severityOK},
+	}
+	for _, tt := range tests {
+		if got := severity(tt.conditionType, tt.status); got != tt.want {
+			t.Errorf("severity(%s, %s) = %s, want %s", tt.conditionType, tt.status, got, tt.want)
+		}
+	}
+}
+
+func Test_getConditions(t *testing.T) {
+	resources := []unstructured.Unstructured{
+		*fake.NewManagedCluster("c1", fake.WithConditions(
+			newAgedCondition("HubAcceptedManagedCluster", "True", "HubClusterAdminAccepted", 48*time.Hour),
+			newAgedCondition("ManagedClusterConditionAvailable", "Unknown", "ManagedClusterLeaseUpdateStopped", 5*time.Minute))),
+		*newAddon("c1", "work-manager",
+			newAgedCondition("Available", "True", "ManagedClusterAddOnLeaseUpdated", time.Hour),
+			newAgedCondition("Degraded", "True", "ImagePullBackOff", 90*time.Second)),
+	}
+	conditions := getConditions(resources, now)
+	got := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		got = append(got, strings.Join([]string{c.Resource, c.Type, c.Severity, c.Age}, " "))
+	}
+	want := []string{
+		"addon/work-manager Degraded Error 90s",
+		"cluster/c1 ManagedClusterConditionAvailable Warning 5m",
+		"cluster/c1 HubAcceptedManagedCluster OK 2d",
+		"addon/work-manager Available OK 60m",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("getConditions() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	conditions = getConditions([]unstructured.Unstructured{*fake.NewManagedCluster("c1", fake.WithConditions(fake.NewCondition("ManagedClusterJoined", "True")))}, now)
+	if conditions[0].Age != unknownTime || conditions[0].LastTransition != "" {
+		t.Errorf("a condition without transition time must have an unknown age, got %+v", conditions[0])
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	objs := []runtime.Object{
+		fake.NewManagedCluster("c1", fake.WithConditions(newAgedCondition("ManagedClusterConditionAvailable", "False", "ManagedClusterUnavailable", time.Hour))),
+		newAddon("c1", "work-manager", newAgedCondition("Available", "True", "ManagedClusterAddOnLeaseUpdated", time.Hour)),
+		newAddon("c2", "search-collector", newAgedCondition("Available", "True", "ManagedClusterAddOnLeaseUpdated", time.Hour)),
+	}
+	tests := []struct {
+		name         string
+		clusterName  string
+		outputFormat string
+		contains     []string
+		excludes     []string
+		wantErr      string
+	}{
+		{
+			name:        "Success",
+			clusterName: "c1",
+			contains:    []string{"SEVERITY", "LAST TRANSITION", "cluster/c1", "ManagedClusterUnavailable", "Error", "addon/work-manager"},
+			excludes:    []string{"search-collector", "message"},
+		},
+		{
+			name:         "Success, json",
+			clusterName:  "c1",
+			outputFormat: printers.OutputJSON,
+			contains:     []string{`"severity": "Error"`, `"message": "ManagedClusterUnavailable message"`},
+		},
+		{
+			name:        "Failed, unknown cluster",
+			clusterName: "c3",
+			wantErr:     "does not exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				printOptions: &printers.PrintOptions{OutputFormat: tt.outputFormat},
+				clusterName:  tt.clusterName,
+				IOStreams:    streams,
+			}
+			err := o.runWithClient(fake.NewClient(objs...))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("runWithClient() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+			for _, e := range tt.excludes {
+				if strings.Contains(out.String(), e) {
+					t.Errorf("output must not contain %q, got:\n%s", e, out.String())
+				}
+			}
+			if tt.outputFormat == printers.OutputJSON {
+				conditions := []condition{}
+				if err := json.Unmarshal(out.Bytes(), &conditions); err != nil || len(conditions) != 2 {
+					t.Errorf("the json output must be the 2 conditions of c1, got %v: %s", err, out.String())
+				}
+			}
+		})
+	}
+}
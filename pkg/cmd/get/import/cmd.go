@@ -0,0 +1,52 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Print the import manifests of a cluster
+%[1]s get import mycluster
+
+# Write the crds.yaml and import.yaml of a cluster in a directory
+%[1]s get import mycluster --output-dir mycluster-import
+`
+
+// NewCmd provides a cobra command retrieving the decoded import manifests of a managed cluster
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "import <cluster>",
+		Short:        "Get the decoded crds.yaml and import.yaml of a managed cluster",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.outputDir, "output-dir", "", "The directory where the crds.yaml and import.yaml are written, if not set they are printed")
+	cmd.Flags().StringVar(&o.additionalCABundleFile, helpers.AdditionalCABundleFlag, "", "The PEM file of the CA of a hub fronted by a custom PKI, appended to the CA data or the certificate-authority file of the bootstrap kubeconfig of the import manifests. "+
+		"A bootstrap kubeconfig skipping the TLS verification or trusting the system roots then only trusts this CA")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
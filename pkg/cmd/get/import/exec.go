@@ -0,0 +1,82 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) != 1 {
+		return fmt.Errorf("the cluster name is required")
+	}
+	o.clusterName = args[0]
+	if o.additionalCABundleFile != "" {
+		o.additionalCABundle, err = helpers.ReadCABundle(o.additionalCABundleFile)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.clusterName == "" {
+		return fmt.Errorf("the cluster name is required")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	importSecret, err := helpers.GetImportSecret(o.ctx, client, o.clusterName)
+	if err != nil {
+		return err
+	}
+	crds, imports, err := helpers.GetAnnotatedImportManifests(importSecret)
+	if err != nil {
+		return err
+	}
+	imports, err = helpers.AddBootstrapCA(imports, o.additionalCABundle)
+	if err != nil {
+		return err
+	}
+
+	if o.outputDir == "" {
+		fmt.Fprintf(o.Out, "%s\n---\n%s\n", string(crds), string(imports))
+		return nil
+	}
+
+	if err := os.MkdirAll(o.outputDir, 0700); err != nil {
+		return err
+	}
+	for name, data := range map[string][]byte{
+		helpers.ImportSecretCRDsKey:   crds,
+		helpers.ImportSecretImportKey: imports,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(o.outputDir, name), data, 0600); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "%s and %s written in %s, apply them in that order on the managed cluster\n",
+		helpers.ImportSecretCRDsKey, helpers.ImportSecretImportKey, o.outputDir)
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testCRDs   = "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: klusterlets.operator.open-cluster-management.io\n"
+	testImport = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: open-cluster-management-agent\n"
+	//testBootstrap is the bootstrap-hub-kubeconfig secret, the base64 of a kubeconfig of the hub cluster
+	testBootstrap = "apiVersion: v1\nkind: Secret\nmetadata:\n  name: bootstrap-hub-kubeconfig\n  namespace: open-cluster-management-agent\n" +
+		"data:\n  kubeconfig: YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOgotIG5hbWU6IGh1YgogIGNsdXN0ZXI6CiAgICBzZXJ2ZXI6IGh0dHBzOi8vaHViOjY0NDMK\n"
+)
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name               string
+		objs               []runtime.Object
+		additionalCABundle []byte
+		contains           []string
+		wantErr            bool
+	}{
+		{
+			name: "Success",
+			objs: []runtime.Object{fake.NewImportSecret("cluster1", map[string][]byte{
+				helpers.ImportSecretCRDsKey:   []byte(testCRDs),
+				helpers.ImportSecretImportKey: []byte(testImport),
+			})},
+			contains: []string{"kind: CustomResourceDefinition", "---\n", "kind: Namespace", helpers.ProvenanceContentHashAnnotation},
+		},
+		{
+			name: "Success, additional CA bundle",
+			objs: []runtime.Object{fake.NewImportSecret("cluster1", map[string][]byte{
+				helpers.ImportSecretCRDsKey:   []byte(testCRDs),
+				helpers.ImportSecretImportKey: []byte(testImport + "---\n" + testBootstrap),
+			})},
+			additionalCABundle: []byte("ca"),
+			contains:           []string{"kind: Namespace", "kubeconfig: " + base64.StdEncoding.EncodeToString([]byte("apiVersion: v1\nclusters:\n- cluster:\n    certificate-authority-data: Y2E="))},
+		},
+		{
+			name: "Failed, additional CA bundle without bootstrap secret",
+			objs: []runtime.Object{fake.NewImportSecret("cluster1", map[string][]byte{
+				helpers.ImportSecretCRDsKey:   []byte(testCRDs),
+				helpers.ImportSecretImportKey: []byte(testImport),
+			})},
+			additionalCABundle: []byte("ca"),
+			wantErr:            true,
+		},
+		{
+			name:    "Failed, secret not found",
+			wantErr: true,
+		},
+		{
+			name: "Failed, import.yaml missing",
+			objs: []runtime.Object{fake.NewImportSecret("cluster1", map[string][]byte{
+				helpers.ImportSecretCRDsKey: []byte(testCRDs),
+			})},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				clusterName:        "cluster1",
+				additionalCABundle: tt.additionalCABundle,
+				IOStreams:          streams,
+			}
+			err := o.runWithClient(crclientfake.NewFakeClient(tt.objs...))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient_outputDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	o := &Options{
+		clusterName: "cluster1",
+		outputDir:   filepath.Join(dir, "cluster1"),
+		IOStreams:   streams,
+	}
+	client := crclientfake.NewFakeClient(fake.NewImportSecret("cluster1", map[string][]byte{
+		helpers.ImportSecretCRDsKey:   []byte(testCRDs),
+		helpers.ImportSecretImportKey: []byte(testImport),
+	}))
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		helpers.ImportSecretCRDsKey:   "kind: CustomResourceDefinition",
+		helpers.ImportSecretImportKey: "kind: Namespace",
+	} {
+		b, err := ioutil.ReadFile(filepath.Join(o.outputDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(b), want) || !strings.Contains(string(b), helpers.ProvenanceSourceAnnotation+": cluster1/cluster1-import") {
+			t.Errorf("%s: expected %s with the provenance annotations got %s", name, want, string(b))
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"context"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags *genericclioptions.ConfigFlags
+	clusterName string
+	outputDir   string
+	//additionalCABundle is appended to the bootstrap kubeconfig of the import manifests
+	additionalCABundleFile string
+	additionalCABundle     []byte
+	ctx                    context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ctx:         context.Background(),
+
+		IOStreams: streams,
+	}
+}
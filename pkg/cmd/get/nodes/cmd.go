@@ -0,0 +1,54 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# List the nodes of a managed cluster
+%[1]s get nodes --cluster mycluster
+
+# List the worker nodes of a managed cluster with their labels
+%[1]s get nodes --cluster mycluster -l node-role.kubernetes.io/worker --show-labels
+`
+
+// NewCmd provides a cobra command listing the nodes of a managed cluster from the hub
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "nodes",
+		Short:        "List the nodes of a managed cluster as reported to the hub",
+		Long:         "List the nodes of a managed cluster with their roles, status and capacity from the ManagedClusterInfo reported to the hub, without accessing the managed cluster",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.clusterName, "cluster", "", "Name of the managed cluster")
+	cmd.Flags().StringVarP(&o.selector, "selector", "l", "", "Label selector of the nodes to list, e.g. node-role.kubernetes.io/worker")
+	cmd.Flags().BoolVar(&o.showLabels, "show-labels", false, "If set, show the labels of the nodes")
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
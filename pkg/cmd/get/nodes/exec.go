@@ -0,0 +1,167 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+// nodeRoleLabelPrefix is the prefix of the labels setting the roles of a node
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// node is a node of a managed cluster as reported in the ManagedClusterInfo
+type node struct {
+	Name     string            `json:"name"`
+	Roles    []string          `json:"roles"`
+	Status   string            `json:"status"`
+	Capacity map[string]string `json:"capacity,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 0 {
+		return fmt.Errorf("the nodes are selected with --selector, got %s", strings.Join(args, " "))
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.clusterName == "" {
+		return fmt.Errorf("the managed cluster is missing, set it with --cluster")
+	}
+	if _, err := labels.Parse(o.selector); err != nil {
+		return fmt.Errorf("invalid selector %s: %s", o.selector, err.Error())
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	nodes, err := o.getNodes(client)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"NAME", "ROLES", "STATUS", "CPU", "MEMORY"}
+	if o.showLabels {
+		headers = append(headers, "LABELS")
+	}
+	table := &printers.Table{Headers: headers}
+	for _, n := range nodes {
+		row := []string{n.Name, strings.Join(n.Roles, ","), n.Status, n.Capacity["cpu"], n.Capacity["memory"]}
+		if o.showLabels {
+			row = append(row, formatLabels(n.Labels))
+		}
+		table.AddRow(row...)
+	}
+	return o.printOptions.Print(o.Out, table, nodes)
+}
+
+// getNodes reads the nodes of the cluster from its ManagedClusterInfo and keeps the ones matching the selector,
+// the ManagedClusterInfo is created on the hub by the klusterlet addons
+func (o *Options) getNodes(client crclient.Client) ([]node, error) {
+	selector, err := labels.Parse(o.selector)
+	if err != nil {
+		return nil, err
+	}
+	info := &unstructured.Unstructured{}
+	info.SetGroupVersionKind(helpers.ManagedClusterInfoGVK)
+	err = client.Get(o.ctx, types.NamespacedName{Namespace: o.clusterName, Name: o.clusterName}, info)
+	if errors.IsNotFound(err) {
+		mc := &unstructured.Unstructured{}
+		mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+		if err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName}, mc); errors.IsNotFound(err) {
+			return nil, fmt.Errorf("cluster %s does not exist", o.clusterName)
+		}
+		return nil, fmt.Errorf("no node inventory found for cluster %s, it is reported by the klusterlet addons which must be enabled on the cluster", o.clusterName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nodeList, _, _ := unstructured.NestedSlice(info.Object, "status", "nodeList")
+	nodes := make([]node, 0, len(nodeList))
+	for _, in := range nodeList {
+		m, ok := in.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		nodeLabels, _, _ := unstructured.NestedStringMap(m, "labels")
+		if !selector.Matches(labels.Set(nodeLabels)) {
+			continue
+		}
+		n := node{
+			Roles:    nodeRoles(nodeLabels),
+			Status:   nodeStatus(m),
+			Capacity: map[string]string{},
+			Labels:   nodeLabels,
+		}
+		n.Name, _, _ = unstructured.NestedString(m, "name")
+		capacity, _, _ := unstructured.NestedMap(m, "capacity")
+		for k, v := range capacity {
+			n.Capacity[k] = fmt.Sprint(v)
+		}
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes, nil
+}
+
+// nodeRoles returns the roles set by the node-role.kubernetes.io/<role> labels, <none> if there are none
+func nodeRoles(nodeLabels map[string]string) []string {
+	roles := make([]string, 0)
+	for k := range nodeLabels {
+		if role := strings.TrimPrefix(k, nodeRoleLabelPrefix); role != k && role != "" {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) == 0 {
+		return []string{"<none>"}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// nodeStatus returns Ready or NotReady depending on the Ready condition of the node, Unknown if it is not reported
+func nodeStatus(n map[string]interface{}) string {
+	conditions, _, _ := unstructured.NestedSlice(n, "conditions")
+	switch helpers.GetConditionStatus(conditions, "Ready") {
+	case "True":
+		return "Ready"
+	case "False":
+		return "NotReady"
+	}
+	return "Unknown"
+}
+
+func formatLabels(nodeLabels map[string]string) string {
+	pairs := make([]string, 0, len(nodeLabels))
+	for k, v := range nodeLabels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
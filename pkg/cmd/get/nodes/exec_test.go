@@ -0,0 +1,142 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newNode(name, ready string, nodeLabels map[string]interface{}) interface{} {
+	return map[string]interface{}{
+		"name":       name,
+		"labels":     nodeLabels,
+		"capacity":   map[string]interface{}{"cpu": "8", "memory": "32Gi"},
+		"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": ready}},
+	}
+}
+
+func newManagedClusterInfo(clusterName string, nodes ...interface{}) *unstructured.Unstructured {
+	info := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"nodeList": nodes},
+	}}
+	info.SetGroupVersionKind(helpers.ManagedClusterInfoGVK)
+	info.SetNamespace(clusterName)
+	info.SetName(clusterName)
+	return info
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	info := newManagedClusterInfo("c1",
+		newNode("worker-1", "True", map[string]interface{}{"node-role.kubernetes.io/worker": "", "zone": "a"}),
+		newNode("master-1", "False", map[string]interface{}{"node-role.kubernetes.io/master": "", "node-role.kubernetes.io/control-plane": ""}),
+		newNode("edge-1", "", map[string]interface{}{}),
+	)
+	tests := []struct {
+		name       string
+		objs       []runtime.Object
+		selector   string
+		showLabels bool
+		contains   []string
+		excludes   []string
+		wantErr    string
+	}{
+		{
+			name:     "Success",
+			objs:     []runtime.Object{fake.NewManagedCluster("c1"), info},
+			contains: []string{"worker-1", "worker", "Ready", "32Gi", "control-plane,master", "NotReady", "<none>", "Unknown"},
+			excludes: []string{"LABELS"},
+		},
+		{
+			name:       "Success, selector and labels",
+			objs:       []runtime.Object{fake.NewManagedCluster("c1"), info},
+			selector:   "node-role.kubernetes.io/worker",
+			showLabels: true,
+			contains:   []string{"worker-1", "LABELS", "node-role.kubernetes.io/worker=,zone=a"},
+			excludes:   []string{"master-1", "edge-1"},
+		},
+		{
+			name:    "Failed, no node inventory",
+			objs:    []runtime.Object{fake.NewManagedCluster("c1")},
+			wantErr: "klusterlet addons",
+		},
+		{
+			name:    "Failed, unknown cluster",
+			wantErr: "does not exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.clusterName = "c1"
+			o.selector = tt.selector
+			o.showLabels = tt.showLabels
+			err := o.runWithClient(fake.NewClient(tt.objs...))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error containing %q expected, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+			for _, c := range tt.excludes {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %q, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient_json(t *testing.T) {
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	o.clusterName = "c1"
+	o.printOptions.OutputFormat = printers.OutputJSON
+	info := newManagedClusterInfo("c1", newNode("worker-1", "True", map[string]interface{}{"node-role.kubernetes.io/worker": ""}))
+	if err := o.runWithClient(fake.NewClient(fake.NewManagedCluster("c1"), info)); err != nil {
+		t.Fatal(err)
+	}
+	nodes := make([]node, 0)
+	if err := json.Unmarshal(out.Bytes(), &nodes); err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].Name != "worker-1" || nodes[0].Roles[0] != "worker" || nodes[0].Capacity["cpu"] != "8" {
+		t.Errorf("unexpected nodes %+v", nodes)
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       Options
+		wantErr bool
+	}{
+		{name: "Success", o: Options{clusterName: "c1", printOptions: printers.NewPrintOptions()}},
+		{name: "Success, selector", o: Options{clusterName: "c1", selector: "zone in (a,b)", printOptions: printers.NewPrintOptions()}},
+		{name: "Failed, no cluster", o: Options{printOptions: printers.NewPrintOptions()}, wantErr: true},
+		{name: "Failed, invalid selector", o: Options{clusterName: "c1", selector: "zone in (", printOptions: printers.NewPrintOptions()}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"context"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags  *genericclioptions.ConfigFlags
+	printOptions *printers.PrintOptions
+	clusterName  string
+	selector     string
+	showLabels   bool
+	ctx          context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	configFlags := genericclioptions.NewConfigFlags(true)
+	//--cluster is used to select the managed cluster and not the kubeconfig cluster
+	configFlags.ClusterName = nil
+	return &Options{
+		configFlags:  configFlags,
+		printOptions: printers.NewPrintOptions(),
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# List the manifestworks of a cluster
+%[1]s get work --cluster mycluster
+
+# List the manifestworks of all clusters
+%[1]s get work -A
+
+# List the manifestworks of the clusters of a clusterset
+%[1]s get work --clusterset myclusterset
+
+# Show the conditions and feedback values of each manifest of a manifestwork
+%[1]s get work mywork --cluster mycluster
+`
+
+// NewCmd provides a cobra command inspecting the manifestworks dispatched to the managed clusters
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "work [name]",
+		Short:        "List the manifestworks or show the status of the manifests of a manifestwork",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.clusterName, "cluster", "", "Name of the managed cluster")
+	cmd.Flags().BoolVarP(&o.allClusters, "all-clusters", "A", false, "If set, list the manifestworks of all clusters")
+	o.scope.AddClusterSetFlag(cmd.Flags())
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
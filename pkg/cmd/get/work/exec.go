@@ -0,0 +1,124 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 0 {
+		o.workName = args[0]
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.clusterName == "" && !o.allClusters && len(o.scope.ClusterSets) == 0 {
+		return fmt.Errorf("either --cluster, --clusterset or -A must be provided")
+	}
+	if o.clusterName != "" && len(o.scope.ClusterSets) != 0 {
+		return fmt.Errorf("--cluster and --clusterset are mutually exclusive")
+	}
+	if o.workName != "" && o.clusterName == "" {
+		return fmt.Errorf("--cluster is required to show a manifestwork")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	if err := o.scope.Complete(o.configFlags); err != nil {
+		return err
+	}
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	if o.workName == "" {
+		return o.printWorks(client)
+	}
+	return o.printManifests(client)
+}
+
+func (o *Options) printWorks(client crclient.Client) error {
+	works := &unstructured.UnstructuredList{}
+	works.SetGroupVersionKind(helpers.ManifestWorkListGVK)
+	//The manifestworks are in the cluster namespaces
+	if o.clusterName != "" {
+		if err := client.List(o.ctx, works, crclient.InNamespace(o.clusterName)); err != nil {
+			return err
+		}
+	} else {
+		items, err := o.scope.ListInClusters(o.ctx, client, helpers.ManifestWorkGVK)
+		if err != nil {
+			return err
+		}
+		works.Items = items
+		if message := o.scope.Describe(); message != "" {
+			fmt.Fprintln(printers.Messages(o.ErrOut), message)
+		}
+	}
+
+	table := &printers.Table{
+		Headers: []string{"CLUSTER", "NAME", "APPLIED", "AVAILABLE", "MANIFESTS"},
+	}
+	items := make([]map[string]interface{}, 0)
+	for i := range works.Items {
+		w := &works.Items[i]
+		items = append(items, w.Object)
+		conditions, _, _ := unstructured.NestedSlice(w.Object, "status", "conditions")
+		manifests, _, _ := unstructured.NestedSlice(w.Object, "spec", "workload", "manifests")
+		table.AddRow(w.GetNamespace(),
+			w.GetName(),
+			helpers.GetConditionStatus(conditions, helpers.WorkAppliedCondition),
+			helpers.GetConditionStatus(conditions, helpers.WorkAvailableCondition),
+			strconv.Itoa(len(manifests)))
+	}
+	return o.printOptions.Print(o.Out, table, items)
+}
+
+func (o *Options) printManifests(client crclient.Client) error {
+	work := &unstructured.Unstructured{}
+	work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.workName, Namespace: o.clusterName}, work)
+	if err != nil {
+		return err
+	}
+
+	statuses := helpers.GetManifestStatuses(work)
+	table := &printers.Table{
+		Headers: []string{"ORDINAL", "KIND", "NAMESPACE", "NAME", "APPLIED", "AVAILABLE", "FEEDBACK"},
+	}
+	for _, s := range statuses {
+		feedback := make([]string, 0)
+		for k, v := range s.Feedback {
+			feedback = append(feedback, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(feedback)
+		table.AddRow(strconv.FormatInt(s.Ordinal, 10),
+			s.Kind,
+			s.Namespace,
+			s.Name,
+			s.Applied,
+			s.Available,
+			strings.Join(feedback, ","))
+	}
+	return o.printOptions.Print(o.Out, table, statuses)
+}
@@ -0,0 +1,198 @@
+// Copyright Contributors to the Open Cluster Management project
+package get
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newManifestWork(cluster, name string) *unstructured.Unstructured {
+	w := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"workload": map[string]interface{}{
+					"manifests": []interface{}{
+						map[string]interface{}{"kind": "Deployment"},
+						map[string]interface{}{"kind": "ConfigMap"},
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					fake.NewCondition("Applied", "True"),
+					fake.NewCondition("Available", "False"),
+				},
+				"resourceStatus": map[string]interface{}{
+					"manifests": []interface{}{
+						map[string]interface{}{
+							"resourceMeta": map[string]interface{}{
+								"ordinal":   int64(0),
+								"group":     "apps",
+								"version":   "v1",
+								"kind":      "Deployment",
+								"namespace": "default",
+								"name":      "hello",
+							},
+							"conditions": []interface{}{
+								fake.NewCondition("Applied", "True"),
+								fake.NewCondition("Available", "True"),
+							},
+							"statusFeedback": map[string]interface{}{
+								"values": []interface{}{
+									map[string]interface{}{
+										"name": "ReadyReplicas",
+										"fieldValue": map[string]interface{}{
+											"type":    "Integer",
+											"integer": int64(2),
+										},
+									},
+									map[string]interface{}{
+										"name": "Paused",
+										"fieldValue": map[string]interface{}{
+											"type":    "Boolean",
+											"boolean": false,
+										},
+									},
+								},
+							},
+						},
+						map[string]interface{}{
+							"resourceMeta": map[string]interface{}{
+								"ordinal":   int64(1),
+								"version":   "v1",
+								"kind":      "ConfigMap",
+								"namespace": "default",
+								"name":      "hello-config",
+							},
+							"conditions": []interface{}{
+								fake.NewCondition("Applied", "True"),
+								fake.NewCondition("Available", "False"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	w.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	w.SetNamespace(cluster)
+	w.SetName(name)
+	return w
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := fake.NewClient(
+		fake.NewManagedCluster("cluster1", fake.WithClusterSet("prod")),
+		fake.NewManagedCluster("cluster2", fake.WithClusterSet("dev")),
+		newManifestWork("cluster1", "work1"),
+		newManifestWork("cluster2", "work2"),
+	)
+	tests := []struct {
+		name        string
+		workName    string
+		clusterName string
+		allClusters bool
+		clusterSets []string
+		contains    []string
+		notContains []string
+		wantErr     bool
+	}{
+		{
+			name:        "List cluster",
+			clusterName: "cluster1",
+			contains:    []string{"cluster1   work1   True      False       2"},
+			notContains: []string{"work2"},
+		},
+		{
+			name:        "List all clusters",
+			allClusters: true,
+			contains:    []string{"work1", "work2"},
+		},
+		{
+			name:        "List clusterset",
+			clusterSets: []string{"prod"},
+			contains:    []string{"cluster1   work1"},
+			notContains: []string{"work2"},
+		},
+		{
+			name:        "Manifests",
+			workName:    "work1",
+			clusterName: "cluster1",
+			contains: []string{
+				"Deployment   default     hello          True      True        Paused=false,ReadyReplicas=2",
+				"ConfigMap    default     hello-config   True      False",
+			},
+		},
+		{
+			name:        "Manifests, not found",
+			workName:    "work2",
+			clusterName: "cluster1",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				printOptions: printers.NewPrintOptions(),
+				workName:     tt.workName,
+				clusterName:  tt.clusterName,
+				allClusters:  tt.allClusters,
+				scope:        helpers.ClusterScope{ClusterSets: tt.clusterSets},
+				IOStreams:    streams,
+			}
+			err := o.runWithClient(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+			for _, c := range tt.notContains {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %q, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		workName    string
+		clusterName string
+		allClusters bool
+		clusterSets []string
+		wantErr     bool
+	}{
+		{name: "Cluster", clusterName: "cluster1"},
+		{name: "All clusters", allClusters: true},
+		{name: "Clusterset", clusterSets: []string{"prod"}},
+		{name: "Failed, cluster and clusterset", clusterName: "cluster1", clusterSets: []string{"prod"}, wantErr: true},
+		{name: "Failed, no cluster", wantErr: true},
+		{name: "Failed, work without cluster", workName: "work1", allClusters: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				printOptions: printers.NewPrintOptions(),
+				workName:     tt.workName,
+				clusterName:  tt.clusterName,
+				allClusters:  tt.allClusters,
+				scope:        helpers.ClusterScope{ClusterSets: tt.clusterSets},
+			}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
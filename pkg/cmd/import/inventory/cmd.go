@@ -0,0 +1,53 @@
+// Copyright Contributors to the Open Cluster Management project
+package inventory
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Show the changes needed to reconcile the hub with an inventory
+%[1]s import inventory --input inventory.yaml --dry-run
+
+# Reconcile the hub with an inventory
+%[1]s import inventory --input inventory.yaml
+`
+
+// NewCmd provides a cobra command reconciling the hub with an inventory
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "Reconcile the hub with an inventory",
+		Long: "Create or update the clustersets, managed clusters and addon configurations of an inventory generated by export inventory, " +
+			"the clusters which are not in the inventory are left untouched. The created clusters must then be imported",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.inputFile, "input", "", "The inventory file generated by export inventory")
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, "If set, the changes are printed but not applied")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
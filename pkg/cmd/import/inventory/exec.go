@@ -0,0 +1,67 @@
+// Copyright Contributors to the Open Cluster Management project
+package inventory
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if o.inputFile == "" {
+		return nil
+	}
+	o.inventory, err = helpers.ReadInventory(o.inputFile)
+	return err
+}
+
+func (o *Options) validate() error {
+	if o.inputFile == "" {
+		return fmt.Errorf("the inventory file must be provided with --input")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	changes, err := helpers.ReconcileInventory(o.ctx, client, o.inventory, o.dryRun)
+	//Print the changes done before the failure
+	table := &printers.Table{Headers: []string{"KIND", "NAME", "ACTION"}}
+	created := make([]string, 0)
+	for _, c := range changes {
+		table.AddRow(c.Kind, c.Name, c.Action)
+		if c.Kind == helpers.ManagedClusterGVK.Kind && c.Action == helpers.InventoryActionCreated {
+			created = append(created, c.Name)
+		}
+	}
+	if perr := printers.PrintTable(o.Out, table); perr != nil {
+		return perr
+	}
+	if err != nil {
+		return err
+	}
+
+	if o.dryRun {
+		fmt.Fprintf(printers.Messages(o.ErrOut), "Dry run, no change applied\n")
+		return nil
+	}
+	for _, name := range created {
+		fmt.Fprintf(printers.Messages(o.ErrOut), "The cluster %[2]s must be imported, apply on the cluster the manifests given by\n%[1]s get import %[2]s\n",
+			helpers.GetExampleHeader(), name)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright Contributors to the Open Cluster Management project
+package inventory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		wantErr   bool
+	}{
+		{name: "Success", inputFile: "inventory.yaml"},
+		{name: "Failed, no input", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{inputFile: tt.inputFile}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	inventory := &helpers.Inventory{
+		APIVersion: helpers.InventoryAPIVersion,
+		Kind:       helpers.InventoryKind,
+		Clusters:   []helpers.InventoryCluster{{Name: "cluster1", Labels: map[string]string{"env": "dev"}}},
+	}
+	tests := []struct {
+		name   string
+		dryRun bool
+	}{
+		{name: "Success, dry-run", dryRun: true},
+		{name: "Success"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient()
+			streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				inventory: inventory,
+				dryRun:    tt.dryRun,
+				IOStreams: streams,
+			}
+			if err := o.runWithClient(client); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(out.String(), "ManagedCluster   cluster1   created") {
+				t.Errorf("the created cluster must be printed, got %s", out.String())
+			}
+
+			mc := &unstructured.Unstructured{}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			err := client.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, mc)
+			if tt.dryRun != (err != nil) {
+				t.Errorf("dry-run %v, got error %v", tt.dryRun, err)
+			}
+			if !tt.dryRun && !strings.Contains(errOut.String(), "get import cluster1") {
+				t.Errorf("the import command must be printed, got %s", errOut.String())
+			}
+		})
+	}
+}
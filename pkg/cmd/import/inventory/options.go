@@ -0,0 +1,29 @@
+// Copyright Contributors to the Open Cluster Management project
+package inventory
+
+import (
+	"context"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags *genericclioptions.ConfigFlags
+	inputFile   string
+	dryRun      bool
+	inventory   *helpers.Inventory
+	ctx         context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ctx:         context.Background(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright Contributors to the Open Cluster Management project
+package hub
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Install the open-cluster-management cluster-manager and wait until the hub is ready
+%[1]s init hub --wait
+
+# Install a given version of the cluster-manager
+%[1]s init hub --version v0.4.0
+
+# Install Red Hat Advanced Cluster Management from a given channel
+%[1]s init hub --mode multiClusterHub --channel release-2.2 --wait
+`
+
+const (
+	scenarioDirectory = "scenarios/init"
+)
+
+var valuesTemplatePath = filepath.Join(scenarioDirectory, "values-template.yaml")
+
+// valuesSchema defines the flag of each value of the values-template.yaml
+var valuesSchema = applierscenarios.ValuesSchema{
+	{Path: "mode", Flag: "mode", Type: applierscenarios.StringValue, Usage: "The hub to install, clusterManager or multiClusterHub"},
+	{Path: "clusterManager.registry", Flag: "registry", Type: applierscenarios.StringValue, Usage: "The registry of the cluster-manager images"},
+	{Path: "clusterManager.version", Flag: "version", Type: applierscenarios.StringValue, Usage: "The version of the cluster-manager images"},
+	{Path: "multiClusterHub.channel", Flag: "channel", Type: applierscenarios.StringValue, Usage: "The subscription channel of the MultiClusterHub operator"},
+}
+
+// NewCmd provides a cobra command installing a hub
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "hub",
+		Short: "Install a hub",
+		Long: "Install the open-cluster-management cluster-manager or the MultiClusterHub on the cluster, " +
+			"when already installed the hub is updated and validated",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.SetUsageTemplate(applierscenarios.UsageTempate(cmd, valuesTemplatePath))
+	valuesSchema.AddFlags(cmd.Flags())
+	cmd.Flags().BoolVar(&o.wait, "wait", false, "Wait until all hub components are ready")
+	helpers.DurationVar(cmd.Flags(), &o.waitTimeout, "wait-timeout", 15*time.Minute, "Timeout to wait for the hub, e.g. 10m")
+	//--timeout was the wait timeout in seconds before it was a duration
+	cmd.Flags().Var(cmd.Flags().Lookup("wait-timeout").Value, "timeout", "Timeout to wait for the hub")
+	_ = cmd.Flags().MarkDeprecated("timeout", "use --wait-timeout instead")
+
+	//--timeout is the deprecated wait timeout, the applier timeout is --apply-timeout
+	o.applierScenariosOptions.TimeoutFlag = "apply-timeout"
+	o.applierScenariosOptions.AddFlags(cmd.Flags())
+	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,183 @@
+// Copyright Contributors to the Open Cluster Management project
+package hub
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	ClusterManagerMode  = "clusterManager"
+	MultiClusterHubMode = "multiClusterHub"
+)
+
+const (
+	clusterManagerCRDName  = "clustermanagers.operator.open-cluster-management.io"
+	multiClusterHubCRDName = "multiclusterhubs.operator.open-cluster-management.io"
+	clusterManagerName     = "cluster-manager"
+	multiClusterHubName    = "multiclusterhub"
+	//clusterManagerNamespace is the namespace in which the cluster-manager deploys the hub controllers
+	clusterManagerNamespace = "open-cluster-management-hub"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	o.values, err = o.applierScenariosOptions.ReadValuesWithDefaults(valuesTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	var flagSet *pflag.FlagSet
+	if cmd != nil {
+		flagSet = cmd.Flags()
+	}
+	if err := valuesSchema.MergeFlags(flagSet, o.values); err != nil {
+		return err
+	}
+
+	o.mode = applierscenarios.GetString(o.values, "mode")
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.mode != ClusterManagerMode && o.mode != MultiClusterHubMode {
+		return fmt.Errorf("supported modes are %s and %s, got %s", ClusterManagerMode, MultiClusterHubMode, o.mode)
+	}
+	if o.wait && o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("wait can not be used with outFile")
+	}
+	if o.wait && o.waitTimeout <= 0 {
+		return fmt.Errorf("wait-timeout must be greater than 0")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.applierScenariosOptions.ConfigFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	reporter := o.applierScenariosOptions.NewProgressReporter()
+	reader := resources.NewResourcesReader()
+	modeDirectory := filepath.Join(scenarioDirectory, "hub", strings.ToLower(o.mode))
+
+	applyOptions := &appliercmd.Options{
+		OutFile:     o.applierScenariosOptions.OutFile,
+		ConfigFlags: o.applierScenariosOptions.ConfigFlags,
+
+		Timeout:   o.applierScenariosOptions.ApplierTimeout(),
+		Force:     o.applierScenariosOptions.Force,
+		Silent:    o.applierScenariosOptions.Silent,
+		IOStreams: o.applierScenariosOptions.IOStreams,
+	}
+
+	//The generated file contains the operator and the custom resource, sorted by kind
+	if o.applierScenariosOptions.OutFile != "" {
+		return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader, modeDirectory, o.values)
+	}
+
+	crdName, kind, name := clusterManagerCRDName, helpers.ClusterManagerGVK.Kind, clusterManagerName
+	ready := o.clusterManagerReady
+	if o.mode == MultiClusterHubMode {
+		crdName, kind, name = multiClusterHubCRDName, helpers.MultiClusterHubGVK.Kind, multiClusterHubName
+		ready = o.multiClusterHubReady
+	}
+
+	err := reporter.Step("apply", "operator", func() error {
+		return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader, filepath.Join(modeDirectory, "operator"), o.values)
+	})
+	if err != nil {
+		return err
+	}
+
+	//The custom resource can only be created once the operator CRD is served
+	err = reporter.Step("crd", "CustomResourceDefinition/"+crdName, func() error {
+		return o.poll(func() (bool, error) {
+			return helpers.CRDEstablished(o.ctx, client, crdName)
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = reporter.Step("apply", kind+"/"+name, func() error {
+		return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader, filepath.Join(modeDirectory, "cr"), o.values)
+	})
+	if err != nil {
+		return err
+	}
+
+	if o.wait {
+		err = reporter.Step("wait", kind+"/"+name, func() error {
+			return o.poll(func() (bool, error) {
+				return ready(client)
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if !o.applierScenariosOptions.Silent {
+		if o.wait {
+			fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "The hub is ready\n")
+		} else {
+			fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "The hub is being installed, use --wait to wait until it is ready\n")
+		}
+	}
+	return nil
+}
+
+func (o *Options) poll(condition wait.ConditionFunc) error {
+	err := helpers.PollImmediate(o.ctx, o.pollInterval, o.waitTimeout, condition)
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("not ready after %s", o.waitTimeout)
+	}
+	return err
+}
+
+// clusterManagerReady checks the cluster-manager is applied and all hub controllers are available
+func (o *Options) clusterManagerReady(client crclient.Client) (bool, error) {
+	cm := &unstructured.Unstructured{}
+	cm.SetGroupVersionKind(helpers.ClusterManagerGVK)
+	if err := client.Get(o.ctx, types.NamespacedName{Name: clusterManagerName}, cm); err != nil {
+		return false, crclient.IgnoreNotFound(err)
+	}
+	conditions, _, _ := unstructured.NestedSlice(cm.Object, "status", "conditions")
+	if helpers.GetConditionStatus(conditions, "Applied") != "True" {
+		return false, nil
+	}
+	return helpers.DeploymentsAvailable(o.ctx, client, clusterManagerNamespace)
+}
+
+// multiClusterHubReady checks the MultiClusterHub reports the Running phase
+func (o *Options) multiClusterHubReady(client crclient.Client) (bool, error) {
+	mch := &unstructured.Unstructured{}
+	mch.SetGroupVersionKind(helpers.MultiClusterHubGVK)
+	namespace := applierscenarios.GetString(o.values, "multiClusterHub.namespace")
+	if err := client.Get(o.ctx, types.NamespacedName{Name: multiClusterHubName, Namespace: namespace}, mch); err != nil {
+		return false, crclient.IgnoreNotFound(err)
+	}
+	phase, _, _ := unstructured.NestedString(mch.Object, "status", "phase")
+	return phase == "Running", nil
+}
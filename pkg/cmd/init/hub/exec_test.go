@@ -0,0 +1,223 @@
+// Copyright Contributors to the Open Cluster Management project
+package hub
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newCRD(name string, established bool) *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(helpers.CustomResourceDefinitionGVK)
+	crd.SetName(name)
+	if established {
+		unstructured.SetNestedSlice(crd.Object, []interface{}{
+			map[string]interface{}{"type": "Established", "status": "True"},
+		}, "status", "conditions")
+	}
+	return crd
+}
+
+func newValuesCmd(t *testing.T, args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	valuesSchema.AddFlags(cmd.Flags())
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestOptions_complete(t *testing.T) {
+	tests := []struct {
+		name        string
+		cmd         *cobra.Command
+		wantMode    string
+		wantVersion string
+	}{
+		{
+			name:        "Defaults",
+			wantMode:    ClusterManagerMode,
+			wantVersion: "latest",
+		},
+		{
+			name:        "Flags",
+			cmd:         newValuesCmd(t, "--mode", MultiClusterHubMode, "--version", "v0.4.0"),
+			wantMode:    MultiClusterHubMode,
+			wantVersion: "v0.4.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{}}
+			if err := o.complete(tt.cmd, nil); err != nil {
+				t.Fatal(err)
+			}
+			if o.mode != tt.wantMode {
+				t.Errorf("mode = %s, want %s", o.mode, tt.wantMode)
+			}
+			if v := applierscenarios.GetString(o.values, "clusterManager.version"); v != tt.wantVersion {
+				t.Errorf("version = %s, want %s", v, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		wait    bool
+		outFile string
+		wantErr bool
+	}{
+		{name: "Success, clusterManager", mode: ClusterManagerMode},
+		{name: "Success, multiClusterHub", mode: MultiClusterHubMode, wait: true},
+		{name: "Failed, unknown mode", mode: "hive", wantErr: true},
+		{name: "Failed, wait with outFile", mode: ClusterManagerMode, wait: true, outFile: "hub.yaml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{OutFile: tt.outFile},
+				mode:                    tt.mode,
+				wait:                    tt.wait,
+				waitTimeout:             10 * time.Second,
+			}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	replicas := int32(1)
+	tests := []struct {
+		name    string
+		mode    string
+		wait    bool
+		objs    []runtime.Object
+		wantErr bool
+	}{
+		{
+			name: "Success, clusterManager",
+			mode: ClusterManagerMode,
+			objs: []runtime.Object{newCRD(clusterManagerCRDName, true)},
+		},
+		{
+			name: "Success, clusterManager ready",
+			mode: ClusterManagerMode,
+			wait: true,
+			objs: []runtime.Object{
+				newCRD(clusterManagerCRDName, true),
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster-manager-registration-controller", Namespace: clusterManagerNamespace},
+					Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+					Status:     appsv1.DeploymentStatus{AvailableReplicas: 1},
+				},
+			},
+		},
+		{
+			name:    "Failed, CRD not established",
+			mode:    MultiClusterHubMode,
+			objs:    []runtime.Object{newCRD(multiClusterHubCRDName, false)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(tt.objs...)
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+					Timeout:   time.Second,
+					Silent:    true,
+					IOStreams: genericclioptions.NewTestIOStreamsDiscard(),
+				},
+				mode:         tt.mode,
+				wait:         tt.wait,
+				waitTimeout:  time.Second,
+				ctx:          context.Background(),
+				pollInterval: 100 * time.Millisecond,
+			}
+			if err := o.complete(nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			o.mode = tt.mode
+			if tt.wait {
+				//Simulate the operator
+				go func() {
+					cm := &unstructured.Unstructured{}
+					cm.SetGroupVersionKind(helpers.ClusterManagerGVK)
+					for i := 0; client.Get(context.TODO(), types.NamespacedName{Name: clusterManagerName}, cm) != nil; i++ {
+						if i == 200 {
+							return
+						}
+						time.Sleep(50 * time.Millisecond)
+					}
+					unstructured.SetNestedSlice(cm.Object, []interface{}{
+						map[string]interface{}{"type": "Applied", "status": "True"},
+					}, "status", "conditions")
+					client.Update(context.TODO(), cm)
+				}()
+			}
+			err := o.runWithClient(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			cm := &unstructured.Unstructured{}
+			cm.SetGroupVersionKind(helpers.ClusterManagerGVK)
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: clusterManagerName}, cm); err != nil {
+				t.Fatal(err)
+			}
+			if image, _, _ := unstructured.NestedString(cm.Object, "spec", "registrationImagePullSpec"); image != "quay.io/open-cluster-management/registration:latest" {
+				t.Errorf("registrationImagePullSpec = %s", image)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient_outFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cm-init-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	outFile := filepath.Join(dir, "hub.yaml")
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{OutFile: outFile},
+	}
+	if err := o.complete(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.runWithClient(fake.NewClient()); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(outFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"kind: CustomResourceDefinition", "kind: Deployment", "kind: ClusterManager"} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("the generated file must contain %s", want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright Contributors to the Open Cluster Management project
+package hub
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	applierScenariosOptions *applierscenarios.ApplierScenariosOptions
+	values                  map[string]interface{}
+	mode                    string
+	wait                    bool
+	waitTimeout             time.Duration
+	pollInterval            time.Duration
+	//ctx is canceled on Ctrl+C to abort the waits
+	ctx context.Context
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(streams),
+		pollInterval:            5 * time.Second,
+		ctx:                     context.Background(),
+	}
+}
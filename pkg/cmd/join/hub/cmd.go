@@ -0,0 +1,83 @@
+// Copyright Contributors to the Open Cluster Management project
+package hub
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Join the hub from the cluster of the current context with a bootstrap token created on the hub with "%[1]s token create", then accept the cluster on the hub
+%[1]s join hub --cluster-name mycluster --hub-apiserver https://api.hub.example.com:6443 --bootstrap-token <token> --hub-ca-file ca.crt
+
+# Join the hub with the token of a service account and wait until the klusterlet is running
+%[1]s join hub --cluster-name mycluster --hub-apiserver https://api.hub.example.com:6443 --hub-token <token> --wait
+`
+
+const (
+	scenarioDirectory = "scenarios/join"
+)
+
+var valuesTemplatePath = filepath.Join(scenarioDirectory, "values-template.yaml")
+
+// valuesSchema defines the flag of each value of the values-template.yaml
+var valuesSchema = applierscenarios.ValuesSchema{
+	{Path: "clusterName", Flag: "cluster-name", Type: applierscenarios.StringValue, Usage: "The name of the cluster on the hub"},
+	{Path: "hub.apiServer", Flag: "hub-apiserver", Type: applierscenarios.StringValue, Usage: "The api server url of the hub"},
+	{Path: "hub.token", Flag: "hub-token", Type: applierscenarios.StringValue, Usage: "The token used by the klusterlet to request its registration on the hub"},
+	{Path: "klusterlet.registry", Flag: "registry", Type: applierscenarios.StringValue, Usage: "The registry of the klusterlet images"},
+	{Path: "klusterlet.version", Flag: "version", Type: applierscenarios.StringValue, Usage: "The version of the klusterlet images"},
+}
+
+// NewCmd provides a cobra command joining a hub from the managed cluster
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "hub",
+		Short: "Join a hub",
+		Long: "Deploy the klusterlet on the cluster of the current context and request its registration on the hub, " +
+			"the registration must then be accepted on the hub",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.SetUsageTemplate(applierscenarios.UsageTempate(cmd, valuesTemplatePath))
+	valuesSchema.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.bootstrapToken, "bootstrap-token", "", fmt.Sprintf("The short-lived bootstrap token created on the hub with '%s token create', instead of --hub-token", helpers.GetExampleHeader()))
+	cmd.Flags().StringVar(&o.hubCAFile, "hub-ca-file", "", "The CA bundle of the hub api server, the server certificate is not verified if not set")
+	cmd.Flags().StringVar(&o.additionalCABundleFile, helpers.AdditionalCABundleFlag, "", "The PEM file of the CA of a hub fronted by a custom PKI, appended to the CA of --hub-ca-file in the bootstrap kubeconfig, or its only CA without --hub-ca-file so the hub certificate is verified")
+	cmd.Flags().BoolVar(&o.wait, "wait", false, "Wait until the klusterlet is running")
+	helpers.DurationVar(cmd.Flags(), &o.waitTimeout, "wait-timeout", 5*time.Minute, "Timeout to wait for the klusterlet, e.g. 10m")
+	//--timeout was the wait timeout in seconds before it was a duration
+	cmd.Flags().Var(cmd.Flags().Lookup("wait-timeout").Value, "timeout", "Timeout to wait for the klusterlet")
+	_ = cmd.Flags().MarkDeprecated("timeout", "use --wait-timeout instead")
+
+	//--timeout is the deprecated wait timeout, the applier timeout is --apply-timeout
+	o.applierScenariosOptions.TimeoutFlag = "apply-timeout"
+	o.applierScenariosOptions.AddFlags(cmd.Flags())
+	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,240 @@
+// Copyright Contributors to the Open Cluster Management project
+package hub
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientcmdapiv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	klusterletCRDName = "klusterlets.operator.open-cluster-management.io"
+	klusterletName    = "klusterlet"
+	//agentNamespace is the namespace in which the klusterlet deploys the registration and work agents
+	agentNamespace = "open-cluster-management-agent"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	o.values, err = o.applierScenariosOptions.ReadValuesWithDefaults(valuesTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	var flagSet *pflag.FlagSet
+	if cmd != nil {
+		flagSet = cmd.Flags()
+	}
+	if err := valuesSchema.MergeFlags(flagSet, o.values); err != nil {
+		return err
+	}
+
+	o.clusterName = applierscenarios.GetString(o.values, "clusterName")
+	o.hubAPIServer = applierscenarios.GetString(o.values, "hub.apiServer")
+	o.hubToken = applierscenarios.GetString(o.values, "hub.token")
+	//The bootstrap token is a short-lived token used like the hub token
+	if o.bootstrapToken != "" {
+		if o.hubToken != "" {
+			return fmt.Errorf("bootstrap-token and hub-token are mutually exclusive")
+		}
+		o.hubToken = o.bootstrapToken
+	}
+	if o.hubCAFile != "" {
+		o.hubCA, err = ioutil.ReadFile(filepath.Clean(o.hubCAFile))
+		if err != nil {
+			return err
+		}
+	}
+	if o.additionalCABundleFile != "" {
+		caBundle, err := helpers.ReadCABundle(o.additionalCABundleFile)
+		if err != nil {
+			return err
+		}
+		if len(o.hubCA) != 0 && !bytes.HasSuffix(o.hubCA, []byte("\n")) {
+			o.hubCA = append(o.hubCA, '\n')
+		}
+		o.hubCA = append(o.hubCA, caBundle...)
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.clusterName == "" {
+		return fmt.Errorf("the cluster name must be provided with --cluster-name")
+	}
+	if errs := validation.IsDNS1123Label(o.clusterName); len(errs) != 0 {
+		return fmt.Errorf("invalid cluster name %s: %v", o.clusterName, errs)
+	}
+	if o.hubAPIServer == "" {
+		return fmt.Errorf("the hub api server must be provided with --hub-apiserver")
+	}
+	if u, err := url.Parse(o.hubAPIServer); err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("the hub api server must be an https url, got %s", o.hubAPIServer)
+	}
+	if o.hubToken == "" {
+		return fmt.Errorf("the hub token must be provided with --bootstrap-token or --hub-token")
+	}
+	if o.bootstrapToken != "" {
+		if _, err := helpers.ParseBootstrapToken(o.bootstrapToken); err != nil {
+			return err
+		}
+	}
+	if o.wait && o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("wait can not be used with outFile")
+	}
+	if o.wait && o.waitTimeout <= 0 {
+		return fmt.Errorf("wait-timeout must be greater than 0")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.applierScenariosOptions.ConfigFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	reporter := o.applierScenariosOptions.NewProgressReporter()
+	reader := resources.NewResourcesReader()
+	klusterletDirectory := filepath.Join(scenarioDirectory, "klusterlet")
+
+	bootstrapHubKubeConfig, err := o.bootstrapHubKubeConfig()
+	if err != nil {
+		return err
+	}
+	o.values["bootstrapHubKubeConfig"] = string(bootstrapHubKubeConfig)
+
+	applyOptions := &appliercmd.Options{
+		OutFile:     o.applierScenariosOptions.OutFile,
+		ConfigFlags: o.applierScenariosOptions.ConfigFlags,
+
+		Timeout:   o.applierScenariosOptions.ApplierTimeout(),
+		Force:     o.applierScenariosOptions.Force,
+		Silent:    o.applierScenariosOptions.Silent,
+		IOStreams: o.applierScenariosOptions.IOStreams,
+	}
+
+	//The generated file contains the operator, the bootstrap secret and the klusterlet, sorted by kind
+	if o.applierScenariosOptions.OutFile != "" {
+		return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader, klusterletDirectory, o.values)
+	}
+
+	err = reporter.Step("apply", "operator", func() error {
+		return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader, filepath.Join(klusterletDirectory, "operator"), o.values)
+	})
+	if err != nil {
+		return err
+	}
+
+	//The klusterlet can only be created once the operator CRD is served
+	err = reporter.Step("crd", "CustomResourceDefinition/"+klusterletCRDName, func() error {
+		return o.poll(func() (bool, error) {
+			return helpers.CRDEstablished(o.ctx, client, klusterletCRDName)
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = reporter.Step("apply", helpers.KlusterletGVK.Kind+"/"+klusterletName, func() error {
+		return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader, filepath.Join(klusterletDirectory, "cr"), o.values)
+	})
+	if err != nil {
+		return err
+	}
+
+	if o.wait {
+		err = reporter.Step("wait", helpers.KlusterletGVK.Kind+"/"+klusterletName, func() error {
+			return o.poll(func() (bool, error) {
+				return klusterletReady(o.ctx, client)
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if !o.applierScenariosOptions.Silent {
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut),
+			"The cluster %[1]s requested its registration, accept it on the hub with:\n"+
+				"kubectl certificate approve $(kubectl get csr -l %[2]s=%[1]s -o name)\n"+
+				"kubectl patch managedcluster %[1]s --type merge -p '{\"spec\":{\"hubAcceptsClient\":true}}'\n",
+			o.clusterName, helpers.ClusterNameLabel)
+	}
+	printers.PrintIdentifier(o.applierScenariosOptions.Out, o.clusterName)
+	return nil
+}
+
+// bootstrapHubKubeConfig generates the kubeconfig used by the registration agent to request
+// its registration, the agent replaces it by its own client certificate once accepted
+func (o *Options) bootstrapHubKubeConfig() ([]byte, error) {
+	config := clientcmdapiv1.Config{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters: []clientcmdapiv1.NamedCluster{{
+			Name: "hub",
+			Cluster: clientcmdapiv1.Cluster{
+				Server:                   o.hubAPIServer,
+				CertificateAuthorityData: o.hubCA,
+				InsecureSkipTLSVerify:    len(o.hubCA) == 0,
+			},
+		}},
+		AuthInfos: []clientcmdapiv1.NamedAuthInfo{{
+			Name:     "bootstrap",
+			AuthInfo: clientcmdapiv1.AuthInfo{Token: o.hubToken},
+		}},
+		Contexts: []clientcmdapiv1.NamedContext{{
+			Name:    "bootstrap",
+			Context: clientcmdapiv1.Context{Cluster: "hub", AuthInfo: "bootstrap"},
+		}},
+		CurrentContext: "bootstrap",
+	}
+	return yaml.Marshal(config)
+}
+
+func (o *Options) poll(condition wait.ConditionFunc) error {
+	err := helpers.PollImmediate(o.ctx, o.pollInterval, o.waitTimeout, condition)
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("not ready after %s", o.waitTimeout)
+	}
+	return err
+}
+
+// klusterletReady checks the klusterlet is applied and the agents are available
+func klusterletReady(ctx context.Context, client crclient.Client) (bool, error) {
+	klusterlet := &unstructured.Unstructured{}
+	klusterlet.SetGroupVersionKind(helpers.KlusterletGVK)
+	if err := client.Get(ctx, types.NamespacedName{Name: klusterletName}, klusterlet); err != nil {
+		return false, crclient.IgnoreNotFound(err)
+	}
+	conditions, _, _ := unstructured.NestedSlice(klusterlet.Object, "status", "conditions")
+	if helpers.GetConditionStatus(conditions, "Applied") != "True" {
+		return false, nil
+	}
+	return helpers.DeploymentsAvailable(ctx, client, agentNamespace)
+}
@@ -0,0 +1,244 @@
+// Copyright Contributors to the Open Cluster Management project
+package hub
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func newCRD(name string, established bool) *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(helpers.CustomResourceDefinitionGVK)
+	crd.SetName(name)
+	if established {
+		unstructured.SetNestedSlice(crd.Object, []interface{}{
+			map[string]interface{}{"type": "Established", "status": "True"},
+		}, "status", "conditions")
+	}
+	return crd
+}
+
+func newValuesCmd(t *testing.T, args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	valuesSchema.AddFlags(cmd.Flags())
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestOptions_complete(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cm-join-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	caFile := filepath.Join(dir, "ca.crt")
+	if err := ioutil.WriteFile(caFile, []byte("ca"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+		hubCAFile:               caFile,
+	}
+	cmd := newValuesCmd(t, "--cluster-name", "mycluster", "--hub-apiserver", "https://hub:6443", "--hub-token", "token")
+	if err := o.complete(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if o.clusterName != "mycluster" || o.hubAPIServer != "https://hub:6443" || o.hubToken != "token" {
+		t.Errorf("got clusterName %s, hubAPIServer %s, hubToken %s", o.clusterName, o.hubAPIServer, o.hubToken)
+	}
+	if string(o.hubCA) != "ca" {
+		t.Errorf("hubCA = %s, want ca", string(o.hubCA))
+	}
+	if v := applierscenarios.GetString(o.values, "klusterlet.version"); v != "latest" {
+		t.Errorf("version = %s, want latest", v)
+	}
+}
+
+func TestOptions_complete_bootstrapToken(t *testing.T) {
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+		bootstrapToken:          "abc123.0123456789abcdef",
+	}
+	if err := o.complete(newValuesCmd(t, "--cluster-name", "mycluster", "--hub-apiserver", "https://hub:6443"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if o.hubToken != o.bootstrapToken {
+		t.Errorf("hubToken = %s, want the bootstrap token", o.hubToken)
+	}
+
+	o.applierScenariosOptions = &applierscenarios.ApplierScenariosOptions{}
+	if err := o.complete(newValuesCmd(t, "--cluster-name", "mycluster", "--hub-token", "token"), nil); err == nil {
+		t.Error("complete() expected an error with both --bootstrap-token and --hub-token")
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterName  string
+		hubAPIServer string
+		hubToken     string
+		bootstrap    string
+		wait         bool
+		outFile      string
+		wantErr      bool
+	}{
+		{name: "Success, bootstrap token", clusterName: "mycluster", hubAPIServer: "https://hub:6443", hubToken: "abc123.0123456789abcdef", bootstrap: "abc123.0123456789abcdef"},
+		{name: "Failed, invalid bootstrap token", clusterName: "mycluster", hubAPIServer: "https://hub:6443", hubToken: "token", bootstrap: "token", wantErr: true},
+		{name: "Success", clusterName: "mycluster", hubAPIServer: "https://hub:6443", hubToken: "token"},
+		{name: "Failed, no cluster name", hubAPIServer: "https://hub:6443", hubToken: "token", wantErr: true},
+		{name: "Failed, invalid cluster name", clusterName: "My_Cluster", hubAPIServer: "https://hub:6443", hubToken: "token", wantErr: true},
+		{name: "Failed, no hub api server", clusterName: "mycluster", hubToken: "token", wantErr: true},
+		{name: "Failed, hub api server not https", clusterName: "mycluster", hubAPIServer: "http://hub:6443", hubToken: "token", wantErr: true},
+		{name: "Failed, no hub token", clusterName: "mycluster", hubAPIServer: "https://hub:6443", wantErr: true},
+		{name: "Failed, wait with outFile", clusterName: "mycluster", hubAPIServer: "https://hub:6443", hubToken: "token", wait: true, outFile: "join.yaml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{OutFile: tt.outFile},
+				clusterName:             tt.clusterName,
+				hubAPIServer:            tt.hubAPIServer,
+				hubToken:                tt.hubToken,
+				bootstrapToken:          tt.bootstrap,
+				wait:                    tt.wait,
+				waitTimeout:             10 * time.Second,
+			}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		hubCA   []byte
+		objs    []runtime.Object
+		wantErr bool
+	}{
+		{
+			name: "Success, insecure",
+			objs: []runtime.Object{newCRD(klusterletCRDName, true)},
+		},
+		{
+			name:  "Success, with CA",
+			hubCA: []byte("ca"),
+			objs:  []runtime.Object{newCRD(klusterletCRDName, true)},
+		},
+		{
+			name:    "Failed, CRD not established",
+			objs:    []runtime.Object{newCRD(klusterletCRDName, false)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(tt.objs...)
+			streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+					Timeout:   time.Second,
+					IOStreams: streams,
+				},
+				hubCA:        tt.hubCA,
+				waitTimeout:  time.Second,
+				ctx:          context.Background(),
+				pollInterval: 100 * time.Millisecond,
+			}
+			cmd := newValuesCmd(t, "--cluster-name", "mycluster", "--hub-apiserver", "https://hub:6443", "--hub-token", "token")
+			if err := o.complete(cmd, nil); err != nil {
+				t.Fatal(err)
+			}
+			o.hubCA = tt.hubCA
+			err := o.runWithClient(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			klusterlet := &unstructured.Unstructured{}
+			klusterlet.SetGroupVersionKind(helpers.KlusterletGVK)
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: klusterletName}, klusterlet); err != nil {
+				t.Fatal(err)
+			}
+			if name, _, _ := unstructured.NestedString(klusterlet.Object, "spec", "clusterName"); name != "mycluster" {
+				t.Errorf("clusterName = %s, want mycluster", name)
+			}
+
+			secret := &corev1.Secret{}
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "bootstrap-hub-kubeconfig", Namespace: agentNamespace}, secret); err != nil {
+				t.Fatal(err)
+			}
+			config, err := clientcmd.Load(secret.Data["kubeconfig"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			cluster := config.Clusters[config.Contexts[config.CurrentContext].Cluster]
+			if cluster.Server != "https://hub:6443" {
+				t.Errorf("server = %s, want https://hub:6443", cluster.Server)
+			}
+			if !bytes.Equal(cluster.CertificateAuthorityData, tt.hubCA) || cluster.InsecureSkipTLSVerify != (len(tt.hubCA) == 0) {
+				t.Errorf("got certificate authority %s and insecure %v", string(cluster.CertificateAuthorityData), cluster.InsecureSkipTLSVerify)
+			}
+			if token := config.AuthInfos[config.Contexts[config.CurrentContext].AuthInfo].Token; token != "token" {
+				t.Errorf("token = %s, want token", token)
+			}
+
+			if !strings.Contains(errOut.String(), "kubectl patch managedcluster mycluster") {
+				t.Errorf("the accept command is missing from the output: %s", errOut.String())
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient_outFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cm-join-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	outFile := filepath.Join(dir, "join.yaml")
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{OutFile: outFile},
+	}
+	cmd := newValuesCmd(t, "--cluster-name", "mycluster", "--hub-apiserver", "https://hub:6443", "--hub-token", "token")
+	if err := o.complete(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.runWithClient(fake.NewClient()); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(outFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"kind: CustomResourceDefinition", "kind: Deployment", "kind: Klusterlet", "name: bootstrap-hub-kubeconfig"} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("the generated file must contain %s", want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusters
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Add the label region=eu and remove the label env of the clusters labeled env=dev
+%[1]s label clusters --selector env=dev region=eu env-
+
+# Preview the changes
+%[1]s label clusters --selector env=dev region=eu env- --dry-run
+
+# Change the value of an existing label of two clusters
+%[1]s label clusters --clusters cluster1,cluster2 tier=gold --overwrite
+`
+
+// NewCmd provides a cobra command adding and removing labels on many managed clusters
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "clusters KEY_1=VAL_1 ... KEY_N=VAL_N | KEY-",
+		Short: "Add or remove labels on many managed clusters",
+		Long: "Add or remove labels on the managed clusters selected by a label selector or by name, " +
+			"a KEY- argument removes the label. Nothing is changed if a cluster can not be labeled.",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.selector, "selector", "l", "", "Label selector of the managed clusters to label, e.g. env=dev")
+	cmd.Flags().StringSliceVar(&o.clusters, "clusters", nil, "Names of the managed clusters to label")
+	cmd.Flags().BoolVar(&o.overwrite, "overwrite", false, "If set, the existing labels can be given a new value")
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, "If set, the changes are printed but not applied")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
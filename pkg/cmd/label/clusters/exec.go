@@ -0,0 +1,204 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+// labelChange is the change of the labels of a cluster
+type labelChange struct {
+	cluster *unstructured.Unstructured
+	changes []string
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	o.addLabels, o.removeLabels, err = parseLabels(args)
+	return err
+}
+
+func (o *Options) validate() error {
+	if len(o.addLabels) == 0 && len(o.removeLabels) == 0 {
+		return fmt.Errorf("at least one label to add as KEY=VALUE or to remove as KEY- is required")
+	}
+	if o.selector == "" && len(o.clusters) == 0 {
+		return fmt.Errorf("the clusters must be selected with --selector or --clusters")
+	}
+	if o.selector != "" && len(o.clusters) != 0 {
+		return fmt.Errorf("--selector and --clusters are mutually exclusive")
+	}
+	if o.selector != "" {
+		if _, err := labels.Parse(o.selector); err != nil {
+			return fmt.Errorf("invalid selector %s: %s", o.selector, err.Error())
+		}
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	clusters, err := o.selectClusters(client)
+	if err != nil {
+		return err
+	}
+	if len(clusters) == 0 {
+		return fmt.Errorf("no managed cluster matches the selector %s", o.selector)
+	}
+
+	//All changes are computed first so nothing is changed if a cluster can not be labeled
+	changes := make([]labelChange, 0, len(clusters))
+	for i := range clusters {
+		change, err := o.changeLabels(&clusters[i])
+		if err != nil {
+			return err
+		}
+		changes = append(changes, change)
+	}
+
+	labeled := 0
+	for _, c := range changes {
+		if len(c.changes) == 0 {
+			fmt.Fprintf(printers.Messages(o.ErrOut), "%s unchanged\n", c.cluster.GetName())
+			continue
+		}
+		if o.dryRun {
+			fmt.Fprintf(o.Out, "%s would be labeled: %s\n", c.cluster.GetName(), strings.Join(c.changes, ", "))
+			continue
+		}
+		if err := client.Update(o.ctx, c.cluster); err != nil {
+			return fmt.Errorf("%d clusters labeled, unable to label %s: %s", labeled, c.cluster.GetName(), err.Error())
+		}
+		labeled++
+		fmt.Fprintf(printers.Messages(o.ErrOut), "%s labeled: %s\n", c.cluster.GetName(), strings.Join(c.changes, ", "))
+		printers.PrintIdentifier(o.Out, c.cluster.GetName())
+	}
+	if !o.dryRun {
+		fmt.Fprintf(printers.Messages(o.ErrOut), "%d of %d clusters labeled\n", labeled, len(changes))
+	}
+	return nil
+}
+
+// selectClusters returns the clusters matching the selector or named by --clusters, sorted by name
+func (o *Options) selectClusters(client crclient.Client) ([]unstructured.Unstructured, error) {
+	if len(o.clusters) != 0 {
+		clusters := make([]unstructured.Unstructured, 0, len(o.clusters))
+		for _, name := range o.clusters {
+			mc := unstructured.Unstructured{}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			if err := client.Get(o.ctx, types.NamespacedName{Name: name}, &mc); err != nil {
+				return nil, err
+			}
+			clusters = append(clusters, mc)
+		}
+		return clusters, nil
+	}
+
+	selector, err := labels.Parse(o.selector)
+	if err != nil {
+		return nil, err
+	}
+	mcs := &unstructured.UnstructuredList{}
+	mcs.SetGroupVersionKind(helpers.ManagedClusterGVK.GroupVersion().WithKind(helpers.ManagedClusterGVK.Kind + "List"))
+	if err := client.List(o.ctx, mcs, crclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	sort.Slice(mcs.Items, func(i, j int) bool {
+		return mcs.Items[i].GetName() < mcs.Items[j].GetName()
+	})
+	return mcs.Items, nil
+}
+
+// changeLabels sets the labels on the cluster and returns the changes done
+func (o *Options) changeLabels(mc *unstructured.Unstructured) (labelChange, error) {
+	change := labelChange{cluster: mc}
+	mcLabels := mc.GetLabels()
+	if mcLabels == nil {
+		mcLabels = map[string]string{}
+	}
+
+	keys := make([]string, 0, len(o.addLabels))
+	for k := range o.addLabels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := o.addLabels[k]
+		current, ok := mcLabels[k]
+		switch {
+		case !ok:
+			change.changes = append(change.changes, fmt.Sprintf("+%s=%s", k, v))
+		case current == v:
+			continue
+		case !o.overwrite:
+			return change, fmt.Errorf("the cluster %s already has the label %s=%s, use --overwrite to change it", mc.GetName(), k, current)
+		default:
+			change.changes = append(change.changes, fmt.Sprintf("%s=%s (was %s)", k, v, current))
+		}
+		mcLabels[k] = v
+	}
+
+	for _, k := range o.removeLabels {
+		if _, ok := mcLabels[k]; !ok {
+			continue
+		}
+		delete(mcLabels, k)
+		change.changes = append(change.changes, "-"+k)
+	}
+
+	mc.SetLabels(mcLabels)
+	return change, nil
+}
+
+// parseLabels parses the KEY=VALUE labels to add and the KEY- labels to remove
+func parseLabels(args []string) (map[string]string, []string, error) {
+	add := map[string]string{}
+	remove := make([]string, 0)
+	for _, arg := range args {
+		switch {
+		case strings.Contains(arg, "="):
+			kv := strings.SplitN(arg, "=", 2)
+			if errs := validation.IsQualifiedName(kv[0]); len(errs) != 0 {
+				return nil, nil, fmt.Errorf("invalid label key %s: %s", kv[0], strings.Join(errs, ", "))
+			}
+			if errs := validation.IsValidLabelValue(kv[1]); len(errs) != 0 {
+				return nil, nil, fmt.Errorf("invalid label value %s: %s", kv[1], strings.Join(errs, ", "))
+			}
+			add[kv[0]] = kv[1]
+		case strings.HasSuffix(arg, "-"):
+			key := strings.TrimSuffix(arg, "-")
+			if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+				return nil, nil, fmt.Errorf("invalid label key %s: %s", key, strings.Join(errs, ", "))
+			}
+			remove = append(remove, key)
+		default:
+			return nil, nil, fmt.Errorf("invalid label %s, expected KEY=VALUE or KEY-", arg)
+		}
+	}
+	for _, k := range remove {
+		if _, ok := add[k]; ok {
+			return nil, nil, fmt.Errorf("the label %s can not be both added and removed", k)
+		}
+	}
+	return add, remove, nil
+}
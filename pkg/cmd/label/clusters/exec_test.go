@@ -0,0 +1,209 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusters
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func getLabels(t *testing.T, client crclient.Client, name string) map[string]string {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: name}, mc); err != nil {
+		t.Fatal(err)
+	}
+	return mc.GetLabels()
+}
+
+func Test_parseLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantAdd    map[string]string
+		wantRemove []string
+		wantErr    bool
+	}{
+		{
+			name:       "Success",
+			args:       []string{"region=eu", "env-", "empty="},
+			wantAdd:    map[string]string{"region": "eu", "empty": ""},
+			wantRemove: []string{"env"},
+		},
+		{
+			name:    "Failed, no operator",
+			args:    []string{"region"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, invalid value",
+			args:    []string{"region=eu west"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, added and removed",
+			args:    []string{"region=eu", "region-"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add, remove, err := parseLabels(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(add, tt.wantAdd) || !reflect.DeepEqual(remove, tt.wantRemove) {
+				t.Errorf("parseLabels() = %v %v, want %v %v", add, remove, tt.wantAdd, tt.wantRemove)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    Options
+		wantErr    bool
+		wantLabels map[string]map[string]string
+	}{
+		{
+			name: "Success, selector",
+			options: Options{
+				selector:     "env=dev",
+				addLabels:    map[string]string{"region": "eu"},
+				removeLabels: []string{"env"},
+			},
+			wantLabels: map[string]map[string]string{
+				"cluster1": {"region": "eu"},
+				"cluster2": {"region": "eu", "tier": "gold"},
+				"cluster3": {"env": "prod"},
+			},
+		},
+		{
+			name: "Success, dry-run",
+			options: Options{
+				selector:     "env=dev",
+				addLabels:    map[string]string{"region": "eu"},
+				removeLabels: []string{"env"},
+				dryRun:       true,
+			},
+			wantLabels: map[string]map[string]string{
+				"cluster1": {"env": "dev"},
+				"cluster2": {"env": "dev", "tier": "gold"},
+			},
+		},
+		{
+			name: "Success, overwrite",
+			options: Options{
+				clusters:  []string{"cluster2", "cluster3"},
+				addLabels: map[string]string{"tier": "silver"},
+				overwrite: true,
+			},
+			wantLabels: map[string]map[string]string{
+				"cluster2": {"env": "dev", "tier": "silver"},
+				"cluster3": {"env": "prod", "tier": "silver"},
+			},
+		},
+		{
+			name: "Failed, existing label without overwrite, nothing changed",
+			options: Options{
+				clusters:  []string{"cluster3", "cluster2"},
+				addLabels: map[string]string{"tier": "silver"},
+			},
+			wantErr: true,
+			wantLabels: map[string]map[string]string{
+				"cluster3": {"env": "prod"},
+			},
+		},
+		{
+			name: "Failed, no cluster matches",
+			options: Options{
+				selector:  "env=test",
+				addLabels: map[string]string{"region": "eu"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed, cluster not found",
+			options: Options{
+				clusters:  []string{"cluster4"},
+				addLabels: map[string]string{"region": "eu"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(
+				fake.NewManagedCluster("cluster1", fake.WithLabels(map[string]string{"env": "dev"})),
+				fake.NewManagedCluster("cluster2", fake.WithLabels(map[string]string{"env": "dev", "tier": "gold"})),
+				fake.NewManagedCluster("cluster3", fake.WithLabels(map[string]string{"env": "prod"})),
+			)
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := tt.options
+			o.IOStreams = streams
+			if err := o.runWithClient(client); (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for name, want := range tt.wantLabels {
+				if got := getLabels(t, client, name); !reflect.DeepEqual(got, want) {
+					t.Errorf("labels of %s = %v, want %v", name, got, want)
+				}
+			}
+			if o.dryRun && !strings.Contains(out.String(), "cluster1 would be labeled: +region=eu, -env") {
+				t.Errorf("the dry-run must print the changes, got %s", out.String())
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr bool
+	}{
+		{
+			name:    "Success",
+			options: Options{selector: "env in (dev,test)", removeLabels: []string{"env"}},
+		},
+		{
+			name:    "Failed, no label",
+			options: Options{selector: "env=dev"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, no cluster selected",
+			options: Options{removeLabels: []string{"env"}},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, selector and clusters",
+			options: Options{selector: "env=dev", clusters: []string{"cluster1"}, removeLabels: []string{"env"}},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, invalid selector",
+			options: Options{selector: "env in (dev", removeLabels: []string{"env"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusters
+
+import (
+	"context"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags *genericclioptions.ConfigFlags
+	selector    string
+	clusters    []string
+	overwrite   bool
+	dryRun      bool
+	//addLabels and removeLabels are parsed from the arguments
+	addLabels    map[string]string
+	removeLabels []string
+	ctx          context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ctx:         context.Background(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusters
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Move the clusters c1 and c2 of the current hub to the hub of the new-hub context
+%[1]s migrate clusters --to-hub new-hub --clusters c1,c2
+
+# Move the clusters through their own kubeconfigs, read from spokes/c1.kubeconfig and spokes/c2.kubeconfig
+%[1]s migrate clusters --to-hub new-hub --clusters c1,c2 --spoke-kubeconfigs spokes
+
+# Move the clusters and keep them on the current hub, to detach them later
+%[1]s migrate clusters --to-hub new-hub --clusters c1 --keep-source
+`
+
+// NewCmd provides a cobra command moving managed clusters from the current hub to another hub
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "clusters",
+		Short: "Move managed clusters to another hub",
+		Long: "Move managed clusters from the current hub to the hub of the --to-hub context, for a disaster recovery or a hub migration. " +
+			"The clusters are created on the target hub with their labels, annotations, clusterset and addon configuration, " +
+			"then their klusterlet is re-pointed at the target hub: the bootstrap kubeconfig of the target hub is delivered " +
+			"by a ManifestWork of the current hub, or the target hub imports the clusters with their --spoke-kubeconfigs. " +
+			"Once a cluster joined the target hub, it is detached from the current hub. The klusterlets which do not " +
+			"bootstrap again when their bootstrap kubeconfig changes need their hub-kubeconfig-secret to be deleted",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.toHub, "to-hub", "", "The kubeconfig context of the target hub")
+	cmd.Flags().StringSliceVar(&o.clusterNames, "clusters", nil, "The managed clusters to move, separated by commas")
+	cmd.Flags().StringVar(&o.spokeKubeConfigsDir, "spoke-kubeconfigs", "", "A directory with a <cluster>.kubeconfig per cluster, the target hub imports the clusters with them instead of the ManifestWork of the current hub")
+	cmd.Flags().BoolVar(&o.keepSource, "keep-source", false, "Keep the clusters on the current hub once they joined the target hub")
+	helpers.DurationVar(cmd.Flags(), &o.timeout, "timeout", 10*time.Minute, "Timeout to wait for each cluster to join the target hub, e.g. 10m")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,266 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusters
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	autoImportSecretName = "auto-import-secret"
+	autoImportRetry      = "5"
+	// bootstrapWorkSuffix is the suffix of the ManifestWork delivering the bootstrap secret of the target hub
+	bootstrapWorkSuffix = "-migrate-bootstrap"
+
+	deliveryAutoImport   = "auto-import"
+	deliveryManifestWork = "manifestwork"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	if o.spokeKubeConfigsDir == "" {
+		return nil
+	}
+	o.kubeConfigs = make(map[string][]byte, len(o.clusterNames))
+	for _, clusterName := range o.clusterNames {
+		b, err := ioutil.ReadFile(filepath.Join(filepath.Clean(o.spokeKubeConfigsDir), clusterName+".kubeconfig"))
+		if err != nil {
+			return err
+		}
+		o.kubeConfigs[clusterName] = b
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.toHub == "" {
+		return fmt.Errorf("the kubeconfig context of the target hub is missing, set it with --to-hub")
+	}
+	if o.configFlags.Context != nil && *o.configFlags.Context == o.toHub {
+		return fmt.Errorf("the target hub %s must be different from the current hub", o.toHub)
+	}
+	if len(o.clusterNames) == 0 {
+		return fmt.Errorf("the clusters to migrate are missing, set them with --clusters")
+	}
+	for _, clusterName := range o.clusterNames {
+		if clusterName == "local-cluster" {
+			return fmt.Errorf("the local-cluster is the hub itself and can not be migrated")
+		}
+	}
+	if o.timeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than 0")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	source, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	targetFlags := genericclioptions.NewConfigFlags(true)
+	targetFlags.KubeConfig = o.configFlags.KubeConfig
+	targetFlags.Context = &o.toHub
+	target, err := clients.ForFlags(targetFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClients(source, target)
+}
+
+func (o *Options) runWithClients(source, target crclient.Client) error {
+	inventory, err := o.clustersInventory(source)
+	if err != nil {
+		return err
+	}
+	w := printers.Messages(o.ErrOut)
+	changes, err := helpers.ReconcileInventory(o.ctx, target, inventory, false)
+	if err != nil {
+		return fmt.Errorf("failed to create the clusters on the target hub %s: %v", o.toHub, err)
+	}
+	for _, c := range changes {
+		fmt.Fprintf(w, "%s %s %s on the target hub\n", c.Kind, c.Name, c.Action)
+	}
+
+	table := &printers.Table{Headers: []string{"CLUSTER", "DELIVERY", "STATUS"}}
+	failed := make([]string, 0)
+	for _, clusterName := range o.clusterNames {
+		delivery, err := o.migrateCluster(source, target, clusterName)
+		status := "migrated"
+		if err != nil {
+			status = err.Error()
+			failed = append(failed, clusterName)
+		}
+		table.AddRow(clusterName, delivery, status)
+		if err == helpers.ErrInterrupted {
+			break
+		}
+	}
+	if err := printers.PrintTable(o.Out, table); err != nil {
+		return err
+	}
+	if len(failed) != 0 {
+		return fmt.Errorf("the migration of clusters %s failed", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// clustersInventory returns the inventory of the clusters to migrate with the clustersets they belong to
+func (o *Options) clustersInventory(source crclient.Client) (*helpers.Inventory, error) {
+	hubInventory, err := helpers.ExportInventory(o.ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	clusters := make(map[string]helpers.InventoryCluster, len(hubInventory.Clusters))
+	for _, c := range hubInventory.Clusters {
+		clusters[c.Name] = c
+	}
+	inventory := &helpers.Inventory{APIVersion: hubInventory.APIVersion, Kind: hubInventory.Kind}
+	clusterSets := make(map[string]bool)
+	for _, clusterName := range o.clusterNames {
+		c, ok := clusters[clusterName]
+		if !ok {
+			return nil, fmt.Errorf("cluster %s does not exist on the current hub", clusterName)
+		}
+		inventory.Clusters = append(inventory.Clusters, c)
+		if c.ClusterSet != "" {
+			clusterSets[c.ClusterSet] = true
+		}
+	}
+	for _, cs := range hubInventory.ClusterSets {
+		if clusterSets[cs.Name] {
+			inventory.ClusterSets = append(inventory.ClusterSets, cs)
+		}
+	}
+	return inventory, nil
+}
+
+// migrateCluster re-points the klusterlet of the cluster at the target hub, waits for it to join
+// and detaches the cluster from the current hub. It returns how the target hub was delivered to the klusterlet
+func (o *Options) migrateCluster(source, target crclient.Client, clusterName string) (string, error) {
+	w := printers.Messages(o.ErrOut)
+	delivery := deliveryManifestWork
+	if _, ok := o.kubeConfigs[clusterName]; ok {
+		delivery = deliveryAutoImport
+	}
+	var err error
+	switch delivery {
+	case deliveryAutoImport:
+		err = o.createAutoImportSecret(target, clusterName)
+	default:
+		err = o.applyBootstrapWork(source, target, clusterName)
+	}
+	if err != nil {
+		return delivery, err
+	}
+
+	fmt.Fprintf(w, "Waiting for cluster %s to join the target hub %s\n", clusterName, o.toHub)
+	if err := helpers.WaitForCluster(o.ctx, target, clusterName, helpers.WaitForJoined, o.pollInterval, o.timeout); err != nil {
+		return delivery, err
+	}
+	if o.keepSource {
+		fmt.Fprintf(w, "Cluster %s joined the target hub, it is kept on the current hub\n", clusterName)
+		return delivery, nil
+	}
+
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	mc.SetName(clusterName)
+	if err := source.Delete(o.ctx, mc); err != nil && !errors.IsNotFound(err) {
+		return delivery, fmt.Errorf("joined the target hub but was not detached from the current hub: %v", err)
+	}
+	fmt.Fprintf(w, "Cluster %s joined the target hub and was detached from the current hub\n", clusterName)
+	return delivery, nil
+}
+
+// createAutoImportSecret lets the import controller of the target hub import the cluster with its kubeconfig
+func (o *Options) createAutoImportSecret(target crclient.Client, clusterName string) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: clusterName, Name: autoImportSecretName},
+		Type:       corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"autoImportRetry": []byte(autoImportRetry),
+			"kubeconfig":      o.kubeConfigs[clusterName],
+		},
+	}
+	err := target.Create(o.ctx, secret)
+	if errors.IsAlreadyExists(err) {
+		return target.Update(o.ctx, secret)
+	}
+	return err
+}
+
+// applyBootstrapWork delivers the bootstrap secret of the target hub to the klusterlet with a ManifestWork of
+// the current hub. The work orphans the secret, it must stay on the cluster once the cluster is detached from the current hub
+func (o *Options) applyBootstrapWork(source, target crclient.Client, clusterName string) error {
+	bootstrap, err := o.waitForBootstrapSecret(target, clusterName)
+	if err != nil {
+		return err
+	}
+	work := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"deleteOption": map[string]interface{}{"propagationPolicy": "Orphan"},
+			"workload": map[string]interface{}{
+				"manifests": []interface{}{bootstrap.Object},
+			},
+		},
+	}}
+	work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	work.SetNamespace(clusterName)
+	work.SetName(clusterName + bootstrapWorkSuffix)
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	err = source.Get(o.ctx, types.NamespacedName{Namespace: clusterName, Name: work.GetName()}, existing)
+	switch {
+	case errors.IsNotFound(err):
+		err = source.Create(o.ctx, work)
+	case err == nil:
+		existing.Object["spec"] = work.Object["spec"]
+		err = source.Update(o.ctx, existing)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to deliver the bootstrap kubeconfig of the target hub: %v", err)
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "Bootstrap kubeconfig of the target hub delivered to cluster %s by the ManifestWork %s/%s\n",
+		clusterName, clusterName, work.GetName())
+	return nil
+}
+
+// waitForBootstrapSecret waits for the import controller of the target hub to generate the import secret
+// of the cluster and returns its bootstrap secret
+func (o *Options) waitForBootstrapSecret(target crclient.Client, clusterName string) (*unstructured.Unstructured, error) {
+	var importSecret *corev1.Secret
+	err := helpers.PollImmediate(o.ctx, o.pollInterval, o.timeout, func() (bool, error) {
+		var err error
+		importSecret, err = helpers.GetImportSecret(o.ctx, target, clusterName)
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err == wait.ErrWaitTimeout {
+		return nil, fmt.Errorf("the import secret was not generated by the target hub after %s", o.timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return helpers.GetBootstrapSecret(importSecret)
+}
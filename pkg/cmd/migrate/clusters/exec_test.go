@@ -0,0 +1,214 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusters
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var importYAML = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: open-cluster-management-agent
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: bootstrap-hub-kubeconfig
+  namespace: open-cluster-management-agent
+data:
+  kubeconfig: dGFyZ2V0
+`
+
+// newProdCluster returns a prod cluster of the set, accepted and joined as given
+func newProdCluster(name, clusterSet, joined string) *unstructured.Unstructured {
+	return fake.NewManagedCluster(name,
+		fake.WithLabels(map[string]string{"env": "prod", helpers.ClusterSetLabel: clusterSet}),
+		fake.WithConditions(
+			fake.NewCondition("HubAcceptedManagedCluster", joined),
+			fake.NewCondition("ManagedClusterJoined", joined)))
+}
+
+func newClusterSet(name string) *unstructured.Unstructured {
+	cs := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	cs.SetGroupVersionKind(helpers.ManagedClusterSetGVK)
+	cs.SetName(name)
+	return cs
+}
+
+// newTargetImportSecret returns the import secret generated by the target hub
+func newTargetImportSecret(clusterName string) *corev1.Secret {
+	return fake.NewImportSecret(clusterName, map[string][]byte{
+		helpers.ImportSecretCRDsKey:   []byte(""),
+		helpers.ImportSecretImportKey: []byte(importYAML),
+	})
+}
+
+func TestOptions_runWithClients(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      []runtime.Object
+		target      []runtime.Object
+		kubeConfigs map[string][]byte
+		keepSource  bool
+		contains    []string
+		wantWork    bool
+		wantErr     string
+	}{
+		{
+			name:     "Success, manifestwork",
+			source:   []runtime.Object{newProdCluster("c1", "set1", "True"), newClusterSet("set1"), newClusterSet("set2")},
+			target:   []runtime.Object{newTargetImportSecret("c1"), newProdCluster("c1", "", "True")},
+			contains: []string{"c1", deliveryManifestWork, "migrated"},
+			wantWork: true,
+		},
+		{
+			name:        "Success, auto-import",
+			source:      []runtime.Object{newProdCluster("c1", "", "True")},
+			target:      []runtime.Object{newProdCluster("c1", "", "True")},
+			kubeConfigs: map[string][]byte{"c1": []byte("spoke-kubeconfig")},
+			contains:    []string{deliveryAutoImport, "migrated"},
+		},
+		{
+			name:       "Success, keep source",
+			source:     []runtime.Object{newProdCluster("c1", "", "True")},
+			target:     []runtime.Object{newTargetImportSecret("c1"), newProdCluster("c1", "", "True")},
+			keepSource: true,
+			wantWork:   true,
+		},
+		{
+			name:    "Failed, unknown cluster",
+			wantErr: "does not exist",
+		},
+		{
+			name:    "Failed, no import secret",
+			source:  []runtime.Object{newProdCluster("c1", "", "True")},
+			wantErr: "migration of clusters c1 failed",
+		},
+		{
+			name:    "Failed, not joined",
+			source:  []runtime.Object{newProdCluster("c1", "", "True")},
+			target:  []runtime.Object{newTargetImportSecret("c1")},
+			wantErr: "migration of clusters c1 failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.toHub = "target"
+			o.clusterNames = []string{"c1"}
+			o.kubeConfigs = tt.kubeConfigs
+			o.keepSource = tt.keepSource
+			o.timeout = 50 * time.Millisecond
+			o.pollInterval = 10 * time.Millisecond
+			source := fake.NewClient(tt.source...)
+			target := fake.NewClient(tt.target...)
+			err := o.runWithClients(source, target)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error containing %q expected, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+
+			mc := &unstructured.Unstructured{}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			if err := target.Get(context.TODO(), types.NamespacedName{Name: "c1"}, mc); err != nil {
+				t.Fatal(err)
+			}
+			if mc.GetLabels()["env"] != "prod" {
+				t.Errorf("the labels of the cluster must be copied to the target hub, got %v", mc.GetLabels())
+			}
+			if set := mc.GetLabels()[helpers.ClusterSetLabel]; set != "" {
+				cs := newClusterSet(set)
+				if err := target.Get(context.TODO(), types.NamespacedName{Name: set}, cs); err != nil {
+					t.Errorf("the clusterset %s must be created on the target hub: %v", set, err)
+				}
+				if err := target.Get(context.TODO(), types.NamespacedName{Name: "set2"}, newClusterSet("set2")); err == nil {
+					t.Error("the clusterset set2 is not used by the migrated clusters")
+				}
+			}
+
+			err = source.Get(context.TODO(), types.NamespacedName{Name: "c1"}, mc)
+			if tt.keepSource && err != nil {
+				t.Errorf("the cluster must be kept on the current hub: %v", err)
+			}
+			if !tt.keepSource && err == nil {
+				t.Error("the cluster must be detached from the current hub")
+			}
+
+			if tt.kubeConfigs != nil {
+				secret := &corev1.Secret{}
+				if err := target.Get(context.TODO(), types.NamespacedName{Namespace: "c1", Name: autoImportSecretName}, secret); err != nil {
+					t.Fatal(err)
+				}
+				if string(secret.Data["kubeconfig"]) != "spoke-kubeconfig" {
+					t.Errorf("unexpected auto-import secret %v", secret.Data)
+				}
+			}
+			work := &unstructured.Unstructured{}
+			work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+			err = source.Get(context.TODO(), types.NamespacedName{Namespace: "c1", Name: "c1" + bootstrapWorkSuffix}, work)
+			if !tt.wantWork {
+				if err == nil {
+					t.Error("no bootstrap ManifestWork expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			manifests, _, _ := unstructured.NestedSlice(work.Object, "spec", "workload", "manifests")
+			if len(manifests) != 1 || manifests[0].(map[string]interface{})["metadata"].(map[string]interface{})["name"] != helpers.BootstrapSecretName {
+				t.Errorf("the ManifestWork must carry the bootstrap secret, got %v", manifests)
+			}
+			if policy, _, _ := unstructured.NestedString(work.Object, "spec", "deleteOption", "propagationPolicy"); policy != "Orphan" {
+				t.Errorf("the bootstrap secret must be orphaned, got %s", policy)
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	source := "source"
+	tests := []struct {
+		name    string
+		o       Options
+		wantErr bool
+	}{
+		{name: "Success", o: Options{toHub: "target", clusterNames: []string{"c1"}, timeout: time.Minute}},
+		{name: "Failed, no target hub", o: Options{clusterNames: []string{"c1"}, timeout: time.Minute}, wantErr: true},
+		{name: "Failed, same hub", o: Options{toHub: "source", clusterNames: []string{"c1"}, timeout: time.Minute}, wantErr: true},
+		{name: "Failed, no clusters", o: Options{toHub: "target", timeout: time.Minute}, wantErr: true},
+		{name: "Failed, local-cluster", o: Options{toHub: "target", clusterNames: []string{"local-cluster"}, timeout: time.Minute}, wantErr: true},
+		{name: "Failed, no timeout", o: Options{toHub: "target", clusterNames: []string{"c1"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.o.configFlags = genericclioptions.NewConfigFlags(true)
+			tt.o.configFlags.Context = &source
+			if err := tt.o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
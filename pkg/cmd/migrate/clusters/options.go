@@ -0,0 +1,37 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusters
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//configFlags are the flags of the source hub
+	configFlags  *genericclioptions.ConfigFlags
+	toHub        string
+	clusterNames []string
+	//spokeKubeConfigsDir holds a <cluster>.kubeconfig per cluster imported by the target hub
+	spokeKubeConfigsDir string
+	//kubeConfigs are the kubeconfigs of the clusters read from spokeKubeConfigsDir
+	kubeConfigs  map[string][]byte
+	keepSource   bool
+	timeout      time.Duration
+	pollInterval time.Duration
+	//ctx is canceled on Ctrl+C to abort the waits
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		pollInterval: 5 * time.Second,
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
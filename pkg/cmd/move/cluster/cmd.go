@@ -0,0 +1,57 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Rename a cluster, the kubeconfig of the cluster is needed to import it again
+%[1]s move cluster mycluster --to newname --cluster-kubeconfig mycluster.kubeconfig
+
+# Rename a cluster using a server/token pair
+%[1]s move cluster mycluster --to newname --cluster-server https://api.mycluster:6443 --cluster-token <token>
+`
+
+// NewCmd provides a cobra command detaching a cluster and attaching it again under a new name
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "cluster <name>",
+		Short: "Rename a managed cluster",
+		Long: "Rename a managed cluster by detaching it and importing it again under the new name, " +
+			"the labels, annotations, clusterset membership and addons of the cluster are preserved",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.newClusterName, "to", "", "The new name of the cluster")
+	cmd.Flags().StringVar(&o.kubeConfigPath, "cluster-kubeconfig", "", "Path to the kubeconfig of the cluster to import again")
+	cmd.Flags().StringVar(&o.clusterServer, "cluster-server", "", "Server url of the cluster to import again")
+	cmd.Flags().StringVar(&o.clusterToken, "cluster-token", "", "Token to access the cluster to import again")
+	helpers.DurationVar(cmd.Flags(), &o.timeout, "timeout", 10*time.Minute, "Timeout to wait for the detach of the cluster, e.g. 10m")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
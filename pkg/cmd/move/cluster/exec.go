@@ -0,0 +1,236 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	attachScenarioDirectory = "scenarios/attach"
+)
+
+// ignoredLabels are set by the hub for the cluster name and must not be copied to the new cluster
+var ignoredLabels = []string{"name", "local-cluster"}
+
+// ignoredAnnotations are related to the previous object and must not be copied to the new cluster
+var ignoredAnnotations = []string{"kubectl.kubernetes.io/last-applied-configuration"}
+
+// addonPaths are the addons of the KlusterletAddonConfig carried over to the new cluster
+var addonPaths = [][]string{
+	{"applicationManager", "enabled"},
+	{"applicationManager", "argocdCluster"},
+	{"policyController", "enabled"},
+	{"searchCollector", "enabled"},
+	{"certPolicyController", "enabled"},
+	{"iamPolicyController", "enabled"},
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 0 {
+		o.clusterName = args[0]
+	}
+	if o.kubeConfigPath != "" {
+		b, err := ioutil.ReadFile(filepath.Clean(o.kubeConfigPath))
+		if err != nil {
+			return err
+		}
+		o.clusterKubeConfig = string(b)
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.clusterName == "" {
+		return fmt.Errorf("the name of the cluster to move is missing")
+	}
+	if o.clusterName == "local-cluster" {
+		return fmt.Errorf("the local-cluster can not be renamed")
+	}
+	if o.newClusterName == "" {
+		return fmt.Errorf("the new name must be provided with --to")
+	}
+	if o.newClusterName == o.clusterName {
+		return fmt.Errorf("the new name must be different from %s", o.clusterName)
+	}
+	if errs := validation.IsDNS1123Label(o.newClusterName); len(errs) != 0 {
+		return fmt.Errorf("invalid new name %s: %v", o.newClusterName, errs)
+	}
+	if o.clusterKubeConfig != "" && (o.clusterServer != "" || o.clusterToken != "") {
+		return fmt.Errorf("--cluster-kubeconfig and --cluster-server/--cluster-token are mutually exclusive")
+	}
+	if o.clusterKubeConfig == "" && (o.clusterServer == "" || o.clusterToken == "") {
+		return fmt.Errorf("either --cluster-kubeconfig or --cluster-server and --cluster-token must be provided to import the cluster again")
+	}
+	if o.timeout <= 0 {
+		return fmt.Errorf("timeout must be greater than 0")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName}, mc); err != nil {
+		return err
+	}
+
+	newMC := &unstructured.Unstructured{}
+	newMC.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.newClusterName}, newMC)
+	if err == nil {
+		return fmt.Errorf("the cluster %s already exists", o.newClusterName)
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	values, err := o.getValues(client)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(printers.Messages(o.ErrOut), "Detaching cluster %s\n", o.clusterName)
+	if err := client.Delete(o.ctx, mc); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	err = helpers.PollImmediate(o.ctx, o.pollInterval, o.timeout, func() (bool, error) {
+		err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName}, mc.DeepCopy())
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("the cluster %s was not detached: %s", o.clusterName, err.Error())
+	}
+
+	fmt.Fprintf(printers.Messages(o.ErrOut), "Attaching cluster as %s\n", o.newClusterName)
+	applyOptions := &appliercmd.Options{
+		ConfigFlags: o.configFlags,
+		//the applier timeout is in seconds, rounded up so a sub-second timeout is not 0
+		Timeout:   int(math.Ceil(o.timeout.Seconds())),
+		Silent:    true,
+		IOStreams: o.IOStreams,
+	}
+	err = applyOptions.ApplyWithValues(client, resources.NewResourcesReader(),
+		filepath.Join(attachScenarioDirectory, "hub"),
+		values)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Get(o.ctx, types.NamespacedName{Name: o.newClusterName}, newMC); err != nil {
+		return err
+	}
+	newMC.SetLabels(preservedLabels(mc, newMC))
+	newMC.SetAnnotations(preservedAnnotations(mc, newMC))
+	if err := client.Update(o.ctx, newMC); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(printers.Messages(o.ErrOut), "Cluster %s moved to %s, follow the import with\n%s status %s\n",
+		o.clusterName, o.newClusterName, helpers.GetExampleHeader(), o.newClusterName)
+	printers.PrintIdentifier(o.Out, o.newClusterName)
+	return nil
+}
+
+// getValues builds the attach values of the new cluster with the addons of the old cluster
+func (o *Options) getValues(client crclient.Client) (map[string]interface{}, error) {
+	addons := map[string]interface{}{}
+	kac := &unstructured.Unstructured{}
+	kac.SetGroupVersionKind(helpers.KlusterletAddonConfigGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName, Namespace: o.clusterName}, kac)
+	switch {
+	case errors.IsNotFound(err):
+	case err != nil:
+		return nil, err
+	default:
+		for _, p := range addonPaths {
+			if v, found, _ := unstructured.NestedBool(kac.Object, append([]string{"spec"}, p...)...); found {
+				if err := unstructured.SetNestedField(addons, v, p...); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	//The template requires all addons, the missing ones keep the attach defaults
+	for _, p := range addonPaths {
+		if _, found, _ := unstructured.NestedFieldNoCopy(addons, p...); !found {
+			if err := unstructured.SetNestedField(addons, p[1] == "enabled", p...); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"managedClusterName": o.newClusterName,
+		"autoImportRetry":    int64(5),
+		"kubeConfig":         o.clusterKubeConfig,
+		"server":             o.clusterServer,
+		"token":              o.clusterToken,
+		"addons":             addons,
+	}, nil
+}
+
+// preservedLabels returns the labels of the new cluster overwritten by the labels of the old cluster,
+// the clusterset membership is a label and so is preserved
+func preservedLabels(oldMC, newMC *unstructured.Unstructured) map[string]string {
+	labels := newMC.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for k, v := range oldMC.GetLabels() {
+		labels[k] = v
+	}
+	for _, k := range ignoredLabels {
+		delete(labels, k)
+		if v, ok := newMC.GetLabels()[k]; ok {
+			labels[k] = v
+		}
+	}
+	return labels
+}
+
+// preservedAnnotations returns the annotations of the new cluster overwritten by the annotations of the old cluster
+func preservedAnnotations(oldMC, newMC *unstructured.Unstructured) map[string]string {
+	annotations := newMC.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for k, v := range oldMC.GetAnnotations() {
+		annotations[k] = v
+	}
+	for _, k := range ignoredAnnotations {
+		delete(annotations, k)
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
@@ -0,0 +1,177 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newKlusterletAddonConfig(name string) *unstructured.Unstructured {
+	kac := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"searchCollector": map[string]interface{}{
+					"enabled": false,
+				},
+				"policyController": map[string]interface{}{
+					"enabled": true,
+				},
+			},
+		},
+	}
+	kac.SetGroupVersionKind(helpers.KlusterletAddonConfigGVK)
+	kac.SetName(name)
+	kac.SetNamespace(name)
+	return kac
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       Options
+		wantErr bool
+	}{
+		{
+			name: "Success, kubeconfig",
+			o:    Options{clusterName: "old", newClusterName: "new", clusterKubeConfig: "kubeconfig", timeout: 10 * time.Second},
+		},
+		{
+			name: "Success, server/token",
+			o:    Options{clusterName: "old", newClusterName: "new", clusterServer: "server", clusterToken: "token", timeout: 10 * time.Second},
+		},
+		{
+			name:    "Failed, new name missing",
+			o:       Options{clusterName: "old", clusterKubeConfig: "kubeconfig", timeout: 10 * time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, same name",
+			o:       Options{clusterName: "old", newClusterName: "old", clusterKubeConfig: "kubeconfig", timeout: 10 * time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, invalid new name",
+			o:       Options{clusterName: "old", newClusterName: "New_Name", clusterKubeConfig: "kubeconfig", timeout: 10 * time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, local-cluster",
+			o:       Options{clusterName: "local-cluster", newClusterName: "new", clusterKubeConfig: "kubeconfig", timeout: 10 * time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, credentials missing",
+			o:       Options{clusterName: "old", newClusterName: "new", clusterServer: "server", timeout: 10 * time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, kubeconfig and server/token",
+			o:       Options{clusterName: "old", newClusterName: "new", clusterKubeConfig: "kubeconfig", clusterServer: "server", clusterToken: "token", timeout: 10 * time.Second},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		objs    []runtime.Object
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			objs: []runtime.Object{
+				fake.NewManagedCluster("old",
+					fake.WithLabels(map[string]string{helpers.ClusterSetLabel: "myset", "env": "prod", "name": "old"}),
+					fake.WithAnnotations(map[string]string{"owner": "team-a"})),
+				newKlusterletAddonConfig("old"),
+			},
+		},
+		{
+			name:    "Failed, cluster not found",
+			wantErr: true,
+		},
+		{
+			name: "Failed, new name already exists",
+			objs: []runtime.Object{
+				fake.NewManagedCluster("old"),
+				fake.NewManagedCluster("new"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(tt.objs...)
+			o := &Options{
+				configFlags:    genericclioptions.NewConfigFlags(true),
+				clusterName:    "old",
+				newClusterName: "new",
+				clusterServer:  "https://api.old:6443",
+				clusterToken:   "token",
+				timeout:        time.Second,
+				ctx:            context.Background(),
+				pollInterval:   10 * time.Millisecond,
+				IOStreams:      genericclioptions.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}},
+			}
+			err := o.runWithClient(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			old := fake.NewManagedCluster("old")
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "old"}, old); !errors.IsNotFound(err) {
+				t.Errorf("the old cluster must be deleted, got %v", err)
+			}
+
+			mc := fake.NewManagedCluster("new")
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "new"}, mc); err != nil {
+				t.Fatal(err)
+			}
+			labels := mc.GetLabels()
+			if labels[helpers.ClusterSetLabel] != "myset" || labels["env"] != "prod" {
+				t.Errorf("the labels must be preserved, got %v", labels)
+			}
+			if _, ok := labels["name"]; ok {
+				t.Errorf("the name label must not be copied, got %v", labels)
+			}
+			if mc.GetAnnotations()["owner"] != "team-a" {
+				t.Errorf("the annotations must be preserved, got %v", mc.GetAnnotations())
+			}
+
+			kac := newKlusterletAddonConfig("new")
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "new", Namespace: "new"}, kac); err != nil {
+				t.Fatal(err)
+			}
+			if enabled, _, _ := unstructured.NestedBool(kac.Object, "spec", "searchCollector", "enabled"); enabled {
+				t.Error("the disabled addons must stay disabled")
+			}
+
+			secret := &corev1.Secret{}
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "auto-import-secret", Namespace: "new"}, secret); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
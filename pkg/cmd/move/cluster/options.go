@@ -0,0 +1,35 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags       *genericclioptions.ConfigFlags
+	clusterName       string
+	newClusterName    string
+	kubeConfigPath    string
+	clusterKubeConfig string
+	clusterServer     string
+	clusterToken      string
+	timeout           time.Duration
+	pollInterval      time.Duration
+	//ctx is canceled on Ctrl+C to abort the waits
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		pollInterval: 5 * time.Second,
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
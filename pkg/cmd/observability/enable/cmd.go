@@ -0,0 +1,73 @@
+// Copyright Contributors to the Open Cluster Management project
+package enable
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Enable the observability with metrics stored in a S3 bucket
+%[1]s observability enable --storage-config s3.yaml
+
+# Enable the observability and wait until it is ready
+%[1]s observability enable --storage-config s3.yaml --wait
+`
+
+const (
+	scenarioDirectory = "scenarios/observability"
+)
+
+var valuesTemplatePath = filepath.Join(scenarioDirectory, "values-template.yaml")
+
+// valuesSchema defines the flag of each value of the values-template.yaml
+var valuesSchema = applierscenarios.ValuesSchema{
+	{Path: "observability.enableMetrics", Flag: "enable-metrics", Type: applierscenarios.BoolValue, Usage: "Collect the metrics of the managed clusters"},
+	{Path: "observability.interval", Flag: "interval", Type: applierscenarios.IntValue, Usage: "Interval in seconds between two metrics collections"},
+}
+
+// NewCmd provides a cobra command setting up the MultiClusterObservability on the hub
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "enable",
+		Short: "Enable the observability of the managed clusters",
+		Long: "Create the MultiClusterObservability on the hub with the thanos object storage configuration, " +
+			"the metrics-collector is then deployed on the managed clusters",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.SetUsageTemplate(applierscenarios.UsageTempate(cmd, valuesTemplatePath))
+	valuesSchema.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.storageConfigPath, "storage-config", "", "The thanos object storage configuration file, for example a S3 bucket configuration")
+	cmd.Flags().BoolVar(&o.wait, "wait", false, "Wait until the MultiClusterObservability is ready")
+	helpers.DurationVar(cmd.Flags(), &o.waitTimeout, "wait-timeout", 10*time.Minute, "Timeout to wait for the observability, e.g. 10m")
+
+	o.applierScenariosOptions.AddFlags(cmd.Flags())
+	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,174 @@
+// Copyright Contributors to the Open Cluster Management project
+package enable
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	multiClusterObservabilityName = "observability"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	o.values, err = o.applierScenariosOptions.ReadValuesWithDefaults(valuesTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	var flagSet *pflag.FlagSet
+	if cmd != nil {
+		flagSet = cmd.Flags()
+	}
+	if err := valuesSchema.MergeFlags(flagSet, o.values); err != nil {
+		return err
+	}
+
+	if o.storageConfigPath != "" {
+		storageConfig, err := readStorageConfig(o.storageConfigPath)
+		if err != nil {
+			return err
+		}
+		observability, _ := o.values["observability"].(map[string]interface{})
+		if observability == nil {
+			observability = map[string]interface{}{}
+			o.values["observability"] = observability
+		}
+		observability["storageConfig"] = storageConfig
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	observability, _ := o.values["observability"].(map[string]interface{})
+	storageConfig, _ := observability["storageConfig"].(map[string]interface{})
+	if err := validateStorageConfig(storageConfig); err != nil {
+		return err
+	}
+	if o.wait && o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("wait can not be used with outFile")
+	}
+	if o.wait && o.waitTimeout <= 0 {
+		return fmt.Errorf("wait-timeout must be greater than 0")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.applierScenariosOptions.ConfigFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	reporter := o.applierScenariosOptions.NewProgressReporter()
+
+	//The pull-secret only exists on OpenShift hubs, the observability images are public otherwise
+	pullSecret, err := helpers.GetPullSecretValues(o.ctx, client)
+	switch {
+	case err == nil:
+		o.values["pullSecret"] = pullSecret
+	case !errors.IsNotFound(err):
+		return err
+	}
+
+	applyOptions := &appliercmd.Options{
+		OutFile:     o.applierScenariosOptions.OutFile,
+		ConfigFlags: o.applierScenariosOptions.ConfigFlags,
+
+		Timeout:   o.applierScenariosOptions.ApplierTimeout(),
+		Force:     o.applierScenariosOptions.Force,
+		Silent:    o.applierScenariosOptions.Silent,
+		IOStreams: o.applierScenariosOptions.IOStreams,
+	}
+
+	err = reporter.Step("apply", helpers.MultiClusterObservabilityGVK.Kind+"/"+multiClusterObservabilityName, func() error {
+		return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, resources.NewResourcesReader(), filepath.Join(scenarioDirectory, "hub"), o.values)
+	})
+	if err != nil || o.applierScenariosOptions.OutFile != "" {
+		return err
+	}
+
+	if o.wait {
+		err = reporter.Step("wait", helpers.MultiClusterObservabilityGVK.Kind+"/"+multiClusterObservabilityName, func() error {
+			err := helpers.PollImmediate(o.ctx, o.pollInterval, o.waitTimeout, func() (bool, error) {
+				return multiClusterObservabilityReady(o.ctx, client)
+			})
+			if err == wait.ErrWaitTimeout {
+				return fmt.Errorf("not ready after %s", o.waitTimeout)
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if !o.applierScenariosOptions.Silent {
+		if o.wait {
+			fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "The observability is ready, use \"%s observability status\" to check the metrics collection of the managed clusters\n", helpers.GetExampleHeader())
+		} else {
+			fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "The observability is being installed, use --wait to wait until it is ready\n")
+		}
+	}
+	return nil
+}
+
+// readStorageConfig reads the thanos object storage configuration file
+func readStorageConfig(path string) (map[string]interface{}, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	storageConfig := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &storageConfig); err != nil {
+		return nil, fmt.Errorf("unable to parse the storage configuration %s: %s", path, err.Error())
+	}
+	return storageConfig, nil
+}
+
+// validateStorageConfig checks the thanos object storage configuration has a type and a config
+func validateStorageConfig(storageConfig map[string]interface{}) error {
+	if len(storageConfig) == 0 {
+		return fmt.Errorf("the object storage configuration is required, use --storage-config or observability.storageConfig in the values file")
+	}
+	if t, _ := storageConfig["type"].(string); t == "" {
+		return fmt.Errorf("the object storage configuration must have a type, for example S3")
+	}
+	if c, _ := storageConfig["config"].(map[string]interface{}); len(c) == 0 {
+		return fmt.Errorf("the object storage configuration must have a config")
+	}
+	return nil
+}
+
+// multiClusterObservabilityReady checks the MultiClusterObservability reports the Ready condition
+func multiClusterObservabilityReady(ctx context.Context, client crclient.Client) (bool, error) {
+	mco := &unstructured.Unstructured{}
+	mco.SetGroupVersionKind(helpers.MultiClusterObservabilityGVK)
+	if err := client.Get(ctx, types.NamespacedName{Name: multiClusterObservabilityName}, mco); err != nil {
+		return false, crclient.IgnoreNotFound(err)
+	}
+	conditions, _, _ := unstructured.NestedSlice(mco.Object, "status", "conditions")
+	return helpers.GetConditionStatus(conditions, "Ready") == "True", nil
+}
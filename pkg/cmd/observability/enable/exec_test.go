@@ -0,0 +1,127 @@
+// Copyright Contributors to the Open Cluster Management project
+package enable
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const storageConfigPath = "../../../../test/unit/resources/observability/s3.yaml"
+
+func newValuesCmd(t *testing.T, args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	valuesSchema.AddFlags(cmd.Flags())
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestOptions_complete(t *testing.T) {
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+		storageConfigPath:       storageConfigPath,
+	}
+	if err := o.complete(newValuesCmd(t, "--interval", "60"), nil); err != nil {
+		t.Fatal(err)
+	}
+	observability := o.values["observability"].(map[string]interface{})
+	if fmt.Sprint(observability["interval"]) != "60" {
+		t.Errorf("interval = %v, want 60", observability["interval"])
+	}
+	storageConfig, _ := observability["storageConfig"].(map[string]interface{})
+	if storageConfig["type"] != "s3" {
+		t.Errorf("the storage configuration must be read from the file, got %v", storageConfig)
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	s3 := map[string]interface{}{"type": "s3", "config": map[string]interface{}{"bucket": "mybucket"}}
+	tests := []struct {
+		name          string
+		storageConfig interface{}
+		wait          bool
+		outFile       string
+		wantErr       bool
+	}{
+		{name: "Success", storageConfig: s3, wait: true},
+		{name: "Failed, no storage configuration", wantErr: true},
+		{name: "Failed, no type", storageConfig: map[string]interface{}{"config": map[string]interface{}{"bucket": "mybucket"}}, wantErr: true},
+		{name: "Failed, no config", storageConfig: map[string]interface{}{"type": "s3"}, wantErr: true},
+		{name: "Failed, wait with outFile", storageConfig: s3, wait: true, outFile: "observability.yaml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{OutFile: tt.outFile},
+				values:                  map[string]interface{}{"observability": map[string]interface{}{"storageConfig": tt.storageConfig}},
+				wait:                    tt.wait,
+				waitTimeout:             10 * time.Second,
+			}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := fake.NewClient()
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			Timeout:   time.Second,
+			Silent:    true,
+			IOStreams: genericclioptions.NewTestIOStreamsDiscard(),
+		},
+		storageConfigPath: storageConfigPath,
+		wait:              true,
+		waitTimeout:       5 * time.Second,
+		ctx:               context.Background(),
+		pollInterval:      100 * time.Millisecond,
+	}
+	if err := o.complete(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	//Simulate the observability operator
+	go func() {
+		mco := &unstructured.Unstructured{}
+		mco.SetGroupVersionKind(helpers.MultiClusterObservabilityGVK)
+		for i := 0; client.Get(context.TODO(), types.NamespacedName{Name: multiClusterObservabilityName}, mco) != nil; i++ {
+			if i == 200 {
+				return
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		unstructured.SetNestedSlice(mco.Object, []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True"},
+		}, "status", "conditions")
+		client.Update(context.TODO(), mco)
+	}()
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+
+	secret := &corev1.Secret{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "thanos-object-storage", Namespace: "open-cluster-management-observability"}, secret); err != nil {
+		t.Fatal(err)
+	}
+	storageConfig := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(secret.StringData["thanos.yaml"]+string(secret.Data["thanos.yaml"])), &storageConfig); err != nil {
+		t.Fatal(err)
+	}
+	if storageConfig["type"] != "s3" {
+		t.Errorf("the thanos secret must contain the storage configuration, got %v", secret)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	multiClusterObservabilityName = "observability"
+	//observabilityAddonName is the ManagedClusterAddOn reporting the metrics-collector health
+	observabilityAddonName = "observability-controller"
+	//disabledLabel excludes a cluster from the metrics collection
+	disabledLabel = "observability"
+)
+
+// clusterStatus is the metrics collection status of a cluster
+type clusterStatus struct {
+	Cluster   string `json:"cluster"`
+	Available string `json:"available"`
+	Degraded  string `json:"degraded"`
+	Message   string `json:"message,omitempty"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	return nil
+}
+
+func (o *Options) validate() error {
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	mco := &unstructured.Unstructured{}
+	mco.SetGroupVersionKind(helpers.MultiClusterObservabilityGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: multiClusterObservabilityName}, mco)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("the observability is not enabled, use \"%s observability enable\"", helpers.GetExampleHeader())
+	}
+	if err != nil {
+		return err
+	}
+	conditions, _, _ := unstructured.NestedSlice(mco.Object, "status", "conditions")
+	ready := helpers.GetConditionStatus(conditions, "Ready") == "True"
+
+	clusters, err := o.getClusters(client)
+	if err != nil {
+		return err
+	}
+
+	table := &printers.Table{
+		Headers: []string{"CLUSTER", "AVAILABLE", "DEGRADED", "MESSAGE"},
+	}
+	statuses := make([]clusterStatus, 0, len(clusters))
+	for i := range clusters {
+		status, err := getClusterStatus(o.ctx, client, &clusters[i])
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, status)
+		table.AddRow(status.Cluster, status.Available, status.Degraded, status.Message)
+	}
+
+	if o.printOptions.IsTable() {
+		if ready {
+			fmt.Fprintf(o.Out, "MultiClusterObservability %s is ready\n\n", multiClusterObservabilityName)
+		} else {
+			fmt.Fprintf(o.Out, "MultiClusterObservability %s is not ready: %s\n\n",
+				multiClusterObservabilityName, helpers.GetConditionMessage(conditions, "Ready"))
+		}
+	}
+	return o.printOptions.Print(o.Out, table, map[string]interface{}{
+		"ready":    ready,
+		"clusters": statuses,
+	})
+}
+
+// getClusters returns the clusters named by --clusters or all clusters, sorted by name
+func (o *Options) getClusters(client crclient.Client) ([]unstructured.Unstructured, error) {
+	if len(o.clusters) != 0 {
+		clusters := make([]unstructured.Unstructured, 0, len(o.clusters))
+		for _, name := range o.clusters {
+			mc := unstructured.Unstructured{}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			if err := client.Get(o.ctx, types.NamespacedName{Name: name}, &mc); err != nil {
+				return nil, err
+			}
+			clusters = append(clusters, mc)
+		}
+		return clusters, nil
+	}
+
+	mcs := &unstructured.UnstructuredList{}
+	mcs.SetGroupVersionKind(helpers.ManagedClusterGVK.GroupVersion().WithKind(helpers.ManagedClusterGVK.Kind + "List"))
+	if err := client.List(o.ctx, mcs); err != nil {
+		return nil, err
+	}
+	sort.Slice(mcs.Items, func(i, j int) bool {
+		return mcs.Items[i].GetName() < mcs.Items[j].GetName()
+	})
+	return mcs.Items, nil
+}
+
+// getClusterStatus returns the metrics-collector health reported by the observability addon of the cluster
+func getClusterStatus(ctx context.Context, client crclient.Client, mc *unstructured.Unstructured) (clusterStatus, error) {
+	status := clusterStatus{Cluster: mc.GetName()}
+	if mc.GetLabels()[disabledLabel] == "disabled" {
+		status.Available, status.Degraded = "Disabled", "Disabled"
+		status.Message = fmt.Sprintf("the cluster has the label %s=disabled", disabledLabel)
+		return status, nil
+	}
+
+	addon := &unstructured.Unstructured{}
+	addon.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK)
+	err := client.Get(ctx, types.NamespacedName{Name: observabilityAddonName, Namespace: mc.GetName()}, addon)
+	if errors.IsNotFound(err) {
+		status.Available, status.Degraded = "Unknown", "Unknown"
+		status.Message = "the metrics-collector is not deployed yet"
+		return status, nil
+	}
+	if err != nil {
+		return status, err
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(addon.Object, "status", "conditions")
+	status.Available = unknownIfEmpty(helpers.GetConditionStatus(conditions, "Available"))
+	status.Degraded = unknownIfEmpty(helpers.GetConditionStatus(conditions, "Degraded"))
+	switch {
+	case status.Degraded == "True":
+		status.Message = helpers.GetConditionMessage(conditions, "Degraded")
+	case status.Available != "True":
+		status.Message = helpers.GetConditionMessage(conditions, "Available")
+	}
+	return status, nil
+}
+
+func unknownIfEmpty(status string) string {
+	if status == "" {
+		return "Unknown"
+	}
+	return status
+}
@@ -0,0 +1,115 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newObject(name, namespace string, conditions ...interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{"conditions": conditions},
+		},
+	}
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return obj
+}
+
+func newMultiClusterObservability(conditions ...interface{}) *unstructured.Unstructured {
+	mco := newObject(multiClusterObservabilityName, "", conditions...)
+	mco.SetGroupVersionKind(helpers.MultiClusterObservabilityGVK)
+	return mco
+}
+
+func newAddon(clusterName string, conditions ...interface{}) *unstructured.Unstructured {
+	addon := newObject(observabilityAddonName, clusterName, conditions...)
+	addon.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK)
+	return addon
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	clusters := []runtime.Object{
+		fake.NewManagedCluster("cluster1"),
+		fake.NewManagedCluster("cluster2"),
+		fake.NewManagedCluster("cluster3", fake.WithLabels(map[string]string{disabledLabel: "disabled"})),
+		fake.NewManagedCluster("cluster4"),
+		newAddon("cluster1", fake.NewCondition("Available", "True")),
+		newAddon("cluster2", fake.NewCondition("Available", "False"), fake.NewCondition("Degraded", "True", fake.WithMessage("metrics-collector crashing"))),
+	}
+	tests := []struct {
+		name        string
+		objs        []runtime.Object
+		clusters    []string
+		output      string
+		wantErr     bool
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "All clusters",
+			objs: append(clusters, newMultiClusterObservability(fake.NewCondition("Ready", "True"))),
+			contains: []string{
+				"MultiClusterObservability observability is ready",
+				"metrics-collector crashing",
+				"Disabled",
+				"not deployed yet",
+			},
+		},
+		{
+			name:        "One cluster, not ready",
+			objs:        append(clusters, newMultiClusterObservability(fake.NewCondition("Ready", "False", fake.WithMessage("thanos not available")))),
+			clusters:    []string{"cluster1"},
+			contains:    []string{"not ready: thanos not available", "cluster1"},
+			notContains: []string{"cluster2"},
+		},
+		{
+			name:        "JSON",
+			objs:        append(clusters, newMultiClusterObservability(fake.NewCondition("Ready", "True"))),
+			output:      printers.OutputJSON,
+			contains:    []string{`"ready": true`, `"cluster": "cluster2"`},
+			notContains: []string{"MultiClusterObservability"},
+		},
+		{
+			name:    "Failed, observability not enabled",
+			objs:    clusters,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			printOptions := printers.NewPrintOptions()
+			if tt.output != "" {
+				printOptions.OutputFormat = tt.output
+			}
+			o := &Options{
+				printOptions: printOptions,
+				clusters:     tt.clusters,
+				IOStreams:    streams,
+			}
+			err := o.runWithClient(fake.NewClient(tt.objs...))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got %s", c, out.String())
+				}
+			}
+			for _, c := range tt.notContains {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %q, got %s", c, out.String())
+				}
+			}
+		})
+	}
+}
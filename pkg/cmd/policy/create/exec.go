@@ -0,0 +1,90 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"fmt"
+	"path/filepath"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	o.values, err = o.applierScenariosOptions.ReadValues()
+	if err != nil {
+		return err
+	}
+
+	if len(o.values) == 0 {
+		return fmt.Errorf("values are missing")
+	}
+
+	return nil
+}
+
+func (o *Options) validate() (err error) {
+	ip, ok := o.values["policy"]
+	if !ok || ip == nil {
+		return fmt.Errorf("policy is missing")
+	}
+	p := ip.(map[string]interface{})
+
+	if o.policyName == "" {
+		iname, ok := p["name"]
+		if !ok || iname == nil {
+			return fmt.Errorf("policy name is missing")
+		}
+		o.policyName = iname.(string)
+		if len(o.policyName) == 0 {
+			return fmt.Errorf("policy.name not specified")
+		}
+	}
+	p["name"] = o.policyName
+
+	if o.applierScenariosOptions.ConfigFlags != nil &&
+		o.applierScenariosOptions.ConfigFlags.Namespace != nil &&
+		*o.applierScenariosOptions.ConfigFlags.Namespace != "" {
+		p["namespace"] = *o.applierScenariosOptions.ConfigFlags.Namespace
+	}
+	if ins, ok := p["namespace"]; !ok || ins == nil || ins.(string) == "" {
+		return fmt.Errorf("policy.namespace not specified")
+	}
+
+	if _, ok := o.values["placement"]; !ok {
+		o.values["placement"] = map[string]interface{}{}
+	}
+
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.applierScenariosOptions.ConfigFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	reader := resources.NewResourcesReader()
+
+	applyOptions := &appliercmd.Options{
+		OutFile:     o.applierScenariosOptions.OutFile,
+		ConfigFlags: o.applierScenariosOptions.ConfigFlags,
+
+		Timeout:   o.applierScenariosOptions.ApplierTimeout(),
+		Force:     o.applierScenariosOptions.Force,
+		Silent:    o.applierScenariosOptions.Silent,
+		IOStreams: o.applierScenariosOptions.IOStreams,
+	}
+
+	return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader,
+		filepath.Join(scenarioDirectory, "hub"),
+		o.values)
+}
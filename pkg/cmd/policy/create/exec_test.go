@@ -0,0 +1,153 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testDir = filepath.Join("..", "..", "..", "..", "test", "unit")
+var createPolicyTestDir = filepath.Join(testDir, "resources", "policy", "create")
+
+func TestOptions_complete(t *testing.T) {
+	tests := []struct {
+		name       string
+		valuesPath string
+		wantErr    bool
+	}{
+		{
+			name:       "Failed, bad valuesPath",
+			valuesPath: "bad-values-path.yaml",
+			wantErr:    true,
+		},
+		{
+			name:       "Failed, empty values",
+			valuesPath: filepath.Join(createPolicyTestDir, "values-empty.yaml"),
+			wantErr:    true,
+		},
+		{
+			name:       "Success, with values",
+			valuesPath: filepath.Join(createPolicyTestDir, "values-fake.yaml"),
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+					ValuesPaths: []string{tt.valuesPath},
+				},
+			}
+			if err := o.complete(nil, nil); (err != nil) != tt.wantErr {
+				t.Errorf("Options.complete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		policyName string
+		values     map[string]interface{}
+		wantErr    bool
+	}{
+		{
+			name: "Success, all info in values",
+			values: map[string]interface{}{
+				"policy": map[string]interface{}{
+					"name":      "test",
+					"namespace": "test-ns",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "Success, overwrite name",
+			policyName: "other",
+			values: map[string]interface{}{
+				"policy": map[string]interface{}{
+					"namespace": "test-ns",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Failed, policy missing",
+			values:  map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name: "Failed, name missing",
+			values: map[string]interface{}{
+				"policy": map[string]interface{}{
+					"namespace": "test-ns",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed, namespace missing",
+			values: map[string]interface{}{
+				"policy": map[string]interface{}{
+					"name": "test",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{},
+				policyName:              tt.policyName,
+				values:                  tt.values,
+			}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Options.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	values, err := appliercmd.ConvertValuesFileToValuesMap(filepath.Join(createPolicyTestDir, "values-fake.yaml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := crclientfake.NewFakeClient()
+	o := &Options{
+		applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+			Timeout: time.Second,
+		},
+		values: values,
+	}
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(helpers.PolicyGVK)
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "test-policy", Namespace: "test-ns"}, policy)
+	if err != nil {
+		t.Error(err)
+	}
+	templates, _, err := unstructured.NestedSlice(policy.Object, "spec", "policy-templates")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(templates) != 1 {
+		t.Errorf("expected 1 policy template got %d", len(templates))
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if o.allNamespaces {
+		o.namespace = ""
+		return nil
+	}
+	o.namespace, err = helpers.GetNamespaceFromFlags(o.configFlags)
+	return err
+}
+
+func (o *Options) validate() error {
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	policies := &unstructured.UnstructuredList{}
+	policies.SetGroupVersionKind(helpers.PolicyListGVK)
+	err := client.List(o.ctx, policies, crclient.InNamespace(o.namespace))
+	if err != nil {
+		return err
+	}
+
+	table := &printers.Table{
+		Headers: []string{"NAMESPACE", "NAME", "REMEDIATION", "COMPLIANCE", "CLUSTERS"},
+	}
+	items := make([]map[string]interface{}, 0)
+	for i := range policies.Items {
+		p := &policies.Items[i]
+		//Skip the policies replicated in the cluster namespaces
+		if _, ok := p.GetLabels()[helpers.RootPolicyLabel]; ok {
+			continue
+		}
+		items = append(items, p.Object)
+		remediation, _, _ := unstructured.NestedString(p.Object, "spec", "remediationAction")
+		compliant, _, _ := unstructured.NestedString(p.Object, "status", "compliant")
+		clusters := make([]string, 0)
+		for _, s := range helpers.GetPolicyClusterStatuses(p) {
+			clusters = append(clusters, fmt.Sprintf("%s=%s", s.ClusterName, s.Compliant))
+		}
+		sort.Strings(clusters)
+		table.AddRow(p.GetNamespace(), p.GetName(), remediation, compliant, strings.Join(clusters, ","))
+	}
+	return o.printOptions.Print(o.Out, table, items)
+}
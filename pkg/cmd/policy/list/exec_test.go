@@ -0,0 +1,90 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newPolicy(namespace, name string, labels map[string]string, statuses ...interface{}) *unstructured.Unstructured {
+	p := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"remediationAction": "inform",
+			},
+			"status": map[string]interface{}{
+				"compliant": "NonCompliant",
+				"status":    statuses,
+			},
+		},
+	}
+	p.SetGroupVersionKind(helpers.PolicyGVK)
+	p.SetNamespace(namespace)
+	p.SetName(name)
+	p.SetLabels(labels)
+	return p
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := fake.NewClient(
+		newPolicy("default", "policy1", nil,
+			map[string]interface{}{"clustername": "cluster2", "clusternamespace": "cluster2", "compliant": "NonCompliant"},
+			map[string]interface{}{"clustername": "cluster1", "clusternamespace": "cluster1", "compliant": "Compliant"},
+		),
+		newPolicy("cluster1", "default.policy1", map[string]string{helpers.RootPolicyLabel: "default.policy1"}),
+		newPolicy("other", "policy2", nil),
+	)
+	tests := []struct {
+		name        string
+		namespace   string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "Namespace",
+			namespace:   "default",
+			contains:    []string{"policy1", "cluster1=Compliant,cluster2=NonCompliant"},
+			notContains: []string{"policy2"},
+		},
+		{
+			name:        "All namespaces",
+			namespace:   "",
+			contains:    []string{"policy1", "policy2"},
+			notContains: []string{"default.policy1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				printOptions: printers.NewPrintOptions(),
+				namespace:    tt.namespace,
+				IOStreams:    streams,
+			}
+			if err := o.runWithClient(client); err != nil {
+				t.Fatal(err)
+			}
+			checkOutput(t, out, tt.contains, tt.notContains)
+		})
+	}
+}
+
+func checkOutput(t *testing.T, out *bytes.Buffer, contains, notContains []string) {
+	for _, c := range contains {
+		if !strings.Contains(out.String(), c) {
+			t.Errorf("output must contain %s, got:\n%s", c, out.String())
+		}
+	}
+	for _, c := range notContains {
+		if strings.Contains(out.String(), c) {
+			t.Errorf("output must not contain %s, got:\n%s", c, out.String())
+		}
+	}
+}
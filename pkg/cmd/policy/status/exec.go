@@ -0,0 +1,105 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 0 {
+		o.policyName = args[0]
+	}
+	o.namespace, err = helpers.GetNamespaceFromFlags(o.configFlags)
+	return err
+}
+
+func (o *Options) validate() error {
+	if o.policyName == "" {
+		return fmt.Errorf("policy name is missing")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	if o.clusterName == "" {
+		return o.printClustersCompliance(client)
+	}
+	return o.printClusterViolations(client)
+}
+
+func (o *Options) printClustersCompliance(client crclient.Client) error {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(helpers.PolicyGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.policyName, Namespace: o.namespace}, policy)
+	if err != nil {
+		return err
+	}
+
+	statuses := helpers.GetPolicyClusterStatuses(policy)
+	table := &printers.Table{
+		Headers: []string{"CLUSTER", "COMPLIANCE"},
+	}
+	for _, s := range statuses {
+		table.AddRow(s.ClusterName, s.Compliant)
+	}
+	return o.printOptions.Print(o.Out, table, statuses)
+}
+
+func (o *Options) printClusterViolations(client crclient.Client) error {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(helpers.PolicyGVK)
+	err := client.Get(o.ctx,
+		types.NamespacedName{
+			Name:      helpers.ReplicatedPolicyName(o.namespace, o.policyName),
+			Namespace: o.clusterName,
+		}, policy)
+	if err != nil {
+		return err
+	}
+
+	details, _, err := unstructured.NestedSlice(policy.Object, "status", "details")
+	if err != nil {
+		return err
+	}
+	table := &printers.Table{
+		Headers: []string{"TEMPLATE", "COMPLIANCE", "LAST UPDATE", "MESSAGE"},
+	}
+	for _, id := range details {
+		d, ok := id.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		template, _, _ := unstructured.NestedString(d, "templateMeta", "name")
+		compliant, _, _ := unstructured.NestedString(d, "compliant")
+		var lastTimestamp, message string
+		//The most recent event is the first of the history
+		history, _, _ := unstructured.NestedSlice(d, "history")
+		if len(history) > 0 {
+			if h, ok := history[0].(map[string]interface{}); ok {
+				lastTimestamp, _, _ = unstructured.NestedString(h, "lastTimestamp")
+				message, _, _ = unstructured.NestedString(h, "message")
+			}
+		}
+		table.AddRow(template, compliant, lastTimestamp, message)
+	}
+	return o.printOptions.Print(o.Out, table, details)
+}
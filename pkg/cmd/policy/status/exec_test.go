@@ -0,0 +1,111 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newPolicy(namespace, name string, status map[string]interface{}) *unstructured.Unstructured {
+	p := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": status,
+		},
+	}
+	p.SetGroupVersionKind(helpers.PolicyGVK)
+	p.SetNamespace(namespace)
+	p.SetName(name)
+	return p
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := fake.NewClient(
+		newPolicy("default", "policy1", map[string]interface{}{
+			"status": []interface{}{
+				map[string]interface{}{"clustername": "cluster1", "clusternamespace": "cluster1", "compliant": "NonCompliant"},
+			},
+		}),
+		newPolicy("cluster1", "default.policy1", map[string]interface{}{
+			"details": []interface{}{
+				map[string]interface{}{
+					"compliant":    "NonCompliant",
+					"templateMeta": map[string]interface{}{"name": "policy1-namespace"},
+					"history": []interface{}{
+						map[string]interface{}{
+							"lastTimestamp": "2021-03-30T10:00:00Z",
+							"message":       "NonCompliant; violation - namespaces [prod] not found",
+						},
+						map[string]interface{}{
+							"lastTimestamp": "2021-03-30T09:00:00Z",
+							"message":       "older message",
+						},
+					},
+				},
+			},
+		}),
+	)
+	tests := []struct {
+		name        string
+		policyName  string
+		clusterName string
+		wantErr     bool
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:       "Compliance per cluster",
+			policyName: "policy1",
+			contains:   []string{"cluster1", "NonCompliant"},
+		},
+		{
+			name:        "Violations on a cluster",
+			policyName:  "policy1",
+			clusterName: "cluster1",
+			contains:    []string{"policy1-namespace", "namespaces [prod] not found"},
+			notContains: []string{"older message"},
+		},
+		{
+			name:       "Failed, policy not found",
+			policyName: "policy2",
+			wantErr:    true,
+		},
+		{
+			name:        "Failed, policy not propagated on the cluster",
+			policyName:  "policy1",
+			clusterName: "cluster2",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				printOptions: printers.NewPrintOptions(),
+				policyName:   tt.policyName,
+				namespace:    "default",
+				clusterName:  tt.clusterName,
+				IOStreams:    streams,
+			}
+			err := o.runWithClient(client)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Options.runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %s, got:\n%s", c, out.String())
+				}
+			}
+			for _, c := range tt.notContains {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %s, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
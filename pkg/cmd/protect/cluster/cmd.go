@@ -0,0 +1,55 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Protect a cluster, the detach and the delete refuse to act on it
+%[1]s protect cluster mycluster --reason "production"
+
+# Detach the protected cluster anyway
+%[1]s detach cluster --name mycluster --override-protection
+
+# Remove the protection
+%[1]s unprotect cluster mycluster
+`
+
+// NewCmd provides a cobra command protecting a managed cluster against the detach and the delete
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "cluster NAME",
+		Short: "Protect a managed cluster against the detach and the delete",
+		Long: "Annotate a managed cluster so the detach and the delete commands refuse to act on it " +
+			"unless --override-protection is given.",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.reason, "reason", "", "The reason of the protection, printed when the detach or the delete is refused")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,89 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		reason       string
+		annotations  map[string]string
+		wantErr      bool
+		wantMessages string
+	}{
+		{
+			name:         "Success",
+			args:         []string{"cluster1"},
+			reason:       "production",
+			wantMessages: "cluster1 protected",
+		},
+		{
+			name:         "Success, new reason",
+			args:         []string{"cluster1"},
+			reason:       "production",
+			annotations:  map[string]string{helpers.ProtectionAnnotation: "", "owner": "team-a"},
+			wantMessages: "cluster1 protected",
+		},
+		{
+			name:         "Success, unchanged",
+			args:         []string{"cluster1"},
+			reason:       "production",
+			annotations:  map[string]string{helpers.ProtectionAnnotation: "production"},
+			wantMessages: "cluster1 unchanged",
+		},
+		{
+			name:    "Failed, cluster not found",
+			args:    []string{"cluster2"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(fake.NewManagedCluster("cluster1", fake.WithAnnotations(tt.annotations)))
+			streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.reason = tt.reason
+			if err := o.complete(nil, tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := o.runWithClient(client); (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !strings.Contains(errOut.String(), tt.wantMessages) {
+				t.Errorf("messages must contain %q, got %s", tt.wantMessages, errOut.String())
+			}
+			mc := &unstructured.Unstructured{}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, mc); err != nil {
+				t.Fatal(err)
+			}
+			if reason, protected := helpers.ProtectionReason(mc); !protected || reason != tt.reason {
+				t.Errorf("got protection %t with reason %q, want reason %q", protected, reason, tt.reason)
+			}
+			if tt.annotations["owner"] != "" && mc.GetAnnotations()["owner"] != tt.annotations["owner"] {
+				t.Error("the other annotations must be kept")
+			}
+		})
+	}
+}
+
+func TestOptions_complete(t *testing.T) {
+	o := newOptions(genericclioptions.IOStreams{})
+	if err := o.complete(nil, nil); err == nil {
+		t.Error("error expected without cluster name")
+	}
+}
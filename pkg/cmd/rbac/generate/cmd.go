@@ -0,0 +1,56 @@
+// Copyright Contributors to the Open Cluster Management project
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/rbac"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Print the cluster role of the users attaching clusters
+%[1]s rbac generate --persona cluster-attacher
+
+# Apply the read-only cluster role and bind it to a group
+%[1]s rbac generate --persona viewer --bind-group sre --apply
+`
+
+// NewCmd provides a cobra command generating the cluster role of a persona
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "generate",
+		Short:        "Generate the minimal cluster role needed by the commands of a persona",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.persona, "persona", "", fmt.Sprintf("The persona, one of %s", strings.Join(rbac.Personas(), ", ")))
+	cmd.Flags().StringSliceVar(&o.users, "bind-user", nil, "Users bound to the cluster role")
+	cmd.Flags().StringSliceVar(&o.groups, "bind-group", nil, "Groups bound to the cluster role")
+	cmd.Flags().StringSliceVar(&o.serviceAccounts, "bind-serviceaccount", nil, "Service accounts bound to the cluster role as namespace:name")
+	cmd.Flags().BoolVar(&o.apply, "apply", false, "Create or update the cluster role and its binding on the hub instead of printing them")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,130 @@
+// Copyright Contributors to the Open Cluster Management project
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/rbac"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	for _, u := range o.users {
+		o.subjects = append(o.subjects, rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: u})
+	}
+	for _, g := range o.groups {
+		o.subjects = append(o.subjects, rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: g})
+	}
+	for _, sa := range o.serviceAccounts {
+		ss := strings.Split(sa, ":")
+		if len(ss) != 2 || ss[0] == "" || ss[1] == "" {
+			return fmt.Errorf("invalid service account %s, expected namespace:name", sa)
+		}
+		o.subjects = append(o.subjects, rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: ss[0], Name: ss[1]})
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.persona == "" {
+		return fmt.Errorf("the persona is missing, use --persona with one of %s", strings.Join(rbac.Personas(), ", "))
+	}
+	_, err := rbac.Commands(o.persona)
+	return err
+}
+
+func (o *Options) run() error {
+	if !o.apply {
+		return o.print()
+	}
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+// print writes the cluster role and its binding if subjects are given
+func (o *Options) print() error {
+	clusterRole, err := rbac.ClusterRole(o.persona)
+	if err != nil {
+		return err
+	}
+	commands, err := rbac.Commands(o.persona)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "# Cluster role of the %s persona, it allows the commands:\n", o.persona)
+	for _, c := range commands {
+		fmt.Fprintf(o.Out, "# - %s\n", c)
+	}
+	b, err := yaml.Marshal(clusterRole)
+	if err != nil {
+		return err
+	}
+	if _, err := o.Out.Write(b); err != nil {
+		return err
+	}
+	if len(o.subjects) == 0 {
+		return nil
+	}
+	b, err = yaml.Marshal(rbac.ClusterRoleBinding(o.persona, o.subjects))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(o.Out, "---\n%s", string(b))
+	return err
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	clusterRole, err := rbac.ClusterRole(o.persona)
+	if err != nil {
+		return err
+	}
+	existingRole := &rbacv1.ClusterRole{}
+	err = client.Get(o.ctx, types.NamespacedName{Name: clusterRole.Name}, existingRole)
+	switch {
+	case errors.IsNotFound(err):
+		err = client.Create(o.ctx, clusterRole)
+	case err == nil:
+		existingRole.Rules = clusterRole.Rules
+		err = client.Update(o.ctx, existingRole)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "ClusterRole %s applied\n", clusterRole.Name)
+	printers.PrintIdentifier(o.Out, clusterRole.Name)
+
+	if len(o.subjects) == 0 {
+		return nil
+	}
+	binding := rbac.ClusterRoleBinding(o.persona, o.subjects)
+	existingBinding := &rbacv1.ClusterRoleBinding{}
+	err = client.Get(o.ctx, types.NamespacedName{Name: binding.Name}, existingBinding)
+	switch {
+	case errors.IsNotFound(err):
+		err = client.Create(o.ctx, binding)
+	case err == nil:
+		//The role reference of a binding is immutable, only the subjects are updated
+		existingBinding.Subjects = binding.Subjects
+		err = client.Update(o.ctx, existingBinding)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "ClusterRoleBinding %s applied\n", binding.Name)
+	return nil
+}
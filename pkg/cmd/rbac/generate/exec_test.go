@@ -0,0 +1,98 @@
+// Copyright Contributors to the Open Cluster Management project
+package generate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_print(t *testing.T) {
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	o.persona = "viewer"
+	o.groups = []string{"sre"}
+	if err := o.complete(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.run(); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []string{"# - get clusters", "kind: ClusterRole\n", "name: cm-cli-viewer", "kind: ClusterRoleBinding", "name: sre"} {
+		if !strings.Contains(out.String(), c) {
+			t.Errorf("output must contain %s, got:\n%s", c, out.String())
+		}
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := fake.NewClient(
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "cm-cli-cluster-attacher"}},
+	)
+	streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	o.persona = "cluster-attacher"
+	o.serviceAccounts = []string{"ci:attacher"}
+	o.apply = true
+	if err := o.complete(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatalf("Options.runWithClient() error = %v", err)
+	}
+	role := &rbacv1.ClusterRole{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "cm-cli-cluster-attacher"}, role); err != nil {
+		t.Fatal(err)
+	}
+	if len(role.Rules) == 0 {
+		t.Errorf("the rules of the existing cluster role must be updated")
+	}
+	binding := &rbacv1.ClusterRoleBinding{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "cm-cli-cluster-attacher"}, binding); err != nil {
+		t.Fatal(err)
+	}
+	if len(binding.Subjects) != 1 || binding.Subjects[0].Namespace != "ci" || binding.Subjects[0].Name != "attacher" {
+		t.Errorf("unexpected subjects %v", binding.Subjects)
+	}
+	if !strings.Contains(errOut.String(), "ClusterRoleBinding cm-cli-cluster-attacher applied") {
+		t.Errorf("unexpected output %s", errOut.String())
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		persona         string
+		serviceAccounts []string
+		wantErr         bool
+	}{
+		{name: "Success", persona: "cluster-admin", serviceAccounts: []string{"ns:name"}},
+		{name: "Failed, missing persona", wantErr: true},
+		{name: "Failed, unknown persona", persona: "admin", wantErr: true},
+		{name: "Failed, invalid service account", persona: "viewer", serviceAccounts: []string{"name"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOptions(genericclioptions.IOStreams{})
+			o.persona = tt.persona
+			o.serviceAccounts = tt.serviceAccounts
+			err := o.complete(nil, nil)
+			if err == nil {
+				err = o.validate()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
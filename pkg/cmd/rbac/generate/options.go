@@ -0,0 +1,31 @@
+// Copyright Contributors to the Open Cluster Management project
+package generate
+
+import (
+	"context"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags     *genericclioptions.ConfigFlags
+	persona         string
+	users           []string
+	groups          []string
+	serviceAccounts []string
+	apply           bool
+	subjects        []rbacv1.Subject
+	ctx             context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ctx:         context.Background(),
+
+		IOStreams: streams,
+	}
+}
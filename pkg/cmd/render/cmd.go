@@ -0,0 +1,52 @@
+// Copyright Contributors to the Open Cluster Management project
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Print the resources created on the hub by an attach
+%[1]s render --scenario attach/cluster --values values.yaml
+
+# Print the resources of a hub installation with a different registry
+%[1]s render --scenario init/hub --set clusterManager.registry=myregistry.example.com
+`
+
+// NewCmd provides a cobra command printing the rendered templates of a scenario
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "render",
+		Short:        "Print the resources a command would create with the given values",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.scenarioName, "scenario", "", fmt.Sprintf("The scenario to render, one of %s", strings.Join(scenarioNames(), ", ")))
+	cmd.Flags().BoolVar(&o.showValues, "show-values", false, "Print the merged values instead of the rendered resources")
+	o.applierScenariosOptions.AddValuesFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,75 @@
+// Copyright Contributors to the Open Cluster Management project
+package render
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/applier/pkg/templateprocessor"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	s, ok := scenarios[o.scenarioName]
+	if !ok {
+		return nil
+	}
+	if s.withDefaults {
+		o.values, err = o.applierScenariosOptions.ReadValuesWithDefaults(filepath.Join(s.directory, "values-template.yaml"))
+	} else {
+		o.values, err = o.applierScenariosOptions.ReadValues()
+	}
+	return err
+}
+
+func (o *Options) validate() error {
+	if o.scenarioName == "" {
+		return fmt.Errorf("the scenario is missing, use --scenario with one of %s", strings.Join(scenarioNames(), ", "))
+	}
+	if _, ok := scenarios[o.scenarioName]; !ok {
+		return fmt.Errorf("unknown scenario %s, supported scenarios are %s", o.scenarioName, strings.Join(scenarioNames(), ", "))
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	s := scenarios[o.scenarioName]
+	reader := resources.NewResourcesReader()
+	if s.complete != nil {
+		if err := s.complete(reader, o.values); err != nil {
+			return err
+		}
+	}
+
+	if o.showValues {
+		b, err := yaml.Marshal(o.values)
+		if err != nil {
+			return err
+		}
+		_, err = o.applierScenariosOptions.Out.Write(b)
+		return err
+	}
+
+	paths, err := s.paths(o.values)
+	if err != nil {
+		return err
+	}
+	tp, err := templateprocessor.NewTemplateProcessor(reader, &templateprocessor.Options{})
+	if err != nil {
+		return err
+	}
+	rendered := make([][]byte, 0)
+	for _, p := range paths {
+		out, err := tp.TemplateResourcesInPathYaml(filepath.Join(s.directory, p), []string{}, true, o.values)
+		if err != nil {
+			return fmt.Errorf("unable to render %s: %s", p, err.Error())
+		}
+		rendered = append(rendered, out...)
+	}
+	_, err = fmt.Fprint(o.applierScenariosOptions.Out, templateprocessor.ConvertArrayOfBytesToString(rendered))
+	return err
+}
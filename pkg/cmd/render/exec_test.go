@@ -0,0 +1,97 @@
+// Copyright Contributors to the Open Cluster Management project
+package render
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var testDir = filepath.Join("..", "..", "..", "test", "unit")
+
+func TestOptions_run(t *testing.T) {
+	tests := []struct {
+		name         string
+		scenarioName string
+		valuesPath   string
+		setValues    []string
+		showValues   bool
+		wantErr      bool
+		contains     []string
+	}{
+		{
+			name:         "Success, attach with values",
+			scenarioName: "attach/cluster",
+			valuesPath:   filepath.Join(testDir, "resources", "attach", "cluster", "values-with-data.yaml"),
+			contains:     []string{"kind: ManagedCluster", "kind: KlusterletAddonConfig"},
+		},
+		{
+			name:         "Success, init with the default values",
+			scenarioName: "init/hub",
+			setValues:    []string{"clusterManager.registry=myregistry.example.com"},
+			contains:     []string{"kind: ClusterManager", "myregistry.example.com/registration-operator"},
+		},
+		{
+			name:         "Success, create with the install config",
+			scenarioName: "create/cluster",
+			valuesPath:   filepath.Join(testDir, "resources", "create", "cluster", "values-fake-aws.yaml"),
+			contains:     []string{"kind: ClusterDeployment", "install-config.yaml"},
+		},
+		{
+			name:         "Success, show values",
+			scenarioName: "join/hub",
+			setValues:    []string{"clusterName=mycluster"},
+			showValues:   true,
+			contains:     []string{"clusterName: mycluster", "registry: quay.io/open-cluster-management"},
+		},
+		{
+			name:         "Failed, create without cloud",
+			scenarioName: "create/cluster",
+			valuesPath:   filepath.Join(testDir, "resources", "create", "cluster", "values-empty.yaml"),
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+					ValuesPaths: []string{tt.valuesPath},
+					SetValues:   tt.setValues,
+					IOStreams:   streams,
+				},
+				scenarioName: tt.scenarioName,
+				showValues:   tt.showValues,
+			}
+			if err := o.complete(nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := o.validate(); err != nil {
+				t.Fatal(err)
+			}
+			err := o.run()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Options.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %s, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	for _, name := range []string{"", "attach/unknown"} {
+		o := newOptions(genericclioptions.IOStreams{})
+		o.scenarioName = name
+		if err := o.validate(); err == nil {
+			t.Errorf("Options.validate() must fail for the scenario %q", name)
+		}
+	}
+}
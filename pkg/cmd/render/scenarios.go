@@ -0,0 +1,99 @@
+// Copyright Contributors to the Open Cluster Management project
+package render
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-cluster-management/applier/pkg/templateprocessor"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	attachcluster "github.com/open-cluster-management/cm-cli/pkg/cmd/attach/cluster"
+	createcluster "github.com/open-cluster-management/cm-cli/pkg/cmd/create/cluster"
+)
+
+// pullSecretPlaceholder replaces the pull secret of the hub which is not read by the render
+const pullSecretPlaceholder = "<pull-secret of the hub>"
+
+// scenario describes how a command renders its bundled templates
+type scenario struct {
+	//directory holds the values-template.yaml of the scenario
+	directory string
+	//withDefaults is true when the command takes the missing values from the values template
+	withDefaults bool
+	//paths returns the templates rendered by the command, relative to the directory
+	paths func(values map[string]interface{}) ([]string, error)
+	//complete sets the values computed by the command before rendering
+	complete func(reader templateprocessor.TemplateReader, values map[string]interface{}) error
+}
+
+func staticPaths(paths ...string) func(map[string]interface{}) ([]string, error) {
+	return func(map[string]interface{}) ([]string, error) {
+		return paths, nil
+	}
+}
+
+// scenarios are the bundled scenarios by command
+var scenarios = map[string]scenario{
+	"application/create": {
+		directory: "scenarios/application",
+		paths:     staticPaths("hub"),
+	},
+	"attach/cluster": {
+		directory:    "scenarios/attach",
+		withDefaults: true,
+		paths:        staticPaths("hub"),
+		complete: func(reader templateprocessor.TemplateReader, values map[string]interface{}) error {
+			return attachcluster.CompleteValues(values)
+		},
+	},
+	"clusterpool/create": {
+		directory: "scenarios/clusterpool",
+		paths:     staticPaths(filepath.Join("hub", "common")),
+	},
+	"create/cluster": {
+		directory: "scenarios/create",
+		paths:     staticPaths(filepath.Join("hub", "common")),
+		complete: func(reader templateprocessor.TemplateReader, values map[string]interface{}) (err error) {
+			cloud := applierscenarios.GetString(values, "managedCluster.cloud")
+			if cloud == "" {
+				return fmt.Errorf("managedCluster.cloud is missing")
+			}
+			//The pull secret is read from the hub when the cluster is created
+			if _, ok := values["pullSecret"]; !ok {
+				values["pullSecret"] = map[string]interface{}{
+					"data": map[string]interface{}{".dockerconfigjson": pullSecretPlaceholder},
+				}
+			}
+			values["installConfig"], err = createcluster.InstallConfigValues(reader, cloud, values)
+			return err
+		},
+	},
+	"init/hub": {
+		directory:    "scenarios/init",
+		withDefaults: true,
+		paths: func(values map[string]interface{}) ([]string, error) {
+			return []string{filepath.Join("hub", strings.ToLower(applierscenarios.GetString(values, "mode")))}, nil
+		},
+	},
+	"join/hub": {
+		directory:    "scenarios/join",
+		withDefaults: true,
+		paths:        staticPaths("klusterlet"),
+	},
+	"policy/create": {
+		directory: "scenarios/policy",
+		paths:     staticPaths("hub"),
+	},
+}
+
+// scenarioNames returns the sorted names of the scenarios
+func scenarioNames() []string {
+	names := make([]string, 0, len(scenarios))
+	for name := range scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,57 @@
+// Copyright Contributors to the Open Cluster Management project
+package capacity
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Show the cpu and memory of each managed cluster and of the fleet
+%[1]s report capacity
+
+# Export the capacity of the production clusters for a spreadsheet
+%[1]s report capacity -l env=prod -o csv > capacity.csv
+
+# Export the capacity as json
+%[1]s report capacity -o json
+`
+
+// NewCmd provides a cobra command reporting the cpu and memory of the managed clusters and of the fleet
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "capacity",
+		Short: "Report the cpu and memory of the managed clusters and of the fleet",
+		Long: "Sum the cpu and memory reported in the status of the managed clusters. The requested resources are the " +
+			"capacity which is not allocatable anymore and the usage is the requested part of the capacity, " +
+			"the last row is the total of the fleet",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.selector, "selector", "l", "", "Label selector of the managed clusters to report, e.g. env=dev")
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,194 @@
+// Copyright Contributors to the Open Cluster Management project
+package capacity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+// fleetName is the name of the row of the fleet
+const fleetName = "TOTAL"
+
+// usage is the capacity of a resource, in cores for the cpu and in bytes for the memory
+type usage struct {
+	Capacity    float64 `json:"capacity"`
+	Allocatable float64 `json:"allocatable"`
+	Requested   float64 `json:"requested"`
+	//Percent is the requested part of the capacity
+	Percent float64 `json:"usagePercent"`
+}
+
+// clusterCapacity is the cpu and memory of a managed cluster
+type clusterCapacity struct {
+	Name string `json:"name"`
+	//Reported is false until the klusterlet reports the resources of the cluster
+	Reported bool  `json:"reported"`
+	CPU      usage `json:"cpu"`
+	Memory   usage `json:"memory"`
+}
+
+// report is the capacity of the clusters and the total of the fleet
+type report struct {
+	Clusters []clusterCapacity `json:"clusters"`
+	Fleet    clusterCapacity   `json:"fleet"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) != 0 {
+		return fmt.Errorf("the clusters are selected with --selector, got %s", strings.Join(args, " "))
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.selector != "" {
+		if _, err := labels.Parse(o.selector); err != nil {
+			return fmt.Errorf("invalid selector %s: %s", o.selector, err.Error())
+		}
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	selector, err := labels.Parse(o.selector)
+	if err != nil {
+		return err
+	}
+	mcs := &unstructured.UnstructuredList{}
+	mcs.SetGroupVersionKind(helpers.ManagedClusterGVK.GroupVersion().WithKind(helpers.ManagedClusterGVK.Kind + "List"))
+	if err := client.List(o.ctx, mcs, crclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return err
+	}
+	sort.Slice(mcs.Items, func(i, j int) bool {
+		return mcs.Items[i].GetName() < mcs.Items[j].GetName()
+	})
+
+	r := report{
+		Clusters: make([]clusterCapacity, 0, len(mcs.Items)),
+		Fleet:    clusterCapacity{Name: fleetName},
+	}
+	for i := range mcs.Items {
+		c, err := getClusterCapacity(&mcs.Items[i])
+		if err != nil {
+			return err
+		}
+		r.Clusters = append(r.Clusters, c)
+		if !c.Reported {
+			continue
+		}
+		r.Fleet.Reported = true
+		r.Fleet.CPU.add(c.CPU)
+		r.Fleet.Memory.add(c.Memory)
+	}
+	r.Fleet.CPU.setPercent()
+	r.Fleet.Memory.setPercent()
+
+	table := &printers.Table{
+		Headers: []string{"CLUSTER", "CPU ALLOCATABLE", "CPU REQUESTED", "CPU USAGE",
+			"MEMORY ALLOCATABLE", "MEMORY REQUESTED", "MEMORY USAGE"},
+	}
+	for _, c := range append(r.Clusters, r.Fleet) {
+		if !c.Reported {
+			table.AddRow(c.Name, "-", "-", "-", "-", "-", "-")
+			continue
+		}
+		table.AddRow(c.Name,
+			formatCores(c.CPU.Allocatable), formatCores(c.CPU.Requested), formatPercent(c.CPU.Percent),
+			formatBytes(c.Memory.Allocatable), formatBytes(c.Memory.Requested), formatPercent(c.Memory.Percent))
+	}
+	return o.printOptions.Print(o.Out, table, r)
+}
+
+// getClusterCapacity reads the cpu and memory from the capacity and the allocatable resources of the cluster status
+func getClusterCapacity(mc *unstructured.Unstructured) (clusterCapacity, error) {
+	c := clusterCapacity{Name: mc.GetName()}
+	capacity, _, _ := unstructured.NestedStringMap(mc.Object, "status", "capacity")
+	allocatable, _, _ := unstructured.NestedStringMap(mc.Object, "status", "allocatable")
+	if capacity["cpu"] == "" && capacity["memory"] == "" {
+		return c, nil
+	}
+	c.Reported = true
+	var err error
+	if c.CPU, err = getUsage(capacity, allocatable, "cpu", func(q resource.Quantity) float64 {
+		return float64(q.MilliValue()) / 1000
+	}); err != nil {
+		return c, fmt.Errorf("invalid cpu of cluster %s: %v", c.Name, err)
+	}
+	if c.Memory, err = getUsage(capacity, allocatable, "memory", func(q resource.Quantity) float64 {
+		return float64(q.Value())
+	}); err != nil {
+		return c, fmt.Errorf("invalid memory of cluster %s: %v", c.Name, err)
+	}
+	return c, nil
+}
+
+// getUsage returns the usage of a resource, the allocatable is the capacity when the cluster does not report it
+func getUsage(capacity, allocatable map[string]string, name string, value func(resource.Quantity) float64) (usage, error) {
+	u := usage{}
+	if capacity[name] == "" {
+		return u, nil
+	}
+	q, err := resource.ParseQuantity(capacity[name])
+	if err != nil {
+		return u, err
+	}
+	u.Capacity = value(q)
+	u.Allocatable = u.Capacity
+	if allocatable[name] != "" {
+		if q, err = resource.ParseQuantity(allocatable[name]); err != nil {
+			return u, err
+		}
+		u.Allocatable = value(q)
+	}
+	u.Requested = u.Capacity - u.Allocatable
+	if u.Requested < 0 {
+		u.Requested = 0
+	}
+	u.setPercent()
+	return u, nil
+}
+
+func (u *usage) add(other usage) {
+	u.Capacity += other.Capacity
+	u.Allocatable += other.Allocatable
+	u.Requested += other.Requested
+}
+
+func (u *usage) setPercent() {
+	if u.Capacity != 0 {
+		u.Percent = u.Requested * 100 / u.Capacity
+	}
+}
+
+func formatCores(cores float64) string {
+	return fmt.Sprintf("%.1f", cores)
+}
+
+func formatBytes(bytes float64) string {
+	return fmt.Sprintf("%.1fGi", bytes/(1<<30))
+}
+
+func formatPercent(percent float64) string {
+	return fmt.Sprintf("%.0f%%", percent)
+}
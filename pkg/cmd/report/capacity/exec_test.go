@@ -0,0 +1,131 @@
+// Copyright Contributors to the Open Cluster Management project
+package capacity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// newCluster returns a cluster of the env, the capacity is not reported when it is nil
+func newCluster(name, env string, capacity, allocatable map[string]interface{}) *unstructured.Unstructured {
+	status := map[string]interface{}{}
+	if capacity != nil {
+		status = map[string]interface{}{"capacity": capacity, "allocatable": allocatable}
+	}
+	return fake.NewManagedCluster(name, fake.WithLabels(map[string]string{"env": env}), fake.WithField(status, "status"))
+}
+
+func newClusters() []runtime.Object {
+	return []runtime.Object{
+		newCluster("c1", "prod",
+			map[string]interface{}{"cpu": "8", "memory": "32Gi"},
+			map[string]interface{}{"cpu": "6", "memory": "24Gi"}),
+		newCluster("c2", "prod",
+			map[string]interface{}{"cpu": "4", "memory": "16Gi"},
+			map[string]interface{}{"cpu": "3500m", "memory": "16Gi"}),
+		newCluster("c3", "dev", nil, nil),
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name         string
+		selector     string
+		outputFormat string
+		want         []string
+	}{
+		{
+			name: "Success, table",
+			want: []string{
+				"c1   6.0   2.0   25%   24.0Gi   8.0Gi   25%",
+				"c2   3.5   0.5   12%   16.0Gi   0.0Gi   0%",
+				"c3   -",
+				"TOTAL   9.5   2.5   21%   40.0Gi   8.0Gi   17%",
+			},
+		},
+		{
+			name:         "Success, csv of the selected clusters",
+			selector:     "env=dev",
+			outputFormat: printers.OutputCSV,
+			want:         []string{"CLUSTER,CPU ALLOCATABLE,", "c3,-,-,-,-,-,-\nTOTAL,-,"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.selector = tt.selector
+			if tt.outputFormat != "" {
+				o.printOptions.OutputFormat = tt.outputFormat
+			}
+			if err := o.runWithClient(fake.NewClient(newClusters()...)); err != nil {
+				t.Fatal(err)
+			}
+			//The columns are aligned with 3 spaces at least
+			got := strings.Join(strings.Fields(out.String()), " ")
+			if tt.outputFormat != "" {
+				got = out.String()
+			}
+			for _, w := range tt.want {
+				if tt.outputFormat == "" {
+					w = strings.Join(strings.Fields(w), " ")
+				}
+				if !strings.Contains(got, w) {
+					t.Errorf("output must contain %q, got:\n%s", w, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient_json(t *testing.T) {
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	o.printOptions.OutputFormat = printers.OutputJSON
+	if err := o.runWithClient(fake.NewClient(newClusters()...)); err != nil {
+		t.Fatal(err)
+	}
+	r := report{}
+	if err := json.Unmarshal(out.Bytes(), &r); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Clusters) != 3 || r.Clusters[2].Reported {
+		t.Errorf("unexpected clusters %+v", r.Clusters)
+	}
+	if r.Fleet.CPU.Capacity != 12 || r.Fleet.CPU.Requested != 2.5 {
+		t.Errorf("unexpected fleet cpu %+v", r.Fleet.CPU)
+	}
+	if r.Fleet.Memory.Allocatable != 40*(1<<30) {
+		t.Errorf("unexpected fleet memory %+v", r.Fleet.Memory)
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		selector     string
+		outputFormat string
+		wantErr      bool
+	}{
+		{name: "Success", selector: "env=prod", outputFormat: printers.OutputCSV},
+		{name: "Failed, invalid selector", selector: "env==prod,", wantErr: true},
+		{name: "Failed, invalid output", outputFormat: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOptions(genericclioptions.IOStreams{})
+			o.selector = tt.selector
+			o.printOptions.OutputFormat = tt.outputFormat
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright Contributors to the Open Cluster Management project
+package retry
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Retry the failed import of a cluster with the credentials of its auto-import secret
+%[1]s retry import mycluster
+
+# Retry the import with new credentials, the auto-import secret is deleted once the cluster is imported
+%[1]s retry import mycluster --cluster-kubeconfig mycluster.kubeconfig
+
+# Retry the import and wait for the addons of the cluster to be available
+%[1]s retry import mycluster --wait-for addons-ready
+
+# Retry the import without waiting for the cluster to join
+%[1]s retry import mycluster --cluster-server https://api.mycluster.example.com:6443 --cluster-token mytoken --wait=false
+`
+
+// NewCmd provides a cobra command retrying the auto-import of a cluster
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "import <cluster>",
+		Short: "Retry the auto-import of a cluster stuck in a failed import",
+		Long: "Re-trigger the import controller for a cluster which failed to be imported, without detaching and attaching it again. " +
+			"The auto-import secret of the cluster is refreshed with its retry count reset, it is created again with the " +
+			"credentials flags when the import controller already deleted it, then the command waits for the cluster to join, or for the --wait-for state",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.clusterKubeConfig, "cluster-kubeconfig", "", "The kubeconfig file of the cluster, to create the auto-import secret again")
+	cmd.Flags().StringVar(&o.clusterServer, "cluster-server", "", "The API server URL of the cluster, to create the auto-import secret again")
+	cmd.Flags().StringVar(&o.clusterToken, "cluster-token", "", "The token to access the cluster, to create the auto-import secret again")
+	cmd.Flags().IntVar(&o.autoImportRetry, "auto-import-retry", 5, "The number of times the import controller retries the import")
+	cmd.Flags().BoolVar(&o.wait, "wait", true, "Wait for the cluster to join the hub")
+	cmd.Flags().StringVar(&o.waitFor, "wait-for", helpers.WaitForJoined, fmt.Sprintf("The state of the cluster to wait for, one of %s", strings.Join(helpers.WaitForConditions(), ", ")))
+	helpers.DurationVar(cmd.Flags(), &o.timeout, "timeout", 5*time.Minute, "Timeout to wait for the cluster, e.g. 5m")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
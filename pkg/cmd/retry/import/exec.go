@@ -0,0 +1,183 @@
+// Copyright Contributors to the Open Cluster Management project
+package retry
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	autoImportSecretName = "auto-import-secret"
+	// retryAnnotation is set on the auto-import secret and the ManagedCluster with the time of the retry,
+	// the changes requeue them in the import controller
+	retryAnnotation = "cm-cli.open-cluster-management.io/import-retry"
+	joinedCondition = "ManagedClusterJoined"
+	// importCondition is set by the import controllers reporting the result of the auto-import
+	importCondition = "ManagedClusterImportSucceeded"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 1 {
+		return fmt.Errorf("only one cluster name can be given, got %s", strings.Join(args, " "))
+	}
+	if len(args) == 1 {
+		o.clusterName = args[0]
+	}
+	if o.clusterKubeConfig != "" {
+		o.kubeConfig, err = ioutil.ReadFile(filepath.Clean(o.clusterKubeConfig))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.clusterName == "" {
+		return fmt.Errorf("the name of the cluster to import is missing")
+	}
+	if (o.clusterServer == "") != (o.clusterToken == "") {
+		return fmt.Errorf("--cluster-server and --cluster-token must be set together")
+	}
+	if o.clusterKubeConfig != "" && o.clusterServer != "" {
+		return fmt.Errorf("--cluster-kubeconfig can not be used with --cluster-server and --cluster-token")
+	}
+	if o.autoImportRetry < 1 {
+		return fmt.Errorf("--auto-import-retry must be at least 1, got %d", o.autoImportRetry)
+	}
+	return helpers.ValidateWaitFor(o.waitFor)
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName}, mc)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("cluster %s does not exist, attach it with cm attach cluster", o.clusterName)
+	}
+	if err != nil {
+		return err
+	}
+	w := printers.Messages(o.ErrOut)
+	if joined(mc) {
+		fmt.Fprintf(w, "Cluster %s already joined the hub, nothing to retry\n", o.clusterName)
+		return nil
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if err := o.refreshAutoImportSecret(client, now); err != nil {
+		return err
+	}
+	annotations := mc.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[retryAnnotation] = now
+	mc.SetAnnotations(annotations)
+	if err := client.Update(o.ctx, mc); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Import of cluster %s retried, up to %d attempts\n", o.clusterName, o.autoImportRetry)
+	if !o.wait {
+		return nil
+	}
+
+	fmt.Fprintf(w, "Waiting for cluster %s to be %s\n", o.clusterName, o.waitFor)
+	err = helpers.WaitForCluster(o.ctx, client, o.clusterName, o.waitFor, o.pollInterval, o.timeout)
+	if err == helpers.ErrInterrupted {
+		return err
+	}
+	if err != nil {
+		//The import controller reports why the import failed
+		reason := ""
+		if err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName}, mc); err == nil {
+			conditions, _, _ := unstructured.NestedSlice(mc.Object, "status", "conditions")
+			if helpers.GetConditionStatus(conditions, importCondition) != "True" {
+				if msg := helpers.GetConditionMessage(conditions, importCondition); msg != "" {
+					reason = ", the import failed: " + msg
+				}
+			}
+		}
+		return fmt.Errorf("%s%s, run cm troubleshoot cluster %s to find the cause", err.Error(), reason, o.clusterName)
+	}
+	fmt.Fprintf(w, "Cluster %s imported\n", o.clusterName)
+	return nil
+}
+
+// refreshAutoImportSecret resets the retry count of the auto-import secret and updates its credentials,
+// the secret is created again when the import controller already deleted it
+func (o *Options) refreshAutoImportSecret(client crclient.Client, now string) error {
+	secret := &corev1.Secret{}
+	err := client.Get(o.ctx, types.NamespacedName{Namespace: o.clusterName, Name: autoImportSecretName}, secret)
+	found := true
+	switch {
+	case errors.IsNotFound(err):
+		if len(o.kubeConfig) == 0 && o.clusterToken == "" {
+			return fmt.Errorf("the auto-import secret of cluster %s does not exist anymore, set the credentials of the cluster "+
+				"with --cluster-kubeconfig or --cluster-server and --cluster-token", o.clusterName)
+		}
+		found = false
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: o.clusterName, Name: autoImportSecretName},
+			Type:       corev1.SecretTypeOpaque,
+		}
+	case err != nil:
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data["autoImportRetry"] = []byte(strconv.Itoa(o.autoImportRetry))
+	if len(o.kubeConfig) != 0 {
+		secret.Data["kubeconfig"] = o.kubeConfig
+		delete(secret.Data, "server")
+		delete(secret.Data, "token")
+	}
+	if o.clusterToken != "" {
+		secret.Data["server"] = []byte(o.clusterServer)
+		secret.Data["token"] = []byte(o.clusterToken)
+		delete(secret.Data, "kubeconfig")
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[retryAnnotation] = now
+
+	if found {
+		return client.Update(o.ctx, secret)
+	}
+	return client.Create(o.ctx, secret)
+}
+
+// joined returns true if the klusterlet of the cluster joined the hub
+func joined(mc *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(mc.Object, "status", "conditions")
+	return helpers.GetConditionStatus(conditions, joinedCondition) == "True"
+}
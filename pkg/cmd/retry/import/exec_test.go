@@ -0,0 +1,162 @@
+// Copyright Contributors to the Open Cluster Management project
+package retry
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// newImportFailedCluster returns a cluster whose import failed with the message
+func newImportFailedCluster(name, joined, importMessage string) *unstructured.Unstructured {
+	return fake.NewManagedCluster(name, fake.WithConditions(
+		fake.NewCondition(joinedCondition, joined),
+		fake.NewCondition(importCondition, "False", fake.WithMessage(importMessage))))
+}
+
+func newAutoImportSecret(clusterName string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: clusterName, Name: autoImportSecretName},
+		Data: map[string][]byte{
+			"autoImportRetry": []byte("0"),
+			"server":          []byte("https://old:6443"),
+			"token":           []byte("old-token"),
+		},
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		objs       []runtime.Object
+		kubeConfig string
+		server     string
+		token      string
+		wait       bool
+		wantSecret map[string]string
+		wantErr    string
+	}{
+		{
+			name:       "Success, existing secret",
+			objs:       []runtime.Object{newImportFailedCluster("c1", "False", ""), newAutoImportSecret("c1")},
+			wantSecret: map[string]string{"autoImportRetry": "3", "server": "https://old:6443", "token": "old-token"},
+		},
+		{
+			name:       "Success, new credentials",
+			objs:       []runtime.Object{newImportFailedCluster("c1", "False", ""), newAutoImportSecret("c1")},
+			kubeConfig: "kubeconfig-content",
+			wantSecret: map[string]string{"autoImportRetry": "3", "kubeconfig": "kubeconfig-content"},
+		},
+		{
+			name:       "Success, deleted secret created again",
+			objs:       []runtime.Object{newImportFailedCluster("c1", "False", "")},
+			server:     "https://new:6443",
+			token:      "new-token",
+			wantSecret: map[string]string{"autoImportRetry": "3", "server": "https://new:6443", "token": "new-token"},
+		},
+		{
+			name: "Success, already joined",
+			objs: []runtime.Object{newImportFailedCluster("c1", "True", ""), newAutoImportSecret("c1")},
+			wait: true,
+		},
+		{
+			name:    "Failed, deleted secret without credentials",
+			objs:    []runtime.Object{newImportFailedCluster("c1", "False", "")},
+			wantErr: "--cluster-kubeconfig",
+		},
+		{
+			name:    "Failed, unknown cluster",
+			wantErr: "does not exist",
+		},
+		{
+			name:    "Failed, not joined after the timeout",
+			objs:    []runtime.Object{newImportFailedCluster("c1", "False", "the server is unreachable"), newAutoImportSecret("c1")},
+			wait:    true,
+			wantErr: "the server is unreachable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, _, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.clusterName = "c1"
+			o.kubeConfig = []byte(tt.kubeConfig)
+			o.clusterServer = tt.server
+			o.clusterToken = tt.token
+			o.autoImportRetry = 3
+			o.wait = tt.wait
+			o.timeout = 50 * time.Millisecond
+			o.pollInterval = 10 * time.Millisecond
+			client := fake.NewClient(tt.objs...)
+			err := o.runWithClient(client)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error containing %q expected, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantSecret == nil {
+				return
+			}
+			secret := &corev1.Secret{}
+			if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "c1", Name: autoImportSecretName}, secret); err != nil {
+				t.Fatal(err)
+			}
+			if len(secret.Data) != len(tt.wantSecret) {
+				t.Errorf("unexpected auto-import secret data %v", secret.Data)
+			}
+			for k, v := range tt.wantSecret {
+				if string(secret.Data[k]) != v {
+					t.Errorf("%s = %s, want %s", k, secret.Data[k], v)
+				}
+			}
+			if secret.Annotations[retryAnnotation] == "" {
+				t.Error("the auto-import secret must be annotated with the retry")
+			}
+			mc := &unstructured.Unstructured{}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "c1"}, mc); err != nil {
+				t.Fatal(err)
+			}
+			if mc.GetAnnotations()[retryAnnotation] == "" {
+				t.Error("the ManagedCluster must be annotated with the retry")
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       Options
+		wantErr bool
+	}{
+		{name: "Success", o: Options{clusterName: "c1", autoImportRetry: 5}},
+		{name: "Success, server and token", o: Options{clusterName: "c1", autoImportRetry: 5, clusterServer: "s", clusterToken: "t"}},
+		{name: "Failed, no cluster", o: Options{autoImportRetry: 5}, wantErr: true},
+		{name: "Failed, server without token", o: Options{clusterName: "c1", autoImportRetry: 5, clusterServer: "s"}, wantErr: true},
+		{name: "Failed, kubeconfig and token", o: Options{clusterName: "c1", autoImportRetry: 5, clusterKubeConfig: "k", clusterServer: "s", clusterToken: "t"}, wantErr: true},
+		{name: "Failed, no retry", o: Options{clusterName: "c1"}, wantErr: true},
+		{name: "Failed, invalid wait-for", o: Options{clusterName: "c1", autoImportRetry: 5, waitFor: "ready"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright Contributors to the Open Cluster Management project
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags       *genericclioptions.ConfigFlags
+	clusterName       string
+	clusterKubeConfig string
+	clusterServer     string
+	clusterToken      string
+	//kubeConfig is the content of the --cluster-kubeconfig file
+	kubeConfig      []byte
+	autoImportRetry int
+	wait            bool
+	//waitFor is the state of the cluster to wait for
+	waitFor      string
+	timeout      time.Duration
+	pollInterval time.Duration
+	//ctx is canceled on Ctrl+C to abort the wait
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		waitFor:      helpers.WaitForJoined,
+		pollInterval: 5 * time.Second,
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package root
+
+import (
+	"github.com/open-cluster-management/cm-cli/pkg/audit"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/verbs"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/tracing"
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// NewCmdRoot provides the cm command with all its verbs, the commands and their help write
+// on the streams so the cli can be embedded or tested by capturing their output
+func NewCmdRoot(streams genericclioptions.IOStreams) *cobra.Command {
+	//The errors are returned to the caller, main prints them typed and explained with --explain-error
+	cmd := &cobra.Command{Use: "cm", SilenceErrors: true}
+	cmd.SetIn(streams.In)
+	cmd.SetOut(streams.Out)
+	cmd.SetErr(streams.ErrOut)
+	audit.WarnOut = streams.ErrOut
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return cmderrors.NewValidationError(err)
+	})
+	clients.AddFlags(cmd.PersistentFlags())
+	printers.AddColorFlags(cmd.PersistentFlags())
+	printers.AddQuietFlags(cmd.PersistentFlags())
+	cmderrors.AddFlags(cmd.PersistentFlags())
+	tracing.AddFlags(cmd.PersistentFlags())
+	cmd.AddCommand(
+		verbs.NewVerb("init", streams),
+		verbs.NewVerb("join", streams),
+		verbs.NewVerb("token", streams),
+		verbs.NewVerb("create", streams),
+		verbs.NewVerb("get", streams),
+		verbs.NewVerb("update", streams),
+		verbs.NewVerb("delete", streams),
+		// verbs.NewVerb("list", streams),
+		verbs.NewVerb("applier", streams),
+		verbs.NewVerb("attach", streams),
+		verbs.NewVerb("detach", streams),
+		verbs.NewVerb("move", streams),
+		verbs.NewVerb("label", streams),
+		verbs.NewVerb("taint", streams),
+		verbs.NewVerb("untaint", streams),
+		verbs.NewVerb("protect", streams),
+		verbs.NewVerb("unprotect", streams),
+		verbs.NewVerb("troubleshoot", streams),
+		verbs.NewVerb("check", streams),
+		verbs.NewVerb("diff", streams),
+		verbs.NewVerb("collect", streams),
+		verbs.NewVerb("render", streams),
+		verbs.NewVerb("scenarios", streams),
+		verbs.NewVerb("apply", streams),
+		verbs.NewVerb("rbac", streams),
+		verbs.NewVerb("export", streams),
+		verbs.NewVerb("import", streams),
+		verbs.NewVerb("policy", streams),
+		verbs.NewVerb("application", streams),
+		verbs.NewVerb("clusterpool", streams),
+		verbs.NewVerb("addon", streams),
+		verbs.NewVerb("observability", streams),
+		verbs.NewVerb("submariner", streams),
+		verbs.NewVerb("status", streams),
+		verbs.NewVerb("telemetry", streams),
+		verbs.NewVerb("audit", streams),
+		verbs.NewVerb("serve", streams),
+		verbs.NewVerb("gc", streams),
+		verbs.NewVerb("selftest", streams),
+		verbs.NewVerb("verify", streams),
+		verbs.NewVerb("describe", streams),
+		verbs.NewVerb("retry", streams),
+		verbs.NewVerb("upgrade", streams),
+		verbs.NewVerb("example", streams),
+		verbs.NewVerb("report", streams),
+		verbs.NewVerb("migrate", streams),
+		verbs.NewVerb("rotate", streams),
+		verbs.NewVerb("search", streams),
+	)
+	//The help of the commands reading a values file describes the values of their scenario
+	applierscenarios.AddValuesHelp(cmd, helpers.GetExampleHeader())
+
+	return cmd
+}
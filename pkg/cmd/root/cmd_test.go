@@ -0,0 +1,41 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package root
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestNewCmdRoot(t *testing.T) {
+	streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	//All the commands are built, a command defining a flag twice panics
+	cmd := NewCmdRoot(streams)
+
+	cmd.SetArgs([]string{"example", "attach", "cluster"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "managedClusterName:") {
+		t.Errorf("the output must be written on the streams, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	cmd.SetArgs([]string{"attach", "cluster", "--help"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Values of the values file") {
+		t.Errorf("the help must be written on the streams, got:\n%s", out.String())
+	}
+
+	cmd.SetArgs([]string{"example", "get", "clusters"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("error expected for a command without values")
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("the errors are returned to the caller and not printed, got %s", errOut.String())
+	}
+}
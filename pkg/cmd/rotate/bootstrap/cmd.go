@@ -0,0 +1,62 @@
+// Copyright Contributors to the Open Cluster Management project
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Rotate the bootstrap kubeconfig of an available cluster, it is delivered by a ManifestWork
+%[1]s rotate bootstrap mycluster
+
+# Recover a cluster whose hub credentials expired while it was offline
+%[1]s rotate bootstrap mycluster --cluster-kubeconfig mycluster.kubeconfig
+
+# Write the new bootstrap kubeconfig secret to apply it on the cluster
+%[1]s rotate bootstrap mycluster --output-file - | kubectl --kubeconfig mycluster.kubeconfig apply -f -
+`
+
+// NewCmd provides a cobra command regenerating the bootstrap kubeconfig of the klusterlet of a cluster
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "bootstrap <cluster>",
+		Short: "Regenerate and deliver the bootstrap kubeconfig of the klusterlet",
+		Long: "Regenerate the bootstrap-hub-kubeconfig of the klusterlet of a managed cluster and deliver it to the cluster. " +
+			"The bootstrap token of the cluster is revoked and the import controller generates the import manifests again with a new token. " +
+			"The new bootstrap kubeconfig is delivered by a ManifestWork when the cluster is available. A cluster whose hub credentials " +
+			"expired while it was offline can not apply a ManifestWork: with --cluster-kubeconfig the bootstrap kubeconfig is applied on the cluster " +
+			"and the hub-kubeconfig-secret is deleted so the klusterlet bootstraps again, with a new certificate signing request approved by the hub, " +
+			"with --output-file it is written to be applied on the cluster",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.clusterKubeConfig, "cluster-kubeconfig", "", "The kubeconfig of the cluster, the bootstrap kubeconfig is applied directly on the cluster")
+	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "Write the bootstrap kubeconfig secret in this file instead of delivering it, - for the standard output")
+	helpers.DurationVar(cmd.Flags(), &o.timeout, "timeout", 2*time.Minute, "Timeout to wait for the import controller to generate the new bootstrap kubeconfig, e.g. 2m")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
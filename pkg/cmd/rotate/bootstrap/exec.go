@@ -0,0 +1,281 @@
+// Copyright Contributors to the Open Cluster Management project
+package bootstrap
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-cluster-management/cm-cli/pkg/audit"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// outputStdout is the --output-file value writing the bootstrap secret on the standard output
+	outputStdout = "-"
+	// rotationWorkSuffix is the suffix of the ManifestWork delivering the new bootstrap secret
+	rotationWorkSuffix = "-bootstrap-rotation"
+	// hubKubeConfigSecretName is the secret of the klusterlet with the credentials issued by the hub,
+	// the klusterlet bootstraps again when it is missing
+	hubKubeConfigSecretName = "hub-kubeconfig-secret"
+	// registrationAgentSelector selects the pods of the registration agent restarted to bootstrap
+	registrationAgentSelector = "app=klusterlet-registration-agent"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 1 {
+		return fmt.Errorf("only one cluster name can be given")
+	}
+	if len(args) == 1 {
+		o.clusterName = args[0]
+	}
+	if o.clusterKubeConfig != "" {
+		o.kubeConfig, err = ioutil.ReadFile(filepath.Clean(o.clusterKubeConfig))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.clusterName == "" {
+		return fmt.Errorf("the name of the cluster is missing")
+	}
+	if o.clusterKubeConfig != "" && o.outputFile != "" {
+		return fmt.Errorf("--cluster-kubeconfig and --output-file can not be used together")
+	}
+	if o.timeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than 0")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	var spokeClient kubernetes.Interface
+	if len(o.kubeConfig) != 0 {
+		config, err := clientcmd.RESTConfigFromKubeConfig(o.kubeConfig)
+		if err != nil {
+			return err
+		}
+		audit.Enable(config, "")
+		spokeClient, err = kubernetes.NewForConfig(config)
+		if err != nil {
+			return err
+		}
+	}
+	return o.runWithClients(client, spokeClient)
+}
+
+func (o *Options) runWithClients(client crclient.Client, spokeClient kubernetes.Interface) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName}, mc)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("cluster %s does not exist", o.clusterName)
+	}
+	if err != nil {
+		return err
+	}
+	//The work agent of an unavailable cluster can not apply the ManifestWork, the token is not revoked for nothing
+	conditions, _, _ := unstructured.NestedSlice(mc.Object, "status", "conditions")
+	if spokeClient == nil && o.outputFile == "" && helpers.GetConditionStatus(conditions, "ManagedClusterConditionAvailable") != "True" {
+		return fmt.Errorf("cluster %s is not available, its work agent can not apply the ManifestWork, deliver the bootstrap kubeconfig with --cluster-kubeconfig or --output-file", o.clusterName)
+	}
+
+	w := printers.Messages(o.ErrOut)
+	previous, err := o.revokeBootstrap(client)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Bootstrap token of cluster %s revoked, waiting for the new bootstrap kubeconfig\n", o.clusterName)
+	bootstrap, err := o.waitForBootstrapSecret(client, previous)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case o.outputFile != "":
+		return o.writeBootstrapSecret(bootstrap)
+	case spokeClient != nil:
+		return o.applyOnCluster(spokeClient, bootstrap)
+	default:
+		return o.applyBootstrapWork(client, bootstrap)
+	}
+}
+
+// revokeBootstrap deletes the token secrets of the bootstrap service account of the cluster and its import secret,
+// the import controller generates them again with a new token. It returns the previous bootstrap kubeconfig
+func (o *Options) revokeBootstrap(client crclient.Client) (string, error) {
+	previous := ""
+	importSecret, err := helpers.GetImportSecret(o.ctx, client, o.clusterName)
+	switch {
+	case errors.IsNotFound(err):
+	case err != nil:
+		return "", err
+	default:
+		if bootstrap, err := helpers.GetBootstrapSecret(importSecret); err == nil {
+			previous, _, _ = unstructured.NestedString(bootstrap.Object, "data", "kubeconfig")
+		}
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := client.List(o.ctx, secrets, crclient.InNamespace(o.clusterName)); err != nil {
+		return "", err
+	}
+	serviceAccount := o.clusterName + "-bootstrap-sa"
+	for i := range secrets.Items {
+		s := &secrets.Items[i]
+		if s.Type != corev1.SecretTypeServiceAccountToken || s.Annotations[corev1.ServiceAccountNameKey] != serviceAccount {
+			continue
+		}
+		if err := client.Delete(o.ctx, s); err != nil && !errors.IsNotFound(err) {
+			return "", err
+		}
+	}
+	if importSecret != nil {
+		if err := client.Delete(o.ctx, importSecret); err != nil && !errors.IsNotFound(err) {
+			return "", err
+		}
+	}
+	return previous, nil
+}
+
+// waitForBootstrapSecret waits for the import controller to generate the import secret with
+// a bootstrap kubeconfig different from the previous one and returns its bootstrap secret
+func (o *Options) waitForBootstrapSecret(client crclient.Client, previous string) (*unstructured.Unstructured, error) {
+	var bootstrap *unstructured.Unstructured
+	err := helpers.PollImmediate(o.ctx, o.pollInterval, o.timeout, func() (bool, error) {
+		importSecret, err := helpers.GetImportSecret(o.ctx, client, o.clusterName)
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		bootstrap, err = helpers.GetBootstrapSecret(importSecret)
+		if err != nil {
+			return false, err
+		}
+		kubeConfig, _, _ := unstructured.NestedString(bootstrap.Object, "data", "kubeconfig")
+		return kubeConfig != "" && kubeConfig != previous, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return nil, fmt.Errorf("the import controller did not generate a new bootstrap kubeconfig for cluster %s after %s", o.clusterName, o.timeout)
+	}
+	return bootstrap, err
+}
+
+// writeBootstrapSecret writes the bootstrap secret in the output file or on the standard output
+func (o *Options) writeBootstrapSecret(bootstrap *unstructured.Unstructured) error {
+	b, err := yaml.Marshal(bootstrap.Object)
+	if err != nil {
+		return err
+	}
+	if o.outputFile == outputStdout {
+		_, err := o.Out.Write(b)
+		return err
+	}
+	tmp, err := helpers.TempFile(o.outputFile)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := helpers.ReplaceFile(tmp, o.outputFile); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "The bootstrap kubeconfig of cluster %s has been written in %s, apply it on the cluster "+
+		"and delete the %s secret of the klusterlet so it bootstraps again\n", o.clusterName, o.outputFile, hubKubeConfigSecretName)
+	return nil
+}
+
+// applyOnCluster applies the bootstrap secret on the cluster, then deletes the hub kubeconfig secret
+// and restarts the registration agent so the klusterlet bootstraps again with the new kubeconfig
+func (o *Options) applyOnCluster(spokeClient kubernetes.Interface, bootstrap *unstructured.Unstructured) error {
+	secret := &corev1.Secret{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(bootstrap.Object, secret); err != nil {
+		return err
+	}
+	namespace := secret.Namespace
+	secrets := spokeClient.CoreV1().Secrets(namespace)
+	existing, err := secrets.Get(o.ctx, secret.Name, metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		_, err = secrets.Create(o.ctx, secret, metav1.CreateOptions{})
+	case err == nil:
+		existing.Data = secret.Data
+		_, err = secrets.Update(o.ctx, existing, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to apply the bootstrap kubeconfig on cluster %s: %v", o.clusterName, err)
+	}
+	if err := secrets.Delete(o.ctx, hubKubeConfigSecretName, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	pods, err := spokeClient.CoreV1().Pods(namespace).List(o.ctx, metav1.ListOptions{LabelSelector: registrationAgentSelector})
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		if err := spokeClient.CoreV1().Pods(namespace).Delete(o.ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "Bootstrap kubeconfig applied on cluster %s, the klusterlet is bootstrapping again\n", o.clusterName)
+	return nil
+}
+
+// applyBootstrapWork delivers the bootstrap secret with a ManifestWork, the secret is orphaned
+// so the klusterlet keeps it if the ManifestWork is deleted
+func (o *Options) applyBootstrapWork(client crclient.Client, bootstrap *unstructured.Unstructured) error {
+	spec := map[string]interface{}{
+		"deleteOption": map[string]interface{}{"propagationPolicy": "Orphan"},
+		"workload": map[string]interface{}{
+			"manifests": []interface{}{bootstrap.Object},
+		},
+	}
+	work := &unstructured.Unstructured{}
+	work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Namespace: o.clusterName, Name: o.clusterName + rotationWorkSuffix}, work)
+	switch {
+	case errors.IsNotFound(err):
+		work.SetNamespace(o.clusterName)
+		work.SetName(o.clusterName + rotationWorkSuffix)
+		work.Object["spec"] = spec
+		err = client.Create(o.ctx, work)
+	case err == nil:
+		work.Object["spec"] = spec
+		err = client.Update(o.ctx, work)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to deliver the bootstrap kubeconfig to cluster %s: %v", o.clusterName, err)
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "Bootstrap kubeconfig of cluster %s delivered by the ManifestWork %s/%s\n", o.clusterName, o.clusterName, work.GetName())
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright Contributors to the Open Cluster Management project
+package bootstrap
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const agentNamespace = "open-cluster-management-agent"
+
+// newBootstrapImportSecret returns the import secret of c1 with the bootstrap kubeconfig
+func newBootstrapImportSecret(kubeConfig string) *corev1.Secret {
+	importYAML := `apiVersion: v1
+kind: Secret
+metadata:
+  name: bootstrap-hub-kubeconfig
+  namespace: ` + agentNamespace + `
+data:
+  kubeconfig: ` + base64.StdEncoding.EncodeToString([]byte(kubeConfig)) + "\n"
+	return fake.NewImportSecret("c1", map[string][]byte{
+		helpers.ImportSecretCRDsKey:   []byte(""),
+		helpers.ImportSecretImportKey: []byte(importYAML),
+	})
+}
+
+// newCluster returns the cluster c1 with the availability
+func newCluster(available string) *unstructured.Unstructured {
+	return fake.NewManagedCluster("c1", fake.WithConditions(fake.NewCondition("ManagedClusterConditionAvailable", available)))
+}
+
+func newTokenSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "c1",
+			Name:        "c1-bootstrap-sa-token-abcde",
+			Annotations: map[string]string{corev1.ServiceAccountNameKey: "c1-bootstrap-sa"},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+}
+
+// simulateImportController generates the import secret again once it is deleted
+func simulateImportController(ctx context.Context, client crclient.Client) {
+	for ctx.Err() == nil {
+		err := client.Get(context.TODO(), types.NamespacedName{Namespace: "c1", Name: "c1-import"}, &corev1.Secret{})
+		if errors.IsNotFound(err) {
+			_ = client.Create(context.TODO(), newBootstrapImportSecret("new-kubeconfig"))
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestOptions_runWithClients(t *testing.T) {
+	tests := []struct {
+		name       string
+		objs       []runtime.Object
+		spoke      bool
+		outputFile string
+		regenerate bool
+		contains   string
+		wantWork   bool
+		wantErr    string
+	}{
+		{
+			name:       "Success, manifestwork",
+			objs:       []runtime.Object{newCluster("True"), newBootstrapImportSecret("old-kubeconfig"), newTokenSecret()},
+			regenerate: true,
+			wantWork:   true,
+		},
+		{
+			name:       "Success, cluster kubeconfig",
+			objs:       []runtime.Object{newCluster("Unknown"), newBootstrapImportSecret("old-kubeconfig"), newTokenSecret()},
+			spoke:      true,
+			regenerate: true,
+		},
+		{
+			name:       "Success, output file",
+			objs:       []runtime.Object{newCluster("Unknown"), newBootstrapImportSecret("old-kubeconfig")},
+			outputFile: outputStdout,
+			regenerate: true,
+			contains:   base64.StdEncoding.EncodeToString([]byte("new-kubeconfig")),
+		},
+		{
+			name:    "Failed, unavailable cluster",
+			objs:    []runtime.Object{newCluster("Unknown"), newBootstrapImportSecret("old-kubeconfig")},
+			wantErr: "--cluster-kubeconfig",
+		},
+		{
+			name:    "Failed, not regenerated",
+			objs:    []runtime.Object{newCluster("True"), newBootstrapImportSecret("old-kubeconfig")},
+			wantErr: "did not generate a new bootstrap kubeconfig",
+		},
+		{
+			name:    "Failed, unknown cluster",
+			wantErr: "does not exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.clusterName = "c1"
+			o.outputFile = tt.outputFile
+			o.timeout = 200 * time.Millisecond
+			o.pollInterval = 10 * time.Millisecond
+			client := fake.NewClient(tt.objs...)
+			var spokeClient kubernetes.Interface
+			if tt.spoke {
+				spokeClient = kubefake.NewSimpleClientset(
+					&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: agentNamespace, Name: hubKubeConfigSecretName}},
+					&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: agentNamespace, Name: "registration", Labels: map[string]string{"app": "klusterlet-registration-agent"}}},
+				)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.regenerate {
+				go simulateImportController(ctx, client)
+			}
+			err := o.runWithClients(client, spokeClient)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error containing %q expected, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(out.String(), tt.contains) {
+				t.Errorf("output must contain %q, got:\n%s", tt.contains, out.String())
+			}
+			if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "c1", Name: "c1-bootstrap-sa-token-abcde"}, &corev1.Secret{}); !errors.IsNotFound(err) {
+				t.Errorf("the bootstrap token must be revoked, got %v", err)
+			}
+
+			work := &unstructured.Unstructured{}
+			work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+			err = client.Get(context.TODO(), types.NamespacedName{Namespace: "c1", Name: "c1" + rotationWorkSuffix}, work)
+			if tt.wantWork != (err == nil) {
+				t.Errorf("unexpected bootstrap ManifestWork, got %v", err)
+			}
+
+			if !tt.spoke {
+				return
+			}
+			secret, err := spokeClient.CoreV1().Secrets(agentNamespace).Get(context.TODO(), helpers.BootstrapSecretName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(secret.Data["kubeconfig"]) != "new-kubeconfig" {
+				t.Errorf("unexpected bootstrap kubeconfig %s", secret.Data["kubeconfig"])
+			}
+			if _, err := spokeClient.CoreV1().Secrets(agentNamespace).Get(context.TODO(), hubKubeConfigSecretName, metav1.GetOptions{}); !errors.IsNotFound(err) {
+				t.Errorf("the hub kubeconfig secret must be deleted, got %v", err)
+			}
+			if _, err := spokeClient.CoreV1().Pods(agentNamespace).Get(context.TODO(), "registration", metav1.GetOptions{}); !errors.IsNotFound(err) {
+				t.Errorf("the registration agent must be restarted, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       Options
+		wantErr bool
+	}{
+		{name: "Success", o: Options{clusterName: "c1", timeout: time.Minute}},
+		{name: "Failed, no cluster", o: Options{timeout: time.Minute}, wantErr: true},
+		{name: "Failed, kubeconfig and output file", o: Options{clusterName: "c1", clusterKubeConfig: "k", outputFile: "-", timeout: time.Minute}, wantErr: true},
+		{name: "Failed, no timeout", o: Options{clusterName: "c1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright Contributors to the Open Cluster Management project
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags *genericclioptions.ConfigFlags
+	clusterName string
+	//clusterKubeConfig is the path of the kubeconfig of the cluster the bootstrap kubeconfig is applied on
+	clusterKubeConfig string
+	kubeConfig        []byte
+	//outputFile receives the bootstrap secret to apply on the cluster, - for the standard output
+	outputFile   string
+	timeout      time.Duration
+	pollInterval time.Duration
+	//ctx is canceled on Ctrl+C to abort the wait of the import secret
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		pollInterval: 2 * time.Second,
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
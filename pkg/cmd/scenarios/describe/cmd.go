@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+package describe
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Show the templates and the required and optional values of the attach scenario
+%[1]s scenarios describe attach
+
+# Write an example values file of the attach scenario to complete
+%[1]s scenarios describe attach --example > values.yaml
+`
+
+// NewCmd provides a cobra command describing a bundled applier scenario
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "describe <scenario>",
+		Short:        "Show the templates and the values of a bundled scenario",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.example, "example", false, "Print an example values file of the scenario instead of its description")
+	o.printOptions.AddFlags(cmd.Flags())
+
+	return cmd
+}
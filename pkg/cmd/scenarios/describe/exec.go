@@ -0,0 +1,86 @@
+// Copyright Contributors to the Open Cluster Management project
+package describe
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"github.com/spf13/cobra"
+)
+
+// description is the json and yaml output of the describe
+type description struct {
+	applierscenarios.Scenario
+	Templates []string                    `json:"templates"`
+	Values    []applierscenarios.ValueDoc `json:"values"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("only one scenario can be described, got %s", strings.Join(args, " "))
+	}
+	if len(args) == 1 {
+		o.scenarioName = args[0]
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.scenarioName == "" {
+		return fmt.Errorf("the scenario is missing, one of %s", strings.Join(applierscenarios.ScenarioNames(), ", "))
+	}
+	if _, ok := applierscenarios.GetScenario(o.scenarioName); !ok {
+		return fmt.Errorf("unknown scenario %s, the scenarios are %s", o.scenarioName, strings.Join(applierscenarios.ScenarioNames(), ", "))
+	}
+	if o.example && o.printOptions.OutputFormat != printers.OutputTable {
+		return fmt.Errorf("--example can not be used with --output")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	s, _ := applierscenarios.GetScenario(o.scenarioName)
+	b, err := s.ValuesTemplate()
+	if err != nil {
+		return err
+	}
+	if o.example {
+		_, err = o.Out.Write(applierscenarios.ExampleValues(b))
+		return err
+	}
+	templates, err := s.Templates()
+	if err != nil {
+		return err
+	}
+	d := description{
+		Scenario:  s,
+		Templates: templates,
+		Values:    applierscenarios.ParseValuesTemplate(b),
+	}
+	if o.printOptions.OutputFormat != printers.OutputTable {
+		return o.printOptions.Print(o.Out, nil, d)
+	}
+
+	fmt.Fprintf(o.Out, "Name:         %s\n", d.Name)
+	fmt.Fprintf(o.Out, "Command:      %s\n", d.Command)
+	fmt.Fprintf(o.Out, "Description:  %s\n", d.Description)
+	fmt.Fprintf(o.Out, "Templates:\n")
+	for _, t := range d.Templates {
+		fmt.Fprintf(o.Out, "  %s\n", t)
+	}
+	table := &printers.Table{
+		Headers: []string{"VALUE", "REQUIRED", "DEFAULT", "DESCRIPTION"},
+	}
+	for _, v := range d.Values {
+		required := "no"
+		if v.Required {
+			required = "yes"
+		}
+		table.AddRow(v.Path, required, v.Default, v.Description)
+	}
+	fmt.Fprintf(o.Out, "Values:\n")
+	return printers.PrintTable(o.Out, table)
+}
@@ -0,0 +1,89 @@
+// Copyright Contributors to the Open Cluster Management project
+package describe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		scenarioName string
+		example      bool
+		output       string
+		wantErr      bool
+	}{
+		{name: "Success", scenarioName: "attach"},
+		{name: "Failed, missing scenario", wantErr: true},
+		{name: "Failed, unknown scenario", scenarioName: "unknown", wantErr: true},
+		{name: "Failed, example with output", scenarioName: "attach", example: true, output: printers.OutputJSON, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, _, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.scenarioName = tt.scenarioName
+			o.example = tt.example
+			if tt.output != "" {
+				o.printOptions.OutputFormat = tt.output
+			}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_run(t *testing.T) {
+	tests := []struct {
+		name        string
+		example     bool
+		output      string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "Description",
+			contains: []string{"Command:      join hub", "klusterlet/", "hub.apiServer", "yes", "quay.io/open-cluster-management"},
+		},
+		{
+			name:        "Example",
+			example:     true,
+			contains:    []string{"clusterName:", "apiServer:"},
+			notContains: []string{"+required", "Command:"},
+		},
+		{
+			name:     "JSON",
+			output:   printers.OutputJSON,
+			contains: []string{`"name": "join"`, `"path": "hub.token"`, `"required": true`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.scenarioName = "join"
+			o.example = tt.example
+			if tt.output != "" {
+				o.printOptions.OutputFormat = tt.output
+			}
+			if err := o.run(); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %s, got:\n%s", c, out.String())
+				}
+			}
+			for _, c := range tt.notContains {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %s, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
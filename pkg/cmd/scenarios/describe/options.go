@@ -0,0 +1,24 @@
+// Copyright Contributors to the Open Cluster Management project
+package describe
+
+import (
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	scenarioName string
+	example      bool
+	printOptions *printers.PrintOptions
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		printOptions: printers.NewPrintOptions(),
+
+		IOStreams: streams,
+	}
+}
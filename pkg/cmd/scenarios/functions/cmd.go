@@ -0,0 +1,49 @@
+// Copyright Contributors to the Open Cluster Management project
+package functions
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# List the functions available in the scenario templates
+%[1]s scenarios functions
+
+# Show the usage of a function with its example rendered
+%[1]s scenarios functions nindent
+`
+
+// NewCmd provides a cobra command documenting the functions of the scenario templates
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "functions [name]",
+		Short:        "List the functions available in the scenario templates",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	o.printOptions.AddFlags(cmd.Flags())
+
+	return cmd
+}
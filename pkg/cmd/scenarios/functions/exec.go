@@ -0,0 +1,72 @@
+// Copyright Contributors to the Open Cluster Management project
+package functions
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+)
+
+// functionExample is a function with its example rendered with the example values
+type functionExample struct {
+	applierscenarios.TemplateFunction
+	Values map[string]interface{} `json:"values"`
+	Result string                 `json:"result"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("only one function can be shown, got %d", len(args))
+	}
+	if len(args) == 1 {
+		o.functionName = args[0]
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if _, ok := applierscenarios.GetTemplateFunction(o.functionName); o.functionName != "" && !ok {
+		return fmt.Errorf("unknown function %s, the functions are listed by scenarios functions", o.functionName)
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	if o.functionName != "" {
+		return o.showFunction()
+	}
+	table := &printers.Table{
+		Headers: []string{"NAME", "SOURCE", "USAGE", "DESCRIPTION"},
+	}
+	for _, f := range applierscenarios.TemplateFunctions {
+		table.AddRow(f.Name, f.Source, f.Usage, f.Description)
+	}
+	return o.printOptions.Print(o.Out, table, applierscenarios.TemplateFunctions)
+}
+
+// showFunction prints the usage of the function and its example rendered with the example values
+func (o *Options) showFunction() error {
+	f, _ := applierscenarios.GetTemplateFunction(o.functionName)
+	result, err := f.RenderExample()
+	if err != nil {
+		return fmt.Errorf("failed to render the example of %s: %v", f.Name, err)
+	}
+	e := functionExample{TemplateFunction: f, Values: applierscenarios.FunctionExampleValues, Result: result}
+	if !o.printOptions.IsTable() {
+		return o.printOptions.Print(o.Out, &printers.Table{
+			Headers: []string{"NAME", "SOURCE", "USAGE", "EXAMPLE", "RESULT"},
+			Rows:    [][]string{{f.Name, f.Source, f.Usage, f.Example, result}},
+		}, e)
+	}
+	values, err := yaml.Marshal(e.Values)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "%s (%s)\n\n  %s\n\n%s\n\nExample:\n  %s\n\nWith the values:\n%s\nRenders:\n%s\n",
+		f.Name, f.Source, f.Usage, f.Description, f.Example, values, result)
+	return nil
+}
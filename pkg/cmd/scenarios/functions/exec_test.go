@@ -0,0 +1,85 @@
+// Copyright Contributors to the Open Cluster Management project
+package functions
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_run(t *testing.T) {
+	tests := []struct {
+		name         string
+		functionName string
+		contains     []string
+	}{
+		{
+			name:     "Success, list",
+			contains: []string{"NAME", "toYaml", "applier", "b64enc", "sprig", "nindent N STRING"},
+		},
+		{
+			name:         "Success, function",
+			functionName: "b64enc",
+			contains:     []string{"b64enc STRING", "{{ .token | b64enc }}", "token: my-token", "bXktdG9rZW4="},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.functionName = tt.functionName
+			if err := o.validate(); err != nil {
+				t.Fatal(err)
+			}
+			if err := o.run(); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_run_json(t *testing.T) {
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	o.functionName = "default"
+	o.printOptions.OutputFormat = printers.OutputJSON
+	if err := o.run(); err != nil {
+		t.Fatal(err)
+	}
+	e := map[string]interface{}{}
+	if err := json.Unmarshal(out.Bytes(), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e["name"] != "default" || e["result"] != "open-cluster-management" {
+		t.Errorf("unexpected function %v", e)
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		functionName string
+		wantErr      bool
+	}{
+		{name: "Success"},
+		{name: "Success, function", functionName: "toYaml"},
+		{name: "Failed, unknown function", functionName: "lookup", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOptions(genericclioptions.IOStreams{})
+			o.functionName = tt.functionName
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
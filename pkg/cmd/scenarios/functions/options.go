@@ -0,0 +1,24 @@
+// Copyright Contributors to the Open Cluster Management project
+package functions
+
+import (
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	printOptions *printers.PrintOptions
+	//functionName is the function to show, all the functions are listed if not set
+	functionName string
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		printOptions: printers.NewPrintOptions(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *Options) validate() error {
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	table := &printers.Table{
+		Headers: []string{"NAME", "COMMAND", "DESCRIPTION"},
+	}
+	for _, s := range applierscenarios.Scenarios {
+		table.AddRow(s.Name, s.Command, s.Description)
+	}
+	return o.printOptions.Print(o.Out, table, applierscenarios.Scenarios)
+}
@@ -0,0 +1,25 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_run(t *testing.T) {
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.run(); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []string{"NAME", "attach", "attach cluster", "join hub"} {
+		if !strings.Contains(out.String(), c) {
+			t.Errorf("output must contain %s, got:\n%s", c, out.String())
+		}
+	}
+}
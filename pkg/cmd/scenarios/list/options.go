@@ -0,0 +1,22 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	printOptions *printers.PrintOptions
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		printOptions: printers.NewPrintOptions(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+package search
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Search the pods of a namespace on the clusters starting with prod-
+%[1]s search 'kind:pod namespace:openshift-gitops cluster:prod-*'
+
+# Search the deployments and statefulsets of all clusters matching a keyword, as json
+%[1]s search 'kind:deployment,statefulset nginx' -o json
+
+# Search through a search API exposed on a custom url
+%[1]s search 'kind:node' --search-url https://search.example.com/searchapi/graphql
+`
+
+// NewCmd provides a cobra command querying the search API of the hub
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search the resources of the managed clusters",
+		Long: `Search the resources of the managed clusters with the search API of the hub.
+The query is made of keywords and of property:value filters, the values of a property are separated by commas
+and the values containing * or ? are matched as patterns, e.g. cluster:prod-*.`,
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.searchURL, "search-url", "", "URL of the graphql endpoint of the search API, discovered from the search-api route of the hub if not set")
+	cmd.Flags().IntVar(&o.limit, "limit", defaultLimit, "Maximum number of results returned by the search API, -1 for no limit")
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
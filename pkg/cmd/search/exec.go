@@ -0,0 +1,219 @@
+// Copyright Contributors to the Open Cluster Management project
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultLimit is the default maximum number of results, the one of the search console
+	defaultLimit = 1000
+	// searchRouteNamespace and searchRouteName are the route exposing the search API on the hub
+	searchRouteNamespace = "open-cluster-management"
+	searchRouteName      = "search-api"
+	// searchPath is the path of the graphql endpoint of the search API
+	searchPath = "/searchapi/graphql"
+	// searchGraphQL is the graphql query of the search API
+	searchGraphQL = "query searchResult($input: [SearchInput]) { searchResult: search(input: $input) { items count } }"
+)
+
+// searchTimeout bounds the time spent waiting for the search API
+var searchTimeout = time.Minute
+
+// searchResponse is the response of the search API to the search query
+type searchResponse struct {
+	Data struct {
+		SearchResult []struct {
+			Items []map[string]interface{} `json:"items"`
+			Count int                      `json:"count"`
+		} `json:"searchResult"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	o.query = strings.Join(args, " ")
+	return nil
+}
+
+func (o *Options) validate() error {
+	if strings.TrimSpace(o.query) == "" {
+		return fmt.Errorf("the search query is missing, e.g. 'kind:pod cluster:mycluster'")
+	}
+	if o.limit == 0 || o.limit < -1 {
+		return fmt.Errorf("invalid limit %d, it must be positive or -1 for no limit", o.limit)
+	}
+	if _, err := parseQuery(o.query, o.limit); err != nil {
+		return err
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	factory := clients.ForFlags(o.configFlags)
+	config, err := factory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	client, err := factory.Client()
+	if err != nil {
+		return err
+	}
+	httpClient, err := searchHTTPClient(config)
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client, httpClient)
+}
+
+// searchHTTPClient returns an http client authenticated as the hub user, the search API route is
+// served with the certificate of the ingress and not the one of the api server so its CA is not used
+func searchHTTPClient(config *rest.Config) (*http.Client, error) {
+	searchConfig := rest.CopyConfig(config)
+	searchConfig.TLSClientConfig = rest.TLSClientConfig{Insecure: config.Insecure}
+	transport, err := rest.TransportFor(searchConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport, Timeout: searchTimeout}, nil
+}
+
+func (o *Options) runWithClient(client crclient.Client, httpClient *http.Client) error {
+	q, err := parseQuery(o.query, o.limit)
+	if err != nil {
+		return err
+	}
+	searchURL := o.searchURL
+	if searchURL == "" {
+		if searchURL, err = discoverSearchURL(o.ctx, client); err != nil {
+			return err
+		}
+	}
+	items, count, err := o.search(httpClient, searchURL, q)
+	if err != nil {
+		return err
+	}
+
+	table := &printers.Table{Headers: []string{"CLUSTER", "KIND", "NAMESPACE", "NAME", "STATUS"}}
+	for _, item := range items {
+		table.AddRow(itemValue(item, "cluster"), itemValue(item, "kind"), itemValue(item, "namespace"), itemValue(item, "name"), itemValue(item, "status"))
+	}
+	if err := o.printOptions.Print(o.Out, table, items); err != nil {
+		return err
+	}
+	if count > len(items) && len(q.patterns) == 0 {
+		fmt.Fprintf(printers.Messages(o.ErrOut), "%d of %d results shown, raise --limit to show more\n", len(items), count)
+	}
+	return nil
+}
+
+// discoverSearchURL returns the url of the search API from its route on the hub
+func discoverSearchURL(ctx context.Context, client crclient.Client) (string, error) {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(helpers.RouteGVK)
+	err := client.Get(ctx, types.NamespacedName{Namespace: searchRouteNamespace, Name: searchRouteName}, route)
+	if errors.IsNotFound(err) {
+		return "", fmt.Errorf("the search API is not exposed by a %s route in namespace %s, expose it or set its url with --search-url",
+			searchRouteName, searchRouteNamespace)
+	}
+	if err != nil {
+		return "", err
+	}
+	host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+	if host == "" {
+		return "", fmt.Errorf("the route %s/%s of the search API has no host", searchRouteNamespace, searchRouteName)
+	}
+	return "https://" + host + searchPath, nil
+}
+
+// search sends the query to the search API and returns the items matching the patterns, sorted by cluster, kind,
+// namespace and name, with the total number of results
+func (o *Options) search(httpClient *http.Client, searchURL string, q *query) ([]map[string]interface{}, int, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"operationName": "searchResult",
+		"variables":     map[string]interface{}{"input": []searchInput{q.input}},
+		"query":         searchGraphQL,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequestWithContext(o.ctx, http.MethodPost, searchURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query the search API %s: %v", searchURL, err)
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("the search API %s returned %s: %s", searchURL, resp.Status, strings.TrimSpace(string(b)))
+	}
+	result := &searchResponse{}
+	if err := json.Unmarshal(b, result); err != nil {
+		return nil, 0, fmt.Errorf("invalid response of the search API %s: %v", searchURL, err)
+	}
+	if len(result.Errors) != 0 {
+		messages := make([]string, len(result.Errors))
+		for i, e := range result.Errors {
+			messages[i] = e.Message
+		}
+		return nil, 0, fmt.Errorf("the search failed: %s", strings.Join(messages, ", "))
+	}
+
+	items := make([]map[string]interface{}, 0)
+	count := 0
+	for _, r := range result.Data.SearchResult {
+		count += r.Count
+		for _, item := range r.Items {
+			if q.matches(item) {
+				items = append(items, item)
+			}
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, property := range []string{"cluster", "kind", "namespace", "name"} {
+			if a, b := itemValue(items[i], property), itemValue(items[j], property); a != b {
+				return a < b
+			}
+		}
+		return false
+	})
+	return items, count, nil
+}
+
+// itemValue returns the value of a property of a search result, "" if it is not set
+func itemValue(item map[string]interface{}, property string) string {
+	if v, ok := item[property]; ok && v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
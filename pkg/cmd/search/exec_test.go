@@ -0,0 +1,250 @@
+// Copyright Contributors to the Open Cluster Management project
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var searchItems = []map[string]interface{}{
+	{"cluster": "prod-eu", "kind": "pod", "namespace": "openshift-gitops", "name": "repo-server", "status": "Running"},
+	{"cluster": "dev", "kind": "pod", "namespace": "openshift-gitops", "name": "repo-server", "status": "Running"},
+	{"cluster": "prod-us", "kind": "pod", "namespace": "openshift-gitops", "name": "application-controller", "status": "Pending"},
+}
+
+// newSearchServer returns a search API answering with the items and recording the inputs it receives
+func newSearchServer(t *testing.T, inputs *[]searchInput, response string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != searchPath || r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		req := struct {
+			Variables struct {
+				Input []searchInput `json:"input"`
+			} `json:"variables"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		*inputs = append(*inputs, req.Variables.Input...)
+		if response != "" {
+			w.Write([]byte(response))
+			return
+		}
+		b, _ := json.Marshal(map[string]interface{}{
+			"data": map[string]interface{}{"searchResult": []interface{}{
+				map[string]interface{}{"items": searchItems, "count": 5},
+			}},
+		})
+		w.Write(b)
+	}))
+}
+
+func newSearchRoute(host string) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"host": host},
+	}}
+	route.SetGroupVersionKind(helpers.RouteGVK)
+	route.SetNamespace(searchRouteNamespace)
+	route.SetName(searchRouteName)
+	return route
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		objs        []runtime.Object
+		discover    bool
+		response    string
+		wantFilters []searchFilter
+		contains    []string
+		excludes    []string
+		wantErr     string
+	}{
+		{
+			name:        "Success, patterns",
+			query:       "kind:pod namespace:openshift-gitops cluster:prod-*",
+			wantFilters: []searchFilter{{Property: "kind", Values: []string{"pod"}}, {Property: "namespace", Values: []string{"openshift-gitops"}}},
+			contains:    []string{"prod-eu", "prod-us", "application-controller", "Pending"},
+			excludes:    []string{"dev"},
+		},
+		{
+			name:        "Success, keywords",
+			query:       "kind:pod repo-server",
+			wantFilters: []searchFilter{{Property: "kind", Values: []string{"pod"}}},
+			contains:    []string{"CLUSTER", "dev", "prod-eu"},
+		},
+		{
+			name:     "Success, discovered route",
+			query:    "kind:pod",
+			discover: true,
+			contains: []string{"prod-us"},
+		},
+		{
+			name:     "Failed, no route",
+			query:    "kind:pod",
+			objs:     []runtime.Object{},
+			discover: true,
+			wantErr:  "--search-url",
+		},
+		{
+			name:     "Failed, search error",
+			query:    "kind:pod",
+			response: `{"errors":[{"message":"invalid token"}]}`,
+			wantErr:  "invalid token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs := make([]searchInput, 0)
+			server := newSearchServer(t, &inputs, tt.response)
+			defer server.Close()
+
+			streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.ctx = context.Background()
+			o.query = tt.query
+			o.limit = defaultLimit
+			objs := tt.objs
+			if tt.discover && objs == nil {
+				objs = []runtime.Object{newSearchRoute("search.example.com")}
+			}
+			httpClient := server.Client()
+			if !tt.discover {
+				o.searchURL = server.URL + searchPath
+			} else {
+				//The discovered url is sent to the test server
+				httpClient.Transport = rewriteTransport{url: server.URL}
+			}
+			err := o.runWithClient(fake.NewClient(objs...), httpClient)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error containing %q expected, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+			for _, c := range tt.excludes {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %q, got:\n%s", c, out.String())
+				}
+			}
+			if len(inputs) != 1 {
+				t.Fatalf("one search expected, got %v", inputs)
+			}
+			if tt.wantFilters != nil {
+				got, _ := json.Marshal(inputs[0].Filters)
+				want, _ := json.Marshal(tt.wantFilters)
+				if string(got) != string(want) {
+					t.Errorf("filters = %s, want %s", got, want)
+				}
+			}
+			//The total count is not reported once the patterns filtered the results
+			if len(tt.excludes) == 0 && !strings.Contains(errOut.String(), "raise --limit") {
+				t.Errorf("the truncated results must be reported, got %q", errOut.String())
+			}
+		})
+	}
+}
+
+// rewriteTransport sends the requests to the test server
+type rewriteTransport struct {
+	url string
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != "search.example.com" {
+		return nil, http.ErrNotSupported
+	}
+	req.URL.Scheme = "http"
+	req.URL.Host = strings.TrimPrefix(t.url, "http://")
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestOptions_runWithClient_json(t *testing.T) {
+	inputs := make([]searchInput, 0)
+	server := newSearchServer(t, &inputs, "")
+	defer server.Close()
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	o.ctx = context.Background()
+	o.query = "kind:pod"
+	o.limit = defaultLimit
+	o.searchURL = server.URL + searchPath
+	o.printOptions.OutputFormat = printers.OutputJSON
+	if err := o.runWithClient(fake.NewClient(), server.Client()); err != nil {
+		t.Fatal(err)
+	}
+	items := make([]map[string]interface{}, 0)
+	if err := json.Unmarshal(out.Bytes(), &items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 || items[0]["cluster"] != "dev" || items[2]["cluster"] != "prod-us" {
+		t.Errorf("unexpected items %v", items)
+	}
+}
+
+func Test_parseQuery(t *testing.T) {
+	q, err := parseQuery("kind:pod,deployment nginx cluster:prod-*,dev", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.input.Keywords) != 1 || q.input.Keywords[0] != "nginx" || q.input.Limit != 10 {
+		t.Errorf("unexpected input %+v", q.input)
+	}
+	if len(q.input.Filters) != 1 || len(q.input.Filters[0].Values) != 2 {
+		t.Errorf("unexpected filters %+v", q.input.Filters)
+	}
+	if len(q.patterns) != 1 || q.patterns[0].Property != "cluster" {
+		t.Errorf("unexpected patterns %+v", q.patterns)
+	}
+	if !q.matches(map[string]interface{}{"cluster": "dev"}) || q.matches(map[string]interface{}{"cluster": "staging"}) {
+		t.Error("the patterns must match the exact values and the patterns of the filter")
+	}
+	for _, invalid := range []string{"", ":pod", "kind:", "cluster:prod-["} {
+		if _, err := parseQuery(invalid, 10); err == nil {
+			t.Errorf("parseQuery(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       Options
+		wantErr bool
+	}{
+		{name: "Success", o: Options{query: "kind:pod", limit: 10, printOptions: printers.NewPrintOptions()}},
+		{name: "Success, no limit", o: Options{query: "kind:pod", limit: -1, printOptions: printers.NewPrintOptions()}},
+		{name: "Failed, no query", o: Options{limit: 10, printOptions: printers.NewPrintOptions()}, wantErr: true},
+		{name: "Failed, invalid limit", o: Options{query: "kind:pod", printOptions: printers.NewPrintOptions()}, wantErr: true},
+		{name: "Failed, invalid filter", o: Options{query: "kind:", limit: 10, printOptions: printers.NewPrintOptions()}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
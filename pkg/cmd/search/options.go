@@ -0,0 +1,32 @@
+// Copyright Contributors to the Open Cluster Management project
+package search
+
+import (
+	"context"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags  *genericclioptions.ConfigFlags
+	printOptions *printers.PrintOptions
+	//query is the search query, keywords and property:value filters
+	query string
+	//searchURL is the url of the graphql endpoint of the search API, discovered from its route if not set
+	searchURL string
+	limit     int
+	ctx       context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		printOptions: printers.NewPrintOptions(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright Contributors to the Open Cluster Management project
+package search
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// searchFilter filters the results on the values of a property
+type searchFilter struct {
+	Property string   `json:"property"`
+	Values   []string `json:"values"`
+}
+
+// searchInput is the input of the search query of the search API
+type searchInput struct {
+	Keywords []string       `json:"keywords"`
+	Filters  []searchFilter `json:"filters"`
+	Limit    int            `json:"limit"`
+}
+
+// query is a parsed search query: the input sent to the search API and the patterns matched on the results,
+// the patterns are not sent as the search API only matches the exact values
+type query struct {
+	input    searchInput
+	patterns []searchFilter
+}
+
+// parseQuery parses the keywords and the property:value[,value] filters of a query
+func parseQuery(q string, limit int) (*query, error) {
+	parsed := &query{input: searchInput{Keywords: []string{}, Filters: []searchFilter{}, Limit: limit}}
+	for _, token := range strings.Fields(q) {
+		i := strings.Index(token, ":")
+		if i == -1 {
+			parsed.input.Keywords = append(parsed.input.Keywords, token)
+			continue
+		}
+		property := token[:i]
+		values := make([]string, 0)
+		for _, v := range strings.Split(token[i+1:], ",") {
+			if v != "" {
+				values = append(values, v)
+			}
+		}
+		if property == "" || len(values) == 0 {
+			return nil, fmt.Errorf("invalid filter %s, it must be property:value[,value]", token)
+		}
+		filter := searchFilter{Property: property, Values: values}
+		if !hasPattern(values) {
+			parsed.input.Filters = append(parsed.input.Filters, filter)
+			continue
+		}
+		for _, v := range values {
+			if _, err := path.Match(v, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %s in filter %s: %v", v, token, err)
+			}
+		}
+		parsed.patterns = append(parsed.patterns, filter)
+	}
+	if len(parsed.input.Keywords) == 0 && len(parsed.input.Filters) == 0 && len(parsed.patterns) == 0 {
+		return nil, fmt.Errorf("the search query is empty")
+	}
+	return parsed, nil
+}
+
+func hasPattern(values []string) bool {
+	for _, v := range values {
+		if strings.ContainsAny(v, "*?[") {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true if the item matches one of the values of each pattern filter
+func (q *query) matches(item map[string]interface{}) bool {
+	for _, f := range q.patterns {
+		value := itemValue(item, f.Property)
+		matched := false
+		for _, pattern := range f.Values {
+			if ok, _ := path.Match(pattern, value); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+// Copyright Contributors to the Open Cluster Management project
+package selftest
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Run the smoke test against the hub of the current kubeconfig
+%[1]s selftest
+
+# Run the smoke test against a development hub
+%[1]s selftest --kubeconfig dev-hub.kubeconfig -o json
+`
+
+// NewCmd provides a cobra command running a smoke test of the cli against a live hub
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "selftest",
+		Short: "Run a smoke test of the cli against a live hub",
+		Long: "Check the hub is reachable, the CRDs used by the attach and detach are established " +
+			"and the resources of the attach can be created, the creations are server-side dry runs so the hub is not changed",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,130 @@
+// Copyright Contributors to the Open Cluster Management project
+package selftest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/preflight"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+// selftestName is the name of the resources created by the dry runs
+const selftestName = "cm-selftest"
+
+// selftestCRDs are the CRDs of the resources created by the attach and the detach
+var selftestCRDs = []string{
+	helpers.ManagedClusterCRDName,
+	"klusterletaddonconfigs.agent.open-cluster-management.io",
+	"manifestworks.work.open-cluster-management.io",
+}
+
+// checkResult is the outcome of a check as printed in json and yaml
+type checkResult struct {
+	Check   string `json:"check"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	return nil
+}
+
+func (o *Options) validate() error {
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	factory := clients.ForFlags(o.configFlags)
+	client, err := factory.Client()
+	if err != nil {
+		return err
+	}
+	kubeClient, err := factory.KubeClient()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client, kubeClient)
+}
+
+func (o *Options) runWithClient(client crclient.Client, kubeClient kubernetes.Interface) error {
+	results := preflight.Run(checks(o.ctx, client, kubeClient))
+	table := &printers.Table{
+		Headers: []string{"CHECK", "RESULT", "MESSAGE"},
+	}
+	checkResults := make([]checkResult, 0, len(results))
+	for _, r := range results {
+		result := checkResult{Check: r.Name, Passed: r.Err == nil}
+		status := "Passed"
+		if r.Err != nil {
+			result.Message = r.Err.Error()
+			status = "Failed"
+		}
+		checkResults = append(checkResults, result)
+		table.AddRow(result.Check, status, result.Message)
+	}
+	if err := o.printOptions.Print(o.Out, table, checkResults); err != nil {
+		return err
+	}
+	if failed := preflight.Failed(results); len(failed) != 0 {
+		return fmt.Errorf("%d of %d checks failed", len(failed), len(results))
+	}
+	return nil
+}
+
+// checks returns the smoke test checks, the connectivity first so its failure explains the other ones
+func checks(ctx context.Context, client crclient.Client, kubeClient kubernetes.Interface) []preflight.Check {
+	return []preflight.Check{
+		preflight.NewCheck("Connectivity", func() error {
+			_, err := kubeClient.Discovery().ServerVersion()
+			return err
+		}),
+		preflight.NewCheck("CRDs", func() error {
+			return checkCRDs(ctx, client)
+		}),
+		preflight.NewCheck("Namespace creation", func() error {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: selftestName}}
+			return client.Create(ctx, ns, crclient.DryRunAll)
+		}),
+		preflight.NewCheck("ManagedCluster creation", func() error {
+			mc := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{"hubAcceptsClient": true},
+				},
+			}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			mc.SetName(selftestName)
+			return client.Create(ctx, mc, crclient.DryRunAll)
+		}),
+	}
+}
+
+// checkCRDs checks the CRDs used by the attach and the detach are established
+func checkCRDs(ctx context.Context, client crclient.Client) error {
+	problems := make([]string, 0)
+	for _, name := range selftestCRDs {
+		established, err := helpers.CRDEstablished(ctx, client, name)
+		if err != nil {
+			return err
+		}
+		if !established {
+			problems = append(problems, fmt.Sprintf("CRD %s is not established", name))
+		}
+	}
+	if len(problems) != 0 {
+		return fmt.Errorf("%s", strings.Join(problems, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright Contributors to the Open Cluster Management project
+package selftest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newCRD(name string, established bool) *unstructured.Unstructured {
+	status := "False"
+	if established {
+		status = "True"
+	}
+	crd := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Established", "status": status},
+				},
+			},
+		},
+	}
+	crd.SetGroupVersionKind(helpers.CustomResourceDefinitionGVK)
+	crd.SetName(name)
+	return crd
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		objs     []runtime.Object
+		contains []string
+		wantErr  bool
+	}{
+		{
+			name: "Success",
+			objs: []runtime.Object{
+				newCRD(selftestCRDs[0], true),
+				newCRD(selftestCRDs[1], true),
+				newCRD(selftestCRDs[2], true),
+			},
+			contains: []string{"Connectivity", "ManagedCluster creation", "Passed"},
+		},
+		{
+			name: "Failed, CRD not established",
+			objs: []runtime.Object{
+				newCRD(selftestCRDs[0], true),
+				newCRD(selftestCRDs[1], false),
+			},
+			contains: []string{"Failed", selftestCRDs[1] + " is not established", selftestCRDs[2]},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			client := fake.NewClient(tt.objs...)
+			err := o.runWithClient(client, kubefake.NewSimpleClientset())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Options.runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("%q not found in %s", c, out.String())
+				}
+			}
+			//The creations are dry runs
+			mc := &unstructured.Unstructured{}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: selftestName}, mc); !errors.IsNotFound(err) {
+				t.Errorf("the ManagedCluster must not be created, got %v", err)
+			}
+		})
+	}
+}
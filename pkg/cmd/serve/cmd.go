@@ -0,0 +1,62 @@
+// Copyright Contributors to the Open Cluster Management project
+package serve
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Serve the REST API on localhost, the clients authenticate with the token of the file
+%[1]s serve --token-file token.txt
+
+# Serve the REST API over TLS on all interfaces
+%[1]s serve --token-file token.txt --address :8443 --tls-cert-file tls.crt --tls-key-file tls.key
+
+# Attach, list and detach a cluster through the REST API
+curl -H "Authorization: Bearer $(cat token.txt)" -X POST -d '{"name":"mycluster","values":{"kubeConfig":"..."}}' http://localhost:8080/api/v1/clusters
+curl -H "Authorization: Bearer $(cat token.txt)" http://localhost:8080/api/v1/clusters
+curl -H "Authorization: Bearer $(cat token.txt)" -X DELETE http://localhost:8080/api/v1/clusters/mycluster
+`
+
+// VerbFactory creates the command of a verb, the server creates new commands for each operation
+// as the commands keep the options of their last execution
+type VerbFactory func(verb string, streams genericclioptions.IOStreams) *cobra.Command
+
+// NewCmd provides a cobra command serving the attach, detach and get operations over a REST API
+func NewCmd(streams genericclioptions.IOStreams, newVerb VerbFactory) *cobra.Command {
+	o := newOptions(streams, newVerb)
+
+	cmd := &cobra.Command{
+		Use:          "serve",
+		Short:        "Serve the attach, detach and get operations over an authenticated REST API",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.address, "address", "127.0.0.1:8080", "The address the server listens on")
+	cmd.Flags().StringVar(&o.tokenFile, "token-file", "", "The file containing the bearer token the clients must send in the Authorization header")
+	cmd.Flags().StringVar(&o.tlsCertFile, "tls-cert-file", "", "The certificate of the server, the API is served over plain HTTP if not set")
+	cmd.Flags().StringVar(&o.tlsKeyFile, "tls-key-file", "", "The private key of the certificate of the server")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
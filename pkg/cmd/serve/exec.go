@@ -0,0 +1,121 @@
+// Copyright Contributors to the Open Cluster Management project
+package serve
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// shutdownTimeout is the time given to the operations in progress to complete on shutdown
+const shutdownTimeout = 30 * time.Second
+
+// readHeaderTimeout and readTimeout bound the time a client takes to send a request, the responses are not bounded
+// as the operations can take longer
+const (
+	readHeaderTimeout = 10 * time.Second
+	readTimeout       = time.Minute
+)
+
+// serverFlags are the flags of the server which are not passed to the commands of the operations.
+// The global flags are never passed either: they are not local flags of serve, the commands of the operations
+// do not define them and the server restores them after each operation, see globalFlags.
+var serverFlags = map[string]bool{
+	"address":       true,
+	"token-file":    true,
+	"tls-cert-file": true,
+	"tls-key-file":  true,
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	o.configArgs = nil
+	cmd.LocalFlags().Visit(func(f *pflag.Flag) {
+		if serverFlags[f.Name] {
+			return
+		}
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			for _, v := range s.GetSlice() {
+				o.configArgs = append(o.configArgs, fmt.Sprintf("--%s=%s", f.Name, v))
+			}
+			return
+		}
+		o.configArgs = append(o.configArgs, fmt.Sprintf("--%s=%s", f.Name, f.Value.String()))
+	})
+	if o.tokenFile == "" {
+		return nil
+	}
+	b, err := ioutil.ReadFile(filepath.Clean(o.tokenFile))
+	if err != nil {
+		return err
+	}
+	o.token = strings.TrimSpace(string(b))
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.tokenFile == "" {
+		return fmt.Errorf("the token file is required, use --token-file")
+	}
+	if o.token == "" {
+		return fmt.Errorf("the token file %s is empty", o.tokenFile)
+	}
+	if (o.tlsCertFile == "") != (o.tlsKeyFile == "") {
+		return fmt.Errorf("--tls-cert-file and --tls-key-file must be set together")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	s := &server{
+		client:     client,
+		token:      o.token,
+		newVerb:    o.newVerb,
+		configArgs: o.configArgs,
+		ctx:        o.ctx,
+		log:        o.ErrOut,
+	}
+	httpServer := &http.Server{
+		Addr:              o.address,
+		Handler:           s.handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		if o.tlsCertFile != "" {
+			errs <- httpServer.ListenAndServeTLS(o.tlsCertFile, o.tlsKeyFile)
+			return
+		}
+		errs <- httpServer.ListenAndServe()
+	}()
+	if o.tlsCertFile == "" {
+		fmt.Fprintln(printers.Messages(o.ErrOut), "Warning: serving over plain HTTP, the token is sent in clear, use --tls-cert-file and --tls-key-file outside of localhost")
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "Serving the REST API on %s\n", o.address)
+
+	select {
+	case err := <-errs:
+		return err
+	case <-o.ctx.Done():
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return httpServer.Shutdown(ctx)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright Contributors to the Open Cluster Management project
+package serve
+
+import (
+	"io"
+
+	"github.com/open-cluster-management/cm-cli/pkg/audit"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/tracing"
+)
+
+// globalFlags are the values of the global flags, such as --quiet or --qps, which are package variables.
+// They are set once for the process by the flags of serve and never forwarded to the operations,
+// the server restores them after each operation so a request can not change them for the next ones.
+type globalFlags struct {
+	quiet         bool
+	qps           float32
+	burst         int
+	hubRateLimits map[string]string
+	warnOut       io.Writer
+	explainErrors bool
+	trace         bool
+}
+
+// saveGlobalFlags returns the current values of the global flags
+func saveGlobalFlags() globalFlags {
+	g := globalFlags{
+		quiet:         printers.Quiet,
+		qps:           clients.QPS,
+		burst:         clients.Burst,
+		warnOut:       audit.WarnOut,
+		explainErrors: cmderrors.ExplainErrors,
+		trace:         tracing.Enabled,
+	}
+	if clients.HubRateLimits != nil {
+		g.hubRateLimits = make(map[string]string, len(clients.HubRateLimits))
+		for k, v := range clients.HubRateLimits {
+			g.hubRateLimits[k] = v
+		}
+	}
+	return g
+}
+
+// restore sets the global flags back to the saved values
+func (g globalFlags) restore() {
+	printers.Quiet = g.quiet
+	clients.QPS = g.qps
+	clients.Burst = g.burst
+	clients.HubRateLimits = g.hubRateLimits
+	audit.WarnOut = g.warnOut
+	cmderrors.ExplainErrors = g.explainErrors
+	tracing.Enabled = g.trace
+}
@@ -0,0 +1,34 @@
+// Copyright Contributors to the Open Cluster Management project
+package serve
+
+import (
+	"context"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags *genericclioptions.ConfigFlags
+	newVerb     VerbFactory
+	address     string
+	tokenFile   string
+	tlsCertFile string
+	tlsKeyFile  string
+	token       string
+	//configArgs are the hub connection flags passed to the commands of the operations
+	configArgs []string
+	//ctx is canceled on Ctrl+C to shutdown the server
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams, newVerb VerbFactory) *Options {
+	return &Options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		newVerb:     newVerb,
+		ctx:         context.Background(),
+
+		IOStreams: streams,
+	}
+}
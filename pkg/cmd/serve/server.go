@@ -0,0 +1,253 @@
+// Copyright Contributors to the Open Cluster Management project
+package serve
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/tracing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	clustersPath = "/api/v1/clusters"
+	//maxRequestSize limits the size of the attach requests, the values contain at most a kubeconfig
+	maxRequestSize = 1 << 20
+	//skipCredentialResolution keeps the vault:// and keychain:// references of the values,
+	//a request must not read the credentials of the host of the server
+	skipCredentialResolution = "--skip-credential-resolution"
+)
+
+// server serves the REST API, the operations are run by the commands of the CLI
+type server struct {
+	client     crclient.Client
+	token      string
+	newVerb    VerbFactory
+	configArgs []string
+	ctx        context.Context
+	log        io.Writer
+	//lock serializes the operations, they share the client factories released once they complete
+	lock sync.Mutex
+}
+
+// clusterInfo is a managed cluster as returned by the API
+type clusterInfo struct {
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Joined    string            `json:"joined"`
+	Available string            `json:"available"`
+}
+
+// attachRequest is the body of the attach requests
+type attachRequest struct {
+	Name   string                 `json:"name"`
+	Values map[string]interface{} `json:"values"`
+}
+
+// operationResponse is the result of an attach or detach
+type operationResponse struct {
+	Cluster   string `json:"cluster"`
+	Operation string `json:"operation"`
+	Output    string `json:"output"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.Handle(clustersPath, s.authenticate(http.HandlerFunc(s.clusters)))
+	mux.Handle(clustersPath+"/", s.authenticate(http.HandlerFunc(s.cluster)))
+	return s.logRequests(mux)
+}
+
+// authenticate rejects the requests without the bearer token of the server
+func (s *server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="cm"`)
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("missing or invalid bearer token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// statusRecorder keeps the status of the response to log it
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (s *server) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		fmt.Fprintf(s.log, "%s %s %d %s\n", r.Method, r.URL.Path, recorder.status, time.Since(start).Round(time.Millisecond))
+	})
+}
+
+// clusters serves the list of the clusters and the attach
+func (s *server) clusters(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.listClusters(w)
+	case http.MethodPost:
+		s.attachCluster(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed on %s", r.Method, clustersPath))
+	}
+}
+
+// cluster serves a cluster and its detach
+func (s *server) cluster(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, clustersPath+"/")
+	if name == "" || strings.Contains(name, "/") {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s not found", r.URL.Path))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		s.getCluster(w, name)
+	case http.MethodDelete:
+		s.detachCluster(w, name)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path))
+	}
+}
+
+func (s *server) listClusters(w http.ResponseWriter) {
+	mcs := &unstructured.UnstructuredList{}
+	mcs.SetGroupVersionKind(helpers.ManagedClusterGVK.GroupVersion().WithKind(helpers.ManagedClusterGVK.Kind + "List"))
+	if err := s.client.List(s.ctx, mcs); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	sort.Slice(mcs.Items, func(i, j int) bool {
+		return mcs.Items[i].GetName() < mcs.Items[j].GetName()
+	})
+	infos := make([]clusterInfo, 0, len(mcs.Items))
+	for i := range mcs.Items {
+		infos = append(infos, newClusterInfo(&mcs.Items[i]))
+	}
+	writeJSON(w, http.StatusOK, infos)
+}
+
+func (s *server) getCluster(w http.ResponseWriter, name string) {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	err := s.client.Get(s.ctx, types.NamespacedName{Name: name}, mc)
+	switch {
+	case errors.IsNotFound(err):
+		writeError(w, http.StatusNotFound, fmt.Errorf("managed cluster %s not found", name))
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		writeJSON(w, http.StatusOK, newClusterInfo(mc))
+	}
+}
+
+func newClusterInfo(mc *unstructured.Unstructured) clusterInfo {
+	conditions, _, _ := unstructured.NestedSlice(mc.Object, "status", "conditions")
+	return clusterInfo{
+		Name:      mc.GetName(),
+		Labels:    mc.GetLabels(),
+		Joined:    helpers.GetConditionStatus(conditions, "ManagedClusterJoined"),
+		Available: helpers.GetConditionStatus(conditions, "ManagedClusterConditionAvailable"),
+	}
+}
+
+func (s *server) attachCluster(w http.ResponseWriter, r *http.Request) {
+	req := &attachRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid attach request: %s", err.Error()))
+		return
+	}
+	if errs := validation.IsDNS1123Label(req.Name); len(errs) != 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid cluster name %s: %s", req.Name, strings.Join(errs, ", ")))
+		return
+	}
+	if req.Values == nil {
+		req.Values = map[string]interface{}{}
+	}
+	req.Values["managedClusterName"] = req.Name
+	s.runOperation(w, http.StatusCreated, "attach", req.Name, req.Values, "--name="+req.Name, skipCredentialResolution)
+}
+
+func (s *server) detachCluster(w http.ResponseWriter, name string) {
+	values := map[string]interface{}{"managedClusterName": name}
+	s.runOperation(w, http.StatusOK, "detach", name, values, "--name="+name, "--yes", skipCredentialResolution)
+}
+
+// runOperation runs the cluster command of the verb with the values in a new command tree
+func (s *server) runOperation(w http.ResponseWriter, status int, verb, clusterName string, values map[string]interface{}, args ...string) {
+	valuesFile, err := helpers.WriteValuesFile(values)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer os.Remove(valuesFile)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	defer clients.ReleaseFactories()
+	defer saveGlobalFlags().restore()
+
+	out := &bytes.Buffer{}
+	cmd := s.newVerb(verb, genericclioptions.IOStreams{In: &bytes.Buffer{}, Out: out, ErrOut: out})
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SilenceErrors = true
+	cmd.SetArgs(append(append([]string{"cluster", "--values=" + valuesFile}, args...), s.configArgs...))
+	resp := operationResponse{Cluster: clusterName, Operation: verb}
+	//Each operation has its own trace, summarized in its output, so the trace of serve does not grow with the requests
+	trace := tracing.Current
+	tracing.Start("serve " + verb)
+	err = cmd.ExecuteContext(s.ctx)
+	tracing.Finish(out, err)
+	tracing.Current = trace
+	resp.Output = out.String()
+	if err != nil {
+		resp.Error = err.Error()
+		writeJSON(w, http.StatusInternalServerError, resp)
+		return
+	}
+	writeJSON(w, status, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
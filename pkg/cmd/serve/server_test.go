@@ -0,0 +1,276 @@
+// Copyright Contributors to the Open Cluster Management project
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/tracing"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// newCluster returns a joined dev cluster with the availability
+func newCluster(name, available string) *unstructured.Unstructured {
+	return fake.NewManagedCluster(name, fake.WithLabels(map[string]string{"env": "dev"}), fake.WithConditions(
+		fake.NewCondition("ManagedClusterJoined", "True"),
+		fake.NewCondition("ManagedClusterConditionAvailable", available)))
+}
+
+// newFakeVerb returns a verb whose cluster command prints its arguments and the values file
+func newFakeVerb(fail bool) VerbFactory {
+	return func(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+		cmd := &cobra.Command{Use: verb}
+		var values, name string
+		var yes, skipCredentialResolution bool
+		cluster := &cobra.Command{
+			Use: "cluster",
+			RunE: func(c *cobra.Command, args []string) error {
+				b, err := ioutil.ReadFile(values)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(streams.Out, "%s %s yes=%v skip-credential-resolution=%v\n%s", verb, name, yes, skipCredentialResolution, string(b))
+				if fail {
+					return fmt.Errorf("%s failed", verb)
+				}
+				return nil
+			},
+		}
+		cluster.Flags().StringVar(&values, "values", "", "")
+		cluster.Flags().StringVar(&name, "name", "", "")
+		cluster.Flags().BoolVar(&yes, "yes", false, "")
+		cluster.Flags().BoolVar(&skipCredentialResolution, "skip-credential-resolution", false, "")
+		cluster.Flags().String("kubeconfig", "", "")
+		cmd.AddCommand(cluster)
+		return cmd
+	}
+}
+
+func newTestServer(fail bool) *server {
+	return &server{
+		client:     fake.NewClient(newCluster("cluster2", "Unknown"), newCluster("cluster1", "True")),
+		token:      "secret",
+		newVerb:    newFakeVerb(fail),
+		configArgs: []string{"--kubeconfig=hub.kubeconfig"},
+		ctx:        context.TODO(),
+		log:        ioutil.Discard,
+	}
+}
+
+func TestServer_handler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		body       string
+		fail       bool
+		wantStatus int
+		wantBody   []string
+	}{
+		{
+			name:       "Health without token",
+			method:     http.MethodGet,
+			path:       "/healthz",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Failed, missing token",
+			method:     http.MethodGet,
+			path:       clustersPath,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Failed, invalid token",
+			method:     http.MethodGet,
+			path:       clustersPath,
+			token:      "wrong",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "List clusters",
+			method:     http.MethodGet,
+			path:       clustersPath,
+			token:      "secret",
+			wantStatus: http.StatusOK,
+			wantBody: []string{
+				`[{"name":"cluster1","labels":{"env":"dev"},"joined":"True","available":"True"},` +
+					`{"name":"cluster2","labels":{"env":"dev"},"joined":"True","available":"Unknown"}]`,
+			},
+		},
+		{
+			name:       "Get cluster",
+			method:     http.MethodGet,
+			path:       clustersPath + "/cluster2",
+			token:      "secret",
+			wantStatus: http.StatusOK,
+			wantBody:   []string{`"name":"cluster2"`, `"available":"Unknown"`},
+		},
+		{
+			name:       "Failed, cluster not found",
+			method:     http.MethodGet,
+			path:       clustersPath + "/cluster3",
+			token:      "secret",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Attach cluster",
+			method:     http.MethodPost,
+			path:       clustersPath,
+			token:      "secret",
+			body:       `{"name":"cluster3","values":{"server":"https://cluster3:6443","token":"t"}}`,
+			wantStatus: http.StatusCreated,
+			wantBody:   []string{"attach cluster3 yes=false skip-credential-resolution=true", "managedClusterName: cluster3", "server: https://cluster3:6443"},
+		},
+		{
+			name:       "Failed, attach with invalid name",
+			method:     http.MethodPost,
+			path:       clustersPath,
+			token:      "secret",
+			body:       `{"name":"Cluster_3"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Failed, attach command failed",
+			method:     http.MethodPost,
+			path:       clustersPath,
+			token:      "secret",
+			body:       `{"name":"cluster3"}`,
+			fail:       true,
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   []string{`"error":"attach failed"`},
+		},
+		{
+			name:       "Detach cluster",
+			method:     http.MethodDelete,
+			path:       clustersPath + "/cluster1",
+			token:      "secret",
+			wantStatus: http.StatusOK,
+			wantBody:   []string{"detach cluster1 yes=true skip-credential-resolution=true", "managedClusterName: cluster1"},
+		},
+		{
+			name:       "Failed, method not allowed",
+			method:     http.MethodPut,
+			path:       clustersPath + "/cluster1",
+			token:      "secret",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			newTestServer(tt.fail).handler().ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			body := rec.Body.String()
+			//The output of the operations is escaped in a JSON string
+			resp := operationResponse{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err == nil {
+				body += resp.Output
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("body must contain %s, got %s", want, rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr bool
+	}{
+		{
+			name:    "Success",
+			options: Options{tokenFile: "token.txt", token: "secret"},
+		},
+		{
+			name:    "Success, TLS",
+			options: Options{tokenFile: "token.txt", token: "secret", tlsCertFile: "tls.crt", tlsKeyFile: "tls.key"},
+		},
+		{
+			name:    "Failed, no token file",
+			options: Options{},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, empty token",
+			options: Options{tokenFile: "token.txt"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, certificate without key",
+			options: Options{tokenFile: "token.txt", token: "secret", tlsCertFile: "tls.crt"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServer_runOperation_globalFlags(t *testing.T) {
+	s := newTestServer(false)
+	//The operation changes the global flags, as a command parsing -q or --qps would
+	s.newVerb = func(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+		cmd := newFakeVerb(false)(verb, streams)
+		cluster, _, _ := cmd.Find([]string{"cluster"})
+		run := cluster.RunE
+		cluster.RunE = func(c *cobra.Command, args []string) error {
+			printers.Quiet = true
+			clients.QPS = 1
+			return run(c, args)
+		}
+		return cmd
+	}
+	quiet, qps := printers.Quiet, clients.QPS
+	req := httptest.NewRequest(http.MethodDelete, clustersPath+"/cluster1", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	s.handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if printers.Quiet != quiet || clients.QPS != qps {
+		t.Errorf("the global flags must be restored after the operation, got quiet=%v qps=%v", printers.Quiet, clients.QPS)
+	}
+}
+
+func TestServer_runOperation_trace(t *testing.T) {
+	defer func() { tracing.Enabled = false }()
+	tracing.Enabled = true
+	trace := tracing.Current
+	req := httptest.NewRequest(http.MethodDelete, clustersPath+"/cluster1", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	newTestServer(false).handler().ServeHTTP(rec, req)
+	if !strings.Contains(rec.Body.String(), "serve detach took") {
+		t.Errorf("the output must hold the trace of the operation, got %s", rec.Body.String())
+	}
+	if tracing.Current != trace {
+		t.Error("the trace of serve must be restored after the operation")
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+// importPhases are the managedcluster conditions reached during an import, from the last to the first
+var importPhases = []struct {
+	condition string
+	phase     string
+}{
+	{condition: "ManagedClusterConditionAvailable", phase: "Available"},
+	{condition: "ManagedClusterJoined", phase: "Joined"},
+	{condition: "HubAcceptedManagedCluster", phase: "Accepted"},
+}
+
+// ImportStatus is the import progress of a cluster
+type ImportStatus struct {
+	Operation *helpers.Operation `json:"operation,omitempty"`
+	Cluster   string             `json:"cluster"`
+	Phase     string             `json:"phase"`
+	Message   string             `json:"message,omitempty"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 0 {
+		o.name = args[0]
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.name == "" {
+		return fmt.Errorf("operation ID or cluster name is missing")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	op, err := helpers.GetOperation(o.ctx, client, o.name)
+	if err != nil {
+		return err
+	}
+	clusterName := o.name
+	if op != nil {
+		clusterName = op.Cluster
+	} else {
+		op, err = helpers.GetLastClusterOperation(o.ctx, client, clusterName)
+		if err != nil {
+			return err
+		}
+	}
+
+	status := &ImportStatus{
+		Operation: op,
+		Cluster:   clusterName,
+	}
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	err = client.Get(o.ctx, types.NamespacedName{Name: clusterName}, mc)
+	switch {
+	case errors.IsNotFound(err):
+		if op == nil {
+			return fmt.Errorf("no operation or cluster named %s found", o.name)
+		}
+		status.Phase = "Pending"
+		status.Message = "the managedcluster is not created yet"
+	case err != nil:
+		return err
+	default:
+		status.Phase, status.Message = getImportPhase(mc)
+	}
+
+	table := &printers.Table{
+		Headers: []string{"OPERATION", "CLUSTER", "AGE", "PHASE", "MESSAGE"},
+	}
+	opID, age := "", ""
+	if op != nil {
+		opID = op.ID
+		age = duration.HumanDuration(time.Since(op.StartTime))
+	}
+	table.AddRow(opID, status.Cluster, age, status.Phase, status.Message)
+	return o.printOptions.Print(o.Out, table, status)
+}
+
+// getImportPhase returns the last import phase reached by a managedcluster and its message
+func getImportPhase(mc *unstructured.Unstructured) (string, string) {
+	conditions, _, _ := unstructured.NestedSlice(mc.Object, "status", "conditions")
+	for _, p := range importPhases {
+		for _, ic := range conditions {
+			c, ok := ic.(map[string]interface{})
+			if !ok || c["type"] != p.condition || c["status"] != "True" {
+				continue
+			}
+			message, _, _ := unstructured.NestedString(c, "message")
+			return p.phase, message
+		}
+	}
+	return "Pending", "waiting for the hub to accept the cluster"
+}
@@ -0,0 +1,90 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newCluster returns a cluster with the conditions of the types, all True
+func newCluster(name string, conditionTypes ...string) *unstructured.Unstructured {
+	conditions := make([]map[string]interface{}, 0, len(conditionTypes))
+	for _, c := range conditionTypes {
+		conditions = append(conditions, fake.NewCondition(c, "True", fake.WithMessage(c+" message")))
+	}
+	return fake.NewManagedCluster(name, fake.WithConditions(conditions...))
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := crclientfake.NewFakeClient(
+		newCluster("joined", "HubAcceptedManagedCluster", "ManagedClusterJoined"),
+		newCluster("available", "HubAcceptedManagedCluster", "ManagedClusterJoined", "ManagedClusterConditionAvailable"),
+	)
+	op, err := helpers.NewOperation(context.TODO(), client, "attach", "pending")
+	if err != nil {
+		t.Fatal(err)
+	}
+	joinedOp, err := helpers.NewOperation(context.TODO(), client, "attach", "joined")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		arg      string
+		contains []string
+		wantErr  bool
+	}{
+		{
+			name:     "Operation, managedcluster not created",
+			arg:      op.ID,
+			contains: []string{op.ID, "pending", "Pending"},
+		},
+		{
+			name:     "Operation, joined",
+			arg:      joinedOp.ID,
+			contains: []string{joinedOp.ID, "Joined", "ManagedClusterJoined message"},
+		},
+		{
+			name:     "Cluster with operation",
+			arg:      "joined",
+			contains: []string{joinedOp.ID, "Joined"},
+		},
+		{
+			name:     "Cluster without operation",
+			arg:      "available",
+			contains: []string{"available", "Available"},
+		},
+		{
+			name:    "Not found",
+			arg:     "unknown",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				printOptions: printers.NewPrintOptions(),
+				name:         tt.arg,
+				IOStreams:    streams,
+			}
+			err := o.runWithClient(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %s, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
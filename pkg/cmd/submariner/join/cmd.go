@@ -0,0 +1,77 @@
+// Copyright Contributors to the Open Cluster Management project
+package join
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Connect the networks of two clusters of a clusterset
+%[1]s submariner join --clusterset myset --clusters cluster1,cluster2
+
+# Connect clusters with overlapping CIDRs and label their gateway nodes
+%[1]s submariner join --clusterset myset --clusters cluster1,cluster2 --globalnet \
+  --gateway-nodes cluster1=worker-1,cluster2=worker-0 \
+  --cluster-kubeconfigs cluster1=cluster1.kubeconfig,cluster2=cluster2.kubeconfig
+`
+
+const (
+	scenarioDirectory = "scenarios/submariner"
+)
+
+var valuesTemplatePath = filepath.Join(scenarioDirectory, "values-template.yaml")
+
+// valuesSchema defines the flag of each value of the values-template.yaml
+var valuesSchema = applierscenarios.ValuesSchema{
+	{Path: "submariner.clusterSet", Flag: "clusterset", Type: applierscenarios.StringValue, Usage: "The clusterset of the clusters, the submariner broker is created for the clusterset"},
+	{Path: "submariner.globalnet", Flag: "globalnet", Type: applierscenarios.BoolValue, Usage: "Enable the globalnet to connect clusters with overlapping CIDRs"},
+	{Path: "submariner.gateways", Flag: "gateways", Type: applierscenarios.IntValue, Usage: "The number of gateway nodes of each cluster"},
+	{Path: "submariner.cableDriver", Flag: "cable-driver", Type: applierscenarios.StringValue, Usage: "The cable driver of the tunnels between the gateways, libreswan, wireguard or vxlan"},
+}
+
+// NewCmd provides a cobra command deploying the submariner addon on the clusters of a clusterset
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "join",
+		Short: "Connect the networks of the clusters of a clusterset with submariner",
+		Long: "Create the submariner broker of the clusterset, then the SubmarinerConfig and the submariner addon of each cluster. " +
+			"The gateway nodes are prepared by the submariner addon with the cloud provider credentials, " +
+			"or labeled with --gateway-nodes when the kubeconfig of the cluster is given",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.SetUsageTemplate(applierscenarios.UsageTempate(cmd, valuesTemplatePath))
+	valuesSchema.AddFlags(cmd.Flags())
+	cmd.Flags().StringSliceVar(&o.clusters, "clusters", nil, "Names of the managed clusters to connect, they are added to the clusterset if they have none")
+	cmd.Flags().StringToStringVar(&o.gatewayNodes, "gateway-nodes", nil, "The node of a cluster to label as gateway, as cluster=node")
+	cmd.Flags().StringToStringVar(&o.kubeConfigPaths, "cluster-kubeconfigs", nil, "The kubeconfig file of a cluster used to label its gateway node, as cluster=path")
+
+	o.applierScenariosOptions.AddFlags(cmd.Flags())
+	o.applierScenariosOptions.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
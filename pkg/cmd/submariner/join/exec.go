@@ -0,0 +1,207 @@
+// Copyright Contributors to the Open Cluster Management project
+package join
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	"github.com/open-cluster-management/cm-cli/pkg/audit"
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/resources"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	//gatewayLabel marks the nodes running the submariner gateway
+	gatewayLabel = "submariner.io/gateway"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	o.values, err = o.applierScenariosOptions.ReadValuesWithDefaults(valuesTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	var flagSet *pflag.FlagSet
+	if cmd != nil {
+		flagSet = cmd.Flags()
+	}
+	if err := valuesSchema.MergeFlags(flagSet, o.values); err != nil {
+		return err
+	}
+	o.clusterSet = applierscenarios.GetString(o.values, "submariner.clusterSet")
+
+	o.spokeClients = map[string]kubernetes.Interface{}
+	for clusterName, path := range o.kubeConfigPaths {
+		b, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		config, err := clientcmd.RESTConfigFromKubeConfig(b)
+		if err != nil {
+			return fmt.Errorf("invalid kubeconfig %s of the cluster %s: %s", path, clusterName, err.Error())
+		}
+		config.Timeout = 10 * time.Second
+		//The gateway nodes of the cluster are labeled
+		audit.Enable(config, "")
+		o.spokeClients[clusterName], err = kubernetes.NewForConfig(config)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.clusterSet == "" {
+		return fmt.Errorf("the clusterset must be provided with --clusterset")
+	}
+	if len(o.clusters) == 0 {
+		return fmt.Errorf("the clusters must be provided with --clusters")
+	}
+	if o.applierScenariosOptions.OutFile != "" {
+		return fmt.Errorf("outFile is not supported, the clusters are changed on the hub")
+	}
+	clusters := map[string]bool{}
+	for _, clusterName := range o.clusters {
+		clusters[clusterName] = true
+	}
+	for clusterName := range o.gatewayNodes {
+		if !clusters[clusterName] {
+			return fmt.Errorf("the gateway node of %s is given but the cluster is not in --clusters", clusterName)
+		}
+		if _, ok := o.spokeClients[clusterName]; !ok {
+			return fmt.Errorf("the kubeconfig of %s is required to label its gateway node, use --cluster-kubeconfigs", clusterName)
+		}
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.applierScenariosOptions.ConfigFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	reporter := o.applierScenariosOptions.NewProgressReporter()
+
+	clusterSet := &unstructured.Unstructured{}
+	clusterSet.SetGroupVersionKind(helpers.ManagedClusterSetGVK)
+	err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterSet}, clusterSet)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("the clusterset %s does not exist", o.clusterSet)
+	}
+	if err != nil {
+		return err
+	}
+
+	//All clusters are checked first so nothing is changed if a cluster belongs to another clusterset
+	mcs := make([]*unstructured.Unstructured, 0, len(o.clusters))
+	for _, clusterName := range o.clusters {
+		mc := &unstructured.Unstructured{}
+		mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+		if err := client.Get(o.ctx, types.NamespacedName{Name: clusterName}, mc); err != nil {
+			return err
+		}
+		if set := mc.GetLabels()[helpers.ClusterSetLabel]; set != "" && set != o.clusterSet {
+			return fmt.Errorf("the cluster %s belongs to the clusterset %s", clusterName, set)
+		}
+		mcs = append(mcs, mc)
+	}
+
+	applyOptions := &appliercmd.Options{
+		ConfigFlags: o.applierScenariosOptions.ConfigFlags,
+
+		Timeout:   o.applierScenariosOptions.ApplierTimeout(),
+		Force:     o.applierScenariosOptions.Force,
+		Silent:    o.applierScenariosOptions.Silent,
+		IOStreams: o.applierScenariosOptions.IOStreams,
+	}
+	reader := resources.NewResourcesReader()
+
+	err = reporter.Step("apply", helpers.BrokerGVK.Kind+"/"+o.clusterSet+"-broker", func() error {
+		return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader, filepath.Join(scenarioDirectory, "hub", "broker"), o.values)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, mc := range mcs {
+		clusterName := mc.GetName()
+		if mc.GetLabels()[helpers.ClusterSetLabel] == "" {
+			err = reporter.Step("clusterset", helpers.ManagedClusterGVK.Kind+"/"+clusterName, func() error {
+				labels := mc.GetLabels()
+				if labels == nil {
+					labels = map[string]string{}
+				}
+				labels[helpers.ClusterSetLabel] = o.clusterSet
+				mc.SetLabels(labels)
+				return client.Update(o.ctx, mc)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		o.values["managedClusterName"] = clusterName
+		err = reporter.Step("apply", helpers.SubmarinerConfigGVK.Kind+"/"+clusterName, func() error {
+			return o.applierScenariosOptions.Apply(o.ctx, applyOptions, client, reader, filepath.Join(scenarioDirectory, "hub", "cluster"), o.values)
+		})
+		if err != nil {
+			return err
+		}
+
+		if node, ok := o.gatewayNodes[clusterName]; ok {
+			err = reporter.Step("label", clusterName+"/Node/"+node, func() error {
+				return labelGatewayNode(o.ctx, o.spokeClients[clusterName], node)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if !o.applierScenariosOptions.Silent {
+		fmt.Fprintf(printers.Messages(o.applierScenariosOptions.ErrOut), "The submariner addon is being deployed on %d clusters, use \"%s addon status --addon submariner\" to check it\n",
+			len(mcs), helpers.GetExampleHeader())
+	}
+	return nil
+}
+
+// labelGatewayNode labels the node of the managed cluster to run the submariner gateway
+func labelGatewayNode(ctx context.Context, kubeClient kubernetes.Interface, nodeName string) error {
+	node, err := kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if node.Labels[gatewayLabel] == "true" {
+		return nil
+	}
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+	node.Labels[gatewayLabel] = "true"
+	_, err = kubeClient.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,194 @@
+// Copyright Contributors to the Open Cluster Management project
+package join
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newValuesCmd(t *testing.T, args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	valuesSchema.AddFlags(cmd.Flags())
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func newClusterSet(name string) *unstructured.Unstructured {
+	cs := &unstructured.Unstructured{}
+	cs.SetGroupVersionKind(helpers.ManagedClusterSetGVK)
+	cs.SetName(name)
+	return cs
+}
+
+func getObject(client crclient.Client, obj *unstructured.Unstructured, name, namespace string) error {
+	return client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, obj)
+}
+
+func TestOptions_complete(t *testing.T) {
+	o := &Options{applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{}}
+	if err := o.complete(newValuesCmd(t, "--clusterset", "myset", "--globalnet"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if o.clusterSet != "myset" {
+		t.Errorf("clusterSet = %s, want myset", o.clusterSet)
+	}
+	if v := applierscenarios.GetString(o.values, "submariner.cableDriver"); v != "libreswan" {
+		t.Errorf("cableDriver = %s, want libreswan", v)
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			options: Options{
+				clusterSet:   "myset",
+				clusters:     []string{"cluster1"},
+				gatewayNodes: map[string]string{"cluster1": "worker-0"},
+				spokeClients: map[string]kubernetes.Interface{"cluster1": kubefake.NewSimpleClientset()},
+			},
+		},
+		{
+			name:    "Failed, no clusterset",
+			options: Options{clusters: []string{"cluster1"}},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, no clusters",
+			options: Options{clusterSet: "myset"},
+			wantErr: true,
+		},
+		{
+			name: "Failed, gateway node of another cluster",
+			options: Options{
+				clusterSet:   "myset",
+				clusters:     []string{"cluster1"},
+				gatewayNodes: map[string]string{"cluster2": "worker-0"},
+				spokeClients: map[string]kubernetes.Interface{"cluster2": kubefake.NewSimpleClientset()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed, gateway node without kubeconfig",
+			options: Options{
+				clusterSet:   "myset",
+				clusters:     []string{"cluster1"},
+				gatewayNodes: map[string]string{"cluster1": "worker-0"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := tt.options
+			o.applierScenariosOptions = &applierscenarios.ApplierScenariosOptions{}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		objs    []runtime.Object
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			objs: []runtime.Object{newClusterSet("myset"), fake.NewManagedCluster("cluster1", fake.WithClusterSet("myset")), fake.NewManagedCluster("cluster2", fake.WithClusterSet(""))},
+		},
+		{
+			name:    "Failed, clusterset not found",
+			objs:    []runtime.Object{fake.NewManagedCluster("cluster1", fake.WithClusterSet("myset")), fake.NewManagedCluster("cluster2", fake.WithClusterSet(""))},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, cluster of another clusterset",
+			objs:    []runtime.Object{newClusterSet("myset"), fake.NewManagedCluster("cluster1", fake.WithClusterSet("myset")), fake.NewManagedCluster("cluster2", fake.WithClusterSet("otherset"))},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(tt.objs...)
+			spokeClient := kubefake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}})
+			o := &Options{
+				applierScenariosOptions: &applierscenarios.ApplierScenariosOptions{
+					Timeout:   time.Second,
+					Silent:    true,
+					IOStreams: genericclioptions.NewTestIOStreamsDiscard(),
+				},
+				clusters:     []string{"cluster1", "cluster2"},
+				gatewayNodes: map[string]string{"cluster2": "worker-0"},
+			}
+			if err := o.complete(newValuesCmd(t, "--clusterset", "myset"), nil); err != nil {
+				t.Fatal(err)
+			}
+			o.spokeClients["cluster2"] = spokeClient
+			o.values["submariner"].(map[string]interface{})["credentialsSecret"] = "aws-creds"
+			err := o.runWithClient(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if getObject(client, &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "v1", "kind": "Namespace"}}, "myset-broker", "") == nil {
+					t.Error("nothing must be created when a cluster can not join")
+				}
+				return
+			}
+
+			broker := &unstructured.Unstructured{}
+			broker.SetGroupVersionKind(helpers.BrokerGVK)
+			if err := getObject(client, broker, "submariner-broker", "myset-broker"); err != nil {
+				t.Errorf("the broker must be created: %v", err)
+			}
+			for _, clusterName := range o.clusters {
+				config := &unstructured.Unstructured{}
+				config.SetGroupVersionKind(helpers.SubmarinerConfigGVK)
+				if err := getObject(client, config, "submariner", clusterName); err != nil {
+					t.Errorf("the SubmarinerConfig of %s must be created: %v", clusterName, err)
+				}
+				if secret, _, _ := unstructured.NestedString(config.Object, "spec", "credentialsSecret", "name"); secret != "aws-creds" {
+					t.Errorf("the SubmarinerConfig of %s must reference the credentials, got %s", clusterName, secret)
+				}
+				addon := &unstructured.Unstructured{}
+				addon.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK)
+				if err := getObject(client, addon, "submariner", clusterName); err != nil {
+					t.Errorf("the submariner addon of %s must be created: %v", clusterName, err)
+				}
+				mc := &unstructured.Unstructured{}
+				mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+				if err := getObject(client, mc, clusterName, ""); err != nil || mc.GetLabels()[helpers.ClusterSetLabel] != "myset" {
+					t.Errorf("the cluster %s must be in the clusterset, got %v", clusterName, mc.GetLabels())
+				}
+			}
+			node, err := spokeClient.CoreV1().Nodes().Get(context.TODO(), "worker-0", metav1.GetOptions{})
+			if err != nil || node.Labels[gatewayLabel] != "true" {
+				t.Errorf("the gateway node must be labeled, got %v", node.Labels)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright Contributors to the Open Cluster Management project
+package join
+
+import (
+	"context"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/applierscenarios"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Options struct {
+	applierScenariosOptions *applierscenarios.ApplierScenariosOptions
+	values                  map[string]interface{}
+	clusterSet              string
+	clusters                []string
+	//gatewayNodes maps a cluster to the node labeled as gateway
+	gatewayNodes map[string]string
+	//kubeConfigPaths maps a cluster to its kubeconfig file
+	kubeConfigPaths map[string]string
+	//spokeClients are the clients of the clusters having a gateway node to label
+	spokeClients map[string]kubernetes.Interface
+	ctx          context.Context
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		applierScenariosOptions: applierscenarios.NewApplierScenariosOptions(streams),
+		ctx:                     context.Background(),
+	}
+}
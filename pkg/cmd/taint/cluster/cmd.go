@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Cordon a cluster, the placements which do not tolerate the taint do not select it anymore
+%[1]s taint cluster mycluster maintenance=true:NoSelect
+
+# Keep the existing selections of the cluster but prevent new ones
+%[1]s taint cluster mycluster maintenance:NoSelectIfNew
+
+# Change the value of an existing taint
+%[1]s taint cluster mycluster maintenance=upgrade:NoSelect --overwrite
+
+# Remove the taint
+%[1]s untaint cluster mycluster maintenance
+`
+
+// NewCmd provides a cobra command adding taints on a managed cluster
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "cluster NAME KEY_1[=VAL_1]:EFFECT_1 ... KEY_N[=VAL_N]:EFFECT_N",
+		Short: "Taint a managed cluster",
+		Long: "Add taints on a managed cluster, the placements which do not tolerate the taints do not select it. " +
+			"The effects are NoSelect, PreferNoSelect and NoSelectIfNew.",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.overwrite, "overwrite", false, "If set, the existing taints can be given a new value")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
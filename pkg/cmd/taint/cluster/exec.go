@@ -0,0 +1,79 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) == 0 {
+		return fmt.Errorf("the cluster name is missing")
+	}
+	o.clusterName = args[0]
+	o.taints = make([]helpers.Taint, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		t, err := helpers.ParseTaint(arg)
+		if err != nil {
+			return err
+		}
+		o.taints = append(o.taints, t)
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if len(o.taints) == 0 {
+		return fmt.Errorf("at least one taint as KEY[=VALUE]:EFFECT is required")
+	}
+	seen := map[string]bool{}
+	for _, t := range o.taints {
+		id := t.Key + ":" + t.Effect
+		if seen[id] {
+			return fmt.Errorf("the taint %s is given more than once", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName}, mc); err != nil {
+		return err
+	}
+	changes, err := helpers.AddTaints(mc, o.taints, o.overwrite, o.now())
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintf(printers.Messages(o.ErrOut), "%s unchanged\n", o.clusterName)
+	} else {
+		if err := client.Update(o.ctx, mc); err != nil {
+			return err
+		}
+		fmt.Fprintf(printers.Messages(o.ErrOut), "%s tainted: %s\n", o.clusterName, strings.Join(changes, ", "))
+	}
+	printers.PrintIdentifier(o.Out, o.clusterName)
+	return nil
+}
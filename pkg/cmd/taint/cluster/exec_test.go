@@ -0,0 +1,104 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_complete(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{name: "Success", args: []string{"cluster1", "maintenance=true:NoSelect", "gpu:PreferNoSelect"}},
+		{name: "Failed, no taint", args: []string{"cluster1"}, wantErr: true},
+		{name: "Failed, invalid taint", args: []string{"cluster1", "maintenance=true"}, wantErr: true},
+		{name: "Failed, duplicated taint", args: []string{"cluster1", "maintenance=true:NoSelect", "maintenance=false:NoSelect"}, wantErr: true},
+		{name: "Failed, no cluster", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOptions(genericclioptions.IOStreams{})
+			err := o.complete(nil, tt.args)
+			if err == nil {
+				err = o.validate()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("complete() and validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	existing := map[string]interface{}{"key": "maintenance", "value": "true", "effect": helpers.TaintNoSelect, "timeAdded": "2021-05-01T12:00:00Z"}
+	tests := []struct {
+		name         string
+		args         []string
+		overwrite    bool
+		wantErr      bool
+		wantMessages string
+		wantTaints   int
+	}{
+		{
+			name:         "Success",
+			args:         []string{"cluster1", "gpu:PreferNoSelect"},
+			wantMessages: "cluster1 tainted: +gpu:PreferNoSelect",
+			wantTaints:   2,
+		},
+		{
+			name:         "Success, unchanged",
+			args:         []string{"cluster1", "maintenance=true:NoSelect"},
+			wantMessages: "cluster1 unchanged",
+			wantTaints:   1,
+		},
+		{
+			name:       "Failed, existing taint without overwrite",
+			args:       []string{"cluster1", "maintenance=false:NoSelect"},
+			wantErr:    true,
+			wantTaints: 1,
+		},
+		{
+			name:       "Failed, cluster not found",
+			args:       []string{"cluster2", "maintenance=false:NoSelect"},
+			wantErr:    true,
+			wantTaints: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(fake.NewManagedCluster("cluster1", fake.WithField([]interface{}{existing}, "spec", "taints")))
+			streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.overwrite = tt.overwrite
+			o.now = func() time.Time { return time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC) }
+			if err := o.complete(nil, tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := o.runWithClient(client); (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(errOut.String(), tt.wantMessages) {
+				t.Errorf("messages must contain %q, got %s", tt.wantMessages, errOut.String())
+			}
+			mc := &unstructured.Unstructured{}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, mc); err != nil {
+				t.Fatal(err)
+			}
+			if taints, _, _ := unstructured.NestedSlice(mc.Object, "spec", "taints"); len(taints) != tt.wantTaints {
+				t.Errorf("got taints %v, want %d taints", taints, tt.wantTaints)
+			}
+		})
+	}
+}
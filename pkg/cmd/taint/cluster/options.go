@@ -0,0 +1,35 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags *genericclioptions.ConfigFlags
+	clusterName string
+	overwrite   bool
+	//taints are parsed from the arguments
+	taints []helpers.Taint
+	//now is the timeAdded of the new taints
+	now func() time.Time
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		now:         time.Now,
+		ctx:         context.Background(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright Contributors to the Open Cluster Management project
+package telemetry
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Opt-in the anonymous usage metrics
+%[1]s telemetry on --endpoint https://metrics.example.com/cm
+
+# Opt-out the anonymous usage metrics
+%[1]s telemetry off
+
+# Show the telemetry configuration
+%[1]s telemetry status
+`
+
+// NewCmd provides a cobra command managing the opt-in anonymous usage metrics
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:     "telemetry",
+		Short:   "Manage the opt-in anonymous usage metrics (command name, duration, success/failure)",
+		Example: fmt.Sprintf(example, helpers.GetExampleHeader()),
+	}
+
+	on := &cobra.Command{
+		Use:          "on",
+		Short:        "Enable the anonymous usage metrics",
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.runOn()
+		},
+	}
+	on.Flags().StringVar(&o.endpoint, "endpoint", "", "The endpoint receiving the metrics, stored in the configuration file")
+
+	off := &cobra.Command{
+		Use:          "off",
+		Short:        "Disable the anonymous usage metrics",
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.runOff()
+		},
+	}
+
+	status := &cobra.Command{
+		Use:          "status",
+		Short:        "Show the telemetry configuration",
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.runStatus()
+		},
+	}
+
+	cmd.AddCommand(on, off, status)
+
+	return cmd
+}
@@ -0,0 +1,72 @@
+// Copyright Contributors to the Open Cluster Management project
+package telemetry
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/open-cluster-management/cm-cli/pkg/config"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+)
+
+func (o *Options) loadConfig() (*config.Config, string, error) {
+	path := o.configPath
+	if path == "" {
+		var err error
+		path, err = config.DefaultPath()
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	c, err := config.Load(path)
+	return c, path, err
+}
+
+func (o *Options) runOn() error {
+	c, path, err := o.loadConfig()
+	if err != nil {
+		return err
+	}
+	if o.endpoint != "" {
+		u, err := url.Parse(o.endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid endpoint %s, an http or https url is expected", o.endpoint)
+		}
+		c.Telemetry.Endpoint = o.endpoint
+	}
+	if c.Telemetry.Endpoint == "" {
+		return fmt.Errorf("no telemetry endpoint configured, use --endpoint")
+	}
+	c.Telemetry.Enabled = true
+	if err := c.Save(path); err != nil {
+		return err
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "telemetry enabled, metrics are sent to %s\n", c.Telemetry.Endpoint)
+	return nil
+}
+
+func (o *Options) runOff() error {
+	c, path, err := o.loadConfig()
+	if err != nil {
+		return err
+	}
+	c.Telemetry.Enabled = false
+	if err := c.Save(path); err != nil {
+		return err
+	}
+	fmt.Fprintln(printers.Messages(o.ErrOut), "telemetry disabled")
+	return nil
+}
+
+func (o *Options) runStatus() error {
+	c, path, err := o.loadConfig()
+	if err != nil {
+		return err
+	}
+	status := "off"
+	if c.Telemetry.Enabled {
+		status = "on"
+	}
+	fmt.Fprintf(o.Out, "telemetry: %s\nendpoint: %s\nconfiguration: %s\n", status, c.Telemetry.Endpoint, path)
+	return nil
+}
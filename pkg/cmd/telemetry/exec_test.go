@@ -0,0 +1,66 @@
+// Copyright Contributors to the Open Cluster Management project
+package telemetry
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/config"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_run(t *testing.T) {
+	dir, err := ioutil.TempDir("", "telemetry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	configPath := filepath.Join(dir, "config.yaml")
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := &Options{
+		configPath: configPath,
+		IOStreams:  streams,
+	}
+
+	if err := o.runOn(); err == nil {
+		t.Error("expected an error without endpoint")
+	}
+
+	o.endpoint = "not-an-url"
+	if err := o.runOn(); err == nil {
+		t.Error("expected an error with an invalid endpoint")
+	}
+
+	o.endpoint = "https://metrics.example.com/cm"
+	if err := o.runOn(); err != nil {
+		t.Fatal(err)
+	}
+	c, err := config.Load(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Telemetry.Enabled || c.Telemetry.Endpoint != o.endpoint {
+		t.Errorf("expected telemetry enabled with endpoint %s got %v", o.endpoint, c.Telemetry)
+	}
+
+	if err := o.runOff(); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := o.runStatus(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "telemetry: off") || !strings.Contains(out.String(), o.endpoint) {
+		t.Errorf("unexpected status:\n%s", out.String())
+	}
+
+	//The endpoint is kept when enabling again
+	o.endpoint = ""
+	if err := o.runOn(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright Contributors to the Open Cluster Management project
+package telemetry
+
+import (
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//configPath is the configuration file, the default one is used if empty
+	configPath string
+	endpoint   string
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: streams,
+	}
+}
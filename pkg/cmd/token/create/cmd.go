@@ -0,0 +1,52 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Create a bootstrap token valid 2 hours to join the hub
+%[1]s token create --ttl 2h
+
+# Create a bootstrap token with a description
+%[1]s token create --ttl 30m --description "join mycluster"
+`
+
+// NewCmd provides a cobra command creating a short-lived bootstrap token to join the hub
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "create",
+		Short:        "Create a short-lived bootstrap token to join the hub",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	helpers.DurationVar(cmd.Flags(), &o.ttl, "ttl", 2*time.Hour, "The duration after which the token expires, e.g. 2h")
+	cmd.Flags().StringVar(&o.description, "description", "", "The description of the token")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,59 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	//The api server of the hub is printed in the join command
+	config, err := o.configFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	o.hubAPIServer = config.Host
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.ttl <= 0 {
+		return fmt.Errorf("ttl must be greater than 0")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	token, err := helpers.CreateBootstrapToken(o.ctx, client, o.ttl, o.description, o.now())
+	if err != nil {
+		return err
+	}
+	//A token the hub does not authenticate is deleted rather than failing later in the join
+	if err := helpers.CheckBootstrapTokenAuth(o.ctx, client, token, o.pollInterval, o.authTimeout); err != nil {
+		id, _ := helpers.ParseBootstrapToken(token)
+		if derr := helpers.DeleteBootstrapToken(o.ctx, client, id); derr != nil {
+			return fmt.Errorf("%s, the token %s was not deleted: %s", err.Error(), id, derr.Error())
+		}
+		return err
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "The token expires in %s, join the hub from the managed cluster with:\n%s join hub --cluster-name <cluster name> --hub-apiserver %s --bootstrap-token %s\n",
+		o.ttl, helpers.GetExampleHeader(), o.hubAPIServer, token)
+	_, err = fmt.Fprintln(o.Out, token)
+	return err
+}
@@ -0,0 +1,74 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		wantErr bool
+	}{
+		{name: "Success", ttl: 2 * time.Hour},
+		{name: "Failed, no ttl", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{ttl: tt.ttl}
+			if err := o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := &fake.TokenReviewClient{Client: fake.NewClient(), Authenticated: true}
+	streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	o.ttl = 30 * time.Minute
+	o.hubAPIServer = "https://hub:6443"
+	o.ctx = context.TODO()
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	token := strings.TrimSpace(out.String())
+	if _, err := helpers.ParseBootstrapToken(token); err != nil {
+		t.Fatalf("the standard output must only contain the token, got %s: %v", out.String(), err)
+	}
+	if !strings.Contains(errOut.String(), "--hub-apiserver https://hub:6443 --bootstrap-token "+token) {
+		t.Errorf("the join command must be printed, got %s", errOut.String())
+	}
+	tokens, err := helpers.ListBootstrapTokens(context.TODO(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 {
+		t.Errorf("got %d tokens, want 1", len(tokens))
+	}
+}
+
+func TestOptions_runWithClient_authDisabled(t *testing.T) {
+	client := &fake.TokenReviewClient{Client: fake.NewClient()}
+	o := newOptions(genericclioptions.NewTestIOStreamsDiscard())
+	o.ttl = 30 * time.Minute
+	o.ctx = context.TODO()
+	o.pollInterval = time.Millisecond
+	o.authTimeout = 10 * time.Millisecond
+	err := o.runWithClient(client)
+	if err == nil || !strings.Contains(err.Error(), "bootstrap token authenticator") {
+		t.Fatalf("runWithClient() must report the disabled authenticator, got %v", err)
+	}
+	if tokens, _ := helpers.ListBootstrapTokens(context.TODO(), client); len(tokens) != 0 {
+		t.Errorf("the token must be deleted, got %d tokens", len(tokens))
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright Contributors to the Open Cluster Management project
+package create
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags  *genericclioptions.ConfigFlags
+	ttl          time.Duration
+	description  string
+	hubAPIServer string
+	now          func() time.Time
+	//authTimeout bounds the check that the hub api server authenticates the token
+	authTimeout  time.Duration
+	pollInterval time.Duration
+	//ctx is canceled on Ctrl+C to abort the calls to the hub
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		now:          time.Now,
+		authTimeout:  10 * time.Second,
+		pollInterval: time.Second,
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+package delete
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	o.ids = make([]string, 0, len(args))
+	for _, arg := range args {
+		//The full token is accepted, only its id identifies it
+		if strings.Contains(arg, ".") {
+			id, err := helpers.ParseBootstrapToken(arg)
+			if err != nil {
+				return err
+			}
+			arg = id
+		}
+		o.ids = append(o.ids, arg)
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if len(o.ids) == 0 {
+		return fmt.Errorf("the id of the token to delete is missing")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	for _, id := range o.ids {
+		if err := helpers.DeleteBootstrapToken(o.ctx, client, id); err != nil {
+			return err
+		}
+		fmt.Fprintf(printers.Messages(o.ErrOut), "Bootstrap token %s deleted\n", id)
+		printers.PrintIdentifier(o.Out, id)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright Contributors to the Open Cluster Management project
+package delete
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_complete(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantIDs []string
+		wantErr bool
+	}{
+		{name: "Success, ids", args: []string{"abc123", "def456"}, wantIDs: []string{"abc123", "def456"}},
+		{name: "Success, full token", args: []string{"abc123.0123456789abcdef"}, wantIDs: []string{"abc123"}},
+		{name: "Failed, invalid token", args: []string{"abc123.secret"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{}
+			err := o.complete(nil, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("complete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(o.ids) != len(tt.wantIDs) {
+				t.Fatalf("complete() ids = %v, want %v", o.ids, tt.wantIDs)
+			}
+			for i := range o.ids {
+				if o.ids[i] != tt.wantIDs[i] {
+					t.Errorf("complete() ids = %v, want %v", o.ids, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := fake.NewClient()
+	token, err := helpers.CreateBootstrapToken(context.TODO(), client, time.Hour, "", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	if err := o.complete(nil, []string{token}); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.runWithClient(client); err != nil {
+		t.Fatal(err)
+	}
+	if tokens, _ := helpers.ListBootstrapTokens(context.TODO(), client); len(tokens) != 0 {
+		t.Errorf("the token must be deleted, got %v", tokens)
+	}
+	if err := o.runWithClient(client); err == nil {
+		t.Error("runWithClient() expected an error for a deleted token")
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright Contributors to the Open Cluster Management project
+package delete
+
+import (
+	"context"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags *genericclioptions.ConfigFlags
+	ids         []string
+	//ctx is canceled on Ctrl+C to abort the calls to the hub
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+
+		IOStreams: streams,
+	}
+}
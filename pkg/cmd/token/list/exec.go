@@ -0,0 +1,54 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	return nil
+}
+
+func (o *Options) validate() error {
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	tokens, err := helpers.ListBootstrapTokens(o.ctx, client)
+	if err != nil {
+		return err
+	}
+	table := &printers.Table{
+		Headers: []string{"ID", "EXPIRATION", "TTL", "DESCRIPTION"},
+	}
+	now := o.now()
+	for _, t := range tokens {
+		expiration, ttl := "<never>", "<forever>"
+		if !t.Expiration.IsZero() {
+			expiration = t.Expiration.Format(time.RFC3339)
+			ttl = "expired"
+			if t.Expiration.After(now) {
+				ttl = t.Expiration.Sub(now).Round(time.Second).String()
+			}
+		}
+		table.AddRow(t.ID, expiration, ttl, t.Description)
+	}
+	return o.printOptions.Print(o.Out, table, tokens)
+}
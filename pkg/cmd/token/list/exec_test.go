@@ -0,0 +1,69 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_runWithClient(t *testing.T) {
+	client := fake.NewClient()
+	now := time.Date(2021, 4, 1, 10, 0, 0, 0, time.UTC)
+	valid, err := helpers.CreateBootstrapToken(context.TODO(), client, 2*time.Hour, "join mycluster", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := helpers.CreateBootstrapToken(context.TODO(), client, time.Minute, "", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validID, _ := helpers.ParseBootstrapToken(valid)
+	expiredID, _ := helpers.ParseBootstrapToken(expired)
+
+	tests := []struct {
+		name        string
+		output      string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "Table",
+			contains: []string{validID, "2h0m0s", "join mycluster", expiredID, "expired"},
+		},
+		{
+			name:        "Json",
+			output:      "json",
+			contains:    []string{validID, expiredID},
+			notContains: []string{valid, expired},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.printOptions = &printers.PrintOptions{OutputFormat: tt.output}
+			o.now = func() time.Time { return now }
+			if err := o.runWithClient(client); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %s, got:\n%s", c, out.String())
+				}
+			}
+			//The secrets of the tokens are never printed
+			for _, c := range tt.notContains {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %s, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/troubleshoot"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	//leaseName is the lease renewed by the registration agent on the hub
+	leaseName = "managed-cluster-lease"
+	//defaultLeaseDurationSeconds is the lease duration of the registration agent when the cluster does not set one
+	defaultLeaseDurationSeconds = 60
+	//leaseGraceFactor is the number of lease durations after which the hub considers the cluster unknown
+	leaseGraceFactor = 5
+	agentNamespace   = "open-cluster-management-agent"
+)
+
+// clusterConditions are the conditions of a healthy ManagedCluster with the remediation if they are not true
+var clusterConditions = []struct {
+	conditionType string
+	remediation   string
+}{
+	{
+		conditionType: "HubAcceptedManagedCluster",
+		remediation:   "accept the cluster by setting spec.hubAcceptsClient to true on the ManagedCluster",
+	},
+	{
+		conditionType: "ManagedClusterJoined",
+		remediation:   "approve the pending certificate signing requests of the cluster and check the registration agent on the managed cluster",
+	},
+	{
+		conditionType: "ManagedClusterConditionAvailable",
+		remediation:   "check the klusterlet agents on the managed cluster and their connectivity to the hub",
+	},
+}
+
+func checkManagedClusterExists(ctx context.Context, client crclient.Client, clusterName string) (*unstructured.Unstructured, []troubleshoot.Finding, error) {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	err := client.Get(ctx, types.NamespacedName{Name: clusterName}, mc)
+	switch {
+	case errors.IsNotFound(err):
+		return nil, []troubleshoot.Finding{{
+			Severity:    troubleshoot.SeverityCritical,
+			Check:       "ManagedCluster",
+			Message:     fmt.Sprintf("the managed cluster %s does not exist", clusterName),
+			Remediation: fmt.Sprintf("attach the cluster with %s attach cluster", helpers.GetExampleHeader()),
+		}}, nil
+	case err != nil:
+		return nil, nil, err
+	}
+	return mc, nil, nil
+}
+
+func checkConditions(mc *unstructured.Unstructured) []troubleshoot.Finding {
+	findings := make([]troubleshoot.Finding, 0)
+	conditions, _, _ := unstructured.NestedSlice(mc.Object, "status", "conditions")
+	for _, c := range clusterConditions {
+		status := helpers.GetConditionStatus(conditions, c.conditionType)
+		if status == "True" {
+			continue
+		}
+		if status == "" {
+			status = "missing"
+		}
+		findings = append(findings, troubleshoot.Finding{
+			Severity:    troubleshoot.SeverityCritical,
+			Message:     fmt.Sprintf("condition %s is %s", c.conditionType, status),
+			Remediation: c.remediation,
+		})
+		//The next conditions depend on this one
+		break
+	}
+	return findings
+}
+
+func checkLease(ctx context.Context, client crclient.Client, clusterName string, now time.Time) ([]troubleshoot.Finding, error) {
+	lease := &coordinationv1.Lease{}
+	err := client.Get(ctx, types.NamespacedName{Name: leaseName, Namespace: clusterName}, lease)
+	if errors.IsNotFound(err) {
+		return []troubleshoot.Finding{{
+			Severity:    troubleshoot.SeverityWarning,
+			Message:     fmt.Sprintf("the lease %s/%s does not exist", clusterName, leaseName),
+			Remediation: "the lease is created once the cluster joined, check the registration agent on the managed cluster",
+		}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	duration := int32(defaultLeaseDurationSeconds)
+	if lease.Spec.LeaseDurationSeconds != nil && *lease.Spec.LeaseDurationSeconds > 0 {
+		duration = *lease.Spec.LeaseDurationSeconds
+	}
+	if lease.Spec.RenewTime == nil {
+		return []troubleshoot.Finding{{
+			Severity:    troubleshoot.SeverityCritical,
+			Message:     "the lease was never renewed",
+			Remediation: "check the registration agent on the managed cluster",
+		}}, nil
+	}
+	age := now.Sub(lease.Spec.RenewTime.Time)
+	if age > time.Duration(leaseGraceFactor*duration)*time.Second {
+		return []troubleshoot.Finding{{
+			Severity:    troubleshoot.SeverityCritical,
+			Message:     fmt.Sprintf("the lease was last renewed %s ago", age.Round(time.Second)),
+			Remediation: "check the registration agent on the managed cluster and its connectivity to the hub",
+		}}, nil
+	}
+	return nil, nil
+}
+
+func checkCSRs(ctx context.Context, client crclient.Client, clusterName string) ([]troubleshoot.Finding, error) {
+	csrs := &certificatesv1.CertificateSigningRequestList{}
+	if err := client.List(ctx, csrs, crclient.MatchingLabels{helpers.ClusterNameLabel: clusterName}); err != nil {
+		return nil, err
+	}
+	findings := make([]troubleshoot.Finding, 0)
+	for _, csr := range csrs.Items {
+		approved, denied := false, false
+		for _, c := range csr.Status.Conditions {
+			switch c.Type {
+			case certificatesv1.CertificateApproved:
+				approved = true
+			case certificatesv1.CertificateDenied:
+				denied = true
+			}
+		}
+		switch {
+		case denied:
+			findings = append(findings, troubleshoot.Finding{
+				Severity:    troubleshoot.SeverityWarning,
+				Message:     fmt.Sprintf("the certificate signing request %s was denied", csr.Name),
+				Remediation: "the registration agent creates a new request, approve it",
+			})
+		case !approved:
+			findings = append(findings, troubleshoot.Finding{
+				Severity:    troubleshoot.SeverityCritical,
+				Message:     fmt.Sprintf("the certificate signing request %s is pending", csr.Name),
+				Remediation: fmt.Sprintf("approve it with kubectl certificate approve %s", csr.Name),
+			})
+		case len(csr.Status.Certificate) == 0:
+			findings = append(findings, troubleshoot.Finding{
+				Severity:    troubleshoot.SeverityWarning,
+				Message:     fmt.Sprintf("the certificate signing request %s is approved but not issued", csr.Name),
+				Remediation: "check the certificate signer of the hub",
+			})
+		}
+	}
+	return findings, nil
+}
+
+func checkImportSecret(ctx context.Context, client crclient.Client, clusterName string) ([]troubleshoot.Finding, error) {
+	_, err := helpers.GetImportSecret(ctx, client, clusterName)
+	if errors.IsNotFound(err) {
+		return []troubleshoot.Finding{{
+			Severity:    troubleshoot.SeverityWarning,
+			Message:     fmt.Sprintf("the import secret %s-import does not exist", clusterName),
+			Remediation: "check the managedcluster-import-controller on the hub",
+		}}, nil
+	}
+	return nil, err
+}
+
+func checkAddons(ctx context.Context, client crclient.Client, clusterName string) ([]troubleshoot.Finding, error) {
+	addons := &unstructured.UnstructuredList{}
+	addons.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK.GroupVersion().WithKind(helpers.ManagedClusterAddOnGVK.Kind + "List"))
+	if err := client.List(ctx, addons, crclient.InNamespace(clusterName)); err != nil {
+		return nil, err
+	}
+	findings := make([]troubleshoot.Finding, 0)
+	for _, addon := range addons.Items {
+		conditions, _, _ := unstructured.NestedSlice(addon.Object, "status", "conditions")
+		if status := helpers.GetConditionStatus(conditions, "Available"); status != "True" {
+			if status == "" {
+				status = "missing"
+			}
+			findings = append(findings, troubleshoot.Finding{
+				Severity:    troubleshoot.SeverityWarning,
+				Message:     fmt.Sprintf("the addon %s availability is %s", addon.GetName(), status),
+				Remediation: fmt.Sprintf("check the %s agent in the %s namespace of the managed cluster", addon.GetName(), helpers.AddonInstallNamespace),
+			})
+		}
+	}
+	return findings, nil
+}
+
+// checkAgents reports the pods of the klusterlet and of the addons which are not ready on the managed cluster
+func checkAgents(ctx context.Context, kubeClient kubernetes.Interface) ([]troubleshoot.Finding, error) {
+	findings := make([]troubleshoot.Finding, 0)
+	for _, ns := range []struct {
+		name     string
+		severity troubleshoot.Severity
+	}{
+		{name: agentNamespace, severity: troubleshoot.SeverityCritical},
+		{name: helpers.AddonInstallNamespace, severity: troubleshoot.SeverityWarning},
+	} {
+		pods, err := kubeClient.CoreV1().Pods(ns.name).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return findings, err
+		}
+		if ns.name == agentNamespace && len(pods.Items) == 0 {
+			findings = append(findings, troubleshoot.Finding{
+				Severity:    ns.severity,
+				Message:     fmt.Sprintf("no klusterlet agent is running in %s", agentNamespace),
+				Remediation: "apply the import secret of the cluster on the managed cluster",
+			})
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if problem := helpers.PodProblem(pod); problem != "" {
+				findings = append(findings, troubleshoot.Finding{
+					Severity:    ns.severity,
+					Message:     fmt.Sprintf("pod %s/%s is %s", pod.Namespace, pod.Name, problem),
+					Remediation: fmt.Sprintf("check the logs with kubectl logs -n %s %s", pod.Namespace, pod.Name),
+				})
+			}
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,51 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Diagnose a managed cluster from the hub
+%[1]s troubleshoot cluster mycluster
+
+# Also inspect the klusterlet and addon agents on the managed cluster
+%[1]s troubleshoot cluster mycluster --cluster-kubeconfig mycluster.kubeconfig
+`
+
+// NewCmd provides a cobra command diagnosing a managed cluster
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "cluster <name>",
+		Short:        "Diagnose a managed cluster and suggest remediations",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.kubeConfigPath, "cluster-kubeconfig", "", "Kubeconfig of the managed cluster, used to inspect the agents running on it")
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
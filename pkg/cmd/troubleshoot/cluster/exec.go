@@ -0,0 +1,105 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/open-cluster-management/cm-cli/pkg/troubleshoot"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) > 0 {
+		o.clusterName = args[0]
+	}
+	if o.kubeConfigPath == "" {
+		return nil
+	}
+	b, err := ioutil.ReadFile(filepath.Clean(o.kubeConfigPath))
+	if err != nil {
+		return err
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(b)
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig %s: %s", o.kubeConfigPath, err.Error())
+	}
+	config.Timeout = 10 * time.Second
+	o.spokeClient, err = kubernetes.NewForConfig(config)
+	return err
+}
+
+func (o *Options) validate() error {
+	if o.clusterName == "" {
+		return fmt.Errorf("the name of the cluster to diagnose is missing")
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	findings, err := o.diagnose(client)
+	if err != nil {
+		return err
+	}
+	if len(findings) == 0 && o.printOptions.OutputFormat == printers.OutputTable {
+		fmt.Fprintf(o.Out, "No problem found on cluster %s\n", o.clusterName)
+		return nil
+	}
+	table := &printers.Table{
+		Headers: []string{"SEVERITY", "CHECK", "FINDING", "REMEDIATION"},
+	}
+	for _, f := range findings {
+		table.AddRow(f.Severity.String(), f.Check, f.Message, f.Remediation)
+	}
+	return o.printOptions.Print(o.Out, table, findings)
+}
+
+// diagnose runs the checks of the cluster, the other checks are skipped if the cluster does not exist
+func (o *Options) diagnose(client crclient.Client) ([]troubleshoot.Finding, error) {
+	mc, findings, err := checkManagedClusterExists(o.ctx, client, o.clusterName)
+	if err != nil || mc == nil {
+		return findings, err
+	}
+	checks := []troubleshoot.Check{
+		troubleshoot.NewCheck("ManagedCluster conditions", func() ([]troubleshoot.Finding, error) {
+			return checkConditions(mc), nil
+		}),
+		troubleshoot.NewCheck("Lease", func() ([]troubleshoot.Finding, error) {
+			return checkLease(o.ctx, client, o.clusterName, time.Now())
+		}),
+		troubleshoot.NewCheck("Certificate signing requests", func() ([]troubleshoot.Finding, error) {
+			return checkCSRs(o.ctx, client, o.clusterName)
+		}),
+		troubleshoot.NewCheck("Import secret", func() ([]troubleshoot.Finding, error) {
+			return checkImportSecret(o.ctx, client, o.clusterName)
+		}),
+		troubleshoot.NewCheck("Addons", func() ([]troubleshoot.Finding, error) {
+			return checkAddons(o.ctx, client, o.clusterName)
+		}),
+	}
+	if o.spokeClient != nil {
+		checks = append(checks, troubleshoot.NewCheck("Agents", func() ([]troubleshoot.Finding, error) {
+			return checkAgents(o.ctx, o.spokeClient)
+		}))
+	}
+	return troubleshoot.Run(checks), nil
+}
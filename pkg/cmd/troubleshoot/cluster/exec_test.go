@@ -0,0 +1,172 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newLease(namespace string, renewTime time.Time) *coordinationv1.Lease {
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: leaseName, Namespace: namespace},
+		Spec: coordinationv1.LeaseSpec{
+			RenewTime: &metav1.MicroTime{Time: renewTime},
+		},
+	}
+}
+
+func newAddon(namespace, name, available string) *unstructured.Unstructured {
+	addon := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"conditions": []interface{}{fake.NewCondition("Available", available)},
+			},
+		},
+	}
+	addon.SetGroupVersionKind(helpers.ManagedClusterAddOnGVK)
+	addon.SetNamespace(namespace)
+	addon.SetName(name)
+	return addon
+}
+
+func newPod(namespace, name string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	available := []map[string]interface{}{
+		fake.NewCondition("HubAcceptedManagedCluster", "True"),
+		fake.NewCondition("ManagedClusterJoined", "True"),
+		fake.NewCondition("ManagedClusterConditionAvailable", "True"),
+	}
+	tests := []struct {
+		name         string
+		clusterName  string
+		objs         []runtime.Object
+		spokeClient  kubernetes.Interface
+		outputFormat string
+		wantErr      bool
+		contains     []string
+		notContains  []string
+	}{
+		{
+			name:        "Success, healthy cluster",
+			clusterName: "cluster1",
+			objs: []runtime.Object{
+				fake.NewManagedCluster("cluster1", fake.WithConditions(available...)),
+				newLease("cluster1", time.Now()),
+				fake.NewImportSecret("cluster1", nil),
+				newAddon("cluster1", "work-manager", "True"),
+			},
+			spokeClient: kubefake.NewSimpleClientset(newPod(agentNamespace, "klusterlet-registration-agent", corev1.PodRunning)),
+			contains:    []string{"No problem found on cluster cluster1"},
+		},
+		{
+			name:        "Success, cluster not found",
+			clusterName: "cluster1",
+			contains:    []string{"critical", "the managed cluster cluster1 does not exist"},
+			notContains: []string{"Lease"},
+		},
+		{
+			name:        "Success, pending csr and expired lease",
+			clusterName: "cluster1",
+			objs: []runtime.Object{
+				fake.NewManagedCluster("cluster1", fake.WithConditions(fake.NewCondition("HubAcceptedManagedCluster", "True"))),
+				newLease("cluster1", time.Now().Add(-time.Hour)),
+				&certificatesv1.CertificateSigningRequest{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "cluster1-abcde",
+						Labels: map[string]string{helpers.ClusterNameLabel: "cluster1"},
+					},
+				},
+				newAddon("cluster1", "work-manager", "False"),
+			},
+			contains: []string{
+				"condition ManagedClusterJoined is missing",
+				"kubectl certificate approve cluster1-abcde",
+				"the lease was last renewed",
+				"the import secret cluster1-import does not exist",
+				"the addon work-manager availability is False",
+			},
+			notContains: []string{"ManagedClusterConditionAvailable"},
+		},
+		{
+			name:        "Success, agents not running",
+			clusterName: "cluster1",
+			objs: []runtime.Object{
+				fake.NewManagedCluster("cluster1", fake.WithConditions(available...)),
+				newLease("cluster1", time.Now()),
+				fake.NewImportSecret("cluster1", nil),
+			},
+			spokeClient: kubefake.NewSimpleClientset(newPod(helpers.AddonInstallNamespace, "work-manager", corev1.PodPending)),
+			contains: []string{
+				"no klusterlet agent is running",
+				"pod open-cluster-management-agent-addon/work-manager is Pending",
+			},
+		},
+		{
+			name:         "Success, json output",
+			clusterName:  "cluster1",
+			outputFormat: printers.OutputJSON,
+			contains:     []string{`"severity": "critical"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			printOptions := printers.NewPrintOptions()
+			if tt.outputFormat != "" {
+				printOptions.OutputFormat = tt.outputFormat
+			}
+			o := &Options{
+				printOptions: printOptions,
+				clusterName:  tt.clusterName,
+				spokeClient:  tt.spokeClient,
+				IOStreams:    streams,
+			}
+			err := o.runWithClient(fake.NewClient(tt.objs...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Options.runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %s, got:\n%s", c, out.String())
+				}
+			}
+			for _, c := range tt.notContains {
+				if strings.Contains(out.String(), c) {
+					t.Errorf("output must not contain %s, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	o := newOptions(genericclioptions.IOStreams{})
+	if err := o.validate(); err == nil {
+		t.Errorf("Options.validate() must fail without cluster name")
+	}
+	o.clusterName = "cluster1"
+	if err := o.validate(); err != nil {
+		t.Errorf("Options.validate() error = %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Options struct {
+	configFlags    *genericclioptions.ConfigFlags
+	printOptions   *printers.PrintOptions
+	clusterName    string
+	kubeConfigPath string
+	//spokeClient is set when the kubeconfig of the managed cluster is provided
+	spokeClient kubernetes.Interface
+	ctx         context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		printOptions: printers.NewPrintOptions(),
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) != 1 {
+		return fmt.Errorf("one cluster name is expected")
+	}
+	o.clusterName = args[0]
+	return nil
+}
+
+func (o *Options) validate() error {
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName}, mc); err != nil {
+		return err
+	}
+	if _, protected := helpers.ProtectionReason(mc); !protected {
+		fmt.Fprintf(printers.Messages(o.ErrOut), "%s unchanged\n", o.clusterName)
+	} else {
+		annotations := mc.GetAnnotations()
+		delete(annotations, helpers.ProtectionAnnotation)
+		mc.SetAnnotations(annotations)
+		if err := client.Update(o.ctx, mc); err != nil {
+			return err
+		}
+		fmt.Fprintf(printers.Messages(o.ErrOut), "%s unprotected\n", o.clusterName)
+	}
+	printers.PrintIdentifier(o.Out, o.clusterName)
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		wantMessages string
+	}{
+		{
+			name:         "Success",
+			annotations:  map[string]string{helpers.ProtectionAnnotation: "production"},
+			wantMessages: "cluster1 unprotected",
+		},
+		{
+			name:         "Success, unchanged",
+			wantMessages: "cluster1 unchanged",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &unstructured.Unstructured{}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			mc.SetName("cluster1")
+			mc.SetAnnotations(tt.annotations)
+			client := fake.NewClient(mc)
+			streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			if err := o.complete(nil, []string{"cluster1"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := o.runWithClient(client); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(errOut.String(), tt.wantMessages) {
+				t.Errorf("messages must contain %q, got %s", tt.wantMessages, errOut.String())
+			}
+			got := &unstructured.Unstructured{}
+			got.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, got); err != nil {
+				t.Fatal(err)
+			}
+			if _, protected := helpers.ProtectionReason(got); protected {
+				t.Error("the cluster must not be protected")
+			}
+		})
+	}
+}
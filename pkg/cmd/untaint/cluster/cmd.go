@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Remove the maintenance taints of a cluster, whatever their effect
+%[1]s untaint cluster mycluster maintenance
+
+# Remove only the NoSelect maintenance taint
+%[1]s untaint cluster mycluster maintenance:NoSelect
+`
+
+// NewCmd provides a cobra command removing taints from a managed cluster
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "cluster NAME KEY_1[:EFFECT_1] ... KEY_N[:EFFECT_N]",
+		Short:        "Remove taints from a managed cluster",
+		Long:         "Remove taints from a managed cluster, a key without effect removes the taints of the key whatever their effect.",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
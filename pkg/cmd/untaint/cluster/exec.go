@@ -0,0 +1,78 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) error {
+	o.ctx = helpers.CommandContext(cmd)
+	if len(args) == 0 {
+		return fmt.Errorf("the cluster name is missing")
+	}
+	o.clusterName = args[0]
+	o.taints = make([]taintKey, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		key, effect, err := helpers.ParseTaintKey(arg)
+		if err != nil {
+			return err
+		}
+		o.taints = append(o.taints, taintKey{key: key, effect: effect})
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if len(o.taints) == 0 {
+		return fmt.Errorf("at least one taint to remove as KEY[:EFFECT] is required")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(o.ctx, types.NamespacedName{Name: o.clusterName}, mc); err != nil {
+		return err
+	}
+	//Nothing is changed if one of the taints is not found
+	changes := make([]string, 0, len(o.taints))
+	for _, t := range o.taints {
+		removed, err := helpers.RemoveTaint(mc, t.key, t.effect)
+		if err != nil {
+			return err
+		}
+		if len(removed) == 0 {
+			if t.effect != "" {
+				return fmt.Errorf("the cluster %s has no taint %s:%s", o.clusterName, t.key, t.effect)
+			}
+			return fmt.Errorf("the cluster %s has no taint %s", o.clusterName, t.key)
+		}
+		changes = append(changes, removed...)
+	}
+	if err := client.Update(o.ctx, mc); err != nil {
+		return err
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "%s untainted: %s\n", o.clusterName, strings.Join(changes, ", "))
+	printers.PrintIdentifier(o.Out, o.clusterName)
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_runWithClient(t *testing.T) {
+	noSelect := map[string]interface{}{"key": "maintenance", "value": "true", "effect": helpers.TaintNoSelect}
+	ifNew := map[string]interface{}{"key": "maintenance", "effect": helpers.TaintNoSelectIfNew}
+	gpu := map[string]interface{}{"key": "gpu", "effect": helpers.TaintPreferNoSelect}
+	tests := []struct {
+		name         string
+		args         []string
+		wantErr      bool
+		wantMessages string
+		wantTaints   []interface{}
+	}{
+		{
+			name:         "Success, all the effects of the key",
+			args:         []string{"cluster1", "maintenance"},
+			wantMessages: "cluster1 untainted: -maintenance=true:NoSelect, -maintenance:NoSelectIfNew",
+			wantTaints:   []interface{}{gpu},
+		},
+		{
+			name:         "Success, one effect",
+			args:         []string{"cluster1", "maintenance:NoSelect", "gpu:PreferNoSelect"},
+			wantMessages: "cluster1 untainted: -maintenance=true:NoSelect, -gpu:PreferNoSelect",
+			wantTaints:   []interface{}{ifNew},
+		},
+		{
+			name:       "Failed, taint not found, nothing changed",
+			args:       []string{"cluster1", "gpu", "region"},
+			wantErr:    true,
+			wantTaints: []interface{}{noSelect, ifNew, gpu},
+		},
+		{
+			name:    "Failed, no taint",
+			args:    []string{"cluster1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &unstructured.Unstructured{}
+			mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			mc.SetName("cluster1")
+			unstructured.SetNestedSlice(mc.Object, []interface{}{noSelect, ifNew, gpu}, "spec", "taints")
+			client := fake.NewClient(mc)
+			streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			err := o.complete(nil, tt.args)
+			if err == nil {
+				err = o.validate()
+			}
+			if err == nil {
+				err = o.runWithClient(client)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("untaint error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(errOut.String(), tt.wantMessages) {
+				t.Errorf("messages must contain %q, got %s", tt.wantMessages, errOut.String())
+			}
+			if tt.wantTaints == nil {
+				return
+			}
+			got := &unstructured.Unstructured{}
+			got.SetGroupVersionKind(helpers.ManagedClusterGVK)
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, got); err != nil {
+				t.Fatal(err)
+			}
+			if taints, _, _ := unstructured.NestedSlice(got.Object, "spec", "taints"); !reflect.DeepEqual(taints, tt.wantTaints) {
+				t.Errorf("taints = %v, want %v", taints, tt.wantTaints)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// taintKey is a taint to remove, all the taints of the key if effect is ""
+type taintKey struct {
+	key    string
+	effect string
+}
+
+type Options struct {
+	configFlags *genericclioptions.ConfigFlags
+	clusterName string
+	//taints are parsed from the arguments
+	taints []taintKey
+	ctx    context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ctx:         context.Background(),
+
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright Contributors to the Open Cluster Management project
+package update
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Update the cli to the latest stable release
+%[1]s update
+
+# Check if a new release is available without updating
+%[1]s update --check
+
+# Update to the latest release, including the pre-releases
+%[1]s update --channel latest
+
+# Update from a mirror of the releases through a proxy
+%[1]s update --mirror https://mirror.example.com/cm-cli --proxy http://proxy.example.com:3128
+
+# Update from the releases copied in a directory of a disconnected host
+%[1]s update --mirror /media/cm-cli-releases --public-key <base64 key>
+`
+
+// NewCmd provides a cobra command replacing the binary of the cli by its latest release
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update the cli to its latest release",
+		Long: "Download the latest release of the cli from GitHub or a mirror, verify its checksum and the signature of the checksums, " +
+			"the releases which are not signed are refused unless --insecure-skip-signature is set, " +
+			"and replace the running binary. The mirror, channel, public key and proxy defaults are read from the update section of ~/.cm/config.yaml",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.channel, "channel", "", "The release channel, stable for the releases or latest to include the pre-releases, stable by default")
+	cmd.Flags().StringVar(&o.mirror, "mirror", "", "The url, or the directory or file:// url, of a mirror of the releases holding a releases.json in the format of the GitHub releases API")
+	cmd.Flags().StringVar(&o.publicKey, "public-key", "", "The base64 ed25519 public key verifying the signature of the checksums of the release, the release key of the build if not set")
+	cmd.Flags().BoolVar(&o.insecureSkipSignature, "insecure-skip-signature", false, "If set, the signature of the checksums is not verified and the unsigned releases are accepted, only the checksum of the binary is verified")
+	cmd.Flags().StringVar(&o.proxy, "proxy", "", "The proxy the release is downloaded through, the HTTPS_PROXY and NO_PROXY environment variables are used if not set")
+	cmd.Flags().BoolVar(&o.check, "check", false, "If set, only report if a new release is available")
+
+	return cmd
+}
@@ -0,0 +1,284 @@
+// Copyright Contributors to the Open Cluster Management project
+package update
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/config"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	//releasesURL lists the releases of the cli on GitHub
+	releasesURL = "https://api.github.com/repos/open-cluster-management/cm-cli/releases"
+	//mirrorReleasesFile is the file of a mirror listing its releases
+	mirrorReleasesFile = "releases.json"
+	downloadTimeout    = 5 * time.Minute
+)
+
+// releasePublicKey is the base64 ed25519 public key signing the checksums of the releases, pinned in the binary
+// at build time with -ldflags "-X github.com/open-cluster-management/cm-cli/pkg/cmd/update.releasePublicKey=<key>"
+var releasePublicKey = ""
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	path := o.configPath
+	if path == "" {
+		path, err = config.DefaultPath()
+		if err != nil {
+			return err
+		}
+	}
+	c, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	//The flags override the configuration
+	if o.channel == "" {
+		o.channel = c.Update.Channel
+	}
+	if o.channel == "" {
+		o.channel = channelStable
+	}
+	if o.mirror == "" {
+		o.mirror = c.Update.Mirror
+	}
+	if o.publicKey == "" {
+		o.publicKey = c.Update.PublicKey
+	}
+	if o.proxy == "" {
+		o.proxy = c.Update.Proxy
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.channel != channelStable && o.channel != channelLatest {
+		return fmt.Errorf("invalid channel %s, supported channels are %s and %s", o.channel, channelStable, channelLatest)
+	}
+	//The public key of the flag or of the configuration overrides the release key of the build
+	publicKey := o.publicKey
+	if publicKey == "" {
+		publicKey = releasePublicKey
+	}
+	if publicKey != "" {
+		b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+		if err != nil || len(b) != ed25519.PublicKeySize {
+			return fmt.Errorf("invalid public key, a base64 ed25519 public key of %d bytes is expected", ed25519.PublicKeySize)
+		}
+		o.key = ed25519.PublicKey(b)
+	}
+	if o.mirror != "" && !strings.HasPrefix(o.mirror, "http://") && !strings.HasPrefix(o.mirror, "https://") {
+		dir, err := filepath.Abs(strings.TrimPrefix(o.mirror, "file://"))
+		if err != nil {
+			return err
+		}
+		o.mirrorDir = dir
+	}
+	if o.proxy != "" {
+		u, err := url.Parse(o.proxy)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("invalid proxy %s, an url such as http://proxy.example.com:3128 is expected", o.proxy)
+		}
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	return o.runWithClient(o.httpClient())
+}
+
+func (o *Options) runWithClient(client *http.Client) error {
+	source, err := o.releasesURL()
+	if err != nil {
+		return err
+	}
+	releases := make([]release, 0)
+	b, err := download(client, source)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, &releases); err != nil {
+		return fmt.Errorf("invalid releases from %s: %s", source, err.Error())
+	}
+	latest, latestVersion := latestRelease(releases, o.channel)
+	if latest == nil {
+		return fmt.Errorf("no %s release of %s found in %s", o.channel, binaryAsset(runtime.GOOS, runtime.GOARCH), source)
+	}
+	if !isNewer(latestVersion, helpers.Version) {
+		fmt.Fprintf(printers.Messages(o.ErrOut), "cm %s is up to date, the latest %s release is %s\n", helpers.Version, o.channel, latest.TagName)
+		return nil
+	}
+	if o.check {
+		fmt.Fprintf(o.Out, "current: %s\nlatest: %s\n", helpers.Version, latest.TagName)
+		fmt.Fprintf(printers.Messages(o.ErrOut), "Run %s update to install %s\n", helpers.GetExampleHeader(), latest.TagName)
+		return nil
+	}
+
+	binary, err := o.downloadRelease(client, source, latest)
+	if err != nil {
+		return err
+	}
+	executable := o.executable
+	if executable == "" {
+		executable, err = os.Executable()
+		if err != nil {
+			return err
+		}
+		executable, err = filepath.EvalSymlinks(executable)
+		if err != nil {
+			return err
+		}
+	}
+	if err := replaceBinary(executable, binary); err != nil {
+		return fmt.Errorf("failed to replace %s: %s", executable, err.Error())
+	}
+	fmt.Fprintf(printers.Messages(o.ErrOut), "cm updated from %s to %s\n", helpers.Version, latest.TagName)
+	return nil
+}
+
+// downloadRelease downloads the binary of the platform and verifies it against the checksums of the release,
+// and the checksums against their signature unless --insecure-skip-signature is set
+func (o *Options) downloadRelease(client *http.Client, source string, r *release) ([]byte, error) {
+	name := binaryAsset(runtime.GOOS, runtime.GOARCH)
+	checksums, err := downloadAsset(client, source, r, checksumsAsset)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case o.insecureSkipSignature:
+		fmt.Fprintf(printers.Messages(o.ErrOut), "Warning: the signature of %s is not verified, only its checksum\n", r.TagName)
+	case o.key == nil:
+		return nil, fmt.Errorf("no public key verifies the signature of %s, this build has no release key: "+
+			"set --public-key or the publicKey of the update configuration, or --insecure-skip-signature to only verify the checksum", r.TagName)
+	default:
+		signature, err := downloadAsset(client, source, r, signatureAsset)
+		if err != nil {
+			return nil, fmt.Errorf("%s, the unsigned releases are refused unless --insecure-skip-signature is set", err.Error())
+		}
+		sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+		if err != nil || !ed25519.Verify(o.key, checksums, sig) {
+			return nil, fmt.Errorf("the signature of the checksums of %s is not valid for the public key", r.TagName)
+		}
+	}
+	binary, err := downloadAsset(client, source, r, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyChecksum(checksums, name, binary); err != nil {
+		return nil, err
+	}
+	return binary, nil
+}
+
+// releasesURL returns the url listing the releases, the file urls of a mirror directory are relative to the directory
+func (o *Options) releasesURL() (string, error) {
+	switch {
+	case o.mirrorDir != "":
+		return "file:///" + mirrorReleasesFile, nil
+	case o.mirror != "":
+		return strings.TrimSuffix(o.mirror, "/") + "/" + mirrorReleasesFile, nil
+	}
+	return releasesURL, nil
+}
+
+// httpClient returns the client downloading the releases through the proxy. The file urls are only read
+// for a mirror directory, and only from this directory.
+func (o *Options) httpClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if o.proxy != "" {
+		//The proxy is validated by validate()
+		u, _ := url.Parse(o.proxy)
+		transport.Proxy = http.ProxyURL(u)
+	}
+	if o.mirrorDir != "" {
+		transport.RegisterProtocol("file", http.NewFileTransport(http.Dir(o.mirrorDir)))
+	}
+	return &http.Client{Transport: transport, Timeout: downloadTimeout}
+}
+
+// downloadAsset downloads the asset of the release, its url is resolved against the releases url for the mirrors
+func downloadAsset(client *http.Client, source string, r *release, name string) ([]byte, error) {
+	asset, ok := r.asset(name)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s asset", r.TagName, name)
+	}
+	base, err := url.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := url.Parse(asset.URL)
+	if err != nil {
+		return nil, err
+	}
+	return download(client, base.ResolveReference(ref).String())
+}
+
+func download(client *http.Client, u string) ([]byte, error) {
+	resp, err := client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", u, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// replaceBinary writes the binary next to the executable and renames it over the executable,
+// so the executable is either the old or the new binary if the update is interrupted
+func replaceBinary(executable string, binary []byte) error {
+	mode := os.FileMode(0755)
+	if fi, err := os.Stat(executable); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	f, err := ioutil.TempFile(filepath.Dir(executable), ".cm-update-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(binary); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), mode); err != nil {
+		return err
+	}
+	//A running executable can not be replaced on windows but it can be renamed
+	old := ""
+	if runtime.GOOS == "windows" {
+		old = executable + ".old"
+		os.Remove(old)
+		if err := os.Rename(executable, old); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(f.Name(), executable); err != nil {
+		//The old binary is put back so the cli is not lost
+		if old != "" {
+			if rerr := os.Rename(old, executable); rerr != nil {
+				return fmt.Errorf("%s, the previous binary is kept as %s: %s", err.Error(), old, rerr.Error())
+			}
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+// Copyright Contributors to the Open Cluster Management project
+package update
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// writeReleases writes in the directory the releases.json of a mirror and the assets of the releases,
+// with asset urls relative to the releases.json
+func writeReleases(t *testing.T, dir string, key ed25519.PrivateKey, releases map[string]bool, corrupt bool) {
+	name := binaryAsset(runtime.GOOS, runtime.GOARCH)
+	list := make([]release, 0, len(releases))
+	for tag, prerelease := range releases {
+		if err := os.MkdirAll(filepath.Join(dir, tag), 0700); err != nil {
+			t.Fatal(err)
+		}
+		binary := []byte("binary " + tag)
+		sum := sha256.Sum256(binary)
+		checksums := []byte(fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), name))
+		if corrupt {
+			binary = []byte("corrupted")
+		}
+		files := map[string][]byte{
+			name:           binary,
+			checksumsAsset: checksums,
+			signatureAsset: []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(key, checksums))),
+		}
+		r := release{TagName: tag, Prerelease: prerelease}
+		for asset, content := range files {
+			if err := ioutil.WriteFile(filepath.Join(dir, tag, asset), content, 0600); err != nil {
+				t.Fatal(err)
+			}
+			r.Assets = append(r.Assets, releaseAsset{Name: asset, URL: tag + "/" + asset})
+		}
+		list = append(list, r)
+	}
+	b, err := json.Marshal(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, mirrorReleasesFile), b, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		current     string
+		channel     string
+		key         ed25519.PublicKey
+		check       bool
+		insecure    bool
+		directory   bool
+		corrupt     bool
+		wantBinary  string
+		wantOut     string
+		wantErr     string
+		wantMessage string
+	}{
+		{
+			name:       "Success, stable",
+			current:    "v0.1.0",
+			channel:    channelStable,
+			key:        public,
+			wantBinary: "binary v0.2.0",
+		},
+		{
+			name:       "Success, latest",
+			current:    "v0.1.0",
+			channel:    channelLatest,
+			key:        public,
+			wantBinary: "binary v0.3.0-rc.1",
+		},
+		{
+			name:        "Success, development build skipping the signature",
+			current:     "dev",
+			channel:     channelStable,
+			insecure:    true,
+			wantBinary:  "binary v0.2.0",
+			wantMessage: "signature of v0.2.0 is not verified",
+		},
+		{
+			name:    "Failed, no public key",
+			current: "v0.1.0",
+			channel: channelStable,
+			wantErr: "--insecure-skip-signature",
+		},
+		{
+			name:       "Success, mirror directory",
+			current:    "v0.1.0",
+			channel:    channelStable,
+			key:        public,
+			directory:  true,
+			wantBinary: "binary v0.2.0",
+		},
+		{
+			name:        "Success, up to date",
+			current:     "v0.2.0-3-gabcdef0",
+			channel:     channelStable,
+			wantMessage: "up to date",
+		},
+		{
+			name:        "Success, check",
+			current:     "v0.1.0",
+			channel:     channelStable,
+			check:       true,
+			wantOut:     "latest: v0.2.0",
+			wantMessage: "update to install v0.2.0",
+		},
+		{
+			name:    "Failed, checksum mismatch",
+			current: "v0.1.0",
+			channel: channelStable,
+			key:     public,
+			corrupt: true,
+			wantErr: "checksum mismatch",
+		},
+		{
+			name:    "Failed, invalid signature",
+			current: "v0.1.0",
+			channel: channelStable,
+			key:     otherPublic,
+			wantErr: "signature",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mirror := t.TempDir()
+			writeReleases(t, mirror, private, map[string]bool{"v0.1.0": false, "v0.2.0": false, "v0.3.0-rc.1": true}, tt.corrupt)
+			executable := filepath.Join(t.TempDir(), "cm")
+			if err := ioutil.WriteFile(executable, []byte("old"), 0700); err != nil {
+				t.Fatal(err)
+			}
+
+			current := helpers.Version
+			helpers.Version = tt.current
+			defer func() { helpers.Version = current }()
+
+			streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.channel = tt.channel
+			o.key = tt.key
+			o.check = tt.check
+			o.insecureSkipSignature = tt.insecure
+			o.executable = executable
+			o.mirror = mirror
+			o.mirrorDir = mirror
+			if !tt.directory {
+				o.mirrorDir = ""
+				server := httptest.NewServer(http.FileServer(http.Dir(mirror)))
+				defer server.Close()
+				o.mirror = server.URL
+			}
+			err := o.runWithClient(o.httpClient())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error containing %q expected, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ioutil.ReadFile(executable)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantBinary := tt.wantBinary
+			if wantBinary == "" {
+				wantBinary = "old"
+			}
+			if string(b) != wantBinary {
+				t.Errorf("executable = %q, want %q", string(b), wantBinary)
+			}
+			if !strings.Contains(out.String(), tt.wantOut) {
+				t.Errorf("output must contain %q, got %q", tt.wantOut, out.String())
+			}
+			if !strings.Contains(errOut.String(), tt.wantMessage) {
+				t.Errorf("messages must contain %q, got %q", tt.wantMessage, errOut.String())
+			}
+		})
+	}
+}
+
+func TestOptions_httpClient(t *testing.T) {
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, mirrorReleasesFile), []byte("[]"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	//The file urls are read from the mirror directory only
+	o := &Options{mirrorDir: dir}
+	if _, err := download(o.httpClient(), "file:///"+mirrorReleasesFile); err != nil {
+		t.Errorf("the releases of the mirror directory must be read, got %v", err)
+	}
+	if _, err := download(o.httpClient(), "file:///../"+filepath.Base(dir)+"/"+mirrorReleasesFile); err == nil {
+		t.Error("the files out of the mirror directory must not be read")
+	}
+	//Without a mirror directory the file urls are not read
+	o = &Options{mirror: "https://mirror.example.com"}
+	if _, err := download(o.httpClient(), "file://"+filepath.ToSlash(filepath.Join(dir, mirrorReleasesFile))); err == nil {
+		t.Error("the file urls must not be read without a mirror directory")
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		o       Options
+		wantErr bool
+	}{
+		{name: "Success", o: Options{channel: channelStable}},
+		{name: "Success, all set", o: Options{channel: channelLatest, publicKey: base64.StdEncoding.EncodeToString(public), proxy: "http://proxy.example.com:3128"}},
+		{name: "Failed, invalid channel", o: Options{channel: "nightly"}, wantErr: true},
+		{name: "Failed, invalid public key", o: Options{channel: channelStable, publicKey: "bm90IGEga2V5"}, wantErr: true},
+		{name: "Failed, invalid proxy", o: Options{channel: channelStable, proxy: "proxy"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_isNewer(t *testing.T) {
+	latest := version.MustParseSemantic("v0.2.0")
+	tests := []struct {
+		current string
+		want    bool
+	}{
+		{"v0.1.0", true},
+		{"v0.2.0", false},
+		{"v0.2.0-4-g1234abc", false},
+		{"v0.1.0-4-g1234abc-dirty", true},
+		{"v0.3.0", false},
+		{"dev", true},
+	}
+	for _, tt := range tests {
+		if got := isNewer(latest, tt.current); got != tt.want {
+			t.Errorf("isNewer(%s) = %v, want %v", tt.current, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright Contributors to the Open Cluster Management project
+package update
+
+import (
+	"crypto/ed25519"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//configPath is the configuration file, the default one is used if empty
+	configPath string
+	channel    string
+	mirror     string
+	publicKey  string
+	proxy      string
+	check      bool
+	//insecureSkipSignature accepts the releases whose signature is missing or not verified
+	insecureSkipSignature bool
+	//executable is the binary to replace, the running one if empty
+	executable string
+	//key is the decoded public key, or the release key of the build
+	key ed25519.PublicKey
+	//mirrorDir is the absolute directory of a mirror which is not served over http
+	mirrorDir string
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: streams,
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright Contributors to the Open Cluster Management project
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+const (
+	channelStable = "stable"
+	channelLatest = "latest"
+	//checksumsAsset is the sha256sum of the binaries of a release
+	checksumsAsset = "checksums.txt"
+	//signatureAsset is the base64 ed25519 signature of the checksums
+	signatureAsset = checksumsAsset + ".sig"
+)
+
+// release is a release of the GitHub releases API, the mirrors serve the same format
+type release struct {
+	TagName    string         `json:"tag_name"`
+	Prerelease bool           `json:"prerelease"`
+	Draft      bool           `json:"draft"`
+	Assets     []releaseAsset `json:"assets"`
+}
+
+type releaseAsset struct {
+	Name string `json:"name"`
+	//URL is absolute or relative to the releases file of a mirror
+	URL string `json:"browser_download_url"`
+}
+
+// binaryAsset returns the name of the binary of the platform in the assets of a release
+func binaryAsset(goos, goarch string) string {
+	name := fmt.Sprintf("cm_%s_%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+func (r *release) asset(name string) (releaseAsset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return releaseAsset{}, false
+}
+
+// latestRelease returns the highest release of the channel providing the binary of the platform,
+// the stable channel ignores the pre-releases and the drafts are always ignored
+func latestRelease(releases []release, channel string) (*release, *version.Version) {
+	var latest *release
+	var latestVersion *version.Version
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft || (r.Prerelease && channel == channelStable) {
+			continue
+		}
+		if _, ok := r.asset(binaryAsset(runtime.GOOS, runtime.GOARCH)); !ok {
+			continue
+		}
+		v, err := version.ParseSemantic(r.TagName)
+		if err != nil {
+			continue
+		}
+		if latestVersion == nil || latestVersion.LessThan(v) {
+			latest, latestVersion = r, v
+		}
+	}
+	return latest, latestVersion
+}
+
+// describeSuffix is the suffix added by git describe to the version of a build made after a tag
+var describeSuffix = regexp.MustCompile(`-[0-9]+-g[0-9a-f]+(-dirty)?$`)
+
+// isNewer returns true if the release is newer than the current version, a development build
+// without a semantic version is always older than a release
+func isNewer(latest *version.Version, current string) bool {
+	//A build made after a tag is at least the version of the tag
+	current = describeSuffix.ReplaceAllString(current, "")
+	v, err := version.ParseSemantic(current)
+	if err != nil {
+		return true
+	}
+	return v.LessThan(latest)
+}
+
+// verifyChecksum checks the sha256 of the content is the one of the name in the checksums,
+// in the format of sha256sum
+func verifyChecksum(checksums []byte, name string, content []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(content)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("checksum mismatch for %s, expected %s got %s", name, fields[0], hex.EncodeToString(sum[:]))
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("no checksum for %s in %s", name, checksumsAsset)
+}
@@ -0,0 +1,62 @@
+// Copyright Contributors to the Open Cluster Management project
+package klusterlet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Upgrade the klusterlet of two clusters and wait for the rollout
+%[1]s upgrade klusterlet --clusters c1,c2 --bundle-version 2.3.0
+
+# Show the current and target versions without upgrading
+%[1]s upgrade klusterlet --clusters c1,c2 --bundle-version 2.3.0 --dry-run
+
+# Upgrade the klusterlet with the images of a mirror registry
+%[1]s upgrade klusterlet --clusters c1 --bundle-version 2.3.0 --registry registry.example.com/open-cluster-management
+`
+
+// NewCmd provides a cobra command upgrading the klusterlet of managed clusters
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "klusterlet",
+		Short: "Upgrade the klusterlet of managed clusters",
+		Long: "Upgrade the klusterlet operator and agents of managed clusters to the images of a bundle version. " +
+			"The images of the klusterlet ManifestWork of each cluster are updated, the current and target versions " +
+			"are shown first, then the command waits for the work agent to roll them out and the clusters to be available",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&o.clusterNames, "clusters", nil, "The comma separated names of the clusters to upgrade")
+	cmd.Flags().StringVar(&o.bundleVersion, "bundle-version", "", "The version of the klusterlet images to upgrade to")
+	cmd.Flags().StringVar(&o.registry, "registry", "", "The registry of the klusterlet images, the registry of the current images if not set")
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, "Only show the current and target versions")
+	cmd.Flags().BoolVar(&o.wait, "wait", true, "Wait for the rollout of the klusterlet")
+	helpers.DurationVar(cmd.Flags(), &o.timeout, "timeout", 10*time.Minute, "Timeout to wait for the rollout, e.g. 10m")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,305 @@
+// Copyright Contributors to the Open Cluster Management project
+package klusterlet
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// klusterletWorkSuffix is the suffix of the ManifestWork of the klusterlet created by the import controller
+	klusterletWorkSuffix = "-klusterlet"
+	// operatorContainer is the container of the klusterlet operator Deployment
+	operatorContainer = "registration-operator"
+)
+
+// tagRegexp is the format of an image tag
+var tagRegexp = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+
+// upgrade is the upgrade of the klusterlet of a cluster
+type upgrade struct {
+	cluster  string
+	current  string
+	target   string
+	upToDate bool
+	work     *unstructured.Unstructured
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	o.registry = strings.TrimSuffix(o.registry, "/")
+	return nil
+}
+
+func (o *Options) validate() error {
+	if len(o.clusterNames) == 0 {
+		return fmt.Errorf("the clusters to upgrade are missing, set them with --clusters")
+	}
+	if o.bundleVersion == "" {
+		return fmt.Errorf("the version to upgrade to is missing, set it with --bundle-version")
+	}
+	if !tagRegexp.MatchString(o.bundleVersion) {
+		return fmt.Errorf("invalid bundle version %s, it must be an image tag", o.bundleVersion)
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	upgrades := make([]*upgrade, 0, len(o.clusterNames))
+	table := &printers.Table{Headers: []string{"CLUSTER", "WORK", "CURRENT", "TARGET"}}
+	for _, clusterName := range o.clusterNames {
+		u, err := o.planUpgrade(client, clusterName)
+		if err != nil {
+			return err
+		}
+		upgrades = append(upgrades, u)
+		target := u.target
+		if u.upToDate {
+			target += " (up to date)"
+		}
+		table.AddRow(u.cluster, u.work.GetName(), u.current, target)
+	}
+	if err := printers.PrintTable(o.Out, table); err != nil {
+		return err
+	}
+	if o.dryRun {
+		return nil
+	}
+
+	w := printers.Messages(o.ErrOut)
+	pending := make([]*upgrade, 0)
+	for _, u := range upgrades {
+		if u.upToDate {
+			continue
+		}
+		if err := client.Update(o.ctx, u.work); err != nil {
+			return fmt.Errorf("failed to upgrade the klusterlet of cluster %s: %v", u.cluster, err)
+		}
+		fmt.Fprintf(w, "Klusterlet of cluster %s upgrading from %s to %s\n", u.cluster, u.current, u.target)
+		pending = append(pending, u)
+	}
+	if !o.wait || len(pending) == 0 {
+		return nil
+	}
+	return o.waitForRollout(client, pending)
+}
+
+// planUpgrade finds the klusterlet ManifestWork of the cluster and sets the images of the target version in its manifests
+func (o *Options) planUpgrade(client crclient.Client, clusterName string) (*upgrade, error) {
+	work, err := getKlusterletWork(o.ctx, client, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	u := &upgrade{cluster: clusterName, target: o.bundleVersion, work: work}
+	manifests, _, _ := unstructured.NestedSlice(work.Object, "spec", "workload", "manifests")
+	changed := false
+	for _, im := range manifests {
+		m, ok := im.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch m["kind"] {
+		case helpers.KlusterletGVK.Kind:
+			for _, field := range []string{"registrationImagePullSpec", "workImagePullSpec"} {
+				image, _, _ := unstructured.NestedString(m, "spec", field)
+				if image == "" {
+					continue
+				}
+				if field == "registrationImagePullSpec" {
+					u.current = imageTag(image)
+				}
+				target := retag(image, o.registry, o.bundleVersion)
+				if target != image {
+					changed = true
+					if err := unstructured.SetNestedField(m, target, "spec", field); err != nil {
+						return nil, err
+					}
+				}
+			}
+		case "Deployment":
+			containers, found, _ := unstructured.NestedSlice(m, "spec", "template", "spec", "containers")
+			if !found {
+				continue
+			}
+			for _, ic := range containers {
+				c, ok := ic.(map[string]interface{})
+				if !ok || c["name"] != operatorContainer {
+					continue
+				}
+				image, _ := c["image"].(string)
+				if target := retag(image, o.registry, o.bundleVersion); image != "" && target != image {
+					changed = true
+					c["image"] = target
+				}
+			}
+			if err := unstructured.SetNestedSlice(m, containers, "spec", "template", "spec", "containers"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if u.current == "" {
+		return nil, fmt.Errorf("the klusterlet ManifestWork %s/%s has no klusterlet image", clusterName, work.GetName())
+	}
+	if !changed {
+		u.upToDate = true
+	}
+	return u, unstructured.SetNestedSlice(work.Object, manifests, "spec", "workload", "manifests")
+}
+
+// getKlusterletWork returns the ManifestWork of the cluster deploying the Klusterlet, the import
+// controller names it <cluster>-klusterlet, the other ManifestWorks are looked up for a Klusterlet otherwise
+func getKlusterletWork(ctx context.Context, client crclient.Client, clusterName string) (*unstructured.Unstructured, error) {
+	works := &unstructured.UnstructuredList{}
+	works.SetGroupVersionKind(helpers.ManifestWorkListGVK)
+	if err := client.List(ctx, works, crclient.InNamespace(clusterName)); err != nil {
+		return nil, err
+	}
+	var found *unstructured.Unstructured
+	for i := range works.Items {
+		work := &works.Items[i]
+		if !hasKlusterlet(work) {
+			continue
+		}
+		if work.GetName() == clusterName+klusterletWorkSuffix {
+			return work, nil
+		}
+		if found == nil {
+			found = work
+		}
+	}
+	if found != nil {
+		return found, nil
+	}
+	return nil, fmt.Errorf("no klusterlet ManifestWork found in the namespace of cluster %s, the cluster was not imported by the hub", clusterName)
+}
+
+func hasKlusterlet(work *unstructured.Unstructured) bool {
+	manifests, _, _ := unstructured.NestedSlice(work.Object, "spec", "workload", "manifests")
+	for _, im := range manifests {
+		if m, ok := im.(map[string]interface{}); ok && m["kind"] == helpers.KlusterletGVK.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// imageTag returns the tag of the image, or its digest
+func imageTag(image string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.Index(name, "@"); i != -1 {
+		return name[i+1:]
+	}
+	if i := strings.LastIndex(name, ":"); i != -1 {
+		return name[i+1:]
+	}
+	return "latest"
+}
+
+// retag returns the image with the version as tag, in the registry if set
+func retag(image, registry, version string) string {
+	repository := ""
+	name := image
+	if i := strings.LastIndex(image, "/"); i != -1 {
+		repository, name = image[:i], image[i+1:]
+	}
+	if i := strings.IndexAny(name, "@:"); i != -1 {
+		name = name[:i]
+	}
+	if registry != "" {
+		repository = registry
+	}
+	if repository == "" {
+		return name + ":" + version
+	}
+	return repository + "/" + name + ":" + version
+}
+
+// waitForRollout waits for the work agents to apply the upgraded ManifestWorks and for the clusters
+// to be available again with the upgraded agents
+func (o *Options) waitForRollout(client crclient.Client, pending []*upgrade) error {
+	w := printers.Messages(o.ErrOut)
+	fmt.Fprintf(w, "Waiting for the rollout of the klusterlet on %d clusters\n", len(pending))
+	err := helpers.PollImmediate(o.ctx, o.pollInterval, o.timeout, func() (bool, error) {
+		remaining := pending[:0]
+		for _, u := range pending {
+			done, err := rolledOut(o.ctx, client, u)
+			if err != nil {
+				return false, err
+			}
+			if done {
+				fmt.Fprintf(w, "Klusterlet of cluster %s upgraded to %s\n", u.cluster, u.target)
+				continue
+			}
+			remaining = append(remaining, u)
+		}
+		pending = remaining
+		return len(pending) == 0, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		clusters := make([]string, len(pending))
+		for i, u := range pending {
+			clusters[i] = u.cluster
+		}
+		return fmt.Errorf("the klusterlet of clusters %s was not rolled out after %s, check them with cm troubleshoot cluster",
+			strings.Join(clusters, ", "), o.timeout)
+	}
+	return err
+}
+
+// rolledOut returns true once the upgraded ManifestWork is applied and the cluster is available
+func rolledOut(ctx context.Context, client crclient.Client, u *upgrade) (bool, error) {
+	work := &unstructured.Unstructured{}
+	work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	if err := client.Get(ctx, types.NamespacedName{Namespace: u.cluster, Name: u.work.GetName()}, work); err != nil {
+		return false, err
+	}
+	conditions, _, _ := unstructured.NestedSlice(work.Object, "status", "conditions")
+	for _, ic := range conditions {
+		c, ok := ic.(map[string]interface{})
+		if !ok || c["type"] != helpers.WorkAppliedCondition {
+			continue
+		}
+		//The condition is the one of the previous generation until the work agent applies the upgrade
+		if generation, found, _ := unstructured.NestedInt64(c, "observedGeneration"); found && generation < work.GetGeneration() {
+			return false, nil
+		}
+	}
+	if helpers.GetConditionStatus(conditions, helpers.WorkAppliedCondition) != "True" ||
+		helpers.GetConditionStatus(conditions, helpers.WorkAvailableCondition) != "True" {
+		return false, nil
+	}
+
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	err := client.Get(ctx, types.NamespacedName{Name: u.cluster}, mc)
+	if errors.IsNotFound(err) {
+		return false, fmt.Errorf("cluster %s was detached during the upgrade", u.cluster)
+	}
+	if err != nil {
+		return false, err
+	}
+	conditions, _, _ = unstructured.NestedSlice(mc.Object, "status", "conditions")
+	return helpers.GetConditionStatus(conditions, "ManagedClusterConditionAvailable") == "True", nil
+}
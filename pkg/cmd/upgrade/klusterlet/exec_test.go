@@ -0,0 +1,204 @@
+// Copyright Contributors to the Open Cluster Management project
+package klusterlet
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newKlusterletWork(clusterName, version, available string) *unstructured.Unstructured {
+	work := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"workload": map[string]interface{}{
+				"manifests": []interface{}{
+					map[string]interface{}{
+						"apiVersion": "apps/v1",
+						"kind":       "Deployment",
+						"metadata":   map[string]interface{}{"name": "klusterlet", "namespace": "open-cluster-management-agent"},
+						"spec": map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{
+							"containers": []interface{}{map[string]interface{}{
+								"name":  operatorContainer,
+								"image": "quay.io/open-cluster-management/registration-operator:" + version,
+							}},
+						}}},
+					},
+					map[string]interface{}{
+						"apiVersion": "operator.open-cluster-management.io/v1",
+						"kind":       "Klusterlet",
+						"metadata":   map[string]interface{}{"name": "klusterlet"},
+						"spec": map[string]interface{}{
+							"registrationImagePullSpec": "quay.io/open-cluster-management/registration:" + version,
+							"workImagePullSpec":         "quay.io/open-cluster-management/work:" + version,
+						},
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{"conditions": []interface{}{
+			map[string]interface{}{"type": helpers.WorkAppliedCondition, "status": available},
+			map[string]interface{}{"type": helpers.WorkAvailableCondition, "status": available},
+		}},
+	}}
+	work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+	work.SetNamespace(clusterName)
+	work.SetName(clusterName + klusterletWorkSuffix)
+	return work
+}
+
+// newCluster returns a cluster with the availability
+func newCluster(name, available string) *unstructured.Unstructured {
+	return fake.NewManagedCluster(name, fake.WithConditions(fake.NewCondition("ManagedClusterConditionAvailable", available)))
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		objs     []runtime.Object
+		clusters []string
+		registry string
+		dryRun   bool
+		wait     bool
+		contains []string
+		want     map[string]string
+		wantErr  string
+	}{
+		{
+			name: "Success",
+			objs: []runtime.Object{
+				newKlusterletWork("c1", "2.2.0", "True"), newCluster("c1", "True"),
+				newKlusterletWork("c2", "2.3.0", "True"), newCluster("c2", "True"),
+			},
+			clusters: []string{"c1", "c2"},
+			wait:     true,
+			contains: []string{"c1", "2.2.0", "2.3.0 (up to date)"},
+			want: map[string]string{
+				"c1": "quay.io/open-cluster-management/registration-operator:2.3.0",
+				"c2": "quay.io/open-cluster-management/registration-operator:2.3.0",
+			},
+		},
+		{
+			name:     "Success, registry",
+			objs:     []runtime.Object{newKlusterletWork("c1", "2.3.0", "True"), newCluster("c1", "True")},
+			clusters: []string{"c1"},
+			registry: "registry.example.com/ocm",
+			want:     map[string]string{"c1": "registry.example.com/ocm/registration-operator:2.3.0"},
+		},
+		{
+			name:     "Success, dry run",
+			objs:     []runtime.Object{newKlusterletWork("c1", "2.2.0", "True"), newKlusterletWork("c2", "2.2.0", "True")},
+			clusters: []string{"c1", "c2"},
+			dryRun:   true,
+			contains: []string{"CURRENT", "2.2.0"},
+			want: map[string]string{
+				"c1": "quay.io/open-cluster-management/registration-operator:2.2.0",
+				"c2": "quay.io/open-cluster-management/registration-operator:2.2.0",
+			},
+		},
+		{
+			name:     "Failed, not rolled out",
+			objs:     []runtime.Object{newKlusterletWork("c1", "2.2.0", "True"), newCluster("c1", "Unknown")},
+			clusters: []string{"c1"},
+			wait:     true,
+			wantErr:  "not rolled out",
+		},
+		{
+			name:     "Failed, no klusterlet work",
+			objs:     []runtime.Object{newKlusterletWork("c1", "2.2.0", "True")},
+			clusters: []string{"c1", "c2"},
+			wantErr:  "cluster c2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := newOptions(streams)
+			o.clusterNames = tt.clusters
+			o.bundleVersion = "2.3.0"
+			o.registry = tt.registry
+			o.dryRun = tt.dryRun
+			o.wait = tt.wait
+			o.timeout = 50 * time.Millisecond
+			o.pollInterval = 10 * time.Millisecond
+			client := fake.NewClient(tt.objs...)
+			err := o.runWithClient(client)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error containing %q expected, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+			for clusterName, image := range tt.want {
+				work := &unstructured.Unstructured{}
+				work.SetGroupVersionKind(helpers.ManifestWorkGVK)
+				if err := client.Get(context.TODO(), types.NamespacedName{Namespace: clusterName, Name: clusterName + klusterletWorkSuffix}, work); err != nil {
+					t.Fatal(err)
+				}
+				manifests, _, _ := unstructured.NestedSlice(work.Object, "spec", "workload", "manifests")
+				containers, _, _ := unstructured.NestedSlice(manifests[0].(map[string]interface{}), "spec", "template", "spec", "containers")
+				if got := containers[0].(map[string]interface{})["image"]; got != image {
+					t.Errorf("operator image of %s = %s, want %s", clusterName, got, image)
+				}
+				if tt.dryRun {
+					continue
+				}
+				workImage, _, _ := unstructured.NestedString(manifests[1].(map[string]interface{}), "spec", "workImagePullSpec")
+				if !strings.HasSuffix(workImage, "/work:2.3.0") {
+					t.Errorf("work image of %s = %s", clusterName, workImage)
+				}
+			}
+		})
+	}
+}
+
+func Test_retag(t *testing.T) {
+	for _, tt := range []struct{ image, registry, want string }{
+		{"quay.io/ocm/registration:2.2.0", "", "quay.io/ocm/registration:2.3.0"},
+		{"quay.io/ocm/work@sha256:abc", "", "quay.io/ocm/work:2.3.0"},
+		{"localhost:5000/ocm/work", "", "localhost:5000/ocm/work:2.3.0"},
+		{"work:2.2.0", "mirror.example.com", "mirror.example.com/work:2.3.0"},
+	} {
+		if got := retag(tt.image, tt.registry, "2.3.0"); got != tt.want {
+			t.Errorf("retag(%s, %s) = %s, want %s", tt.image, tt.registry, got, tt.want)
+		}
+	}
+	if got := imageTag("localhost:5000/ocm/work"); got != "latest" {
+		t.Errorf("imageTag() = %s, want latest", got)
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       Options
+		wantErr bool
+	}{
+		{name: "Success", o: Options{clusterNames: []string{"c1"}, bundleVersion: "2.3.0"}},
+		{name: "Failed, no clusters", o: Options{bundleVersion: "2.3.0"}, wantErr: true},
+		{name: "Failed, no version", o: Options{clusterNames: []string{"c1"}}, wantErr: true},
+		{name: "Failed, invalid version", o: Options{clusterNames: []string{"c1"}, bundleVersion: "2.3:0"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright Contributors to the Open Cluster Management project
+package klusterlet
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	configFlags   *genericclioptions.ConfigFlags
+	clusterNames  []string
+	bundleVersion string
+	registry      string
+	dryRun        bool
+	wait          bool
+	timeout       time.Duration
+	pollInterval  time.Duration
+	//ctx is canceled on Ctrl+C to abort the wait
+	ctx context.Context
+
+	genericclioptions.IOStreams
+}
+
+func newOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		configFlags:  genericclioptions.NewConfigFlags(true),
+		pollInterval: 5 * time.Second,
+		ctx:          context.Background(),
+
+		IOStreams: streams,
+	}
+}
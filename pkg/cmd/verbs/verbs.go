@@ -5,16 +5,79 @@ import (
 	"fmt"
 
 	appliercmd "github.com/open-cluster-management/applier/pkg/applier/cmd"
+	addondisable "github.com/open-cluster-management/cm-cli/pkg/cmd/addon/disable"
+	addonenable "github.com/open-cluster-management/cm-cli/pkg/cmd/addon/enable"
+	addonstatus "github.com/open-cluster-management/cm-cli/pkg/cmd/addon/status"
+	applicationcreate "github.com/open-cluster-management/cm-cli/pkg/cmd/application/create"
+	applicationlist "github.com/open-cluster-management/cm-cli/pkg/cmd/application/list"
+	applicationstatus "github.com/open-cluster-management/cm-cli/pkg/cmd/application/status"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/apply"
 	attachcluster "github.com/open-cluster-management/cm-cli/pkg/cmd/attach/cluster"
+	auditshow "github.com/open-cluster-management/cm-cli/pkg/cmd/audit/show"
+	checkhub "github.com/open-cluster-management/cm-cli/pkg/cmd/check/hub"
+	checkspoke "github.com/open-cluster-management/cm-cli/pkg/cmd/check/spoke"
+	clusterpoolclaim "github.com/open-cluster-management/cm-cli/pkg/cmd/clusterpool/claim"
+	clusterpoolcreate "github.com/open-cluster-management/cm-cli/pkg/cmd/clusterpool/create"
+	clusterpoollist "github.com/open-cluster-management/cm-cli/pkg/cmd/clusterpool/list"
+	clusterpoolrelease "github.com/open-cluster-management/cm-cli/pkg/cmd/clusterpool/release"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/collect"
 	createcluster "github.com/open-cluster-management/cm-cli/pkg/cmd/create/cluster"
+	creatework "github.com/open-cluster-management/cm-cli/pkg/cmd/create/work"
 	deletecluster "github.com/open-cluster-management/cm-cli/pkg/cmd/delete/cluster"
+	describecluster "github.com/open-cluster-management/cm-cli/pkg/cmd/describe/cluster"
 	detachcluster "github.com/open-cluster-management/cm-cli/pkg/cmd/detach/cluster"
+	diffcluster "github.com/open-cluster-management/cm-cli/pkg/cmd/diff/cluster"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/example"
+	exportinventory "github.com/open-cluster-management/cm-cli/pkg/cmd/export/inventory"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/gc"
+	getclusterclaims "github.com/open-cluster-management/cm-cli/pkg/cmd/get/clusterclaims"
+	getclusters "github.com/open-cluster-management/cm-cli/pkg/cmd/get/clusters"
+	getconditions "github.com/open-cluster-management/cm-cli/pkg/cmd/get/conditions"
+	getimport "github.com/open-cluster-management/cm-cli/pkg/cmd/get/import"
+	getnodes "github.com/open-cluster-management/cm-cli/pkg/cmd/get/nodes"
+	getwork "github.com/open-cluster-management/cm-cli/pkg/cmd/get/work"
+	importinventory "github.com/open-cluster-management/cm-cli/pkg/cmd/import/inventory"
+	inithub "github.com/open-cluster-management/cm-cli/pkg/cmd/init/hub"
+	joinhub "github.com/open-cluster-management/cm-cli/pkg/cmd/join/hub"
+	labelclusters "github.com/open-cluster-management/cm-cli/pkg/cmd/label/clusters"
+	migrateclusters "github.com/open-cluster-management/cm-cli/pkg/cmd/migrate/clusters"
+	movecluster "github.com/open-cluster-management/cm-cli/pkg/cmd/move/cluster"
+	observabilityenable "github.com/open-cluster-management/cm-cli/pkg/cmd/observability/enable"
+	observabilitystatus "github.com/open-cluster-management/cm-cli/pkg/cmd/observability/status"
+	policycreate "github.com/open-cluster-management/cm-cli/pkg/cmd/policy/create"
+	policylist "github.com/open-cluster-management/cm-cli/pkg/cmd/policy/list"
+	policystatus "github.com/open-cluster-management/cm-cli/pkg/cmd/policy/status"
+	protectcluster "github.com/open-cluster-management/cm-cli/pkg/cmd/protect/cluster"
+	rbacgenerate "github.com/open-cluster-management/cm-cli/pkg/cmd/rbac/generate"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/render"
+	reportcapacity "github.com/open-cluster-management/cm-cli/pkg/cmd/report/capacity"
+	retryimport "github.com/open-cluster-management/cm-cli/pkg/cmd/retry/import"
+	rotatebootstrap "github.com/open-cluster-management/cm-cli/pkg/cmd/rotate/bootstrap"
+	scenariosdescribe "github.com/open-cluster-management/cm-cli/pkg/cmd/scenarios/describe"
+	scenariosfunctions "github.com/open-cluster-management/cm-cli/pkg/cmd/scenarios/functions"
+	scenarioslist "github.com/open-cluster-management/cm-cli/pkg/cmd/scenarios/list"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/search"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/selftest"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/serve"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/status"
+	submarinerjoin "github.com/open-cluster-management/cm-cli/pkg/cmd/submariner/join"
+	taintcluster "github.com/open-cluster-management/cm-cli/pkg/cmd/taint/cluster"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/telemetry"
+	tokencreate "github.com/open-cluster-management/cm-cli/pkg/cmd/token/create"
+	tokendelete "github.com/open-cluster-management/cm-cli/pkg/cmd/token/delete"
+	tokenlist "github.com/open-cluster-management/cm-cli/pkg/cmd/token/list"
+	troubleshootcluster "github.com/open-cluster-management/cm-cli/pkg/cmd/troubleshoot/cluster"
+	unprotectcluster "github.com/open-cluster-management/cm-cli/pkg/cmd/unprotect/cluster"
+	untaintcluster "github.com/open-cluster-management/cm-cli/pkg/cmd/untaint/cluster"
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/update"
+	upgradeklusterlet "github.com/open-cluster-management/cm-cli/pkg/cmd/upgrade/klusterlet"
+	verifyimport "github.com/open-cluster-management/cm-cli/pkg/cmd/verify/import"
 	"github.com/spf13/cobra"
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
-//NewVerb creates a new verb
+// NewVerb creates a new verb
 func NewVerb(verb string, streams genericclioptions.IOStreams) *cobra.Command {
 	switch verb {
 	case "create":
@@ -22,7 +85,7 @@ func NewVerb(verb string, streams genericclioptions.IOStreams) *cobra.Command {
 	case "get":
 		return newVerbGet(verb, streams)
 	case "update":
-		return newVerbUpdate(verb, streams)
+		return update.NewCmd(streams)
 	case "delete":
 		return newVerbDelete(verb, streams)
 	case "list":
@@ -33,6 +96,86 @@ func NewVerb(verb string, streams genericclioptions.IOStreams) *cobra.Command {
 		return newVerbApplier(verb, streams)
 	case "detach":
 		return newVerbDetach(verb, streams)
+	case "move":
+		return newVerbMove(verb, streams)
+	case "policy":
+		return newVerbPolicy(verb, streams)
+	case "application":
+		return newVerbApplication(verb, streams)
+	case "clusterpool":
+		return newVerbClusterPool(verb, streams)
+	case "addon":
+		return newVerbAddon(verb, streams)
+	case "observability":
+		return newVerbObservability(verb, streams)
+	case "submariner":
+		return newVerbSubmariner(verb, streams)
+	case "init":
+		return newVerbInit(verb, streams)
+	case "join":
+		return newVerbJoin(verb, streams)
+	case "token":
+		return newVerbToken(verb, streams)
+	case "export":
+		return newVerbExport(verb, streams)
+	case "import":
+		return newVerbImport(verb, streams)
+	case "label":
+		return newVerbLabel(verb, streams)
+	case "taint":
+		return newVerbTaint(verb, streams)
+	case "untaint":
+		return newVerbUntaint(verb, streams)
+	case "protect":
+		return newVerbProtect(verb, streams)
+	case "unprotect":
+		return newVerbUnprotect(verb, streams)
+	case "troubleshoot":
+		return newVerbTroubleshoot(verb, streams)
+	case "check":
+		return newVerbCheck(verb, streams)
+	case "diff":
+		return newVerbDiff(verb, streams)
+	case "rbac":
+		return newVerbRBAC(verb, streams)
+	case "render":
+		return render.NewCmd(streams)
+	case "scenarios":
+		return newVerbScenarios(verb, streams)
+	case "apply":
+		return apply.NewCmd(streams)
+	case "collect":
+		return collect.NewCmd(streams)
+	case "status":
+		return status.NewCmd(streams)
+	case "telemetry":
+		return telemetry.NewCmd(streams)
+	case "audit":
+		return newVerbAudit(verb, streams)
+	case "serve":
+		return serve.NewCmd(streams, NewVerb)
+	case "gc":
+		return gc.NewCmd(streams)
+	case "selftest":
+		return selftest.NewCmd(streams)
+	case "search":
+		return search.NewCmd(streams)
+	case "example":
+		return example.NewCmd(streams)
+	case "verify":
+		return newVerbVerify(verb, streams)
+	case "describe":
+		return newVerbDescribe(verb, streams)
+	case "report":
+		return newVerbReport(verb, streams)
+	case "retry":
+		return newVerbRetry(verb, streams)
+	case "upgrade":
+		return newVerbUpgrade(verb, streams)
+	case "migrate":
+		return newVerbMigrate(verb, streams)
+	case "rotate":
+		return newVerbRotate(verb, streams)
 	}
 	panic(fmt.Sprintf("Unknow verb: %s", verb))
 }
@@ -43,6 +186,7 @@ func newVerbCreate(verb string, streams genericclioptions.IOStreams) *cobra.Comm
 	}
 	cmd.AddCommand(
 		createcluster.NewCmd(streams),
+		creatework.NewCmd(streams),
 	)
 
 	return cmd
@@ -50,18 +194,16 @@ func newVerbCreate(verb string, streams genericclioptions.IOStreams) *cobra.Comm
 
 func newVerbGet(verb string, streams genericclioptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   verb,
-		Short: "Not yet implemented",
-	}
-
-	return cmd
-}
-
-func newVerbUpdate(verb string, streams genericclioptions.IOStreams) *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   verb,
-		Short: "Not yet implemented",
+		Use: verb,
 	}
+	cmd.AddCommand(
+		getimport.NewCmd(streams),
+		getwork.NewCmd(streams),
+		getclusterclaims.NewCmd(streams),
+		getclusters.NewCmd(streams),
+		getnodes.NewCmd(streams),
+		getconditions.NewCmd(streams),
+	)
 
 	return cmd
 }
@@ -113,3 +255,392 @@ func newVerbDetach(verb string, streams genericclioptions.IOStreams) *cobra.Comm
 
 	return cmd
 }
+
+func newVerbInit(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Install a hub",
+	}
+
+	cmd.AddCommand(inithub.NewCmd(streams))
+
+	return cmd
+}
+
+func newVerbJoin(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Join a hub from the managed cluster",
+	}
+
+	cmd.AddCommand(joinhub.NewCmd(streams))
+
+	return cmd
+}
+
+func newVerbExport(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Export the hub state",
+	}
+
+	cmd.AddCommand(exportinventory.NewCmd(streams))
+
+	return cmd
+}
+
+func newVerbImport(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Import a hub state",
+	}
+
+	cmd.AddCommand(importinventory.NewCmd(streams))
+
+	return cmd
+}
+
+func newVerbLabel(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Label the managed clusters",
+	}
+
+	cmd.AddCommand(labelclusters.NewCmd(streams))
+
+	return cmd
+}
+
+func newVerbTroubleshoot(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Diagnose the managed clusters",
+	}
+
+	cmd.AddCommand(troubleshootcluster.NewCmd(streams))
+
+	return cmd
+}
+
+func newVerbRBAC(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Manage the hub permissions of the cli users",
+	}
+
+	cmd.AddCommand(rbacgenerate.NewCmd(streams))
+
+	return cmd
+}
+
+func newVerbMove(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Move a cluster to a new name",
+	}
+
+	cmd.AddCommand(movecluster.NewCmd(streams))
+
+	return cmd
+}
+
+func newVerbPolicy(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Manage the governance policies",
+	}
+
+	cmd.AddCommand(
+		policycreate.NewCmd(streams),
+		policylist.NewCmd(streams),
+		policystatus.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbApplication(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Manage the applications deployed on the managed clusters",
+	}
+
+	cmd.AddCommand(
+		applicationcreate.NewCmd(streams),
+		applicationlist.NewCmd(streams),
+		applicationstatus.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbClusterPool(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Manage the Hive clusterpools and their claims",
+	}
+
+	cmd.AddCommand(
+		clusterpoolcreate.NewCmd(streams),
+		clusterpoollist.NewCmd(streams),
+		clusterpoolclaim.NewCmd(streams),
+		clusterpoolrelease.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbAddon(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Manage the addons of the managed clusters",
+	}
+
+	cmd.AddCommand(
+		addonenable.NewCmd(streams),
+		addondisable.NewCmd(streams),
+		addonstatus.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbObservability(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Manage the observability of the managed clusters",
+	}
+
+	cmd.AddCommand(
+		observabilityenable.NewCmd(streams),
+		observabilitystatus.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbSubmariner(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Connect the networks of the managed clusters with submariner",
+	}
+
+	cmd.AddCommand(
+		submarinerjoin.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbCheck(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Check the health of the hub and the prerequisites of the clusters",
+	}
+
+	cmd.AddCommand(
+		checkhub.NewCmd(streams),
+		checkspoke.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbDiff(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Detect the drift of the hub resources",
+	}
+
+	cmd.AddCommand(
+		diffcluster.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbVerify(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Verify the manifests written by the cli against the hub",
+	}
+
+	cmd.AddCommand(
+		verifyimport.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbDescribe(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Describe the hub resources",
+	}
+
+	cmd.AddCommand(
+		describecluster.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbTaint(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Taint the managed clusters to prevent the placements from selecting them",
+	}
+
+	cmd.AddCommand(
+		taintcluster.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbUntaint(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Remove the taints of the managed clusters",
+	}
+
+	cmd.AddCommand(
+		untaintcluster.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbProtect(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Protect the managed clusters against the detach and the delete",
+	}
+
+	cmd.AddCommand(
+		protectcluster.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbUnprotect(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Remove the protection of the managed clusters",
+	}
+
+	cmd.AddCommand(
+		unprotectcluster.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbToken(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Manage the short-lived bootstrap tokens to join the hub",
+	}
+
+	cmd.AddCommand(
+		tokencreate.NewCmd(streams),
+		tokenlist.NewCmd(streams),
+		tokendelete.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbAudit(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Show the audit log of the mutating actions sent by the cli",
+	}
+
+	cmd.AddCommand(
+		auditshow.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbScenarios(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "List and describe the bundled scenarios applied by the commands",
+	}
+
+	cmd.AddCommand(
+		scenarioslist.NewCmd(streams),
+		scenariosdescribe.NewCmd(streams),
+		scenariosfunctions.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbRetry(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Retry a failed operation of the hub",
+	}
+
+	cmd.AddCommand(
+		retryimport.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbUpgrade(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Upgrade the agents of the managed clusters",
+	}
+
+	cmd.AddCommand(
+		upgradeklusterlet.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbReport(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Report on the managed clusters of the fleet",
+	}
+
+	cmd.AddCommand(
+		reportcapacity.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbMigrate(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Migrate the managed clusters to another hub",
+	}
+
+	cmd.AddCommand(
+		migrateclusters.NewCmd(streams),
+	)
+
+	return cmd
+}
+
+func newVerbRotate(verb string, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb,
+		Short: "Rotate the credentials of the managed clusters",
+	}
+
+	cmd.AddCommand(
+		rotatebootstrap.NewCmd(streams),
+	)
+
+	return cmd
+}
@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+package verify
+
+import (
+	"fmt"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmderrors"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Verify the import file written by the attach is the one the hub currently serves
+%[1]s verify import -f import.yaml
+
+# Verify the crds.yaml and import.yaml written by get import
+%[1]s verify import -f mycluster-import
+
+# Verify manifests written without the provenance annotations
+%[1]s verify import -f import.yaml --cluster-name mycluster
+`
+
+// NewCmd provides a cobra command comparing import manifests with the import secret of the hub
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Verify import manifests match the ones the hub currently serves",
+		Long: "Compare the manifests of an import file or directory with the crds.yaml and import.yaml of the import secret " +
+			"of the cluster, the cluster is read from the provenance annotations written by the attach and get import. " +
+			"The modified, missing and unexpected manifests are reported and the command fails",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.validate(); err != nil {
+				return cmderrors.NewValidationError(err)
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.filename, "filename", "f", "", "The import file or the directory of the crds.yaml and import.yaml to verify")
+	cmd.Flags().StringVar(&o.clusterName, "cluster-name", "", "The cluster of the import manifests, read from their provenance annotations if not set")
+	o.printOptions.AddFlags(cmd.Flags())
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
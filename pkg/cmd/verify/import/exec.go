@@ -0,0 +1,140 @@
+// Copyright Contributors to the Open Cluster Management project
+package verify
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/open-cluster-management/cm-cli/pkg/clients"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+// mismatch is a difference between the import manifests and the ones served by the hub
+type mismatch struct {
+	Resource string `json:"resource"`
+	Reason   string `json:"reason"`
+}
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.ctx = helpers.CommandContext(cmd)
+	if o.filename == "" {
+		return fmt.Errorf("the import file is required, set it with -f")
+	}
+	o.manifests, err = helpers.ReadManifests(o.filename)
+	if err != nil {
+		return err
+	}
+	o.provenance = helpers.GetImportProvenance(o.manifests)
+	if o.clusterName == "" && o.provenance != nil {
+		o.clusterName = o.provenance.SourceNamespace()
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if len(o.manifests) == 0 {
+		return fmt.Errorf("no manifests found in %s", o.filename)
+	}
+	if o.clusterName == "" {
+		return fmt.Errorf("%s has no provenance annotations, set the cluster with --cluster-name", o.filename)
+	}
+	return o.printOptions.Validate()
+}
+
+func (o *Options) run() error {
+	client, err := clients.ForFlags(o.configFlags).Client()
+	if err != nil {
+		return err
+	}
+	return o.runWithClient(client)
+}
+
+func (o *Options) runWithClient(client crclient.Client) error {
+	importSecret, err := helpers.GetImportSecret(o.ctx, client, o.clusterName)
+	if err != nil {
+		return err
+	}
+	crds, imports, err := helpers.GetImportManifests(importSecret)
+	if err != nil {
+		return err
+	}
+	served, err := helpers.DecodeManifests(append(append(crds, []byte("\n---\n")...), imports...), "the import secret of cluster "+o.clusterName)
+	if err != nil {
+		return err
+	}
+
+	mismatches := make([]mismatch, 0)
+	if hash := helpers.ImportContentHash(crds, imports); o.provenance != nil && o.provenance.ContentHash != hash {
+		mismatches = append(mismatches, mismatch{
+			Resource: "Secret/" + importSecret.Namespace + "/" + importSecret.Name,
+			Reason: fmt.Sprintf("written from resourceVersion %s (%s), the hub serves resourceVersion %s (%s)",
+				o.provenance.ResourceVersion, o.provenance.ContentHash, importSecret.ResourceVersion, hash),
+		})
+	}
+	mismatches = append(mismatches, compareManifests(o.manifests, served)...)
+
+	if len(mismatches) == 0 && o.printOptions.IsTable() {
+		fmt.Fprintf(o.Out, "%s matches the import manifests the hub serves for cluster %s\n", o.filename, o.clusterName)
+		return nil
+	}
+	table := &printers.Table{
+		Headers: []string{"RESOURCE", "REASON"},
+	}
+	for _, m := range mismatches {
+		table.AddRow(m.Resource, m.Reason)
+	}
+	if err := o.printOptions.Print(o.Out, table, mismatches); err != nil {
+		return err
+	}
+	if len(mismatches) != 0 {
+		return fmt.Errorf("%s does not match the import manifests the hub serves for cluster %s, %d differences found",
+			o.filename, o.clusterName, len(mismatches))
+	}
+	return nil
+}
+
+// compareManifests returns the manifests which differ, without their provenance annotations, from the served ones,
+// the served manifests missing in the file and the manifests of the file which are not served
+func compareManifests(manifests, served []*unstructured.Unstructured) []mismatch {
+	found := make(map[string]*unstructured.Unstructured, len(manifests))
+	keys := make([]string, 0, len(manifests))
+	for _, m := range manifests {
+		m = m.DeepCopy()
+		helpers.RemoveImportProvenance(m)
+		found[manifestKey(m)] = m
+		keys = append(keys, manifestKey(m))
+	}
+	mismatches := make([]mismatch, 0)
+	seen := make(map[string]bool, len(served))
+	for _, s := range served {
+		key := manifestKey(s)
+		seen[key] = true
+		m, ok := found[key]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, mismatch{Resource: key, Reason: "missing"})
+		case !reflect.DeepEqual(m.Object, s.Object):
+			mismatches = append(mismatches, mismatch{Resource: key, Reason: "modified"})
+		}
+	}
+	for _, key := range keys {
+		if !seen[key] {
+			mismatches = append(mismatches, mismatch{Resource: key, Reason: "not served by the hub"})
+		}
+	}
+	return mismatches
+}
+
+// manifestKey returns the Kind/namespace/name of a manifest
+func manifestKey(m *unstructured.Unstructured) string {
+	if m.GetNamespace() == "" {
+		return m.GetKind() + "/" + m.GetName()
+	}
+	return m.GetKind() + "/" + m.GetNamespace() + "/" + m.GetName()
+}
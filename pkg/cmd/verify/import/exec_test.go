@@ -0,0 +1,156 @@
+// Copyright Contributors to the Open Cluster Management project
+package verify
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+	"github.com/open-cluster-management/cm-cli/pkg/helpers/fake"
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testCRDs   = "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: klusterlets.operator.open-cluster-management.io\n"
+	testImport = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: open-cluster-management-agent\n"
+)
+
+// newServedImportSecret returns the import secret of cluster1 serving the imports
+func newServedImportSecret(imports string) *corev1.Secret {
+	secret := fake.NewImportSecret("cluster1", map[string][]byte{
+		helpers.ImportSecretCRDsKey:   []byte(testCRDs),
+		helpers.ImportSecretImportKey: []byte(imports),
+	})
+	secret.ResourceVersion = "1"
+	return secret
+}
+
+// writeImportFile writes the annotated manifests of the import secret as the attach does
+func writeImportFile(t *testing.T, dir string, importSecret *corev1.Secret) string {
+	crds, imports, err := helpers.GetAnnotatedImportManifests(importSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "import.yaml")
+	if err := ioutil.WriteFile(path, append(append(crds, []byte("---\n")...), imports...), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOptions_runWithClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "verify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	served := newServedImportSecret(testImport)
+	changed := newServedImportSecret(testImport + "---\napiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: klusterlet\n  namespace: open-cluster-management-agent\n")
+	changed.ResourceVersion = "2"
+
+	tests := []struct {
+		name        string
+		file        string
+		clusterName string
+		secret      *corev1.Secret
+		contains    []string
+		wantErr     bool
+	}{
+		{
+			name:     "Success",
+			file:     writeImportFile(t, dir, served),
+			secret:   served,
+			contains: []string{"matches the import manifests the hub serves for cluster cluster1"},
+		},
+		{
+			name:        "Success, without provenance",
+			file:        filepath.Join(dir, "plain.yaml"),
+			clusterName: "cluster1",
+			secret:      served,
+			contains:    []string{"matches"},
+		},
+		{
+			name:     "Failed, the hub serves new manifests",
+			file:     filepath.Join(dir, "import.yaml"),
+			secret:   changed,
+			contains: []string{"Secret/cluster1/cluster1-import", "resourceVersion 1", "resourceVersion 2", "ServiceAccount/open-cluster-management-agent/klusterlet", "missing"},
+			wantErr:  true,
+		},
+		{
+			name:     "Failed, modified manifest",
+			file:     filepath.Join(dir, "modified.yaml"),
+			secret:   served,
+			contains: []string{"Namespace/open-cluster-management-agent", "modified", "ConfigMap/extra", "not served by the hub"},
+			wantErr:  true,
+		},
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "plain.yaml"), []byte(testCRDs+"---\n"+testImport), 0600); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, "import.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	modified := strings.Replace(string(b), "name: open-cluster-management-agent", "name: open-cluster-management-agent\n  labels:\n    edited: \"true\"", 1) +
+		"---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: extra\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "modified.yaml"), []byte(modified), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &Options{
+				printOptions: printers.NewPrintOptions(),
+				filename:     tt.file,
+				clusterName:  tt.clusterName,
+				IOStreams:    streams,
+			}
+			if err := o.complete(nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := o.validate(); err != nil {
+				t.Fatal(err)
+			}
+			err := o.runWithClient(crclientfake.NewFakeClient(tt.secret))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("output must contain %q, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_validate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "verify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "plain.yaml")
+	if err := ioutil.WriteFile(path, []byte(testImport), 0600); err != nil {
+		t.Fatal(err)
+	}
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams)
+	if err := o.complete(nil, nil); err == nil {
+		t.Error("complete() expected an error without file")
+	}
+	o.filename = path
+	if err := o.complete(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.validate(); err == nil {
+		t.Error("validate() expected an error without provenance nor cluster")
+	}
+}
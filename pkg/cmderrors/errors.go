@@ -0,0 +1,120 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package cmderrors
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ValidationError is returned when the arguments, the flags or the values of a command are invalid
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// NewValidationError returns err as a ValidationError, nil if err is nil.
+// The errors already typed, the connection failures and the errors of the API server are returned unchanged.
+func NewValidationError(err error) error {
+	var statusErr apierrors.APIStatus
+	if err == nil || isTyped(err) || IsUnreachable(err) || errors.As(err, &statusErr) {
+		return err
+	}
+	return &ValidationError{Err: err}
+}
+
+// HubUnreachableError is returned when the API server of the hub can not be reached
+type HubUnreachableError struct {
+	Host string
+	Err  error
+}
+
+func (e *HubUnreachableError) Error() string {
+	if e.Host == "" {
+		return fmt.Sprintf("the hub is not reachable: %s", e.Err.Error())
+	}
+	return fmt.Sprintf("the hub %s is not reachable: %s", e.Host, e.Err.Error())
+}
+
+func (e *HubUnreachableError) Unwrap() error {
+	return e.Err
+}
+
+// SpokeUnreachableError is returned when the API server of a managed cluster can not be reached
+type SpokeUnreachableError struct {
+	Host string
+	Err  error
+}
+
+func (e *SpokeUnreachableError) Error() string {
+	return fmt.Sprintf("the cluster %s is not reachable: %s", e.Host, e.Err.Error())
+}
+
+func (e *SpokeUnreachableError) Unwrap() error {
+	return e.Err
+}
+
+// ImportTimeoutError is returned when the import secret of a cluster is not generated in time,
+// Causes are the likely root causes found on the hub
+type ImportTimeoutError struct {
+	ClusterName string
+	Timeout     time.Duration
+	Causes      []string
+}
+
+func (e *ImportTimeoutError) Error() string {
+	msg := fmt.Sprintf("the import secret %s-import was not generated after %s", e.ClusterName, e.Timeout)
+	if len(e.Causes) == 0 {
+		return msg + ", no root cause found on the hub, check the managedcluster-import-controller logs"
+	}
+	msg += ", likely root causes:"
+	for _, c := range e.Causes {
+		msg += "\n - " + c
+	}
+	return msg
+}
+
+// IsUnreachable returns true if err is a failure to connect to an API server,
+// as opposed to an error returned by the API server
+func IsUnreachable(err error) bool {
+	var netErr net.Error
+	var urlErr *url.Error
+	return errors.As(err, &netErr) || errors.As(err, &urlErr)
+}
+
+// Classify returns the typed error of err, the connection failures not typed by the commands
+// are failures to reach the hub
+func Classify(err error) error {
+	if err == nil || isTyped(err) || !IsUnreachable(err) {
+		return err
+	}
+	host := ""
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if u, perr := url.Parse(urlErr.URL); perr == nil && u.Host != "" {
+			host = u.Scheme + "://" + u.Host
+		}
+	}
+	return &HubUnreachableError{Host: host, Err: err}
+}
+
+func isTyped(err error) bool {
+	var validationErr *ValidationError
+	var hubErr *HubUnreachableError
+	var spokeErr *SpokeUnreachableError
+	var importErr *ImportTimeoutError
+	return errors.As(err, &validationErr) || errors.As(err, &hubErr) ||
+		errors.As(err, &spokeErr) || errors.As(err, &importErr)
+}
@@ -0,0 +1,127 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package cmderrors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func unreachableErr() error {
+	return &url.Error{Op: "Get", URL: "https://hub.example.com:6443/api?timeout=32s", Err: syscall.ECONNREFUSED}
+}
+
+func TestNewValidationError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Nil", err: nil},
+		{name: "Invalid value", err: fmt.Errorf("cluster name is missing"), want: true},
+		{name: "Unreachable", err: unreachableErr()},
+		{name: "API server error", err: apierrors.NewForbidden(schema.GroupResource{Resource: "managedclusters"}, "cluster1", errors.New("denied"))},
+		{name: "Typed", err: &ImportTimeoutError{ClusterName: "cluster1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewValidationError(tt.err)
+			var validationErr *ValidationError
+			if got := errors.As(err, &validationErr); got != tt.want {
+				t.Errorf("NewValidationError() = %v, want a ValidationError %v", err, tt.want)
+			}
+			if tt.err == nil && err != nil {
+				t.Errorf("NewValidationError() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	err := Classify(fmt.Errorf("other"))
+	if err.Error() != "other" {
+		t.Errorf("Classify() = %v, want the error unchanged", err)
+	}
+	spokeErr := &SpokeUnreachableError{Host: "https://spoke:6443", Err: unreachableErr()}
+	if err := Classify(spokeErr); err != spokeErr {
+		t.Errorf("Classify() = %v, want the spoke error", err)
+	}
+	err = Classify(unreachableErr())
+	var hubErr *HubUnreachableError
+	if !errors.As(err, &hubErr) {
+		t.Fatalf("Classify() = %v, want a HubUnreachableError", err)
+	}
+	if hubErr.Host != "https://hub.example.com:6443" {
+		t.Errorf("Host = %s", hubErr.Host)
+	}
+}
+
+func TestImportTimeoutError_Error(t *testing.T) {
+	err := &ImportTimeoutError{ClusterName: "cluster1", Timeout: 2 * time.Minute}
+	if !strings.Contains(err.Error(), "no root cause found") {
+		t.Errorf("Error() = %s", err.Error())
+	}
+	err.Causes = []string{"Import controller: not running"}
+	if !strings.Contains(err.Error(), "likely root causes:\n - Import controller: not running") {
+		t.Errorf("Error() = %s", err.Error())
+	}
+}
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		contains []string
+	}{
+		{
+			name:     "Validation",
+			err:      NewValidationError(fmt.Errorf("values are missing")),
+			contains: []string{"Cause: The arguments", "--help", "#commands"},
+		},
+		{
+			name:     "Hub unreachable",
+			err:      Classify(unreachableErr()),
+			contains: []string{"API server of the hub", "kubectl config current-context", "#authentication"},
+		},
+		{
+			name:     "Spoke unreachable",
+			err:      fmt.Errorf("attach: %w", &SpokeUnreachableError{Host: "https://spoke:6443", Err: unreachableErr()}),
+			contains: []string{"https://spoke:6443", "--import-file", "#checking-a-cluster-before-attaching-it"},
+		},
+		{
+			name:     "Import timeout",
+			err:      &ImportTimeoutError{ClusterName: "cluster1", Timeout: time.Minute},
+			contains: []string{"cluster1", "cm troubleshoot cluster cluster1", "#troubleshooting-a-cluster"},
+		},
+		{
+			name:     "Forbidden",
+			err:      apierrors.NewForbidden(schema.GroupResource{Resource: "managedclusters"}, "cluster1", errors.New("denied")),
+			contains: []string{"not allowed", "cm rbac generate", "#hub-permissions"},
+		},
+		{
+			name:     "Unknown",
+			err:      fmt.Errorf("other"),
+			contains: []string{"No explanation available"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			Print(out, tt.err)
+			for _, c := range tt.contains {
+				if !strings.Contains(out.String(), c) {
+					t.Errorf("Print() must contain %s, got:\n%s", c, out.String())
+				}
+			}
+		})
+	}
+}
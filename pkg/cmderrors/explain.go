@@ -0,0 +1,109 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package cmderrors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/pflag"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const docsURL = "https://github.com/open-cluster-management/cm-cli"
+
+// ExplainErrors prints the explanation of the error of a failed command, set by the global --explain-error flag
+var ExplainErrors = false
+
+// AddFlags adds the --explain-error flag to the flagset
+func AddFlags(flagSet *pflag.FlagSet) {
+	flagSet.BoolVar(&ExplainErrors, "explain-error", ExplainErrors, "On failure, print the cause of the error, the likely fixes and the relevant documentation")
+}
+
+// Explanation describes an error for the user
+type Explanation struct {
+	Cause string
+	Fixes []string
+	// Docs is the anchor of the README section documenting the failed operation
+	Docs string
+}
+
+// Explain returns the explanation of err, nil if the error is unknown
+func Explain(err error) *Explanation {
+	var validationErr *ValidationError
+	var hubErr *HubUnreachableError
+	var spokeErr *SpokeUnreachableError
+	var importErr *ImportTimeoutError
+	switch {
+	case errors.As(err, &validationErr):
+		return &Explanation{
+			Cause: "The arguments, the flags or the values of the command are invalid, nothing was changed.",
+			Fixes: []string{
+				"Check the flags and the examples of the command with --help",
+				"Check the values file against the values template printed by --help",
+			},
+			Docs: "commands",
+		}
+	case errors.As(err, &hubErr):
+		return &Explanation{
+			Cause: "The API server of the hub could not be reached with the current kubeconfig.",
+			Fixes: []string{
+				"Check the hub of the current context with: kubectl config current-context",
+				"Check the hub is running and reachable from this machine, through the VPN or the proxy if any",
+				"Check the certificate authority of the kubeconfig if the error mentions x509",
+			},
+			Docs: "authentication",
+		}
+	case errors.As(err, &spokeErr):
+		return &Explanation{
+			Cause: fmt.Sprintf("The API server %s of the cluster to attach could not be reached.", spokeErr.Host),
+			Fixes: []string{
+				"Check the server and the credentials of the cluster kubeconfig or token",
+				"Check the cluster is reachable from this machine with: cm check spoke --cluster-kubeconfig <file>",
+				"Attach the cluster without connecting to it with --import-file or --bundle and apply the import on the cluster",
+			},
+			Docs: "checking-a-cluster-before-attaching-it",
+		}
+	case errors.As(err, &importErr):
+		return &Explanation{
+			Cause: fmt.Sprintf("The import controller of the hub did not generate the import secret of cluster %s in time.", importErr.ClusterName),
+			Fixes: []string{
+				"Check the hub controllers with: cm check hub",
+				fmt.Sprintf("Diagnose the cluster with: cm troubleshoot cluster %s", importErr.ClusterName),
+				"Increase the timeout with --import-secret-timeout on a loaded hub",
+			},
+			Docs: "troubleshooting-a-cluster",
+		}
+	case apierrors.IsUnauthorized(err):
+		return &Explanation{
+			Cause: "The credentials of the kubeconfig were rejected by the hub.",
+			Fixes: []string{
+				"Log in again, the token of the kubeconfig may have expired",
+			},
+			Docs: "authentication",
+		}
+	case apierrors.IsForbidden(err):
+		return &Explanation{
+			Cause: "The user of the kubeconfig is not allowed to run the command on the hub.",
+			Fixes: []string{
+				"Ask an administrator of the hub for the role of the persona of the command, generated with: cm rbac generate",
+			},
+			Docs: "hub-permissions",
+		}
+	}
+	return nil
+}
+
+// Print prints the explanation of err
+func Print(w io.Writer, err error) {
+	e := Explain(err)
+	if e == nil {
+		fmt.Fprintf(w, "\nNo explanation available for this error.\n")
+		return
+	}
+	fmt.Fprintf(w, "\nCause: %s\n", e.Cause)
+	fmt.Fprintf(w, "Likely fixes:\n - %s\n", strings.Join(e.Fixes, "\n - "))
+	fmt.Fprintf(w, "Documentation: %s#%s\n", docsURL, e.Docs)
+}
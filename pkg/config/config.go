@@ -0,0 +1,96 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	configDir  = ".cm"
+	configFile = "config.yaml"
+	auditFile  = "audit.log"
+)
+
+// Config is the user configuration of the cli stored in ~/.cm/config.yaml
+type Config struct {
+	Telemetry Telemetry `json:"telemetry,omitempty"`
+	Audit     Audit     `json:"audit,omitempty"`
+	Update    Update    `json:"update,omitempty"`
+}
+
+// Telemetry is the opt-in configuration of the anonymous usage metrics
+type Telemetry struct {
+	Enabled  bool   `json:"enabled"`
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// Audit configures the log of the mutating requests sent by the cli, enabled by default
+type Audit struct {
+	Disabled bool `json:"disabled,omitempty"`
+	// Sink is the file receiving the audit entries or an http(s) endpoint to which they are posted,
+	// ~/.cm/audit.log if not set
+	Sink string `json:"sink,omitempty"`
+}
+
+// Update configures where the update command downloads the releases of the cli
+type Update struct {
+	// Mirror is the url or the directory of a mirror of the releases, for the hosts without access to GitHub
+	Mirror string `json:"mirror,omitempty"`
+	// Channel is stable or latest, stable if not set
+	Channel string `json:"channel,omitempty"`
+	// PublicKey is the base64 ed25519 public key verifying the signature of the checksums of a release, the release key of the build if not set
+	PublicKey string `json:"publicKey,omitempty"`
+	// Proxy is the proxy the releases are downloaded through, the HTTPS_PROXY environment variable if not set
+	Proxy string `json:"proxy,omitempty"`
+}
+
+// DefaultPath returns the path of the user configuration file
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, configDir, configFile), nil
+}
+
+// DefaultAuditPath returns the path of the audit log when no sink is configured
+func DefaultAuditPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, configDir, auditFile), nil
+}
+
+// Load reads the configuration file, an empty configuration is returned if the file doesn't exist
+func Load(path string) (*Config, error) {
+	c := &Config{}
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Save writes the configuration file, creating its directory if needed
+func (c *Config) Save(path string) error {
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
@@ -0,0 +1,40 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestConfig_SaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, ".cm", "config.yaml")
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(c, &Config{}) {
+		t.Errorf("expected an empty config got %v", c)
+	}
+
+	c.Telemetry = Telemetry{Enabled: true, Endpoint: "http://localhost:8080"}
+	if err := c.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, c) {
+		t.Errorf("Load() = %v, want %v", got, c)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package credentials
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// CredentialProvider resolves the references to the credentials kept in a store, such as a vault or the OS keychain,
+// so the tokens and kubeconfigs are not written in plaintext in the values files
+type CredentialProvider interface {
+	// Scheme is the scheme of the references resolved by the provider, vault for vault://path#key
+	Scheme() string
+	// Resolve returns the credential of the reference
+	Resolve(ref Reference) (string, error)
+}
+
+// Reference is a reference to a credential, <scheme>://<path>#<key>
+type Reference struct {
+	Scheme string
+	Path   string
+	Key    string
+}
+
+func (r Reference) String() string {
+	s := r.Scheme + "://" + r.Path
+	if r.Key != "" {
+		s += "#" + r.Key
+	}
+	return s
+}
+
+var (
+	lock      sync.RWMutex
+	providers = make(map[string]CredentialProvider)
+)
+
+// Register adds a provider, replacing the provider of the same scheme
+func Register(p CredentialProvider) {
+	lock.Lock()
+	defer lock.Unlock()
+	providers[p.Scheme()] = p
+}
+
+func provider(scheme string) (CredentialProvider, bool) {
+	lock.RLock()
+	defer lock.RUnlock()
+	p, ok := providers[scheme]
+	return p, ok
+}
+
+// ParseReference returns the reference of the value if its scheme is the one of a registered provider,
+// the other values, including the urls, are not references
+func ParseReference(value string) (Reference, bool) {
+	i := strings.Index(value, "://")
+	if i <= 0 {
+		return Reference{}, false
+	}
+	if _, ok := provider(value[:i]); !ok {
+		return Reference{}, false
+	}
+	ref := Reference{Scheme: value[:i], Path: value[i+3:]}
+	if j := strings.LastIndex(ref.Path, "#"); j >= 0 {
+		ref.Path, ref.Key = ref.Path[:j], ref.Path[j+1:]
+	}
+	return ref, true
+}
+
+// ResolveString returns the credential of the value if it is a reference, the value otherwise
+func ResolveString(value string) (string, error) {
+	ref, ok := ParseReference(value)
+	if !ok {
+		return value, nil
+	}
+	p, _ := provider(ref.Scheme)
+	credential, err := p.Resolve(ref)
+	if err != nil {
+		//The error never contains the credential, only its reference
+		return "", fmt.Errorf("failed to resolve %s: %s", ref, err.Error())
+	}
+	return credential, nil
+}
+
+// ResolveValues replaces the string values at the paths, such as hub.token, which are references by their credentials,
+// each reference is resolved once. The values at the other paths are never resolved, so a reference can only be
+// read into a value which is a credential.
+func ResolveValues(values map[string]interface{}, paths ...string) error {
+	resolved := make(map[string]string)
+	for _, path := range paths {
+		fields := strings.Split(path, ".")
+		m := values
+		for _, field := range fields[:len(fields)-1] {
+			next, ok := m[field].(map[string]interface{})
+			if !ok {
+				m = nil
+				break
+			}
+			m = next
+		}
+		key := fields[len(fields)-1]
+		v, ok := m[key].(string)
+		if !ok {
+			continue
+		}
+		credential, ok := resolved[v]
+		if !ok {
+			var err error
+			credential, err = ResolveString(v)
+			if err != nil {
+				return err
+			}
+			resolved[v] = credential
+		}
+		m[key] = credential
+	}
+	return nil
+}
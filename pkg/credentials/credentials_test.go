@@ -0,0 +1,95 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package credentials
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeProvider resolves the references of the fake scheme from a map, counting the resolutions
+type fakeProvider struct {
+	credentials map[string]string
+	calls       int
+}
+
+func (p *fakeProvider) Scheme() string {
+	return "fake"
+}
+
+func (p *fakeProvider) Resolve(ref Reference) (string, error) {
+	p.calls++
+	c, ok := p.credentials[ref.Path+"#"+ref.Key]
+	if !ok {
+		return "", fmt.Errorf("not found")
+	}
+	return c, nil
+}
+
+func TestParseReference(t *testing.T) {
+	Register(&fakeProvider{})
+	tests := []struct {
+		value string
+		want  Reference
+		ok    bool
+	}{
+		{value: "fake://clusters/prod#token", want: Reference{Scheme: "fake", Path: "clusters/prod", Key: "token"}, ok: true},
+		{value: "fake://clusters/prod", want: Reference{Scheme: "fake", Path: "clusters/prod"}, ok: true},
+		{value: "https://api.example.com:6443#token"},
+		{value: "my-token"},
+		{value: "://clusters/prod#token"},
+	}
+	for _, tt := range tests {
+		got, ok := ParseReference(tt.value)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseReference(%s) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+		if ok && got.String() != tt.value {
+			t.Errorf("String() = %s, want %s", got.String(), tt.value)
+		}
+	}
+}
+
+func TestResolveValues(t *testing.T) {
+	p := &fakeProvider{credentials: map[string]string{"clusters/prod#token": "my-token"}}
+	Register(p)
+	values := map[string]interface{}{
+		"token":  "fake://clusters/prod#token",
+		"server": "https://api.example.com:6443",
+		"hub": map[string]interface{}{
+			"token": "fake://clusters/prod#token",
+		},
+		"labels": map[string]interface{}{
+			"token": "fake://clusters/prod#token",
+		},
+		"enabled": true,
+	}
+	if err := ResolveValues(values, "token", "hub.token", "kubeConfig", "missing.token"); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"token":  "my-token",
+		"server": "https://api.example.com:6443",
+		"hub": map[string]interface{}{
+			"token": "my-token",
+		},
+		//The values which are not credentials are not resolved
+		"labels": map[string]interface{}{
+			"token": "fake://clusters/prod#token",
+		},
+		"enabled": true,
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("ResolveValues() = %v, want %v", values, want)
+	}
+	if p.calls != 1 {
+		t.Errorf("the reference must be resolved once, got %d resolutions", p.calls)
+	}
+
+	err := ResolveValues(map[string]interface{}{"token": "fake://clusters/dev#token"}, "token")
+	if err == nil || !strings.Contains(err.Error(), "fake://clusters/dev#token") {
+		t.Errorf("the error must name the reference, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package credentials
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// KeychainProvider reads the references keychain://<service>#<account> from the OS keychain, the macOS keychain
+// with the security command and the Secret Service of Linux (GNOME Keyring, KWallet) with the secret-tool command
+type KeychainProvider struct {
+	GOOS string
+	// Run runs the command and returns its standard output
+	Run func(name string, args ...string) ([]byte, error)
+}
+
+func init() {
+	Register(&KeychainProvider{GOOS: runtime.GOOS, Run: runCommand})
+}
+
+func runCommand(name string, args ...string) ([]byte, error) {
+	stderr := &bytes.Buffer{}
+	//#nosec G204 the command is one of the keychain commands, the arguments are not interpreted by a shell
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil && stderr.Len() != 0 {
+		return nil, fmt.Errorf("%s: %s", err.Error(), strings.TrimSpace(stderr.String()))
+	}
+	return out, err
+}
+
+func (p *KeychainProvider) Scheme() string {
+	return "keychain"
+}
+
+func (p *KeychainProvider) Resolve(ref Reference) (string, error) {
+	if ref.Path == "" {
+		return "", fmt.Errorf("a keychain reference is keychain://<service>#<account>")
+	}
+	var out []byte
+	var err error
+	switch p.GOOS {
+	case "darwin":
+		args := []string{"find-generic-password", "-s", ref.Path, "-w"}
+		if ref.Key != "" {
+			args = append(args, "-a", ref.Key)
+		}
+		out, err = p.Run("security", args...)
+	case "linux":
+		args := []string{"lookup", "service", ref.Path}
+		if ref.Key != "" {
+			args = append(args, "account", ref.Key)
+		}
+		out, err = p.Run("secret-tool", args...)
+	default:
+		return "", fmt.Errorf("the keychain is not supported on %s, use a vault reference", p.GOOS)
+	}
+	if err != nil {
+		return "", err
+	}
+	credential := strings.TrimSuffix(string(out), "\n")
+	if credential == "" {
+		return "", fmt.Errorf("no credential found in the keychain")
+	}
+	return credential, nil
+}
@@ -0,0 +1,72 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package credentials
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeychainProvider_Resolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		goos     string
+		ref      Reference
+		output   string
+		wantArgs []string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "Success, macOS",
+			goos:     "darwin",
+			ref:      Reference{Scheme: "keychain", Path: "cm-cli", Key: "prod"},
+			output:   "my-token\n",
+			wantArgs: []string{"security", "find-generic-password", "-s", "cm-cli", "-w", "-a", "prod"},
+			want:     "my-token",
+		},
+		{
+			name:     "Success, linux",
+			goos:     "linux",
+			ref:      Reference{Scheme: "keychain", Path: "cm-cli", Key: "prod"},
+			output:   "my-token",
+			wantArgs: []string{"secret-tool", "lookup", "service", "cm-cli", "account", "prod"},
+			want:     "my-token",
+		},
+		{
+			name:     "Failed, not found",
+			goos:     "linux",
+			ref:      Reference{Scheme: "keychain", Path: "cm-cli"},
+			wantArgs: []string{"secret-tool", "lookup", "service", "cm-cli"},
+			wantErr:  true,
+		},
+		{
+			name:    "Failed, windows",
+			goos:    "windows",
+			ref:     Reference{Scheme: "keychain", Path: "cm-cli"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var args []string
+			p := &KeychainProvider{
+				GOOS: tt.goos,
+				Run: func(name string, a ...string) ([]byte, error) {
+					args = append([]string{name}, a...)
+					return []byte(tt.output), nil
+				},
+			}
+			got, err := p.Resolve(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("command = %v, want %v", args, tt.wantArgs)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package credentials
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const vaultTimeout = 30 * time.Second
+
+// VaultProvider reads the references vault://<path>#<key> from the key of the secret at the path of a Vault server,
+// such as vault://secret/data/clusters/prod#token for a KV version 2 engine. The server and the token are the ones
+// of the vault cli, VAULT_ADDR and VAULT_TOKEN or ~/.vault-token, with VAULT_NAMESPACE, VAULT_CACERT and VAULT_SKIP_VERIFY
+type VaultProvider struct {
+	LookupEnv func(string) (string, bool)
+}
+
+func init() {
+	Register(&VaultProvider{LookupEnv: os.LookupEnv})
+}
+
+func (p *VaultProvider) Scheme() string {
+	return "vault"
+}
+
+func (p *VaultProvider) Resolve(ref Reference) (string, error) {
+	if ref.Path == "" || ref.Key == "" {
+		return "", fmt.Errorf("a vault reference is vault://<path>#<key>")
+	}
+	addr, ok := p.LookupEnv("VAULT_ADDR")
+	if !ok || addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+	token, err := p.token()
+	if err != nil {
+		return "", err
+	}
+	client, err := p.httpClient()
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(addr, "/")+"/v1/"+strings.TrimPrefix(ref.Path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace, ok := p.LookupEnv("VAULT_NAMESPACE"); ok && namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("secret %s not found", ref.Path)
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("vault returned %s", resp.Status)
+	}
+	secret := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", err
+	}
+	data := secret.Data
+	//The KV version 2 engine nests the data of the secret with its metadata
+	if nested, ok := data["data"].(map[string]interface{}); ok && data["metadata"] != nil {
+		data = nested
+	}
+	v, ok := data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in the secret %s", ref.Key, ref.Path)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("key %s of the secret %s is not a string", ref.Key, ref.Path)
+	}
+	return s, nil
+}
+
+// token returns the token of the environment or the one the vault cli stores after a login
+func (p *VaultProvider) token() (string, error) {
+	if token, ok := p.LookupEnv("VAULT_TOKEN"); ok && token != "" {
+		return token, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	b, err := ioutil.ReadFile(filepath.Join(home, ".vault-token"))
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("VAULT_TOKEN is not set and there is no ~/.vault-token, log in with vault login")
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func (p *VaultProvider) httpClient() (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caCert, ok := p.LookupEnv("VAULT_CACERT"); ok && caCert != "" {
+		b, err := ioutil.ReadFile(filepath.Clean(caCert))
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no certificate found in VAULT_CACERT %s", caCert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if skip, ok := p.LookupEnv("VAULT_SKIP_VERIFY"); ok && skip != "" {
+		insecure, err := strconv.ParseBool(skip)
+		if err != nil {
+			return nil, fmt.Errorf("invalid VAULT_SKIP_VERIFY %s", skip)
+		}
+		//#nosec G402 explicitly requested with VAULT_SKIP_VERIFY as for the vault cli
+		tlsConfig.InsecureSkipVerify = insecure
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport, Timeout: vaultTimeout}, nil
+}
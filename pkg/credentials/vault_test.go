@@ -0,0 +1,74 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package credentials
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVaultProvider_Resolve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/clusters/prod":
+			w.Write([]byte(`{"data":{"data":{"token":"kv2-token","port":6443},"metadata":{"version":1}}}`))
+		case "/v1/kv/clusters/prod":
+			w.Write([]byte(`{"data":{"token":"kv1-token"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		ref     string
+		env     map[string]string
+		want    string
+		wantErr string
+	}{
+		{name: "Success, kv version 2", ref: "vault://secret/data/clusters/prod#token", want: "kv2-token"},
+		{name: "Success, kv version 1", ref: "vault://kv/clusters/prod#token", want: "kv1-token"},
+		{name: "Failed, no key", ref: "vault://kv/clusters/prod", wantErr: "vault://<path>#<key>"},
+		{name: "Failed, missing key", ref: "vault://kv/clusters/prod#kubeconfig", wantErr: "key kubeconfig not found"},
+		{name: "Failed, not a string", ref: "vault://secret/data/clusters/prod#port", wantErr: "not a string"},
+		{name: "Failed, missing secret", ref: "vault://kv/clusters/dev#token", wantErr: "not found"},
+		{name: "Failed, forbidden", ref: "vault://kv/clusters/prod#token", env: map[string]string{"VAULT_TOKEN": "other"}, wantErr: "403"},
+		{name: "Failed, no address", ref: "vault://kv/clusters/prod#token", env: map[string]string{"VAULT_ADDR": ""}, wantErr: "VAULT_ADDR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{"VAULT_ADDR": server.URL, "VAULT_TOKEN": "root"}
+			for k, v := range tt.env {
+				env[k] = v
+			}
+			p := &VaultProvider{LookupEnv: func(name string) (string, bool) {
+				v, ok := env[name]
+				return v, ok
+			}}
+			ref, ok := ParseReference(tt.ref)
+			if !ok {
+				t.Fatalf("%s is not a reference", tt.ref)
+			}
+			got, err := p.Resolve(ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error containing %q expected, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
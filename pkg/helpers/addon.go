@@ -0,0 +1,99 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AddonInstallNamespace is the namespace of the managed cluster in which the addon agents are installed
+const AddonInstallNamespace = "open-cluster-management-agent-addon"
+
+// klusterletAddons maps the addons managed by the KlusterletAddonConfig to their field of its spec,
+// the klusterlet-addon-controller creates or deletes their ManagedClusterAddOn
+var klusterletAddons = map[string]string{
+	"application-manager":         "applicationManager",
+	"governance-policy-framework": "policyController",
+	"search-collector":            "searchCollector",
+	"cert-policy-controller":      "certPolicyController",
+	"iam-policy-controller":       "iamPolicyController",
+}
+
+// KlusterletAddons returns the sorted names of the addons managed by the KlusterletAddonConfig
+func KlusterletAddons() []string {
+	names := make([]string, 0, len(klusterletAddons))
+	for name := range klusterletAddons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EnableAddon enables the addon on the cluster, through the KlusterletAddonConfig of the cluster
+// if it manages the addon, otherwise by creating the ManagedClusterAddOn
+func EnableAddon(ctx context.Context, client crclient.Client, clusterName, addonName, installNamespace string) error {
+	managed, err := setKlusterletAddon(ctx, client, clusterName, addonName, true)
+	if err != nil || managed {
+		return err
+	}
+	addon := &unstructured.Unstructured{}
+	addon.SetGroupVersionKind(ManagedClusterAddOnGVK)
+	addon.SetName(addonName)
+	addon.SetNamespace(clusterName)
+	if err := unstructured.SetNestedField(addon.Object, installNamespace, "spec", "installNamespace"); err != nil {
+		return err
+	}
+	err = client.Create(ctx, addon)
+	if errors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// DisableAddon disables the addon on the cluster in its KlusterletAddonConfig if it manages the addon
+// and deletes the ManagedClusterAddOn
+func DisableAddon(ctx context.Context, client crclient.Client, clusterName, addonName string) error {
+	if _, err := setKlusterletAddon(ctx, client, clusterName, addonName, false); err != nil {
+		return err
+	}
+	addon := &unstructured.Unstructured{}
+	addon.SetGroupVersionKind(ManagedClusterAddOnGVK)
+	addon.SetName(addonName)
+	addon.SetNamespace(clusterName)
+	if err := client.Delete(ctx, addon); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// setKlusterletAddon sets the enabled field of the addon in the KlusterletAddonConfig of the cluster,
+// it returns false if the addon is not managed by a KlusterletAddonConfig
+func setKlusterletAddon(ctx context.Context, client crclient.Client, clusterName, addonName string, enabled bool) (bool, error) {
+	field, ok := klusterletAddons[addonName]
+	if !ok {
+		return false, nil
+	}
+	kac := &unstructured.Unstructured{}
+	kac.SetGroupVersionKind(KlusterletAddonConfigGVK)
+	err := client.Get(ctx, types.NamespacedName{Name: clusterName, Namespace: clusterName}, kac)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if current, found, _ := unstructured.NestedBool(kac.Object, "spec", field, "enabled"); found && current == enabled {
+		return true, nil
+	}
+	if err := unstructured.SetNestedField(kac.Object, enabled, "spec", field, "enabled"); err != nil {
+		return false, fmt.Errorf("unable to set %s in the KlusterletAddonConfig %s: %s", field, clusterName, err.Error())
+	}
+	return true, client.Update(ctx, kac)
+}
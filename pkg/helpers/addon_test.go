@@ -0,0 +1,108 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newKlusterletAddonConfig(clusterName string) *unstructured.Unstructured {
+	kac := &unstructured.Unstructured{}
+	kac.SetGroupVersionKind(KlusterletAddonConfigGVK)
+	kac.SetName(clusterName)
+	kac.SetNamespace(clusterName)
+	unstructured.SetNestedField(kac.Object, false, "spec", "searchCollector", "enabled")
+	return kac
+}
+
+func getAddon(client crclient.Client, clusterName, addonName string) error {
+	addon := &unstructured.Unstructured{}
+	addon.SetGroupVersionKind(ManagedClusterAddOnGVK)
+	return client.Get(context.TODO(), types.NamespacedName{Name: addonName, Namespace: clusterName}, addon)
+}
+
+func getKlusterletAddonEnabled(t *testing.T, client crclient.Client, clusterName, field string) bool {
+	kac := &unstructured.Unstructured{}
+	kac.SetGroupVersionKind(KlusterletAddonConfigGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: clusterName, Namespace: clusterName}, kac); err != nil {
+		t.Fatal(err)
+	}
+	enabled, _, _ := unstructured.NestedBool(kac.Object, "spec", field, "enabled")
+	return enabled
+}
+
+func TestEnableAddon(t *testing.T) {
+	tests := []struct {
+		name      string
+		objs      []runtime.Object
+		addonName string
+		wantKAC   bool
+		wantAddon bool
+	}{
+		{
+			name:      "Klusterlet addon, enabled in the KlusterletAddonConfig",
+			objs:      []runtime.Object{newKlusterletAddonConfig("cluster1")},
+			addonName: "search-collector",
+			wantKAC:   true,
+		},
+		{
+			name:      "Klusterlet addon without KlusterletAddonConfig",
+			addonName: "search-collector",
+			wantAddon: true,
+		},
+		{
+			name:      "Other addon",
+			objs:      []runtime.Object{newKlusterletAddonConfig("cluster1")},
+			addonName: "my-addon",
+			wantAddon: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewFakeClient(tt.objs...)
+			if err := EnableAddon(context.TODO(), client, "cluster1", tt.addonName, AddonInstallNamespace); err != nil {
+				t.Fatal(err)
+			}
+			//Enabling twice is a no-op
+			if err := EnableAddon(context.TODO(), client, "cluster1", tt.addonName, AddonInstallNamespace); err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantKAC && !getKlusterletAddonEnabled(t, client, "cluster1", "searchCollector") {
+				t.Error("the addon must be enabled in the KlusterletAddonConfig")
+			}
+			if err := getAddon(client, "cluster1", tt.addonName); tt.wantAddon != (err == nil) {
+				t.Errorf("the ManagedClusterAddOn must be created %v, got %v", tt.wantAddon, err)
+			}
+		})
+	}
+}
+
+func TestDisableAddon(t *testing.T) {
+	kac := newKlusterletAddonConfig("cluster1")
+	unstructured.SetNestedField(kac.Object, true, "spec", "searchCollector", "enabled")
+	addon := &unstructured.Unstructured{}
+	addon.SetGroupVersionKind(ManagedClusterAddOnGVK)
+	addon.SetName("search-collector")
+	addon.SetNamespace("cluster1")
+	client := NewFakeClient(kac, addon)
+
+	if err := DisableAddon(context.TODO(), client, "cluster1", "search-collector"); err != nil {
+		t.Fatal(err)
+	}
+	if getKlusterletAddonEnabled(t, client, "cluster1", "searchCollector") {
+		t.Error("the addon must be disabled in the KlusterletAddonConfig")
+	}
+	if err := getAddon(client, "cluster1", "search-collector"); !errors.IsNotFound(err) {
+		t.Errorf("the ManagedClusterAddOn must be deleted, got %v", err)
+	}
+	if err := DisableAddon(context.TODO(), client, "cluster1", "my-addon"); err != nil {
+		t.Errorf("disabling a missing addon must be a no-op, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	//ansibleJobSuccessful is the status of the result of a job which completed on the tower
+	ansibleJobSuccessful = "successful"
+)
+
+// ansibleJobFailures are the statuses of the result of a job which will not complete
+var ansibleJobFailures = []string{"failed", "error", "canceled"}
+
+// NewAnsibleJob returns the AnsibleJob launching the job template on the Ansible Tower or AWX
+// of the tower secret, the extra vars are passed to the playbook
+func NewAnsibleJob(namespace, name, jobTemplate, towerSecret string, extraVars map[string]interface{}) *unstructured.Unstructured {
+	job := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"job_template_name": jobTemplate,
+				"tower_auth_secret": towerSecret,
+				"extra_vars":        extraVars,
+			},
+		},
+	}
+	job.SetGroupVersionKind(AnsibleJobGVK)
+	job.SetNamespace(namespace)
+	job.SetName(name)
+	return job
+}
+
+// WaitForAnsibleJob waits until the job completes on the tower,
+// an error is returned if the job failed, errored or was canceled
+func WaitForAnsibleJob(ctx context.Context, client crclient.Client, namespace, name string, interval, timeout time.Duration) error {
+	err := PollImmediate(ctx, interval, timeout, func() (bool, error) {
+		job := &unstructured.Unstructured{}
+		job.SetGroupVersionKind(AnsibleJobGVK)
+		if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, job); err != nil {
+			return false, err
+		}
+		status, _, _ := unstructured.NestedString(job.Object, "status", "ansibleJobResult", "status")
+		if status == ansibleJobSuccessful {
+			return true, nil
+		}
+		for _, failure := range ansibleJobFailures {
+			if status == failure {
+				url, _, _ := unstructured.NestedString(job.Object, "status", "ansibleJobResult", "url")
+				return false, fmt.Errorf("the AnsibleJob %s/%s %s, see %s", namespace, name, status, url)
+			}
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("the AnsibleJob %s/%s did not complete after %s", namespace, name, timeout)
+	}
+	return err
+}
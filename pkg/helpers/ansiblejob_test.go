@@ -0,0 +1,63 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newAnsibleJob(status string) *unstructured.Unstructured {
+	job := NewAnsibleJob("mycluster", "mycluster-post-attach", "onboard", "tower", map[string]interface{}{"cluster_name": "mycluster"})
+	if status != "" {
+		job.Object["status"] = map[string]interface{}{
+			"ansibleJobResult": map[string]interface{}{"status": status, "url": "https://tower/jobs/1"},
+		}
+	}
+	return job
+}
+
+func TestWaitForAnsibleJob(t *testing.T) {
+	tests := []struct {
+		name    string
+		objs    []runtime.Object
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			objs: []runtime.Object{newAnsibleJob("successful")},
+		},
+		{
+			name:    "Failed, job failed",
+			objs:    []runtime.Object{newAnsibleJob("failed")},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, job running",
+			objs:    []runtime.Object{newAnsibleJob("running")},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, job not launched",
+			objs:    []runtime.Object{newAnsibleJob("")},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, no job",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewFakeClient(tt.objs...)
+			err := WaitForAnsibleJob(context.Background(), client, "mycluster", "mycluster-post-attach", 10*time.Millisecond, 100*time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WaitForAnsibleJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
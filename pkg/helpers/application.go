@@ -0,0 +1,108 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SubscriptionClusterStatus is the deployment phase of a subscription package on a given cluster
+type SubscriptionClusterStatus struct {
+	Subscription string `json:"subscription"`
+	Cluster      string `json:"cluster"`
+	Package      string `json:"package,omitempty"`
+	Phase        string `json:"phase"`
+}
+
+// GetApplicationSubscriptions returns the subscriptions selected by the application
+func GetApplicationSubscriptions(ctx context.Context, client crclient.Client, app *unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	subscriptions := &unstructured.UnstructuredList{}
+	subscriptions.SetGroupVersionKind(SubscriptionListGVK)
+	opts := []crclient.ListOption{crclient.InNamespace(app.GetNamespace())}
+	iselector, ok, err := unstructured.NestedMap(app.Object, "spec", "selector")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		ls := &metav1.LabelSelector{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(iselector, ls); err != nil {
+			return nil, err
+		}
+		selector, err := metav1.LabelSelectorAsSelector(ls)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, crclient.MatchingLabelsSelector{Selector: selector})
+	}
+	err = client.List(ctx, subscriptions, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions.Items, nil
+}
+
+// GetSubscriptionClusterStatuses returns the deployment phase of the subscription packages on each cluster.
+// The clusters selected by the placement rule which did not report any status yet are returned with an empty phase.
+func GetSubscriptionClusterStatuses(ctx context.Context, client crclient.Client, subscription *unstructured.Unstructured) ([]SubscriptionClusterStatus, error) {
+	statuses := make([]SubscriptionClusterStatus, 0)
+	reported := make(map[string]bool)
+	clusters, _, _ := unstructured.NestedMap(subscription.Object, "status", "statuses")
+	for cluster, ic := range clusters {
+		reported[cluster] = true
+		packages, _, _ := unstructured.NestedMap(ic.(map[string]interface{}), "packages")
+		if len(packages) == 0 {
+			statuses = append(statuses, SubscriptionClusterStatus{
+				Subscription: subscription.GetName(),
+				Cluster:      cluster,
+			})
+			continue
+		}
+		for name, ip := range packages {
+			phase, _, _ := unstructured.NestedString(ip.(map[string]interface{}), "phase")
+			statuses = append(statuses, SubscriptionClusterStatus{
+				Subscription: subscription.GetName(),
+				Cluster:      cluster,
+				Package:      name,
+				Phase:        phase,
+			})
+		}
+	}
+
+	placementRuleName, ok, _ := unstructured.NestedString(subscription.Object, "spec", "placement", "placementRef", "name")
+	if ok && placementRuleName != "" {
+		placementRule := &unstructured.Unstructured{}
+		placementRule.SetGroupVersionKind(PlacementRuleGVK)
+		err := client.Get(ctx,
+			types.NamespacedName{Name: placementRuleName, Namespace: subscription.GetNamespace()},
+			placementRule)
+		if crclient.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		decisions, _, _ := unstructured.NestedSlice(placementRule.Object, "status", "decisions")
+		for _, id := range decisions {
+			cluster, _, _ := unstructured.NestedString(id.(map[string]interface{}), "clusterName")
+			if cluster == "" || reported[cluster] {
+				continue
+			}
+			statuses = append(statuses, SubscriptionClusterStatus{
+				Subscription: subscription.GetName(),
+				Cluster:      cluster,
+			})
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Cluster != statuses[j].Cluster {
+			return statuses[i].Cluster < statuses[j].Cluster
+		}
+		return statuses[i].Package < statuses[j].Package
+	})
+	return statuses, nil
+}
@@ -0,0 +1,24 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FieldManager owns the fields set by the server-side apply of the CLI
+const FieldManager = "cm-cli"
+
+// ServerSideApply applies the resource with the cm-cli field manager, only the fields of the resource
+// are owned by the CLI so the fields set by the controllers and the GitOps tools are kept.
+// With forceConflicts, the CLI takes the ownership of the fields owned by another manager.
+func ServerSideApply(ctx context.Context, client crclient.Client, u *unstructured.Unstructured, forceConflicts bool) error {
+	opts := []crclient.PatchOption{crclient.FieldOwner(FieldManager)}
+	if forceConflicts {
+		opts = append(opts, crclient.ForceOwnership)
+	}
+	return client.Patch(ctx, u, crclient.Apply, opts...)
+}
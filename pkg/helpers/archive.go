@@ -0,0 +1,41 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"time"
+)
+
+// ArchiveEntry is a file of a tar.gz archive, the name is a slash separated relative path
+type ArchiveEntry struct {
+	Name string
+	Data []byte
+}
+
+// WriteTarGz writes the entries in a gzipped tar archive
+func WriteTarGz(w io.Writer, entries []ArchiveEntry) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	now := time.Now()
+	for _, e := range entries {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    e.Name,
+			Mode:    0644,
+			Size:    int64(len(e.Data)),
+			ModTime: now,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = tw.Write(e.Data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
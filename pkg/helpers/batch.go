@@ -0,0 +1,93 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/spf13/pflag"
+)
+
+// DefaultMaxConcurrency is the default number of items of a batch operation run at the same time
+const DefaultMaxConcurrency = 5
+
+// AddMaxConcurrencyFlag adds the --max-concurrency flag of the batch operations, --concurrency is kept as a deprecated alias
+func AddMaxConcurrencyFlag(flagSet *pflag.FlagSet, p *int, what string) {
+	flagSet.IntVar(p, "max-concurrency", DefaultMaxConcurrency, fmt.Sprintf("The maximum number of %s at the same time, the requests to the hub are also limited by --qps and --hub-rate-limit", what))
+	flagSet.IntVar(p, "concurrency", DefaultMaxConcurrency, "")
+	_ = flagSet.MarkDeprecated("concurrency", "use --max-concurrency instead")
+}
+
+// ValidateMaxConcurrency checks the --max-concurrency value
+func ValidateMaxConcurrency(maxConcurrency int) error {
+	if maxConcurrency < 1 {
+		return fmt.Errorf("--max-concurrency must be greater than 0, got %d", maxConcurrency)
+	}
+	return nil
+}
+
+// RunBatch runs fn for each item of a batch operation, at most maxConcurrency at the same time.
+// hubs[i] is the hub item i is run against: the items are started in a round-robin across the hubs,
+// so a hub with many items does not hold all the slots while the items of the other hubs wait.
+// A failed item does not stop the others, the error of each item is returned at its index.
+// Once ctx is canceled the items not started yet fail with ErrInterrupted
+func RunBatch(ctx context.Context, hubs []string, maxConcurrency int, fn func(i int) error) []error {
+	errs := make([]error, len(hubs))
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrency)
+	for _, i := range fairOrder(hubs) {
+		//select picks a ready case at random, the cancellation must win over a free slot
+		if ctx.Err() != nil {
+			errs[i] = ErrInterrupted
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			errs[i] = ErrInterrupted
+			continue
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+// RunSingleHubBatch runs fn for the n items of a batch operation run against a single hub,
+// they are started in their order, see RunBatch
+func RunSingleHubBatch(ctx context.Context, n int, maxConcurrency int, fn func(i int) error) []error {
+	return RunBatch(ctx, make([]string, n), maxConcurrency, fn)
+}
+
+// fairOrder returns the indexes of the items interleaved across their hubs, in the order of the
+// first item of each hub and keeping the order of the items of a hub
+func fairOrder(hubs []string) []int {
+	queues := make(map[string][]int)
+	order := make([]string, 0)
+	for i, hub := range hubs {
+		if _, ok := queues[hub]; !ok {
+			order = append(order, hub)
+		}
+		queues[hub] = append(queues[hub], i)
+	}
+	indexes := make([]int, 0, len(hubs))
+	for len(indexes) < len(hubs) {
+		for _, hub := range order {
+			if q := queues[hub]; len(q) != 0 {
+				indexes = append(indexes, q[0])
+				queues[hub] = q[1:]
+			}
+		}
+	}
+	return indexes
+}
@@ -0,0 +1,73 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func Test_fairOrder(t *testing.T) {
+	got := fairOrder([]string{"hub1", "hub1", "hub1", "hub2", "hub3", "hub2"})
+	want := []int{0, 3, 4, 1, 5, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fairOrder() = %v, want %v", got, want)
+	}
+}
+
+func TestRunBatch(t *testing.T) {
+	hubs := []string{"hub1", "hub1", "hub1", "hub1", "hub2", "hub2"}
+	var lock sync.Mutex
+	running, maxRunning := 0, 0
+	errs := RunBatch(context.Background(), hubs, 2, func(i int) error {
+		lock.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		lock.Unlock()
+		defer func() {
+			lock.Lock()
+			running--
+			lock.Unlock()
+		}()
+		if i == 2 {
+			return fmt.Errorf("item %d failed", i)
+		}
+		return nil
+	})
+	if maxRunning > 2 {
+		t.Errorf("at most 2 items must run at the same time, got %d", maxRunning)
+	}
+	for i, err := range errs {
+		if (err != nil) != (i == 2) {
+			t.Errorf("unexpected error of item %d: %v", i, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs = RunBatch(ctx, hubs, 2, func(i int) error { return nil })
+	for i, err := range errs {
+		if err != ErrInterrupted {
+			t.Errorf("item %d must be interrupted, got %v", i, err)
+		}
+	}
+}
+
+func TestRunSingleHubBatch(t *testing.T) {
+	var lock sync.Mutex
+	started := make([]int, 0)
+	errs := RunSingleHubBatch(context.Background(), 3, 1, func(i int) error {
+		lock.Lock()
+		defer lock.Unlock()
+		started = append(started, i)
+		return nil
+	})
+	if len(errs) != 3 || !reflect.DeepEqual(started, []int{0, 1, 2}) {
+		t.Errorf("the items must be run in their order, got %v", started)
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// BootstrapTokenNamespace is the namespace of the bootstrap token secrets read by the api server
+	BootstrapTokenNamespace = "kube-system"
+	// BootstrapTokenGroup is the group of the bootstrap tokens created to join the hub
+	BootstrapTokenGroup = "system:bootstrappers:managedcluster"
+	// BootstrapTokenBindingName binds the group of the bootstrap tokens to the registration role of the hub
+	BootstrapTokenBindingName = "cm-cli:bootstrap-token"
+	// bootstrapClusterRoleName is the role created by the cluster-manager to request the registration
+	bootstrapClusterRoleName = "open-cluster-management:bootstrap"
+
+	bootstrapTokenSecretPrefix = "bootstrap-token-"
+	bootstrapTokenSecretType   = corev1.SecretType("bootstrap.kubernetes.io/token")
+	bootstrapTokenChars        = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// bootstrapTokenRegexp matches the <id>.<secret> bootstrap tokens
+var bootstrapTokenRegexp = regexp.MustCompile(`^([a-z0-9]{6})\.([a-z0-9]{16})$`)
+
+// BootstrapToken is a short-lived token used by a klusterlet to request its registration on the hub
+type BootstrapToken struct {
+	ID          string    `json:"id"`
+	Expiration  time.Time `json:"expiration"`
+	Description string    `json:"description,omitempty"`
+}
+
+// ParseBootstrapToken checks the token is an <id>.<secret> bootstrap token and returns its id
+func ParseBootstrapToken(token string) (string, error) {
+	m := bootstrapTokenRegexp.FindStringSubmatch(token)
+	if m == nil {
+		return "", fmt.Errorf("invalid bootstrap token, expected <id>.<secret> with an id of 6 and a secret of 16 lowercase letters or digits")
+	}
+	return m[1], nil
+}
+
+// CreateBootstrapToken creates a bootstrap token expiring after the ttl and returns it,
+// the group of the token is granted the registration role of the hub
+func CreateBootstrapToken(ctx context.Context, client crclient.Client, ttl time.Duration, description string, now time.Time) (string, error) {
+	if err := ensureBootstrapTokenBinding(ctx, client); err != nil {
+		return "", err
+	}
+	id, err := randomString(6)
+	if err != nil {
+		return "", err
+	}
+	secret, err := randomString(16)
+	if err != nil {
+		return "", err
+	}
+	data := map[string][]byte{
+		"token-id":                       []byte(id),
+		"token-secret":                   []byte(secret),
+		"expiration":                     []byte(now.Add(ttl).UTC().Format(time.RFC3339)),
+		"usage-bootstrap-authentication": []byte("true"),
+		"auth-extra-groups":              []byte(BootstrapTokenGroup),
+	}
+	if description != "" {
+		data["description"] = []byte(description)
+	}
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bootstrapTokenSecretPrefix + id,
+			Namespace: BootstrapTokenNamespace,
+		},
+		Type: bootstrapTokenSecretType,
+		Data: data,
+	}
+	if err := client.Create(ctx, s); err != nil {
+		return "", err
+	}
+	return id + "." + secret, nil
+}
+
+// ListBootstrapTokens returns the bootstrap tokens created to join the hub sorted by expiration,
+// the secrets of the tokens are not returned
+func ListBootstrapTokens(ctx context.Context, client crclient.Client) ([]BootstrapToken, error) {
+	secrets := &corev1.SecretList{}
+	if err := client.List(ctx, secrets, crclient.InNamespace(BootstrapTokenNamespace)); err != nil {
+		return nil, err
+	}
+	tokens := make([]BootstrapToken, 0)
+	for _, s := range secrets.Items {
+		if s.Type != bootstrapTokenSecretType || !strings.Contains(bootstrapTokenValue(s, "auth-extra-groups"), BootstrapTokenGroup) {
+			continue
+		}
+		t := BootstrapToken{
+			ID:          bootstrapTokenValue(s, "token-id"),
+			Description: bootstrapTokenValue(s, "description"),
+		}
+		//A token without expiration never expires, it is shown as the zero time
+		if e := bootstrapTokenValue(s, "expiration"); e != "" {
+			expiration, err := time.Parse(time.RFC3339, e)
+			if err != nil {
+				return nil, fmt.Errorf("invalid expiration of the bootstrap token %s: %s", t.ID, err.Error())
+			}
+			t.Expiration = expiration
+		}
+		tokens = append(tokens, t)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Expiration.Before(tokens[j].Expiration)
+	})
+	return tokens, nil
+}
+
+// DeleteBootstrapToken deletes the bootstrap token of the id
+func DeleteBootstrapToken(ctx context.Context, client crclient.Client, id string) error {
+	s := &corev1.Secret{}
+	err := client.Get(ctx, types.NamespacedName{Namespace: BootstrapTokenNamespace, Name: bootstrapTokenSecretPrefix + id}, s)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("bootstrap token %s not found", id)
+	}
+	if err != nil {
+		return err
+	}
+	if s.Type != bootstrapTokenSecretType || !strings.Contains(bootstrapTokenValue(*s, "auth-extra-groups"), BootstrapTokenGroup) {
+		return fmt.Errorf("the bootstrap token %s was not created to join the hub", id)
+	}
+	if err := client.Delete(ctx, s); err != nil {
+		return err
+	}
+	return deleteUnusedBootstrapTokenBinding(ctx, client)
+}
+
+// CheckBootstrapTokenAuth checks the api server authenticates the bootstrap token with a TokenReview, the bootstrap
+// token authenticator is not enabled on all the hubs, such as OpenShift. The authenticator reads the token secrets
+// from a cache, the review is retried until the timeout.
+func CheckBootstrapTokenAuth(ctx context.Context, client crclient.Client, token string, interval, timeout time.Duration) error {
+	err := PollImmediate(ctx, interval, timeout, func() (bool, error) {
+		review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+		if err := client.Create(ctx, review); err != nil {
+			return false, err
+		}
+		return review.Status.Authenticated, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("the hub api server does not authenticate the bootstrap tokens, the bootstrap token authenticator " +
+			"is not enabled (kube-apiserver --enable-bootstrap-token-auth, not available on OpenShift), join the hub with a service account token and --hub-token")
+	}
+	return err
+}
+
+// deleteUnusedBootstrapTokenBinding deletes the binding of the bootstrap tokens once no valid token remains,
+// it is created again with the next token
+func deleteUnusedBootstrapTokenBinding(ctx context.Context, client crclient.Client) error {
+	tokens, err := ListBootstrapTokens(ctx, client)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, t := range tokens {
+		if t.Expiration.IsZero() || t.Expiration.After(now) {
+			return nil
+		}
+	}
+	crb := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: BootstrapTokenBindingName}}
+	if err := client.Delete(ctx, crb); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// ensureBootstrapTokenBinding grants the registration role of the hub to the group of the bootstrap tokens
+func ensureBootstrapTokenBinding(ctx context.Context, client crclient.Client) error {
+	crb := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: BootstrapTokenBindingName},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     bootstrapClusterRoleName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				APIGroup: rbacv1.GroupName,
+				Kind:     rbacv1.GroupKind,
+				Name:     BootstrapTokenGroup,
+			},
+		},
+	}
+	if err := client.Create(ctx, crb); err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// bootstrapTokenValue returns a value of the bootstrap token secret
+func bootstrapTokenValue(s corev1.Secret, key string) string {
+	return string(s.Data[key])
+}
+
+// randomString returns a random string of lowercase letters and digits
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	for i := range b {
+		c, err := rand.Int(rand.Reader, big.NewInt(int64(len(bootstrapTokenChars))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = bootstrapTokenChars[c.Int64()]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,149 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestParseBootstrapToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		wantID  string
+		wantErr bool
+	}{
+		{name: "Success", token: "abc123.0123456789abcdef", wantID: "abc123"},
+		{name: "Failed, no secret", token: "abc123", wantErr: true},
+		{name: "Failed, uppercase", token: "ABC123.0123456789abcdef", wantErr: true},
+		{name: "Failed, short secret", token: "abc123.0123", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := ParseBootstrapToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBootstrapToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if id != tt.wantID {
+				t.Errorf("ParseBootstrapToken() = %s, want %s", id, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestBootstrapTokens(t *testing.T) {
+	//A bootstrap token which was not created to join the hub
+	other := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: bootstrapTokenSecretPrefix + "other1", Namespace: BootstrapTokenNamespace},
+		Type:       bootstrapTokenSecretType,
+		Data:       map[string][]byte{"token-id": []byte("other1"), "auth-extra-groups": []byte("system:bootstrappers:kubeadm:default-node-token")},
+	}
+	client := NewFakeClient(other)
+	now := time.Date(2021, 4, 1, 10, 0, 0, 0, time.UTC)
+
+	token, err := CreateBootstrapToken(context.TODO(), client, 2*time.Hour, "join mycluster", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := ParseBootstrapToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//The binding already exists for the second token
+	if _, err := CreateBootstrapToken(context.TODO(), client, time.Hour, "", now); err != nil {
+		t.Fatal(err)
+	}
+	crb := &rbacv1.ClusterRoleBinding{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: BootstrapTokenBindingName}, crb); err != nil {
+		t.Fatal(err)
+	}
+	if crb.RoleRef.Name != bootstrapClusterRoleName || crb.Subjects[0].Name != BootstrapTokenGroup {
+		t.Errorf("unexpected binding %v", crb)
+	}
+
+	tokens, err := ListBootstrapTokens(context.TODO(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 {
+		t.Fatalf("ListBootstrapTokens() got %d tokens, want 2", len(tokens))
+	}
+	last := tokens[1]
+	if last.ID != id || last.Description != "join mycluster" || !last.Expiration.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("ListBootstrapTokens() got %v", last)
+	}
+
+	if err := DeleteBootstrapToken(context.TODO(), client, "other1"); err == nil {
+		t.Error("DeleteBootstrapToken() must not delete the tokens which were not created to join the hub")
+	}
+	if err := DeleteBootstrapToken(context.TODO(), client, id); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteBootstrapToken(context.TODO(), client, id); err == nil {
+		t.Error("DeleteBootstrapToken() expected an error for a deleted token")
+	}
+	if tokens, _ := ListBootstrapTokens(context.TODO(), client); len(tokens) != 1 {
+		t.Errorf("ListBootstrapTokens() got %d tokens after delete, want 1", len(tokens))
+	}
+}
+
+func TestDeleteBootstrapToken_binding(t *testing.T) {
+	client := NewFakeClient()
+	ids := make([]string, 0, 2)
+	for i := 0; i < 2; i++ {
+		token, err := CreateBootstrapToken(context.TODO(), client, time.Hour, "", time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, _ := ParseBootstrapToken(token)
+		ids = append(ids, id)
+	}
+	if err := DeleteBootstrapToken(context.TODO(), client, ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: BootstrapTokenBindingName}, &rbacv1.ClusterRoleBinding{}); err != nil {
+		t.Errorf("the binding must be kept while a valid token remains, got %v", err)
+	}
+	if err := DeleteBootstrapToken(context.TODO(), client, ids[1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: BootstrapTokenBindingName}, &rbacv1.ClusterRoleBinding{}); !errors.IsNotFound(err) {
+		t.Errorf("the binding must be deleted with the last token, got %v", err)
+	}
+}
+
+// tokenReviewClient authenticates the TokenReviews as the api server would
+type tokenReviewClient struct {
+	crclient.Client
+	authenticated bool
+}
+
+func (c *tokenReviewClient) Create(ctx context.Context, obj runtime.Object, opts ...crclient.CreateOption) error {
+	if review, ok := obj.(*authenticationv1.TokenReview); ok {
+		review.Status.Authenticated = c.authenticated
+		return nil
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func TestCheckBootstrapTokenAuth(t *testing.T) {
+	if err := CheckBootstrapTokenAuth(context.TODO(), &tokenReviewClient{authenticated: true}, "abc123.0123456789abcdef", time.Millisecond, 10*time.Millisecond); err != nil {
+		t.Errorf("CheckBootstrapTokenAuth() unexpected error %v", err)
+	}
+	err := CheckBootstrapTokenAuth(context.TODO(), &tokenReviewClient{}, "abc123.0123456789abcdef", time.Millisecond, 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "--hub-token") {
+		t.Errorf("CheckBootstrapTokenAuth() must report the disabled authenticator, got %v", err)
+	}
+}
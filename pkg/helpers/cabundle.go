@@ -0,0 +1,144 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	clientcmdapiv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+)
+
+// AdditionalCABundleFlag is the flag of the commands adding a CA bundle to the bootstrap kubeconfig of the klusterlet
+const AdditionalCABundleFlag = "additional-ca-bundle"
+
+// ReadCABundle reads a PEM file of CA certificates, it must contain at least one valid certificate
+func ReadCABundle(path string) ([]byte, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	rest := b
+	certificates := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, fmt.Errorf("invalid certificate in %s: %s", path, err.Error())
+		}
+		certificates++
+	}
+	if certificates == 0 {
+		return nil, fmt.Errorf("no PEM certificate found in %s", path)
+	}
+	return b, nil
+}
+
+// AddKubeconfigCA adds the CA bundle to the certificate authority of each cluster of the kubeconfig,
+// the clusters which already trust it are unchanged:
+//   - the bundle is appended to the certificate-authority-data of the cluster,
+//   - or to the content of its certificate-authority file, which is replaced by the data,
+//   - a cluster which skips the TLS verification is verified with the bundle as its only CA,
+//   - a cluster trusting the system roots trusts the bundle only, the kubeconfig can not combine them.
+func AddKubeconfigCA(kubeconfig []byte, caBundle []byte) ([]byte, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	for name, cluster := range config.Clusters {
+		ca := cluster.CertificateAuthorityData
+		if len(ca) == 0 && cluster.CertificateAuthority != "" {
+			ca, err = ioutil.ReadFile(filepath.Clean(cluster.CertificateAuthority))
+			if err != nil {
+				return nil, fmt.Errorf("unable to read the certificate-authority of cluster %s to add the CA bundle: %s", name, err.Error())
+			}
+		}
+		if bytes.Contains(ca, bytes.TrimSpace(caBundle)) {
+			continue
+		}
+		if len(ca) != 0 && !bytes.HasSuffix(ca, []byte("\n")) {
+			ca = append(ca, '\n')
+		}
+		cluster.CertificateAuthorityData = append(ca, caBundle...)
+		cluster.CertificateAuthority = ""
+		//A CA can not be set with the insecure flag, the server certificate is now verified with the bundle
+		if cluster.InsecureSkipTLSVerify && len(ca) == 0 {
+			cluster.InsecureSkipTLSVerify = false
+		}
+	}
+	return MarshalKubeConfig(config)
+}
+
+// MarshalKubeConfig returns the yaml of the v1 kubeconfig
+func MarshalKubeConfig(config *clientcmdapi.Config) ([]byte, error) {
+	v1 := &clientcmdapiv1.Config{}
+	if err := clientcmdapiv1.Convert_api_Config_To_v1_Config(config, v1, nil); err != nil {
+		return nil, err
+	}
+	v1.APIVersion = "v1"
+	v1.Kind = "Config"
+	return yaml.Marshal(v1)
+}
+
+// AddBootstrapCA appends the CA bundle to the kubeconfig of the bootstrap-hub-kubeconfig secret of the import
+// manifests, so the klusterlet trusts a hub fronted by a custom PKI. The manifests are unchanged if the bundle is empty.
+func AddBootstrapCA(imports []byte, caBundle []byte) ([]byte, error) {
+	if len(caBundle) == 0 {
+		return imports, nil
+	}
+	manifests, err := DecodeManifests(imports, "the import manifests")
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	out := &bytes.Buffer{}
+	for i, m := range manifests {
+		if m.GetKind() == "Secret" && m.GetName() == BootstrapSecretName {
+			if err := addSecretKubeconfigCA(m, caBundle); err != nil {
+				return nil, err
+			}
+			found = true
+		}
+		y, err := yaml.Marshal(m.Object)
+		if err != nil {
+			return nil, err
+		}
+		if i != 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(y)
+	}
+	if !found {
+		return nil, fmt.Errorf("no %s secret in the import manifests", BootstrapSecretName)
+	}
+	return out.Bytes(), nil
+}
+
+// addSecretKubeconfigCA appends the CA bundle to the kubeconfig key of the secret
+func addSecretKubeconfigCA(secret *unstructured.Unstructured, caBundle []byte) error {
+	encoded, _, _ := unstructured.NestedString(secret.Object, "data", "kubeconfig")
+	kubeconfig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig in the %s secret: %s", secret.GetName(), err.Error())
+	}
+	kubeconfig, err = AddKubeconfigCA(kubeconfig, caBundle)
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig in the %s secret: %s", secret.GetName(), err.Error())
+	}
+	return unstructured.SetNestedField(secret.Object, base64.StdEncoding.EncodeToString(kubeconfig), "data", "kubeconfig")
+}
@@ -0,0 +1,192 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func newTestCA(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "custom-pki"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestReadCABundle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cabundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := newTestCA(t)
+	tests := []struct {
+		name    string
+		content []byte
+		wantErr bool
+	}{
+		{name: "Certificate", content: ca},
+		{name: "Not PEM", content: []byte("not a certificate"), wantErr: true},
+		{name: "Invalid certificate", content: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("invalid")}), wantErr: true},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, string(rune('a'+i))+".pem")
+			if err := ioutil.WriteFile(path, tt.content, 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := ReadCABundle(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadCABundle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !bytes.Equal(got, tt.content) {
+				t.Errorf("ReadCABundle() = %s, want %s", got, tt.content)
+			}
+		})
+	}
+}
+
+const testBootstrapKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: hub
+  cluster:
+    server: https://api.hub.example.com:6443
+    insecure-skip-tls-verify: true
+contexts:
+- name: bootstrap
+  context:
+    cluster: hub
+    user: bootstrap
+current-context: bootstrap
+users:
+- name: bootstrap
+  user:
+    token: token
+`
+
+func TestAddBootstrapCA(t *testing.T) {
+	ca := newTestCA(t)
+	imports := "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: open-cluster-management-agent\n---\n" +
+		"apiVersion: v1\nkind: Secret\nmetadata:\n  name: " + BootstrapSecretName + "\n  namespace: open-cluster-management-agent\n" +
+		"data:\n  kubeconfig: " + base64.StdEncoding.EncodeToString([]byte(testBootstrapKubeconfig)) + "\n"
+
+	got, err := AddBootstrapCA([]byte(imports), nil)
+	if err != nil || string(got) != imports {
+		t.Fatalf("AddBootstrapCA() must not change the manifests without a CA bundle, got %s, %v", got, err)
+	}
+
+	got, err = AddBootstrapCA([]byte(imports), ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifests, err := DecodeManifests(got, "the import manifests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifests) != 2 || manifests[0].GetKind() != "Namespace" {
+		t.Fatalf("AddBootstrapCA() must keep the manifests, got:\n%s", got)
+	}
+	encoded := manifests[1].Object["data"].(map[string]interface{})["kubeconfig"].(string)
+	kubeconfig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cluster := config.Clusters["hub"]
+	if !bytes.Equal(cluster.CertificateAuthorityData, ca) || cluster.InsecureSkipTLSVerify {
+		t.Errorf("the hub cluster must trust the CA bundle, got %+v", cluster)
+	}
+
+	again, err := AddKubeconfigCA(kubeconfig, ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, _ = clientcmd.Load(again)
+	if n := strings.Count(string(config.Clusters["hub"].CertificateAuthorityData), "BEGIN CERTIFICATE"); n != 1 {
+		t.Errorf("the CA bundle must be added once, got %d certificates", n)
+	}
+
+	if _, err := AddBootstrapCA([]byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: agent\n"), ca); err == nil {
+		t.Error("AddBootstrapCA() expected an error without the bootstrap secret")
+	}
+}
+
+func TestAddKubeconfigCA(t *testing.T) {
+	ca := newTestCA(t)
+	hubCA := newTestCA(t)
+	caFile := filepath.Join(t.TempDir(), "hub-ca.crt")
+	if err := ioutil.WriteFile(caFile, hubCA, 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		cluster clientcmdapi.Cluster
+		wantCA  []byte
+		wantErr bool
+	}{
+		{name: "CA data", cluster: clientcmdapi.Cluster{CertificateAuthorityData: hubCA}, wantCA: append(append([]byte{}, hubCA...), ca...)},
+		{name: "CA file", cluster: clientcmdapi.Cluster{CertificateAuthority: caFile}, wantCA: append(append([]byte{}, hubCA...), ca...)},
+		{name: "Insecure", cluster: clientcmdapi.Cluster{InsecureSkipTLSVerify: true}, wantCA: ca},
+		{name: "System roots", cluster: clientcmdapi.Cluster{}, wantCA: ca},
+		{name: "Failed, missing CA file", cluster: clientcmdapi.Cluster{CertificateAuthority: caFile + ".missing"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := clientcmdapi.NewConfig()
+			cluster := tt.cluster
+			cluster.Server = "https://api.hub.example.com:6443"
+			config.Clusters["hub"] = &cluster
+			kubeconfig, err := clientcmd.Write(*config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := AddKubeconfigCA(kubeconfig, ca)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddKubeconfigCA() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			config, err = clientcmd.Load(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := config.Clusters["hub"]
+			if !bytes.Equal(c.CertificateAuthorityData, tt.wantCA) || c.CertificateAuthority != "" || c.InsecureSkipTLSVerify {
+				t.Errorf("AddKubeconfigCA() cluster = %+v, want the CA data %s", c, tt.wantCA)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// reservedClusterNamePrefixes are the prefixes of the namespaces of the platform, a cluster namespace
+// with one of these names would mix the resources of the cluster with the ones of the platform
+var reservedClusterNamePrefixes = []string{"kube-", "open-cluster-management"}
+
+// reservedClusterNames are the names of the namespaces which can not be the namespace of a cluster
+var reservedClusterNames = []string{"default"}
+
+// ValidateClusterName rejects the names reserved for the namespaces of the platform,
+// the hub creates a namespace named after each cluster
+func ValidateClusterName(clusterName string) error {
+	for _, reserved := range reservedClusterNames {
+		if clusterName == reserved {
+			return fmt.Errorf("cluster name %s is reserved, the namespace of the cluster would be the %s namespace", clusterName, reserved)
+		}
+	}
+	for _, prefix := range reservedClusterNamePrefixes {
+		if strings.HasPrefix(clusterName, prefix) {
+			return fmt.Errorf("cluster name %s is reserved, the names starting with %s are the namespaces of the platform", clusterName, prefix)
+		}
+	}
+	return nil
+}
+
+// SimilarClusterName returns the existing cluster whose name only differs from the cluster name by its case
+// or one typo, "" if there is none. The names only differing by their numbers, such as prod-eu1 and prod-eu2,
+// are the clusters of a series and are not considered as a typo.
+func SimilarClusterName(clusterName string, existing []string) string {
+	for _, name := range existing {
+		if name == clusterName {
+			return ""
+		}
+	}
+	similar := ""
+	for _, name := range existing {
+		if strings.EqualFold(name, clusterName) {
+			return name
+		}
+		if similar == "" && stripDigits(name) != stripDigits(clusterName) && editDistance(name, clusterName) == 1 {
+			similar = name
+		}
+	}
+	return similar
+}
+
+func stripDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// editDistance returns the levenshtein distance of a and b, the number of single character insertions,
+// deletions and substitutions turning a into b, a transposition of two adjacent characters counts as one typo
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
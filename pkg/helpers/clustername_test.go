@@ -0,0 +1,63 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"testing"
+)
+
+func TestValidateClusterName(t *testing.T) {
+	for _, name := range []string{"mycluster", "local-cluster", "prod-eu1", "kube", "defaults"} {
+		if err := ValidateClusterName(name); err != nil {
+			t.Errorf("ValidateClusterName(%s) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"default", "kube-system", "kube-public", "open-cluster-management", "open-cluster-management-agent"} {
+		if err := ValidateClusterName(name); err == nil {
+			t.Errorf("ValidateClusterName(%s) expected an error", name)
+		}
+	}
+}
+
+func TestSimilarClusterName(t *testing.T) {
+	existing := []string{"prod-eu1", "prod-us1", "dev", "Staging"}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "prod-eu1", want: ""},
+		{name: "prdo-eu1", want: "prod-eu1"},
+		{name: "prod-eu11", want: ""},
+		{name: "prod-e1", want: "prod-eu1"},
+		{name: "prod-eu2", want: ""},
+		{name: "prod-ue1", want: "prod-eu1"},
+		{name: "staging", want: "Staging"},
+		{name: "dve", want: "dev"},
+		{name: "deb", want: "dev"},
+		{name: "devs", want: "dev"},
+		{name: "prod", want: ""},
+		{name: "qa", want: ""},
+	}
+	for _, tt := range tests {
+		if got := SimilarClusterName(tt.name, existing); got != tt.want {
+			t.Errorf("SimilarClusterName(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_editDistance(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"prod-eu1", "prdo-eu1", 1},
+		{"prod-eu1", "prod-us1", 2},
+	} {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterScope restricts the listings to the clusters of clustersets. The users who can not list all the managed
+// clusters, such as the clusterset users of a multi-tenant hub, are given the clusters of the clustersets bound
+// to their namespace instead of a Forbidden error.
+type ClusterScope struct {
+	// ClusterSets are the clustersets of --clusterset, all the clustersets if empty
+	ClusterSets []string
+	// Namespace is the namespace whose ManagedClusterSetBindings give the clustersets of the user
+	Namespace string
+	// Bound are the clustersets bound to the namespace, set when the clusters were listed through them
+	Bound []string
+}
+
+// AddClusterSetFlag adds the --clusterset flag restricting a listing to the clusters of clustersets
+func (s *ClusterScope) AddClusterSetFlag(flagSet *pflag.FlagSet) {
+	flagSet.StringSliceVar(&s.ClusterSets, "clusterset", nil, "Only list the clusters of these clustersets (can specify multiple or separate values with commas: set1,set2)")
+}
+
+// Complete sets the namespace of the clustersets bindings from the kubeconfig flags
+func (s *ClusterScope) Complete(configFlags *genericclioptions.ConfigFlags) (err error) {
+	s.Namespace, err = GetNamespaceFromFlags(configFlags)
+	return err
+}
+
+// Describe returns a message telling which clusters were listed, "" if all the clusters were listed
+func (s *ClusterScope) Describe() string {
+	if len(s.Bound) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("You can not list all the managed clusters, only the clusters of the clustersets %s bound to the namespace %s are listed",
+		strings.Join(s.Bound, ", "), s.Namespace)
+}
+
+// ListClusters lists the managed clusters of the selector in the clustersets of the scope. When the user can not list
+// the managed clusters, they are listed through the clusterview API in the clustersets bound to the namespace.
+func (s *ClusterScope) ListClusters(ctx context.Context, client crclient.Client, selector labels.Selector) ([]unstructured.Unstructured, error) {
+	s.Bound = nil
+	mcs, err := listClusters(ctx, client, ManagedClusterGVK, selector, s.ClusterSets)
+	if err == nil || !errors.IsForbidden(err) {
+		return mcs, err
+	}
+	bound, berr := s.boundClusterSets(ctx, client)
+	if berr != nil {
+		return nil, fmt.Errorf("%s, and the clustersets bound to the namespace %s can not be listed: %s", err.Error(), s.Namespace, berr.Error())
+	}
+	if len(bound) == 0 {
+		return nil, fmt.Errorf("%s, and no clusterset in scope is bound to the namespace %s", err.Error(), s.Namespace)
+	}
+	mcs, err = listClusters(ctx, client, ClusterViewManagedClusterGVK, selector, bound)
+	if err != nil {
+		return nil, err
+	}
+	s.Bound = bound
+	return mcs, nil
+}
+
+// ListInClusters lists the objects of the kind in the namespaces of the clusters of the scope, each managed cluster
+// has its namespace on the hub. The objects are listed across the namespaces when the scope is not restricted
+// and the user can list them, the namespaces of the clusters which can not be read are skipped.
+func (s *ClusterScope) ListInClusters(ctx context.Context, client crclient.Client, gvk schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	s.Bound = nil
+	if len(s.ClusterSets) == 0 {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err := client.List(ctx, list)
+		if err == nil || !errors.IsForbidden(err) {
+			return list.Items, err
+		}
+	}
+	mcs, err := s.ListClusters(ctx, client, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	items := make([]unstructured.Unstructured, 0)
+	for _, mc := range mcs {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err := client.List(ctx, list, crclient.InNamespace(mc.GetName()))
+		if errors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, list.Items...)
+	}
+	return items, nil
+}
+
+// boundClusterSets returns the clustersets of the scope bound to the namespace
+func (s *ClusterScope) boundClusterSets(ctx context.Context, client crclient.Client) ([]string, error) {
+	bindings := &unstructured.UnstructuredList{}
+	bindings.SetGroupVersionKind(ManagedClusterSetBindingGVK.GroupVersion().WithKind(ManagedClusterSetBindingGVK.Kind + "List"))
+	if err := client.List(ctx, bindings, crclient.InNamespace(s.Namespace)); err != nil {
+		return nil, err
+	}
+	requested := make(map[string]bool, len(s.ClusterSets))
+	for _, cs := range s.ClusterSets {
+		requested[cs] = true
+	}
+	bound := make([]string, 0, len(bindings.Items))
+	for _, b := range bindings.Items {
+		//The binding is named after its clusterset
+		clusterSet, _, _ := unstructured.NestedString(b.Object, "spec", "clusterSet")
+		if clusterSet == "" {
+			clusterSet = b.GetName()
+		}
+		if len(requested) == 0 || requested[clusterSet] {
+			bound = append(bound, clusterSet)
+		}
+	}
+	sort.Strings(bound)
+	return bound, nil
+}
+
+// listClusters lists the clusters of the selector in the clustersets, in all the clustersets if empty
+func listClusters(ctx context.Context, client crclient.Client, gvk schema.GroupVersionKind, selector labels.Selector, clusterSets []string) ([]unstructured.Unstructured, error) {
+	if selector == nil {
+		selector = labels.Everything()
+	}
+	if len(clusterSets) != 0 {
+		r, err := labels.NewRequirement(ClusterSetLabel, selection.In, clusterSets)
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*r)
+	}
+	mcs := &unstructured.UnstructuredList{}
+	mcs.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := client.List(ctx, mcs, crclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	sort.Slice(mcs.Items, func(i, j int) bool {
+		return mcs.Items[i].GetName() < mcs.Items[j].GetName()
+	})
+	return mcs.Items, nil
+}
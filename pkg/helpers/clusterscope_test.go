@@ -0,0 +1,126 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// tenantClient is the client of a clusterset user, who can not list the managed clusters
+// nor the objects across the namespaces
+type tenantClient struct {
+	crclient.Client
+}
+
+func (c tenantClient) List(ctx context.Context, list runtime.Object, opts ...crclient.ListOption) error {
+	listOptions := &crclient.ListOptions{}
+	listOptions.ApplyOptions(opts)
+	gvk := list.GetObjectKind().GroupVersionKind()
+	if gvk.Group == ManagedClusterGVK.Group && gvk.Kind == ManagedClusterGVK.Kind+"List" ||
+		gvk.Kind != ManagedClusterSetBindingGVK.Kind+"List" && gvk.Group != ClusterViewManagedClusterGVK.Group && listOptions.Namespace == "" {
+		return errors.NewForbidden(schema.GroupResource{Group: gvk.Group, Resource: strings.ToLower(strings.TrimSuffix(gvk.Kind, "List")) + "s"}, "", nil)
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func newScopeObject(gvk schema.GroupVersionKind, namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetLabels(labels)
+	return u
+}
+
+func newScopeObjects() []runtime.Object {
+	objs := make([]runtime.Object, 0)
+	for name, set := range map[string]string{"prod-eu": "prod", "prod-us": "prod", "dev": "dev", "staging": ""} {
+		labels := map[string]string{}
+		if set != "" {
+			labels[ClusterSetLabel] = set
+		}
+		objs = append(objs,
+			newScopeObject(ManagedClusterGVK, "", name, labels),
+			newScopeObject(ClusterViewManagedClusterGVK, "", name, labels),
+			newScopeObject(ManifestWorkGVK, name, "work-"+name, nil))
+	}
+	return append(objs, newScopeObject(ManagedClusterSetBindingGVK, "team-a", "prod", nil))
+}
+
+func names(items []unstructured.Unstructured) string {
+	n := make([]string, 0, len(items))
+	for _, i := range items {
+		n = append(n, i.GetName())
+	}
+	return strings.Join(n, ",")
+}
+
+func TestClusterScope_ListClusters(t *testing.T) {
+	tests := []struct {
+		name        string
+		tenant      bool
+		clusterSets []string
+		namespace   string
+		want        string
+		wantBound   bool
+		wantErr     bool
+	}{
+		{name: "All clusters", want: "dev,prod-eu,prod-us,staging"},
+		{name: "Clusterset", clusterSets: []string{"prod"}, want: "prod-eu,prod-us"},
+		{name: "Tenant, bound clustersets", tenant: true, namespace: "team-a", want: "prod-eu,prod-us", wantBound: true},
+		{name: "Tenant, clusterset not bound", tenant: true, namespace: "team-a", clusterSets: []string{"dev"}, wantErr: true},
+		{name: "Tenant, no binding", tenant: true, namespace: "team-b", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var client crclient.Client = NewFakeClient(newScopeObjects()...)
+			if tt.tenant {
+				client = tenantClient{Client: client}
+			}
+			s := &ClusterScope{ClusterSets: tt.clusterSets, Namespace: tt.namespace}
+			mcs, err := s.ListClusters(context.TODO(), client, labels.Everything())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListClusters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := names(mcs); got != tt.want {
+				t.Errorf("ListClusters() = %s, want %s", got, tt.want)
+			}
+			if (s.Describe() != "") != tt.wantBound {
+				t.Errorf("Describe() = %q, the fallback must be described", s.Describe())
+			}
+		})
+	}
+}
+
+func TestClusterScope_ListInClusters(t *testing.T) {
+	client := NewFakeClient(newScopeObjects()...)
+	s := &ClusterScope{}
+	works, err := s.ListInClusters(context.TODO(), client, ManifestWorkGVK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(works) != 4 {
+		t.Errorf("all the works must be listed, got %s", names(works))
+	}
+
+	s = &ClusterScope{Namespace: "team-a"}
+	works, err = s.ListInClusters(context.TODO(), tenantClient{Client: client}, ManifestWorkGVK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(works); got != "work-prod-eu,work-prod-us" {
+		t.Errorf("only the works of the bound clustersets must be listed, got %s", got)
+	}
+}
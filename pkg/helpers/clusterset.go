@@ -0,0 +1,34 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EnsureClusterSet checks the ManagedClusterSet a new cluster joins exists, it is created if create is true.
+// It returns true if the clusterset was created.
+func EnsureClusterSet(ctx context.Context, client crclient.Client, name string, create bool) (bool, error) {
+	clusterSet := &unstructured.Unstructured{}
+	clusterSet.SetGroupVersionKind(ManagedClusterSetGVK)
+	err := client.Get(ctx, types.NamespacedName{Name: name}, clusterSet)
+	switch {
+	case err == nil:
+		return false, nil
+	case !errors.IsNotFound(err):
+		return false, err
+	case !create:
+		return false, fmt.Errorf("the clusterset %s does not exist, create it or set --create-clusterset", name)
+	}
+	clusterSet.SetName(name)
+	if err := client.Create(ctx, clusterSet); err != nil && !errors.IsAlreadyExists(err) {
+		return false, err
+	}
+	return true, nil
+}
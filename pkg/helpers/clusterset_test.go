@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestEnsureClusterSet(t *testing.T) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(ManagedClusterSetGVK)
+	existing.SetName("prod")
+
+	tests := []struct {
+		name        string
+		clusterSet  string
+		create      bool
+		wantCreated bool
+		wantErr     bool
+	}{
+		{name: "Existing", clusterSet: "prod"},
+		{name: "Existing, create", clusterSet: "prod", create: true},
+		{name: "Missing", clusterSet: "dev", wantErr: true},
+		{name: "Missing, create", clusterSet: "dev", create: true, wantCreated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewFakeClient(existing.DeepCopy())
+			created, err := EnsureClusterSet(context.TODO(), client, tt.clusterSet, tt.create)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EnsureClusterSet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if created != tt.wantCreated {
+				t.Errorf("EnsureClusterSet() created = %v, want %v", created, tt.wantCreated)
+			}
+			if tt.wantErr {
+				return
+			}
+			clusterSet := &unstructured.Unstructured{}
+			clusterSet.SetGroupVersionKind(ManagedClusterSetGVK)
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: tt.clusterSet}, clusterSet); err != nil {
+				t.Errorf("the clusterset must exist, got %v", err)
+			}
+		})
+	}
+}
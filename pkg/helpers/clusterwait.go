@@ -0,0 +1,117 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// WaitForImported waits for the import controller to apply the import manifests on the cluster
+	WaitForImported = "imported"
+	// WaitForJoined waits for the klusterlet to join the hub
+	WaitForJoined = "joined"
+	// WaitForAvailable waits for the cluster to be available
+	WaitForAvailable = "available"
+	// WaitForAddonsReady waits for the cluster and all its addons to be available
+	WaitForAddonsReady = "addons-ready"
+)
+
+// clusterWaitConditions are the conditions of the ManagedCluster which must be True for each --wait-for
+var clusterWaitConditions = map[string][]string{
+	WaitForImported:    {"ManagedClusterImportSucceeded"},
+	WaitForJoined:      {"HubAcceptedManagedCluster", "ManagedClusterJoined"},
+	WaitForAvailable:   {"HubAcceptedManagedCluster", "ManagedClusterJoined", "ManagedClusterConditionAvailable"},
+	WaitForAddonsReady: {"HubAcceptedManagedCluster", "ManagedClusterJoined", "ManagedClusterConditionAvailable"},
+}
+
+// WaitForConditions returns the supported values of --wait-for, in the order the cluster reaches them
+func WaitForConditions() []string {
+	return []string{WaitForImported, WaitForJoined, WaitForAvailable, WaitForAddonsReady}
+}
+
+// ValidateWaitFor checks the --wait-for value is supported, an empty value waits for nothing
+func ValidateWaitFor(waitFor string) error {
+	if _, ok := clusterWaitConditions[waitFor]; waitFor != "" && !ok {
+		return fmt.Errorf("invalid wait-for %s, supported values are %s", waitFor, strings.Join(WaitForConditions(), ", "))
+	}
+	return nil
+}
+
+// WaitForCluster waits until the cluster reaches the --wait-for state, on timeout the error lists
+// the conditions and the addons which are not ready yet with their message
+func WaitForCluster(ctx context.Context, client crclient.Client, clusterName, waitFor string, interval, timeout time.Duration) error {
+	var pending []string
+	err := PollImmediate(ctx, interval, timeout, func() (bool, error) {
+		var err error
+		pending, err = pendingClusterConditions(ctx, client, clusterName, waitFor)
+		return len(pending) == 0, err
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("cluster %s is not %s after %s: %s", clusterName, waitFor, timeout, strings.Join(pending, ", "))
+	}
+	return err
+}
+
+// pendingClusterConditions returns the conditions and the addons of the cluster which are not ready for the --wait-for
+func pendingClusterConditions(ctx context.Context, client crclient.Client, clusterName, waitFor string) ([]string, error) {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(ManagedClusterGVK)
+	if err := client.Get(ctx, types.NamespacedName{Name: clusterName}, mc); err != nil {
+		if crclient.IgnoreNotFound(err) == nil {
+			return []string{fmt.Sprintf("ManagedCluster %s not found", clusterName)}, nil
+		}
+		return nil, err
+	}
+	pending := make([]string, 0)
+	conditions, _, _ := unstructured.NestedSlice(mc.Object, "status", "conditions")
+	for _, conditionType := range clusterWaitConditions[waitFor] {
+		if pendingCondition := checkCondition(conditions, conditionType); pendingCondition != "" {
+			pending = append(pending, pendingCondition)
+		}
+	}
+	if waitFor != WaitForAddonsReady || len(pending) != 0 {
+		return pending, nil
+	}
+
+	addons := &unstructured.UnstructuredList{}
+	addons.SetGroupVersionKind(ManagedClusterAddOnGVK.GroupVersion().WithKind(ManagedClusterAddOnGVK.Kind + "List"))
+	if err := client.List(ctx, addons, crclient.InNamespace(clusterName)); err != nil {
+		return nil, err
+	}
+	sort.Slice(addons.Items, func(i, j int) bool {
+		return addons.Items[i].GetName() < addons.Items[j].GetName()
+	})
+	for _, addon := range addons.Items {
+		conditions, _, _ := unstructured.NestedSlice(addon.Object, "status", "conditions")
+		if pendingCondition := checkCondition(conditions, "Available"); pendingCondition != "" {
+			pending = append(pending, fmt.Sprintf("addon %s %s", addon.GetName(), pendingCondition))
+		}
+	}
+	return pending, nil
+}
+
+// checkCondition returns an empty string if the condition is True, its status and message otherwise
+func checkCondition(conditions []interface{}, conditionType string) string {
+	status := GetConditionStatus(conditions, conditionType)
+	if status == "True" {
+		return ""
+	}
+	if status == "" {
+		status = "Unknown"
+	}
+	pending := fmt.Sprintf("%s is %s", conditionType, status)
+	if message := GetConditionMessage(conditions, conditionType); message != "" {
+		pending += " (" + message + ")"
+	}
+	return pending
+}
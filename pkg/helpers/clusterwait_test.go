@@ -0,0 +1,109 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newWaitManagedCluster(name string, statuses map[string]string) *unstructured.Unstructured {
+	conditions := make([]interface{}, 0)
+	for t, s := range statuses {
+		conditions = append(conditions, map[string]interface{}{"type": t, "status": s, "message": t + " message"})
+	}
+	mc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"conditions": conditions},
+	}}
+	mc.SetGroupVersionKind(ManagedClusterGVK)
+	mc.SetName(name)
+	return mc
+}
+
+func newWaitAddon(clusterName, name, available string) *unstructured.Unstructured {
+	addon := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"conditions": []interface{}{
+			map[string]interface{}{"type": "Available", "status": available},
+		}},
+	}}
+	addon.SetGroupVersionKind(ManagedClusterAddOnGVK)
+	addon.SetNamespace(clusterName)
+	addon.SetName(name)
+	return addon
+}
+
+func TestWaitForCluster(t *testing.T) {
+	joined := map[string]string{"ManagedClusterImportSucceeded": "True", "HubAcceptedManagedCluster": "True", "ManagedClusterJoined": "True"}
+	available := map[string]string{"HubAcceptedManagedCluster": "True", "ManagedClusterJoined": "True", "ManagedClusterConditionAvailable": "True"}
+	tests := []struct {
+		name    string
+		objs    []runtime.Object
+		waitFor string
+		wantErr string
+	}{
+		{
+			name:    "Success, joined",
+			objs:    []runtime.Object{newWaitManagedCluster("mycluster", joined)},
+			waitFor: WaitForJoined,
+		},
+		{
+			name:    "Success, imported",
+			objs:    []runtime.Object{newWaitManagedCluster("mycluster", joined)},
+			waitFor: WaitForImported,
+		},
+		{
+			name: "Success, addons ready",
+			objs: []runtime.Object{newWaitManagedCluster("mycluster", available),
+				newWaitAddon("mycluster", "search-collector", "True"), newWaitAddon("other", "search-collector", "False")},
+			waitFor: WaitForAddonsReady,
+		},
+		{
+			name:    "Failed, not available",
+			objs:    []runtime.Object{newWaitManagedCluster("mycluster", joined)},
+			waitFor: WaitForAvailable,
+			wantErr: "ManagedClusterConditionAvailable is Unknown",
+		},
+		{
+			name:    "Failed, addon not available",
+			objs:    []runtime.Object{newWaitManagedCluster("mycluster", available), newWaitAddon("mycluster", "search-collector", "False")},
+			waitFor: WaitForAddonsReady,
+			wantErr: "addon search-collector Available is False",
+		},
+		{
+			name:    "Failed, no cluster",
+			waitFor: WaitForJoined,
+			wantErr: "not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewFakeClient(tt.objs...)
+			err := WaitForCluster(context.Background(), client, "mycluster", tt.waitFor, 10*time.Millisecond, 50*time.Millisecond)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Error(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("WaitForCluster() error containing %q expected, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateWaitFor(t *testing.T) {
+	for _, waitFor := range append(WaitForConditions(), "") {
+		if err := ValidateWaitFor(waitFor); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := ValidateWaitFor("ready"); err == nil {
+		t.Error("ValidateWaitFor() expected an error")
+	}
+}
@@ -2,10 +2,18 @@
 
 package helpers
 
-import "os"
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
 
 func GetExampleHeader() string {
-	switch os.Args[0] {
+	//On Windows the binary is called with its full path and the .exe extension
+	switch strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe") {
 	case "oc":
 		return "oc cm"
 	case "kubectl":
@@ -14,3 +22,12 @@ func GetExampleHeader() string {
 		return os.Args[0]
 	}
 }
+
+// CommandContext returns the context of the command, canceled on Ctrl+C,
+// or the background context if the command is not executed, as in the unit tests
+func CommandContext(cmd *cobra.Command) context.Context {
+	if cmd == nil || cmd.Context() == nil {
+		return context.Background()
+	}
+	return cmd.Context()
+}
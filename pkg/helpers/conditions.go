@@ -0,0 +1,39 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// GetConditionStatus returns the status of the condition of the given type found in a
+// list of unstructured conditions, an empty string is returned if the condition is not found
+func GetConditionStatus(conditions []interface{}, conditionType string) string {
+	for _, ic := range conditions {
+		c, ok := ic.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if t, _, _ := unstructured.NestedString(c, "type"); t == conditionType {
+			status, _, _ := unstructured.NestedString(c, "status")
+			return status
+		}
+	}
+	return ""
+}
+
+// GetConditionMessage returns the message of the condition of the given type found in a
+// list of unstructured conditions, an empty string is returned if the condition is not found
+func GetConditionMessage(conditions []interface{}, conditionType string) string {
+	for _, ic := range conditions {
+		c, ok := ic.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if t, _, _ := unstructured.NestedString(c, "type"); t == conditionType {
+			message, _, _ := unstructured.NestedString(c, "message")
+			return message
+		}
+	}
+	return ""
+}
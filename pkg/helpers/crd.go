@@ -0,0 +1,28 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CRDEstablished returns true once the CustomResourceDefinition is served,
+// a missing CustomResourceDefinition is not an error as it may not be created yet
+func CRDEstablished(ctx context.Context, client crclient.Client, name string) (bool, error) {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(CustomResourceDefinitionGVK)
+	err := client.Get(ctx, types.NamespacedName{Name: name}, crd)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	return GetConditionStatus(conditions, "Established") == "True", nil
+}
@@ -0,0 +1,102 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	//curatorJobCondition is set by the cluster-curator controller, it is true once the curation is over
+	curatorJobCondition = "clustercurator-job"
+	curatorJobFailed    = "Job_failed"
+)
+
+// ReadCuratorFile reads the hooks of a curation, the file contains the towerAuthSecret
+// and the prehook and posthook lists of Ansible job templates as expected by the ClusterCurator
+func ReadCuratorFile(path string) (map[string]interface{}, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	curator := make(map[string]interface{})
+	if err := yaml.Unmarshal(b, &curator); err != nil {
+		return nil, fmt.Errorf("invalid curator file %s: %s", path, err.Error())
+	}
+	for k := range curator {
+		if k != "towerAuthSecret" && k != "prehook" && k != "posthook" {
+			return nil, fmt.Errorf("invalid curator file %s: unknown key %s", path, k)
+		}
+	}
+	if s, _, _ := unstructured.NestedString(curator, "towerAuthSecret"); s == "" {
+		return nil, fmt.Errorf("invalid curator file %s: towerAuthSecret is missing", path)
+	}
+	hooks := 0
+	for _, hook := range []string{"prehook", "posthook"} {
+		jobs, _, err := unstructured.NestedSlice(curator, hook)
+		if err != nil {
+			return nil, fmt.Errorf("invalid curator file %s: %s must be a list of job templates", path, hook)
+		}
+		for i, j := range jobs {
+			job, ok := j.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid curator file %s: %s[%d] must be a job template", path, hook, i)
+			}
+			if name, _, _ := unstructured.NestedString(job, "name"); name == "" {
+				return nil, fmt.Errorf("invalid curator file %s: %s[%d] has no name", path, hook, i)
+			}
+		}
+		hooks += len(jobs)
+	}
+	if hooks == 0 {
+		return nil, fmt.Errorf("invalid curator file %s: at least one prehook or posthook is required", path)
+	}
+	return curator, nil
+}
+
+// WaitForCuration waits until the ClusterCurator of the cluster completes,
+// an error is returned if a job of the curation failed
+func WaitForCuration(ctx context.Context, client crclient.Client, clusterName string, interval, timeout time.Duration) error {
+	err := PollImmediate(ctx, interval, timeout, func() (bool, error) {
+		curator := &unstructured.Unstructured{}
+		curator.SetGroupVersionKind(ClusterCuratorGVK)
+		err := client.Get(ctx, types.NamespacedName{Name: clusterName, Namespace: clusterName}, curator)
+		if err != nil {
+			return false, crclient.IgnoreNotFound(err)
+		}
+		conditions, _, _ := unstructured.NestedSlice(curator.Object, "status", "conditions")
+		for _, ic := range conditions {
+			c, ok := ic.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if t, _, _ := unstructured.NestedString(c, "type"); t != curatorJobCondition {
+				continue
+			}
+			if status, _, _ := unstructured.NestedString(c, "status"); status != "True" {
+				return false, nil
+			}
+			if reason, _, _ := unstructured.NestedString(c, "reason"); reason == curatorJobFailed {
+				message, _, _ := unstructured.NestedString(c, "message")
+				return false, fmt.Errorf("the curation of %s failed: %s", clusterName, message)
+			}
+			return true, nil
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("the curation of %s did not complete after %s", clusterName, timeout)
+	}
+	return err
+}
@@ -0,0 +1,120 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestReadCuratorFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "curator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "Success",
+			content: "towerAuthSecret: toweraccess\nprehook:\n- name: Demo Job Template\n  extra_vars:\n    variable1: something\n",
+		},
+		{
+			name:    "Failed, towerAuthSecret missing",
+			content: "prehook:\n- name: Demo Job Template\n",
+			wantErr: true,
+		},
+		{
+			name:    "Failed, no hook",
+			content: "towerAuthSecret: toweraccess\n",
+			wantErr: true,
+		},
+		{
+			name:    "Failed, hook without name",
+			content: "towerAuthSecret: toweraccess\nposthook:\n- extra_vars:\n    variable1: something\n",
+			wantErr: true,
+		},
+		{
+			name:    "Failed, unknown key",
+			content: "towerAuthSecret: toweraccess\nprehook:\n- name: Demo Job Template\ndesiredCuration: install\n",
+			wantErr: true,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, fmt.Sprintf("curator-%d.yaml", i))
+			if err := ioutil.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			curator, err := ReadCuratorFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadCuratorFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && curator["towerAuthSecret"] != "toweraccess" {
+				t.Errorf("towerAuthSecret = %v, want toweraccess", curator["towerAuthSecret"])
+			}
+		})
+	}
+}
+
+func newClusterCurator(name string, conditions ...interface{}) *unstructured.Unstructured {
+	curator := &unstructured.Unstructured{}
+	curator.SetGroupVersionKind(ClusterCuratorGVK)
+	curator.SetName(name)
+	curator.SetNamespace(name)
+	if len(conditions) != 0 {
+		unstructured.SetNestedSlice(curator.Object, conditions, "status", "conditions")
+	}
+	return curator
+}
+
+func TestWaitForCuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		objs    []runtime.Object
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			objs: []runtime.Object{newClusterCurator("mycluster",
+				map[string]interface{}{"type": "clustercurator-job", "status": "True", "reason": "Job_has_finished"})},
+		},
+		{
+			name: "Failed, job failed",
+			objs: []runtime.Object{newClusterCurator("mycluster",
+				map[string]interface{}{"type": "clustercurator-job", "status": "True", "reason": "Job_failed", "message": "prehook failed"})},
+			wantErr: true,
+		},
+		{
+			name: "Failed, curation running",
+			objs: []runtime.Object{newClusterCurator("mycluster",
+				map[string]interface{}{"type": "clustercurator-job", "status": "False"})},
+			wantErr: true,
+		},
+		{
+			name:    "Failed, no curator",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewFakeClient(tt.objs...)
+			err := WaitForCuration(context.Background(), client, "mycluster", 10*time.Millisecond, 100*time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WaitForCuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeploymentsAvailable returns true if the namespace has deployments and all of them
+// have their desired replicas available
+func DeploymentsAvailable(ctx context.Context, client crclient.Client, namespace string) (bool, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := client.List(ctx, deployments, crclient.InNamespace(namespace)); err != nil {
+		return false, err
+	}
+	if len(deployments.Items) == 0 {
+		return false, nil
+	}
+	for _, d := range deployments.Items {
+		replicas := int32(1)
+		if d.Spec.Replicas != nil {
+			replicas = *d.Spec.Replicas
+		}
+		if d.Status.AvailableReplicas < replicas {
+			return false, nil
+		}
+	}
+	return true, nil
+}
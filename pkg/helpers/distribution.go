@@ -0,0 +1,72 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DistributionKubernetes is a standard Kubernetes cluster, including the managed ones such as EKS, GKE and AKS
+	DistributionKubernetes = "kubernetes"
+	// DistributionOpenShift is an OpenShift cluster
+	DistributionOpenShift = "openshift"
+	// DistributionK3s is a k3s cluster
+	DistributionK3s = "k3s"
+	// DistributionMicroShift is a MicroShift cluster, it has some OpenShift APIs but not the OpenShift configuration
+	DistributionMicroShift = "microshift"
+)
+
+// Distributions are the supported distributions of the managed clusters
+var Distributions = []string{DistributionKubernetes, DistributionOpenShift, DistributionK3s, DistributionMicroShift}
+
+// openShiftConfigGroup is only served by OpenShift, MicroShift serves the route and security groups but not this one
+const openShiftConfigGroup = "config.openshift.io"
+
+// ValidateDistribution checks the distribution is supported, "" lets the distribution be detected
+func ValidateDistribution(distribution string) error {
+	if distribution == "" {
+		return nil
+	}
+	for _, d := range Distributions {
+		if distribution == d {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported distribution %s, supported distributions are %s", distribution, strings.Join(Distributions, ", "))
+}
+
+// DetectDistribution returns the distribution of a cluster from its version, its microshift-version
+// ConfigMap and the API groups it serves
+func DetectDistribution(ctx context.Context, client kubernetes.Interface) (string, error) {
+	info, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(info.GitVersion, "+k3s") {
+		return DistributionK3s, nil
+	}
+	_, err = client.CoreV1().ConfigMaps("kube-public").Get(ctx, "microshift-version", metav1.GetOptions{})
+	switch {
+	case err == nil:
+		return DistributionMicroShift, nil
+	case !errors.IsNotFound(err) && !errors.IsForbidden(err):
+		return "", err
+	}
+	groups, err := client.Discovery().ServerGroups()
+	if err != nil {
+		return "", err
+	}
+	for _, g := range groups.Groups {
+		if g.Name == openShiftConfigGroup {
+			return DistributionOpenShift, nil
+		}
+	}
+	return DistributionKubernetes, nil
+}
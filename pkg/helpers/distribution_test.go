@@ -0,0 +1,79 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDetectDistribution(t *testing.T) {
+	tests := []struct {
+		name       string
+		gitVersion string
+		objs       []runtime.Object
+		groups     []string
+		want       string
+	}{
+		{
+			name:       "Kubernetes",
+			gitVersion: "v1.20.4",
+			want:       DistributionKubernetes,
+		},
+		{
+			name:       "OpenShift",
+			gitVersion: "v1.20.0+bafe72f",
+			groups:     []string{"route.openshift.io/v1", "config.openshift.io/v1"},
+			want:       DistributionOpenShift,
+		},
+		{
+			name:       "k3s",
+			gitVersion: "v1.20.4+k3s1",
+			want:       DistributionK3s,
+		},
+		{
+			name:       "MicroShift",
+			gitVersion: "v1.21.0",
+			objs: []runtime.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "microshift-version", Namespace: "kube-public"}},
+			},
+			groups: []string{"route.openshift.io/v1", "security.openshift.io/v1"},
+			want:   DistributionMicroShift,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := kubefake.NewSimpleClientset(tt.objs...)
+			fd := client.Discovery().(*fakediscovery.FakeDiscovery)
+			fd.FakedServerVersion = &version.Info{GitVersion: tt.gitVersion}
+			for _, gv := range tt.groups {
+				fd.Resources = append(fd.Resources, &metav1.APIResourceList{GroupVersion: gv})
+			}
+			got, err := DetectDistribution(context.TODO(), client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectDistribution() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateDistribution(t *testing.T) {
+	for _, d := range append([]string{""}, Distributions...) {
+		if err := ValidateDistribution(d); err != nil {
+			t.Errorf("ValidateDistribution(%s) error = %v", d, err)
+		}
+	}
+	if err := ValidateDistribution("rancher"); err == nil {
+		t.Error("error expected for an unsupported distribution")
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package fake provides the fake client and the fixtures of the unit tests
+package fake
+
+import (
+	"context"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// NewClient returns a fake client for the unit tests, the OCM kinds which are not part of
+// the client-go scheme are registered as unstructured so they can be listed.
+func NewClient(objs ...runtime.Object) crclient.Client {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		panic(err)
+	}
+	gvks := append([]schema.GroupVersionKind{}, helpers.UnstructuredGVKs...)
+	for _, o := range objs {
+		if _, ok := o.(*unstructured.Unstructured); ok {
+			gvks = append(gvks, o.GetObjectKind().GroupVersionKind())
+		}
+	}
+	for _, gvk := range gvks {
+		if !s.Recognizes(gvk) {
+			s.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		}
+		listGVK := gvk.GroupVersion().WithKind(gvk.Kind + "List")
+		if !s.Recognizes(listGVK) {
+			s.AddKnownTypeWithName(listGVK, &unstructured.UnstructuredList{})
+		}
+	}
+	return crclientfake.NewFakeClientWithScheme(s, objs...)
+}
+
+// TokenReviewClient answers the TokenReviews as an api server which authenticates the tokens or not,
+// the other requests are sent to the client
+type TokenReviewClient struct {
+	crclient.Client
+	Authenticated bool
+}
+
+func (c *TokenReviewClient) Create(ctx context.Context, obj runtime.Object, opts ...crclient.CreateOption) error {
+	if review, ok := obj.(*authenticationv1.TokenReview); ok {
+		review.Status.Authenticated = c.Authenticated
+		return nil
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
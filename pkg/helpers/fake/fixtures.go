@@ -0,0 +1,114 @@
+// Copyright Contributors to the Open Cluster Management project
+package fake
+
+import (
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ManagedClusterOption sets a field of the ManagedCluster of NewManagedCluster
+type ManagedClusterOption func(mc *unstructured.Unstructured)
+
+// NewManagedCluster returns the ManagedCluster of the name with the fields of the options
+func NewManagedCluster(name string, options ...ManagedClusterOption) *unstructured.Unstructured {
+	mc := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	mc.SetName(name)
+	for _, option := range options {
+		option(mc)
+	}
+	return mc
+}
+
+// WithLabels adds the labels to the ManagedCluster
+func WithLabels(labels map[string]string) ManagedClusterOption {
+	return func(mc *unstructured.Unstructured) {
+		l := mc.GetLabels()
+		if l == nil {
+			l = make(map[string]string)
+		}
+		for k, v := range labels {
+			l[k] = v
+		}
+		mc.SetLabels(l)
+	}
+}
+
+// WithClusterSet adds the ManagedClusterSet label to the ManagedCluster, nothing is set for an empty set
+func WithClusterSet(clusterSet string) ManagedClusterOption {
+	if clusterSet == "" {
+		return func(mc *unstructured.Unstructured) {}
+	}
+	return WithLabels(map[string]string{helpers.ClusterSetLabel: clusterSet})
+}
+
+// WithAnnotations sets the annotations of the ManagedCluster
+func WithAnnotations(annotations map[string]string) ManagedClusterOption {
+	return func(mc *unstructured.Unstructured) {
+		mc.SetAnnotations(annotations)
+	}
+}
+
+// WithConditions appends the conditions to the status of the ManagedCluster
+func WithConditions(conditions ...map[string]interface{}) ManagedClusterOption {
+	return func(mc *unstructured.Unstructured) {
+		cs, _, _ := unstructured.NestedSlice(mc.Object, "status", "conditions")
+		for _, c := range conditions {
+			cs = append(cs, c)
+		}
+		_ = unstructured.SetNestedSlice(mc.Object, cs, "status", "conditions")
+	}
+}
+
+// WithField sets a nested field of the ManagedCluster, such as spec.taints
+func WithField(value interface{}, fields ...string) ManagedClusterOption {
+	return func(mc *unstructured.Unstructured) {
+		_ = unstructured.SetNestedField(mc.Object, value, fields...)
+	}
+}
+
+// ConditionOption sets a field of the condition of NewCondition
+type ConditionOption func(c map[string]interface{})
+
+// NewCondition returns the status condition of the type and status with the fields of the options
+func NewCondition(conditionType, status string, options ...ConditionOption) map[string]interface{} {
+	c := map[string]interface{}{"type": conditionType, "status": status}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// WithReason sets the reason of the condition
+func WithReason(reason string) ConditionOption {
+	return func(c map[string]interface{}) {
+		c["reason"] = reason
+	}
+}
+
+// WithMessage sets the message of the condition
+func WithMessage(message string) ConditionOption {
+	return func(c map[string]interface{}) {
+		c["message"] = message
+	}
+}
+
+// WithLastTransitionTime sets the last transition time of the condition
+func WithLastTransitionTime(t time.Time) ConditionOption {
+	return func(c map[string]interface{}) {
+		c["lastTransitionTime"] = t.Format(time.RFC3339)
+	}
+}
+
+// NewImportSecret returns the import secret generated by the import controller for the cluster
+func NewImportSecret(clusterName string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: clusterName, Name: clusterName + "-import"},
+		Data:       data,
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// NewFakeClient is the fake.NewClient of the helpers tests, which can not import the fake package as it imports helpers
+func NewFakeClient(objs ...runtime.Object) crclient.Client {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		panic(err)
+	}
+	gvks := append([]schema.GroupVersionKind{}, UnstructuredGVKs...)
+	for _, o := range objs {
+		if _, ok := o.(*unstructured.Unstructured); ok {
+			gvks = append(gvks, o.GetObjectKind().GroupVersionKind())
+		}
+	}
+	for _, gvk := range gvks {
+		if !s.Recognizes(gvk) {
+			s.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		}
+		listGVK := gvk.GroupVersion().WithKind(gvk.Kind + "List")
+		if !s.Recognizes(listGVK) {
+			s.AddKnownTypeWithName(listGVK, &unstructured.UnstructuredList{})
+		}
+	}
+	return crclientfake.NewFakeClientWithScheme(s, objs...)
+}
@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// TempFile creates an empty temporary file in the directory of path and returns its name.
+// The file is created next to path because a rename across volumes fails, especially on Windows.
+func TempFile(path string) (string, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	f, err := ioutil.TempFile(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	name := f.Name()
+	if err := f.Close(); err != nil {
+		os.Remove(name)
+		return "", err
+	}
+	return name, nil
+}
+
+// ReplaceFile moves src to dst, replacing dst if it exists, and restricts dst to the current user
+func ReplaceFile(src, dst string) error {
+	if err := os.Rename(src, dst); err != nil {
+		//Windows refuses to replace a read-only file
+		if _, serr := os.Stat(dst); serr != nil {
+			return err
+		}
+		if err := os.Chmod(dst, 0600); err != nil {
+			return err
+		}
+		if err := os.Remove(dst); err != nil {
+			return err
+		}
+		if err := os.Rename(src, dst); err != nil {
+			return err
+		}
+	}
+	return RestrictPermissions(dst)
+}
+
+// RestrictPermissions sets the 0600 permissions on a file.
+// Windows only supports the read-only attribute, the file keeps the ACLs inherited from its directory.
+func RestrictPermissions(path string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	return os.Chmod(path, 0600)
+}
@@ -0,0 +1,56 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestTempFileReplaceFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dst := filepath.Join(dir, "sub", "import.yaml")
+
+	for _, content := range []string{"first", "second"} {
+		tmp, err := TempFile(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filepath.Dir(tmp) != filepath.Dir(dst) {
+			t.Errorf("temporary file %s must be in the directory of %s", tmp, dst)
+		}
+		if err := ioutil.WriteFile(tmp, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := ReplaceFile(tmp, dst); err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadFile(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != content {
+			t.Errorf("expected %s got %s", content, string(b))
+		}
+		if _, err := os.Stat(tmp); !os.IsNotExist(err) {
+			t.Errorf("temporary file %s must be removed", tmp)
+		}
+	}
+
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Mode().Perm() != 0600 {
+			t.Errorf("expected permissions 0600 got %o", fi.Mode().Perm())
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// durationValue is a duration flag which also accepts a number of seconds,
+// the unit of the timeout flags before they were durations
+type durationValue time.Duration
+
+func (d *durationValue) Set(s string) error {
+	if seconds, err := strconv.Atoi(s); err == nil {
+		*d = durationValue(time.Duration(seconds) * time.Second)
+		return nil
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = durationValue(v)
+	return nil
+}
+
+func (d *durationValue) Type() string {
+	return "duration"
+}
+
+func (d *durationValue) String() string {
+	return time.Duration(*d).String()
+}
+
+// DurationVar defines a duration flag such as 2m30s, a number without unit is a number of seconds
+func DurationVar(flagSet *pflag.FlagSet, p *time.Duration, name string, value time.Duration, usage string) {
+	*p = value
+	flagSet.Var((*durationValue)(p), name, usage)
+}
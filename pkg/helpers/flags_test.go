@@ -0,0 +1,39 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func TestDurationVar(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "2m30s", want: 150 * time.Second},
+		{value: "300", want: 300 * time.Second},
+		{value: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			var d time.Duration
+			flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			DurationVar(flagSet, &d, "timeout", time.Minute, "")
+			if d != time.Minute {
+				t.Errorf("default = %v, want %v", d, time.Minute)
+			}
+			err := flagSet.Set("timeout", tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && d != tt.want {
+				t.Errorf("duration = %v, want %v", d, tt.want)
+			}
+		})
+	}
+}
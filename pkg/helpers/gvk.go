@@ -0,0 +1,242 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	CustomResourceDefinitionGVK = schema.GroupVersionKind{
+		Group:   "apiextensions.k8s.io",
+		Version: "v1",
+		Kind:    "CustomResourceDefinition",
+	}
+	ManagedClusterGVK = schema.GroupVersionKind{
+		Group:   "cluster.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "ManagedCluster",
+	}
+	PolicyGVK = schema.GroupVersionKind{
+		Group:   "policy.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "Policy",
+	}
+	PolicyListGVK = schema.GroupVersionKind{
+		Group:   "policy.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "PolicyList",
+	}
+	ApplicationGVK = schema.GroupVersionKind{
+		Group:   "app.k8s.io",
+		Version: "v1beta1",
+		Kind:    "Application",
+	}
+	ApplicationListGVK = schema.GroupVersionKind{
+		Group:   "app.k8s.io",
+		Version: "v1beta1",
+		Kind:    "ApplicationList",
+	}
+	SubscriptionGVK = schema.GroupVersionKind{
+		Group:   "apps.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "Subscription",
+	}
+	SubscriptionListGVK = schema.GroupVersionKind{
+		Group:   "apps.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "SubscriptionList",
+	}
+	PlacementRuleGVK = schema.GroupVersionKind{
+		Group:   "apps.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "PlacementRule",
+	}
+	ClusterPoolGVK = schema.GroupVersionKind{
+		Group:   "hive.openshift.io",
+		Version: "v1",
+		Kind:    "ClusterPool",
+	}
+	ClusterPoolListGVK = schema.GroupVersionKind{
+		Group:   "hive.openshift.io",
+		Version: "v1",
+		Kind:    "ClusterPoolList",
+	}
+	ClusterClaimGVK = schema.GroupVersionKind{
+		Group:   "hive.openshift.io",
+		Version: "v1",
+		Kind:    "ClusterClaim",
+	}
+	ClusterClaimListGVK = schema.GroupVersionKind{
+		Group:   "hive.openshift.io",
+		Version: "v1",
+		Kind:    "ClusterClaimList",
+	}
+	ClusterDeploymentGVK = schema.GroupVersionKind{
+		Group:   "hive.openshift.io",
+		Version: "v1",
+		Kind:    "ClusterDeployment",
+	}
+	ManifestWorkGVK = schema.GroupVersionKind{
+		Group:   "work.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "ManifestWork",
+	}
+	ManifestWorkListGVK = schema.GroupVersionKind{
+		Group:   "work.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "ManifestWorkList",
+	}
+	PlacementDecisionGVK = schema.GroupVersionKind{
+		Group:   "cluster.open-cluster-management.io",
+		Version: "v1alpha1",
+		Kind:    "PlacementDecision",
+	}
+	PlacementDecisionListGVK = schema.GroupVersionKind{
+		Group:   "cluster.open-cluster-management.io",
+		Version: "v1alpha1",
+		Kind:    "PlacementDecisionList",
+	}
+	KlusterletAddonConfigGVK = schema.GroupVersionKind{
+		Group:   "agent.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "KlusterletAddonConfig",
+	}
+	ClusterManagerGVK = schema.GroupVersionKind{
+		Group:   "operator.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "ClusterManager",
+	}
+	MultiClusterHubGVK = schema.GroupVersionKind{
+		Group:   "operator.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "MultiClusterHub",
+	}
+	KlusterletGVK = schema.GroupVersionKind{
+		Group:   "operator.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "Klusterlet",
+	}
+	ManagedClusterSetGVK = schema.GroupVersionKind{
+		Group:   "cluster.open-cluster-management.io",
+		Version: "v1alpha1",
+		Kind:    "ManagedClusterSet",
+	}
+	ManagedClusterAddOnGVK = schema.GroupVersionKind{
+		Group:   "addon.open-cluster-management.io",
+		Version: "v1alpha1",
+		Kind:    "ManagedClusterAddOn",
+	}
+	ClusterCuratorGVK = schema.GroupVersionKind{
+		Group:   "cluster.open-cluster-management.io",
+		Version: "v1beta1",
+		Kind:    "ClusterCurator",
+	}
+	MultiClusterObservabilityGVK = schema.GroupVersionKind{
+		Group:   "observability.open-cluster-management.io",
+		Version: "v1beta1",
+		Kind:    "MultiClusterObservability",
+	}
+	BrokerGVK = schema.GroupVersionKind{
+		Group:   "submariner.io",
+		Version: "v1alpha1",
+		Kind:    "Broker",
+	}
+	SubmarinerConfigGVK = schema.GroupVersionKind{
+		Group:   "submarineraddon.open-cluster-management.io",
+		Version: "v1alpha1",
+		Kind:    "SubmarinerConfig",
+	}
+	KlusterletConfigGVK = schema.GroupVersionKind{
+		Group:   "config.open-cluster-management.io",
+		Version: "v1alpha1",
+		Kind:    "KlusterletConfig",
+	}
+	ClusterVersionGVK = schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "ClusterVersion",
+	}
+	InfrastructureGVK = schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "Infrastructure",
+	}
+	DNSGVK = schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "DNS",
+	}
+	AnsibleJobGVK = schema.GroupVersionKind{
+		Group:   "tower.ansible.com",
+		Version: "v1alpha1",
+		Kind:    "AnsibleJob",
+	}
+	ManagedClusterInfoGVK = schema.GroupVersionKind{
+		Group:   "internal.open-cluster-management.io",
+		Version: "v1beta1",
+		Kind:    "ManagedClusterInfo",
+	}
+	RouteGVK = schema.GroupVersionKind{
+		Group:   "route.openshift.io",
+		Version: "v1",
+		Kind:    "Route",
+	}
+	ManagedClusterSetBindingGVK = schema.GroupVersionKind{
+		Group:   "cluster.open-cluster-management.io",
+		Version: "v1alpha1",
+		Kind:    "ManagedClusterSetBinding",
+	}
+	// ClusterViewManagedClusterGVK lists the managed clusters the user has access to, without the right to list all the clusters
+	ClusterViewManagedClusterGVK = schema.GroupVersionKind{
+		Group:   "clusterview.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "ManagedCluster",
+	}
+)
+
+// UnstructuredGVKs are the kinds manipulated as unstructured objects, the fake clients of the tests register them
+var UnstructuredGVKs = []schema.GroupVersionKind{
+	CustomResourceDefinitionGVK,
+	ManagedClusterGVK,
+	PolicyGVK,
+	ApplicationGVK,
+	SubscriptionGVK,
+	PlacementRuleGVK,
+	ClusterPoolGVK,
+	ClusterClaimGVK,
+	ClusterDeploymentGVK,
+	ManifestWorkGVK,
+	PlacementDecisionGVK,
+	KlusterletAddonConfigGVK,
+	ClusterManagerGVK,
+	MultiClusterHubGVK,
+	KlusterletGVK,
+	ClusterCuratorGVK,
+	ManagedClusterSetGVK,
+	ManagedClusterAddOnGVK,
+	MultiClusterObservabilityGVK,
+	BrokerGVK,
+	SubmarinerConfigGVK,
+	KlusterletConfigGVK,
+	ClusterVersionGVK,
+	InfrastructureGVK,
+	DNSGVK,
+	AnsibleJobGVK,
+	ManagedClusterInfoGVK,
+	RouteGVK,
+	ManagedClusterSetBindingGVK,
+	ClusterViewManagedClusterGVK,
+}
+
+const (
+	ManagedClusterCRDName = "managedclusters.cluster.open-cluster-management.io"
+	// RootPolicyLabel is set by the policy propagator on the policies replicated in the cluster namespaces
+	RootPolicyLabel = "policy.open-cluster-management.io/root-policy"
+	// PlacementLabel is set by the placement controller on the placementdecisions of a placement
+	PlacementLabel = "cluster.open-cluster-management.io/placement"
+	// ClusterSetLabel sets the clusterset of a managed cluster
+	ClusterSetLabel = "cluster.open-cluster-management.io/clusterset"
+	// ClusterNameLabel is set by the registration agent on its certificate signing requests
+	ClusterNameLabel = "open-cluster-management.io/cluster-name"
+)
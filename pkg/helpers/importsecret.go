@@ -0,0 +1,63 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ImportSecretCRDsKey   = "crds.yaml"
+	ImportSecretImportKey = "import.yaml"
+	// BootstrapSecretName is the secret of the import manifests holding the kubeconfig the klusterlet bootstraps with
+	BootstrapSecretName = "bootstrap-hub-kubeconfig"
+)
+
+// GetImportSecret returns the <cluster>-import secret generated on the hub for a managed cluster
+func GetImportSecret(ctx context.Context, client crclient.Client, clusterName string) (*corev1.Secret, error) {
+	importSecret := &corev1.Secret{}
+	err := client.Get(ctx,
+		types.NamespacedName{Name: fmt.Sprintf("%s-import", clusterName),
+			Namespace: clusterName}, importSecret)
+	if err != nil {
+		return nil, err
+	}
+	return importSecret, nil
+}
+
+// GetImportManifests returns the decoded crds.yaml and import.yaml of an import secret
+func GetImportManifests(importSecret *corev1.Secret) (crds []byte, imports []byte, err error) {
+	crds, ok := importSecret.Data[ImportSecretCRDsKey]
+	if !ok {
+		return nil, nil, fmt.Errorf("%s not found in secret %s/%s", ImportSecretCRDsKey, importSecret.Namespace, importSecret.Name)
+	}
+	imports, ok = importSecret.Data[ImportSecretImportKey]
+	if !ok {
+		return nil, nil, fmt.Errorf("%s not found in secret %s/%s", ImportSecretImportKey, importSecret.Namespace, importSecret.Name)
+	}
+	return crds, imports, nil
+}
+
+// GetBootstrapSecret returns the bootstrap-hub-kubeconfig Secret of the import manifests of an import secret
+func GetBootstrapSecret(importSecret *corev1.Secret) (*unstructured.Unstructured, error) {
+	_, imports, err := GetImportManifests(importSecret)
+	if err != nil {
+		return nil, err
+	}
+	manifests, err := DecodeManifests(imports, fmt.Sprintf("the import secret %s/%s", importSecret.Namespace, importSecret.Name))
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range manifests {
+		if m.GetKind() == "Secret" && m.GetName() == BootstrapSecretName {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s secret in the import secret %s/%s", BootstrapSecretName, importSecret.Namespace, importSecret.Name)
+}
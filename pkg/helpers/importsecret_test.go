@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetBootstrapSecret(t *testing.T) {
+	importYAML := `apiVersion: v1
+kind: Namespace
+metadata:
+  name: open-cluster-management-agent
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: bootstrap-hub-kubeconfig
+  namespace: open-cluster-management-agent
+data:
+  kubeconfig: aHVi
+`
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		wantErr bool
+	}{
+		{name: "Success", data: map[string][]byte{ImportSecretCRDsKey: nil, ImportSecretImportKey: []byte(importYAML)}},
+		{name: "Failed, no bootstrap secret", data: map[string][]byte{ImportSecretCRDsKey: nil, ImportSecretImportKey: []byte("")}, wantErr: true},
+		{name: "Failed, no import.yaml", data: map[string][]byte{ImportSecretCRDsKey: nil}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: "c1-import"},
+				Data:       tt.data,
+			}
+			secret, err := GetBootstrapSecret(importSecret)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBootstrapSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && secret.GetNamespace() != "open-cluster-management-agent" {
+				t.Errorf("unexpected bootstrap secret %v", secret.Object)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/ghodss/yaml"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	InventoryAPIVersion = "cm-cli.open-cluster-management.io/v1alpha1"
+	InventoryKind       = "Inventory"
+
+	InventoryActionCreated   = "created"
+	InventoryActionUpdated   = "updated"
+	InventoryActionUnchanged = "unchanged"
+)
+
+// Inventory is the portable description of the clusters managed by a hub
+type Inventory struct {
+	APIVersion  string                `json:"apiVersion"`
+	Kind        string                `json:"kind"`
+	ClusterSets []InventoryClusterSet `json:"clusterSets,omitempty"`
+	Clusters    []InventoryCluster    `json:"clusters,omitempty"`
+}
+
+// InventoryClusterSet is a ManagedClusterSet of the inventory
+type InventoryClusterSet struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// InventoryCluster is a ManagedCluster of the inventory, the addons are the spec of its KlusterletAddonConfig
+type InventoryCluster struct {
+	Name        string                 `json:"name"`
+	ClusterSet  string                 `json:"clusterSet,omitempty"`
+	Labels      map[string]string      `json:"labels,omitempty"`
+	Annotations map[string]string      `json:"annotations,omitempty"`
+	Addons      map[string]interface{} `json:"addons,omitempty"`
+}
+
+// InventoryChange is a change done, or to be done in dry-run, by the reconciliation of an inventory
+type InventoryChange struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+// inventoryIgnoredLabels are set by the hub itself, the clusterset label is the clusterSet of the cluster
+var inventoryIgnoredLabels = []string{"name", ClusterSetLabel}
+
+// inventoryIgnoredAnnotations are related to the objects of the exported hub
+var inventoryIgnoredAnnotations = []string{"kubectl.kubernetes.io/last-applied-configuration"}
+
+// kacClusterFields are the fields of the KlusterletAddonConfig spec derived from the cluster
+var kacClusterFields = []string{"clusterName", "clusterNamespace", "clusterLabels"}
+
+// ExportInventory returns the inventory of the hub, the local-cluster is the hub itself and is not exported
+func ExportInventory(ctx context.Context, client crclient.Client) (*Inventory, error) {
+	inventory := &Inventory{
+		APIVersion: InventoryAPIVersion,
+		Kind:       InventoryKind,
+	}
+
+	clusterSets := &unstructured.UnstructuredList{}
+	clusterSets.SetGroupVersionKind(ManagedClusterSetGVK.GroupVersion().WithKind(ManagedClusterSetGVK.Kind + "List"))
+	if err := client.List(ctx, clusterSets); err != nil {
+		return nil, err
+	}
+	for _, cs := range clusterSets.Items {
+		inventory.ClusterSets = append(inventory.ClusterSets, InventoryClusterSet{
+			Name:   cs.GetName(),
+			Labels: cs.GetLabels(),
+		})
+	}
+	sort.Slice(inventory.ClusterSets, func(i, j int) bool {
+		return inventory.ClusterSets[i].Name < inventory.ClusterSets[j].Name
+	})
+
+	clusters := &unstructured.UnstructuredList{}
+	clusters.SetGroupVersionKind(ManagedClusterGVK.GroupVersion().WithKind(ManagedClusterGVK.Kind + "List"))
+	if err := client.List(ctx, clusters); err != nil {
+		return nil, err
+	}
+	for _, mc := range clusters.Items {
+		if mc.GetName() == "local-cluster" {
+			continue
+		}
+		c := InventoryCluster{
+			Name:        mc.GetName(),
+			ClusterSet:  mc.GetLabels()[ClusterSetLabel],
+			Labels:      withoutKeys(mc.GetLabels(), inventoryIgnoredLabels),
+			Annotations: withoutKeys(mc.GetAnnotations(), inventoryIgnoredAnnotations),
+		}
+		kac := &unstructured.Unstructured{}
+		kac.SetGroupVersionKind(KlusterletAddonConfigGVK)
+		err := client.Get(ctx, types.NamespacedName{Name: mc.GetName(), Namespace: mc.GetName()}, kac)
+		switch {
+		case errors.IsNotFound(err):
+		case err != nil:
+			return nil, err
+		default:
+			spec, _, _ := unstructured.NestedMap(kac.Object, "spec")
+			for _, f := range kacClusterFields {
+				delete(spec, f)
+			}
+			if len(spec) != 0 {
+				c.Addons = spec
+			}
+		}
+		inventory.Clusters = append(inventory.Clusters, c)
+	}
+	sort.Slice(inventory.Clusters, func(i, j int) bool {
+		return inventory.Clusters[i].Name < inventory.Clusters[j].Name
+	})
+	return inventory, nil
+}
+
+// ReadInventory reads and validates an inventory file
+func ReadInventory(path string) (*Inventory, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	inventory := &Inventory{}
+	if err := yaml.Unmarshal(b, inventory); err != nil {
+		return nil, fmt.Errorf("invalid inventory %s: %s", path, err.Error())
+	}
+	if inventory.APIVersion != InventoryAPIVersion || inventory.Kind != InventoryKind {
+		return nil, fmt.Errorf("invalid inventory %s: expected apiVersion %s and kind %s", path, InventoryAPIVersion, InventoryKind)
+	}
+	clusterSets := map[string]bool{}
+	for _, cs := range inventory.ClusterSets {
+		if cs.Name == "" {
+			return nil, fmt.Errorf("invalid inventory %s: a clusterset has no name", path)
+		}
+		clusterSets[cs.Name] = true
+	}
+	for _, c := range inventory.Clusters {
+		if c.Name == "" {
+			return nil, fmt.Errorf("invalid inventory %s: a cluster has no name", path)
+		}
+		if c.ClusterSet != "" && !clusterSets[c.ClusterSet] {
+			return nil, fmt.Errorf("invalid inventory %s: the clusterset %s of the cluster %s is not defined", path, c.ClusterSet, c.Name)
+		}
+	}
+	return inventory, nil
+}
+
+// ReconcileInventory creates or updates the clustersets, clusters and addon configurations of the inventory,
+// the objects which are not in the inventory are left untouched.
+// In dry-run the changes are returned but not applied.
+func ReconcileInventory(ctx context.Context, client crclient.Client, inventory *Inventory, dryRun bool) ([]InventoryChange, error) {
+	changes := make([]InventoryChange, 0)
+	for _, cs := range inventory.ClusterSets {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(ManagedClusterSetGVK)
+		obj.SetName(cs.Name)
+		action, err := reconcileObject(ctx, client, obj, func(o *unstructured.Unstructured) {
+			o.SetLabels(mergedKeys(o.GetLabels(), cs.Labels))
+		}, dryRun)
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, InventoryChange{Kind: ManagedClusterSetGVK.Kind, Name: cs.Name, Action: action})
+	}
+
+	for _, c := range inventory.Clusters {
+		labels := c.Labels
+		if c.ClusterSet != "" {
+			labels = mergedKeys(c.Labels, map[string]string{ClusterSetLabel: c.ClusterSet})
+		}
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: c.Name}}
+		if err := client.Get(ctx, types.NamespacedName{Name: c.Name}, &corev1.Namespace{}); err != nil {
+			if !errors.IsNotFound(err) {
+				return changes, err
+			}
+			if !dryRun {
+				if err := client.Create(ctx, ns); err != nil {
+					return changes, err
+				}
+			}
+		}
+
+		mc := &unstructured.Unstructured{}
+		mc.SetGroupVersionKind(ManagedClusterGVK)
+		mc.SetName(c.Name)
+		action, err := reconcileObject(ctx, client, mc, func(o *unstructured.Unstructured) {
+			o.SetLabels(mergedKeys(o.GetLabels(), labels))
+			o.SetAnnotations(mergedKeys(o.GetAnnotations(), c.Annotations))
+			if _, found, _ := unstructured.NestedBool(o.Object, "spec", "hubAcceptsClient"); !found {
+				unstructured.SetNestedField(o.Object, true, "spec", "hubAcceptsClient")
+			}
+		}, dryRun)
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, InventoryChange{Kind: ManagedClusterGVK.Kind, Name: c.Name, Action: action})
+
+		if len(c.Addons) == 0 {
+			continue
+		}
+		kac := &unstructured.Unstructured{}
+		kac.SetGroupVersionKind(KlusterletAddonConfigGVK)
+		kac.SetName(c.Name)
+		kac.SetNamespace(c.Name)
+		action, err = reconcileObject(ctx, client, kac, func(o *unstructured.Unstructured) {
+			for k, v := range c.Addons {
+				unstructured.SetNestedField(o.Object, v, "spec", k)
+			}
+			unstructured.SetNestedField(o.Object, c.Name, "spec", "clusterName")
+			unstructured.SetNestedField(o.Object, c.Name, "spec", "clusterNamespace")
+		}, dryRun)
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, InventoryChange{Kind: KlusterletAddonConfigGVK.Kind, Name: c.Name, Action: action})
+	}
+	return changes, nil
+}
+
+// reconcileObject creates obj or updates the existing object if mutate changes it
+func reconcileObject(ctx context.Context, client crclient.Client, obj *unstructured.Unstructured, mutate func(*unstructured.Unstructured), dryRun bool) (string, error) {
+	existing := obj.DeepCopy()
+	err := client.Get(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, existing)
+	if errors.IsNotFound(err) {
+		mutate(obj)
+		if !dryRun {
+			if err := client.Create(ctx, obj); err != nil {
+				return "", err
+			}
+		}
+		return InventoryActionCreated, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	updated := existing.DeepCopy()
+	mutate(updated)
+	if reflect.DeepEqual(existing.Object, updated.Object) {
+		return InventoryActionUnchanged, nil
+	}
+	if !dryRun {
+		if err := client.Update(ctx, updated); err != nil {
+			return "", err
+		}
+	}
+	return InventoryActionUpdated, nil
+}
+
+// withoutKeys returns a copy of m without the keys, nil if the copy is empty
+func withoutKeys(m map[string]string, keys []string) map[string]string {
+	r := mergedKeys(nil, m)
+	for _, k := range keys {
+		delete(r, k)
+	}
+	return mergedKeys(nil, r)
+}
+
+// mergedKeys returns a copy of m overwritten by the keys of overrides, nil if the copy is empty
+func mergedKeys(m, overrides map[string]string) map[string]string {
+	r := make(map[string]string, len(m)+len(overrides))
+	for k, v := range m {
+		r[k] = v
+	}
+	for k, v := range overrides {
+		r[k] = v
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	return r
+}
@@ -0,0 +1,189 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newInventoryObject(gvk schema.GroupVersionKind, name, namespace string, labels map[string]string, spec map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetName(name)
+	u.SetNamespace(namespace)
+	u.SetLabels(labels)
+	if spec != nil {
+		u.Object["spec"] = spec
+	}
+	return u
+}
+
+func newInventoryHub() []runtime.Object {
+	return []runtime.Object{
+		newInventoryObject(ManagedClusterSetGVK, "dev", "", map[string]string{"team": "a"}, nil),
+		newInventoryObject(ManagedClusterGVK, "local-cluster", "", map[string]string{"local-cluster": "true"}, nil),
+		newInventoryObject(ManagedClusterGVK, "cluster1", "", map[string]string{
+			"name":          "cluster1",
+			"env":           "dev",
+			ClusterSetLabel: "dev",
+		}, map[string]interface{}{"hubAcceptsClient": true}),
+		newInventoryObject(KlusterletAddonConfigGVK, "cluster1", "cluster1", nil, map[string]interface{}{
+			"clusterName":      "cluster1",
+			"clusterNamespace": "cluster1",
+			"searchCollector":  map[string]interface{}{"enabled": false},
+		}),
+	}
+}
+
+func TestExportInventory(t *testing.T) {
+	inventory, err := ExportInventory(context.TODO(), NewFakeClient(newInventoryHub()...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Inventory{
+		APIVersion:  InventoryAPIVersion,
+		Kind:        InventoryKind,
+		ClusterSets: []InventoryClusterSet{{Name: "dev", Labels: map[string]string{"team": "a"}}},
+		Clusters: []InventoryCluster{{
+			Name:       "cluster1",
+			ClusterSet: "dev",
+			Labels:     map[string]string{"env": "dev"},
+			Addons:     map[string]interface{}{"searchCollector": map[string]interface{}{"enabled": false}},
+		}},
+	}
+	if !reflect.DeepEqual(inventory, want) {
+		t.Errorf("ExportInventory() = %+v, want %+v", inventory, want)
+	}
+}
+
+func TestReadInventory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "inventory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name      string
+		inventory Inventory
+		wantErr   bool
+	}{
+		{
+			name: "Success",
+			inventory: Inventory{
+				APIVersion:  InventoryAPIVersion,
+				Kind:        InventoryKind,
+				ClusterSets: []InventoryClusterSet{{Name: "dev"}},
+				Clusters:    []InventoryCluster{{Name: "cluster1", ClusterSet: "dev"}},
+			},
+		},
+		{
+			name:      "Failed, wrong kind",
+			inventory: Inventory{APIVersion: InventoryAPIVersion, Kind: "ManagedCluster"},
+			wantErr:   true,
+		},
+		{
+			name: "Failed, undefined clusterset",
+			inventory: Inventory{
+				APIVersion: InventoryAPIVersion,
+				Kind:       InventoryKind,
+				Clusters:   []InventoryCluster{{Name: "cluster1", ClusterSet: "dev"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := yaml.Marshal(tt.inventory)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(dir, "inventory.yaml")
+			if err := ioutil.WriteFile(path, b, 0600); err != nil {
+				t.Fatal(err)
+			}
+			_, err = ReadInventory(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadInventory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReconcileInventory(t *testing.T) {
+	inventory, err := ExportInventory(context.TODO(), NewFakeClient(newInventoryHub()...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	inventory.Clusters = append(inventory.Clusters, InventoryCluster{Name: "cluster2", Labels: map[string]string{"env": "prod"}})
+
+	//The target hub already has cluster1 without its labels
+	client := NewFakeClient(
+		newInventoryObject(ManagedClusterGVK, "cluster1", "", nil, map[string]interface{}{"hubAcceptsClient": true}),
+	)
+
+	changes, err := ReconcileInventory(context.TODO(), client, inventory, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []InventoryChange{
+		{Kind: "ManagedClusterSet", Name: "dev", Action: InventoryActionCreated},
+		{Kind: "ManagedCluster", Name: "cluster1", Action: InventoryActionUpdated},
+		{Kind: "KlusterletAddonConfig", Name: "cluster1", Action: InventoryActionCreated},
+		{Kind: "ManagedCluster", Name: "cluster2", Action: InventoryActionCreated},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("dry-run changes = %v, want %v", changes, want)
+	}
+	cs := &unstructured.Unstructured{}
+	cs.SetGroupVersionKind(ManagedClusterSetGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "dev"}, cs); err == nil {
+		t.Error("the dry-run must not create the clusterset")
+	}
+
+	changes, err = ReconcileInventory(context.TODO(), client, inventory, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %v, want %v", changes, want)
+	}
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(ManagedClusterGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, mc); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(mc.GetLabels(), map[string]string{"env": "dev", ClusterSetLabel: "dev"}) {
+		t.Errorf("labels = %v", mc.GetLabels())
+	}
+	kac := &unstructured.Unstructured{}
+	kac.SetGroupVersionKind(KlusterletAddonConfigGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "cluster1", Namespace: "cluster1"}, kac); err != nil {
+		t.Fatal(err)
+	}
+	if enabled, found, _ := unstructured.NestedBool(kac.Object, "spec", "searchCollector", "enabled"); !found || enabled {
+		t.Errorf("searchCollector must be disabled")
+	}
+
+	//A second reconciliation has nothing to do
+	changes, err = ReconcileInventory(context.TODO(), client, inventory, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range changes {
+		if c.Action != InventoryActionUnchanged {
+			t.Errorf("%s %s must be unchanged, got %s", c.Kind, c.Name, c.Action)
+		}
+	}
+}
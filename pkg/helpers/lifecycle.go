@@ -0,0 +1,77 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// OwnerAnnotation is the owner of a ManagedCluster, such as a team or an email
+	OwnerAnnotation = "cm-cli.open-cluster-management.io/owner"
+	// TicketAnnotation is the ticket tracking a ManagedCluster
+	TicketAnnotation = "cm-cli.open-cluster-management.io/ticket"
+	// ExpiresAnnotation is the RFC 3339 time after which a ManagedCluster can be deleted by "gc --expired"
+	ExpiresAnnotation = "cm-cli.open-cluster-management.io/expires"
+)
+
+// ParseExpiry returns the expiry of a duration from now such as 72h or 7d, of a date such as 2026-12-31
+// which expires at the end of the day in UTC, or of an RFC 3339 time such as 2026-12-31T18:00:00Z
+func ParseExpiry(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if days := strings.TrimSuffix(s, "d"); days != s {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return now.AddDate(0, 0, n).UTC(), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return now.Add(d).UTC(), nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid expiry %s, expected a duration such as 72h or 7d, a date such as 2026-12-31 or an RFC 3339 time", s)
+}
+
+// NormalizeExpiry returns the RFC 3339 time of the annotation for an expiry given to ParseExpiry,
+// "" if the expiry is empty. An expiry in the past is rejected.
+func NormalizeExpiry(s string, now time.Time) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	t, err := ParseExpiry(s, now)
+	if err != nil {
+		return "", err
+	}
+	if !t.After(now) {
+		return "", fmt.Errorf("the expiry %s is in the past", t.Format(time.RFC3339))
+	}
+	return t.Format(time.RFC3339), nil
+}
+
+// ClusterExpiry returns the expiry of the ManagedCluster and true if it has a valid expiry annotation
+func ClusterExpiry(mc *unstructured.Unstructured) (time.Time, bool) {
+	v, ok := mc.GetAnnotations()[ExpiresAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// IsExpired returns true if the ManagedCluster has an expiry before now
+func IsExpired(mc *unstructured.Unstructured, now time.Time) bool {
+	t, ok := ClusterExpiry(mc)
+	return ok && t.Before(now)
+}
@@ -0,0 +1,65 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNormalizeExpiry(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		expiry  string
+		want    string
+		wantErr bool
+	}{
+		{expiry: "", want: ""},
+		{expiry: "72h", want: "2026-10-19T12:00:00Z"},
+		{expiry: "7d", want: "2026-10-23T12:00:00Z"},
+		{expiry: "2026-12-31", want: "2026-12-31T23:59:59Z"},
+		{expiry: "2026-12-31T20:00:00+02:00", want: "2026-12-31T18:00:00Z"},
+		{expiry: "2026-01-01", wantErr: true},
+		{expiry: "-1h", wantErr: true},
+		{expiry: "0d", wantErr: true},
+		{expiry: "next week", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeExpiry(tt.expiry, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeExpiry(%s) error = %v, wantErr %v", tt.expiry, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeExpiry(%s) = %s, want %s", tt.expiry, got, tt.want)
+		}
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		expires string
+		want    bool
+	}{
+		{name: "Expired", expires: "2026-10-16T11:00:00Z", want: true},
+		{name: "Not expired", expires: "2026-10-16T13:00:00Z"},
+		{name: "Invalid", expires: "tomorrow"},
+		{name: "No expiry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &unstructured.Unstructured{}
+			mc.SetGroupVersionKind(ManagedClusterGVK)
+			if tt.expires != "" {
+				mc.SetAnnotations(map[string]string{ExpiresAnnotation: tt.expires})
+			}
+			if got := IsExpired(mc, now); got != tt.want {
+				t.Errorf("IsExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
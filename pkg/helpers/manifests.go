@@ -0,0 +1,82 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// ReadManifests reads the yaml or json manifests of a file or of the files of a directory,
+// a file can contain several yaml documents. The files of a directory are read in alphabetical order.
+func ReadManifests(path string) ([]*unstructured.Unstructured, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if fi.IsDir() {
+		files = make([]string, 0)
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			switch strings.ToLower(filepath.Ext(e.Name())) {
+			case ".yaml", ".yml", ".json":
+				if !e.IsDir() {
+					files = append(files, filepath.Join(path, e.Name()))
+				}
+			}
+		}
+		sort.Strings(files)
+	}
+
+	manifests := make([]*unstructured.Unstructured, 0)
+	for _, f := range files {
+		b, err := ioutil.ReadFile(filepath.Clean(f))
+		if err != nil {
+			return nil, err
+		}
+		ms, err := DecodeManifests(b, f)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, ms...)
+	}
+	return manifests, nil
+}
+
+// DecodeManifests decodes the yaml documents or the json manifests, the source names them in the errors
+func DecodeManifests(b []byte, source string) ([]*unstructured.Unstructured, error) {
+	manifests := make([]*unstructured.Unstructured, 0)
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(b), 4096)
+	for {
+		u := &unstructured.Unstructured{}
+		err := decoder.Decode(&u.Object)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %s", source, err.Error())
+		}
+		//Skip the empty documents
+		if len(u.Object) == 0 {
+			continue
+		}
+		if u.GetKind() == "" || u.GetAPIVersion() == "" {
+			return nil, fmt.Errorf("manifest without kind or apiVersion in %s", source)
+		}
+		manifests = append(manifests, u)
+	}
+	return manifests, nil
+}
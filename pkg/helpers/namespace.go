@@ -0,0 +1,13 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// GetNamespaceFromFlags returns the namespace set with --namespace or the one of the current context
+func GetNamespaceFromFlags(configFlags *genericclioptions.ConfigFlags) (string, error) {
+	namespace, _, err := configFlags.ToRawKubeConfigLoader().Namespace()
+	return namespace, err
+}
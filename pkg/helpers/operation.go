@@ -0,0 +1,106 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OperationIDLabel is set on the configmaps recording the operations launched asynchronously
+	OperationIDLabel = "cm-cli.open-cluster-management.io/operation-id"
+	// OperationClusterLabel is the cluster targeted by an operation
+	OperationClusterLabel = "cm-cli.open-cluster-management.io/cluster"
+)
+
+// Operation is an asynchronous operation recorded on the hub
+type Operation struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Cluster   string    `json:"cluster"`
+	StartTime time.Time `json:"startTime"`
+}
+
+// NewOperation records an operation in a configmap of the cluster namespace
+func NewOperation(ctx context.Context, client crclient.Client, operationType, clusterName string) (*Operation, error) {
+	op := &Operation{
+		ID:        fmt.Sprintf("%s-%s-%s", operationType, clusterName, rand.String(5)),
+		Type:      operationType,
+		Cluster:   clusterName,
+		StartTime: time.Now().UTC().Truncate(time.Second),
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      op.ID,
+			Namespace: clusterName,
+			Labels: map[string]string{
+				OperationIDLabel:      op.ID,
+				OperationClusterLabel: clusterName,
+			},
+		},
+		Data: map[string]string{
+			"type":      op.Type,
+			"cluster":   op.Cluster,
+			"startTime": op.StartTime.Format(time.RFC3339),
+		},
+	}
+	if err := client.Create(ctx, cm); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// GetOperation returns the operation with the given ID or nil if not found
+func GetOperation(ctx context.Context, client crclient.Client, id string) (*Operation, error) {
+	cms := &corev1.ConfigMapList{}
+	err := client.List(ctx, cms, crclient.MatchingLabels{OperationIDLabel: id})
+	if err != nil {
+		return nil, err
+	}
+	if len(cms.Items) == 0 {
+		return nil, nil
+	}
+	return operationFromConfigMap(&cms.Items[0])
+}
+
+// GetLastClusterOperation returns the last operation launched on a cluster or nil if none
+func GetLastClusterOperation(ctx context.Context, client crclient.Client, clusterName string) (*Operation, error) {
+	cms := &corev1.ConfigMapList{}
+	err := client.List(ctx, cms,
+		crclient.InNamespace(clusterName),
+		crclient.MatchingLabels{OperationClusterLabel: clusterName})
+	if err != nil {
+		return nil, err
+	}
+	var last *Operation
+	for i := range cms.Items {
+		op, err := operationFromConfigMap(&cms.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		if last == nil || op.StartTime.After(last.StartTime) {
+			last = op
+		}
+	}
+	return last, nil
+}
+
+func operationFromConfigMap(cm *corev1.ConfigMap) (*Operation, error) {
+	startTime, err := time.Parse(time.RFC3339, cm.Data["startTime"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid startTime in operation %s: %s", cm.Name, err.Error())
+	}
+	return &Operation{
+		ID:        cm.Labels[OperationIDLabel],
+		Type:      cm.Data["type"],
+		Cluster:   cm.Data["cluster"],
+		StartTime: startTime,
+	}, nil
+}
@@ -0,0 +1,49 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	crclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOperation(t *testing.T) {
+	client := crclientfake.NewFakeClient()
+	op, err := NewOperation(context.TODO(), client, "attach", "cluster1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetOperation(context.TODO(), client, op.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || *got != *op {
+		t.Errorf("GetOperation() = %v, want %v", got, op)
+	}
+
+	got, err = GetLastClusterOperation(context.TODO(), client, "cluster1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.ID != op.ID {
+		t.Errorf("GetLastClusterOperation() = %v, want %v", got, op)
+	}
+
+	got, err = GetOperation(context.TODO(), client, "unknown")
+	if err != nil || got != nil {
+		t.Errorf("GetOperation() = %v, %v, want nil", got, err)
+	}
+
+	got, err = GetLastClusterOperation(context.TODO(), client, "cluster2")
+	if err != nil || got != nil {
+		t.Errorf("GetLastClusterOperation() = %v, %v, want nil", got, err)
+	}
+
+	if !op.StartTime.Equal(op.StartTime.Truncate(time.Second)) {
+		t.Errorf("startTime must be truncated to the second, got %v", op.StartTime)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetPlacementClusters returns the sorted names of the clusters selected by a placement
+func GetPlacementClusters(ctx context.Context, client crclient.Client, namespace, name string) ([]string, error) {
+	decisions := &unstructured.UnstructuredList{}
+	decisions.SetGroupVersionKind(PlacementDecisionListGVK)
+	err := client.List(ctx, decisions,
+		crclient.InNamespace(namespace),
+		crclient.MatchingLabels{PlacementLabel: name})
+	if err != nil {
+		return nil, err
+	}
+	clusters := make([]string, 0)
+	for _, pd := range decisions.Items {
+		items, _, _ := unstructured.NestedSlice(pd.Object, "status", "decisions")
+		for _, i := range items {
+			d, ok := i.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if c, _, _ := unstructured.NestedString(d, "clusterName"); c != "" {
+				clusters = append(clusters, c)
+			}
+		}
+	}
+	sort.Strings(clusters)
+	return clusters, nil
+}
@@ -0,0 +1,23 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodProblem returns why the pod is not ready or an empty string if it is ready
+func PodProblem(pod *corev1.Pod) string {
+	if pod.Status.Phase != corev1.PodRunning {
+		return string(pod.Status.Phase)
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil {
+			return cs.State.Waiting.Reason
+		}
+		if !cs.Ready {
+			return "not ready"
+		}
+	}
+	return ""
+}
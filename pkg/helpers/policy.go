@@ -0,0 +1,37 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// PolicyClusterStatus is the compliance of a policy on a given cluster
+type PolicyClusterStatus struct {
+	ClusterName      string
+	ClusterNamespace string
+	Compliant        string
+}
+
+// GetPolicyClusterStatuses returns the per-cluster compliance found in the status of a root policy
+func GetPolicyClusterStatuses(policy *unstructured.Unstructured) []PolicyClusterStatus {
+	statuses := make([]PolicyClusterStatus, 0)
+	items, _, _ := unstructured.NestedSlice(policy.Object, "status", "status")
+	for _, i := range items {
+		m, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		s := PolicyClusterStatus{}
+		s.ClusterName, _, _ = unstructured.NestedString(m, "clustername")
+		s.ClusterNamespace, _, _ = unstructured.NestedString(m, "clusternamespace")
+		s.Compliant, _, _ = unstructured.NestedString(m, "compliant")
+		statuses = append(statuses, s)
+	}
+	return statuses
+}
+
+// ReplicatedPolicyName returns the name of the policy replicated in the cluster namespaces
+func ReplicatedPolicyName(namespace, name string) string {
+	return namespace + "." + name
+}
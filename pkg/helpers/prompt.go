@@ -0,0 +1,41 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// AddYesFlag adds the --yes flag skipping the confirmation of a destructive operation
+func AddYesFlag(flagSet *pflag.FlagSet, p *bool) {
+	flagSet.BoolVarP(p, "yes", "y", false, "Skip the confirmation prompt, required when the input is not interactive")
+}
+
+// Confirm prints what will be removed and asks to type the expected name,
+// the operation is aborted if the answer does not match or if there is no input to read it from.
+func Confirm(in io.Reader, out io.Writer, summary []string, expected string) error {
+	for _, line := range summary {
+		fmt.Fprintln(out, line)
+	}
+	fmt.Fprintf(out, "Type %s to confirm: ", expected)
+	if in == nil {
+		fmt.Fprintln(out)
+		return fmt.Errorf("aborted, no input to confirm, use --yes to skip the confirmation")
+	}
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if strings.TrimSpace(answer) != expected {
+		if err == io.EOF {
+			fmt.Fprintln(out)
+		}
+		return fmt.Errorf("aborted, %s was not confirmed, use --yes to skip the confirmation", expected)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      io.Reader
+		wantErr bool
+	}{
+		{
+			name: "Confirmed",
+			in:   strings.NewReader("mycluster\n"),
+		},
+		{
+			name: "Confirmed without newline",
+			in:   strings.NewReader(" mycluster"),
+		},
+		{
+			name:    "Wrong name",
+			in:      strings.NewReader("other\n"),
+			wantErr: true,
+		},
+		{
+			name:    "Empty input",
+			in:      strings.NewReader(""),
+			wantErr: true,
+		},
+		{
+			name:    "No input",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := Confirm(tt.in, out, []string{"The cluster mycluster will be deleted"}, "mycluster")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Confirm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(out.String(), "The cluster mycluster will be deleted") {
+				t.Errorf("Confirm() must print the summary, got %s", out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ProtectionAnnotation protects a ManagedCluster against the detach and the delete of the CLI,
+	// its value is the reason of the protection
+	ProtectionAnnotation = "cm-cli.open-cluster-management.io/deletion-protection"
+	// OverrideProtectionFlag is the flag of the detach and the delete acting on a protected cluster
+	OverrideProtectionFlag = "override-protection"
+)
+
+// ProtectionReason returns the reason of the protection of the ManagedCluster and true if it is protected
+func ProtectionReason(mc *unstructured.Unstructured) (string, bool) {
+	reason, ok := mc.GetAnnotations()[ProtectionAnnotation]
+	return reason, ok
+}
+
+// CheckProtection returns an error if the ManagedCluster is protected and the protection is not overridden,
+// a cluster which does not exist is not protected
+func CheckProtection(ctx context.Context, client crclient.Client, clusterName string, override bool) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(ManagedClusterGVK)
+	err := client.Get(ctx, types.NamespacedName{Name: clusterName}, mc)
+	switch {
+	case errors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	}
+	reason, protected := ProtectionReason(mc)
+	if !protected || override {
+		return nil
+	}
+	if reason != "" {
+		reason = fmt.Sprintf(" (%s)", reason)
+	}
+	return fmt.Errorf("the cluster %s is protected%s, remove the protection with \"%s unprotect cluster %s\" or use --%s",
+		clusterName, reason, GetExampleHeader(), clusterName, OverrideProtectionFlag)
+}
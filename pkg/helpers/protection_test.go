@@ -0,0 +1,45 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCheckProtection(t *testing.T) {
+	protected := &unstructured.Unstructured{}
+	protected.SetGroupVersionKind(ManagedClusterGVK)
+	protected.SetName("protected")
+	protected.SetAnnotations(map[string]string{ProtectionAnnotation: "production"})
+	unprotected := &unstructured.Unstructured{}
+	unprotected.SetGroupVersionKind(ManagedClusterGVK)
+	unprotected.SetName("unprotected")
+	client := NewFakeClient(protected, unprotected)
+
+	tests := []struct {
+		name        string
+		clusterName string
+		override    bool
+		wantErr     bool
+	}{
+		{name: "Protected", clusterName: "protected", wantErr: true},
+		{name: "Protected, overridden", clusterName: "protected", override: true},
+		{name: "Unprotected", clusterName: "unprotected"},
+		{name: "Not found", clusterName: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckProtection(context.TODO(), client, tt.clusterName, tt.override)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckProtection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "(production)") {
+				t.Errorf("the error must give the reason of the protection, got %s", err.Error())
+			}
+		})
+	}
+}
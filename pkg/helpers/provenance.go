@@ -0,0 +1,153 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// ProvenanceCLIVersionAnnotation is the version of the cli which wrote the import manifests
+	ProvenanceCLIVersionAnnotation = "cm-cli.open-cluster-management.io/cli-version"
+	// ProvenanceSourceAnnotation is the namespace/name of the import secret the manifests were written from
+	ProvenanceSourceAnnotation = "cm-cli.open-cluster-management.io/source"
+	// ProvenanceResourceVersionAnnotation is the resourceVersion of the import secret the manifests were written from
+	ProvenanceResourceVersionAnnotation = "cm-cli.open-cluster-management.io/source-resource-version"
+	// ProvenanceContentHashAnnotation is the sha256 of the crds.yaml and import.yaml of the import secret
+	ProvenanceContentHashAnnotation = "cm-cli.open-cluster-management.io/content-hash"
+)
+
+// provenanceAnnotations are the annotations added to the import manifests written by the cli
+var provenanceAnnotations = []string{
+	ProvenanceCLIVersionAnnotation,
+	ProvenanceSourceAnnotation,
+	ProvenanceResourceVersionAnnotation,
+	ProvenanceContentHashAnnotation,
+}
+
+// ImportProvenance is the origin of the import manifests written by the cli
+type ImportProvenance struct {
+	CLIVersion      string
+	Source          string
+	ResourceVersion string
+	ContentHash     string
+}
+
+// NewImportProvenance returns the provenance of the manifests of the import secret
+func NewImportProvenance(importSecret *corev1.Secret) (*ImportProvenance, error) {
+	crds, imports, err := GetImportManifests(importSecret)
+	if err != nil {
+		return nil, err
+	}
+	return &ImportProvenance{
+		CLIVersion:      Version,
+		Source:          importSecret.Namespace + "/" + importSecret.Name,
+		ResourceVersion: importSecret.ResourceVersion,
+		ContentHash:     ImportContentHash(crds, imports),
+	}, nil
+}
+
+// ImportContentHash returns the sha256 of the crds.yaml and import.yaml of an import secret
+func ImportContentHash(crds, imports []byte) string {
+	h := sha256.New()
+	h.Write(crds)
+	h.Write(imports)
+	return fmt.Sprintf("sha256:%x", h.Sum(nil))
+}
+
+// SourceNamespace returns the namespace of the import secret, which is the name of the cluster
+func (p *ImportProvenance) SourceNamespace() string {
+	return strings.SplitN(p.Source, "/", 2)[0]
+}
+
+// AnnotateImportManifests adds the provenance annotations to each manifest of the yaml documents
+func AnnotateImportManifests(b []byte, p *ImportProvenance) ([]byte, error) {
+	manifests, err := DecodeManifests(b, "the import manifests")
+	if err != nil {
+		return nil, err
+	}
+	out := &bytes.Buffer{}
+	for i, m := range manifests {
+		annotations := m.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, len(provenanceAnnotations))
+		}
+		annotations[ProvenanceCLIVersionAnnotation] = p.CLIVersion
+		annotations[ProvenanceSourceAnnotation] = p.Source
+		annotations[ProvenanceResourceVersionAnnotation] = p.ResourceVersion
+		annotations[ProvenanceContentHashAnnotation] = p.ContentHash
+		m.SetAnnotations(annotations)
+		y, err := yaml.Marshal(m.Object)
+		if err != nil {
+			return nil, err
+		}
+		if i != 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(y)
+	}
+	return out.Bytes(), nil
+}
+
+// GetImportProvenance returns the provenance of the first annotated manifest, nil if no manifest is annotated
+func GetImportProvenance(manifests []*unstructured.Unstructured) *ImportProvenance {
+	for _, m := range manifests {
+		annotations := m.GetAnnotations()
+		if annotations[ProvenanceContentHashAnnotation] == "" {
+			continue
+		}
+		return &ImportProvenance{
+			CLIVersion:      annotations[ProvenanceCLIVersionAnnotation],
+			Source:          annotations[ProvenanceSourceAnnotation],
+			ResourceVersion: annotations[ProvenanceResourceVersionAnnotation],
+			ContentHash:     annotations[ProvenanceContentHashAnnotation],
+		}
+	}
+	return nil
+}
+
+// RemoveImportProvenance removes the provenance annotations of the manifest
+func RemoveImportProvenance(m *unstructured.Unstructured) {
+	annotations := m.GetAnnotations()
+	if annotations == nil {
+		return
+	}
+	for _, a := range provenanceAnnotations {
+		delete(annotations, a)
+	}
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(m.Object, "metadata", "annotations")
+		return
+	}
+	m.SetAnnotations(annotations)
+}
+
+// GetAnnotatedImportManifests returns the decoded crds.yaml and import.yaml of an import secret
+// with the provenance annotations, so the written manifests can be verified against the hub
+func GetAnnotatedImportManifests(importSecret *corev1.Secret) (crds []byte, imports []byte, err error) {
+	provenance, err := NewImportProvenance(importSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+	crds, imports, err = GetImportManifests(importSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+	crds, err = AnnotateImportManifests(crds, provenance)
+	if err != nil {
+		return nil, nil, err
+	}
+	imports, err = AnnotateImportManifests(imports, provenance)
+	if err != nil {
+		return nil, nil, err
+	}
+	return crds, imports, nil
+}
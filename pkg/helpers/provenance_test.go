@@ -0,0 +1,55 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAnnotateImportManifests(t *testing.T) {
+	importSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1-import", Namespace: "cluster1", ResourceVersion: "42"},
+		Data: map[string][]byte{
+			ImportSecretCRDsKey:   []byte("apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: klusterlets.operator.open-cluster-management.io\n"),
+			ImportSecretImportKey: []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: agent\n  annotations:\n    owner: me\n---\napiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: klusterlet\n  namespace: agent\n"),
+		},
+	}
+	_, imports, err := GetAnnotatedImportManifests(importSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifests, err := DecodeManifests(imports, "import.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifests) != 2 {
+		t.Fatalf("expected 2 manifests got %d", len(manifests))
+	}
+	p := GetImportProvenance(manifests)
+	if p == nil {
+		t.Fatal("provenance expected")
+	}
+	want := ImportContentHash(importSecret.Data[ImportSecretCRDsKey], importSecret.Data[ImportSecretImportKey])
+	if p.ContentHash != want || p.ResourceVersion != "42" || p.SourceNamespace() != "cluster1" || p.CLIVersion != Version {
+		t.Errorf("unexpected provenance %+v", p)
+	}
+
+	RemoveImportProvenance(manifests[0])
+	if a := manifests[0].GetAnnotations(); len(a) != 1 || a["owner"] != "me" {
+		t.Errorf("only the provenance annotations must be removed, got %v", a)
+	}
+	RemoveImportProvenance(manifests[1])
+	if _, ok := manifests[1].Object["metadata"].(map[string]interface{})["annotations"]; ok {
+		t.Error("the empty annotations must be removed")
+	}
+
+	if GetImportProvenance(manifests) != nil {
+		t.Error("no provenance expected once removed")
+	}
+	if _, err := AnnotateImportManifests([]byte("crds: mycrds"), p); err == nil {
+		t.Error("error expected for a document which is not a manifest")
+	}
+}
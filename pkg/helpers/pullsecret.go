@@ -0,0 +1,40 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+
+	"github.com/ghodss/yaml"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetPullSecretValues returns the hub pull-secret as a values map to be used in the templates
+func GetPullSecretValues(ctx context.Context, client crclient.Client) (map[string]interface{}, error) {
+	pullSecret := &corev1.Secret{}
+	err := client.Get(
+		ctx,
+		types.NamespacedName{
+			Name:      "pull-secret",
+			Namespace: "openshift-config",
+		},
+		pullSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := yaml.Marshal(pullSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	valueps := make(map[string]interface{})
+	err = yaml.Unmarshal(ps, &valueps)
+	if err != nil {
+		return nil, err
+	}
+	return valueps, nil
+}
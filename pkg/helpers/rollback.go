@@ -0,0 +1,88 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Rollback records the resources and files created by an operation to remove them if the operation fails,
+// the resources which existed before the operation are never removed
+type Rollback struct {
+	client    crclient.Client
+	resources []*unstructured.Unstructured
+	files     []string
+}
+
+// NewRollback creates a Rollback removing the resources with the client
+func NewRollback(client crclient.Client) *Rollback {
+	return &Rollback{client: client}
+}
+
+// TrackResources records the resources of the manifests which do not exist yet, they are about to be created
+func (r *Rollback) TrackResources(ctx context.Context, manifests []*unstructured.Unstructured) error {
+	for _, m := range manifests {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(m.GroupVersionKind())
+		err := r.client.Get(ctx, types.NamespacedName{Name: m.GetName(), Namespace: m.GetNamespace()}, u)
+		switch {
+		case errors.IsNotFound(err):
+			r.resources = append(r.resources, m)
+		case err != nil:
+			return err
+		}
+	}
+	return nil
+}
+
+// TrackFile records a file about to be written
+func (r *Rollback) TrackFile(path string) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		r.files = append(r.files, path)
+	}
+}
+
+// Run removes the tracked files and resources, the resources in the reverse order of their creation.
+// It removes as much as possible and returns an error listing what could not be removed.
+func (r *Rollback) Run(ctx context.Context) error {
+	failed := make([]string, 0)
+	for _, f := range r.files {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			failed = append(failed, fmt.Sprintf("file %s: %s", f, err.Error()))
+		}
+	}
+	for i := len(r.resources) - 1; i >= 0; i-- {
+		u := r.resources[i]
+		if err := r.client.Delete(ctx, u); err != nil && !errors.IsNotFound(err) {
+			failed = append(failed, fmt.Sprintf("%s %s: %s", u.GetKind(), resourceName(u), err.Error()))
+		}
+	}
+	if len(failed) != 0 {
+		return fmt.Errorf("unable to remove %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// Resources returns the kind and name of the tracked resources
+func (r *Rollback) Resources() []string {
+	names := make([]string, 0, len(r.resources))
+	for _, u := range r.resources {
+		names = append(names, fmt.Sprintf("%s/%s", u.GetKind(), resourceName(u)))
+	}
+	return names
+}
+
+func resourceName(u *unstructured.Unstructured) string {
+	if u.GetNamespace() == "" {
+		return u.GetName()
+	}
+	return u.GetNamespace() + "/" + u.GetName()
+}
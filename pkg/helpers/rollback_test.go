@@ -0,0 +1,89 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newManifest(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func TestRollback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rollback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	existingFile := filepath.Join(dir, "existing.yaml")
+	if err := ioutil.WriteFile(existingFile, []byte("existing"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	newFile := filepath.Join(dir, "new.yaml")
+
+	client := NewFakeClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "existing"}})
+	manifests := []*unstructured.Unstructured{
+		newManifest("v1", "Namespace", "", "existing"),
+		newManifest("v1", "Namespace", "", "cluster1"),
+		newManifest(ManagedClusterGVK.GroupVersion().String(), ManagedClusterGVK.Kind, "", "cluster1"),
+	}
+	r := NewRollback(client)
+	if err := r.TrackResources(context.TODO(), manifests); err != nil {
+		t.Fatal(err)
+	}
+	r.TrackFile(existingFile)
+	r.TrackFile(newFile)
+	if got := len(r.Resources()); got != 2 {
+		t.Errorf("expected 2 tracked resources, got %v", r.Resources())
+	}
+
+	//The operation creates the resources and the file, then fails
+	for _, m := range manifests[1:] {
+		if err := client.Create(context.TODO(), m.DeepCopy()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(newFile, []byte("new"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Run(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range manifests[1:] {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(m.GroupVersionKind())
+		if err := client.Get(context.TODO(), types.NamespacedName{Name: m.GetName()}, u); !errors.IsNotFound(err) {
+			t.Errorf("%s %s must be removed, got %v", m.GetKind(), m.GetName(), err)
+		}
+	}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "existing"}, &corev1.Namespace{}); err != nil {
+		t.Errorf("the existing namespace must be kept, got %v", err)
+	}
+	if _, err := os.Stat(existingFile); err != nil {
+		t.Errorf("the existing file must be kept, got %v", err)
+	}
+	if _, err := os.Stat(newFile); !os.IsNotExist(err) {
+		t.Errorf("the new file must be removed, got %v", err)
+	}
+	//Running twice is a no-op
+	if err := r.Run(context.TODO()); err != nil {
+		t.Errorf("a second rollback must succeed, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ImportServiceAccountNamespace is the namespace of the service account used to import a cluster
+	ImportServiceAccountNamespace = "open-cluster-management-import"
+	// ImportServiceAccountName is the name of the service account, its cluster role binding and token secret
+	ImportServiceAccountName = "managed-cluster-import"
+)
+
+var (
+	serviceAccountTokenPollInterval = 2 * time.Second
+	serviceAccountTokenTimeout      = 60 * time.Second
+)
+
+// BootstrapImportServiceAccount creates on the cluster to import a cluster-admin service account
+// with a non-expiring token and returns that token.
+// The cloud providers tokens expire after a few minutes, which is too short for the auto-import,
+// so the provider credentials are only used to create this service account.
+func BootstrapImportServiceAccount(ctx context.Context, kubeClient kubernetes.Interface) (string, error) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: ImportServiceAccountNamespace},
+	}
+	if _, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return "", err
+	}
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ImportServiceAccountName,
+			Namespace: ImportServiceAccountNamespace,
+		},
+	}
+	if _, err := kubeClient.CoreV1().ServiceAccounts(ImportServiceAccountNamespace).Create(ctx, sa, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return "", err
+	}
+
+	//The import creates CRDs, namespaces and cluster roles
+	crb := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: ImportServiceAccountName},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     "cluster-admin",
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      ImportServiceAccountName,
+				Namespace: ImportServiceAccountNamespace,
+			},
+		},
+	}
+	if _, err := kubeClient.RbacV1().ClusterRoleBindings().Create(ctx, crb, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return "", err
+	}
+
+	//The token secret is created explicitly as recent clusters no longer generate one per service account
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ImportServiceAccountName,
+			Namespace: ImportServiceAccountNamespace,
+			Annotations: map[string]string{
+				corev1.ServiceAccountNameKey: ImportServiceAccountName,
+			},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+	if _, err := kubeClient.CoreV1().Secrets(ImportServiceAccountNamespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return "", err
+	}
+
+	var token string
+	err := PollImmediate(ctx, serviceAccountTokenPollInterval, serviceAccountTokenTimeout, func() (bool, error) {
+		s, err := kubeClient.CoreV1().Secrets(ImportServiceAccountNamespace).Get(ctx, ImportServiceAccountName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		token = string(s.Data[corev1.ServiceAccountTokenKey])
+		return token != "", nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("the token of the service account %s/%s was not generated: %s",
+			ImportServiceAccountNamespace, ImportServiceAccountName, err.Error())
+	}
+	return token, nil
+}
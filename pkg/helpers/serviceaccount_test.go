@@ -0,0 +1,59 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestBootstrapImportServiceAccount(t *testing.T) {
+	serviceAccountTokenPollInterval = 10 * time.Millisecond
+	serviceAccountTokenTimeout = 100 * time.Millisecond
+
+	tests := []struct {
+		name      string
+		populate  bool
+		wantToken string
+		wantErr   bool
+	}{
+		{name: "Success", populate: true, wantToken: "sa-token"},
+		{name: "Failed, token not generated", populate: false, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset()
+			if tt.populate {
+				//Simulate the token controller
+				kubeClient.PrependReactor("create", "secrets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					s := action.(clienttesting.CreateAction).GetObject().(*corev1.Secret)
+					s.Data = map[string][]byte{corev1.ServiceAccountTokenKey: []byte("sa-token")}
+					return false, nil, nil
+				})
+			}
+			token, err := BootstrapImportServiceAccount(context.Background(), kubeClient)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BootstrapImportServiceAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if token != tt.wantToken {
+				t.Errorf("BootstrapImportServiceAccount() = %s, want %s", token, tt.wantToken)
+			}
+			if _, err := kubeClient.RbacV1().ClusterRoleBindings().Get(context.TODO(), ImportServiceAccountName, metav1.GetOptions{}); err != nil {
+				t.Error(err)
+			}
+			//Running it twice must succeed
+			if tt.populate {
+				if _, err := BootstrapImportServiceAccount(context.Background(), kubeClient); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const (
+	OperationSpecAPIVersion = "cm-cli.open-cluster-management.io/v1alpha1"
+	OperationSpecKind       = "OperationSpec"
+	// SaveSpecFlag is the flag of the commands which can be replayed from an operation spec
+	SaveSpecFlag = "save-spec"
+)
+
+// OperationSpec is a command with its resolved values, saved to be replayed
+type OperationSpec struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// Command is the path of the command without the binary name, for example attach cluster
+	Command    string                 `json:"command"`
+	CLIVersion string                 `json:"cliVersion"`
+	Args       []string               `json:"args,omitempty"`
+	Flags      map[string]string      `json:"flags,omitempty"`
+	Values     map[string]interface{} `json:"values"`
+}
+
+// specIgnoredFlags are not recorded, the values are saved once merged and the spec is replayed with its own values file
+var specIgnoredFlags = []string{"values", "set", "set-file", SaveSpecFlag, "help"}
+
+// NewOperationSpec records the command with its arguments, the resolved values and the flags set by the user.
+// The kubeconfig flags are not recorded as they are specific to the environment, nor the valuesFlags
+// which are already merged in the values.
+func NewOperationSpec(cmd *cobra.Command, args []string, values map[string]interface{}, valuesFlags []string) *OperationSpec {
+	ignored := map[string]bool{}
+	for _, name := range append(specIgnoredFlags, valuesFlags...) {
+		ignored[name] = true
+	}
+	configFlags := pflag.NewFlagSet("config", pflag.ContinueOnError)
+	genericclioptions.NewConfigFlags(true).AddFlags(configFlags)
+	configFlags.VisitAll(func(f *pflag.Flag) {
+		ignored[f.Name] = true
+	})
+
+	spec := &OperationSpec{
+		APIVersion: OperationSpecAPIVersion,
+		Kind:       OperationSpecKind,
+		Command:    strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name())),
+		CLIVersion: Version,
+		Args:       args,
+		Values:     values,
+	}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if ignored[f.Name] {
+			return
+		}
+		if spec.Flags == nil {
+			spec.Flags = map[string]string{}
+		}
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			spec.Flags[f.Name] = strings.Join(s.GetSlice(), ",")
+			return
+		}
+		spec.Flags[f.Name] = f.Value.String()
+	})
+	return spec
+}
+
+// WriteOperationSpec writes the spec restricted to the current user, the values may contain credentials
+func WriteOperationSpec(path string, spec *OperationSpec) error {
+	b, err := yaml.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	//Write in a temporary file so a failure never leaves a partial spec
+	tmpFile, err := TempFile(path)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile)
+	if err := ioutil.WriteFile(tmpFile, b, 0600); err != nil {
+		return err
+	}
+	return ReplaceFile(tmpFile, path)
+}
+
+// ReadOperationSpec reads and validates an operation spec file
+func ReadOperationSpec(path string) (*OperationSpec, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	spec := &OperationSpec{}
+	if err := yaml.Unmarshal(b, spec); err != nil {
+		return nil, fmt.Errorf("invalid operation spec %s: %s", path, err.Error())
+	}
+	if spec.APIVersion != OperationSpecAPIVersion || spec.Kind != OperationSpecKind {
+		return nil, fmt.Errorf("invalid operation spec %s: expected apiVersion %s and kind %s", path, OperationSpecAPIVersion, OperationSpecKind)
+	}
+	if spec.Command == "" {
+		return nil, fmt.Errorf("invalid operation spec %s: the command is missing", path)
+	}
+	return spec, nil
+}
+
+// WriteValuesFile writes the values in a temporary file restricted to the current user,
+// it is passed to a command with --values and must be removed by the caller
+func WriteValuesFile(values map[string]interface{}) (string, error) {
+	b, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	f, err := ioutil.TempFile("", "cm-values-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,90 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestNewOperationSpec(t *testing.T) {
+	cluster := &cobra.Command{Use: "cluster"}
+	cluster.Flags().String("values", "", "")
+	cluster.Flags().String("name", "", "")
+	cluster.Flags().Bool("async", false, "")
+	cluster.Flags().StringSlice("clusters", nil, "")
+	cluster.Flags().String("import-file", "", "")
+	cluster.Flags().String(SaveSpecFlag, "", "")
+	genericclioptions.NewConfigFlags(true).AddFlags(cluster.Flags())
+	attach := &cobra.Command{Use: "attach"}
+	attach.AddCommand(cluster)
+	root := &cobra.Command{Use: "cm"}
+	root.AddCommand(attach)
+	if err := cluster.Flags().Parse([]string{
+		"--values", "values.yaml",
+		"--name", "mycluster",
+		"--async",
+		"--clusters", "c1,c2",
+		"--save-spec", "spec.yaml",
+		"--kubeconfig", "hub.kubeconfig",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	values := map[string]interface{}{"managedClusterName": "mycluster"}
+	spec := NewOperationSpec(cluster, []string{"arg"}, values, []string{"name"})
+	want := &OperationSpec{
+		APIVersion: OperationSpecAPIVersion,
+		Kind:       OperationSpecKind,
+		Command:    "attach cluster",
+		CLIVersion: Version,
+		Args:       []string{"arg"},
+		Flags:      map[string]string{"async": "true", "clusters": "c1,c2"},
+		Values:     values,
+	}
+	if !reflect.DeepEqual(spec, want) {
+		t.Errorf("NewOperationSpec() = %+v, want %+v", spec, want)
+	}
+}
+
+func TestWriteReadOperationSpec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cm-spec-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	spec := &OperationSpec{
+		APIVersion: OperationSpecAPIVersion,
+		Kind:       OperationSpecKind,
+		Command:    "create cluster",
+		CLIVersion: "v0.1.0",
+		Flags:      map[string]string{"wait": "true"},
+		Values:     map[string]interface{}{"managedCluster": map[string]interface{}{"name": "mycluster"}},
+	}
+	path := filepath.Join(dir, "spec.yaml")
+	if err := WriteOperationSpec(path, spec); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadOperationSpec(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, spec) {
+		t.Errorf("ReadOperationSpec() = %+v, want %+v", got, spec)
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := ioutil.WriteFile(invalid, []byte("apiVersion: v1\nkind: ConfigMap\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadOperationSpec(invalid); err == nil {
+		t.Error("a file which is not an operation spec must be rejected")
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// TaintNoSelect prevents the placements which do not tolerate the taint to select the cluster
+	TaintNoSelect = "NoSelect"
+	// TaintPreferNoSelect avoids selecting the cluster unless no other cluster matches
+	TaintPreferNoSelect = "PreferNoSelect"
+	// TaintNoSelectIfNew prevents new selections of the cluster, the existing ones are kept
+	TaintNoSelectIfNew = "NoSelectIfNew"
+)
+
+// taintEffects are the effects supported by the ManagedCluster taints
+var taintEffects = []string{TaintNoSelect, TaintPreferNoSelect, TaintNoSelectIfNew}
+
+// Taint is a taint of the spec of a ManagedCluster, it is identified by its key and effect
+type Taint struct {
+	Key    string
+	Value  string
+	Effect string
+}
+
+func (t Taint) String() string {
+	if t.Value == "" {
+		return fmt.Sprintf("%s:%s", t.Key, t.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect)
+}
+
+// ParseTaint parses a KEY[=VALUE]:EFFECT taint
+func ParseTaint(s string) (Taint, error) {
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return Taint{}, fmt.Errorf("invalid taint %s, expected KEY[=VALUE]:EFFECT", s)
+	}
+	t := Taint{Key: s[:i], Effect: s[i+1:]}
+	if kv := strings.SplitN(t.Key, "=", 2); len(kv) == 2 {
+		t.Key, t.Value = kv[0], kv[1]
+		if errs := validation.IsValidLabelValue(t.Value); len(errs) != 0 {
+			return Taint{}, fmt.Errorf("invalid taint value %s: %s", t.Value, strings.Join(errs, ", "))
+		}
+	}
+	if err := validateTaintKey(t.Key); err != nil {
+		return Taint{}, err
+	}
+	if err := validateTaintEffect(t.Effect); err != nil {
+		return Taint{}, err
+	}
+	return t, nil
+}
+
+// ParseTaintKey parses a KEY[:EFFECT] taint to remove, the effect is "" to remove the taints of all effects
+func ParseTaintKey(s string) (key, effect string, err error) {
+	key = s
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		key, effect = s[:i], s[i+1:]
+		if err := validateTaintEffect(effect); err != nil {
+			return "", "", err
+		}
+	}
+	if err := validateTaintKey(key); err != nil {
+		return "", "", err
+	}
+	return key, effect, nil
+}
+
+func validateTaintKey(key string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+		return fmt.Errorf("invalid taint key %s: %s", key, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func validateTaintEffect(effect string) error {
+	for _, e := range taintEffects {
+		if effect == e {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid taint effect %s, supported effects are %s", effect, strings.Join(taintEffects, ", "))
+}
+
+// AddTaints sets the taints in the spec of the ManagedCluster and returns the changes done,
+// a taint of the same key and effect is given its new value only with overwrite
+func AddTaints(mc *unstructured.Unstructured, taints []Taint, overwrite bool, now time.Time) ([]string, error) {
+	current, _, err := unstructured.NestedSlice(mc.Object, "spec", "taints")
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]string, 0)
+	for _, t := range taints {
+		i := findTaint(current, t.Key, t.Effect)
+		if i < 0 {
+			current = append(current, map[string]interface{}{
+				"key":       t.Key,
+				"value":     t.Value,
+				"effect":    t.Effect,
+				"timeAdded": now.UTC().Format(time.RFC3339),
+			})
+			changes = append(changes, "+"+t.String())
+			continue
+		}
+		existing := current[i].(map[string]interface{})
+		value, _, _ := unstructured.NestedString(existing, "value")
+		if value == t.Value {
+			continue
+		}
+		if !overwrite {
+			return nil, fmt.Errorf("the cluster %s already has the taint %s, use --overwrite to change it",
+				mc.GetName(), Taint{Key: t.Key, Value: value, Effect: t.Effect})
+		}
+		existing["value"] = t.Value
+		changes = append(changes, fmt.Sprintf("%s (was %s)", t, value))
+	}
+	if len(changes) == 0 {
+		return changes, nil
+	}
+	return changes, unstructured.SetNestedSlice(mc.Object, current, "spec", "taints")
+}
+
+// RemoveTaint removes the taints of the key and effect from the spec of the ManagedCluster,
+// all the taints of the key if effect is "", and returns the removed taints
+func RemoveTaint(mc *unstructured.Unstructured, key, effect string) ([]string, error) {
+	current, _, err := unstructured.NestedSlice(mc.Object, "spec", "taints")
+	if err != nil {
+		return nil, err
+	}
+	kept := make([]interface{}, 0, len(current))
+	removed := make([]string, 0)
+	for _, c := range current {
+		m, ok := c.(map[string]interface{})
+		if !ok {
+			kept = append(kept, c)
+			continue
+		}
+		k, _, _ := unstructured.NestedString(m, "key")
+		e, _, _ := unstructured.NestedString(m, "effect")
+		if k != key || (effect != "" && e != effect) {
+			kept = append(kept, c)
+			continue
+		}
+		v, _, _ := unstructured.NestedString(m, "value")
+		removed = append(removed, "-"+Taint{Key: k, Value: v, Effect: e}.String())
+	}
+	if len(removed) == 0 {
+		return removed, nil
+	}
+	return removed, unstructured.SetNestedSlice(mc.Object, kept, "spec", "taints")
+}
+
+// findTaint returns the index of the taint of the key and effect, -1 if it is not found
+func findTaint(taints []interface{}, key, effect string) int {
+	for i, t := range taints {
+		m, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if m["key"] == key && m["effect"] == effect {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,150 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newTaintedCluster(taints ...interface{}) *unstructured.Unstructured {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(ManagedClusterGVK)
+	mc.SetName("cluster1")
+	if len(taints) != 0 {
+		unstructured.SetNestedSlice(mc.Object, taints, "spec", "taints")
+	}
+	return mc
+}
+
+func TestParseTaint(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    Taint
+		wantErr bool
+	}{
+		{name: "Success, value", arg: "maintenance=true:NoSelect", want: Taint{Key: "maintenance", Value: "true", Effect: TaintNoSelect}},
+		{name: "Success, no value", arg: "example.com/gpu:PreferNoSelect", want: Taint{Key: "example.com/gpu", Effect: TaintPreferNoSelect}},
+		{name: "Failed, no effect", arg: "maintenance=true", wantErr: true},
+		{name: "Failed, unknown effect", arg: "maintenance=true:NoSchedule", wantErr: true},
+		{name: "Failed, invalid key", arg: "main tenance:NoSelect", wantErr: true},
+		{name: "Failed, invalid value", arg: "maintenance=a b:NoSelect", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTaint(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTaint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTaint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTaintKey(t *testing.T) {
+	if key, effect, err := ParseTaintKey("maintenance"); err != nil || key != "maintenance" || effect != "" {
+		t.Errorf("ParseTaintKey() = %s %s %v", key, effect, err)
+	}
+	if key, effect, err := ParseTaintKey("maintenance:NoSelectIfNew"); err != nil || key != "maintenance" || effect != TaintNoSelectIfNew {
+		t.Errorf("ParseTaintKey() = %s %s %v", key, effect, err)
+	}
+	if _, _, err := ParseTaintKey("maintenance:Unknown"); err == nil {
+		t.Error("ParseTaintKey() must fail on an unknown effect")
+	}
+}
+
+func TestAddTaints(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	existing := map[string]interface{}{"key": "maintenance", "value": "true", "effect": TaintNoSelect, "timeAdded": "2021-05-01T12:00:00Z"}
+	tests := []struct {
+		name        string
+		mc          *unstructured.Unstructured
+		taints      []Taint
+		overwrite   bool
+		wantErr     bool
+		wantChanges []string
+		wantTaints  []interface{}
+	}{
+		{
+			name:        "Success, new taint",
+			mc:          newTaintedCluster(),
+			taints:      []Taint{{Key: "maintenance", Value: "true", Effect: TaintNoSelect}},
+			wantChanges: []string{"+maintenance=true:NoSelect"},
+			wantTaints: []interface{}{
+				map[string]interface{}{"key": "maintenance", "value": "true", "effect": TaintNoSelect, "timeAdded": "2021-06-01T12:00:00Z"},
+			},
+		},
+		{
+			name:        "Success, unchanged",
+			mc:          newTaintedCluster(existing),
+			taints:      []Taint{{Key: "maintenance", Value: "true", Effect: TaintNoSelect}},
+			wantChanges: []string{},
+			wantTaints:  []interface{}{existing},
+		},
+		{
+			name:        "Success, overwrite",
+			mc:          newTaintedCluster(existing),
+			taints:      []Taint{{Key: "maintenance", Value: "upgrade", Effect: TaintNoSelect}},
+			overwrite:   true,
+			wantChanges: []string{"maintenance=upgrade:NoSelect (was true)"},
+			wantTaints: []interface{}{
+				map[string]interface{}{"key": "maintenance", "value": "upgrade", "effect": TaintNoSelect, "timeAdded": "2021-05-01T12:00:00Z"},
+			},
+		},
+		{
+			name:    "Failed, existing taint without overwrite",
+			mc:      newTaintedCluster(existing),
+			taints:  []Taint{{Key: "maintenance", Value: "upgrade", Effect: TaintNoSelect}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := AddTaints(tt.mc, tt.taints, tt.overwrite, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddTaints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(changes, tt.wantChanges) {
+				t.Errorf("AddTaints() = %v, want %v", changes, tt.wantChanges)
+			}
+			taints, _, _ := unstructured.NestedSlice(tt.mc.Object, "spec", "taints")
+			if !reflect.DeepEqual(taints, tt.wantTaints) {
+				t.Errorf("taints = %v, want %v", taints, tt.wantTaints)
+			}
+		})
+	}
+}
+
+func TestRemoveTaint(t *testing.T) {
+	noSelect := map[string]interface{}{"key": "maintenance", "value": "true", "effect": TaintNoSelect}
+	ifNew := map[string]interface{}{"key": "maintenance", "effect": TaintNoSelectIfNew}
+	gpu := map[string]interface{}{"key": "gpu", "effect": TaintPreferNoSelect}
+
+	mc := newTaintedCluster(noSelect, ifNew, gpu)
+	removed, err := RemoveTaint(mc, "maintenance", TaintNoSelectIfNew)
+	if err != nil || !reflect.DeepEqual(removed, []string{"-maintenance:NoSelectIfNew"}) {
+		t.Errorf("RemoveTaint() = %v, %v", removed, err)
+	}
+
+	mc = newTaintedCluster(noSelect, ifNew, gpu)
+	removed, err = RemoveTaint(mc, "maintenance", "")
+	if err != nil || !reflect.DeepEqual(removed, []string{"-maintenance=true:NoSelect", "-maintenance:NoSelectIfNew"}) {
+		t.Errorf("RemoveTaint() = %v, %v", removed, err)
+	}
+	if taints, _, _ := unstructured.NestedSlice(mc.Object, "spec", "taints"); !reflect.DeepEqual(taints, []interface{}{gpu}) {
+		t.Errorf("taints = %v, want only the gpu taint", taints)
+	}
+
+	if removed, err := RemoveTaint(mc, "maintenance", ""); err != nil || len(removed) != 0 {
+		t.Errorf("RemoveTaint() of a missing taint = %v, %v", removed, err)
+	}
+}
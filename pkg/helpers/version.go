@@ -0,0 +1,28 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// Version is the version of the CLI, set at build time with
+// -ldflags "-X github.com/open-cluster-management/cm-cli/pkg/helpers.Version=<version>"
+var Version = "dev"
+
+// MinimumSpokeVersion is the oldest kubernetes version supported by the klusterlet
+var MinimumSpokeVersion = version.MustParseGeneric("v1.16.0")
+
+// CheckSpokeVersion checks the kubernetes version of the spoke, as returned by its discovery, is supported by the klusterlet
+func CheckSpokeVersion(gitVersion string) error {
+	v, err := version.ParseGeneric(gitVersion)
+	if err != nil {
+		return err
+	}
+	if v.LessThan(MinimumSpokeVersion) {
+		return fmt.Errorf("spoke version %s is not supported, minimum version is %s", gitVersion, MinimumSpokeVersion)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ErrInterrupted is returned by the waits aborted by the cancellation of their context, usually on Ctrl+C
+var ErrInterrupted = errors.New("interrupted")
+
+// PollImmediate runs the condition every interval until it returns true or an error.
+// It returns wait.ErrWaitTimeout when the timeout expires and ErrInterrupted when the context is done.
+func PollImmediate(ctx context.Context, interval, timeout time.Duration, condition wait.ConditionFunc) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := wait.PollImmediateUntil(interval, condition, timeoutCtx.Done())
+	if err == wait.ErrWaitTimeout && ctx.Err() != nil {
+		return ErrInterrupted
+	}
+	return err
+}
@@ -0,0 +1,32 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestPollImmediate(t *testing.T) {
+	never := func() (bool, error) { return false, nil }
+
+	if err := PollImmediate(context.Background(), time.Millisecond, time.Second, func() (bool, error) { return true, nil }); err != nil {
+		t.Errorf("PollImmediate() error = %v, want nil", err)
+	}
+	if err := PollImmediate(context.Background(), time.Millisecond, 10*time.Millisecond, never); err != wait.ErrWaitTimeout {
+		t.Errorf("PollImmediate() error = %v, want %v", err, wait.ErrWaitTimeout)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	start := time.Now()
+	if err := PollImmediate(ctx, time.Millisecond, time.Minute, never); err != ErrInterrupted {
+		t.Errorf("PollImmediate() error = %v, want %v", err, ErrInterrupted)
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Errorf("PollImmediate() must return as soon as the context is canceled")
+	}
+}
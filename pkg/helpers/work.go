@@ -0,0 +1,81 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	WorkAppliedCondition   = "Applied"
+	WorkAvailableCondition = "Available"
+)
+
+// ManifestStatus is the status of a manifest of a ManifestWork on the managed cluster
+type ManifestStatus struct {
+	Ordinal   int64             `json:"ordinal"`
+	Group     string            `json:"group,omitempty"`
+	Version   string            `json:"version,omitempty"`
+	Kind      string            `json:"kind"`
+	Namespace string            `json:"namespace,omitempty"`
+	Name      string            `json:"name"`
+	Applied   string            `json:"applied"`
+	Available string            `json:"available"`
+	Feedback  map[string]string `json:"feedback,omitempty"`
+}
+
+// GetManifestStatuses returns the status of each manifest of a ManifestWork with its feedback values
+func GetManifestStatuses(work *unstructured.Unstructured) []ManifestStatus {
+	statuses := make([]ManifestStatus, 0)
+	manifests, _, _ := unstructured.NestedSlice(work.Object, "status", "resourceStatus", "manifests")
+	for _, im := range manifests {
+		m, ok := im.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		s := ManifestStatus{}
+		s.Ordinal, _, _ = unstructured.NestedInt64(m, "resourceMeta", "ordinal")
+		s.Group, _, _ = unstructured.NestedString(m, "resourceMeta", "group")
+		s.Version, _, _ = unstructured.NestedString(m, "resourceMeta", "version")
+		s.Kind, _, _ = unstructured.NestedString(m, "resourceMeta", "kind")
+		s.Namespace, _, _ = unstructured.NestedString(m, "resourceMeta", "namespace")
+		s.Name, _, _ = unstructured.NestedString(m, "resourceMeta", "name")
+		conditions, _, _ := unstructured.NestedSlice(m, "conditions")
+		s.Applied = GetConditionStatus(conditions, WorkAppliedCondition)
+		s.Available = GetConditionStatus(conditions, WorkAvailableCondition)
+		values, _, _ := unstructured.NestedSlice(m, "statusFeedback", "values")
+		for _, iv := range values {
+			v, ok := iv.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if s.Feedback == nil {
+				s.Feedback = make(map[string]string)
+			}
+			name, _, _ := unstructured.NestedString(v, "name")
+			s.Feedback[name] = getFeedbackValue(v)
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses
+}
+
+func getFeedbackValue(v map[string]interface{}) string {
+	fieldType, _, _ := unstructured.NestedString(v, "fieldValue", "type")
+	switch fieldType {
+	case "Integer":
+		i, _, _ := unstructured.NestedFieldNoCopy(v, "fieldValue", "integer")
+		return fmt.Sprintf("%v", i)
+	case "Boolean":
+		b, _, _ := unstructured.NestedBool(v, "fieldValue", "boolean")
+		return fmt.Sprintf("%t", b)
+	case "JsonRaw":
+		s, _, _ := unstructured.NestedString(v, "fieldValue", "jsonRaw")
+		return s
+	default:
+		s, _, _ := unstructured.NestedString(v, "fieldValue", "string")
+		return s
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package preflight
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Check is a single verification run before applying anything
+type Check interface {
+	// Name returns a short human readable description of the check
+	Name() string
+	// Run executes the check and returns an error describing the finding if it fails
+	Run() error
+}
+
+type checkFunc struct {
+	name string
+	run  func() error
+}
+
+func (c *checkFunc) Name() string {
+	return c.name
+}
+
+func (c *checkFunc) Run() error {
+	return c.run()
+}
+
+// NewCheck creates a Check from a function
+func NewCheck(name string, run func() error) Check {
+	return &checkFunc{
+		name: name,
+		run:  run,
+	}
+}
+
+// Result holds the outcome of a Check
+type Result struct {
+	Name string
+	Err  error
+}
+
+// Run executes all checks, it doesn't stop on the first failure so all findings
+// can be reported at once.
+func Run(checks []Check) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, c := range checks {
+		results = append(results, Result{
+			Name: c.Name(),
+			Err:  c.Run(),
+		})
+	}
+	return results
+}
+
+// Failed returns the results which have an error
+func Failed(results []Result) []Result {
+	failed := make([]Result, 0)
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// FailedError lists the failed checks
+type FailedError struct {
+	Failed []Result
+}
+
+func (e *FailedError) Error() string {
+	msgs := make([]string, len(e.Failed))
+	for i, r := range e.Failed {
+		msgs[i] = fmt.Sprintf(" - %s: %s", r.Name, r.Err.Error())
+	}
+	return fmt.Sprintf("%d preflight check(s) failed:\n%s", len(e.Failed), strings.Join(msgs, "\n"))
+}
+
+// As finds the first error of the failed checks matching target, so the typed errors of the checks
+// can be explained
+func (e *FailedError) As(target interface{}) bool {
+	for _, r := range e.Failed {
+		if errors.As(r.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToError returns an error listing all failed checks or nil if all checks passed
+func ToError(results []Result) error {
+	failed := Failed(results)
+	if len(failed) == 0 {
+		return nil
+	}
+	return &FailedError{Failed: failed}
+}
@@ -0,0 +1,88 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package preflight
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	ran := 0
+	checks := []Check{
+		NewCheck("first", func() error { ran++; return fmt.Errorf("first failed") }),
+		NewCheck("second", func() error { ran++; return nil }),
+		NewCheck("third", func() error { ran++; return fmt.Errorf("third failed") }),
+	}
+	results := Run(checks)
+	if ran != len(checks) {
+		t.Errorf("expected %d checks to run, got %d", len(checks), ran)
+	}
+	if len(results) != len(checks) {
+		t.Errorf("expected %d results, got %d", len(checks), len(results))
+	}
+	if got := len(Failed(results)); got != 2 {
+		t.Errorf("expected 2 failed results, got %d", got)
+	}
+}
+
+func TestToError(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  []Result
+		wantErr  bool
+		contains []string
+	}{
+		{
+			name: "All passed",
+			results: []Result{
+				{Name: "first"},
+				{Name: "second"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Some failed",
+			results: []Result{
+				{Name: "first", Err: fmt.Errorf("first failed")},
+				{Name: "second"},
+				{Name: "third", Err: fmt.Errorf("third failed")},
+			},
+			wantErr:  true,
+			contains: []string{"2 preflight", "first: first failed", "third: third failed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ToError(tt.results)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToError() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(err.Error(), c) {
+					t.Errorf("ToError() = %s, must contain %s", err.Error(), c)
+				}
+			}
+		})
+	}
+}
+
+type checkError struct{}
+
+func (e *checkError) Error() string {
+	return "typed"
+}
+
+func TestFailedError_As(t *testing.T) {
+	err := ToError([]Result{
+		{Name: "first", Err: fmt.Errorf("first failed")},
+		{Name: "second", Err: &checkError{}},
+	})
+	var typed *checkError
+	if !errors.As(err, &typed) {
+		t.Errorf("errors.As() must find the typed error of the second check")
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package printers
+
+import (
+	"io"
+	"os"
+	"runtime"
+
+	"github.com/spf13/pflag"
+)
+
+// NoColor disables the colors of the table output, set by the global --no-color flag
+var NoColor = false
+
+const (
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+)
+
+// stateColors maps the states displayed in the tables to their color
+var stateColors = map[string]string{
+	"True":         colorGreen,
+	"Available":    colorGreen,
+	"Ready":        colorGreen,
+	"Running":      colorGreen,
+	"Succeeded":    colorGreen,
+	"Compliant":    colorGreen,
+	"Passed":       colorGreen,
+	"OK":           colorGreen,
+	"False":        colorRed,
+	"Offline":      colorRed,
+	"NotReady":     colorRed,
+	"Failed":       colorRed,
+	"NonCompliant": colorRed,
+	"Error":        colorRed,
+	"Unknown":      colorYellow,
+	"Pending":      colorYellow,
+	"Progressing":  colorYellow,
+	"Warning":      colorYellow,
+}
+
+// negativeConditions are the columns of the conditions for which True is a bad state
+var negativeConditions = map[string]bool{
+	"DEGRADED": true,
+}
+
+// AddColorFlags adds the --no-color flag to the flagset
+func AddColorFlags(flagSet *pflag.FlagSet) {
+	flagSet.BoolVar(&NoColor, "no-color", NoColor, "Disable the colors of the table output")
+}
+
+// colorEnabled returns true if the table written to w can be colorized,
+// the colors are disabled by --no-color, the NO_COLOR environment variable
+// and when the output is not a terminal, for example when piped to another command
+func colorEnabled(w io.Writer) bool {
+	if NoColor || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	//The legacy Windows console does not interpret the escape sequences
+	if runtime.GOOS == "windows" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// cellColor returns the color of the cell in the column, "" if the cell is not a known state
+func cellColor(header, cell string) string {
+	if negativeConditions[header] {
+		switch cell {
+		case "True":
+			return colorRed
+		case "False":
+			return colorGreen
+		}
+	}
+	return stateColors[cell]
+}
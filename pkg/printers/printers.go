@@ -0,0 +1,178 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package printers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/pflag"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	// OutputCSV prints the rows of the table as csv, for the spreadsheets
+	OutputCSV = "csv"
+)
+
+// Table is a set of rows to display with their column headers
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// AddRow appends a row to the table
+func (t *Table) AddRow(cells ...string) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// PrintOptions holds the output format requested by the user
+type PrintOptions struct {
+	OutputFormat string
+}
+
+// NewPrintOptions creates a PrintOptions with the table output as default
+func NewPrintOptions() *PrintOptions {
+	return &PrintOptions{
+		OutputFormat: OutputTable,
+	}
+}
+
+// AddFlags adds the output flag to the flagset
+func (o *PrintOptions) AddFlags(flagSet *pflag.FlagSet) {
+	flagSet.StringVarP(&o.OutputFormat, "output", "o", o.OutputFormat,
+		fmt.Sprintf("Output format. One of: %s|%s|%s|%s|%s=<template>|%s=<expression>", OutputTable, OutputJSON, OutputYAML, OutputCSV, OutputGoTemplate, OutputJSONPath))
+}
+
+// Validate checks the requested output format is supported
+func (o *PrintOptions) Validate() error {
+	switch o.OutputFormat {
+	case "", OutputTable, OutputJSON, OutputYAML, OutputCSV:
+		return nil
+	}
+	if format, tmpl, ok := templateFormat(o.OutputFormat); ok {
+		_, err := newTemplatePrinter(format, tmpl)
+		return err
+	}
+	return fmt.Errorf("unsupported output format %s, supported formats are %s, %s, %s, %s, %s=<template> and %s=<expression>",
+		o.OutputFormat, OutputTable, OutputJSON, OutputYAML, OutputCSV, OutputGoTemplate, OutputJSONPath)
+}
+
+// IsTable returns true if the output is the table, the commands only print their human readable headers with it
+func (o *PrintOptions) IsTable() bool {
+	return o.OutputFormat == "" || o.OutputFormat == OutputTable
+}
+
+// Print prints the table or the object depending on the output format.
+// obj is used for the json, yaml, go-template and jsonpath output formats, the csv prints the table.
+func (o *PrintOptions) Print(w io.Writer, table *Table, obj interface{}) error {
+	if format, tmpl, ok := templateFormat(o.OutputFormat); ok {
+		printer, err := newTemplatePrinter(format, tmpl)
+		if err != nil {
+			return err
+		}
+		return printer(w, obj)
+	}
+	switch o.OutputFormat {
+	case OutputJSON:
+		b, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case OutputYAML:
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	case OutputCSV:
+		return PrintCSV(w, table)
+	default:
+		return PrintTable(w, table)
+	}
+}
+
+// PrintTable prints the table with aligned columns, the states are colorized on a terminal
+func PrintTable(w io.Writer, table *Table) error {
+	if colorEnabled(w) {
+		return printColorTable(w, table)
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+	if len(table.Headers) != 0 {
+		fmt.Fprintln(tw, strings.Join(table.Headers, "\t"))
+	}
+	for _, r := range table.Rows {
+		fmt.Fprintln(tw, strings.Join(r, "\t"))
+	}
+	return tw.Flush()
+}
+
+// PrintCSV prints the headers and the rows of the table as csv, without colors
+func PrintCSV(w io.Writer, table *Table) error {
+	cw := csv.NewWriter(w)
+	if len(table.Headers) != 0 {
+		if err := cw.Write(table.Headers); err != nil {
+			return err
+		}
+	}
+	if err := cw.WriteAll(table.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// printColorTable prints the table with the known states colorized, the columns are aligned
+// on the text without the escape sequences as tabwriter would count them in the width
+func printColorTable(w io.Writer, table *Table) error {
+	rows := table.Rows
+	if len(table.Headers) != 0 {
+		rows = append([][]string{table.Headers}, rows...)
+	}
+	widths := []int{}
+	for _, r := range rows {
+		for i, cell := range r {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	for j, r := range rows {
+		var b strings.Builder
+		for i, cell := range r {
+			color := ""
+			if j != 0 || len(table.Headers) == 0 {
+				header := ""
+				if i < len(table.Headers) {
+					header = table.Headers[i]
+				}
+				color = cellColor(header, cell)
+			}
+			if color != "" {
+				b.WriteString(color + cell + colorReset)
+			} else {
+				b.WriteString(cell)
+			}
+			if i != len(r)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+3))
+			}
+		}
+		if _, err := fmt.Fprintln(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
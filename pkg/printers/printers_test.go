@@ -0,0 +1,139 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package printers
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestPrintOptions_Print(t *testing.T) {
+	table := &Table{
+		Headers: []string{"NAME", "STATUS"},
+	}
+	table.AddRow("cluster1", "Available")
+	obj := map[string]string{"name": "cluster1"}
+	tests := []struct {
+		name         string
+		outputFormat string
+		want         string
+	}{
+		{
+			name:         "table",
+			outputFormat: OutputTable,
+			want:         "NAME       STATUS\ncluster1   Available\n",
+		},
+		{
+			name:         "json",
+			outputFormat: OutputJSON,
+			want:         "{\n  \"name\": \"cluster1\"\n}\n",
+		},
+		{
+			name:         "yaml",
+			outputFormat: OutputYAML,
+			want:         "name: cluster1\n",
+		},
+		{
+			name:         "csv",
+			outputFormat: OutputCSV,
+			want:         "NAME,STATUS\ncluster1,Available\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &PrintOptions{OutputFormat: tt.outputFormat}
+			w := &bytes.Buffer{}
+			if err := o.Print(w, table, obj); err != nil {
+				t.Fatal(err)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("Print() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		outputFormat string
+		wantErr      bool
+	}{
+		{name: "table", outputFormat: OutputTable},
+		{name: "json", outputFormat: OutputJSON},
+		{name: "yaml", outputFormat: OutputYAML},
+		{name: "csv", outputFormat: OutputCSV},
+		{name: "wide", outputFormat: "wide", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &PrintOptions{OutputFormat: tt.outputFormat}
+			if err := o.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_printColorTable(t *testing.T) {
+	table := &Table{
+		Headers: []string{"CLUSTER", "AVAILABLE", "DEGRADED", "MESSAGE"},
+	}
+	table.AddRow("cluster1", "True", "False", "ok")
+	table.AddRow("cluster2", "Unknown", "True", "")
+	w := &bytes.Buffer{}
+	if err := printColorTable(w, table); err != nil {
+		t.Fatal(err)
+	}
+	want := "CLUSTER    AVAILABLE   DEGRADED   MESSAGE\n" +
+		"cluster1   " + colorGreen + "True" + colorReset + "        " + colorGreen + "False" + colorReset + "      ok\n" +
+		"cluster2   " + colorYellow + "Unknown" + colorReset + "     " + colorRed + "True" + colorReset + "       \n"
+	if got := w.String(); got != want {
+		t.Errorf("printColorTable() = %q, want %q", got, want)
+	}
+}
+
+func TestPrintTable_noColor(t *testing.T) {
+	table := &Table{Headers: []string{"NAME", "STATUS"}}
+	table.AddRow("cluster1", "Offline")
+	NoColor = true
+	defer func() { NoColor = false }()
+	if colorEnabled(os.Stdout) {
+		t.Error("the colors must be disabled by --no-color")
+	}
+	w := &bytes.Buffer{}
+	if err := PrintTable(w, table); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.String(); got != "NAME       STATUS\ncluster1   Offline\n" {
+		t.Errorf("PrintTable() = %q", got)
+	}
+}
+
+func TestQuiet(t *testing.T) {
+	defer func() { Quiet = false }()
+	tests := []struct {
+		name         string
+		quiet        bool
+		wantMessages string
+		wantOut      string
+	}{
+		{name: "Messages", wantMessages: "cluster1 attached\n"},
+		{name: "Quiet", quiet: true, wantOut: "cluster1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Quiet = tt.quiet
+			out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+			Messages(errOut).Write([]byte("cluster1 attached\n"))
+			PrintIdentifier(out, "cluster1")
+			if errOut.String() != tt.wantMessages {
+				t.Errorf("messages = %q, want %q", errOut.String(), tt.wantMessages)
+			}
+			if out.String() != tt.wantOut {
+				t.Errorf("output = %q, want %q", out.String(), tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package printers
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/spf13/pflag"
+)
+
+// Quiet suppresses the human readable messages, set by the global -q/--quiet flag,
+// the commands only print their primary identifier on success
+var Quiet = false
+
+// AddQuietFlags adds the -q/--quiet flag to the flagset
+func AddQuietFlags(flagSet *pflag.FlagSet) {
+	flagSet.BoolVarP(&Quiet, "quiet", "q", Quiet, "Suppress the non-error output, only print the primary identifier of the result, such as the cluster name, on success")
+}
+
+// Messages returns the writer of the human readable messages, w which is the standard error
+// so the standard output only contains data, or a discarding writer in quiet mode
+func Messages(w io.Writer) io.Writer {
+	if Quiet {
+		return ioutil.Discard
+	}
+	return w
+}
+
+// PrintIdentifier prints the primary identifiers of the result of a command in quiet mode, one per line
+func PrintIdentifier(w io.Writer, ids ...string) {
+	if !Quiet {
+		return
+	}
+	for _, id := range ids {
+		fmt.Fprintln(w, id)
+	}
+}
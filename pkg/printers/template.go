@@ -0,0 +1,85 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package printers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	// OutputGoTemplate is the prefix of the go-template=<template> output format
+	OutputGoTemplate = "go-template"
+	// OutputJSONPath is the prefix of the jsonpath=<expression> output format
+	OutputJSONPath = "jsonpath"
+)
+
+// templateFormat splits the go-template=<template> and jsonpath=<expression> output formats,
+// ok is false for the other output formats
+func templateFormat(outputFormat string) (format, tmpl string, ok bool) {
+	parts := strings.SplitN(outputFormat, "=", 2)
+	if parts[0] != OutputGoTemplate && parts[0] != OutputJSONPath {
+		return "", "", false
+	}
+	if len(parts) == 2 {
+		tmpl = parts[1]
+	}
+	return parts[0], tmpl, true
+}
+
+// newTemplatePrinter parses the template of the output format, the printer executes
+// it on the json representation of the object, so the fields are named as in -o json
+func newTemplatePrinter(format, tmpl string) (func(w io.Writer, obj interface{}) error, error) {
+	if tmpl == "" {
+		return nil, fmt.Errorf("the template of the %s output format is missing, use %s=<template>", format, format)
+	}
+	if format == OutputGoTemplate {
+		t, err := template.New("output").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the go-template %s: %v", tmpl, err)
+		}
+		return func(w io.Writer, obj interface{}) error {
+			data, err := jsonData(obj)
+			if err != nil {
+				return err
+			}
+			return t.Execute(w, data)
+		}, nil
+	}
+	//An expression without braces, like .items[*].name, is the field to print
+	if !strings.Contains(tmpl, "{") {
+		if !strings.HasPrefix(tmpl, ".") && !strings.HasPrefix(tmpl, "[") {
+			tmpl = "." + tmpl
+		}
+		tmpl = "{" + tmpl + "}"
+	}
+	j := jsonpath.New("output")
+	if err := j.Parse(tmpl); err != nil {
+		return nil, fmt.Errorf("error parsing the jsonpath %s: %v", tmpl, err)
+	}
+	return func(w io.Writer, obj interface{}) error {
+		data, err := jsonData(obj)
+		if err != nil {
+			return err
+		}
+		return j.Execute(w, data)
+	}, nil
+}
+
+// jsonData returns the object as decoded from its json representation
+func jsonData(obj interface{}) (interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,75 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package printers
+
+import (
+	"bytes"
+	"testing"
+)
+
+type testCluster struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func TestPrintOptions_Print_template(t *testing.T) {
+	obj := []testCluster{
+		{Name: "cluster1", Labels: map[string]string{"env": "prod"}},
+		{Name: "cluster2"},
+	}
+	tests := []struct {
+		name         string
+		outputFormat string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "go-template",
+			outputFormat: `go-template={{range .}}{{.name}} {{.labels.env}}{{"\n"}}{{end}}`,
+			want:         "cluster1 prod\ncluster2 <no value>\n",
+		},
+		{
+			name:         "jsonpath",
+			outputFormat: `jsonpath={range [*]}{.name}{"\n"}{end}`,
+			want:         "cluster1\ncluster2\n",
+		},
+		{
+			name:         "jsonpath without braces",
+			outputFormat: "jsonpath=[0].labels.env",
+			want:         "prod",
+		},
+		{
+			name:         "Failed, missing field",
+			outputFormat: "jsonpath={[0].status}",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &PrintOptions{OutputFormat: tt.outputFormat}
+			if err := o.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			w := &bytes.Buffer{}
+			err := o.Print(w, nil, obj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Print() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := w.String(); !tt.wantErr && got != tt.want {
+				t.Errorf("Print() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintOptions_Validate_template(t *testing.T) {
+	for _, outputFormat := range []string{"go-template", "go-template={{.name", "jsonpath=", "jsonpath={.name"} {
+		o := &PrintOptions{OutputFormat: outputFormat}
+		if err := o.Validate(); err == nil {
+			t.Errorf("error expected for %s", outputFormat)
+		}
+	}
+	if (&PrintOptions{OutputFormat: "jsonpath={.name}"}).IsTable() || !(&PrintOptions{}).IsTable() {
+		t.Error("only the default output is the table")
+	}
+}
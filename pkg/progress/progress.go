@@ -0,0 +1,121 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+const (
+	StatusStarted   = "started"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Event is a progress event of a long running operation
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	Phase     string    `json:"phase"`
+	Resource  string    `json:"resource,omitempty"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message,omitempty"`
+	//Summary is the outcome of the operation, only set on its last event
+	Summary interface{} `json:"summary,omitempty"`
+}
+
+// Format is the --progress-format flag value, it is validated when the flag is parsed
+type Format string
+
+func (f *Format) String() string {
+	if *f == "" {
+		return FormatText
+	}
+	return string(*f)
+}
+
+func (f *Format) Set(s string) error {
+	switch s {
+	case FormatText, FormatJSON:
+		*f = Format(s)
+		return nil
+	}
+	return fmt.Errorf("unsupported progress format %s, supported formats are %s and %s", s, FormatText, FormatJSON)
+}
+
+func (f *Format) Type() string {
+	return "string"
+}
+
+// Reporter writes the progress events, only the json format emits events,
+// one json object per line (NDJSON), the text format relies on the usual command output.
+// A nil Reporter reports nothing.
+type Reporter struct {
+	format Format
+	out    io.Writer
+	now    func() time.Time
+}
+
+// NewReporter creates a Reporter writing the events in out
+func NewReporter(format Format, out io.Writer) *Reporter {
+	return &Reporter{
+		format: format,
+		out:    out,
+		now:    time.Now,
+	}
+}
+
+// Enabled returns true if the events are emitted
+func (r *Reporter) Enabled() bool {
+	return r != nil && r.format == FormatJSON && r.out != nil
+}
+
+// Report emits an event
+func (r *Reporter) Report(phase, resource, status, message string) {
+	r.emit(Event{
+		Phase:    phase,
+		Resource: resource,
+		Status:   status,
+		Message:  message,
+	})
+}
+
+// ReportSummary emits the succeeded event of the phase with the outcome of the operation
+func (r *Reporter) ReportSummary(phase, resource string, summary interface{}) {
+	r.emit(Event{
+		Phase:    phase,
+		Resource: resource,
+		Status:   StatusSucceeded,
+		Summary:  summary,
+	})
+}
+
+func (r *Reporter) emit(e Event) {
+	if !r.Enabled() {
+		return
+	}
+	e.Timestamp = r.now().UTC()
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(r.out, string(b))
+}
+
+// Step reports the start of a phase, runs it and reports its success or failure
+func (r *Reporter) Step(phase, resource string, run func() error) error {
+	r.Report(phase, resource, StatusStarted, "")
+	if err := run(); err != nil {
+		r.Report(phase, resource, StatusFailed, err.Error())
+		return err
+	}
+	r.Report(phase, resource, StatusSucceeded, "")
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package progress
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestReporter_Step(t *testing.T) {
+	ts := time.Date(2021, 4, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		format  Format
+		err     error
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "json success",
+			format: FormatJSON,
+			want: `{"timestamp":"2021-04-01T10:00:00Z","phase":"apply","resource":"ManagedCluster/test","status":"started"}` + "\n" +
+				`{"timestamp":"2021-04-01T10:00:00Z","phase":"apply","resource":"ManagedCluster/test","status":"succeeded"}` + "\n",
+		},
+		{
+			name:   "json failure",
+			format: FormatJSON,
+			err:    fmt.Errorf("boom"),
+			want: `{"timestamp":"2021-04-01T10:00:00Z","phase":"apply","resource":"ManagedCluster/test","status":"started"}` + "\n" +
+				`{"timestamp":"2021-04-01T10:00:00Z","phase":"apply","resource":"ManagedCluster/test","status":"failed","message":"boom"}` + "\n",
+			wantErr: true,
+		},
+		{
+			name:   "text emits nothing",
+			format: FormatText,
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			r := NewReporter(tt.format, out)
+			r.now = func() time.Time { return ts }
+			err := r.Step("apply", "ManagedCluster/test", func() error { return tt.err })
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Step() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if out.String() != tt.want {
+				t.Errorf("Step() output = %s, want %s", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestReporter_ReportSummary(t *testing.T) {
+	out := &bytes.Buffer{}
+	r := NewReporter(FormatJSON, out)
+	r.now = func() time.Time { return time.Date(2021, 4, 1, 10, 0, 0, 0, time.UTC) }
+	r.ReportSummary("summary", "ManagedCluster/test", map[string]string{"cluster": "test"})
+	want := `{"timestamp":"2021-04-01T10:00:00Z","phase":"summary","resource":"ManagedCluster/test","status":"succeeded","summary":{"cluster":"test"}}` + "\n"
+	if out.String() != want {
+		t.Errorf("ReportSummary() output = %s, want %s", out.String(), want)
+	}
+}
+
+func TestReporter_nil(t *testing.T) {
+	var r *Reporter
+	if err := r.Step("apply", "", func() error { return nil }); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFormat_Set(t *testing.T) {
+	var f Format
+	if f.String() != FormatText {
+		t.Errorf("default format = %s, want %s", f.String(), FormatText)
+	}
+	if err := f.Set(FormatJSON); err != nil || f != FormatJSON {
+		t.Errorf("Set(json) = %v, format %s", err, f)
+	}
+	if err := f.Set("xml"); err == nil {
+		t.Error("Set(xml) expected an error")
+	}
+}
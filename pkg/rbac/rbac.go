@@ -0,0 +1,341 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package rbac
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	PersonaViewer          = "viewer"
+	PersonaClusterAttacher = "cluster-attacher"
+	PersonaClusterAdmin    = "cluster-admin"
+
+	// clusterRolePrefix is the prefix of the generated cluster roles and bindings
+	clusterRolePrefix = "cm-cli-"
+)
+
+const (
+	clusterGroup   = "cluster.open-cluster-management.io"
+	workGroup      = "work.open-cluster-management.io"
+	addonGroup     = "addon.open-cluster-management.io"
+	agentGroup     = "agent.open-cluster-management.io"
+	policyGroup    = "policy.open-cluster-management.io"
+	appsGroup      = "apps.open-cluster-management.io"
+	hiveGroup      = "hive.openshift.io"
+	towerGroup     = "tower.ansible.com"
+	internalGroup  = "internal.open-cluster-management.io"
+	routeGroup     = "route.openshift.io"
+	readOnly       = "get,list,watch"
+	createOrUpdate = "get,create,update"
+)
+
+func rule(group, resources, verbs string) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups: []string{group},
+		Resources: strings.Split(resources, ","),
+		Verbs:     strings.Split(verbs, ","),
+	}
+}
+
+// commandRules is the permission matrix: the hub permissions needed by each command.
+// It must be updated when a command reads or writes new resources.
+var commandRules = map[string][]rbacv1.PolicyRule{
+	"get clusters": {
+		rule(clusterGroup, "managedclusters,managedclustersets", readOnly),
+		rule(addonGroup, "managedclusteraddons", readOnly),
+	},
+	"report capacity": {
+		rule(clusterGroup, "managedclusters", "list"),
+	},
+	"get work": {
+		rule(workGroup, "manifestworks", readOnly),
+	},
+	"get nodes": {
+		rule(clusterGroup, "managedclusters", "get"),
+		rule(internalGroup, "managedclusterinfos", "get"),
+	},
+	//The search API filters the results with the permissions of the user on the managed clusters
+	"search": {
+		rule(routeGroup, "routes", "get"),
+	},
+	"get import": {
+		rule("", "secrets", "get"),
+	},
+	"get conditions": {
+		rule(clusterGroup, "managedclusters", "get"),
+		rule(addonGroup, "managedclusteraddons", "list"),
+	},
+	"describe cluster": {
+		rule("", "events", "list"),
+		rule(clusterGroup, "managedclusters", "get"),
+		rule(addonGroup, "managedclusteraddons", "list"),
+	},
+	"verify import": {
+		rule("", "secrets", "get"),
+	},
+	"status": {
+		rule(clusterGroup, "managedclusters", "get"),
+		rule("", "configmaps", "list"),
+	},
+	"policy list/status": {
+		rule(policyGroup, "policies", readOnly),
+	},
+	"application list/status": {
+		rule("app.k8s.io", "applications", readOnly),
+		rule(appsGroup, "subscriptions,placementrules", readOnly),
+	},
+	"clusterpool list": {
+		rule(hiveGroup, "clusterpools,clusterclaims", readOnly),
+	},
+	"troubleshoot cluster": {
+		rule(clusterGroup, "managedclusters", "get"),
+		rule(addonGroup, "managedclusteraddons", "list"),
+		rule("coordination.k8s.io", "leases", "get"),
+		rule("certificates.k8s.io", "certificatesigningrequests", "list"),
+		rule("", "secrets", "get"),
+	},
+	"retry import": {
+		rule("", "secrets", createOrUpdate),
+		rule(clusterGroup, "managedclusters", "get,update"),
+		rule(addonGroup, "managedclusteraddons", "list"),
+	},
+	//rotate bootstrap revokes the bootstrap tokens, like gc it is left to the hub administrators and is not part of a persona
+	"rotate bootstrap": {
+		rule("", "secrets", "get,list,delete"),
+		rule(clusterGroup, "managedclusters", "get"),
+		rule(workGroup, "manifestworks", createOrUpdate),
+	},
+	"attach cluster": {
+		rule("", "namespaces", "get,create"),
+		rule("", "secrets", createOrUpdate),
+		rule("", "pods,events", "list"),
+		rule("", "configmaps", "create,update"),
+		rule(clusterGroup, "managedclusters", createOrUpdate),
+		rule(clusterGroup, "managedclustersets", "get,create"),
+		rule(clusterGroup, "managedclustersets/join", "create"),
+		rule(clusterGroup, "clustercurators", createOrUpdate),
+		rule(agentGroup, "klusterletaddonconfigs", createOrUpdate),
+		rule(towerGroup, "ansiblejobs", "get,create"),
+		rule(workGroup, "manifestworks", createOrUpdate),
+		rule(addonGroup, "managedclusteraddons", "list"),
+	},
+	"detach cluster": {
+		rule(clusterGroup, "managedclusters", "get,update,delete"),
+		rule(workGroup, "manifestworks", "get,list,delete"),
+		rule(appsGroup, "subscriptions,placementrules", "get,list,update"),
+	},
+	"move cluster": {
+		rule(clusterGroup, "managedclusters", "get,create,update,delete"),
+		rule(agentGroup, "klusterletaddonconfigs", createOrUpdate),
+		rule("", "namespaces", "get,create"),
+		rule("", "secrets", createOrUpdate),
+	},
+	"create cluster": {
+		rule("", "namespaces", "get,create"),
+		rule("", "secrets", createOrUpdate),
+		rule(hiveGroup, "clusterdeployments,machinepools,clusterimagesets", createOrUpdate),
+		rule(clusterGroup, "managedclusters,clustercurators", createOrUpdate),
+		rule(clusterGroup, "managedclustersets", "get,create"),
+		rule(clusterGroup, "managedclustersets/join", "create"),
+		rule(agentGroup, "klusterletaddonconfigs", createOrUpdate),
+	},
+	"delete cluster": {
+		rule(clusterGroup, "managedclusters", "get,delete"),
+		rule(hiveGroup, "clusterdeployments", "get,delete"),
+	},
+	"protect/unprotect cluster": {
+		rule(clusterGroup, "managedclusters", "get,update"),
+	},
+	"clusterpool create/claim/release": {
+		rule("", "namespaces", "get,create"),
+		rule("", "secrets", createOrUpdate),
+		rule(hiveGroup, "clusterpools,clusterimagesets", createOrUpdate),
+		rule(hiveGroup, "clusterclaims", "get,create,update,delete"),
+	},
+	"create work": {
+		rule(workGroup, "manifestworks", "get,create,update,delete"),
+	},
+	"policy create": {
+		rule(policyGroup, "policies,placementbindings", createOrUpdate),
+		rule(appsGroup, "placementrules", createOrUpdate),
+	},
+	"application create": {
+		rule("", "namespaces", "get,create"),
+		rule("app.k8s.io", "applications", createOrUpdate),
+		rule(appsGroup, "channels,subscriptions,placementrules", createOrUpdate),
+	},
+	"upgrade klusterlet": {
+		rule(clusterGroup, "managedclusters", "get"),
+		rule(workGroup, "manifestworks", "get,list,update"),
+	},
+	"export/import inventory": {
+		rule("", "namespaces", "get,create"),
+		rule(clusterGroup, "managedclusters,managedclustersets", "get,list,create,update"),
+		rule(agentGroup, "klusterletaddonconfigs", createOrUpdate),
+	},
+	//migrate clusters runs on the current and the target hubs
+	"migrate clusters": {
+		rule("", "namespaces", "get,create"),
+		rule("", "secrets", createOrUpdate),
+		rule(clusterGroup, "managedclusters", "get,list,create,update,delete"),
+		rule(clusterGroup, "managedclustersets", "get,list,create,update"),
+		rule(agentGroup, "klusterletaddonconfigs", createOrUpdate),
+		rule(workGroup, "manifestworks", createOrUpdate),
+	},
+	//gc deletes namespaces and secrets, it is left to the hub administrators and is not part of a persona
+	"gc": {
+		rule("", "namespaces", "list,delete"),
+		rule("", "secrets", "get,delete"),
+		rule(clusterGroup, "managedclusters", "list,delete"),
+		rule(workGroup, "manifestworks", "list,delete"),
+		rule(hiveGroup, "clusterdeployments", "get,delete"),
+	},
+}
+
+// personaCommands are the commands each persona can run
+var personaCommands = map[string][]string{
+	PersonaViewer: {
+		"get clusters",
+		"get work",
+		"get nodes",
+		"get conditions",
+		"search",
+		"report capacity",
+		"status",
+		"describe cluster",
+		"policy list/status",
+		"application list/status",
+		"clusterpool list",
+	},
+	//The import secret read by troubleshoot cluster grants the access to the secrets
+	PersonaClusterAttacher: {
+		"get import",
+		"verify import",
+		"troubleshoot cluster",
+		"attach cluster",
+		"retry import",
+		"detach cluster",
+		"move cluster",
+	},
+	PersonaClusterAdmin: {
+		"create cluster",
+		"delete cluster",
+		"protect/unprotect cluster",
+		"clusterpool create/claim/release",
+		"create work",
+		"policy create",
+		"application create",
+		"upgrade klusterlet",
+		"export/import inventory",
+		"migrate clusters",
+	},
+}
+
+// personaParents are the personas whose commands are included in a persona
+var personaParents = map[string]string{
+	PersonaClusterAttacher: PersonaViewer,
+	PersonaClusterAdmin:    PersonaClusterAttacher,
+}
+
+// Personas returns the names of the personas
+func Personas() []string {
+	return []string{PersonaViewer, PersonaClusterAttacher, PersonaClusterAdmin}
+}
+
+// Commands returns the commands a persona can run, including the commands of its parent personas
+func Commands(persona string) ([]string, error) {
+	if _, ok := personaCommands[persona]; !ok {
+		return nil, fmt.Errorf("unknown persona %s, supported personas are %s", persona, strings.Join(Personas(), ", "))
+	}
+	commands := make([]string, 0)
+	for p := persona; p != ""; p = personaParents[p] {
+		commands = append(commands, personaCommands[p]...)
+	}
+	sort.Strings(commands)
+	return commands, nil
+}
+
+// Rules returns the minimal rules needed by the commands of a persona,
+// one rule per group and resource with the union of the verbs
+func Rules(persona string) ([]rbacv1.PolicyRule, error) {
+	commands, err := Commands(persona)
+	if err != nil {
+		return nil, err
+	}
+	type groupResource struct{ group, resource string }
+	verbs := map[groupResource]map[string]bool{}
+	for _, c := range commands {
+		for _, r := range commandRules[c] {
+			for _, g := range r.APIGroups {
+				for _, res := range r.Resources {
+					gr := groupResource{group: g, resource: res}
+					if verbs[gr] == nil {
+						verbs[gr] = map[string]bool{}
+					}
+					for _, v := range r.Verbs {
+						verbs[gr][v] = true
+					}
+				}
+			}
+		}
+	}
+
+	grs := make([]groupResource, 0, len(verbs))
+	for gr := range verbs {
+		grs = append(grs, gr)
+	}
+	sort.Slice(grs, func(i, j int) bool {
+		if grs[i].group != grs[j].group {
+			return grs[i].group < grs[j].group
+		}
+		return grs[i].resource < grs[j].resource
+	})
+	rules := make([]rbacv1.PolicyRule, len(grs))
+	for i, gr := range grs {
+		vs := make([]string, 0, len(verbs[gr]))
+		for v := range verbs[gr] {
+			vs = append(vs, v)
+		}
+		sort.Strings(vs)
+		rules[i] = rbacv1.PolicyRule{APIGroups: []string{gr.group}, Resources: []string{gr.resource}, Verbs: vs}
+	}
+	return rules, nil
+}
+
+// ClusterRoleName returns the name of the cluster role of a persona
+func ClusterRoleName(persona string) string {
+	return clusterRolePrefix + persona
+}
+
+// ClusterRole returns the cluster role of a persona
+func ClusterRole(persona string) (*rbacv1.ClusterRole, error) {
+	rules, err := Rules(persona)
+	if err != nil {
+		return nil, err
+	}
+	return &rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+		ObjectMeta: metav1.ObjectMeta{Name: ClusterRoleName(persona)},
+		Rules:      rules,
+	}, nil
+}
+
+// ClusterRoleBinding returns the binding of the cluster role of a persona to the subjects
+func ClusterRoleBinding(persona string, subjects []rbacv1.Subject) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+		ObjectMeta: metav1.ObjectMeta{Name: ClusterRoleName(persona)},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     ClusterRoleName(persona),
+		},
+		Subjects: subjects,
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package rbac
+
+import (
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// allows returns true if one of the rules allows the verb on the resource
+func allows(rules []rbacv1.PolicyRule, group, resource, verb string) bool {
+	for _, r := range rules {
+		if r.APIGroups[0] != group || r.Resources[0] != resource {
+			continue
+		}
+		for _, v := range r.Verbs {
+			if v == verb {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		persona   string
+		allowed   [][3]string
+		forbidden [][3]string
+		wantErr   bool
+	}{
+		{
+			persona: PersonaViewer,
+			allowed: [][3]string{
+				{clusterGroup, "managedclusters", "list"},
+				{workGroup, "manifestworks", "get"},
+			},
+			forbidden: [][3]string{
+				{clusterGroup, "managedclusters", "create"},
+				{"", "secrets", "get"},
+			},
+		},
+		{
+			persona: PersonaClusterAttacher,
+			allowed: [][3]string{
+				{clusterGroup, "managedclusters", "list"},
+				{clusterGroup, "managedclusters", "create"},
+				{agentGroup, "klusterletaddonconfigs", "create"},
+			},
+			forbidden: [][3]string{
+				{hiveGroup, "clusterdeployments", "create"},
+			},
+		},
+		{
+			persona: PersonaClusterAdmin,
+			allowed: [][3]string{
+				{clusterGroup, "managedclusters", "delete"},
+				{hiveGroup, "clusterdeployments", "create"},
+				{workGroup, "manifestworks", "create"},
+			},
+			forbidden: [][3]string{
+				{"", "secrets", "delete"},
+			},
+		},
+		{
+			persona: "unknown",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.persona, func(t *testing.T) {
+			rules, err := Rules(tt.persona)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Rules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, a := range tt.allowed {
+				if !allows(rules, a[0], a[1], a[2]) {
+					t.Errorf("%s must be allowed to %s %s.%s", tt.persona, a[2], a[1], a[0])
+				}
+			}
+			for _, f := range tt.forbidden {
+				if allows(rules, f[0], f[1], f[2]) {
+					t.Errorf("%s must not be allowed to %s %s.%s", tt.persona, f[2], f[1], f[0])
+				}
+			}
+		})
+	}
+}
+
+func TestCommandRulesCoverPersonas(t *testing.T) {
+	for persona, commands := range personaCommands {
+		for _, c := range commands {
+			if _, ok := commandRules[c]; !ok {
+				t.Errorf("the command %s of the persona %s is not in the permission matrix", c, persona)
+			}
+		}
+	}
+}
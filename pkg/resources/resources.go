@@ -4,6 +4,7 @@ package resources
 
 import (
 	"embed"
+	"path"
 	"path/filepath"
 
 	"github.com/ghodss/yaml"
@@ -11,14 +12,16 @@ import (
 
 type Resources struct{}
 
-//Needed to scenarios/*/*/*/* to include the _helpers.tpl located in:
-//scenarios/create/hub/common/_helpers.tpl and
-//scenarios/destroy/hub/common/_helpers.tpl
+// Needed to scenarios/*/*/*/* to include the _helpers.tpl located in:
+// scenarios/create/hub/common/_helpers.tpl and
+// scenarios/destroy/hub/common/_helpers.tpl
+//
 //go:embed scenarios scenarios/*/*/*/_helpers.tpl
 var files embed.FS
 
+// The embedded files always use slashes while the applier builds the names with the OS separator
 func (*Resources) Asset(name string) ([]byte, error) {
-	return files.ReadFile(name)
+	return files.ReadFile(filepath.ToSlash(name))
 }
 
 func (b *Resources) AssetNames() ([]string, error) {
@@ -50,7 +53,7 @@ func (b *Resources) assetWalk(f string) ([]string, error) {
 			if err != nil {
 				return assets, nil
 			}
-			assetsDir, err := b.assetWalk(filepath.Join(f, di.Name()))
+			assetsDir, err := b.assetWalk(path.Join(f, di.Name()))
 			if err != nil {
 				return assets, err
 			}
@@ -58,7 +61,7 @@ func (b *Resources) assetWalk(f string) ([]string, error) {
 		}
 		return assets, nil
 	}
-	return append(assets, f), nil
+	return append(assets, filepath.FromSlash(f)), nil
 }
 
 func (*Resources) ToJSON(b []byte) ([]byte, error) {
@@ -0,0 +1,13 @@
+{{- define "ocpImage" }}
+  {{ $release := splitList ":" .clusterPool.ocpImage }}
+  {{ if index $release 1 }}
+    {{ $release = index $release 1 | replace "_" "-" | lower }}
+    {{ $release = (print $release "-" .clusterPool.name ) }}
+{{ $release }}
+  {{ end }}
+{{- end }}
+{{- define "baseDomain" }}
+{{- if (eq .clusterPool.cloud "aws") }}{{ .clusterPool.aws.baseDnsDomain }}{{ end }}
+{{- if (eq .clusterPool.cloud "azure") }}{{ .clusterPool.azure.baseDnsDomain }}{{ end }}
+{{- if (eq .clusterPool.cloud "gcp") }}{{ .clusterPool.gcp.baseDnsDomain }}{{ end }}
+{{- end }}
@@ -0,0 +1,76 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// sendTimeout bounds the time spent to send an event so the telemetry never slows down the cli
+var sendTimeout = 2 * time.Second
+
+// Event is the anonymous usage metric sent for each command, it never contains arguments or flag values
+type Event struct {
+	Command    string `json:"command"`
+	DurationMs int64  `json:"durationMs"`
+	Success    bool   `json:"success"`
+	OS         string `json:"os"`
+	Arch       string `json:"arch"`
+}
+
+// NewEvent creates the event of an executed command
+func NewEvent(cmd *cobra.Command, duration time.Duration, err error) Event {
+	command := ""
+	if cmd != nil {
+		//Remove the root command name
+		command = strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+	}
+	return Event{
+		Command:    command,
+		DurationMs: duration.Milliseconds(),
+		Success:    err == nil,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+	}
+}
+
+// Send posts the event to the endpoint
+func Send(endpoint string, e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: sendTimeout}
+	resp, err := client.Post(endpoint, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry endpoint %s returned %s", endpoint, resp.Status)
+	}
+	return nil
+}
+
+// Record sends the event of an executed command if the user opted-in.
+// Errors are ignored, the telemetry must never fail a command.
+func Record(cmd *cobra.Command, duration time.Duration, err error) {
+	path, perr := config.DefaultPath()
+	if perr != nil {
+		return
+	}
+	c, perr := config.Load(path)
+	if perr != nil || !c.Telemetry.Enabled || c.Telemetry.Endpoint == "" {
+		return
+	}
+	_ = Send(c.Telemetry.Endpoint, NewEvent(cmd, duration, err))
+}
@@ -0,0 +1,55 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestNewEvent(t *testing.T) {
+	root := &cobra.Command{Use: "cm"}
+	verb := &cobra.Command{Use: "attach"}
+	noun := &cobra.Command{Use: "cluster"}
+	root.AddCommand(verb)
+	verb.AddCommand(noun)
+
+	e := NewEvent(noun, 1500*time.Millisecond, fmt.Errorf("failed"))
+	if e.Command != "attach cluster" {
+		t.Errorf("expected command attach cluster got %s", e.Command)
+	}
+	if e.DurationMs != 1500 {
+		t.Errorf("expected duration 1500 got %d", e.DurationMs)
+	}
+	if e.Success {
+		t.Error("expected a failed event")
+	}
+}
+
+func TestSend(t *testing.T) {
+	var got Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	e := Event{Command: "attach cluster", DurationMs: 10, Success: true}
+	if err := Send(server.URL, e); err != nil {
+		t.Fatal(err)
+	}
+	if got.Command != e.Command || got.DurationMs != e.DurationMs || !got.Success {
+		t.Errorf("received %v, want %v", got, e)
+	}
+
+	if err := Send(server.URL+"/missing\x7f", e); err == nil {
+		t.Error("expected an error for an invalid endpoint")
+	}
+}
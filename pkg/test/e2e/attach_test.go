@@ -0,0 +1,81 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//go:build e2e
+// +build e2e
+
+package e2e
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/helpers"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestAttachDetach(t *testing.T) {
+	const clusterName = "e2e-cluster"
+	dir := t.TempDir()
+
+	//No klusterlet runs on the test hub, the attach is over once the hub resources are created
+	out, err := runCommand("attach", "cluster", clusterName,
+		"--values-root", dir,
+		"--cluster-server", "https://127.0.0.1:6443",
+		"--cluster-token", "e2e-token",
+		"--distribution", helpers.DistributionKubernetes,
+		"--skip-preflight")
+	if err != nil {
+		t.Fatalf("attach failed: %s\n%s", err.Error(), out)
+	}
+
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(helpers.ManagedClusterGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: clusterName}, mc); err != nil {
+		t.Fatal(err)
+	}
+	if accepted, _, _ := unstructured.NestedBool(mc.Object, "spec", "hubAcceptsClient"); !accepted {
+		t.Error("the ManagedCluster must be accepted by the hub")
+	}
+	secret := &corev1.Secret{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: clusterName, Name: "auto-import-secret"}, secret); err != nil {
+		t.Fatal(err)
+	}
+	if token := string(secret.Data["token"]); token != "e2e-token" {
+		t.Errorf("auto-import-secret token = %s, want e2e-token", token)
+	}
+	addonConfig := &unstructured.Unstructured{}
+	addonConfig.SetGroupVersionKind(helpers.KlusterletAddonConfigGVK)
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: clusterName, Name: clusterName}, addonConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	valuesPath := filepath.Join(dir, "detach.yaml")
+	if err := ioutil.WriteFile(valuesPath, []byte("managedClusterName: "+clusterName+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runCommand("detach", "cluster", "--values", valuesPath, "--yes")
+	if err != nil {
+		t.Fatalf("detach failed: %s\n%s", err.Error(), out)
+	}
+	err = client.Get(context.TODO(), types.NamespacedName{Name: clusterName}, mc)
+	if !errors.IsNotFound(err) {
+		t.Errorf("the ManagedCluster must be deleted by the detach, got %v", err)
+	}
+}
+
+func TestSelftest(t *testing.T) {
+	out, err := runCommand("selftest")
+	if err != nil {
+		t.Fatalf("selftest failed: %s\n%s", err.Error(), out)
+	}
+	if strings.Contains(out, "Failed") {
+		t.Errorf("all the checks must pass:\n%s", out)
+	}
+}
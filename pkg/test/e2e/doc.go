@@ -0,0 +1,9 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package e2e holds the end-to-end tests of the commands against a real API server,
+// they are built with the e2e tag and run by "make e2e-test".
+//
+// The tests start an envtest control plane with the CRDs of testdata/crds, the etcd and kube-apiserver
+// binaries are looked up in KUBEBUILDER_ASSETS. With USE_EXISTING_CLUSTER=true they run against the
+// cluster of the KUBECONFIG instead, for example a kind cluster, the CRDs are installed if missing.
+package e2e
@@ -0,0 +1,105 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//go:build e2e
+// +build e2e
+
+package e2e
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-cluster-management/cm-cli/pkg/cmd/verbs"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+var (
+	//kubeconfig is the kubeconfig of the test hub passed to the commands
+	kubeconfig string
+	//client checks the resources created by the commands on the test hub
+	client crclient.Client
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("testdata", "crds")},
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to start the test hub, check KUBEBUILDER_ASSETS: %s\n", err.Error())
+		return 1
+	}
+	defer func() {
+		if err := testEnv.Stop(); err != nil {
+			fmt.Fprintf(os.Stderr, "unable to stop the test hub: %s\n", err.Error())
+		}
+	}()
+
+	dir, err := ioutil.TempDir("", "cm-e2e")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+	defer os.RemoveAll(dir)
+
+	//The existing cluster is accessed with the credentials of its kubeconfig, which can not be rebuilt from the rest config
+	kubeconfig = os.Getenv("KUBECONFIG")
+	if !strings.EqualFold(os.Getenv("USE_EXISTING_CLUSTER"), "true") || kubeconfig == "" {
+		kubeconfig = filepath.Join(dir, "kubeconfig")
+		if err := writeKubeconfig(kubeconfig, cfg); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return 1
+		}
+	}
+
+	client, err = crclient.New(cfg, crclient.Options{})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+	return m.Run()
+}
+
+// writeKubeconfig writes the kubeconfig of the envtest API server
+func writeKubeconfig(path string, cfg *rest.Config) error {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["e2e"] = &clientcmdapi.Cluster{
+		Server:                   cfg.Host,
+		CertificateAuthorityData: cfg.CAData,
+		InsecureSkipTLSVerify:    cfg.Insecure,
+	}
+	config.AuthInfos["e2e"] = &clientcmdapi.AuthInfo{
+		Token:                 cfg.BearerToken,
+		ClientCertificateData: cfg.CertData,
+		ClientKeyData:         cfg.KeyData,
+		Username:              cfg.Username,
+		Password:              cfg.Password,
+	}
+	config.Contexts["e2e"] = &clientcmdapi.Context{Cluster: "e2e", AuthInfo: "e2e"}
+	config.CurrentContext = "e2e"
+	return clientcmd.WriteToFile(*config, path)
+}
+
+// runCommand runs the command of the verb against the test hub, it returns the standard and the error outputs
+func runCommand(verb string, args ...string) (string, error) {
+	streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	cmd := verbs.NewVerb(verb, streams)
+	cmd.SetArgs(append(args, "--kubeconfig", kubeconfig))
+	err := cmd.Execute()
+	return out.String() + errOut.String(), err
+}
@@ -0,0 +1,435 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package tracing
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-cluster-management/cm-cli/pkg/printers"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/rest"
+)
+
+var (
+	// Enabled records the hub API calls of the command, set by the global --trace flag
+	Enabled = false
+	// Endpoint is the OTLP/HTTP endpoint receiving the spans, they are not sent if empty
+	Endpoint = ""
+)
+
+// sendTimeout bounds the time spent to send the spans, the tracing never fails a command
+var sendTimeout = 5 * time.Second
+
+const (
+	// slowest is the number of slowest calls listed in the summary
+	slowest = 5
+	// maxCalls bounds the calls recorded by a trace, the later ones are only counted
+	maxCalls = 10000
+)
+
+// AddFlags adds the tracing flags to the flagset, the endpoint defaults to the OpenTelemetry environment variables
+func AddFlags(flagSet *pflag.FlagSet) {
+	flagSet.BoolVar(&Enabled, "trace", Enabled, "Record every hub API call with its duration and print a summary of the calls once the command completes")
+	flagSet.StringVar(&Endpoint, "trace-endpoint", defaultEndpoint(), "OTLP/HTTP endpoint receiving the spans of --trace, such as http://localhost:4318/v1/traces, defaults to OTEL_EXPORTER_OTLP_TRACES_ENDPOINT or OTEL_EXPORTER_OTLP_ENDPOINT")
+}
+
+func defaultEndpoint() string {
+	if e := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); e != "" {
+		return e
+	}
+	if e := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); e != "" {
+		return strings.TrimSuffix(e, "/") + "/v1/traces"
+	}
+	return ""
+}
+
+// Span is a timed operation of the trace, the command or a hub API call
+type Span struct {
+	ID       string
+	ParentID string
+	Name     string
+	Start    time.Time
+	End      time.Time
+	// Verb and Resource are the kubernetes verb and resource of a hub API call
+	Verb       string
+	Resource   string
+	Method     string
+	URL        string
+	StatusCode int
+	Err        string
+}
+
+// Duration returns the duration of the span
+func (s *Span) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Trace records the spans of a command
+type Trace struct {
+	ID   string
+	Root Span
+
+	lock  sync.Mutex
+	calls []Span
+	//dropped counts the calls beyond maxCalls
+	dropped int
+}
+
+// Current is the trace of the executed command
+var Current = NewTrace("cm")
+
+// NewTrace starts the trace of a command
+func NewTrace(command string) *Trace {
+	return &Trace{
+		ID:   newID(16),
+		Root: Span{ID: newID(8), Name: command, Start: time.Now()},
+	}
+}
+
+// Start starts the trace of the executed command, a long running command such as serve
+// starts a trace per operation
+func Start(command string) {
+	if command == "" {
+		command = "cm"
+	}
+	Current = NewTrace(command)
+}
+
+// Finish ends the trace of the executed command, prints its summary on w and sends its spans to the endpoint.
+// Nothing is done if the tracing is not enabled.
+func Finish(w io.Writer, err error) {
+	if !Enabled {
+		return
+	}
+	Current.End(err)
+	if perr := Current.PrintSummary(w); perr != nil {
+		fmt.Fprintf(w, "Warning: unable to print the trace summary: %s\n", perr.Error())
+	}
+	if Endpoint == "" {
+		return
+	}
+	if serr := Current.Send(Endpoint); serr != nil {
+		fmt.Fprintf(w, "Warning: unable to send the trace to %s: %s\n", Endpoint, serr.Error())
+	}
+}
+
+// End ends the command span of the trace
+func (t *Trace) End(err error) {
+	t.Root.End = time.Now()
+	if err != nil {
+		t.Root.Err = err.Error()
+	}
+}
+
+// Calls returns the hub API calls recorded so far
+func (t *Trace) Calls() []Span {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]Span{}, t.calls...)
+}
+
+// Dropped returns the number of calls which were not recorded as the trace holds maxCalls calls
+func (t *Trace) Dropped() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.dropped
+}
+
+func (t *Trace) record(s Span) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if len(t.calls) >= maxCalls {
+		t.dropped++
+		return
+	}
+	t.calls = append(t.calls, s)
+}
+
+// WrapConfig records in the current trace the calls sent with the rest config, if the tracing is enabled
+func WrapConfig(restConfig *rest.Config) {
+	if !Enabled {
+		return
+	}
+	Current.WrapConfig(restConfig)
+}
+
+// WrapConfig records in the trace the calls sent with the rest config
+func (t *Trace) WrapConfig(restConfig *rest.Config) {
+	restConfig.Wrap(func(next http.RoundTripper) http.RoundTripper {
+		return &roundTripper{next: next, trace: t}
+	})
+}
+
+type roundTripper struct {
+	next  http.RoundTripper
+	trace *Trace
+}
+
+func (r *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	verb, resource := requestVerb(req)
+	s := Span{
+		ID:       newID(8),
+		ParentID: r.trace.Root.ID,
+		Name:     verb + " " + resource,
+		Start:    time.Now(),
+		Verb:     verb,
+		Resource: resource,
+		Method:   req.Method,
+		URL:      req.URL.Path,
+	}
+	resp, err := r.next.RoundTrip(req)
+	s.End = time.Now()
+	if err != nil {
+		s.Err = err.Error()
+	} else {
+		s.StatusCode = resp.StatusCode
+	}
+	r.trace.record(s)
+	return resp, err
+}
+
+// requestVerb returns the kubernetes verb and resource of a hub API call, the resource is
+// completed by its subresource and its group, the path is returned if it is not a resource path
+func requestVerb(req *http.Request) (verb, resource string) {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	group := ""
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		group = parts[1]
+		parts = parts[3:]
+	default:
+		return strings.ToLower(req.Method), req.URL.Path
+	}
+	if len(parts) > 2 && parts[0] == "namespaces" {
+		parts = parts[2:]
+	}
+	if len(parts) == 0 {
+		return strings.ToLower(req.Method), req.URL.Path
+	}
+	resource = parts[0]
+	named := len(parts) > 1
+	if len(parts) > 2 {
+		resource += "/" + parts[2]
+	}
+	if group != "" {
+		resource += "." + group
+	}
+	switch req.Method {
+	case http.MethodGet:
+		switch {
+		case req.URL.Query().Get("watch") == "true":
+			verb = "watch"
+		case named:
+			verb = "get"
+		default:
+			verb = "list"
+		}
+	case http.MethodPost:
+		verb = "create"
+	case http.MethodPut:
+		verb = "update"
+	case http.MethodPatch:
+		verb = "patch"
+	case http.MethodDelete:
+		verb = "delete"
+		if !named {
+			verb = "deletecollection"
+		}
+	default:
+		verb = strings.ToLower(req.Method)
+	}
+	return verb, resource
+}
+
+// PrintSummary prints the duration of the command, the number and duration of the calls
+// per verb and resource, the most expensive first, and the slowest calls
+func (t *Trace) PrintSummary(w io.Writer) error {
+	calls := t.Calls()
+	var total time.Duration
+	type group struct {
+		request string
+		calls   int
+		total   time.Duration
+		max     time.Duration
+		errors  int
+	}
+	groups := make(map[string]*group)
+	for i := range calls {
+		c := &calls[i]
+		total += c.Duration()
+		g, ok := groups[c.Name]
+		if !ok {
+			g = &group{request: c.Name}
+			groups[c.Name] = g
+		}
+		g.calls++
+		g.total += c.Duration()
+		if c.Duration() > g.max {
+			g.max = c.Duration()
+		}
+		if c.Err != "" || c.StatusCode >= 400 {
+			g.errors++
+		}
+	}
+	fmt.Fprintf(w, "Trace %s: %s took %s, %d hub API calls took %s\n",
+		t.ID, t.Root.Name, round(t.Root.Duration()), len(calls), round(total))
+	if dropped := t.Dropped(); dropped != 0 {
+		fmt.Fprintf(w, "%d calls after the first %d were not recorded\n", dropped, maxCalls)
+	}
+	if len(calls) == 0 {
+		return nil
+	}
+	sorted := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].total != sorted[j].total {
+			return sorted[i].total > sorted[j].total
+		}
+		return sorted[i].request < sorted[j].request
+	})
+	table := &printers.Table{Headers: []string{"REQUEST", "CALLS", "ERRORS", "TOTAL", "AVERAGE", "MAX"}}
+	for _, g := range sorted {
+		table.AddRow(g.request,
+			strconv.Itoa(g.calls),
+			strconv.Itoa(g.errors),
+			round(g.total).String(),
+			round(g.total/time.Duration(g.calls)).String(),
+			round(g.max).String())
+	}
+	if err := printers.PrintTable(w, table); err != nil {
+		return err
+	}
+
+	sort.SliceStable(calls, func(i, j int) bool {
+		return calls[i].Duration() > calls[j].Duration()
+	})
+	if len(calls) > slowest {
+		calls = calls[:slowest]
+	}
+	fmt.Fprintln(w, "Slowest calls:")
+	table = &printers.Table{Headers: []string{"DURATION", "METHOD", "PATH", "RESULT"}}
+	for _, c := range calls {
+		result := c.Err
+		if result == "" {
+			result = strconv.Itoa(c.StatusCode)
+		}
+		table.AddRow(round(c.Duration()).String(), c.Method, c.URL, result)
+	}
+	return printers.PrintTable(w, table)
+}
+
+// Send posts the spans of the trace to an OTLP/HTTP endpoint with the json encoding.
+// The request is encoded here rather than by the otlptracehttp exporter of OpenTelemetry: its releases require
+// go-logr v1 and newer grpc and protobuf modules than the kubernetes and controller-runtime versions of the cli.
+func (t *Trace) Send(endpoint string) error {
+	b, err := json.Marshal(t.otlp())
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: sendTimeout}
+	resp, err := client.Post(endpoint, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("trace endpoint %s returned %s", endpoint, resp.Status)
+	}
+	return nil
+}
+
+// otlp returns the trace as an OTLP ExportTraceServiceRequest
+func (t *Trace) otlp() map[string]interface{} {
+	calls := t.Calls()
+	spans := make([]interface{}, 0, len(calls)+1)
+	spans = append(spans, t.otlpSpan(&t.Root, 1, []interface{}{attribute("cm.command", t.Root.Name)}))
+	for i := range calls {
+		c := &calls[i]
+		attributes := []interface{}{
+			attribute("http.method", c.Method),
+			attribute("http.target", c.URL),
+			attribute("k8s.verb", c.Verb),
+			attribute("k8s.resource", c.Resource),
+		}
+		if c.StatusCode != 0 {
+			attributes = append(attributes, map[string]interface{}{
+				"key":   "http.status_code",
+				"value": map[string]interface{}{"intValue": strconv.Itoa(c.StatusCode)},
+			})
+		}
+		//Client span
+		spans = append(spans, t.otlpSpan(c, 3, attributes))
+	}
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": []interface{}{attribute("service.name", "cm")},
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": "github.com/open-cluster-management/cm-cli"},
+						"spans": spans,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t *Trace) otlpSpan(s *Span, kind int, attributes []interface{}) map[string]interface{} {
+	span := map[string]interface{}{
+		"traceId":           t.ID,
+		"spanId":            s.ID,
+		"name":              s.Name,
+		"kind":              kind,
+		"startTimeUnixNano": strconv.FormatInt(s.Start.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(s.End.UnixNano(), 10),
+		"attributes":        attributes,
+	}
+	if s.ParentID != "" {
+		span["parentSpanId"] = s.ParentID
+	}
+	//The status code 2 is an error
+	if s.Err != "" {
+		span["status"] = map[string]interface{}{"code": 2, "message": s.Err}
+	} else if s.StatusCode >= 400 {
+		span["status"] = map[string]interface{}{"code": 2, "message": http.StatusText(s.StatusCode)}
+	}
+	return span
+}
+
+func attribute(key, value string) map[string]interface{} {
+	return map[string]interface{}{"key": key, "value": map[string]interface{}{"stringValue": value}}
+}
+
+// round rounds the duration for the summary
+func round(d time.Duration) time.Duration {
+	if d > time.Second {
+		return d.Round(10 * time.Millisecond)
+	}
+	return d.Round(100 * time.Microsecond)
+}
+
+// newID returns a random hex id of n bytes
+func newID(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
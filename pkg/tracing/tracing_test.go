@@ -0,0 +1,141 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestRequestVerb(t *testing.T) {
+	tests := []struct {
+		method       string
+		path         string
+		wantVerb     string
+		wantResource string
+	}{
+		{method: http.MethodGet, path: "/api/v1/namespaces", wantVerb: "list", wantResource: "namespaces"},
+		{method: http.MethodGet, path: "/api/v1/namespaces/cluster1", wantVerb: "get", wantResource: "namespaces"},
+		{method: http.MethodGet, path: "/api/v1/namespaces/cluster1/secrets", wantVerb: "list", wantResource: "secrets"},
+		{method: http.MethodPost, path: "/api/v1/namespaces/cluster1/secrets", wantVerb: "create", wantResource: "secrets"},
+		{method: http.MethodGet, path: "/apis/cluster.open-cluster-management.io/v1/managedclusters?watch=true", wantVerb: "watch", wantResource: "managedclusters.cluster.open-cluster-management.io"},
+		{method: http.MethodPatch, path: "/apis/cluster.open-cluster-management.io/v1/managedclusters/cluster1/status", wantVerb: "patch", wantResource: "managedclusters/status.cluster.open-cluster-management.io"},
+		{method: http.MethodDelete, path: "/apis/work.open-cluster-management.io/v1/namespaces/cluster1/manifestworks", wantVerb: "deletecollection", wantResource: "manifestworks.work.open-cluster-management.io"},
+		{method: http.MethodGet, path: "/version", wantVerb: "get", wantResource: "/version"},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verb, resource := requestVerb(&http.Request{Method: tt.method, URL: u})
+		if verb != tt.wantVerb || resource != tt.wantResource {
+			t.Errorf("requestVerb(%s %s) = %s %s, want %s %s", tt.method, tt.path, verb, resource, tt.wantVerb, tt.wantResource)
+		}
+	}
+}
+
+func TestTrace(t *testing.T) {
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/namespaces/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer hub.Close()
+	var received map[string]interface{}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer collector.Close()
+
+	trace := NewTrace("get clusters")
+	config := &rest.Config{Host: hub.URL}
+	trace.WrapConfig(config)
+	transport, err := rest.TransportFor(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: transport}
+	for _, path := range []string{"/api/v1/namespaces", "/api/v1/namespaces", "/api/v1/namespaces/missing"} {
+		resp, err := client.Get(hub.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	trace.End(fmt.Errorf("not found"))
+
+	if len(trace.Calls()) != 3 {
+		t.Fatalf("3 calls expected, got %d", len(trace.Calls()))
+	}
+	out := &bytes.Buffer{}
+	if err := trace.PrintSummary(out); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []string{"Trace " + trace.ID, "get clusters took", "3 hub API calls", "list namespaces   2       0", "get namespaces    1       1", "Slowest calls:", "/api/v1/namespaces/missing   404"} {
+		if !strings.Contains(out.String(), c) {
+			t.Errorf("summary must contain %q, got:\n%s", c, out.String())
+		}
+	}
+
+	if err := trace.Send(collector.URL); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []string{`"traceId":"` + trace.ID, `"parentSpanId":"` + trace.Root.ID, `"name":"list namespaces"`, `"stringValue":"cm"`, `"code":2`} {
+		if !strings.Contains(string(b), c) {
+			t.Errorf("spans must contain %s, got %s", c, string(b))
+		}
+	}
+	spans := received["resourceSpans"].([]interface{})[0].(map[string]interface{})["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})
+	if len(spans) != 4 {
+		t.Errorf("the command and call spans expected, got %d spans", len(spans))
+	}
+}
+
+func TestFinish(t *testing.T) {
+	defer func() { Enabled, Endpoint = false, "" }()
+	Start("")
+	out := &bytes.Buffer{}
+	Finish(out, nil)
+	if out.Len() != 0 {
+		t.Errorf("nothing must be printed when the tracing is disabled, got %s", out.String())
+	}
+
+	Enabled, Endpoint = true, "http://127.0.0.1:0/v1/traces"
+	Start("")
+	Finish(out, nil)
+	if !strings.Contains(out.String(), ": cm took") || !strings.Contains(out.String(), "Warning: unable to send the trace") {
+		t.Errorf("the summary and the send warning expected, got %s", out.String())
+	}
+}
+
+func TestTrace_maxCalls(t *testing.T) {
+	trace := NewTrace("serve")
+	for i := 0; i < maxCalls+2; i++ {
+		trace.record(Span{Name: "get clusters"})
+	}
+	if len(trace.Calls()) != maxCalls || trace.Dropped() != 2 {
+		t.Errorf("%d calls recorded and 2 dropped expected, got %d and %d", maxCalls, len(trace.Calls()), trace.Dropped())
+	}
+	out := &bytes.Buffer{}
+	if err := trace.PrintSummary(out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), fmt.Sprintf("2 calls after the first %d were not recorded", maxCalls)) {
+		t.Errorf("the summary must report the dropped calls, got %s", out.String())
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package troubleshoot
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Severity is the priority of a finding
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityCritical:
+		return "critical"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// MarshalJSON writes the severity as its name
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Finding is a problem found by a check with the suggested remediation
+type Finding struct {
+	Severity    Severity `json:"severity"`
+	Check       string   `json:"check"`
+	Message     string   `json:"message"`
+	Remediation string   `json:"remediation,omitempty"`
+}
+
+// Check inspects one aspect of a cluster
+type Check interface {
+	// Name returns a short human readable description of the check
+	Name() string
+	// Run executes the check and returns its findings, an error means the check could not be executed
+	Run() ([]Finding, error)
+}
+
+type checkFunc struct {
+	name string
+	run  func() ([]Finding, error)
+}
+
+func (c *checkFunc) Name() string {
+	return c.name
+}
+
+func (c *checkFunc) Run() ([]Finding, error) {
+	return c.run()
+}
+
+// NewCheck creates a Check from a function
+func NewCheck(name string, run func() ([]Finding, error)) Check {
+	return &checkFunc{
+		name: name,
+		run:  run,
+	}
+}
+
+// Run executes all checks and returns their findings, the most severe first.
+// A check which can not be executed is reported as a warning so the other findings are still reported.
+func Run(checks []Check) []Finding {
+	findings := make([]Finding, 0)
+	for _, c := range checks {
+		fs, err := c.Run()
+		if err != nil {
+			fs = append(fs, Finding{
+				Severity:    SeverityWarning,
+				Message:     fmt.Sprintf("the check could not be executed: %s", err.Error()),
+				Remediation: "verify the access to the hub and the managed cluster",
+			})
+		}
+		for _, f := range fs {
+			f.Check = c.Name()
+			findings = append(findings, f)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity > findings[j].Severity
+	})
+	return findings
+}
@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package troubleshoot
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	checks := []Check{
+		NewCheck("first", func() ([]Finding, error) {
+			return []Finding{{Severity: SeverityInfo, Message: "info"}}, nil
+		}),
+		NewCheck("second", func() ([]Finding, error) {
+			return nil, fmt.Errorf("forbidden")
+		}),
+		NewCheck("third", func() ([]Finding, error) {
+			return []Finding{{Severity: SeverityCritical, Message: "critical"}}, nil
+		}),
+		NewCheck("fourth", func() ([]Finding, error) {
+			return nil, nil
+		}),
+	}
+	findings := Run(checks)
+	wantChecks := []string{"third", "second", "first"}
+	if len(findings) != len(wantChecks) {
+		t.Fatalf("expected %d findings, got %v", len(wantChecks), findings)
+	}
+	for i, f := range findings {
+		if f.Check != wantChecks[i] {
+			t.Errorf("finding %d: expected check %s, got %s", i, wantChecks[i], f.Check)
+		}
+	}
+	if findings[1].Severity != SeverityWarning {
+		t.Errorf("a failed check must be reported as a warning, got %s", findings[1].Severity)
+	}
+}
+
+func TestFinding_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(Finding{Severity: SeverityCritical, Check: "Lease", Message: "expired"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"severity":"critical","check":"Lease","message":"expired"}`
+	if string(b) != want {
+		t.Errorf("got %s, want %s", string(b), want)
+	}
+}